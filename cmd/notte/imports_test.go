@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bufio"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestImportPathsMatchModule fails if any source file imports a package of
+// this project through a path other than the one declared in go.mod (e.g. a
+// leftover import from a fork). Such imports break vendoring and `go install`.
+func TestImportPathsMatchModule(t *testing.T) {
+	root := filepath.Join("..", "..")
+	module := readModulePath(t, filepath.Join(root, "go.mod"))
+
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		for _, imp := range f.Imports {
+			p, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				return err
+			}
+			if strings.Contains(p, "/notte-cli") && p != module && !strings.HasPrefix(p, module+"/") {
+				t.Errorf("%s imports %q; use the module path %q", path, p, module)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walking source tree: %v", err)
+	}
+}
+
+func readModulePath(t *testing.T, goMod string) string {
+	t.Helper()
+
+	f, err := os.Open(goMod)
+	if err != nil {
+		t.Fatalf("open go.mod: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.TrimSpace(rest)
+		}
+	}
+	t.Fatal("no module directive in go.mod")
+	return ""
+}
@@ -5,9 +5,15 @@ import (
 )
 
 // Set via ldflags
-var version = "dev"
+var (
+	version = "dev"
+	commit  = ""
+	date    = ""
+)
 
 func main() {
 	cmd.Version = version
+	cmd.Commit = commit
+	cmd.BuildDate = date
 	cmd.Execute()
 }
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ResponseCapture keeps the body of the last API response exactly as it was
+// received, for passthrough output
+type ResponseCapture struct {
+	mu   sync.Mutex
+	body []byte
+	ok   bool
+}
+
+// NewResponseCapture creates an empty capture
+func NewResponseCapture() *ResponseCapture {
+	return &ResponseCapture{}
+}
+
+// Last returns the body of the last response and whether there was one
+func (c *ResponseCapture) Last() ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.body, c.ok
+}
+
+func (c *ResponseCapture) set(body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.body = body
+	c.ok = true
+}
+
+// WithResponseCapture stores the body of every final response (after
+// retries) in c
+func WithResponseCapture(c *ResponseCapture) NotteClientOption {
+	return func(nc *NotteClient) {
+		nc.capture = c
+	}
+}
+
+// captureTransport buffers response bodies into a ResponseCapture and hands
+// the caller an identical copy
+type captureTransport struct {
+	base    http.RoundTripper
+	capture *ResponseCapture
+}
+
+func (t *captureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	t.capture.set(body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
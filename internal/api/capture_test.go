@@ -0,0 +1,53 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCapture_KeepsFinalBody(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"detail":"busy"}`))
+			return
+		}
+		_, _ = w.Write([]byte("{\"b\": 2,  \"a\": 1}\n"))
+	}))
+	defer server.Close()
+
+	capture := NewResponseCapture()
+	if _, ok := capture.Last(); ok {
+		t.Fatal("new capture should be empty")
+	}
+
+	fastRetry := &RetryConfig{MaxRetries: 1, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	client, err := NewClientWithURL("test-key", server.URL, "", WithRetryConfig(fastRetry), WithResponseCapture(capture))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.HTTPClient().Get(server.URL + "/sessions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "{\"b\": 2,  \"a\": 1}\n"
+	if string(body) != want {
+		t.Errorf("caller body = %q, want %q", body, want)
+	}
+	got, ok := capture.Last()
+	if !ok || string(got) != want {
+		t.Errorf("captured = %q, %v; want the retried response byte for byte", got, ok)
+	}
+}
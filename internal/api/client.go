@@ -2,25 +2,36 @@ package api
 
 import (
 	"context"
-	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
 	notteErrors "github.com/nottelabs/notte-cli/internal/errors"
+	"github.com/nottelabs/notte-cli/internal/timing"
 )
 
 const DefaultBaseURL = "https://api.notte.cc"
 
 // NotteClient wraps the generated client with auth and resilience
 type NotteClient struct {
-	client         *ClientWithResponses
-	httpClient     *http.Client
-	baseURL        string
-	apiKey         string
-	requestOrigin  string
-	retryConfig    *RetryConfig
-	circuitBreaker *CircuitBreaker
+	client           *ClientWithResponses
+	httpClient       *http.Client
+	baseURL          string
+	apiKey           string
+	requestOrigin    string
+	retryConfig      *RetryConfig
+	circuitBreaker   *CircuitBreaker
+	offline          bool
+	mockDir          string
+	mockMode         MockMode
+	baseTransport    http.RoundTripper
+	timings          *timing.Recorder
+	tlsOptions       TLSOptions
+	transportOptions TransportOptions
+	capture          *ResponseCapture
+	curlOutput       io.Writer
 }
 
 // NotteClientOption configures the NotteClient
@@ -47,6 +58,30 @@ func WithRequestOrigin(origin string) NotteClientOption {
 	}
 }
 
+// WithOffline forces offline mode: every request fails immediately with an
+// OfflineError without touching the network
+func WithOffline(offline bool) NotteClientOption {
+	return func(c *NotteClient) {
+		c.offline = offline
+	}
+}
+
+// WithBaseTransport replaces the underlying network transport, e.g. to route
+// requests through the local daemon. Auth, retries and the circuit breaker
+// still apply on top of it.
+func WithBaseTransport(rt http.RoundTripper) NotteClientOption {
+	return func(c *NotteClient) {
+		c.baseTransport = rt
+	}
+}
+
+// WithTimings records request and retry timings into rec
+func WithTimings(rec *timing.Recorder) NotteClientOption {
+	return func(c *NotteClient) {
+		c.timings = rec
+	}
+}
+
 // NewClient creates a new Notte API client
 func NewClient(apiKey string, opts ...NotteClientOption) (*NotteClient, error) {
 	return NewClientWithURL(apiKey, DefaultBaseURL, "", opts...)
@@ -71,24 +106,39 @@ func NewClientWithURL(apiKey, baseURL, version string, opts ...NotteClientOption
 		opt(nc)
 	}
 
-	// Create HTTP client with TLS 1.2+ and connection pooling
+	// Create HTTP transport with TLS 1.2+ and connection pooling
+	var base http.RoundTripper = nc.baseTransport
+	if base == nil {
+		transport, err := NewTransport(nc.tlsOptions, nc.transportOptions)
+		if err != nil {
+			return nil, err
+		}
+		base = transport
+	}
+	if nc.mockDir != "" {
+		base = newRecordingTransport(nc.mockDir, nc.mockMode, base)
+	}
+
+	var transport http.RoundTripper = &resilientTransport{
+		apiKey:         apiKey,
+		version:        version,
+		requestOrigin:  nc.requestOrigin,
+		retryConfig:    nc.retryConfig,
+		circuitBreaker: nc.circuitBreaker,
+		offline:        nc.offline,
+		timings:        nc.timings,
+		base:           base,
+	}
+	if nc.capture != nil {
+		transport = &captureTransport{base: transport, capture: nc.capture}
+	}
+	if nc.curlOutput != nil {
+		transport = &curlTransport{base: transport, out: nc.curlOutput}
+	}
+
 	nc.httpClient = &http.Client{
-		Timeout: 45 * time.Second,
-		Transport: &resilientTransport{
-			apiKey:         apiKey,
-			version:        version,
-			requestOrigin:  nc.requestOrigin,
-			retryConfig:    nc.retryConfig,
-			circuitBreaker: nc.circuitBreaker,
-			base: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					MinVersion: tls.VersionTLS12,
-				},
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 10,
-				IdleConnTimeout:     90 * time.Second,
-			},
-		},
+		Timeout:   nc.transportOptions.withDefaults().RequestTimeout,
+		Transport: transport,
 	}
 
 	client, err := NewClientWithResponses(baseURL, WithHTTPClient(nc.httpClient))
@@ -107,10 +157,17 @@ type resilientTransport struct {
 	requestOrigin  string
 	retryConfig    *RetryConfig
 	circuitBreaker *CircuitBreaker
+	offline        bool
+	timings        *timing.Recorder
 	base           http.RoundTripper
 }
 
 func (t *resilientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Fail fast when offline mode is forced
+	if t.offline {
+		return nil, &notteErrors.OfflineError{Host: req.URL.Host}
+	}
+
 	// Check circuit breaker
 	if !t.circuitBreaker.Allow() {
 		return nil, &notteErrors.CircuitBreakerError{
@@ -129,9 +186,16 @@ func (t *resilientTransport) RoundTrip(req *http.Request) (*http.Response, error
 	AddIdempotencyKey(req)
 
 	// Execute with retry
+	start := time.Now()
 	resp, err := t.doWithRetry(req)
+	t.timings.AddCall(timing.Call{Method: req.Method, Path: req.URL.Path, Duration: time.Since(start)})
 	if err != nil {
-		t.circuitBreaker.RecordFailure()
+		// A missing network says nothing about the API's health, so don't
+		// let it trip the circuit breaker
+		var offlineErr *notteErrors.OfflineError
+		if !errors.As(err, &offlineErr) {
+			t.circuitBreaker.RecordFailure()
+		}
 		return nil, err
 	}
 
@@ -153,21 +217,31 @@ func (t *resilientTransport) doWithRetry(req *http.Request) (*http.Response, err
 		// Clone request for each attempt
 		reqCopy := cloneRequest(req)
 
+		stop := t.timings.Start(timing.PhaseRequest)
 		resp, err = t.base.RoundTrip(reqCopy)
+		stop()
 		if err != nil {
-			// Network error - retry for idempotent methods
-			if !isIdempotent(req.Method) {
+			// No network at all - retrying won't help
+			if isOfflineError(err) {
+				return nil, &notteErrors.OfflineError{Host: req.URL.Host, Cause: err}
+			}
+			// Cancelled or timed out by the caller - retrying can't succeed
+			if req.Context().Err() != nil {
+				return nil, err
+			}
+			// Network error - retry for idempotent methods and keyed POSTs
+			if !t.retryConfig.canRetry(req) {
 				return nil, err
 			}
 			if attempt < t.retryConfig.MaxRetries {
-				time.Sleep(t.retryConfig.Backoff(attempt))
+				t.backoff(attempt)
 				continue
 			}
 			return nil, err
 		}
 
 		// Check if we should retry based on status
-		if !t.retryConfig.ShouldRetry(resp.StatusCode, req.Method, attempt) {
+		if !t.retryConfig.ShouldRetryRequest(resp.StatusCode, req, attempt) {
 			return resp, nil
 		}
 
@@ -176,13 +250,18 @@ func (t *resilientTransport) doWithRetry(req *http.Request) (*http.Response, err
 
 		// Sleep before retry
 		if attempt < t.retryConfig.MaxRetries {
-			time.Sleep(t.retryConfig.Backoff(attempt))
+			t.backoff(attempt)
 		}
 	}
 
 	return resp, err
 }
 
+func (t *resilientTransport) backoff(attempt int) {
+	defer t.timings.Start(timing.PhaseRetry)()
+	time.Sleep(t.retryConfig.Backoff(attempt))
+}
+
 // cloneRequest creates a shallow copy of the request
 func cloneRequest(req *http.Request) *http.Request {
 	reqCopy := req.Clone(req.Context())
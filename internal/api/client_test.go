@@ -4,11 +4,17 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
+
+	notteErrors "github.com/nottelabs/notte-cli/internal/errors"
+	"github.com/nottelabs/notte-cli/internal/timing"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
 )
 
 type transportFunc func(*http.Request) (*http.Response, error)
@@ -345,6 +351,29 @@ func TestResilientTransport_DoWithRetry_RetriesOnNetworkError(t *testing.T) {
 	}
 }
 
+func TestResilientTransport_DoWithRetry_CancelledContext(t *testing.T) {
+	callCount := 0
+	rt := &resilientTransport{
+		retryConfig:    &RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Jitter: false},
+		circuitBreaker: NewCircuitBreaker(5, time.Minute),
+		base: transportFunc(func(req *http.Request) (*http.Response, error) {
+			callCount++
+			return nil, req.Context().Err()
+		}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil).WithContext(ctx)
+	if _, err := rt.doWithRetry(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if callCount != 1 {
+		t.Errorf("expected 1 call, got %d", callCount)
+	}
+}
+
 func TestResilientTransport_DoWithRetry_NonIdempotentError(t *testing.T) {
 	callCount := 0
 	rt := &resilientTransport{
@@ -366,6 +395,149 @@ func TestResilientTransport_DoWithRetry_NonIdempotentError(t *testing.T) {
 	}
 }
 
+func TestResilientTransport_RoundTrip_RetriesKeyedPOST(t *testing.T) {
+	var keys []string
+	rt := &resilientTransport{
+		retryConfig:    &RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, RetryKeyedPOST: true},
+		circuitBreaker: NewCircuitBreaker(5, time.Minute),
+		base: transportFunc(func(req *http.Request) (*http.Response, error) {
+			keys = append(keys, req.Header.Get(IdempotencyKeyHeader))
+			if len(keys) == 1 {
+				return nil, errors.New("network error")
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("{}")),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		}),
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "http://example.com/sessions/start", strings.NewReader("{}"))
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("expected the retry to reuse the idempotency key, got %q", keys)
+	}
+}
+
+func TestResilientTransport_RoundTrip_ForcedOffline(t *testing.T) {
+	called := false
+	rt := &resilientTransport{
+		retryConfig:    DefaultRetryConfig(),
+		circuitBreaker: NewCircuitBreaker(5, time.Minute),
+		offline:        true,
+		base: transportFunc(func(req *http.Request) (*http.Response, error) {
+			called = true
+			return nil, errors.New("should not be called")
+		}),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	_, err := rt.RoundTrip(req)
+
+	var offlineErr *notteErrors.OfflineError
+	if !errors.As(err, &offlineErr) {
+		t.Fatalf("expected OfflineError, got %v", err)
+	}
+	if called {
+		t.Error("expected no network call in offline mode")
+	}
+}
+
+func TestResilientTransport_RoundTrip_OfflineFailsFast(t *testing.T) {
+	callCount := 0
+	cb := NewCircuitBreaker(1, time.Hour)
+	rt := &resilientTransport{
+		retryConfig:    &RetryConfig{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Jitter: false},
+		circuitBreaker: cb,
+		base: transportFunc(func(req *http.Request) (*http.Response, error) {
+			callCount++
+			return nil, &net.DNSError{Err: "no such host", Name: "example.com", IsNotFound: true}
+		}),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	_, err := rt.RoundTrip(req)
+
+	var offlineErr *notteErrors.OfflineError
+	if !errors.As(err, &offlineErr) {
+		t.Fatalf("expected OfflineError, got %v", err)
+	}
+	if callCount != 1 {
+		t.Errorf("expected 1 call, got %d", callCount)
+	}
+	if !cb.Allow() {
+		t.Error("expected offline errors not to trip the circuit breaker")
+	}
+}
+
+func TestIsOfflineError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain", errors.New("boom"), false},
+		{"dns not found", &net.DNSError{Err: "no such host", IsNotFound: true}, true},
+		{"dns timeout", &net.DNSError{Err: "timeout", IsTimeout: true}, false},
+		{"net unreachable", &net.OpError{Op: "dial", Err: syscall.ENETUNREACH}, true},
+		{"host unreachable", &net.OpError{Op: "dial", Err: syscall.EHOSTUNREACH}, true},
+		{"connection refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isOfflineError(tt.err); got != tt.want {
+				t.Errorf("isOfflineError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotteClient_RetriesAgainstMockServer(t *testing.T) {
+	server := mockserver.New()
+	defer server.Close()
+
+	server.AddSequence("/health",
+		mockserver.JSONResponse(http.StatusServiceUnavailable, `{}`),
+		mockserver.JSONResponse(http.StatusOK, `{"status":"ok"}`),
+	)
+	server.AddSequence("/sessions/start",
+		mockserver.RateLimitResponse(time.Second),
+		mockserver.JSONResponse(http.StatusOK, `{}`),
+	)
+
+	client, err := NewClientWithURL("test-key", server.URL(), "test",
+		WithRetryConfig(&RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := client.HTTPClient().Get(server.URL() + "/health")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || len(server.Requests("/health")) != 2 {
+		t.Errorf("expected 5xx to be retried once, got status %d after %d requests", resp.StatusCode, len(server.Requests("/health")))
+	}
+
+	resp, err = client.HTTPClient().Post(server.URL()+"/sessions/start", "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || len(server.Requests("/sessions/start")) != 1 {
+		t.Errorf("expected 429 not to be retried, got status %d after %d requests", resp.StatusCode, len(server.Requests("/sessions/start")))
+	}
+}
+
 func TestNotteClient_Client(t *testing.T) {
 	client, err := NewClient("test-key")
 	if err != nil {
@@ -404,3 +576,40 @@ func TestDefaultContext(t *testing.T) {
 		t.Error("DefaultContext() should return context.Background()")
 	}
 }
+
+func TestResilientTransport_RecordsTimings(t *testing.T) {
+	rec := timing.New()
+	attempts := 0
+	rt := &resilientTransport{
+		apiKey:         "test-key",
+		retryConfig:    &RetryConfig{MaxRetries: 1, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+		circuitBreaker: NewCircuitBreaker(5, time.Minute),
+		timings:        rec,
+		base: transportFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			status := http.StatusOK
+			if attempts == 1 {
+				status = http.StatusBadGateway
+			}
+			return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+		}),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/sessions", nil)
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got := rec.Total(timing.PhaseRequest).Count; got != 2 {
+		t.Errorf("request count = %d, want 2", got)
+	}
+	if got := rec.Total(timing.PhaseRetry).Count; got != 1 {
+		t.Errorf("retry count = %d, want 1", got)
+	}
+	calls := rec.Calls()
+	if len(calls) != 1 || calls[0].Method != http.MethodGet || calls[0].Path != "/sessions" {
+		t.Errorf("calls = %+v", calls)
+	}
+}
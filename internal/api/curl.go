@@ -0,0 +1,237 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// CurlAuthPlaceholder stands in for the API key in exported curl commands,
+// so they can be shared without leaking credentials
+const CurlAuthPlaceholder = "Bearer $NOTTE_API_KEY"
+
+// curlSkipHeaders are set per request by the client or by curl itself and
+// would only make a shared command misleading
+var curlSkipHeaders = []string{"Authorization", "Content-Length", "Idempotency-Key", "User-Agent", "Accept-Encoding"}
+
+// WithCurlOutput writes every API request to w as an equivalent curl
+// command before it is sent, with the API key replaced by
+// CurlAuthPlaceholder
+func WithCurlOutput(w io.Writer) NotteClientOption {
+	return func(nc *NotteClient) {
+		nc.curlOutput = w
+	}
+}
+
+// curlTransport prints each request as a curl command, once per call
+// rather than once per retry
+type curlTransport struct {
+	base http.RoundTripper
+	out  io.Writer
+}
+
+func (t *curlTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cmd, err := CurlCommand(req)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = fmt.Fprintln(t.out, cmd)
+	return t.base.RoundTrip(req)
+}
+
+// curlSecretHeaders are headers whose values are credentials when they turn
+// up inside a body, as in a session's extra_http_headers
+var curlSecretHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+}
+
+// CurlCommand renders req as a shell-quoted curl command line. The body is
+// read and put back, so req can still be sent afterwards. Secrets in the
+// body are redacted the way recorded requests are, and so are credentials
+// in URLs and headers the body carries.
+func CurlCommand(req *http.Request) (string, error) {
+	parts := []string{"curl"}
+	if req.Method != http.MethodGet {
+		parts = append(parts, "-X", req.Method)
+	}
+	parts = append(parts, shellQuote(stripUserinfo(req.URL.String())))
+	parts = append(parts, "-H", `"Authorization: `+CurlAuthPlaceholder+`"`)
+
+	body, err := peekBody(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	var form []string
+	mediaType, params, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if len(body) > 0 && mediaType == "multipart/form-data" {
+		form, err = curlFormFields(body, params["boundary"])
+		if err != nil {
+			return "", err
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(req.Header)) {
+		if slices.Contains(curlSkipHeaders, name) || (form != nil && name == "Content-Type") {
+			continue
+		}
+		for _, v := range req.Header[name] {
+			parts = append(parts, "-H", shellQuote(name+": "+v))
+		}
+	}
+
+	switch {
+	case form != nil:
+		parts = append(parts, form...)
+	case len(body) == 0:
+	case utf8.Valid(body):
+		parts = append(parts, "--data-raw", shellQuote(string(redactCurlBody(req.URL.Path, body))))
+	default:
+		// Binary bodies don't survive a terminal; point at a file instead
+		parts = append(parts, "--data-binary", "@request.bin")
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// peekBody reads the request body and replaces it with an identical copy
+func peekBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = rc.Close() }()
+		return io.ReadAll(rc)
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// curlFormFields turns a multipart body into -F arguments. File contents
+// are replaced by a reference to the uploaded file name.
+func curlFormFields(body []byte, boundary string) ([]string, error) {
+	mr := multipart.NewReader(bytes.NewReader(body), boundary)
+	var fields []string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return fields, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read multipart body: %w", err)
+		}
+		if name := part.FileName(); name != "" {
+			fields = append(fields, "-F", shellQuote(part.FormName()+"=@"+name))
+			continue
+		}
+		value, err := io.ReadAll(part)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read multipart body: %w", err)
+		}
+		name := part.FormName()
+		text := stripUserinfo(string(value))
+		if secretFields[strings.ToLower(name)] && text != "" {
+			text = redactedValue
+		}
+		fields = append(fields, "-F", shellQuote(name+"="+text))
+	}
+}
+
+// redactCurlBody returns body, from a request to path, with secretFields
+// redacted, credentials in header maps redacted and userinfo stripped from
+// URLs. Bodies that aren't JSON are returned unchanged.
+func redactCurlBody(path string, body []byte) []byte {
+	body = redactJSON(path, body)
+	if jsonOrNil(body) == nil {
+		return body
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+	if !redactCredentials(v) {
+		return body
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+// redactCredentials redacts v in place and reports whether anything was
+func redactCredentials(v any) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				changed = redactCredentials(item) || changed
+				continue
+			}
+			switch {
+			case s == "" || s == redactedValue:
+			case curlSecretHeaders[strings.ToLower(k)]:
+				v[k] = redactedValue
+				changed = true
+			case stripUserinfo(s) != s:
+				v[k] = stripUserinfo(s)
+				changed = true
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if s, ok := item.(string); ok && stripUserinfo(s) != s {
+				v[i] = stripUserinfo(s)
+				changed = true
+				continue
+			}
+			changed = redactCredentials(item) || changed
+		}
+	}
+	return changed
+}
+
+// stripUserinfo drops the user and password from s when it's a URL that
+// carries them, and returns anything else unchanged
+func stripUserinfo(s string) string {
+	if !strings.Contains(s, "@") || !strings.Contains(s, "://") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	u.User = nil
+	return u.String()
+}
+
+// shellQuote wraps s in single quotes for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// isOfflineError reports whether err means the network itself is unavailable
+// (DNS resolution failed or the host/network is unreachable), as opposed to
+// the API being up but misbehaving. Such errors are not worth retrying.
+func isOfflineError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsTimeout
+	}
+
+	return errors.Is(err, syscall.ENETUNREACH) ||
+		errors.Is(err, syscall.EHOSTUNREACH) ||
+		errors.Is(err, syscall.ENETDOWN)
+}
@@ -0,0 +1,298 @@
+// internal/api/recorder.go
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// MockMode selects how the recording transport behaves
+type MockMode string
+
+const (
+	MockReplay MockMode = "replay" // Serve responses from recordings, never touch the network
+	MockRecord MockMode = "record" // Forward requests and save every interaction to disk
+)
+
+// Recording is a single captured request/response pair stored on disk
+type Recording struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the request half of a Recording.
+// Headers are intentionally not stored so the API key never ends up on disk,
+// and secrets in bodies are redacted (see redactJSON).
+type RecordedRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  string          `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse is the response half of a Recording
+type RecordedResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       json.RawMessage   `json:"body,omitempty"`
+	RawBody    []byte            `json:"raw_body,omitempty"` // Used when the body is not JSON
+}
+
+// WithMock routes requests through a VCR-style recorder backed by dir
+func WithMock(dir string, mode MockMode) NotteClientOption {
+	return func(c *NotteClient) {
+		c.mockDir = dir
+		c.mockMode = mode
+	}
+}
+
+// recordingTransport records interactions to disk or replays them.
+// Repeated identical requests (e.g. status polling) are stored as a sequence
+// and replayed in order; the last recording is reused once exhausted.
+type recordingTransport struct {
+	dir  string
+	mode MockMode
+	base http.RoundTripper
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func newRecordingTransport(dir string, mode MockMode, base http.RoundTripper) *recordingTransport {
+	return &recordingTransport{
+		dir:   dir,
+		mode:  mode,
+		base:  base,
+		calls: make(map[string]int),
+	}
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	// Keyed on the redacted body, so file names don't depend on secrets
+	stored := redactJSON(req.URL.Path, body)
+	key := recordingKey(req, stored)
+	t.mu.Lock()
+	n := t.calls[key]
+	t.calls[key]++
+	t.mu.Unlock()
+
+	if t.mode == MockRecord {
+		return t.record(req, stored, key, n)
+	}
+	return t.replay(req, key, n)
+}
+
+func (t *recordingTransport) record(req *http.Request, body []byte, key string, n int) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	rec := Recording{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  req.URL.RawQuery,
+			Body:   jsonOrNil(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    map[string]string{},
+		},
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		rec.Response.Headers["Content-Type"] = ct
+	}
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		rec.Response.Headers["Retry-After"] = ra
+	}
+	if raw := jsonOrNil(redactJSON(req.URL.Path, respBody)); raw != nil {
+		rec.Response.Body = raw
+	} else {
+		rec.Response.RawBody = respBody
+	}
+
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode recording: %w", err)
+	}
+	if err := os.MkdirAll(t.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create recordings directory: %w", err)
+	}
+	if err := os.WriteFile(t.path(key, n), data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write recording: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (t *recordingTransport) replay(req *http.Request, key string, n int) (*http.Response, error) {
+	data, err := os.ReadFile(t.path(key, n))
+	// Fall back to the most recent recording in the sequence
+	for err != nil && os.IsNotExist(err) && n > 0 {
+		n--
+		data, err = os.ReadFile(t.path(key, n))
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no recording for %s %s in %s (record it with NOTTE_MOCK_MODE=record)", req.Method, req.URL.Path, t.dir)
+		}
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	var rec Recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("failed to parse recording %s: %w", t.path(key, n), err)
+	}
+
+	body := []byte(rec.Response.Body)
+	if len(body) == 0 {
+		body = rec.Response.RawBody
+	}
+
+	header := http.Header{}
+	for k, v := range rec.Response.Headers {
+		header.Set(k, v)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Response.StatusCode, http.StatusText(rec.Response.StatusCode)),
+		StatusCode:    rec.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// recordingKey derives a stable, human-readable file key for a request
+func recordingKey(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(req.URL.Path))
+	h.Write([]byte{0})
+	h.Write([]byte(req.URL.RawQuery))
+	h.Write([]byte{0})
+	h.Write(body)
+	sum := hex.EncodeToString(h.Sum(nil))[:12]
+
+	name := strings.Trim(unsafePathChars.ReplaceAllString(req.URL.Path, "_"), "_")
+	if name == "" {
+		name = "root"
+	}
+	return fmt.Sprintf("%s_%s_%s", strings.ToLower(req.Method), name, sum)
+}
+
+func (t *recordingTransport) path(key string, n int) string {
+	return filepath.Join(t.dir, fmt.Sprintf("%s-%03d.json", key, n))
+}
+
+// jsonOrNil returns data as raw JSON if it is valid JSON, nil otherwise
+func jsonOrNil(data []byte) json.RawMessage {
+	if len(data) == 0 || !json.Valid(data) {
+		return nil
+	}
+	return json.RawMessage(data)
+}
+
+// redactedValue replaces secrets in recorded bodies
+const redactedValue = "[REDACTED]"
+
+// secretFields are the body fields that hold secrets: vault credentials and
+// cards, and keys and tokens
+var secretFields = map[string]bool{
+	"password":             true,
+	"mfa_secret":           true,
+	"card_number":          true,
+	"card_cvv":             true,
+	"card_full_expiration": true,
+	"card_holder_name":     true,
+	"oauth_client_secret":  true,
+	"decryption_key":       true,
+	"api_key":              true,
+	"secret":               true,
+	"token":                true,
+	"access_token":         true,
+	"refresh_token":        true,
+}
+
+// redactJSON returns body, from a request or response of path, with the
+// string values of secretFields replaced. Under /secrets and cookie
+// endpoints "value" holds a secret too. Bodies that aren't JSON, or hold no
+// secrets, are returned unchanged.
+func redactJSON(path string, body []byte) []byte {
+	if jsonOrNil(body) == nil {
+		return body
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+	values := strings.Contains(path, "/secrets") || strings.Contains(path, "cookies")
+	if !redactSecrets(v, values) {
+		return body
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// redactSecrets redacts v in place and reports whether anything was
+func redactSecrets(v any, values bool) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			name := strings.ToLower(k)
+			if s, ok := item.(string); ok && s != "" && (secretFields[name] || values && name == "value") {
+				v[k] = redactedValue
+				changed = true
+				continue
+			}
+			changed = redactSecrets(item, values) || changed
+		}
+	case []any:
+		for _, item := range v {
+			changed = redactSecrets(item, values) || changed
+		}
+	}
+	return changed
+}
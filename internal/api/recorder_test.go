@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRecordingTransport_RecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		if r.Header.Get("Authorization") != "Bearer secret-key" {
+			t.Errorf("expected auth header to reach the server")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if n == 1 {
+			_, _ = w.Write([]byte(`{"status":"pending"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"done"}`))
+	}))
+
+	recorder, err := NewClientWithURL("secret-key", server.URL, "test", WithMock(dir, MockRecord))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{"pending", "done"} {
+		if got := getStatus(t, recorder.HTTPClient(), server.URL+"/sessions/abc"); got != want {
+			t.Fatalf("record: got %q, want %q", got, want)
+		}
+	}
+	server.Close()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read recordings: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 recordings, got %d", len(entries))
+	}
+	for _, e := range entries {
+		data, _ := os.ReadFile(dir + "/" + e.Name())
+		if strings.Contains(string(data), "secret-key") {
+			t.Fatal("recordings must not contain credentials")
+		}
+	}
+
+	replayer, err := NewClientWithURL("other-key", server.URL, "test", WithMock(dir, MockReplay))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Sequence is replayed in order, then the last response sticks
+	for _, want := range []string{"pending", "done", "done"} {
+		if got := getStatus(t, replayer.HTTPClient(), server.URL+"/sessions/abc"); got != want {
+			t.Fatalf("replay: got %q, want %q", got, want)
+		}
+	}
+	if hits.Load() != 2 {
+		t.Errorf("expected replay to skip the network, got %d server hits", hits.Load())
+	}
+}
+
+func TestRecordingTransport_ReplayMissing(t *testing.T) {
+	client, err := NewClientWithURL("key", "http://example.invalid", "test",
+		WithMock(t.TempDir(), MockReplay),
+		WithRetryConfig(&RetryConfig{MaxRetries: 0}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.invalid/health", nil)
+	_, err = client.HTTPClient().Do(req)
+	if err == nil || !strings.Contains(err.Error(), "no recording for GET /health") {
+		t.Fatalf("expected missing recording error, got %v", err)
+	}
+}
+
+func TestRecordingTransport_RedactsSecrets(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(r.URL.Path, "/secrets/") {
+			_, _ = w.Write([]byte(`{"value":"s3cr3t-value"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL("key", server.URL, "test", WithMock(dir, MockRecord))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	username := "alice"
+	resp, err := client.Client().VaultCredentialsAdd(context.Background(), "vault_1", &VaultCredentialsAddParams{}, AddCredentialsRequest{
+		Url:         "https://example.com",
+		Credentials: CredentialsDictInput{Password: "hunter2-password", Username: &username},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if got := getStatus(t, client.HTTPClient(), server.URL+"/secrets/sec_1"); got != "" {
+		t.Fatalf("unexpected status %q", got)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected 2 recordings, got %d, %v", len(entries), err)
+	}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		data, _ := os.ReadFile(path)
+		for _, secret := range []string{"hunter2-password", "s3cr3t-value"} {
+			if strings.Contains(e.Name()+string(data), secret) {
+				t.Errorf("%s contains %s:\n%s", e.Name(), secret, data)
+			}
+		}
+		if strings.HasPrefix(e.Name(), "post_") && (!strings.Contains(string(data), redactedValue) || !strings.Contains(string(data), "alice")) {
+			t.Errorf("expected only the password to be redacted:\n%s", data)
+		}
+		if info, err := os.Stat(path); err == nil && runtime.GOOS != "windows" && info.Mode().Perm() != 0o600 {
+			t.Errorf("%s has mode %v, want 0600", e.Name(), info.Mode().Perm())
+		}
+	}
+}
+
+func TestRecordingKey_DependsOnBody(t *testing.T) {
+	a := httptest.NewRequest(http.MethodPost, "http://x/sessions/start", nil)
+	b := httptest.NewRequest(http.MethodPost, "http://x/sessions/start", nil)
+
+	if recordingKey(a, []byte(`{"a":1}`)) == recordingKey(b, []byte(`{"a":2}`)) {
+		t.Error("expected different keys for different bodies")
+	}
+	if !strings.HasPrefix(recordingKey(a, nil), "post_sessions_start_") {
+		t.Errorf("unexpected key %q", recordingKey(a, nil))
+	}
+}
+
+func getStatus(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var out struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return out.Status
+}
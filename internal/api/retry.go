@@ -15,6 +15,7 @@ type RetryConfig struct {
 	InitialBackoff time.Duration // Initial backoff duration
 	MaxBackoff     time.Duration // Maximum backoff duration
 	Jitter         bool          // Add random jitter to backoff
+	RetryKeyedPOST bool          // Also retry POSTs that carry an idempotency key
 }
 
 // DefaultRetryConfig returns sensible defaults
@@ -24,6 +25,7 @@ func DefaultRetryConfig() *RetryConfig {
 		InitialBackoff: time.Second,
 		MaxBackoff:     30 * time.Second,
 		Jitter:         true,
+		RetryKeyedPOST: true,
 	}
 }
 
@@ -47,6 +49,29 @@ func (c *RetryConfig) ShouldRetry(statusCode int, method string, attempt int) bo
 	return false
 }
 
+// ShouldRetryRequest is ShouldRetry for req, which also retries a POST
+// carrying an idempotency key when RetryKeyedPOST is set
+func (c *RetryConfig) ShouldRetryRequest(statusCode int, req *http.Request, attempt int) bool {
+	if c.ShouldRetry(statusCode, req.Method, attempt) {
+		return true
+	}
+	return statusCode >= 500 && statusCode < 600 && attempt < c.MaxRetries && c.isRetryableKeyed(req)
+}
+
+// canRetry reports whether req may be sent again after a failure
+func (c *RetryConfig) canRetry(req *http.Request) bool {
+	return isIdempotent(req.Method) || c.isRetryableKeyed(req)
+}
+
+// isRetryableKeyed reports whether req is a POST the API deduplicates by its
+// idempotency key, and whose body can be sent again
+func (c *RetryConfig) isRetryableKeyed(req *http.Request) bool {
+	if !c.RetryKeyedPOST || req.Method != http.MethodPost || req.Header.Get(IdempotencyKeyHeader) == "" {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
 // Backoff calculates the backoff duration for an attempt
 func (c *RetryConfig) Backoff(attempt int) time.Duration {
 	// Exponential backoff: initial * 2^attempt
@@ -92,12 +117,12 @@ func DoWithRetry(ctx context.Context, client *http.Client, req *http.Request, cf
 		}
 
 		// Clone request for retry (body needs to be re-readable)
-		reqCopy := req.Clone(ctx)
+		reqCopy := cloneRequest(req.WithContext(ctx))
 
 		resp, err = client.Do(reqCopy)
 		if err != nil {
-			// Network error - retry for idempotent methods
-			if !isIdempotent(req.Method) {
+			// Network error - retry for idempotent methods and keyed POSTs
+			if !cfg.canRetry(req) {
 				return nil, err
 			}
 			if attempt < cfg.MaxRetries {
@@ -108,7 +133,7 @@ func DoWithRetry(ctx context.Context, client *http.Client, req *http.Request, cf
 		}
 
 		// Check if we should retry based on status
-		if !cfg.ShouldRetry(resp.StatusCode, req.Method, attempt) {
+		if !cfg.ShouldRetryRequest(resp.StatusCode, req, attempt) {
 			return resp, nil
 		}
 
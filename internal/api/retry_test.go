@@ -285,3 +285,84 @@ func TestDoWithRetry_NonIdempotentNetworkErrorNoRetry(t *testing.T) {
 		t.Errorf("expected 1 call, got %d", callCount)
 	}
 }
+
+func TestRetryConfig_ShouldRetryRequest(t *testing.T) {
+	keyed := func(method string) *http.Request {
+		req, _ := http.NewRequest(method, "http://example.com", strings.NewReader("{}"))
+		req.Header.Set(IdempotencyKeyHeader, "key")
+		return req
+	}
+	unkeyed, _ := http.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("{}"))
+	noGetBody, _ := http.NewRequest(http.MethodPost, "http://example.com", io.NopCloser(strings.NewReader("{}")))
+	noGetBody.Header.Set(IdempotencyKeyHeader, "key")
+
+	tests := []struct {
+		name        string
+		cfg         *RetryConfig
+		statusCode  int
+		req         *http.Request
+		attempt     int
+		shouldRetry bool
+	}{
+		{"500 keyed POST", DefaultRetryConfig(), 500, keyed(http.MethodPost), 0, true},
+		{"503 keyed POST last attempt", DefaultRetryConfig(), 503, keyed(http.MethodPost), 3, false},
+		{"500 unkeyed POST", DefaultRetryConfig(), 500, unkeyed, 0, false},
+		{"500 keyed POST without a rewindable body", DefaultRetryConfig(), 500, noGetBody, 0, false},
+		{"500 keyed POST, disabled", &RetryConfig{MaxRetries: 3}, 500, keyed(http.MethodPost), 0, false},
+		{"500 keyed DELETE", DefaultRetryConfig(), 500, keyed(http.MethodDelete), 0, false},
+		{"400 keyed POST", DefaultRetryConfig(), 400, keyed(http.MethodPost), 0, false},
+		{"429 keyed POST", DefaultRetryConfig(), 429, keyed(http.MethodPost), 0, false},
+		{"500 GET", DefaultRetryConfig(), 500, httptest.NewRequest(http.MethodGet, "http://example.com", nil), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.ShouldRetryRequest(tt.statusCode, tt.req, tt.attempt); got != tt.shouldRetry {
+				t.Errorf("ShouldRetryRequest(%d, %s, %d) = %v, want %v",
+					tt.statusCode, tt.req.Method, tt.attempt, got, tt.shouldRetry)
+			}
+		})
+	}
+}
+
+func TestDoWithRetry_RetriesKeyedPOST(t *testing.T) {
+	var bodies, keys []string
+	client := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+			keys = append(keys, req.Header.Get(IdempotencyKeyHeader))
+			if len(bodies) == 1 {
+				return nil, errors.New("connection reset")
+			}
+			status := http.StatusOK
+			if len(bodies) == 2 {
+				status = http.StatusBadGateway
+			}
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(strings.NewReader("{}")),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		}),
+	}
+
+	cfg := &RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, RetryKeyedPOST: true}
+	req, _ := http.NewRequest(http.MethodPost, "http://example.com/sessions/start", strings.NewReader(`{"headless":true}`))
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+
+	resp, err := DoWithRetry(context.Background(), client, req, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK || len(bodies) != 3 {
+		t.Fatalf("expected success on the third call, got %d after %d calls", resp.StatusCode, len(bodies))
+	}
+	for i := range bodies {
+		if bodies[i] != `{"headless":true}` || keys[i] != "key-1" {
+			t.Errorf("call %d sent body %q with key %q", i+1, bodies[i], keys[i])
+		}
+	}
+}
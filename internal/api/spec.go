@@ -0,0 +1,187 @@
+package api
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// openAPISpec is the OpenAPI document the client is generated from; it is
+// refreshed together with client.gen.go by scripts/generate.sh
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// openAPISpecPin is the SHA-256 of openapi.json recorded by
+// scripts/generate.sh, in sha256sum format
+//
+//go:embed openapi.json.sha256
+var openAPISpecPin string
+
+// SpecSHA256 returns the hex SHA-256 of the bundled OpenAPI document
+func SpecSHA256() string {
+	sum := sha256.Sum256(openAPISpec)
+	return hex.EncodeToString(sum[:])
+}
+
+// PinnedSpecSHA256 returns the SHA-256 recorded for the bundled document when
+// it was generated. It differs from SpecSHA256 when openapi.json was edited
+// by hand instead of regenerated.
+func PinnedSpecSHA256() string {
+	return ParseSHA256Pin(openAPISpecPin)
+}
+
+// ParseSHA256Pin returns the hash of an openapi.json.sha256 file
+func ParseSHA256Pin(pin string) string {
+	hash, _, _ := strings.Cut(strings.TrimSpace(pin), " ")
+	return strings.ToLower(hash)
+}
+
+// Spec is the bundled OpenAPI document, reduced to what describing an
+// operation needs
+type Spec struct {
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Operation is one method on one path
+type Operation struct {
+	// Method and Path are set from the document's paths map
+	Method      string               `json:"method"`
+	Path        string               `json:"path"`
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses,omitempty"`
+}
+
+// Parameter is a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody is an operation's body, by content type
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content,omitempty"`
+}
+
+// Response is an operation's response for one status code
+type Response struct {
+	Description string               `json:"description,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body for one content type
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Schema is the subset of an OpenAPI schema object shown to users
+type Schema struct {
+	Ref         string             `json:"$ref,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Enum        []any              `json:"enum,omitempty"`
+	Default     any                `json:"default,omitempty"`
+	Nullable    bool               `json:"nullable,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	// AdditionalProperties is either a boolean or a schema
+	AdditionalProperties json.RawMessage `json:"additionalProperties,omitempty"`
+	OneOf                []*Schema       `json:"oneOf,omitempty"`
+	AnyOf                []*Schema       `json:"anyOf,omitempty"`
+	AllOf                []*Schema       `json:"allOf,omitempty"`
+}
+
+// ValueSchema returns the schema of an object's additional properties, or
+// nil when they aren't described
+func (s *Schema) ValueSchema() *Schema {
+	if len(s.AdditionalProperties) == 0 {
+		return nil
+	}
+	var v Schema
+	if json.Unmarshal(s.AdditionalProperties, &v) != nil {
+		return nil
+	}
+	return &v
+}
+
+const schemaRefPrefix = "#/components/schemas/"
+
+// RefName returns the component name a $ref points to
+func RefName(ref string) string {
+	return strings.TrimPrefix(ref, schemaRefPrefix)
+}
+
+// Resolve follows a $ref to the component schema. It returns s itself for
+// inline schemas, and nil for a dangling reference.
+func (sp *Spec) Resolve(s *Schema) *Schema {
+	for i := 0; s != nil && s.Ref != "" && i < 32; i++ {
+		s = sp.Components.Schemas[RefName(s.Ref)]
+	}
+	return s
+}
+
+var (
+	specOnce   sync.Once
+	specParsed *Spec
+	specErr    error
+)
+
+// LoadSpec parses the bundled OpenAPI document
+func LoadSpec() (*Spec, error) {
+	specOnce.Do(func() {
+		var sp Spec
+		if err := json.Unmarshal(openAPISpec, &sp); err != nil {
+			specErr = fmt.Errorf("failed to parse bundled OpenAPI spec: %w", err)
+			return
+		}
+		for path, methods := range sp.Paths {
+			for method, op := range methods {
+				if op == nil {
+					continue
+				}
+				op.Method = strings.ToUpper(method)
+				op.Path = path
+			}
+		}
+		specParsed = &sp
+	})
+	return specParsed, specErr
+}
+
+// Operations returns every operation, sorted by path then method
+func (sp *Spec) Operations() []*Operation {
+	var ops []*Operation
+	for _, methods := range sp.Paths {
+		for _, op := range methods {
+			if op != nil {
+				ops = append(ops, op)
+			}
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Path != ops[j].Path {
+			return ops[i].Path < ops[j].Path
+		}
+		return ops[i].Method < ops[j].Method
+	})
+	return ops
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadSpec(t *testing.T) {
+	spec, err := LoadSpec()
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	var execute *Operation
+	for _, op := range spec.Operations() {
+		if op.OperationID == "" {
+			t.Errorf("%s %s has no operationId", op.Method, op.Path)
+		}
+		if op.OperationID == "page_execute" {
+			execute = op
+		}
+	}
+	if execute == nil {
+		t.Fatal("page_execute not found in the bundled spec")
+	}
+	if execute.Method != "POST" || execute.Path != "/sessions/{session_id}/page/execute" {
+		t.Errorf("page_execute = %s %s", execute.Method, execute.Path)
+	}
+	if execute.RequestBody == nil || execute.RequestBody.Content["application/json"].Schema == nil {
+		t.Error("page_execute should have a JSON request body")
+	}
+}
+
+func TestSpecSHA256_MatchesPin(t *testing.T) {
+	if got, want := SpecSHA256(), PinnedSpecSHA256(); got != want {
+		t.Errorf("openapi.json has SHA-256 %s but %s is pinned; regenerate it with `notte dev regen` or scripts/generate.sh instead of editing it", got, want)
+	}
+}
+
+func TestParseSHA256Pin(t *testing.T) {
+	if got := ParseSHA256Pin("ABC123  openapi.json\n"); got != "abc123" {
+		t.Errorf("ParseSHA256Pin() = %q", got)
+	}
+	if got := ParseSHA256Pin(""); got != "" {
+		t.Errorf("ParseSHA256Pin(\"\") = %q", got)
+	}
+}
+
+func TestLoadSpec_RefsResolve(t *testing.T) {
+	spec, err := LoadSpec()
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	var check func(where string, s *Schema)
+	check = func(where string, s *Schema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			if spec.Resolve(s) == nil {
+				t.Errorf("%s: dangling $ref %s", where, s.Ref)
+			}
+			return
+		}
+		check(where, s.Items)
+		check(where, s.ValueSchema())
+		for _, p := range s.Properties {
+			check(where, p)
+		}
+		for _, group := range [][]*Schema{s.OneOf, s.AnyOf, s.AllOf} {
+			for _, v := range group {
+				check(where, v)
+			}
+		}
+	}
+	for name, s := range spec.Components.Schemas {
+		check(name, s)
+	}
+	for _, op := range spec.Operations() {
+		for _, p := range op.Parameters {
+			check(op.OperationID, p.Schema)
+		}
+		if op.RequestBody != nil {
+			for _, mt := range op.RequestBody.Content {
+				check(op.OperationID, mt.Schema)
+			}
+		}
+		for _, r := range op.Responses {
+			for _, mt := range r.Content {
+				check(op.OperationID, mt.Schema)
+			}
+		}
+	}
+}
+
+func TestSchemaValueSchema(t *testing.T) {
+	open := &Schema{AdditionalProperties: []byte(`{"type":"string"}`)}
+	if got := open.ValueSchema(); !reflect.DeepEqual(got, &Schema{Type: "string"}) {
+		t.Errorf("ValueSchema() = %+v", got)
+	}
+	if got := (&Schema{AdditionalProperties: []byte(`true`)}).ValueSchema(); got != nil {
+		t.Errorf("ValueSchema() for a boolean = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSOptions configures trust and client authentication for API connections,
+// for APIs served behind private gateways
+type TLSOptions struct {
+	CACert     string // PEM bundle trusted in addition to the system roots
+	ClientCert string // PEM client certificate for mutual TLS
+	ClientKey  string // PEM private key for ClientCert
+}
+
+// WithTLS sets custom CA and client certificates
+func WithTLS(opts TLSOptions) NotteClientOption {
+	return func(c *NotteClient) {
+		c.tlsOptions = opts
+	}
+}
+
+// Config builds a TLS 1.2+ config from the options
+func (o TLSOptions) Config() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if o.CACert != "" {
+		pem, err := os.ReadFile(o.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", o.CACert)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if (o.ClientCert == "") != (o.ClientKey == "") {
+		return nil, fmt.Errorf("client certificate and client key must be set together")
+	}
+	if o.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(o.ClientCert, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// writeSelfSigned writes a self-signed certificate and key to dir and
+// returns their paths
+func writeSelfSigned(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPath := filepath.Join(dir, name+".crt")
+	keyPath := filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certPath, keyPath
+}
+
+func TestTLSOptions_Config_Default(t *testing.T) {
+	cfg, err := TLSOptions{}.Config()
+	if err != nil {
+		t.Fatalf("Config() error = %v", err)
+	}
+	if cfg.MinVersion != tls.VersionTLS12 || cfg.RootCAs != nil || len(cfg.Certificates) != 0 {
+		t.Errorf("unexpected default config: %+v", cfg)
+	}
+}
+
+func TestTLSOptions_Config_Errors(t *testing.T) {
+	dir := t.TempDir()
+	certPath, _ := writeSelfSigned(t, dir, "client")
+	garbage := filepath.Join(dir, "garbage.pem")
+	if err := os.WriteFile(garbage, []byte("not a cert"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]TLSOptions{
+		"missing CA":       {CACert: filepath.Join(dir, "missing.pem")},
+		"CA without cert":  {CACert: garbage},
+		"cert without key": {ClientCert: certPath},
+		"key mismatch":     {ClientCert: certPath, ClientKey: garbage},
+	}
+	for name, opts := range tests {
+		if _, err := opts.Config(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestNewClient_CustomCAAndClientCert(t *testing.T) {
+	dir := t.TempDir()
+	clientCert, clientKey := writeSelfSigned(t, dir, "client")
+	clientPEM, err := os.ReadFile(clientCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientPool := x509.NewCertPool()
+	clientPool.AppendCertsFromPEM(clientPEM)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientPool}
+	server.StartTLS()
+	defer server.Close()
+
+	caPath := filepath.Join(dir, "server-ca.pem")
+	if err := os.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	noRetry := WithRetryConfig(&RetryConfig{MaxRetries: 0})
+
+	// Without the CA bundle the server certificate isn't trusted
+	plain, err := NewClientWithURL("key", server.URL, "", noRetry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := plain.HTTPClient().Get(server.URL); err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("expected TLS verification error without custom CA")
+	}
+
+	client, err := NewClientWithURL("key", server.URL, "", noRetry,
+		WithTLS(TLSOptions{CACert: caPath, ClientCert: clientCert, ClientKey: clientKey}))
+	if err != nil {
+		t.Fatalf("NewClientWithURL() error = %v", err)
+	}
+	resp, err := client.HTTPClient().Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with custom CA and client cert failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestNewClient_InvalidTLSOptions(t *testing.T) {
+	if _, err := NewClientWithURL("key", "https://example.com", "", WithTLS(TLSOptions{ClientKey: "key.pem"})); err == nil {
+		t.Error("expected error for client key without certificate")
+	}
+}
+
+func TestNewTransport_HonorsProxyEnv(t *testing.T) {
+	transport, err := NewTransport(TLSOptions{}, TransportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// http.ProxyFromEnvironment caches the environment on first use, so
+	// compare the function rather than setting HTTPS_PROXY here
+	if transport.Proxy == nil || reflect.ValueOf(transport.Proxy).Pointer() != reflect.ValueOf(http.ProxyFromEnvironment).Pointer() {
+		t.Error("expected transport to use http.ProxyFromEnvironment")
+	}
+}
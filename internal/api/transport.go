@@ -0,0 +1,87 @@
+package api
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// TransportOptions tunes connection pooling and timeouts. Zero values fall
+// back to DefaultTransportOptions.
+type TransportOptions struct {
+	MaxIdleConns        int  // idle connections kept across all hosts
+	MaxIdleConnsPerHost int  // idle connections kept per host
+	MaxConnsPerHost     int  // cap on concurrent connections per host; 0 means unlimited
+	DisableHTTP2        bool // force HTTP/1.1
+
+	DialTimeout           time.Duration // TCP connect timeout
+	ResponseHeaderTimeout time.Duration // wait for response headers; 0 means no limit
+	RequestTimeout        time.Duration // overall limit per request, including the body
+}
+
+// DefaultTransportOptions returns defaults sized for bulk commands that run
+// many requests in parallel (downloads, batch runs)
+func DefaultTransportOptions() TransportOptions {
+	return TransportOptions{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 32,
+		DialTimeout:         10 * time.Second,
+		RequestTimeout:      5 * time.Minute,
+	}
+}
+
+// WithTransportOptions sets connection pooling, HTTP/2 and timeout settings
+func WithTransportOptions(opts TransportOptions) NotteClientOption {
+	return func(c *NotteClient) {
+		c.transportOptions = opts
+	}
+}
+
+// withDefaults fills unset fields from DefaultTransportOptions
+func (o TransportOptions) withDefaults() TransportOptions {
+	d := DefaultTransportOptions()
+	if o.MaxIdleConns == 0 {
+		o.MaxIdleConns = d.MaxIdleConns
+	}
+	if o.MaxIdleConnsPerHost == 0 {
+		o.MaxIdleConnsPerHost = d.MaxIdleConnsPerHost
+	}
+	if o.DialTimeout == 0 {
+		o.DialTimeout = d.DialTimeout
+	}
+	if o.RequestTimeout == 0 {
+		o.RequestTimeout = d.RequestTimeout
+	}
+	return o
+}
+
+// NewTransport returns the pooled HTTP transport used for API connections.
+// It honors HTTPS_PROXY, HTTP_PROXY and NO_PROXY.
+func NewTransport(tlsOpts TLSOptions, opts TransportOptions) (*http.Transport, error) {
+	opts = opts.withDefaults()
+
+	tlsConfig, err := tlsOpts.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           (&net.Dialer{Timeout: opts.DialTimeout, KeepAlive: 30 * time.Second}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: opts.ResponseHeaderTimeout,
+		MaxIdleConns:          opts.MaxIdleConns,
+		MaxIdleConnsPerHost:   opts.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       opts.MaxConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		// A custom TLS config turns off HTTP/2 negotiation unless forced
+		ForceAttemptHTTP2: !opts.DisableHTTP2,
+	}
+	if opts.DisableHTTP2 {
+		// A non-nil empty map is how net/http disables HTTP/2
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return transport, nil
+}
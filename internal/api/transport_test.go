@@ -0,0 +1,63 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTransport_Defaults(t *testing.T) {
+	transport, err := NewTransport(TLSOptions{}, TransportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := DefaultTransportOptions()
+	if transport.MaxIdleConns != d.MaxIdleConns || transport.MaxIdleConnsPerHost != d.MaxIdleConnsPerHost {
+		t.Errorf("pool = %d/%d, want defaults %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, d.MaxIdleConns, d.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 0 {
+		t.Errorf("MaxConnsPerHost = %d, want unlimited", transport.MaxConnsPerHost)
+	}
+	if !transport.ForceAttemptHTTP2 || transport.TLSNextProto != nil {
+		t.Error("expected HTTP/2 to be enabled by default")
+	}
+}
+
+func TestNewTransport_Tuned(t *testing.T) {
+	transport, err := NewTransport(TLSOptions{}, TransportOptions{
+		MaxIdleConns:          8,
+		MaxIdleConnsPerHost:   4,
+		MaxConnsPerHost:       2,
+		DisableHTTP2:          true,
+		ResponseHeaderTimeout: 3 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transport.MaxIdleConns != 8 || transport.MaxIdleConnsPerHost != 4 || transport.MaxConnsPerHost != 2 {
+		t.Errorf("pool = %d/%d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost)
+	}
+	if transport.ResponseHeaderTimeout != 3*time.Second {
+		t.Errorf("ResponseHeaderTimeout = %s", transport.ResponseHeaderTimeout)
+	}
+	if transport.ForceAttemptHTTP2 || transport.TLSNextProto == nil {
+		t.Error("expected HTTP/2 to be disabled")
+	}
+}
+
+func TestNewClient_RequestTimeout(t *testing.T) {
+	client, err := NewClientWithURL("key", "https://example.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := client.HTTPClient().Timeout; got != DefaultTransportOptions().RequestTimeout {
+		t.Errorf("default Timeout = %s", got)
+	}
+
+	client, err = NewClientWithURL("key", "https://example.com", "", WithTransportOptions(TransportOptions{RequestTimeout: 15 * time.Second}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := client.HTTPClient().Timeout; got != 15*time.Second {
+		t.Errorf("Timeout = %s, want 15s", got)
+	}
+}
@@ -0,0 +1,8 @@
+package auth
+
+import "golang.org/x/sys/unix"
+
+// bootSessionID returns an ID that changes on every boot
+func bootSessionID() (string, error) {
+	return unix.Sysctl("kern.bootsessionuuid")
+}
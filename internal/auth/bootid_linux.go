@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"os"
+	"strings"
+)
+
+// bootSessionID returns an ID that changes on every boot
+func bootSessionID() (string, error) {
+	data, err := os.ReadFile("/proc/sys/kernel/random/boot_id")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package auth
+
+import "errors"
+
+// bootSessionID has no source here, which keeps the file cache off
+func bootSessionID() (string, error) {
+	return "", errors.New("no boot session ID on this platform")
+}
@@ -0,0 +1,281 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+)
+
+const (
+	// EnvKeyringCacheTTL opts into the on-disk keyring cache, e.g. "5m"
+	EnvKeyringCacheTTL = "NOTTE_KEYRING_CACHE_TTL"
+
+	// MaxKeyringCacheTTL caps how long a key may live in the file cache
+	MaxKeyringCacheTTL = time.Hour
+
+	keyringCacheDir  = "keyring-cache"
+	keyringCacheFile = "cache.json"
+)
+
+// cachedKeyring memoizes keyring reads for the life of the process and, when
+// a TTL is configured, in a short-lived file readable only by the current
+// user, so that rapid scripting loops don't trigger a keychain prompt on
+// every invocation. The file is encrypted with a key tied to the boot
+// session, so copies of it are useless on another machine or after a
+// reboot; processes running as the same user can still derive the key,
+// which is why the cache is opt-in and short-lived.
+type cachedKeyring struct {
+	base KeyringStore
+
+	// file returns the on-disk cache and its TTL; a nil store disables it
+	file func() (KeyringStore, time.Duration)
+
+	mu  sync.Mutex
+	mem map[string]string
+}
+
+func newCachedKeyring(base KeyringStore) *cachedKeyring {
+	return &cachedKeyring{base: base, file: openFileCache, mem: make(map[string]string)}
+}
+
+// cacheEntry is what the file cache stores per key
+type cacheEntry struct {
+	Value     string    `json:"value"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (c *cachedKeyring) Get(key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if val, ok := c.mem[key]; ok {
+		return val, nil
+	}
+
+	store, ttl := c.file()
+	if store != nil {
+		if raw, err := store.Get(key); err == nil {
+			var entry cacheEntry
+			if json.Unmarshal([]byte(raw), &entry) == nil && time.Now().Before(entry.ExpiresAt) {
+				c.mem[key] = entry.Value
+				return entry.Value, nil
+			}
+			_ = store.Delete(key)
+		}
+	}
+
+	val, err := c.base.Get(key)
+	if err != nil {
+		return "", err
+	}
+	c.mem[key] = val
+
+	if store != nil {
+		if data, err := json.Marshal(cacheEntry{Value: val, ExpiresAt: time.Now().Add(ttl)}); err == nil {
+			_ = store.Set(key, string(data))
+		}
+	}
+	return val, nil
+}
+
+func (c *cachedKeyring) Set(key, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.forget(key)
+	if err := c.base.Set(key, value); err != nil {
+		return err
+	}
+	c.mem[key] = value
+	return nil
+}
+
+func (c *cachedKeyring) Delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.forget(key)
+	return c.base.Delete(key)
+}
+
+// forget drops key from both cache layers. A file cache left over from
+// before the TTL was turned off is removed so it can't outlive a logout.
+func (c *cachedKeyring) forget(key string) {
+	delete(c.mem, key)
+	if store, _ := c.file(); store != nil {
+		_ = store.Delete(key)
+		return
+	}
+	if dir, err := config.Dir(); err == nil {
+		_ = os.RemoveAll(filepath.Join(dir, keyringCacheDir))
+	}
+}
+
+// keyringCacheTTL returns the configured file cache TTL, or 0 when disabled.
+// NOTTE_KEYRING_CACHE_TTL takes precedence over keyring_cache_ttl in config.
+func keyringCacheTTL() time.Duration {
+	raw := os.Getenv(EnvKeyringCacheTTL)
+	if raw == "" {
+		if cfg, err := config.Load(); err == nil {
+			raw = cfg.KeyringCacheTTL
+		}
+	}
+	if raw == "" {
+		return 0
+	}
+	ttl, err := time.ParseDuration(raw)
+	if err != nil || ttl <= 0 {
+		return 0
+	}
+	return min(ttl, MaxKeyringCacheTTL)
+}
+
+// openFileCache returns the file cache when a TTL is configured
+func openFileCache() (KeyringStore, time.Duration) {
+	ttl := keyringCacheTTL()
+	if ttl == 0 {
+		return nil, 0
+	}
+	store, err := openFileCacheStore()
+	if err != nil {
+		return nil, 0
+	}
+	return store, ttl
+}
+
+// openFileCacheStore opens the file store under the config dir. It fails on
+// platforms without a boot session ID to key the encryption on.
+func openFileCacheStore() (KeyringStore, error) {
+	bootID, err := bootSessionID()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	dir = filepath.Join(dir, keyringCacheDir)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return newFileStore(filepath.Join(dir, keyringCacheFile), fileCacheKey(bootID))
+}
+
+// fileCacheKey derives the file cache key from the boot session and the
+// user, so the file can't be read after a reboot or by another account
+// sharing the config dir
+func fileCacheKey(bootID string) []byte {
+	sum := sha256.Sum256([]byte("notte-cli keyring cache\x00" + bootID + "\x00" + strconv.Itoa(os.Getuid())))
+	return sum[:]
+}
+
+// errNotCached is returned by fileStore for keys it doesn't hold
+var errNotCached = errors.New("key not cached")
+
+// fileStore keeps keys in a JSON file, sealed with AES-GCM and written with
+// owner-only permissions
+type fileStore struct {
+	path string
+	aead cipher.AEAD
+}
+
+func newFileStore(path string, key []byte) (*fileStore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &fileStore{path: path, aead: aead}, nil
+}
+
+func (f *fileStore) Get(key string) (string, error) {
+	entries, err := f.load()
+	if err != nil {
+		return "", err
+	}
+	val, ok := entries[key]
+	if !ok {
+		return "", errNotCached
+	}
+	return val, nil
+}
+
+func (f *fileStore) Set(key, value string) error {
+	entries, err := f.load()
+	if err != nil {
+		entries = map[string]string{}
+	}
+	entries[key] = value
+	return f.save(entries)
+}
+
+func (f *fileStore) Delete(key string) error {
+	entries, err := f.load()
+	if err != nil {
+		return nil
+	}
+	delete(entries, key)
+	return f.save(entries)
+}
+
+func (f *fileStore) load() (map[string]string, error) {
+	sealed, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+	// A file from an earlier boot, or from before it was encrypted, fails
+	// to open and is overwritten by the next save
+	size := f.aead.NonceSize()
+	if len(sealed) < size {
+		return nil, errNotCached
+	}
+	data, err := f.aead.Open(nil, sealed[:size], sealed[size:], []byte(keyringCacheFile))
+	if err != nil {
+		return nil, err
+	}
+	entries := map[string]string{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// save replaces the file through a temporary one, so a concurrent reader
+// never sees it half written
+func (f *fileStore) save(entries map[string]string) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, f.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data = f.aead.Seal(nonce, nonce, data, []byte(keyringCacheFile))
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), keyringCacheFile+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
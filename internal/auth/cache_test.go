@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+// countingKeyring counts reads that reach the underlying keyring
+type countingKeyring struct {
+	*testutil.MockKeyring
+	gets int
+}
+
+func (c *countingKeyring) Get(key string) (string, error) {
+	c.gets++
+	return c.MockKeyring.Get(key)
+}
+
+func setupCacheTest(t *testing.T) {
+	t.Helper()
+	testutil.SetupTestEnv(t)
+	config.SetTestConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+	t.Setenv(EnvKeyringCacheTTL, "")
+}
+
+func TestCachedKeyring_MemoizesWithinProcess(t *testing.T) {
+	setupCacheTest(t)
+	base := &countingKeyring{MockKeyring: testutil.NewMockKeyring()}
+	_ = base.Set("api_key:prod", "secret")
+	c := newCachedKeyring(base)
+
+	for range 3 {
+		if got, err := c.Get("api_key:prod"); err != nil || got != "secret" {
+			t.Fatalf("Get() = %q, %v", got, err)
+		}
+	}
+	if base.gets != 1 {
+		t.Errorf("keyring reads = %d, want 1", base.gets)
+	}
+
+	if err := c.Set("api_key:prod", "rotated"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := c.Get("api_key:prod"); got != "rotated" {
+		t.Errorf("Get() after Set = %q, want rotated", got)
+	}
+
+	if err := c.Delete("api_key:prod"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get("api_key:prod"); err == nil {
+		t.Error("expected error after Delete")
+	}
+}
+
+func TestCachedKeyring_FileCacheAcrossProcesses(t *testing.T) {
+	setupCacheTest(t)
+	if _, err := bootSessionID(); err != nil {
+		t.Skipf("no file cache here: %v", err)
+	}
+	t.Setenv(EnvKeyringCacheTTL, "5m")
+	base := &countingKeyring{MockKeyring: testutil.NewMockKeyring()}
+	_ = base.Set("api_key:prod", "secret")
+
+	// Each cachedKeyring stands in for a separate CLI invocation
+	if got, err := newCachedKeyring(base).Get("api_key:prod"); err != nil || got != "secret" {
+		t.Fatalf("first Get() = %q, %v", got, err)
+	}
+	if got, err := newCachedKeyring(base).Get("api_key:prod"); err != nil || got != "secret" {
+		t.Fatalf("second Get() = %q, %v", got, err)
+	}
+	if base.gets != 1 {
+		t.Errorf("keyring reads = %d, want 1", base.gets)
+	}
+
+	dir, _ := config.Dir()
+	path := filepath.Join(dir, keyringCacheDir, keyringCacheFile)
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("reading cache file: %v", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0o600 {
+		t.Errorf("cache file mode = %v, want 0600", info.Mode().Perm())
+	}
+	if data, _ := os.ReadFile(path); bytes.Contains(data, []byte("secret")) {
+		t.Error("cache file holds the key in plain text")
+	}
+
+	// Logout clears the file cache for the next invocation
+	if err := newCachedKeyring(base).Delete("api_key:prod"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newCachedKeyring(base).Get("api_key:prod"); err == nil {
+		t.Error("expected error after Delete")
+	}
+}
+
+func TestCachedKeyring_FileCacheExpires(t *testing.T) {
+	setupCacheTest(t)
+	base := &countingKeyring{MockKeyring: testutil.NewMockKeyring()}
+	_ = base.Set("api_key:prod", "secret")
+	file := testutil.NewMockKeyring()
+
+	c := newCachedKeyring(base)
+	c.file = func() (KeyringStore, time.Duration) { return file, -time.Second }
+	if _, err := c.Get("api_key:prod"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The entry written above is already expired
+	c = newCachedKeyring(base)
+	c.file = func() (KeyringStore, time.Duration) { return file, time.Minute }
+	if _, err := c.Get("api_key:prod"); err != nil {
+		t.Fatal(err)
+	}
+	if base.gets != 2 {
+		t.Errorf("keyring reads = %d, want 2 (expired entry ignored)", base.gets)
+	}
+}
+
+func TestFileStore_KeyedOnBootSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), keyringCacheFile)
+	store, err := newFileStore(path, fileCacheKey("boot-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("api_key:prod", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := store.Get("api_key:prod"); err != nil || got != "secret" {
+		t.Fatalf("Get() = %q, %v", got, err)
+	}
+
+	// After a reboot the file no longer opens, and is replaced on write
+	rebooted, _ := newFileStore(path, fileCacheKey("boot-2"))
+	if got, err := rebooted.Get("api_key:prod"); err == nil {
+		t.Errorf("Get() after reboot = %q, want error", got)
+	}
+	if err := rebooted.Set("api_key:dev", "other"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rebooted.Get("api_key:prod"); err == nil {
+		t.Error("entry from the earlier boot survived the rewrite")
+	}
+}
+
+func TestKeyringCacheTTL(t *testing.T) {
+	setupCacheTest(t)
+
+	tests := []struct {
+		env  string
+		want time.Duration
+	}{
+		{"", 0},
+		{"nonsense", 0},
+		{"-1m", 0},
+		{"30s", 30 * time.Second},
+		{"48h", MaxKeyringCacheTTL},
+	}
+	for _, tt := range tests {
+		t.Setenv(EnvKeyringCacheTTL, tt.env)
+		if got := keyringCacheTTL(); got != tt.want {
+			t.Errorf("keyringCacheTTL(%q) = %s, want %s", tt.env, got, tt.want)
+		}
+	}
+
+	t.Setenv(EnvKeyringCacheTTL, "")
+	cfg, _ := config.Load()
+	cfg.KeyringCacheTTL = "2m"
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if got := keyringCacheTTL(); got != 2*time.Minute {
+		t.Errorf("keyringCacheTTL() from config = %s, want 2m", got)
+	}
+}
@@ -40,9 +40,21 @@ func KeyringKeyForEnv(envLabel string) string {
 	return KeyringKey + ":" + envLabel
 }
 
+// apiURLOverride is set from the --api-url flag and wins over everything else
+var apiURLOverride string
+
+// SetAPIURLOverride makes GetCurrentAPIURL return u for the rest of the
+// process. Pass an empty string to clear it.
+func SetAPIURLOverride(u string) {
+	apiURLOverride = u
+}
+
 // GetCurrentAPIURL resolves the current API URL using the same logic as GetClient():
-// NOTTE_API_URL env var -> config file -> DefaultAPIURL.
+// --api-url flag -> NOTTE_API_URL env var -> config file -> DefaultAPIURL.
 func GetCurrentAPIURL() string {
+	if apiURLOverride != "" {
+		return apiURLOverride
+	}
 	if u := os.Getenv(config.EnvAPIURL); u != "" {
 		return u
 	}
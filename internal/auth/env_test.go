@@ -68,3 +68,18 @@ func TestKeyringKeyForEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCurrentAPIURL_Override(t *testing.T) {
+	t.Setenv("NOTTE_API_URL", "https://us-staging.notte.cc")
+	SetAPIURLOverride("https://us-dev.notte.cc")
+	t.Cleanup(func() { SetAPIURLOverride("") })
+
+	if got := GetCurrentAPIURL(); got != "https://us-dev.notte.cc" {
+		t.Errorf("GetCurrentAPIURL() = %q, want override", got)
+	}
+
+	SetAPIURLOverride("")
+	if got := GetCurrentAPIURL(); got != "https://us-staging.notte.cc" {
+		t.Errorf("GetCurrentAPIURL() = %q, want env value", got)
+	}
+}
@@ -8,8 +8,8 @@ type KeyringStore interface {
 }
 
 // defaultKeyring is the package-level keyring used by GetKeyringAPIKey etc.
-// Can be overridden for testing via SetKeyring()
-var defaultKeyring KeyringStore = &realKeyring{}
+// Reads are cached (see cachedKeyring). Can be overridden for testing via SetKeyring()
+var defaultKeyring KeyringStore = newCachedKeyring(&realKeyring{})
 
 // SetKeyring replaces the default keyring (for testing)
 func SetKeyring(k KeyringStore) {
@@ -18,7 +18,7 @@ func SetKeyring(k KeyringStore) {
 
 // ResetKeyring restores the real keyring
 func ResetKeyring() {
-	defaultKeyring = &realKeyring{}
+	defaultKeyring = newCachedKeyring(&realKeyring{})
 }
 
 // realKeyring wraps the actual 99designs/keyring implementation
@@ -1,8 +1,8 @@
 package cmd
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,14 +11,15 @@ import (
 
 	"github.com/nottelabs/notte-cli/internal/api"
 	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
-var agentID string
+var agentsStartAttachViewer bool
 
 // GetCurrentAgentID returns the agent ID from flag, env var, or file (in priority order)
-func GetCurrentAgentID() string {
-	if agentID != "" {
-		return agentID
+func GetCurrentAgentID(cmd *cobra.Command) string {
+	if id := flagsFor(cmd).AgentID; id != "" {
+		return id
 	}
 	if envID := os.Getenv(config.EnvAgentID); envID != "" {
 		return envID
@@ -42,7 +43,11 @@ func setCurrentAgent(id string) error {
 	if err := os.MkdirAll(configDir, 0o700); err != nil {
 		return err
 	}
-	return os.WriteFile(filepath.Join(configDir, config.CurrentAgentFile), []byte(id), 0o600)
+	if err := os.WriteFile(filepath.Join(configDir, config.CurrentAgentFile), []byte(id), 0o600); err != nil {
+		return err
+	}
+	recordIDUse(idKindAgent, id)
+	return nil
 }
 
 func clearCurrentAgent() error {
@@ -76,26 +81,28 @@ func clearCurrentAgentIfMatches(expectedID string) error {
 	return nil
 }
 
-// RequireAgentID ensures an agent ID is available from flag, env, or file
-func RequireAgentID() error {
-	resolvedID := GetCurrentAgentID()
-	if resolvedID == "" {
-		return errors.New("agent ID required: use --agent-id flag, set NOTTE_AGENT_ID env var, or start an agent first")
+// RequireAgentID resolves the agent ID for cmd from flag, env, or file
+func RequireAgentID(cmd *cobra.Command) (string, error) {
+	id := GetCurrentAgentID(cmd)
+	if id == "" {
+		return "", errors.New(i18n.T(i18n.AgentIDRequired))
 	}
-	agentID = resolvedID
-	return nil
+	recordIDUse(idKindAgent, id)
+	return id, nil
 }
 
 var agentsCmd = &cobra.Command{
-	Use:   "agents",
-	Short: "Manage AI agents",
-	Long:  "List, start, and operate on AI agents.",
+	Use:     "agents",
+	Aliases: []string{"agent", "a"},
+	Short:   "Manage AI agents",
+	Long:    "List, start, and operate on AI agents.",
 }
 
 var agentsListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List running agents",
-	RunE:  runAgentsList,
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List running agents",
+	RunE:    runAgentsList,
 }
 
 var agentsStartCmd = &cobra.Command{
@@ -111,9 +118,10 @@ var agentsStatusCmd = &cobra.Command{
 }
 
 var agentsStopCmd = &cobra.Command{
-	Use:   "stop",
-	Short: "Stop the agent",
-	RunE:  runAgentStop,
+	Use:     "stop",
+	Aliases: []string{"rm"},
+	Short:   "Stop the agent",
+	RunE:    runAgentStop,
 }
 
 var agentsWorkflowCodeCmd = &cobra.Command{
@@ -132,6 +140,7 @@ func init() {
 	rootCmd.AddCommand(agentsCmd)
 	agentsCmd.AddCommand(agentsListCmd)
 	registerPaginationFlags(agentsListCmd)
+	registerScopeFlag(agentsListCmd)
 	agentsListCmd.Flags().Bool("only-active", false, "Only return active agents")
 	agentsListCmd.Flags().Bool("only-saved", false, "Only return saved agents")
 
@@ -143,19 +152,25 @@ func init() {
 
 	// Start command flags (auto-generated)
 	RegisterAgentStartFlags(agentsStartCmd)
-	_ = agentsStartCmd.MarkFlagRequired("task")
+	_ = agentsStartCmd.RegisterFlagCompletionFunc("session-id", completeIDsFromHistory(idKindSession))
+	agentsStartCmd.Flags().BoolVar(&agentsStartAttachViewer, "attach-viewer", false, "Open the session's live viewer in the browser once the agent has started")
+	agentsStartCmd.Flags().BoolVar(&skipSchemaCheck, "no-validate", false, "Send --response-format-json without checking that it is a JSON Schema first")
 
 	// Status command flags
-	agentsStatusCmd.Flags().StringVar(&agentID, "agent-id", "", "Agent ID (uses current agent if not specified)")
+	addAgentIDFlag(agentsStatusCmd)
+	registerWaitForFlags(agentsStatusCmd, agentStatuses)
+	addCallbackURLFlag(agentsStatusCmd)
+	addStdinIDsFlag(agentsStatusCmd, "agent-id")
 
 	// Stop command flags
-	agentsStopCmd.Flags().StringVar(&agentID, "agent-id", "", "Agent ID (uses current agent if not specified)")
+	addAgentIDFlag(agentsStopCmd)
+	addStdinIDsFlag(agentsStopCmd, "agent-id")
 
 	// Workflow-code command flags
-	agentsWorkflowCodeCmd.Flags().StringVar(&agentID, "agent-id", "", "Agent ID (uses current agent if not specified)")
+	addAgentIDFlag(agentsWorkflowCodeCmd)
 
 	// Replay command flags
-	agentsReplayCmd.Flags().StringVar(&agentID, "agent-id", "", "Agent ID (uses current agent if not specified)")
+	addAgentIDFlag(agentsReplayCmd)
 }
 
 func runAgentsList(cmd *cobra.Command, args []string) error {
@@ -175,9 +190,14 @@ func runAgentsList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	scope, onlyCurrentToken, err := getScopeFlag(cmd)
+	if err != nil {
+		return err
+	}
 	params := &api.ListAgentsParams{
-		Page:     page,
-		PageSize: pageSize,
+		Page:             page,
+		PageSize:         pageSize,
+		OnlyCurrentToken: onlyCurrentToken,
 	}
 	if cmd.Flags().Changed("only-active") {
 		v, _ := cmd.Flags().GetBool("only-active")
@@ -189,7 +209,7 @@ func runAgentsList(cmd *cobra.Command, args []string) error {
 	}
 	resp, err := client.Client().ListAgentsWithResponse(ctx, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -206,52 +226,82 @@ func runAgentsList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if scope != "" {
+		owned, err := scopeOwnedIDs(scope, func(page *int) ([]string, bool, error) {
+			mine := *params
+			mine.Page, mine.OnlyCurrentToken = page, boolPtr(true)
+			r, err := client.Client().ListAgentsWithResponse(ctx, &mine)
+			if err == nil {
+				err = HandleAPIResponse(r.HTTPResponse, r.Body)
+			}
+			if err != nil || r.JSON200 == nil {
+				return nil, false, err
+			}
+			ids := make([]string, len(r.JSON200.Items))
+			for i, item := range r.JSON200.Items {
+				ids[i] = item.AgentId
+			}
+			return ids, r.JSON200.HasNext, nil
+		})
+		if err != nil {
+			return err
+		}
+		rows := make([]ownedAgent, len(items))
+		for i, item := range items {
+			rows[i] = ownedAgent{Owner: ownerOf(scope, owned, item.AgentId), AgentResponse: item}
+		}
+		return GetFormatter().Print(rows)
+	}
+
 	return GetFormatter().Print(items)
 }
 
 func runAgentsStart(cmd *cobra.Command, args []string) error {
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	// Build request body from generated flags, and check it before any
+	// current agent is stopped
+	body, err := BuildAgentStartRequest(cmd)
+	if err != nil {
+		return err
+	}
+	if err := checkResponseFormat(body.ResponseFormat); err != nil {
+		return err
+	}
+	if err := checkAgentStepPolicy(cmd.Context(), client, body); err != nil {
+		return err
+	}
+
 	// Check if there's already a current agent
-	existingAgentID := GetCurrentAgentID()
+	existingAgentID := GetCurrentAgentID(cmd)
 	if existingAgentID != "" {
 		confirmed, err := confirmReplaceAgent(existingAgentID)
 		if err != nil {
 			return err
 		}
 		if confirmed {
-			stopClient, err := GetClient()
-			if err != nil {
-				return err
-			}
 			ctx, cancel := GetContextWithTimeout(cmd.Context())
 			params := &api.AgentStopParams{
-				SessionId: GetCurrentSessionID(),
+				SessionId: GetCurrentSessionID(cmd),
 			}
-			_, stopErr := stopClient.Client().AgentStopWithResponse(ctx, existingAgentID, params)
+			_, stopErr := client.Client().AgentStopWithResponse(ctx, existingAgentID, params)
 			cancel()
 			if stopErr != nil {
-				PrintInfo(fmt.Sprintf("Warning: could not stop agent %s: %v", existingAgentID, stopErr))
+				PrintInfo(i18n.T(i18n.WarningCouldNotStopAgent, existingAgentID, stopErr))
 			}
 			_ = clearCurrentAgent()
 		}
 	}
 
-	client, err := GetClient()
-	if err != nil {
-		return err
-	}
-
 	ctx, cancel := GetContextWithTimeout(cmd.Context())
 	defer cancel()
 
-	// Build request body from generated flags
-	body, err := BuildAgentStartRequest(cmd)
-	if err != nil {
-		return err
-	}
-
 	// Auto-use current session ID if --session-id not provided
 	if body.SessionId == "" {
-		if currentSessionID := GetCurrentSessionID(); currentSessionID != "" {
+		if currentSessionID := GetCurrentSessionID(cmd); currentSessionID != "" {
 			body.SessionId = currentSessionID
 		}
 	}
@@ -259,25 +309,52 @@ func runAgentsStart(cmd *cobra.Command, args []string) error {
 	params := &api.AgentStartParams{}
 	resp, err := client.Client().AgentStartWithResponse(ctx, params, *body)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
 		return err
 	}
 
-	// Save agent ID as current agent
+	// Save agent ID as current agent, and its parameters for agents rerun
 	if resp.JSON200 != nil {
 		if err := setCurrentAgent(resp.JSON200.AgentId); err != nil {
-			PrintInfo(fmt.Sprintf("Warning: could not save current agent: %v", err))
+			PrintInfo(i18n.T(i18n.WarningCouldNotSaveCurrentAgent, err))
 		}
+		rememberAgentRun(resp.JSON200.AgentId, body, sessionSettings(cmd.Context(), client, resp.JSON200.SessionId))
 	}
 
-	return GetFormatter().Print(resp.JSON200)
+	if err := GetFormatter().Print(resp.JSON200); err != nil {
+		return err
+	}
+
+	if agentsStartAttachViewer && resp.JSON200 != nil {
+		attachAgentViewer(cmd.Context(), client, resp.JSON200.SessionId)
+	}
+	return nil
+}
+
+// attachAgentViewer opens the viewer of the session an agent runs on. The
+// agent is already running, so failures are only reported as warnings.
+func attachAgentViewer(ctx context.Context, client *api.NotteClient, sessionID string) {
+	viewerURL, err := fetchViewerURL(ctx, client, sessionID)
+	if err != nil {
+		PrintInfo(i18n.T(i18n.WarningCouldNotGetViewerURL, sessionID, err))
+		return
+	}
+	PrintInfo(i18n.T(i18n.OpeningViewerInBrowser, viewerURL))
+	if err := openBrowser(viewerURL); err != nil {
+		PrintInfo(i18n.T(i18n.WarningFailedToOpenBrowser, err))
+	}
 }
 
 func runAgentStatus(cmd *cobra.Command, args []string) error {
-	if err := RequireAgentID(); err != nil {
+	agentID, err := RequireAgentID(cmd)
+	if err != nil {
+		return err
+	}
+	waitFor, waitTimeout, err := getWaitForFlags(cmd, agentStatuses)
+	if err != nil {
 		return err
 	}
 
@@ -286,24 +363,49 @@ func runAgentStatus(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx, cancel := GetContextWithTimeout(cmd.Context())
-	defer cancel()
+	var agent *api.LegacyAgentStatusResponse
+	fetch := func(ctx context.Context) (string, error) {
+		ctx, cancel := GetContextWithTimeout(ctx)
+		defer cancel()
 
-	params := &api.AgentStatusParams{}
-	resp, err := client.Client().AgentStatusWithResponse(ctx, agentID, params)
-	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		params := &api.AgentStatusParams{}
+		resp, err := client.Client().AgentStatusWithResponse(ctx, agentID, params)
+		if err != nil {
+			return "", i18n.Errorf(i18n.APIRequestFailed, err)
+		}
+
+		if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return "", err
+		}
+		if resp.JSON200 == nil {
+			return "", i18n.Errorf(i18n.EmptyAgentStatusResponse)
+		}
+		agent = resp.JSON200
+		return string(agent.Status), nil
 	}
 
-	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+	if waitFor != "" {
+		err = waitForStatus(cmd.Context(), "agent", agentID, waitFor, waitTimeout, fetch)
+	} else {
+		_, err = fetch(cmd.Context())
+	}
+	if agent != nil {
+		recordCallbackResult(agent)
+	}
+	if err != nil {
 		return err
 	}
 
-	return GetFormatter().Print(resp.JSON200)
+	return GetFormatter().Print(agent)
 }
 
 func runAgentStop(cmd *cobra.Command, args []string) error {
-	if err := RequireAgentID(); err != nil {
+	agentID, err := RequireAgentID(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := checkProtected("agent", agentID); err != nil {
 		return err
 	}
 
@@ -312,7 +414,7 @@ func runAgentStop(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	if !confirmed {
-		return PrintResult("Cancelled.", map[string]any{"cancelled": true})
+		return PrintResult(i18n.T(i18n.Cancelled), map[string]any{"cancelled": true})
 	}
 
 	client, err := GetClient()
@@ -325,11 +427,11 @@ func runAgentStop(cmd *cobra.Command, args []string) error {
 
 	// Use current session ID for the stop request
 	params := &api.AgentStopParams{
-		SessionId: GetCurrentSessionID(),
+		SessionId: GetCurrentSessionID(cmd),
 	}
 	resp, err := client.Client().AgentStopWithResponse(ctx, agentID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -338,17 +440,18 @@ func runAgentStop(cmd *cobra.Command, args []string) error {
 
 	// Clear current agent only if it matches the stopped agent
 	if err := clearCurrentAgentIfMatches(agentID); err != nil {
-		PrintInfo(fmt.Sprintf("Warning: could not clear current agent: %v", err))
+		PrintInfo(i18n.T(i18n.WarningCouldNotClearCurrentAgent, err))
 	}
 
-	return PrintResult(fmt.Sprintf("Agent %s stopped.", agentID), map[string]any{
+	return PrintResult(i18n.T(i18n.AgentStopped, agentID), map[string]any{
 		"id":     agentID,
 		"status": "stopped",
 	})
 }
 
 func runAgentWorkflowCode(cmd *cobra.Command, args []string) error {
-	if err := RequireAgentID(); err != nil {
+	agentID, err := RequireAgentID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -366,7 +469,7 @@ func runAgentWorkflowCode(cmd *cobra.Command, args []string) error {
 	}
 	resp, err := client.Client().GetScriptWithResponse(ctx, agentID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -377,7 +480,8 @@ func runAgentWorkflowCode(cmd *cobra.Command, args []string) error {
 }
 
 func runAgentReplay(cmd *cobra.Command, args []string) error {
-	if err := RequireAgentID(); err != nil {
+	agentID, err := RequireAgentID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -393,7 +497,7 @@ func runAgentReplay(cmd *cobra.Command, args []string) error {
 	statusParams := &api.AgentStatusParams{}
 	statusResp, err := client.Client().AgentStatusWithResponse(ctx, agentID, statusParams)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(statusResp.HTTPResponse, statusResp.Body); err != nil {
@@ -401,19 +505,19 @@ func runAgentReplay(cmd *cobra.Command, args []string) error {
 	}
 
 	if statusResp.JSON200 == nil {
-		return fmt.Errorf("unexpected empty response from agent status API")
+		return i18n.Errorf(i18n.UnexpectedEmptyAgentStatus)
 	}
 
 	agentSessionID := statusResp.JSON200.SessionId
 	if agentSessionID == "" {
-		return fmt.Errorf("agent %s has no associated session ID", agentID)
+		return i18n.Errorf(i18n.AgentHasNoAssociatedSessionID, agentID)
 	}
 
 	// Get session replay using the agent's session ID
 	replayParams := &api.SessionReplayParams{}
 	resp, err := client.Client().SessionReplayWithResponse(ctx, agentSessionID, replayParams)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -421,7 +525,7 @@ func runAgentReplay(cmd *cobra.Command, args []string) error {
 	}
 
 	if resp.JSON200 == nil {
-		return fmt.Errorf("unexpected empty response from replay API")
+		return i18n.Errorf(i18n.UnexpectedEmptyReplay)
 	}
 
 	return GetFormatter().Print(resp.JSON200)
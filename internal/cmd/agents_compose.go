@@ -0,0 +1,379 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	agentsComposeFile         string
+	agentsComposeDryRun       bool
+	agentsComposeKeepSessions bool
+	agentsComposeInterval     time.Duration
+)
+
+var agentsComposeCmd = &cobra.Command{
+	Use:   "compose",
+	Short: "Run several agents from a compose file, in dependency order",
+	Long: `Run a pipeline of agents described in a YAML file. An agent starts once the
+agents it depends on have succeeded; if one fails, the agents that depend on it
+are skipped. Agents can share a browser session, and a task can use the results
+of the agents it depends on.
+
+  sessions:
+    shop:                        # started by compose, stopped at the end
+      headless: true
+  agents:
+    login:
+      session: shop
+      task: Log in to https://shop.example with the vault credentials
+      vault_id: vault_123
+    orders:
+      session: shop
+      depends_on: [login]
+      task: List my last 5 orders
+      max_steps: 15
+    summary:
+      depends_on: [orders]       # no session: gets a session of its own
+      task: "Summarize these orders: {{.orders.answer}}"
+
+Agent fields other than depends_on, session and session_id are sent to the
+agent start API as is (url, max_steps, reasoning_model, vault_id, persona_id,
+use_vision, response_format, ...). session_id runs the agent on an existing
+session instead. Agents on the same session never run at the same time.
+
+Tasks are Go templates. {{.<agent>.answer}}, .success, .steps, .agent_id and
+.session_id refer to an agent the task depends on, directly or not.
+
+Sessions declared under sessions: take the session start API fields. A session
+that compose started is stopped once no remaining agent needs it, unless
+--keep-sessions is given.
+
+Agents are started as soon as they can, up to --max-parallel at once. When the
+API refuses one for being over the account's concurrency limit, it is queued
+until a running agent finishes instead of failing.`,
+	Example: `  notte agents compose --file pipeline.yaml
+  notte agents compose --file pipeline.yaml --dry-run
+  notte agents compose --file pipeline.yaml -o json`,
+	Args: cobra.NoArgs,
+	RunE: runAgentsCompose,
+}
+
+func init() {
+	agentsCmd.AddCommand(agentsComposeCmd)
+
+	agentsComposeCmd.Flags().StringVarP(&agentsComposeFile, "file", "f", "notte-compose.yaml", "Compose file ('-' for stdin)")
+	agentsComposeCmd.Flags().BoolVar(&agentsComposeDryRun, "dry-run", false, "Check the file and print the run order without starting anything")
+	agentsComposeCmd.Flags().BoolVar(&agentsComposeKeepSessions, "keep-sessions", false, "Leave the sessions compose started running when it finishes")
+	agentsComposeCmd.Flags().DurationVar(&agentsComposeInterval, "interval", 2*time.Second, "How often to poll running agents")
+	addMaxParallelFlag(agentsComposeCmd, "agents running")
+}
+
+// composeName is what agent and session names must look like, so tasks
+// can refer to them as {{.name.answer}}
+var composeName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// composeAgentKeys are the agent fields compose handles itself
+var composeAgentKeys = []string{"depends_on", "session", "session_id", "task"}
+
+// composeAgent is one agent of a compose file
+type composeAgent struct {
+	Name      string
+	DependsOn []string
+	// Session names a session started by compose; SessionID an existing one
+	Session   string
+	SessionID string
+	Task      *template.Template
+	// Fields are the other agent start fields, as written in the file
+	Fields map[string]any
+}
+
+// composeFile is a parsed compose file. Agents keep the order of the file.
+type composeFile struct {
+	Sessions map[string]*api.ApiSessionStartRequest
+	Agents   []*composeAgent
+}
+
+func (f *composeFile) agent(name string) *composeAgent {
+	for _, a := range f.Agents {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// decodeStrict converts v to the JSON type out, rejecting unknown fields
+func decodeStrict(v any, out any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return errors.New(strings.TrimPrefix(err.Error(), "json: "))
+	}
+	return nil
+}
+
+// parseComposeFile parses and checks a compose file
+func parseComposeFile(data []byte) (*composeFile, error) {
+	var raw struct {
+		Sessions map[string]map[string]any `yaml:"sessions"`
+		Agents   yaml.Node                 `yaml:"agents"`
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&raw); err != nil {
+		return nil, i18n.Errorf(i18n.InvalidComposeFile, err)
+	}
+	if raw.Agents.Kind != yaml.MappingNode || len(raw.Agents.Content) == 0 {
+		return nil, errors.New(i18n.T(i18n.InvalidComposeFileNoAgents))
+	}
+
+	f := &composeFile{Sessions: map[string]*api.ApiSessionStartRequest{}}
+	for name, fields := range raw.Sessions {
+		if !composeName.MatchString(name) {
+			return nil, i18n.Errorf(i18n.InvalidComposeSessionName, name)
+		}
+		body := &api.ApiSessionStartRequest{}
+		if fields != nil {
+			if err := decodeStrict(fields, body); err != nil {
+				return nil, i18n.Errorf(i18n.SessionError, name, err)
+			}
+		}
+		f.Sessions[name] = body
+	}
+
+	for i := 0; i < len(raw.Agents.Content); i += 2 {
+		name := raw.Agents.Content[i].Value
+		a, err := parseComposeAgent(name, raw.Agents.Content[i+1])
+		if err != nil {
+			return nil, i18n.Errorf(i18n.AgentError, name, err)
+		}
+		if f.agent(name) != nil {
+			return nil, i18n.Errorf(i18n.AgentIsDefinedTwice, name)
+		}
+		f.Agents = append(f.Agents, a)
+	}
+
+	if err := f.check(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func parseComposeAgent(name string, node *yaml.Node) (*composeAgent, error) {
+	if !composeName.MatchString(name) {
+		return nil, errors.New(i18n.T(i18n.InvalidComposeName))
+	}
+	var fields map[string]any
+	if err := node.Decode(&fields); err != nil {
+		return nil, err
+	}
+	a := &composeAgent{Name: name, Fields: map[string]any{}}
+	for k, v := range fields {
+		if !slices.Contains(composeAgentKeys, k) {
+			a.Fields[k] = v
+		}
+	}
+
+	var keys struct {
+		DependsOn []string `yaml:"depends_on"`
+		Session   string   `yaml:"session"`
+		SessionID string   `yaml:"session_id"`
+		Task      string   `yaml:"task"`
+	}
+	if err := node.Decode(&keys); err != nil {
+		return nil, err
+	}
+	a.DependsOn, a.Session, a.SessionID = keys.DependsOn, keys.Session, keys.SessionID
+	if a.Session != "" && a.SessionID != "" {
+		return nil, errors.New(i18n.T(i18n.SessionAndSessionID))
+	}
+	if strings.TrimSpace(keys.Task) == "" {
+		return nil, errors.New(i18n.T(i18n.TaskIsRequired))
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(keys.Task)
+	if err != nil {
+		return nil, i18n.Errorf(i18n.InvalidTaskTemplate, err)
+	}
+	a.Task = tmpl
+
+	// Catch misspelled fields before anything is started
+	if _, err := a.request("", ""); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// sessionKey identifies the session the agent runs on; agents with the
+// same key share a session and take turns on it
+func (a *composeAgent) sessionKey() string {
+	switch {
+	case a.SessionID != "":
+		return "id:" + a.SessionID
+	case a.Session != "":
+		return "session:" + a.Session
+	default:
+		return "agent:" + a.Name
+	}
+}
+
+// request builds the agent start request for the rendered task
+func (a *composeAgent) request(task, sessionID string) (*api.ApiAgentStartRequest, error) {
+	body := &api.ApiAgentStartRequest{}
+	if err := decodeStrict(a.Fields, body); err != nil {
+		return nil, err
+	}
+	body.Task, body.SessionId = task, sessionID
+	return body, nil
+}
+
+// check validates dependencies, task references and sessions
+func (f *composeFile) check() error {
+	for _, a := range f.Agents {
+		for _, dep := range a.DependsOn {
+			if dep == a.Name {
+				return i18n.Errorf(i18n.AgentDependsOnItself, a.Name)
+			}
+			if f.agent(dep) == nil {
+				return i18n.Errorf(i18n.AgentDependsOnUnknownAgent, a.Name, dep)
+			}
+		}
+		if a.Session != "" && f.Sessions[a.Session] == nil {
+			// Sessions that are only referenced use the default settings
+			f.Sessions[a.Session] = &api.ApiSessionStartRequest{}
+		}
+	}
+	if _, err := f.order(); err != nil {
+		return err
+	}
+	for _, a := range f.Agents {
+		upstream := f.upstream(a)
+		for _, ref := range templateVariables(a.Task) {
+			if !slices.Contains(upstream, ref) {
+				return i18n.Errorf(i18n.AgentTaskUsesNonDependency, a.Name, ref, ref)
+			}
+		}
+	}
+	return nil
+}
+
+// upstream returns the agents a depends on, directly or not
+func (f *composeFile) upstream(a *composeAgent) []string {
+	var names []string
+	var visit func(*composeAgent)
+	visit = func(x *composeAgent) {
+		for _, dep := range x.DependsOn {
+			if !slices.Contains(names, dep) {
+				names = append(names, dep)
+				visit(f.agent(dep))
+			}
+		}
+	}
+	visit(a)
+	return names
+}
+
+// order returns agent names in an order that respects dependencies, keeping
+// the file order otherwise. It fails on dependency cycles.
+func (f *composeFile) order() ([]string, error) {
+	var names []string
+	for len(names) < len(f.Agents) {
+		progress := false
+		for _, a := range f.Agents {
+			if slices.Contains(names, a.Name) {
+				continue
+			}
+			ready := true
+			for _, dep := range a.DependsOn {
+				ready = ready && slices.Contains(names, dep)
+			}
+			if ready {
+				names = append(names, a.Name)
+				progress = true
+			}
+		}
+		if !progress {
+			var cycle []string
+			for _, a := range f.Agents {
+				if !slices.Contains(names, a.Name) {
+					cycle = append(cycle, a.Name)
+				}
+			}
+			return nil, i18n.Errorf(i18n.DependencyCycleBetweenAgents, strings.Join(cycle, ", "))
+		}
+	}
+	return names, nil
+}
+
+func runAgentsCompose(cmd *cobra.Command, args []string) error {
+	if agentsComposeInterval <= 0 {
+		return errors.New(i18n.T(i18n.IntervalMustBePositive))
+	}
+	maxParallel, _ := cmd.Flags().GetInt("max-parallel")
+	if maxParallel < 0 {
+		return errors.New(i18n.T(i18n.MaxParallelCannotBeNegative))
+	}
+	var data []byte
+	var err error
+	if agentsComposeFile == "-" {
+		data, err = readFromStdin(cmd, "file")
+	} else {
+		data, err = readJSONInputFile(agentsComposeFile, "compose")
+	}
+	if err != nil {
+		return err
+	}
+	file, err := parseComposeFile(data)
+	if err != nil {
+		return err
+	}
+
+	if agentsComposeDryRun {
+		return printComposePlan(file)
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+	return newComposeRunner(client, file, maxParallel).run(cmd.Context())
+}
+
+// printComposePlan prints the order agents would run in
+func printComposePlan(f *composeFile) error {
+	names, _ := f.order()
+	type planStep struct {
+		Name      string   `json:"name"`
+		DependsOn []string `json:"depends_on"`
+		Session   string   `json:"session"`
+	}
+	plan := make([]planStep, len(names))
+	for i, name := range names {
+		a := f.agent(name)
+		session := a.Session
+		switch {
+		case a.SessionID != "":
+			session = a.SessionID
+		case session == "":
+			session = "(own session)"
+		}
+		plan[i] = planStep{Name: name, DependsOn: append([]string{}, a.DependsOn...), Session: session}
+	}
+	return GetFormatter().Print(plan)
+}
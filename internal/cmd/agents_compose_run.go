@@ -0,0 +1,412 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// Compose agent states
+const (
+	composePending   = "pending"
+	composeRunning   = "running"
+	composeSucceeded = "succeeded"
+	composeFailed    = "failed"
+	composeSkipped   = "skipped"
+	composeStopped   = "stopped"
+)
+
+// composeState is the progress of one agent, as shown in the final summary
+type composeState struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	AgentID   string `json:"agent_id,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+	Steps     int    `json:"steps"`
+	Answer    string `json:"answer,omitempty"`
+	Error     string `json:"error,omitempty"`
+
+	success bool
+	// attempts counts starts refused by the API's concurrency limit
+	attempts int
+	// session is the sessionKey of the session the agent holds while running
+	session string
+}
+
+// composeRunner starts the agents of a compose file as their dependencies
+// complete, and the sessions they need
+type composeRunner struct {
+	client *api.NotteClient
+	file   *composeFile
+	states map[string]*composeState
+	order  []string
+	// sessions maps compose session names to the IDs of sessions started
+	sessions map[string]string
+	busy     map[string]bool
+	limit    *concurrencyLimit
+	// retryAt holds starts back after a concurrency limit error with no
+	// agent of ours running
+	retryAt time.Time
+}
+
+func newComposeRunner(client *api.NotteClient, file *composeFile, maxParallel int) *composeRunner {
+	order, _ := file.order()
+	r := &composeRunner{
+		client:   client,
+		file:     file,
+		states:   map[string]*composeState{},
+		order:    order,
+		sessions: map[string]string{},
+		busy:     map[string]bool{},
+		limit:    newConcurrencyLimit(maxParallel),
+	}
+	for _, a := range file.Agents {
+		r.states[a.Name] = &composeState{Name: a.Name, Status: composePending}
+	}
+	return r
+}
+
+// run executes the pipeline until every agent has finished or been
+// skipped. Ctrl-C stops the running agents. The summary is printed either way.
+func (r *composeRunner) run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(agentsComposeInterval)
+	defer ticker.Stop()
+
+	var runErr error
+	for {
+		r.releaseIdleSessions()
+		r.startReady(ctx)
+		if !r.anyRunning() && !r.anyPending() {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			runErr = errors.New(i18n.T(i18n.Interrupted))
+		case <-ticker.C:
+			r.poll(ctx)
+			continue
+		}
+		break
+	}
+	if runErr != nil {
+		r.stopRunning()
+	}
+	r.stopSessions()
+
+	if err := GetFormatter().Print(r.summary()); err != nil {
+		return err
+	}
+	if runErr != nil {
+		return runErr
+	}
+	failed := 0
+	for _, s := range r.states {
+		if s.Status != composeSucceeded {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return i18n.Errorf(i18n.AgentsDidNotSucceed, failed, len(r.states))
+	}
+	return nil
+}
+
+func (r *composeRunner) summary() []*composeState {
+	out := make([]*composeState, len(r.order))
+	for i, name := range r.order {
+		out[i] = r.states[name]
+	}
+	return out
+}
+
+func (r *composeRunner) anyRunning() bool {
+	for _, s := range r.states {
+		if s.Status == composeRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// anyPending reports agents left to start. With none running, they are
+// waiting out a concurrency limit.
+func (r *composeRunner) anyPending() bool {
+	for _, s := range r.states {
+		if s.Status == composePending {
+			return true
+		}
+	}
+	return false
+}
+
+// startReady starts every pending agent whose dependencies have succeeded
+// and whose session is free, and skips those with a failed dependency
+func (r *composeRunner) startReady(ctx context.Context) {
+	for changed := true; changed; {
+		changed = false
+		for _, name := range r.order {
+			if time.Now().Before(r.retryAt) {
+				return
+			}
+			state := r.states[name]
+			if state.Status != composePending {
+				continue
+			}
+			a := r.file.agent(name)
+			ready, failedDep := true, ""
+			for _, dep := range a.DependsOn {
+				switch r.states[dep].Status {
+				case composeSucceeded:
+				case composePending, composeRunning:
+					ready = false
+				default:
+					failedDep = dep
+				}
+			}
+			if failedDep != "" {
+				state.Status = composeSkipped
+				state.Error = fmt.Sprintf("%s did not succeed", failedDep)
+				PrintInfo(i18n.T(i18n.ComposeAgentSkipped, name, state.Error))
+				changed = true
+				continue
+			}
+			if !ready || r.busy[a.sessionKey()] || !r.limit.tryAcquire() {
+				continue
+			}
+			r.start(ctx, a, state)
+			changed = changed || state.Status == composeFailed
+		}
+	}
+}
+
+// taskData is what a task template sees: the results of finished agents
+func (r *composeRunner) taskData() map[string]any {
+	data := map[string]any{}
+	for name, s := range r.states {
+		if s.Status == composeSucceeded {
+			data[name] = map[string]any{
+				"answer":     s.Answer,
+				"success":    s.success,
+				"steps":      s.Steps,
+				"agent_id":   s.AgentID,
+				"session_id": s.SessionID,
+			}
+		}
+	}
+	return data
+}
+
+func (r *composeRunner) start(ctx context.Context, a *composeAgent, state *composeState) {
+	fail := func(err error) {
+		r.limit.release()
+		state.attempts++
+		if rl, ok := concurrencyLimitError(err); ok && state.attempts < maxQueuedAttempts {
+			// Stay pending and try again once there is room
+			if running := r.limit.limitReached(); running > 0 {
+				PrintInfo(i18n.T(i18n.ConcurrencyLimitQueued, a.Name, running))
+			} else {
+				r.retryAt = time.Now().Add(rl.RetryAfter)
+				PrintInfo(i18n.T(i18n.ConcurrencyLimitRetrying, a.Name, rl.RetryAfter))
+			}
+			return
+		}
+		state.Status, state.Error = composeFailed, err.Error()
+		PrintInfo(i18n.T(i18n.ComposeAgentStartFailed, a.Name, err))
+	}
+
+	var task strings.Builder
+	if err := a.Task.Execute(&task, r.taskData()); err != nil {
+		fail(i18n.Errorf(i18n.TaskTemplateError, err))
+		return
+	}
+
+	sessionID := a.SessionID
+	if sessionID == "" {
+		id, err := r.session(ctx, a)
+		if err != nil {
+			fail(err)
+			return
+		}
+		sessionID = id
+	}
+
+	body, err := a.request(strings.TrimSpace(task.String()), sessionID)
+	if err != nil {
+		fail(err)
+		return
+	}
+	reqCtx, cancel := GetContextWithTimeout(ctx)
+	defer cancel()
+	resp, err := r.client.Client().AgentStartWithResponse(reqCtx, &api.AgentStartParams{}, *body)
+	if err == nil {
+		err = HandleAPIResponse(resp.HTTPResponse, resp.Body)
+	}
+	if err == nil && resp.JSON200 == nil {
+		err = errors.New(i18n.T(i18n.EmptyAgentStartResponse))
+	}
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	state.Status, state.AgentID, state.SessionID = composeRunning, resp.JSON200.AgentId, sessionID
+	var settings *sessionStart
+	switch {
+	case a.SessionID != "":
+		settings = sessionSettings(ctx, r.client, a.SessionID)
+	case r.file.Sessions[a.Session] != nil:
+		settings = sentSessionStart(r.file.Sessions[a.Session])
+	default:
+		settings = sentSessionStart(&api.ApiSessionStartRequest{})
+	}
+	rememberAgentRun(state.AgentID, body, settings)
+	state.session = a.sessionKey()
+	r.busy[state.session] = true
+	PrintInfo(i18n.T(i18n.ComposeAgentStarted, a.Name, state.AgentID, sessionID))
+}
+
+// session returns the session an agent runs on, starting it on first use.
+// Agents without a session get one of their own.
+func (r *composeRunner) session(ctx context.Context, a *composeAgent) (string, error) {
+	key, body := a.sessionKey(), r.file.Sessions[a.Session]
+	if body == nil {
+		body = &api.ApiSessionStartRequest{}
+	}
+	if id, ok := r.sessions[key]; ok {
+		return id, nil
+	}
+
+	reqCtx, cancel := GetContextWithTimeout(ctx)
+	defer cancel()
+	resp, err := r.client.Client().SessionStartWithResponse(reqCtx, &api.SessionStartParams{}, *body)
+	if err == nil {
+		err = HandleAPIResponse(resp.HTTPResponse, resp.Body)
+	}
+	if err == nil && resp.JSON200 == nil {
+		err = errors.New(i18n.T(i18n.EmptySessionStartResponse))
+	}
+	if err != nil {
+		return "", i18n.Errorf(i18n.FailedToStartSession, err)
+	}
+	r.sessions[key] = resp.JSON200.SessionId
+	return resp.JSON200.SessionId, nil
+}
+
+// poll checks every running agent and records those that have finished
+func (r *composeRunner) poll(ctx context.Context) {
+	for _, name := range r.order {
+		state := r.states[name]
+		if state.Status != composeRunning {
+			continue
+		}
+		reqCtx, cancel := GetContextWithTimeout(ctx)
+		resp, err := r.client.Client().AgentStatusWithResponse(reqCtx, state.AgentID, &api.AgentStatusParams{})
+		cancel()
+		if err == nil {
+			err = HandleAPIResponse(resp.HTTPResponse, resp.Body)
+		}
+		if err != nil || resp.JSON200 == nil {
+			// Keep polling; a transient error shouldn't fail the pipeline
+			if err != nil && IsVerbose() {
+				PrintInfo(i18n.T(i18n.ComposeStatusCheckFailed, name, err))
+			}
+			continue
+		}
+		status := resp.JSON200
+		if status.Steps != nil {
+			state.Steps = len(*status.Steps)
+		}
+		if status.Status != api.AgentStatusClosed {
+			continue
+		}
+
+		state.success = status.Success != nil && *status.Success
+		if status.Answer != nil {
+			state.Answer = *status.Answer
+		}
+		state.Status = composeFailed
+		if state.success {
+			state.Status = composeSucceeded
+		}
+		r.busy[state.session] = false
+		r.limit.release()
+		PrintInfo(i18n.T(i18n.ComposeAgentFinished, name, state.Status, state.Steps))
+	}
+}
+
+// stopRunning stops agents that are still running, on interrupt
+func (r *composeRunner) stopRunning() {
+	for _, name := range r.order {
+		state := r.states[name]
+		if state.Status != composeRunning {
+			continue
+		}
+		ctx, cancel := GetContextWithTimeout(context.Background())
+		_, err := r.client.Client().AgentStopWithResponse(ctx, state.AgentID, &api.AgentStopParams{SessionId: state.SessionID})
+		cancel()
+		if err != nil {
+			PrintInfo(i18n.T(i18n.WarningCouldNotStopAgent, state.AgentID, err))
+		}
+		state.Status = composeStopped
+	}
+}
+
+// releaseIdleSessions stops the sessions compose started that no pending or
+// running agent needs anymore, so they don't hold the account's concurrency
+func (r *composeRunner) releaseIdleSessions() {
+	if agentsComposeKeepSessions {
+		return
+	}
+	for _, key := range slices.Sorted(maps.Keys(r.sessions)) {
+		needed := false
+		for _, name := range r.order {
+			status := r.states[name].Status
+			if (status == composePending || status == composeRunning) && r.file.agent(name).sessionKey() == key {
+				needed = true
+				break
+			}
+		}
+		if !needed {
+			r.stopSession(r.sessions[key])
+			delete(r.sessions, key)
+		}
+	}
+}
+
+func (r *composeRunner) stopSession(id string) {
+	ctx, cancel := GetContextWithTimeout(context.Background())
+	defer cancel()
+	if _, err := r.client.Client().SessionStopWithResponse(ctx, id, &api.SessionStopParams{}); err != nil {
+		PrintInfo(i18n.T(i18n.WarningCouldNotStopSession, id, err))
+	}
+}
+
+// stopSessions stops the sessions compose started, unless --keep-sessions
+func (r *composeRunner) stopSessions() {
+	ids := make([]string, 0, len(r.sessions))
+	for _, id := range r.sessions {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	if agentsComposeKeepSessions {
+		for _, id := range ids {
+			PrintInfo(i18n.T(i18n.SessionIsStillRunning, id))
+		}
+		return
+	}
+	for _, id := range ids {
+		r.stopSession(id)
+	}
+}
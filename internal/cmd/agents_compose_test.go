@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+const composeTestFile = `
+sessions:
+  shop:
+    headless: true
+agents:
+  summary:
+    depends_on: [orders]
+    task: "Summarize: {{.orders.answer}}"
+  login:
+    session: shop
+    task: Log in to the shop
+    vault_id: vault_1
+  orders:
+    session: shop
+    depends_on: [login]
+    task: List my orders
+    max_steps: 15
+`
+
+func TestParseComposeFile(t *testing.T) {
+	f, err := parseComposeFile([]byte(composeTestFile))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	order, err := f.order()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"login", "orders", "summary"}; !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if f.Sessions["shop"] == nil || f.Sessions["shop"].Headless == nil || !*f.Sessions["shop"].Headless {
+		t.Errorf("shop session settings not parsed: %+v", f.Sessions["shop"])
+	}
+	body, err := f.agent("orders").request("List my orders", "sess_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body.MaxSteps == nil || *body.MaxSteps != 15 || body.SessionId != "sess_1" {
+		t.Errorf("unexpected request: %+v", body)
+	}
+}
+
+func TestParseComposeFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"no agents", "sessions: {}", "no agents"},
+		{"unknown top-level key", "agent:\n  a:\n    task: x", "field agent not found"},
+		{"unknown agent field", "agents:\n  a:\n    task: x\n    max_stepz: 3", "max_stepz"},
+		{"unknown session field", "sessions:\n  s:\n    headles: true\nagents:\n  a:\n    task: x", "headles"},
+		{"missing task", "agents:\n  a:\n    url: https://example.com", "task is required"},
+		{"bad name", "agents:\n  my-agent:\n    task: x", "names may only use"},
+		{"unknown dependency", "agents:\n  a:\n    task: x\n    depends_on: [b]", `unknown agent "b"`},
+		{"self dependency", "agents:\n  a:\n    task: x\n    depends_on: [a]", "depends on itself"},
+		{"cycle", "agents:\n  a:\n    task: x\n    depends_on: [b]\n  b:\n    task: y\n    depends_on: [a]", "dependency cycle between agents: a, b"},
+		{"both sessions", "agents:\n  a:\n    task: x\n    session: s\n    session_id: sess_1", "either session or session_id"},
+		{"ref to unrelated agent", "agents:\n  a:\n    task: x\n  b:\n    task: \"{{.a.answer}}\"", `"a" is not an agent it depends on`},
+		{"bad template", "agents:\n  a:\n    task: \"{{.x\"", "invalid task template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseComposeFile([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestParseComposeFile_IndirectReference(t *testing.T) {
+	yaml := "agents:\n  a:\n    task: x\n  b:\n    task: y\n    depends_on: [a]\n  c:\n    task: \"{{.a.answer}}\"\n    depends_on: [b]"
+	if _, err := parseComposeFile([]byte(yaml)); err != nil {
+		t.Fatalf("referring to an indirect dependency should be allowed: %v", err)
+	}
+}
+
+func setupComposeTest(t *testing.T) *mockserver.Server {
+	t.Helper()
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+	server := testutil.NewMockServer()
+	t.Cleanup(server.Close)
+	env.SetEnv("NOTTE_API_URL", server.URL())
+
+	origInterval, origKeep, origFormat := agentsComposeInterval, agentsComposeKeepSessions, outputFormat
+	t.Cleanup(func() {
+		agentsComposeInterval, agentsComposeKeepSessions, outputFormat = origInterval, origKeep, origFormat
+	})
+	agentsComposeInterval, agentsComposeKeepSessions, outputFormat = time.Millisecond, false, "json"
+
+	server.AddSequence("/sessions/start",
+		mockserver.JSONResponse(200, `{"session_id":"sess_shop","status":"ACTIVE","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","timeout_minutes":0}`),
+		mockserver.JSONResponse(200, `{"session_id":"sess_own","status":"ACTIVE","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","timeout_minutes":0}`))
+	for _, id := range []string{"sess_shop", "sess_own"} {
+		server.AddResponse("/sessions/"+id+"/stop", 200, `{"session_id":"`+id+`","status":"CLOSED","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","timeout_minutes":0}`)
+	}
+	return server
+}
+
+// addComposeAgent mocks the start and final status of the agent whose task contains task
+func addComposeAgent(server *mockserver.Server, id, task string, success bool, answer string) {
+	server.AddMatchedResponse(mockserver.Match{Path: "/agents/start", BodyContains: task},
+		mockserver.JSONResponse(200, fmt.Sprintf(`{"agent_id":%q,"session_id":"sess","status":"active","created_at":"2020-01-01T00:00:00Z"}`, id)))
+	server.AddResponse("/agents/"+id, 200, fmt.Sprintf(`{"agent_id":%q,"session_id":"sess","status":"closed","task":%q,"success":%t,"answer":%q,"created_at":"2020-01-01T00:00:00Z","steps":[{},{}]}`, id, task, success, answer))
+}
+
+func runComposeTest(t *testing.T, file string) ([]composeState, error) {
+	t.Helper()
+	f, err := parseComposeFile([]byte(file))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client, err := GetClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var runErr error
+	stdout, _ := testutil.CaptureOutput(func() {
+		runErr = newComposeRunner(client, f, 0).run(context.Background())
+	})
+	var states []composeState
+	if err := json.Unmarshal([]byte(stdout), &states); err != nil {
+		t.Fatalf("invalid summary %q: %v", stdout, err)
+	}
+	return states, runErr
+}
+
+func TestComposeRun(t *testing.T) {
+	server := setupComposeTest(t)
+	addComposeAgent(server, "agent_login", "Log in to the shop", true, "")
+	addComposeAgent(server, "agent_orders", "List my orders", true, "mug, lamp")
+	addComposeAgent(server, "agent_summary", "Summarize", true, "2 orders")
+
+	states, err := runComposeTest(t, composeTestFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, s := range states {
+		names = append(names, s.Name)
+		if s.Status != composeSucceeded || s.Steps != 2 {
+			t.Errorf("%s: got status %s after %d steps", s.Name, s.Status, s.Steps)
+		}
+	}
+	if want := []string{"login", "orders", "summary"}; !slices.Equal(names, want) {
+		t.Errorf("summary order = %v, want %v", names, want)
+	}
+
+	// login and orders share the shop session; summary gets its own
+	if n := len(server.Requests("/sessions/start")); n != 2 {
+		t.Errorf("expected 2 sessions started, got %d", n)
+	}
+	starts := server.Requests("/agents/start")
+	if len(starts) != 3 {
+		t.Fatalf("expected 3 agent starts, got %d", len(starts))
+	}
+	for i, want := range []string{`"session_id":"sess_shop"`, `"session_id":"sess_shop"`, `"session_id":"sess_own"`} {
+		if !strings.Contains(starts[i].Body, want) {
+			t.Errorf("start %d: expected %s in %s", i, want, starts[i].Body)
+		}
+	}
+	if !strings.Contains(starts[2].Body, `"task":"Summarize: mug, lamp"`) {
+		t.Errorf("summary task not rendered from orders answer: %s", starts[2].Body)
+	}
+	if !strings.Contains(starts[0].Body, `"vault_id":"vault_1"`) {
+		t.Errorf("agent fields not passed through: %s", starts[0].Body)
+	}
+	for _, id := range []string{"sess_shop", "sess_own"} {
+		if n := len(server.Requests("/sessions/" + id + "/stop")); n != 1 {
+			t.Errorf("expected session %s to be stopped once, got %d", id, n)
+		}
+	}
+}
+
+func TestComposeRun_FailureSkipsDependents(t *testing.T) {
+	server := setupComposeTest(t)
+	agentsComposeKeepSessions = true
+	addComposeAgent(server, "agent_login", "Log in to the shop", false, "wrong password")
+
+	states, err := runComposeTest(t, composeTestFile)
+	if err == nil || err.Error() != "3 of 3 agents did not succeed" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := map[string]string{}
+	for _, s := range states {
+		got[s.Name] = s.Status
+	}
+	want := map[string]string{"login": composeFailed, "orders": composeSkipped, "summary": composeSkipped}
+	for name, status := range want {
+		if got[name] != status {
+			t.Errorf("%s: got %s, want %s", name, got[name], status)
+		}
+	}
+	if n := len(server.Requests("/agents/start")); n != 1 {
+		t.Errorf("expected only login to start, got %d starts", n)
+	}
+	if n := len(server.Requests("/sessions/sess_shop/stop")); n != 0 {
+		t.Errorf("--keep-sessions should leave sessions running, got %d stops", n)
+	}
+}
+
+func TestRunAgentsCompose_DryRun(t *testing.T) {
+	server := setupComposeTest(t)
+	stdin := strings.NewReader(composeTestFile)
+
+	origFile, origDryRun := agentsComposeFile, agentsComposeDryRun
+	t.Cleanup(func() { agentsComposeFile, agentsComposeDryRun = origFile, origDryRun })
+	agentsComposeFile, agentsComposeDryRun = "-", true
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.SetIn(stdin)
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runAgentsCompose(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	want := `[{"name":"login","depends_on":[],"session":"shop"},{"name":"orders","depends_on":["login"],"session":"shop"},{"name":"summary","depends_on":["orders"],"session":"(own session)"}]`
+	if strings.TrimSpace(stdout) != want {
+		t.Errorf("got %s, want %s", stdout, want)
+	}
+	if n := len(server.AllRequests()); n != 0 {
+		t.Errorf("dry run should not call the API, got %d paths", n)
+	}
+}
+
+func TestComposeRun_QueuesOverConcurrencyLimit(t *testing.T) {
+	server := setupComposeTest(t)
+	addComposeAgent(server, "agent_a", "first task", true, "")
+	server.AddMatchedResponse(mockserver.Match{Path: "/agents/start", BodyContains: "second task"},
+		mockserver.RateLimitResponse(0),
+		mockserver.JSONResponse(200, `{"agent_id":"agent_b","session_id":"sess","status":"active","created_at":"2020-01-01T00:00:00Z"}`))
+	server.AddResponse("/agents/agent_b", 200, `{"agent_id":"agent_b","session_id":"sess","status":"closed","task":"second task","success":true,"created_at":"2020-01-01T00:00:00Z","steps":[]}`)
+
+	states, err := runComposeTest(t, "agents:\n  a:\n    task: first task\n  b:\n    task: second task")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, s := range states {
+		if s.Status != composeSucceeded {
+			t.Errorf("%s: got %s (%s)", s.Name, s.Status, s.Error)
+		}
+	}
+	if n := len(server.Requests("/agents/start")); n != 3 {
+		t.Errorf("expected b to be started again once a finished, got %d starts", n)
+	}
+	// b keeps the session started for it while queued
+	if n := len(server.Requests("/sessions/start")); n != 2 {
+		t.Errorf("expected 2 sessions started, got %d", n)
+	}
+}
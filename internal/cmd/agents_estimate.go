@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/output"
+)
+
+var (
+	agentEstimateTask     string
+	agentEstimateSample   int
+	agentEstimateMaxSteps int
+)
+
+// defaultEstimateSample is how many recent agent runs an estimate looks at
+const defaultEstimateSample = 20
+
+// minSimilarRuns is how many similar past tasks an estimate needs before
+// it ignores the other runs
+const minSimilarRuns = 3
+
+// similarTaskThreshold is the word overlap above which two tasks count as similar
+const similarTaskThreshold = 0.25
+
+var agentsEstimateCmd = &cobra.Command{
+	Use:   "estimate",
+	Short: "Estimate how many steps an agent task will take",
+	Long: `Estimate the steps and duration of an agent task from your recent finished
+agent runs. Runs whose task shares enough words with this one are preferred;
+without enough of them, all recent runs are used.
+
+The API doesn't report the cost of a run, so the estimate is in steps and time.
+Set policy.confirm_agent_steps in the config to make agents start ask for
+confirmation when a task is estimated to take more steps than that.`,
+	Example: `  notte agents estimate --task "Find the cheapest flight from Paris to Rome next Friday"
+  notte agents estimate --task "..." --max-steps 15`,
+	Args: cobra.NoArgs,
+	RunE: runAgentEstimate,
+}
+
+func init() {
+	agentsCmd.AddCommand(agentsEstimateCmd)
+
+	agentsEstimateCmd.Flags().StringVar(&agentEstimateTask, "task", "", "The task to estimate")
+	agentsEstimateCmd.Flags().IntVar(&agentEstimateSample, "sample", defaultEstimateSample, "Number of recent agent runs to learn from")
+	agentsEstimateCmd.Flags().IntVar(&agentEstimateMaxSteps, "max-steps", 0, "The step limit the agent would be started with")
+	_ = agentsEstimateCmd.MarkFlagRequired("task")
+}
+
+// agentRun is a finished agent run used as estimation history
+type agentRun struct {
+	Task     string
+	Steps    int
+	Duration time.Duration
+}
+
+// agentEstimate is the expected size of an agent run
+type agentEstimate struct {
+	Steps           int     `json:"steps"`
+	StepsP90        int     `json:"steps_p90"`
+	DurationSeconds float64 `json:"duration_seconds,omitempty"`
+	Runs            int     `json:"runs"`
+	SimilarTasks    bool    `json:"similar_tasks"`
+}
+
+// String describes the estimate in one sentence
+func (e *agentEstimate) String() string {
+	s := fmt.Sprintf("Estimated ~%d steps (up to %d)", e.Steps, e.StepsP90)
+	if e.DurationSeconds > 0 {
+		s += fmt.Sprintf(", about %s", output.FormatDuration(time.Duration(e.DurationSeconds)*time.Second))
+	}
+	basis := "recent runs"
+	if e.SimilarTasks {
+		basis = "similar past tasks"
+	}
+	return s + fmt.Sprintf(" based on %d %s", e.Runs, basis)
+}
+
+// loadAgentHistory returns up to sample recent finished agent runs
+func loadAgentHistory(ctx context.Context, client *api.NotteClient, sample int) ([]agentRun, error) {
+	resp, err := client.Client().ListAgentsWithResponse(ctx, &api.ListAgentsParams{PageSize: &sample})
+	if err != nil {
+		return nil, i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, nil
+	}
+
+	var runs []agentRun
+	for _, item := range resp.JSON200.Items {
+		if item.Status != api.AgentStatusClosed {
+			continue
+		}
+		status, err := client.Client().AgentStatusWithResponse(ctx, item.AgentId, &api.AgentStatusParams{})
+		if err != nil {
+			return nil, i18n.Errorf(i18n.APIRequestFailed, err)
+		}
+		if err := HandleAPIResponse(status.HTTPResponse, status.Body); err != nil {
+			return nil, err
+		}
+		if status.JSON200 == nil || status.JSON200.Steps == nil {
+			continue
+		}
+		run := agentRun{Task: status.JSON200.Task, Steps: len(*status.JSON200.Steps)}
+		if closed := status.JSON200.ClosedAt; closed != nil {
+			var end api.FlexibleTime
+			if json.Unmarshal([]byte(strconv.Quote(*closed)), &end) == nil && end.After(status.JSON200.CreatedAt.Time) {
+				run.Duration = end.Sub(status.JSON200.CreatedAt.Time)
+			}
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// taskWords returns the distinct lowercase words of a task worth comparing
+func taskWords(task string) map[string]bool {
+	words := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(task), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(w) >= 3 {
+			words[w] = true
+		}
+	}
+	return words
+}
+
+// taskSimilarity is the share of words two tasks have in common
+func taskSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for w := range a {
+		if b[w] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []int, p float64) int {
+	rank := int(float64(len(sorted))*p+0.999999) - 1
+	return sorted[max(0, min(rank, len(sorted)-1))]
+}
+
+// estimateAgentRun estimates task from past runs. maxSteps caps the
+// estimate when the agent would be started with a step limit.
+func estimateAgentRun(task string, history []agentRun, maxSteps int) (*agentEstimate, error) {
+	if len(history) == 0 {
+		return nil, errors.New(i18n.T(i18n.NoFinishedAgentRuns))
+	}
+
+	runs, onlySimilar := history, false
+	words := taskWords(task)
+	var similar []agentRun
+	for _, run := range history {
+		if taskSimilarity(words, taskWords(run.Task)) >= similarTaskThreshold {
+			similar = append(similar, run)
+		}
+	}
+	if len(similar) >= minSimilarRuns {
+		runs, onlySimilar = similar, true
+	}
+
+	steps := make([]int, len(runs))
+	var durations []int
+	for i, run := range runs {
+		steps[i] = run.Steps
+		if run.Duration > 0 {
+			durations = append(durations, int(run.Duration.Seconds()))
+		}
+	}
+	slices.Sort(steps)
+	slices.Sort(durations)
+
+	e := &agentEstimate{
+		Steps:        percentile(steps, 0.5),
+		StepsP90:     percentile(steps, 0.9),
+		Runs:         len(runs),
+		SimilarTasks: onlySimilar,
+	}
+	if len(durations) > 0 {
+		e.DurationSeconds = float64(percentile(durations, 0.5))
+	}
+	if maxSteps > 0 {
+		e.Steps = min(e.Steps, maxSteps)
+		e.StepsP90 = min(e.StepsP90, maxSteps)
+	}
+	return e, nil
+}
+
+func runAgentEstimate(cmd *cobra.Command, args []string) error {
+	if agentEstimateSample < 1 {
+		return errors.New(i18n.T(i18n.SampleTooSmall))
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	defer cancel()
+
+	history, err := loadAgentHistory(ctx, client, agentEstimateSample)
+	if err != nil {
+		return err
+	}
+	estimate, err := estimateAgentRun(agentEstimateTask, history, agentEstimateMaxSteps)
+	if err != nil {
+		return err
+	}
+	if IsJSONOutput() {
+		return GetFormatter().Print(estimate)
+	}
+	return PrintResult(estimate.String(), nil)
+}
+
+// checkAgentStepPolicy asks for confirmation before starting an agent that
+// is estimated to exceed policy.confirm_agent_steps. A --max-steps within
+// the limit skips the estimate, and so does a lack of history.
+func checkAgentStepPolicy(ctx context.Context, client *api.NotteClient, body *api.ApiAgentStartRequest) error {
+	policy, err := loadPolicy()
+	if err != nil || policy == nil || policy.ConfirmAgentSteps <= 0 {
+		return err
+	}
+	limit := policy.ConfirmAgentSteps
+	maxSteps := 0
+	if body.MaxSteps != nil {
+		maxSteps = *body.MaxSteps
+		if maxSteps > 0 && maxSteps <= limit {
+			return nil
+		}
+	}
+
+	ctx, cancel := GetContextWithTimeout(ctx)
+	defer cancel()
+	history, err := loadAgentHistory(ctx, client, defaultEstimateSample)
+	var estimate *agentEstimate
+	if err == nil {
+		estimate, err = estimateAgentRun(body.Task, history, maxSteps)
+	}
+	if err != nil {
+		PrintInfo(i18n.T(i18n.WarningCouldNotEstimateSteps, err))
+		return nil
+	}
+	if estimate.Steps <= limit {
+		return nil
+	}
+
+	confirmed, err := confirmAgentSteps(estimate.String(), limit)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return i18n.Errorf(i18n.AgentStepsOverPolicy, estimate.Steps, limit, limit)
+	}
+	return nil
+}
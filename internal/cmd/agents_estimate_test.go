@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func TestEstimateAgentRun(t *testing.T) {
+	history := []agentRun{
+		{Task: "Find the cheapest flight from Paris to Rome", Steps: 20, Duration: 3 * time.Minute},
+		{Task: "Find the cheapest flight from Berlin to Rome", Steps: 24, Duration: 4 * time.Minute},
+		{Task: "find cheapest flight from Paris to Madrid", Steps: 30},
+		{Task: "Log in and download the invoice", Steps: 4, Duration: 30 * time.Second},
+		{Task: "Scrape the pricing page", Steps: 2, Duration: 20 * time.Second},
+	}
+
+	e, err := estimateAgentRun("Find the cheapest flight from Paris to Lisbon", history, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Steps != 24 || e.StepsP90 != 30 || e.Runs != 3 || !e.SimilarTasks || e.DurationSeconds != 180 {
+		t.Errorf("unexpected estimate from similar tasks: %+v", e)
+	}
+	if got := e.String(); got != "Estimated ~24 steps (up to 30), about 3m based on 3 similar past tasks" {
+		t.Errorf("String() = %q", got)
+	}
+
+	e, err = estimateAgentRun("Book a table for two", history, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Steps != 10 || e.StepsP90 != 10 || e.Runs != 5 || e.SimilarTasks {
+		t.Errorf("unexpected capped estimate from all runs: %+v", e)
+	}
+
+	if _, err := estimateAgentRun("anything", nil, 0); err == nil {
+		t.Error("expected an error without history")
+	}
+}
+
+// addAgentHistory serves a list of closed agents that each took steps
+func addAgentHistory(server *testutil.MockServer, tasks []string, steps []int) {
+	var items []string
+	for i, task := range tasks {
+		id := fmt.Sprintf("agent_h%d", i)
+		items = append(items, fmt.Sprintf(`{"agent_id":%q,"session_id":"sess_1","status":"closed","created_at":"2024-01-01T00:00:00Z"}`, id))
+		stepList := strings.TrimSuffix(strings.Repeat(`{"type":"click"},`, steps[i]), ",")
+		server.AddResponse("/agents/"+id, 200, fmt.Sprintf(`{"agent_id":%q,"session_id":"sess_1","status":"closed","task":%q,"created_at":"2024-01-01T00:00:00Z","closed_at":"2024-01-01T00:01:00Z","steps":[%s]}`, id, task, stepList))
+	}
+	items = append(items, `{"agent_id":"agent_live","session_id":"sess_2","status":"active","created_at":"2024-01-01T00:00:00Z"}`)
+	server.AddResponse("/agents", 200, `{"items":[`+strings.Join(items, ",")+`],"page":1,"page_size":20,"has_next":false}`)
+}
+
+func TestRunAgentEstimate(t *testing.T) {
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+	server := testutil.NewMockServer()
+	defer server.Close()
+	env.SetEnv("NOTTE_API_URL", server.URL())
+	addAgentHistory(server, []string{"search flights", "search hotels"}, []int{3, 5})
+
+	origTask, origSample, origFormat := agentEstimateTask, agentEstimateSample, outputFormat
+	t.Cleanup(func() { agentEstimateTask, agentEstimateSample, outputFormat = origTask, origSample, origFormat })
+	agentEstimateTask, agentEstimateSample, outputFormat = "search cars", defaultEstimateSample, "json"
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runAgentEstimate(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if want := `{"steps":3,"steps_p90":5,"duration_seconds":60,"runs":2,"similar_tasks":false}`; strings.TrimSpace(stdout) != want {
+		t.Errorf("got %s, want %s", stdout, want)
+	}
+	if n := len(server.Requests("/agents/agent_live")); n != 0 {
+		t.Errorf("active agents should not be fetched, got %d requests", n)
+	}
+}
+
+func TestCheckAgentStepPolicy(t *testing.T) {
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+	server := testutil.NewMockServer()
+	defer server.Close()
+	env.SetEnv("NOTTE_API_URL", server.URL())
+	addAgentHistory(server, []string{"checkout cart", "checkout cart again", "checkout cart now"}, []int{40, 50, 60})
+	setTestPolicy(t, &config.PolicyConfig{ConfirmAgentSteps: 30})
+
+	client, err := GetClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	capped := 20
+	if err := checkAgentStepPolicy(ctx, client, &api.ApiAgentStartRequest{Task: "checkout cart", MaxSteps: &capped}); err != nil {
+		t.Errorf("a --max-steps within the limit should pass: %v", err)
+	}
+	if n := len(server.Requests("/agents")); n != 0 {
+		t.Errorf("expected no estimate with --max-steps within the limit, got %d list requests", n)
+	}
+
+	SetSkipConfirmation(true)
+	t.Cleanup(func() { SetSkipConfirmation(false) })
+	for _, answer := range []string{"n\n", "y\n"} {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("failed to create pipe: %v", err)
+		}
+		_, _ = w.WriteString(answer)
+		_ = w.Close()
+		origStdin := os.Stdin
+		os.Stdin = r
+
+		_, stderr := testutil.CaptureOutput(func() {
+			err = checkAgentStepPolicy(ctx, client, &api.ApiAgentStartRequest{Task: "checkout cart"})
+		})
+		os.Stdin = origStdin
+		_ = r.Close()
+
+		if !strings.Contains(stderr, "Estimated ~50 steps (up to 60)") {
+			t.Errorf("unexpected prompt: %q", stderr)
+		}
+		if declined := answer == "n\n"; declined != (err != nil) {
+			t.Errorf("answer %q: unexpected error %v (--yes must not skip the policy)", answer, err)
+		}
+	}
+	if n := len(server.Requests("/agents")); n != 2 {
+		t.Errorf("expected the history to be listed twice, got %d", n)
+	}
+}
+
+func TestConfirmAgentStepsWithIO(t *testing.T) {
+	for in, want := range map[string]bool{"y\n": true, "yes\n": true, "\n": false, "n\n": false} {
+		var out bytes.Buffer
+		got, err := confirmAgentStepsWithIO(strings.NewReader(in), &out, "Estimated ~50 steps", 30)
+		if err != nil || got != want {
+			t.Errorf("input %q: got %v (%v), want %v", in, got, err, want)
+		}
+		if !strings.Contains(out.String(), "above the policy limit of 30 steps") {
+			t.Errorf("unexpected prompt: %q", out.String())
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	agentExportOutput string
+	agentExportZip    bool
+)
+
+var agentsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Bundle the agent's status, steps, replay, and workflow code",
+	Long: `Collect everything about an agent run into one directory (or zip) for
+attaching to bug reports.
+
+The bundle contains:
+  status.json             Agent status as returned by the API
+  steps.json              Step log
+  workflow.py             Workflow code generated from the steps
+  workflow_actions.json   The same steps as JSON actions
+  replay.json             Replay metadata
+  replay.mp4              Replay video
+  screenshot.jpg          Final page screenshot (only while the session is open)
+  manifest.json           What was exported, and why anything is missing
+
+Only the agent status is required; artifacts that can't be fetched are
+listed under "errors" in manifest.json.`,
+	Example: `  notte agents export
+  notte agents export --agent-id agent_123 --path bug-report/
+  notte agents export --zip`,
+	Args: cobra.NoArgs,
+	RunE: runAgentExport,
+}
+
+func init() {
+	agentsCmd.AddCommand(agentsExportCmd)
+
+	addAgentIDFlag(agentsExportCmd)
+	agentsExportCmd.Flags().StringVar(&agentExportOutput, "path", "", "Output directory, or zip file with --zip (default: notte-agent-<agent-id>)")
+	agentsExportCmd.Flags().BoolVar(&agentExportZip, "zip", false, "Write a zip archive instead of a directory")
+	addStdinIDsFlag(agentsExportCmd, "agent-id")
+	agentsExportCmd.MarkFlagsMutuallyExclusive(stdinIDsFlag, "path")
+}
+
+func runAgentExport(cmd *cobra.Command, args []string) error {
+	agentID, err := RequireAgentID(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	defer cancel()
+
+	statusResp, err := client.Client().AgentStatusWithResponse(ctx, agentID, &api.AgentStatusParams{})
+	if err != nil {
+		return i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	if err := HandleAPIResponse(statusResp.HTTPResponse, statusResp.Body); err != nil {
+		return err
+	}
+	if statusResp.JSON200 == nil {
+		return i18n.Errorf(i18n.UnexpectedEmptyAgentStatus)
+	}
+	status := statusResp.JSON200
+
+	bundle := newExportBundle()
+	bundle.addJSON("status.json", status)
+
+	steps := []map[string]interface{}{}
+	if status.Steps != nil {
+		steps = *status.Steps
+	}
+	bundle.addJSON("steps.json", steps)
+
+	params := &api.GetScriptParams{
+		AsWorkflow:          true,
+		InferResponseFormat: boolPtr(true),
+	}
+	var code *api.AgentFunctionCodeResponse
+	codeResp, err := client.Client().GetScriptWithResponse(ctx, agentID, params)
+	if err == nil {
+		err = HandleAPIResponse(codeResp.HTTPResponse, codeResp.Body)
+		code = codeResp.JSON200
+	}
+	bundle.addWorkflowCode(code, err)
+
+	if status.SessionId == "" {
+		bundle.fail("replay.json", i18n.Errorf(i18n.AgentHasNoAssociatedSession))
+	} else {
+		exportReplay(ctx, client, status.SessionId, bundle)
+		exportScreenshot(ctx, client, status.SessionId, bundle)
+	}
+
+	output := agentExportOutput
+	if output == "" {
+		output = "notte-agent-" + agentID
+	}
+	output, files, err := bundle.write(ctx, output, agentExportZip, map[string]any{
+		"agent_id":   agentID,
+		"session_id": status.SessionId,
+		"status":     status.Status,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printExportResult("agent", agentID, output, files, bundle)
+}
@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	agentsRerunSessionID string
+	agentsRerunDryRun    bool
+)
+
+// agentRunsLimit caps how many agent runs are remembered
+const agentRunsLimit = 50
+
+var agentsRerunCmd = &cobra.Command{
+	Use:   "rerun [agent-id]",
+	Short: "Start a new agent with the same parameters as a previous one",
+	Long: `Start a new agent with the task, model and other start parameters of an agent
+started earlier from this machine (the current agent if none is given).
+
+Every agent started by the CLI has its resolved start request, and the settings
+of the session it ran on, recorded in ~/.notte/cli/agent_runs.json (the last 50
+runs). The new agent runs on a new session with the recorded settings, which
+becomes the current session, unless --session-id is given.
+
+Session settings are exact for sessions the CLI started for an agent and for
+the session of the last 'notte sessions start' (less flags that can hold
+secrets). Others are read back from the session's status, which misses some
+settings; --dry-run warns when that is the case.`,
+	Example: `  notte agents rerun
+  notte agents rerun agent_123 --dry-run
+  notte agents rerun agent_123 --session-id sess_456`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeIDsFromHistory(idKindAgent),
+	RunE:              runAgentsRerun,
+}
+
+func init() {
+	agentsCmd.AddCommand(agentsRerunCmd)
+
+	agentsRerunCmd.Flags().StringVar(&agentsRerunSessionID, "session-id", "", "Run on this session instead of a new one with the recorded settings")
+	agentsRerunCmd.Flags().BoolVar(&agentsRerunDryRun, "dry-run", false, "Print the recorded parameters without starting anything")
+	_ = agentsRerunCmd.RegisterFlagCompletionFunc("session-id", completeIDsFromHistory(idKindSession))
+}
+
+// agentRunRecord is the resolved start request of an agent started by the CLI
+type agentRunRecord struct {
+	AgentID   string                   `json:"agent_id"`
+	StartedAt time.Time                `json:"started_at"`
+	Request   api.ApiAgentStartRequest `json:"request"`
+	// Session holds the settings of the session the agent ran on, when known
+	Session *api.ApiSessionStartRequest `json:"session,omitempty"`
+	// SessionReconstructed is set when Session was read back from the
+	// session's status rather than taken from the request that started it
+	SessionReconstructed bool `json:"session_reconstructed,omitempty"`
+}
+
+func agentRunsPath() (string, error) {
+	configDir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, config.AgentRunsFile), nil
+}
+
+// loadAgentRuns returns the recorded agent runs, most recent first
+func loadAgentRuns() ([]agentRunRecord, error) {
+	path, err := agentRunsPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var runs []agentRunRecord
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, i18n.Errorf(i18n.FailedToParse, path, err)
+	}
+	return runs, nil
+}
+
+// findAgentRun returns the recorded run of agentID
+func findAgentRun(agentID string) (*agentRunRecord, error) {
+	runs, err := loadAgentRuns()
+	if err != nil {
+		return nil, err
+	}
+	for i := range runs {
+		if runs[i].AgentID == agentID {
+			return &runs[i], nil
+		}
+	}
+	return nil, i18n.Errorf(i18n.NoAgentStartRecorded, agentID, agentRunsLimit)
+}
+
+// recordAgentRun remembers the request an agent was started with
+func recordAgentRun(agentID string, body *api.ApiAgentStartRequest, session *sessionStart) error {
+	path, err := agentRunsPath()
+	if err != nil {
+		return err
+	}
+	runs, err := loadAgentRuns()
+	if err != nil {
+		// Start over rather than failing every agent start on a bad file
+		runs = nil
+	}
+	runs = slices.DeleteFunc(runs, func(r agentRunRecord) bool { return r.AgentID == agentID })
+	run := agentRunRecord{AgentID: agentID, StartedAt: time.Now().UTC(), Request: *body}
+	if session != nil {
+		run.Session, run.SessionReconstructed = session.Request, session.Reconstructed
+	}
+	runs = append([]agentRunRecord{run}, runs...)
+	if len(runs) > agentRunsLimit {
+		runs = runs[:agentRunsLimit]
+	}
+
+	data, err := json.MarshalIndent(runs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// sessionStart is the start request of the session an agent runs on
+type sessionStart struct {
+	Request *api.ApiSessionStartRequest
+	// Reconstructed is set when Request was read back from the session's
+	// status, which doesn't report every setting
+	Reconstructed bool
+}
+
+// sentSessionStart wraps a session start request the CLI sent itself
+func sentSessionStart(body *api.ApiSessionStartRequest) *sessionStart {
+	return &sessionStart{Request: body}
+}
+
+// sessionSettings returns the start settings of a session. A session from
+// the last sessions start gets the request rebuilt from its recorded flags;
+// any other has its settings read back from its status. It returns nil if
+// neither is possible.
+func sessionSettings(ctx context.Context, client *api.NotteClient, sessionID string) *sessionStart {
+	if last, err := loadLastSessionStart(); err == nil && last.SessionID == sessionID {
+		if body, err := sessionStartFromFlags(last.Flags); err == nil {
+			return sentSessionStart(body)
+		}
+	}
+
+	ctx, cancel := GetContextWithTimeout(ctx)
+	defer cancel()
+	resp, err := client.Client().SessionStatusWithResponse(ctx, sessionID, &api.SessionStatusParams{})
+	if err != nil || HandleAPIResponse(resp.HTTPResponse, resp.Body) != nil || resp.JSON200 == nil {
+		return nil
+	}
+	body, err := cloneStartRequest(resp.JSON200)
+	if err != nil {
+		return nil
+	}
+	return &sessionStart{Request: &body, Reconstructed: true}
+}
+
+// sessionStartFromFlags builds the session start request that sessions start
+// sends for flags, on a command of its own
+func sessionStartFromFlags(flags map[string]string) (*api.ApiSessionStartRequest, error) {
+	cmd := &cobra.Command{}
+	registerSessionsStartFlags(cmd)
+	// Registering binds the same variables as sessions start; registering
+	// once more afterwards puts them back to their defaults
+	defer registerSessionsStartFlags(&cobra.Command{})
+	for _, name := range sortedFlagNames(flags) {
+		if err := cmd.Flags().Set(name, flags[name]); err != nil {
+			return nil, err
+		}
+	}
+	return buildSessionsStartRequest(cmd)
+}
+
+// rememberAgentRun records a started agent, warning rather than failing
+func rememberAgentRun(agentID string, body *api.ApiAgentStartRequest, session *sessionStart) {
+	if err := recordAgentRun(agentID, body, session); err != nil {
+		PrintInfo(i18n.T(i18n.WarningCouldNotRecordAgentStart, err))
+	}
+}
+
+func runAgentsRerun(cmd *cobra.Command, args []string) error {
+	var agentID string
+	if len(args) > 0 {
+		agentID = args[0]
+		recordIDUse(idKindAgent, agentID)
+	} else {
+		id, err := RequireAgentID(cmd)
+		if err != nil {
+			return err
+		}
+		agentID = id
+	}
+
+	run, err := findAgentRun(agentID)
+	if err != nil {
+		return err
+	}
+	if agentsRerunDryRun {
+		if run.SessionReconstructed {
+			PrintInfo(i18n.T(i18n.WarningSessionSettingsReconstructed, agentID))
+		}
+		return GetFormatter().Print(run)
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+	body := run.Request
+	if err := checkAgentStepPolicy(cmd.Context(), client, &body); err != nil {
+		return err
+	}
+
+	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	defer cancel()
+
+	// A new session with the recorded settings, unless --session-id is given
+	var session *sessionStart
+	var newSession *api.SessionResponse
+	if agentsRerunSessionID != "" {
+		body.SessionId = agentsRerunSessionID
+		session = sessionSettings(cmd.Context(), client, agentsRerunSessionID)
+	} else {
+		settings := run.Session
+		if settings == nil {
+			PrintInfo(i18n.T(i18n.NoSessionSettingsRecorded, agentID))
+			settings = &api.ApiSessionStartRequest{}
+		}
+		// The new session is started with exactly these settings, so they
+		// are no longer a reconstruction for the rerun's record
+		session = sentSessionStart(settings)
+		resp, err := client.Client().SessionStartWithResponse(ctx, &api.SessionStartParams{}, *settings)
+		if err != nil {
+			return i18n.Errorf(i18n.APIRequestFailed, err)
+		}
+		if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return i18n.Errorf(i18n.EmptySessionStartResponse)
+		}
+		newSession = resp.JSON200
+		body.SessionId = newSession.SessionId
+	}
+
+	resp, err := client.Client().AgentStartWithResponse(ctx, &api.AgentStartParams{}, body)
+	if err == nil {
+		err = HandleAPIResponse(resp.HTTPResponse, resp.Body)
+	}
+	if err == nil && resp.JSON200 == nil {
+		err = i18n.Errorf(i18n.EmptyAgentStartResponse)
+	}
+	if err != nil {
+		if newSession != nil {
+			// Don't leave the session started for the agent running
+			stopCtx, stopCancel := GetContextWithTimeout(context.Background())
+			_, _ = client.Client().SessionStopWithResponse(stopCtx, newSession.SessionId, &api.SessionStopParams{})
+			stopCancel()
+		}
+		return err
+	}
+
+	if newSession != nil {
+		rememberStartedSession(newSession)
+		recordIDUse(idKindSession, newSession.SessionId)
+	}
+
+	if err := setCurrentAgent(resp.JSON200.AgentId); err != nil {
+		PrintInfo(i18n.T(i18n.WarningCouldNotSaveCurrentAgent, err))
+	}
+	rememberAgentRun(resp.JSON200.AgentId, &body, session)
+	PrintInfo(i18n.T(i18n.RerunningAgentAs, agentID, resp.JSON200.AgentId))
+	return GetFormatter().Print(resp.JSON200)
+}
@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func TestRecordAgentRun(t *testing.T) {
+	env := testutil.SetupTestEnv(t)
+	config.SetTestConfigDir(env.TempDir)
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+
+	for i := range agentRunsLimit + 5 {
+		if err := recordAgentRun(fmt.Sprintf("agent_%d", i), &api.ApiAgentStartRequest{Task: "task"}, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	headless := true
+	if err := recordAgentRun("agent_3", &api.ApiAgentStartRequest{Task: "again"}, sentSessionStart(&api.ApiSessionStartRequest{Headless: &headless})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	runs, err := loadAgentRuns()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runs) != agentRunsLimit {
+		t.Errorf("expected %d runs, got %d", agentRunsLimit, len(runs))
+	}
+	if runs[0].AgentID != "agent_3" || runs[0].Request.Task != "again" {
+		t.Errorf("expected the rerecorded agent first, got %+v", runs[0])
+	}
+	for _, r := range runs[1:] {
+		if r.AgentID == "agent_3" {
+			t.Error("agent_3 should only be recorded once")
+		}
+	}
+
+	run, err := findAgentRun("agent_3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.Session == nil || run.Session.Headless == nil || !*run.Session.Headless {
+		t.Errorf("session settings not kept: %+v", run.Session)
+	}
+	if _, err := findAgentRun("agent_0"); err == nil || !strings.Contains(err.Error(), "no start parameters recorded") {
+		t.Errorf("expected the oldest run to be dropped, got %v", err)
+	}
+}
+
+func setupAgentsStartTest(t *testing.T) *testutil.MockServer {
+	t.Helper()
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+	config.SetTestConfigDir(env.TempDir)
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+	server := testutil.NewMockServer()
+	t.Cleanup(server.Close)
+	env.SetEnv("NOTTE_API_URL", server.URL())
+
+	server.AddResponse("/agents/start", 200, `{"agent_id":"agent_1","session_id":"sess_123","status":"RUNNING","created_at":"2020-01-01T00:00:00Z"}`)
+
+	origTask, origSession, origModel, origMaxSteps, origFormat := AgentStartTask, AgentStartSessionId, AgentStartReasoningModel, AgentStartMaxSteps, outputFormat
+	t.Cleanup(func() {
+		AgentStartTask, AgentStartSessionId, AgentStartReasoningModel, AgentStartMaxSteps, outputFormat = origTask, origSession, origModel, origMaxSteps, origFormat
+	})
+	outputFormat = "json"
+	return server
+}
+
+// startRecordedAgent runs agents start on sessionIDTest and returns its record
+func startRecordedAgent(t *testing.T) *agentRunRecord {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	RegisterAgentStartFlags(cmd)
+	_ = cmd.Flags().Set("task", "find the cheapest mug")
+	_ = cmd.Flags().Set("session-id", sessionIDTest)
+	_ = cmd.Flags().Set("reasoning-model", "custom-model")
+	_, _ = testutil.CaptureOutput(func() {
+		if err := runAgentsStart(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	run, err := findAgentRun("agent_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return run
+}
+
+func TestRunAgentsStart_RecordsRun(t *testing.T) {
+	server := setupAgentsStartTest(t)
+	server.AddResponse("/sessions/"+sessionIDTest, 200, `{"session_id":"sess_123","status":"ACTIVE","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","timeout_minutes":0,"headless":true,"viewport_width":1280}`)
+
+	run := startRecordedAgent(t)
+	if run.Request.Task != "find the cheapest mug" || run.Request.SessionId != sessionIDTest {
+		t.Errorf("unexpected recorded request: %+v", run.Request)
+	}
+	if data, _ := json.Marshal(run.Request); !strings.Contains(string(data), `"reasoning_model":"custom-model"`) {
+		t.Errorf("reasoning model not recorded: %s", data)
+	}
+	if run.Session == nil || run.Session.Headless == nil || !*run.Session.Headless || run.Session.ViewportWidth == nil || *run.Session.ViewportWidth != 1280 {
+		t.Errorf("session settings not recorded: %+v", run.Session)
+	}
+	if !run.SessionReconstructed {
+		t.Error("settings read from the session's status should be marked as reconstructed")
+	}
+}
+
+func TestRunAgentsStart_RecordsLastSessionStart(t *testing.T) {
+	setupAgentsStartTest(t)
+
+	start := &cobra.Command{}
+	registerSessionsStartFlags(start)
+	t.Cleanup(func() { registerSessionsStartFlags(&cobra.Command{}) })
+	_ = start.Flags().Set("headless", "false")
+	_ = start.Flags().Set("proxy-country", "fr")
+	_ = start.Flags().Set("extra-http-headers", `{"Authorization": "Bearer secret"}`)
+	if err := recordLastSessionStart(start, sessionIDTest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	run := startRecordedAgent(t)
+	if run.SessionReconstructed {
+		t.Error("settings rebuilt from the sessions start flags are not a reconstruction")
+	}
+	data, _ := json.Marshal(run.Session)
+	for _, want := range []string{`"headless":false`, `"country":"fr"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in %s", want, data)
+		}
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("extra HTTP headers should not be recorded: %s", data)
+	}
+}
+
+func setupAgentsRerunTest(t *testing.T) *testutil.MockServer {
+	t.Helper()
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+	config.SetTestConfigDir(env.TempDir)
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+	server := testutil.NewMockServer()
+	t.Cleanup(server.Close)
+	env.SetEnv("NOTTE_API_URL", server.URL())
+
+	origSession, origDryRun, origFormat := agentsRerunSessionID, agentsRerunDryRun, outputFormat
+	t.Cleanup(func() { agentsRerunSessionID, agentsRerunDryRun, outputFormat = origSession, origDryRun, origFormat })
+	agentsRerunSessionID, agentsRerunDryRun, outputFormat = "", false, "json"
+
+	maxSteps, headless := 7, true
+	if err := recordAgentRun("agent_old", &api.ApiAgentStartRequest{Task: "find the cheapest mug", SessionId: "sess_old", MaxSteps: &maxSteps},
+		sentSessionStart(&api.ApiSessionStartRequest{Headless: &headless})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return server
+}
+
+func TestRunAgentsRerun(t *testing.T) {
+	server := setupAgentsRerunTest(t)
+	server.AddResponse("/sessions/start", 200, `{"session_id":"sess_new","status":"ACTIVE","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","timeout_minutes":0}`)
+	server.AddResponse("/agents/start", 200, `{"agent_id":"agent_new","session_id":"sess_new","status":"RUNNING","created_at":"2020-01-01T00:00:00Z"}`)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	_, stderr := testutil.CaptureOutput(func() {
+		if err := runAgentsRerun(cmd, []string{"agent_old"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "Rerunning agent agent_old as agent_new") {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+
+	sessions := server.Requests("/sessions/start")
+	if len(sessions) != 1 || !strings.Contains(sessions[0].Body, `"headless":true`) {
+		t.Errorf("expected a session with the recorded settings, got %+v", sessions)
+	}
+	starts := server.Requests("/agents/start")
+	if len(starts) != 1 {
+		t.Fatalf("expected 1 agent start, got %d", len(starts))
+	}
+	for _, want := range []string{`"task":"find the cheapest mug"`, `"max_steps":7`, `"session_id":"sess_new"`} {
+		if !strings.Contains(starts[0].Body, want) {
+			t.Errorf("expected %s in %s", want, starts[0].Body)
+		}
+	}
+	if got := GetCurrentAgentID(cmd); got != "agent_new" {
+		t.Errorf("current agent = %q, want agent_new", got)
+	}
+	if got := GetCurrentSessionID(cmd); got != "sess_new" {
+		t.Errorf("current session = %q, want sess_new", got)
+	}
+	if _, err := findAgentRun("agent_new"); err != nil {
+		t.Errorf("the rerun should be recorded too: %v", err)
+	}
+}
+
+func TestRunAgentsRerun_SessionID(t *testing.T) {
+	server := setupAgentsRerunTest(t)
+	server.AddResponse("/agents/start", 200, `{"agent_id":"agent_new","session_id":"sess_mine","status":"RUNNING","created_at":"2020-01-01T00:00:00Z"}`)
+	agentsRerunSessionID = "sess_mine"
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	_, _ = testutil.CaptureOutput(func() {
+		if err := runAgentsRerun(cmd, []string{"agent_old"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if n := len(server.Requests("/sessions/start")); n != 0 {
+		t.Errorf("no session should be started with --session-id, got %d", n)
+	}
+	starts := server.Requests("/agents/start")
+	if len(starts) != 1 || !strings.Contains(starts[0].Body, `"session_id":"sess_mine"`) {
+		t.Errorf("expected the agent on sess_mine, got %+v", starts)
+	}
+}
+
+func TestRunAgentsRerun_StartFailureStopsSession(t *testing.T) {
+	server := setupAgentsRerunTest(t)
+	server.AddResponse("/sessions/start", 200, `{"session_id":"sess_new","status":"ACTIVE","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","timeout_minutes":0}`)
+	server.AddResponse("/agents/start", 500, `{"detail":"boom"}`)
+	server.AddResponse("/sessions/sess_new/stop", 200, `{"session_id":"sess_new","status":"CLOSED","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","timeout_minutes":0}`)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	_, _ = testutil.CaptureOutput(func() {
+		if err := runAgentsRerun(cmd, []string{"agent_old"}); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+	if n := len(server.Requests("/sessions/sess_new/stop")); n != 1 {
+		t.Errorf("expected the new session to be stopped, got %d stops", n)
+	}
+	if got := GetCurrentSessionID(cmd); got == "sess_new" {
+		t.Error("the stopped session should not become the current session")
+	}
+}
+
+func TestRunAgentsRerun_DryRun(t *testing.T) {
+	server := setupAgentsRerunTest(t)
+	agentsRerunDryRun = true
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runAgentsRerun(cmd, []string{"agent_old"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{`"agent_id":"agent_old"`, `"task":"find the cheapest mug"`, `"headless":true`} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %s in %s", want, stdout)
+		}
+	}
+	if n := len(server.AllRequests()); n != 0 {
+		t.Errorf("dry run should not call the API, got %d paths", n)
+	}
+}
+
+func TestRunAgentsRerun_DryRunWarnsReconstructed(t *testing.T) {
+	setupAgentsRerunTest(t)
+	agentsRerunDryRun = true
+	if err := recordAgentRun("agent_old", &api.ApiAgentStartRequest{Task: "find the cheapest mug"},
+		&sessionStart{Request: &api.ApiSessionStartRequest{}, Reconstructed: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	_, stderr := testutil.CaptureOutput(func() {
+		if err := runAgentsRerun(cmd, []string{"agent_old"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "session settings of agent agent_old were read back") {
+		t.Errorf("expected a warning about reconstructed settings, got %q", stderr)
+	}
+}
+
+func TestRunAgentsRerun_NotRecorded(t *testing.T) {
+	setupAgentsRerunTest(t)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	err := runAgentsRerun(cmd, []string{"agent_unknown"})
+	if err == nil || !strings.Contains(err.Error(), "no start parameters recorded for agent agent_unknown") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/output"
+	"github.com/nottelabs/notte-cli/internal/validate"
+)
+
+var (
+	agentStepsStep int
+	agentStepsFull bool
+)
+
+// maxStepTarget bounds how much of a step's target the list shows
+const maxStepTarget = 60
+
+var agentsStepsCmd = &cobra.Command{
+	Use:   "steps [agent-id]",
+	Short: "List an agent's steps, or show one of them",
+	Long: `List the steps of an agent (the current agent if none is given) with their
+action, target, duration and outcome.
+
+With --step N, print step N as the API returned it. Screenshots are left out
+unless --full is given, as they are large base64 images.`,
+	Example: `  notte agents steps
+  notte agents steps agent_123
+  notte agents steps --step 4 --full > step4.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAgentSteps,
+}
+
+func init() {
+	agentsCmd.AddCommand(agentsStepsCmd)
+
+	addAgentIDFlag(agentsStepsCmd)
+	agentsStepsCmd.Flags().IntVar(&agentStepsStep, "step", 0, "Show only this step (numbered from 1, as in the list)")
+	agentsStepsCmd.Flags().BoolVar(&agentStepsFull, "full", false, "With --step, include screenshots")
+}
+
+// agentStepSummary is one line of the step list
+type agentStepSummary struct {
+	Step       int    `json:"step"`
+	Action     string `json:"action"`
+	Target     string `json:"target,omitempty"`
+	DurationMs *int64 `json:"duration_ms,omitempty"`
+	Success    *bool  `json:"success,omitempty"`
+}
+
+func runAgentSteps(cmd *cobra.Command, args []string) error {
+	var agentID string
+	if len(args) > 0 {
+		if err := validate.AgentID(args[0]); err != nil {
+			return err
+		}
+		agentID = args[0]
+		recordIDUse(idKindAgent, agentID)
+	} else {
+		id, err := RequireAgentID(cmd)
+		if err != nil {
+			return err
+		}
+		agentID = id
+	}
+	if agentStepsFull && !cmd.Flags().Changed("step") {
+		return i18n.Errorf(i18n.FullNeedsStep)
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	defer cancel()
+
+	resp, err := client.Client().AgentStatusWithResponse(ctx, agentID, &api.AgentStatusParams{})
+	if err != nil {
+		return i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return i18n.Errorf(i18n.UnexpectedEmptyAgentStatus)
+	}
+	var steps []map[string]any
+	if resp.JSON200.Steps != nil {
+		steps = *resp.JSON200.Steps
+	}
+
+	if cmd.Flags().Changed("step") {
+		if agentStepsStep < 1 || agentStepsStep > len(steps) {
+			return i18n.Errorf(i18n.NoSuchAgentStep, agentID, len(steps), agentStepsStep)
+		}
+		var step any = steps[agentStepsStep-1]
+		if !agentStepsFull {
+			step = withoutScreenshots(step)
+		}
+		if IsJSONOutput() {
+			return GetFormatter().Print(step)
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(step)
+	}
+
+	summaries := make([]agentStepSummary, len(steps))
+	for i, step := range steps {
+		summaries[i] = summarizeAgentStep(i+1, step)
+	}
+	if IsJSONOutput() {
+		return GetFormatter().Print(summaries)
+	}
+	if len(summaries) == 0 {
+		PrintInfo(i18n.T(i18n.AgentHasNoStepsYet, agentID))
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "STEP\tACTION\tTARGET\tDURATION\tSUCCESS")
+	for _, s := range summaries {
+		duration, success := "-", "-"
+		if s.DurationMs != nil {
+			duration = output.FormatDuration(time.Duration(*s.DurationMs) * time.Millisecond)
+		}
+		if s.Success != nil {
+			success = fmt.Sprintf("%t", *s.Success)
+		}
+		target := s.Target
+		if target == "" {
+			target = "-"
+		}
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", s.Step, s.Action, truncate(target, maxStepTarget), duration, success)
+	}
+	return tw.Flush()
+}
+
+// summarizeAgentStep reads the list columns out of a step. Steps are
+// {"type": ..., "value": {...}}: execution results hold the action and its
+// outcome, observations the page they saw. Anything else is shown by type.
+func summarizeAgentStep(n int, step map[string]any) agentStepSummary {
+	s := agentStepSummary{Step: n}
+	stepType, _ := step["type"].(string)
+	value, ok := step["value"].(map[string]any)
+	if !ok {
+		value = step
+	}
+
+	switch stepType {
+	case "execution_result":
+		action, _ := value["action"].(map[string]any)
+		s.Action, _ = action["type"].(string)
+		s.Target = actionTarget(action)
+	case "observation":
+		s.Action = "observation"
+		if metadata, ok := value["metadata"].(map[string]any); ok {
+			s.Target, _ = metadata["url"].(string)
+		}
+	default:
+		s.Action = stepType
+		s.Target = actionTarget(value)
+	}
+	if s.Action == "" {
+		s.Action = "unknown"
+	}
+	if success, ok := value["success"].(bool); ok {
+		s.Success = &success
+	}
+	if d, ok := stepDuration(step, value); ok {
+		ms := d.Milliseconds()
+		s.DurationMs = &ms
+	}
+	return s
+}
+
+// actionTarget is what an action acts on: the URL it opens, or the element
+// it interacts with
+func actionTarget(action map[string]any) string {
+	for _, key := range []string{"url", "id", "selector"} {
+		if target, ok := action[key].(string); ok && target != "" {
+			return target
+		}
+	}
+	return ""
+}
+
+// stepDuration is how long a step took, from its started_at and ended_at
+// or its duration (ISO 8601 or seconds), looked up in the step then in its
+// value
+func stepDuration(maps ...map[string]any) (time.Duration, bool) {
+	for _, m := range maps {
+		start, startOK := m["started_at"].(string)
+		end, endOK := m["ended_at"].(string)
+		if startOK && endOK {
+			s, ok1 := output.ParseTime(start)
+			e, ok2 := output.ParseTime(end)
+			if ok1 && ok2 && !e.Before(s) {
+				return e.Sub(s), true
+			}
+		}
+		switch d := m["duration"].(type) {
+		case string:
+			if parsed, ok := output.ParseISODuration(d); ok {
+				return parsed, true
+			}
+		case float64:
+			return time.Duration(d * float64(time.Second)), true
+		}
+	}
+	return 0, false
+}
+
+// withoutScreenshots copies a step, replacing screenshot images with a note
+// of their size
+func withoutScreenshots(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			if key == "screenshot" {
+				out[key] = omitScreenshot(item)
+				continue
+			}
+			out[key] = withoutScreenshots(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = withoutScreenshots(item)
+		}
+		return out
+	}
+	return v
+}
+
+func omitScreenshot(v any) any {
+	omitted := func(s string) string {
+		return fmt.Sprintf("<%d bytes omitted, use --full>", len(s))
+	}
+	switch v := v.(type) {
+	case string:
+		return omitted(v)
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			if raw, ok := item.(string); ok && key == "raw" {
+				out[key] = omitted(raw)
+				continue
+			}
+			out[key] = withoutScreenshots(item)
+		}
+		return out
+	}
+	return withoutScreenshots(v)
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+const agentStepsJSON = `{"agent_id":"agent_123","session_id":"sess_1","status":"closed","task":"test","created_at":"2020-01-01T00:00:00Z","steps":[` +
+	`{"type":"observation","value":{"metadata":{"url":"https://example.com","title":"Example"},"screenshot":{"raw":"aGVsbG8gd29ybGQ="},"started_at":"2020-01-01T00:00:00Z","ended_at":"2020-01-01T00:00:02Z"}},` +
+	`{"type":"execution_result","value":{"action":{"type":"click","id":"B1"},"success":false,"message":"element not found"}}` +
+	`]}`
+
+func setAgentStepsFlags(t *testing.T, step int, full bool) *cobra.Command {
+	t.Helper()
+	origStep, origFull := agentStepsStep, agentStepsFull
+	t.Cleanup(func() { agentStepsStep, agentStepsFull = origStep, origFull })
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	addAgentIDFlag(cmd)
+	cmd.Flags().IntVar(&agentStepsStep, "step", 0, "")
+	cmd.Flags().BoolVar(&agentStepsFull, "full", false, "")
+	if step != 0 {
+		_ = cmd.Flags().Set("step", strconv.Itoa(step))
+	}
+	if full {
+		_ = cmd.Flags().Set("full", "true")
+	}
+	return cmd
+}
+
+func TestRunAgentSteps_List(t *testing.T) {
+	server := setupAgentTest(t)
+	server.AddResponse("/agents/"+agentIDTest, 200, agentStepsJSON)
+	origFormat := outputFormat
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	outputFormat = "text"
+	cmd := setAgentStepsFlags(t, 0, false)
+	var err error
+	stdout, _ := testutil.CaptureOutput(func() {
+		err = runAgentSteps(cmd, nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"STEP", "observation", "https://example.com", "2s", "click", "B1", "false"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in:\n%s", want, stdout)
+		}
+	}
+
+	outputFormat = "json"
+	stdout, _ = testutil.CaptureOutput(func() {
+		err = runAgentSteps(cmd, nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `[{"step":1,"action":"observation","target":"https://example.com","duration_ms":2000},{"step":2,"action":"click","target":"B1","success":false}]`
+	if strings.TrimSpace(stdout) != want {
+		t.Errorf("got %s, want %s", stdout, want)
+	}
+}
+
+func TestRunAgentSteps_Step(t *testing.T) {
+	server := setupAgentTest(t)
+	server.AddResponse("/agents/"+agentIDTest, 200, agentStepsJSON)
+	origFormat := outputFormat
+	outputFormat = "json"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	cmd := setAgentStepsFlags(t, 1, false)
+	var err error
+	stdout, _ := testutil.CaptureOutput(func() {
+		err = runAgentSteps(cmd, nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(stdout, "aGVsbG8gd29ybGQ=") || !strings.Contains(stdout, "16 bytes omitted") {
+		t.Errorf("expected the screenshot to be left out, got %s", stdout)
+	}
+	if !strings.Contains(stdout, `"title":"Example"`) {
+		t.Errorf("expected the rest of the step, got %s", stdout)
+	}
+
+	cmd = setAgentStepsFlags(t, 1, true)
+	stdout, _ = testutil.CaptureOutput(func() {
+		err = runAgentSteps(cmd, nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout, `"raw":"aGVsbG8gd29ybGQ="`) {
+		t.Errorf("expected the screenshot with --full, got %s", stdout)
+	}
+
+	cmd = setAgentStepsFlags(t, 3, false)
+	if err := runAgentSteps(cmd, nil); err == nil || !strings.Contains(err.Error(), "has 2 step(s), no step 3") {
+		t.Errorf("expected an out of range error, got %v", err)
+	}
+}
+
+func TestRunAgentSteps_Args(t *testing.T) {
+	server := setupAgentTest(t)
+	server.AddResponse("/agents/agent_456", 200, `{"agent_id":"agent_456","session_id":"sess_1","status":"closed","task":"test","created_at":"2020-01-01T00:00:00Z","steps":[]}`)
+	origFormat := outputFormat
+	outputFormat = "text"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	cmd := setAgentStepsFlags(t, 0, true)
+	if err := runAgentSteps(cmd, nil); err == nil || !strings.Contains(err.Error(), "--full needs --step") {
+		t.Errorf("expected --full to need --step, got %v", err)
+	}
+
+	cmd = setAgentStepsFlags(t, 0, false)
+	var err error
+	stdout, _ := testutil.CaptureOutput(func() {
+		err = runAgentSteps(cmd, []string{"agent_456"})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout, "agent_456 has no steps") {
+		t.Errorf("expected the agent from the argument, got %q", stdout)
+	}
+}
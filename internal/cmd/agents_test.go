@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"archive/zip"
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -24,9 +28,7 @@ func setupAgentTest(t *testing.T) *testutil.MockServer {
 	t.Cleanup(func() { server.Close() })
 	env.SetEnv("NOTTE_API_URL", server.URL())
 
-	origAgentID := agentID
-	agentID = agentIDTest
-	t.Cleanup(func() { agentID = origAgentID })
+	env.SetEnv("NOTTE_AGENT_ID", agentIDTest)
 
 	return server
 }
@@ -195,6 +197,104 @@ func TestRunAgentsStart_Minimal(t *testing.T) {
 	}
 }
 
+func TestRunAgentsStart_AttachViewer(t *testing.T) {
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+
+	server := testutil.NewMockServer()
+	defer server.Close()
+	env.SetEnv("NOTTE_API_URL", server.URL())
+
+	server.AddResponse("/agents/start", 200, `{"agent_id":"agent_3","session_id":"sess_3","status":"RUNNING","created_at":"2020-01-01T00:00:00Z"}`)
+	server.AddResponse("/sessions/sess_3", 200, `{"session_id":"sess_3","status":"ACTIVE","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","timeout_minutes":0,"viewer_url":"https://viewer.notte.cc/sess_3"}`)
+
+	origTask := AgentStartTask
+	origSession := AgentStartSessionId
+	origAttach := agentsStartAttachViewer
+	origOpen := openBrowser
+	t.Cleanup(func() {
+		AgentStartTask = origTask
+		AgentStartSessionId = origSession
+		agentsStartAttachViewer = origAttach
+		openBrowser = origOpen
+	})
+
+	AgentStartTask = "do the thing"
+	AgentStartSessionId = "sess_3"
+	agentsStartAttachViewer = true
+
+	var opened []string
+	openBrowser = func(url string) error {
+		opened = append(opened, url)
+		return nil
+	}
+
+	origFormat := outputFormat
+	outputFormat = "json"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	testutil.CaptureOutput(func() {
+		if err := runAgentsStart(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if len(opened) != 1 || opened[0] != "https://viewer.notte.cc/sess_3" {
+		t.Errorf("opened = %v, want the session viewer URL", opened)
+	}
+}
+
+func TestRunAgentsStart_AttachViewerFailureIsWarning(t *testing.T) {
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+
+	server := testutil.NewMockServer()
+	defer server.Close()
+	env.SetEnv("NOTTE_API_URL", server.URL())
+
+	server.AddResponse("/agents/start", 200, `{"agent_id":"agent_4","session_id":"sess_4","status":"RUNNING","created_at":"2020-01-01T00:00:00Z"}`)
+	server.AddResponse("/sessions/sess_4", 403, `{"detail":"forbidden"}`)
+
+	origTask := AgentStartTask
+	origSession := AgentStartSessionId
+	origAttach := agentsStartAttachViewer
+	origOpen := openBrowser
+	t.Cleanup(func() {
+		AgentStartTask = origTask
+		AgentStartSessionId = origSession
+		agentsStartAttachViewer = origAttach
+		openBrowser = origOpen
+	})
+
+	AgentStartTask = "do the thing"
+	AgentStartSessionId = "sess_4"
+	agentsStartAttachViewer = true
+	openBrowser = func(url string) error {
+		t.Errorf("browser should not open without a viewer URL, got %s", url)
+		return nil
+	}
+
+	origFormat := outputFormat
+	outputFormat = "json"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	_, stderr := testutil.CaptureOutput(func() {
+		if err := runAgentsStart(cmd, nil); err != nil {
+			t.Fatalf("agent start should succeed even if the viewer can't open: %v", err)
+		}
+	})
+
+	if !strings.Contains(stderr, "could not get viewer URL") {
+		t.Errorf("expected a warning on stderr, got %q", stderr)
+	}
+}
+
 func TestRunAgentStatus(t *testing.T) {
 	server := setupAgentTest(t)
 	server.AddResponse("/agents/"+agentIDTest, 200, agentStatusJSON())
@@ -347,35 +447,27 @@ func setupAgentFileTest(t *testing.T) string {
 }
 
 func TestGetCurrentAgentID_FromFlag(t *testing.T) {
-	origID := agentID
-	agentID = "flag_agent"
-	t.Cleanup(func() { agentID = origID })
+	cmd := &cobra.Command{}
+	addAgentIDFlag(cmd)
+	_ = cmd.Flags().Set("agent-id", "flag_agent")
 
-	got := GetCurrentAgentID()
+	got := GetCurrentAgentID(cmd)
 	if got != "flag_agent" {
 		t.Errorf("GetCurrentAgentID() = %q, want %q", got, "flag_agent")
 	}
 }
 
 func TestGetCurrentAgentID_FromEnvVar(t *testing.T) {
-	origID := agentID
-	agentID = ""
-	t.Cleanup(func() { agentID = origID })
-
 	env := testutil.SetupTestEnv(t)
 	env.SetEnv("NOTTE_AGENT_ID", "env_agent")
 
-	got := GetCurrentAgentID()
+	got := GetCurrentAgentID(nil)
 	if got != "env_agent" {
 		t.Errorf("GetCurrentAgentID() = %q, want %q", got, "env_agent")
 	}
 }
 
 func TestGetCurrentAgentID_FromFile(t *testing.T) {
-	origID := agentID
-	agentID = ""
-	t.Cleanup(func() { agentID = origID })
-
 	env := testutil.SetupTestEnv(t)
 	env.SetEnv("NOTTE_AGENT_ID", "") // Ensure env var is empty
 
@@ -392,15 +484,15 @@ func TestGetCurrentAgentID_FromFile(t *testing.T) {
 		t.Fatalf("failed to write agent file: %v", err)
 	}
 
-	got := GetCurrentAgentID()
+	got := GetCurrentAgentID(nil)
 	if got != "file_agent" {
 		t.Errorf("GetCurrentAgentID() = %q, want %q", got, "file_agent")
 	}
 }
 
 func TestGetCurrentAgentID_Priority(t *testing.T) {
-	origID := agentID
-	t.Cleanup(func() { agentID = origID })
+	cmd := &cobra.Command{}
+	addAgentIDFlag(cmd)
 
 	env := testutil.SetupTestEnv(t)
 	tmpDir := setupAgentFileTest(t)
@@ -416,24 +508,24 @@ func TestGetCurrentAgentID_Priority(t *testing.T) {
 	}
 
 	// Test: flag > env > file
-	agentID = "flag_agent"
+	_ = cmd.Flags().Set("agent-id", "flag_agent")
 	env.SetEnv("NOTTE_AGENT_ID", "env_agent")
 
-	got := GetCurrentAgentID()
+	got := GetCurrentAgentID(cmd)
 	if got != "flag_agent" {
 		t.Errorf("flag should have highest priority: got %q, want %q", got, "flag_agent")
 	}
 
 	// Test: env > file
-	agentID = ""
-	got = GetCurrentAgentID()
+	_ = cmd.Flags().Set("agent-id", "")
+	got = GetCurrentAgentID(cmd)
 	if got != "env_agent" {
 		t.Errorf("env should have priority over file: got %q, want %q", got, "env_agent")
 	}
 
 	// Test: file as fallback
 	env.SetEnv("NOTTE_AGENT_ID", "")
-	got = GetCurrentAgentID()
+	got = GetCurrentAgentID(cmd)
 	if got != "file_agent" {
 		t.Errorf("file should be fallback: got %q, want %q", got, "file_agent")
 	}
@@ -497,15 +589,11 @@ func TestClearCurrentAgent_NoFile(t *testing.T) {
 }
 
 func TestRequireAgentID_NoAgent(t *testing.T) {
-	origID := agentID
-	agentID = ""
-	t.Cleanup(func() { agentID = origID })
-
 	env := testutil.SetupTestEnv(t)
 	env.SetEnv("NOTTE_AGENT_ID", "")
 	_ = setupAgentFileTest(t)
 
-	err := RequireAgentID()
+	_, err := RequireAgentID(nil)
 	if err == nil {
 		t.Fatal("RequireAgentID() should error when no agent ID available")
 	}
@@ -517,10 +605,6 @@ func TestRequireAgentID_NoAgent(t *testing.T) {
 }
 
 func TestRequireAgentID_FromFile(t *testing.T) {
-	origID := agentID
-	agentID = ""
-	t.Cleanup(func() { agentID = origID })
-
 	env := testutil.SetupTestEnv(t)
 	env.SetEnv("NOTTE_AGENT_ID", "")
 	tmpDir := setupAgentFileTest(t)
@@ -535,13 +619,13 @@ func TestRequireAgentID_FromFile(t *testing.T) {
 		t.Fatalf("failed to write agent file: %v", err)
 	}
 
-	err := RequireAgentID()
+	id, err := RequireAgentID(nil)
 	if err != nil {
 		t.Fatalf("RequireAgentID() error = %v", err)
 	}
 
-	if agentID != "file_agent" {
-		t.Errorf("agentID = %q, want %q", agentID, "file_agent")
+	if id != "file_agent" {
+		t.Errorf("RequireAgentID() = %q, want %q", id, "file_agent")
 	}
 }
 
@@ -615,11 +699,6 @@ func TestAgentsStart_UsesCurrentSession(t *testing.T) {
 		t.Fatalf("failed to write session file: %v", err)
 	}
 
-	// Clear session ID flag to test auto-pickup
-	origSessionID := sessionID
-	sessionID = ""
-	t.Cleanup(func() { sessionID = origSessionID })
-
 	server.AddResponse("/agents/start", 200, `{"agent_id":"agent_with_session","session_id":"current_sess_123","status":"RUNNING","created_at":"2020-01-01T00:00:00Z"}`)
 
 	origTask := AgentStartTask
@@ -674,9 +753,7 @@ func TestAgentStop_ClearsCurrentAgent(t *testing.T) {
 
 	server.AddResponse("/agents/"+agentIDTest+"/stop", 200, agentStatusJSON())
 
-	origID := agentID
-	agentID = agentIDTest
-	t.Cleanup(func() { agentID = origID })
+	env.SetEnv("NOTTE_AGENT_ID", agentIDTest)
 
 	SetSkipConfirmation(true)
 	t.Cleanup(func() { SetSkipConfirmation(false) })
@@ -724,9 +801,7 @@ func TestAgentStop_DifferentAgent_DoesNotClearCurrentAgent(t *testing.T) {
 	// Stop a different agent "agent_different"
 	server.AddResponse("/agents/agent_different/stop", 200, `{"agent_id":"agent_different","session_id":"sess_1","status":"STOPPED","created_at":"2020-01-01T00:00:00Z","replay_start_offset":0,"replay_stop_offset":0}`)
 
-	origID := agentID
-	agentID = "agent_different"
-	t.Cleanup(func() { agentID = origID })
+	env.SetEnv("NOTTE_AGENT_ID", "agent_different")
 
 	SetSkipConfirmation(true)
 	t.Cleanup(func() { SetSkipConfirmation(false) })
@@ -777,12 +852,7 @@ func TestAgentStatus_UsesCurrentAgent(t *testing.T) {
 
 	server.AddResponse("/agents/"+agentIDTest, 200, agentStatusJSON())
 
-	// Clear agentID to test file-based resolution
-	origID := agentID
-	agentID = ""
-	t.Cleanup(func() { agentID = origID })
-
-	// Clear env var too
+	// Clear env var so the current file is used
 	env.SetEnv("NOTTE_AGENT_ID", "")
 
 	origFormat := outputFormat
@@ -803,3 +873,161 @@ func TestAgentStatus_UsesCurrentAgent(t *testing.T) {
 		t.Error("expected output, got empty string")
 	}
 }
+
+func setupAgentExportTest(t *testing.T) (*testutil.MockServer, string) {
+	t.Helper()
+	server := setupAgentTest(t)
+	server.AddResponse("/agents/"+agentIDTest, 200, `{"agent_id":"agent_123","session_id":"sess_456","created_at":"2024-01-01T00:00:00Z","status":"closed","task":"test","replay_start_offset":0,"replay_stop_offset":100,"steps":[{"type":"click","id":"B1"}]}`)
+	server.AddResponse("/agents/"+agentIDTest+"/workflow/code", 200, `{"json_actions":[{"type":"click"}],"python_script":"print('hi')"}`)
+	server.AddResponse("/sessions/sess_456/replay", 200, `{"mp4_url":"`+server.URL()+`/video.mp4","expires_at":"2099-01-01T00:00:00Z"}`)
+	server.AddResponse("/video.mp4", 200, "video-bytes")
+
+	origOutput, origZip, origFormat := agentExportOutput, agentExportZip, outputFormat
+	t.Cleanup(func() {
+		agentExportOutput, agentExportZip, outputFormat = origOutput, origZip, origFormat
+	})
+	agentExportOutput = filepath.Join(t.TempDir(), "bundle")
+	agentExportZip = false
+	outputFormat = "json"
+	return server, agentExportOutput
+}
+
+func TestRunAgentExport_Directory(t *testing.T) {
+	server, dir := setupAgentExportTest(t)
+	server.AddResponse("/sessions/sess_456/page/screenshot", 200, "jpeg-bytes")
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	testutil.CaptureOutput(func() {
+		if err := runAgentExport(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	want := map[string]string{
+		"workflow.py":    "print('hi')",
+		"replay.mp4":     "video-bytes",
+		"screenshot.jpg": "jpeg-bytes",
+	}
+	for name, content := range want {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("missing %s: %v", name, err)
+		}
+		if string(data) != content {
+			t.Errorf("%s = %q, want %q", name, data, content)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "steps.json"))
+	if err != nil || !strings.Contains(string(data), `"B1"`) {
+		t.Errorf("steps.json = %q, %v", data, err)
+	}
+
+	var manifest struct {
+		Files  []string          `json:"files"`
+		Errors map[string]string `json:"errors"`
+	}
+	data, err = os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("invalid manifest: %v", err)
+	}
+	if len(manifest.Errors) != 0 {
+		t.Errorf("unexpected errors: %v", manifest.Errors)
+	}
+	if len(manifest.Files) != 8 {
+		t.Errorf("files = %v, want 8 entries", manifest.Files)
+	}
+}
+
+func TestRunAgentExport_ObjectStorage(t *testing.T) {
+	server, _ := setupAgentExportTest(t)
+	server.AddResponse("/sessions/sess_456/page/screenshot", 200, "jpeg-bytes")
+	t.Setenv("AWS_ENDPOINT_URL_S3", server.URL())
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	for _, name := range []string{"status.json", "steps.json", "workflow.py", "workflow_actions.json", "replay.json", "replay.mp4", "screenshot.jpg", "manifest.json"} {
+		server.AddResponse("/bucket/runs/"+name, 200, "")
+	}
+	agentExportOutput = "s3://bucket/runs/"
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	testutil.CaptureOutput(func() {
+		if err := runAgentExport(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	reqs := server.Requests("/bucket/runs/replay.mp4")
+	if len(reqs) != 1 || reqs[0].Method != http.MethodPut || reqs[0].Body != "video-bytes" {
+		t.Fatalf("expected replay.mp4 to be uploaded, got %+v", reqs)
+	}
+	if got := len(server.Requests("/bucket/runs/manifest.json")); got != 1 {
+		t.Errorf("expected manifest.json to be uploaded once, got %d", got)
+	}
+}
+
+func TestRunAgentExport_ZipRecordsMissingArtifacts(t *testing.T) {
+	server, output := setupAgentExportTest(t)
+	server.AddResponse("/sessions/sess_456/page/screenshot", 404, `{"detail":"session closed"}`)
+	agentExportZip = true
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	testutil.CaptureOutput(func() {
+		if err := runAgentExport(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	zr, err := zip.OpenReader(output + ".zip")
+	if err != nil {
+		t.Fatalf("expected zip archive: %v", err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	names := map[string]*zip.File{}
+	for _, f := range zr.File {
+		names[f.Name] = f
+	}
+	if _, ok := names["screenshot.jpg"]; ok {
+		t.Error("screenshot.jpg should be skipped when the session is closed")
+	}
+	manifest, ok := names["manifest.json"]
+	if !ok {
+		t.Fatal("missing manifest.json")
+	}
+	rc, err := manifest.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = rc.Close() }()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"screenshot.jpg"`) {
+		t.Errorf("manifest should record the screenshot error, got %s", data)
+	}
+}
+
+func TestRunAgentExport_StatusRequired(t *testing.T) {
+	_ = setupAgentTest(t)
+
+	origOutput := agentExportOutput
+	t.Cleanup(func() { agentExportOutput = origOutput })
+	agentExportOutput = filepath.Join(t.TempDir(), "bundle")
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	if err := runAgentExport(cmd, nil); err == nil {
+		t.Fatal("expected error when agent status is unavailable")
+	}
+	if _, err := os.Stat(agentExportOutput); !os.IsNotExist(err) {
+		t.Error("nothing should be written when agent status fails")
+	}
+}
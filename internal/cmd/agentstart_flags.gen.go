@@ -4,32 +4,12 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/nottelabs/notte-cli/internal/api"
 )
 
-// readJSONFile reads JSON from a file path (supports @file.json syntax)
-func readJSONFile(path string) (interface{}, error) {
-	// Remove @ prefix if present
-	filePath := strings.TrimPrefix(path, "@")
-
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
-	}
-
-	var result interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON from %s: %w", filePath, err)
-	}
-
-	return result, nil
-}
-
 // AgentStart command flags
 var (
 	// The maximum number of steps the agent should take
@@ -66,15 +46,28 @@ var (
 // RegisterAgentStartFlags registers all flags for AgentStart command
 func RegisterAgentStartFlags(cmd *cobra.Command) {
 	cmd.Flags().IntVar(&AgentStartMaxSteps, "max-steps", 0, "The maximum number of steps the agent should take")
+	_ = cmd.Flags().SetAnnotation("max-steps", flagFieldAnnotation, []string{"max_steps"})
 	cmd.Flags().StringVar(&AgentStartPersonaId, "persona-id", "", "The persona to use for the agent")
+	_ = cmd.Flags().SetAnnotation("persona-id", flagFieldAnnotation, []string{"persona_id"})
 	cmd.Flags().StringVar(&AgentStartReasoningModel, "reasoning-model", "", "The reasoning model to use (openai/gpt-4o, gemini/gemini-2.5-flash, vertex_ai/gemini-2.5-flash, openrouter/google/gemma-3-27b-it, cerebras/gpt-oss-120b, groq/gpt-oss-120b, perplexity/sonar-pro, deepseek/deepseek-r1, together_ai/meta-llama/llama-3.3-70b-instruct, anthropic/claude-sonnet-4-5-20250929, moonshot/kimi-k2.5, xai/grok-4-1-fast-non-reasoning, minimax/minimax-m2.5)")
-	cmd.Flags().StringVar(&AgentStartResponseFormat, "response-format-json", "", "response-format configuration (JSON file path, e.g., @config.json)")
+	_ = cmd.Flags().SetAnnotation("reasoning-model", flagFieldAnnotation, []string{"reasoning_model"})
+	_ = cmd.Flags().SetAnnotation("reasoning-model", flagSuggestionsAnnotation, []string{"openai/gpt-4o", "gemini/gemini-2.5-flash", "vertex_ai/gemini-2.5-flash", "openrouter/google/gemma-3-27b-it", "cerebras/gpt-oss-120b", "groq/gpt-oss-120b", "perplexity/sonar-pro", "deepseek/deepseek-r1", "together_ai/meta-llama/llama-3.3-70b-instruct", "anthropic/claude-sonnet-4-5-20250929", "moonshot/kimi-k2.5", "xai/grok-4-1-fast-non-reasoning", "minimax/minimax-m2.5"})
+	cmd.Flags().StringVar(&AgentStartResponseFormat, "response-format-json", "", "response-format configuration (JSON, @file, or '-' for stdin)")
+	_ = cmd.Flags().SetAnnotation("response-format-json", flagFieldAnnotation, []string{"response_format"})
 	cmd.Flags().StringVar(&AgentStartSessionId, "session-id", "", "The ID of the session to run the agent on")
+	_ = cmd.Flags().SetAnnotation("session-id", flagFieldAnnotation, []string{"session_id"})
 	cmd.Flags().IntVar(&AgentStartSessionOffset, "session-offset", 0, "[Experimental] The step from which the agent should gather information from in the session. If none, fresh memory")
+	_ = cmd.Flags().SetAnnotation("session-offset", flagFieldAnnotation, []string{"session_offset"})
 	cmd.Flags().StringVar(&AgentStartTask, "task", "", "The task that the agent should perform")
+	_ = cmd.Flags().SetAnnotation("task", flagFieldAnnotation, []string{"task"})
 	cmd.Flags().StringVar(&AgentStartUrl, "url", "", "The URL that the agent should start on (optional)")
+	_ = cmd.Flags().SetAnnotation("url", flagFieldAnnotation, []string{"url"})
 	cmd.Flags().BoolVar(&AgentStartUseVision, "use-vision", false, "Whether to use vision for the agent. Not all reasoning models support vision.")
+	_ = cmd.Flags().SetAnnotation("use-vision", flagFieldAnnotation, []string{"use_vision"})
 	cmd.Flags().StringVar(&AgentStartVaultId, "vault-id", "", "The vault to use for the agent")
+	_ = cmd.Flags().SetAnnotation("vault-id", flagFieldAnnotation, []string{"vault_id"})
+
+	_ = cmd.MarkFlagRequired("task")
 }
 
 // BuildAgentStartRequest builds the API request from CLI flags
@@ -99,11 +92,15 @@ func BuildAgentStartRequest(cmd *cobra.Command) (*api.ApiAgentStartRequest, erro
 
 	// response_format (JSON file input)
 	if AgentStartResponseFormat != "" {
-		data, err := readJSONFile(AgentStartResponseFormat)
+		data, err := readJSONFileInput(cmd, AgentStartResponseFormat, "response-format-json")
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response-format: %w", err)
+			return nil, err
+		}
+		var val interface{}
+		if err := json.Unmarshal(data, &val); err != nil {
+			return nil, fmt.Errorf("invalid JSON for --response-format-json: %w", err)
 		}
-		body.ResponseFormat = data
+		body.ResponseFormat = val
 	}
 
 	if cmd.Flags().Changed("session-id") {
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// expandUserAlias rewrites args when the first argument names a user-defined
+// alias from the config file ("aliases": {"co": "page goto"}). Built-in
+// commands and their aliases always take precedence, so a user alias can
+// never shadow a real command.
+func expandUserAlias(root *cobra.Command, args []string, aliases map[string]string) []string {
+	if len(args) == 0 {
+		return args
+	}
+
+	expansion, ok := aliases[args[0]]
+	if !ok || isBuiltinCommand(root, args[0]) {
+		return args
+	}
+
+	fields := strings.Fields(expansion)
+	if len(fields) == 0 {
+		return args
+	}
+
+	expanded := make([]string, 0, len(fields)+len(args)-1)
+	expanded = append(expanded, fields...)
+	return append(expanded, args[1:]...)
+}
+
+func isBuiltinCommand(root *cobra.Command, name string) bool {
+	for _, c := range root.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	// Cobra adds help and completion lazily
+	return name == "help" || name == "completion"
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandUserAlias(t *testing.T) {
+	aliases := map[string]string{
+		"co":       "page goto",
+		"sessions": "agents list", // must not shadow a built-in command
+		"s":        "agents list", // nor a built-in alias
+		"empty":    "   ",
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"expands alias", []string{"co", "https://example.com"}, []string{"page", "goto", "https://example.com"}},
+		{"keeps flags", []string{"co", "--session-id", "sess_1", "https://example.com"}, []string{"page", "goto", "--session-id", "sess_1", "https://example.com"}},
+		{"builtin wins", []string{"sessions", "list"}, []string{"sessions", "list"}},
+		{"builtin alias wins", []string{"s", "list"}, []string{"s", "list"}},
+		{"empty expansion ignored", []string{"empty"}, []string{"empty"}},
+		{"unknown passes through", []string{"nope"}, []string{"nope"}},
+		{"no args", []string{}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandUserAlias(rootCmd, tt.args, aliases)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandUserAlias(%v) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConventionalAliases(t *testing.T) {
+	tests := [][]string{
+		{"s", "ls"},
+		{"a", "ls"},
+		{"sessions", "rm"},
+		{"agents", "rm"},
+		{"functions", "rm"},
+		{"vaults", "ls"},
+	}
+
+	for _, args := range tests {
+		found, _, err := rootCmd.Find(args)
+		if err != nil {
+			t.Errorf("Find(%v) error = %v", args, err)
+			continue
+		}
+		if found == rootCmd || !found.HasAlias(args[len(args)-1]) {
+			t.Errorf("Find(%v) resolved to %q", args, found.CommandPath())
+		}
+	}
+}
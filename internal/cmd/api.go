@@ -0,0 +1,377 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var apiDescribeDepth int
+
+var apiCmd = &cobra.Command{
+	Use:   "api",
+	Short: "Inspect the Notte API",
+	Long: `Inspect the Notte API using the OpenAPI spec bundled with this binary, so
+it works offline and matches the API version the CLI was built against.`,
+}
+
+var apiDescribeCmd = &cobra.Command{
+	Use:   "describe [operation]",
+	Short: "Describe an API operation's parameters, request body and responses",
+	Long: `Describe an API operation from the bundled OpenAPI spec: its method and
+path, parameters, request body fields and response schemas.
+
+The operation can be given by operation ID (page_execute, page-execute or
+PageExecute all work) or by path, optionally preceded by its method
+("POST /sessions/{session_id}/page/execute"). Without an operation, every
+operation is listed.
+
+Nested objects are expanded up to --depth levels. With -o json, the
+operation and every schema it references are printed as OpenAPI JSON.`,
+	Example: `  notte api describe
+  notte api describe page_execute
+  notte api describe "GET /sessions/{session_id}/cookies"
+  notte api describe session_start --depth 1 -o json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeAPIOperations,
+	RunE:              runAPIDescribe,
+}
+
+func init() {
+	rootCmd.AddCommand(apiCmd)
+	apiCmd.AddCommand(apiDescribeCmd)
+
+	apiDescribeCmd.Flags().IntVar(&apiDescribeDepth, "depth", 2, "Levels of nested objects to expand")
+}
+
+// apiOperationRow is one line of the operation list
+type apiOperationRow struct {
+	OperationID string `json:"operation_id"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+}
+
+func runAPIDescribe(cmd *cobra.Command, args []string) error {
+	spec, err := api.LoadSpec()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		var rows []apiOperationRow
+		for _, op := range spec.Operations() {
+			rows = append(rows, apiOperationRow{OperationID: op.OperationID, Method: op.Method, Path: op.Path})
+		}
+		if printed, err := PrintListOrEmpty(rows, "No operations in the bundled spec."); err != nil || printed {
+			return err
+		}
+		return GetFormatter().Print(rows)
+	}
+
+	op, err := findAPIOperation(spec, args[0])
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		return GetFormatter().Print(map[string]any{
+			"operation": op,
+			"schemas":   referencedSchemas(spec, op),
+		})
+	}
+	printAPIOperation(os.Stdout, spec, op, apiDescribeDepth)
+	return nil
+}
+
+// normalizeOperationID lets page_execute, page-execute and PageExecute match
+func normalizeOperationID(s string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(s))
+}
+
+// findAPIOperation looks an operation up by operation ID or [METHOD] path
+func findAPIOperation(spec *api.Spec, query string) (*api.Operation, error) {
+	ops := spec.Operations()
+
+	if method, path, ok := strings.Cut(strings.TrimSpace(query), " "); ok || strings.HasPrefix(query, "/") {
+		if !ok {
+			method, path = "", query
+		}
+		path = "/" + strings.Trim(strings.TrimSpace(path), "/")
+		var matches []*api.Operation
+		for _, op := range ops {
+			if op.Path == path && (method == "" || strings.EqualFold(op.Method, method)) {
+				matches = append(matches, op)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, i18n.Errorf(i18n.NoSuchOperation, query)
+		case 1:
+			return matches[0], nil
+		}
+		var options []string
+		for _, op := range matches {
+			options = append(options, fmt.Sprintf("%s %s", op.Method, op.Path))
+		}
+		return nil, i18n.Errorf(i18n.SeveralOperations, path, strings.Join(options, ", "))
+	}
+
+	want := normalizeOperationID(query)
+	var ids []string
+	for _, op := range ops {
+		if normalizeOperationID(op.OperationID) == want {
+			return op, nil
+		}
+		ids = append(ids, op.OperationID)
+	}
+	msg := fmt.Sprintf("unknown operation %q", query)
+	if s := closestName(query, ids); s != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", s)
+	}
+	return nil, i18n.Errorf(i18n.OperationHint, msg)
+}
+
+func completeAPIOperations(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	spec, err := api.LoadSpec()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var out []string
+	for _, op := range spec.Operations() {
+		if strings.HasPrefix(op.OperationID, toComplete) {
+			out = append(out, op.OperationID+"\t"+op.Method+" "+op.Path)
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+func printAPIOperation(w io.Writer, spec *api.Spec, op *api.Operation, depth int) {
+	_, _ = fmt.Fprintf(w, "%s\n  %s %s\n", op.OperationID, op.Method, op.Path)
+	if text := strings.TrimSpace(firstNonEmpty(op.Summary, op.Description)); text != "" {
+		_, _ = fmt.Fprintf(w, "\n%s\n", text)
+	}
+
+	if len(op.Parameters) > 0 {
+		_, _ = fmt.Fprintln(w, "\nParameters:")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, p := range op.Parameters {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", p.Name, p.In, schemaLabel(spec, p.Schema), fieldNotes(p.Required, p.Description))
+		}
+		_ = tw.Flush()
+	}
+
+	if body := op.RequestBody; body != nil {
+		for _, ct := range sortedKeys(body.Content) {
+			schema := body.Content[ct].Schema
+			req := ""
+			if body.Required {
+				req = ", required"
+			}
+			_, _ = fmt.Fprintf(w, "\nRequest body (%s%s): %s\n", ct, req, schemaLabel(spec, schema))
+			printSchemaFields(w, spec, schema, "  ", depth)
+		}
+	}
+
+	if len(op.Responses) > 0 {
+		_, _ = fmt.Fprintln(w, "\nResponses:")
+		for _, code := range sortedKeys(op.Responses) {
+			resp := op.Responses[code]
+			var schema *api.Schema
+			for _, ct := range sortedKeys(resp.Content) {
+				schema = resp.Content[ct].Schema
+				break
+			}
+			line := "  " + code
+			if schema != nil {
+				line += "  " + schemaLabel(spec, schema)
+			}
+			if resp.Description != "" {
+				line += "  " + resp.Description
+			}
+			_, _ = fmt.Fprintln(w, line)
+			if strings.HasPrefix(code, "2") && schema != nil {
+				printSchemaFields(w, spec, schema, "      ", depth)
+			}
+		}
+	}
+}
+
+// printSchemaFields lists an object's properties, expanding nested objects
+// up to depth levels, or the variants of a union
+func printSchemaFields(w io.Writer, spec *api.Spec, schema *api.Schema, indent string, depth int) {
+	s := spec.Resolve(schema)
+	if s == nil || depth <= 0 {
+		return
+	}
+	if variants := unionVariants(s); len(variants) > 0 {
+		var names []string
+		for _, v := range variants {
+			names = append(names, schemaLabel(spec, v))
+		}
+		_, _ = fmt.Fprintf(w, "%sone of: %s\n", indent, strings.Join(names, ", "))
+		return
+	}
+	if s.Type == "array" && s.Items != nil {
+		printSchemaFields(w, spec, s.Items, indent, depth)
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, name := range sortedKeys(s.Properties) {
+		prop := s.Properties[name]
+		desc := firstNonEmpty(prop.Description, describedSchema(spec, prop))
+		_, _ = fmt.Fprintf(tw, "%s%s\t%s\t%s\n", indent, name, schemaLabel(spec, prop), fieldNotes(slices.Contains(s.Required, name), desc))
+		if nested := spec.Resolve(unwrapAllOf(prop)); nested != nil && depth > 1 && (len(nested.Properties) > 0 || nested.Type == "array") {
+			_ = tw.Flush()
+			printSchemaFields(w, spec, unwrapAllOf(prop), indent+"  ", depth-1)
+		}
+	}
+	_ = tw.Flush()
+}
+
+// describedSchema returns the description of the component prop refers to
+func describedSchema(spec *api.Spec, prop *api.Schema) string {
+	if s := spec.Resolve(unwrapAllOf(prop)); s != nil {
+		return s.Description
+	}
+	return ""
+}
+
+// unwrapAllOf treats a single-entry allOf (how a described $ref is
+// written) as the schema it wraps
+func unwrapAllOf(s *api.Schema) *api.Schema {
+	if s != nil && s.Ref == "" && len(s.AllOf) == 1 {
+		return s.AllOf[0]
+	}
+	return s
+}
+
+func unionVariants(s *api.Schema) []*api.Schema {
+	if len(s.OneOf) > 0 {
+		return s.OneOf
+	}
+	return s.AnyOf
+}
+
+// schemaLabel is a one-word summary of a schema's type
+func schemaLabel(spec *api.Spec, s *api.Schema) string {
+	s = unwrapAllOf(s)
+	if s == nil {
+		return "any"
+	}
+	if s.Ref != "" {
+		name := api.RefName(s.Ref)
+		if r := spec.Resolve(s); r != nil && len(r.Enum) > 0 {
+			return name + " " + enumLabel(r.Enum)
+		}
+		return name
+	}
+	if variants := unionVariants(s); len(variants) > 0 {
+		var names []string
+		for _, v := range variants {
+			names = append(names, schemaLabel(spec, v))
+		}
+		return strings.Join(names, " | ")
+	}
+	label := s.Type
+	switch {
+	case s.Type == "array":
+		label = "array<" + schemaLabel(spec, s.Items) + ">"
+	case s.Type == "object" && len(s.Properties) == 0:
+		if v := s.ValueSchema(); v != nil {
+			label = "map<" + schemaLabel(spec, v) + ">"
+		}
+	case s.Type == "":
+		label = "any"
+	}
+	if s.Format != "" {
+		label += " (" + s.Format + ")"
+	}
+	if len(s.Enum) > 0 {
+		label += " " + enumLabel(s.Enum)
+	}
+	return label
+}
+
+func enumLabel(values []any) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprint(v)
+	}
+	return "(" + strings.Join(parts, "|") + ")"
+}
+
+func fieldNotes(required bool, desc string) string {
+	desc = strings.Join(strings.Fields(desc), " ")
+	if required {
+		return strings.TrimSpace("required  " + desc)
+	}
+	return desc
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// referencedSchemas collects every component schema op refers to, directly
+// or through other schemas
+func referencedSchemas(spec *api.Spec, op *api.Operation) map[string]*api.Schema {
+	found := map[string]*api.Schema{}
+	var walk func(s *api.Schema)
+	walk = func(s *api.Schema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			name := api.RefName(s.Ref)
+			if _, seen := found[name]; seen {
+				return
+			}
+			target := spec.Components.Schemas[name]
+			found[name] = target
+			walk(target)
+			return
+		}
+		walk(s.Items)
+		walk(s.ValueSchema())
+		for _, name := range sortedKeys(s.Properties) {
+			walk(s.Properties[name])
+		}
+		for _, group := range [][]*api.Schema{s.OneOf, s.AnyOf, s.AllOf} {
+			for _, v := range group {
+				walk(v)
+			}
+		}
+	}
+
+	for _, p := range op.Parameters {
+		walk(p.Schema)
+	}
+	if op.RequestBody != nil {
+		for _, mt := range op.RequestBody.Content {
+			walk(mt.Schema)
+		}
+	}
+	for _, resp := range op.Responses {
+		for _, mt := range resp.Content {
+			walk(mt.Schema)
+		}
+	}
+	return found
+}
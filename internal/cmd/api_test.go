@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func TestFindAPIOperation(t *testing.T) {
+	spec, err := api.LoadSpec()
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	for _, query := range []string{
+		"page_execute",
+		"page-execute",
+		"PageExecute",
+		"POST /sessions/{session_id}/page/execute",
+		"/sessions/{session_id}/page/execute/",
+	} {
+		op, err := findAPIOperation(spec, query)
+		if err != nil {
+			t.Errorf("findAPIOperation(%q) error = %v", query, err)
+			continue
+		}
+		if op.OperationID != "page_execute" {
+			t.Errorf("findAPIOperation(%q) = %s", query, op.OperationID)
+		}
+	}
+
+	_, err = findAPIOperation(spec, "page_exectue")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "page_execute"?`) {
+		t.Errorf("unexpected error for a typo: %v", err)
+	}
+
+	_, err = findAPIOperation(spec, "/sessions/{session_id}/cookies")
+	if err == nil || !strings.Contains(err.Error(), "GET /sessions/{session_id}/cookies, POST /sessions/{session_id}/cookies") {
+		t.Errorf("unexpected error for an ambiguous path: %v", err)
+	}
+}
+
+func TestRunAPIDescribe_Text(t *testing.T) {
+	origFormat, origDepth := outputFormat, apiDescribeDepth
+	t.Cleanup(func() { outputFormat, apiDescribeDepth = origFormat, origDepth })
+	outputFormat = "text"
+	apiDescribeDepth = 2
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runAPIDescribe(apiDescribeCmd, []string{"session_cookies_set"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	for _, want := range []string{
+		"POST /sessions/{session_id}/cookies",
+		"session_id",
+		"Request body (application/json, required)",
+		"cookies",
+		"array<Cookie>",
+		"httpOnly",
+		"200  ExecutionResponse",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("output does not contain %q:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestRunAPIDescribe_JSON(t *testing.T) {
+	origFormat := outputFormat
+	t.Cleanup(func() { outputFormat = origFormat })
+	outputFormat = "json"
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runAPIDescribe(apiDescribeCmd, []string{"session_cookies_set"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var got struct {
+		Operation api.Operation          `json:"operation"`
+		Schemas   map[string]*api.Schema `json:"schemas"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout)
+	}
+	if got.Operation.OperationID != "session_cookies_set" {
+		t.Errorf("operationId = %q", got.Operation.OperationID)
+	}
+	// Cookie is only reachable through the request body schema
+	for _, name := range []string{"Cookie", "ExecutionResponse"} {
+		if got.Schemas[name] == nil {
+			t.Errorf("schemas does not include %s", name)
+		}
+	}
+}
+
+func TestRunAPIDescribe_List(t *testing.T) {
+	origFormat := outputFormat
+	t.Cleanup(func() { outputFormat = origFormat })
+	outputFormat = "json"
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runAPIDescribe(apiDescribeCmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var rows []apiOperationRow
+	if err := json.Unmarshal([]byte(stdout), &rows); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout)
+	}
+	found := false
+	for _, r := range rows {
+		found = found || (r.OperationID == "page_execute" && r.Method == "POST")
+	}
+	if !found {
+		t.Error("operation list does not include page_execute")
+	}
+}
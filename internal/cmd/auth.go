@@ -2,12 +2,12 @@ package cmd
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/nottelabs/notte-cli/internal/auth"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
 var authCmd = &cobra.Command{
@@ -49,7 +49,7 @@ func init() {
 }
 
 func runAuthLogin(cmd *cobra.Command, args []string) error {
-	PrintInfo("Opening browser for authentication...")
+	PrintInfo(i18n.T(i18n.OpeningBrowserForAuthentication))
 
 	server := auth.NewSetupServer()
 
@@ -58,14 +58,14 @@ func runAuthLogin(cmd *cobra.Command, args []string) error {
 
 	result, err := server.Start(ctx)
 	if err != nil {
-		return fmt.Errorf("authentication failed: %w", err)
+		return i18n.Errorf(i18n.AuthenticationFailed, err)
 	}
 
 	if result.Error != nil {
 		return result.Error
 	}
 
-	return PrintResult("API key stored successfully in keychain.", map[string]any{
+	return PrintResult(i18n.T(i18n.APIKeyStored), map[string]any{
 		"authenticated": true,
 		"source":        "keychain",
 	})
@@ -73,10 +73,10 @@ func runAuthLogin(cmd *cobra.Command, args []string) error {
 
 func runAuthLogout(cmd *cobra.Command, args []string) error {
 	if err := auth.DeleteKeyringAPIKey(); err != nil {
-		return fmt.Errorf("failed to remove API key: %w", err)
+		return i18n.Errorf(i18n.FailedToRemoveAPIKey, err)
 	}
 
-	return PrintResult("API key removed from keychain.", map[string]any{
+	return PrintResult(i18n.T(i18n.APIKeyRemoved), map[string]any{
 		"authenticated": false,
 	})
 }
@@ -84,17 +84,10 @@ func runAuthLogout(cmd *cobra.Command, args []string) error {
 func runAuthStatus(cmd *cobra.Command, args []string) error {
 	key, source, err := auth.GetAPIKey("")
 	if err != nil {
-		return fmt.Errorf("not authenticated: %w", err)
-	}
-
-	// Mask key for display (handle short keys safely)
-	var masked string
-	if len(key) < 12 {
-		masked = "****"
-	} else {
-		masked = key[:8] + "..." + key[len(key)-4:]
+		return i18n.Errorf(i18n.NotAuthenticated, err)
 	}
 
+	masked := maskAPIKey(key)
 	envLabel := auth.ResolveEnvLabel(auth.GetCurrentAPIURL())
 
 	formatter := GetFormatter()
@@ -107,3 +100,11 @@ func runAuthStatus(cmd *cobra.Command, args []string) error {
 
 	return formatter.Print(data)
 }
+
+// maskAPIKey shortens key for display, hiding all of short keys
+func maskAPIKey(key string) string {
+	if len(key) < 12 {
+		return "****"
+	}
+	return key[:8] + "..." + key[len(key)-4:]
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// callbackTimeout bounds the completion POST so a slow receiver can't hold
+// up the pipeline that is waiting on the command
+const callbackTimeout = 10 * time.Second
+
+// callbackResult is what the running command reports in the callback
+// payload, set with recordCallbackResult
+var callbackResult any
+
+// callbackPayload is POSTed to --callback-url when the command finishes
+type callbackPayload struct {
+	Command    string    `json:"command"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	DurationMs int64     `json:"duration_ms"`
+	Result     any       `json:"result,omitempty"`
+}
+
+// addCallbackURLFlag registers --callback-url on a long-running command and
+// wraps its RunE to POST a completion summary, whether it succeeds or not
+func addCallbackURLFlag(cmd *cobra.Command) {
+	cmd.Flags().String("callback-url", "", "POST a JSON completion summary to this URL when the command finishes")
+
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		callbackURL, _ := cmd.Flags().GetString("callback-url")
+		if callbackURL == "" {
+			return run(cmd, args)
+		}
+		if u, err := url.Parse(callbackURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return i18n.Errorf(i18n.InvalidCallbackURL, callbackURL)
+		}
+
+		callbackResult = nil
+		started := time.Now()
+		runErr := run(cmd, args)
+		finished := time.Now()
+
+		payload := callbackPayload{
+			Command:    cmd.CommandPath(),
+			Success:    runErr == nil,
+			StartedAt:  started.UTC(),
+			FinishedAt: finished.UTC(),
+			DurationMs: finished.Sub(started).Milliseconds(),
+			Result:     callbackResult,
+		}
+		if runErr != nil {
+			payload.Error = runErr.Error()
+		}
+		if err := postCallback(cmd.Context(), callbackURL, payload); err != nil {
+			PrintInfo(i18n.T(i18n.WarningCallbackFailed, callbackURL, err))
+		}
+		return runErr
+	}
+}
+
+// recordCallbackResult sets the result sent with --callback-url
+func recordCallbackResult(v any) {
+	callbackResult = v
+}
+
+func postCallback(ctx context.Context, callbackURL string, payload callbackPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	// The command's own context may already be cancelled by a timeout
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), callbackTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "notte-cli")
+
+	// Without the API client, so the API key is never sent to the receiver
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
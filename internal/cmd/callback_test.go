@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newCallbackTestCmd(run func(cmd *cobra.Command, args []string) error, callbackURL string) *cobra.Command {
+	cmd := &cobra.Command{Use: "wait", RunE: run}
+	cmd.SetContext(context.Background())
+	addCallbackURLFlag(cmd)
+	_ = cmd.Flags().Set("callback-url", callbackURL)
+	return cmd
+}
+
+func TestAddCallbackURLFlag_PostsSummary(t *testing.T) {
+	payloads := make(chan callbackPayload, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "" {
+			t.Errorf("unexpected request: %s %v", r.Method, r.Header)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var p callbackPayload
+		if err := json.Unmarshal(body, &p); err != nil {
+			t.Errorf("invalid payload %s: %v", body, err)
+		}
+		payloads <- p
+	}))
+	defer receiver.Close()
+
+	tests := []struct {
+		name    string
+		runErr  error
+		wantErr string
+	}{
+		{"success", nil, ""},
+		{"failure", errors.New("agent reached failed"), "agent reached failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newCallbackTestCmd(func(cmd *cobra.Command, args []string) error {
+				recordCallbackResult(map[string]string{"status": "closed"})
+				return tt.runErr
+			}, receiver.URL)
+
+			if err := cmd.RunE(cmd, nil); !errors.Is(err, tt.runErr) {
+				t.Fatalf("expected the command's own error, got %v", err)
+			}
+
+			p := <-payloads
+			if p.Command != "wait" || p.Success != (tt.runErr == nil) || p.Error != tt.wantErr {
+				t.Errorf("unexpected payload: %+v", p)
+			}
+			if result, _ := p.Result.(map[string]any); result["status"] != "closed" {
+				t.Errorf("result = %v", p.Result)
+			}
+			if p.FinishedAt.Before(p.StartedAt) {
+				t.Errorf("finished_at %v before started_at %v", p.FinishedAt, p.StartedAt)
+			}
+		})
+	}
+}
+
+func TestAddCallbackURLFlag_InvalidURL(t *testing.T) {
+	ran := false
+	cmd := newCallbackTestCmd(func(cmd *cobra.Command, args []string) error {
+		ran = true
+		return nil
+	}, "ftp://example.com/hook")
+
+	if err := cmd.RunE(cmd, nil); err == nil || !strings.Contains(err.Error(), "invalid --callback-url") {
+		t.Fatalf("expected invalid URL error, got %v", err)
+	}
+	if ran {
+		t.Error("command should not run with an invalid callback URL")
+	}
+}
+
+func TestAddCallbackURLFlag_ReceiverErrorKeepsResult(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer receiver.Close()
+
+	cmd := newCallbackTestCmd(func(cmd *cobra.Command, args []string) error { return nil }, receiver.URL)
+	if err := cmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("a failed callback should not fail the command: %v", err)
+	}
+}
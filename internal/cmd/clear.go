@@ -1,7 +1,7 @@
 package cmd
 
 import (
-	"fmt"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 
 	"github.com/spf13/cobra"
 )
@@ -19,22 +19,22 @@ func init() {
 
 func runClear(cmd *cobra.Command, args []string) error {
 	if err := clearCurrentSession(); err != nil {
-		return fmt.Errorf("failed to clear current session: %w", err)
+		return i18n.Errorf(i18n.FailedToClearCurrentSession, err)
 	}
 	if err := clearCurrentViewerURL(); err != nil {
-		return fmt.Errorf("failed to clear current viewer URL: %w", err)
+		return i18n.Errorf(i18n.FailedToClearCurrentViewerURL, err)
 	}
 	if err := clearCurrentAgent(); err != nil {
-		return fmt.Errorf("failed to clear current agent: %w", err)
+		return i18n.Errorf(i18n.FailedToClearCurrentAgent, err)
 	}
 	if err := clearCurrentFunction(); err != nil {
-		return fmt.Errorf("failed to clear current function: %w", err)
+		return i18n.Errorf(i18n.FailedToClearCurrentFunction, err)
 	}
 	if err := clearCurrentSessionExpiry(); err != nil {
-		return fmt.Errorf("failed to clear current session expiry: %w", err)
+		return i18n.Errorf(i18n.FailedToClearCurrentSessionExpiry, err)
 	}
 
-	return PrintResult("Cleared all stored state (session, viewer URL, agent, function, session expiry).", map[string]any{
+	return PrintResult(i18n.T(i18n.ClearedAllState), map[string]any{
 		"cleared": []string{"session", "viewer_url", "agent", "function", "session_expiry"},
 		"success": true,
 	})
@@ -3,119 +3,304 @@ package cmd
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	apierrors "github.com/nottelabs/notte-cli/internal/errors"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
 // skipConfirmation is set by --yes flag to skip prompts
 var skipConfirmation bool
 
-// ConfirmAction prompts the user to confirm a destructive action.
-// Returns true if confirmed, false otherwise.
-func ConfirmAction(resource, id string) (bool, error) {
-	if skipConfirmation {
-		return true, nil
+// nonInteractiveFlag is the --non-interactive flag
+var nonInteractiveFlag bool
+
+// nonInteractive is set by --non-interactive, NOTTE_NONINTERACTIVE or CI to
+// answer prompts without reading stdin
+var nonInteractive bool
+
+// Values of non_interactive in the config
+const (
+	nonInteractiveDeny = "deny"
+	nonInteractiveYes  = "yes"
+)
+
+// SetNonInteractive sets whether prompts are answered without reading stdin.
+func SetNonInteractive(enabled bool) {
+	nonInteractive = enabled
+}
+
+// nonInteractiveFromEnv reports whether NOTTE_NONINTERACTIVE asks for
+// non-interactive mode. When it is unset, CI=true turns it on, as set by
+// GitHub Actions, GitLab CI and most other CI services.
+func nonInteractiveFromEnv() bool {
+	if v, ok := os.LookupEnv(config.EnvNonInteractive); ok && v != "" {
+		enabled, err := strconv.ParseBool(v)
+		return err == nil && enabled
 	}
-	return ConfirmActionWithIO(os.Stdin, os.Stderr, resource, id)
+	ci, err := strconv.ParseBool(os.Getenv("CI"))
+	return err == nil && ci
 }
 
-// ConfirmActionWithIO is the testable version of ConfirmAction.
-func ConfirmActionWithIO(in io.Reader, out io.Writer, resource, id string) (bool, error) {
-	if _, err := fmt.Fprintf(out, "Delete %s %s? This cannot be undone. [y/N]: ", resource, id); err != nil {
-		return false, fmt.Errorf("failed to write prompt: %w", err)
+// confirmation is a yes/no question asked before an action. Every prompt in
+// the CLI goes through it, so --yes, non-interactive mode, answer parsing and
+// the audit log work the same way for all of them.
+type confirmation struct {
+	Action   string // What is confirmed, e.g. "delete"
+	Resource string // Resource type, e.g. "vault"
+	ID       string // Resource ID (optional)
+	Question string // Shown before the [y/N] choice
+	// DefaultYes makes an empty answer confirm
+	DefaultYes bool
+	// Policy confirmations are required by the safety policy: --yes doesn't
+	// skip them and non-interactive runs always decline them
+	Policy bool
+}
+
+// Ways a confirmation was answered, as recorded in the audit log
+const (
+	answeredByPrompt         = "prompt"
+	answeredByYesFlag        = "--yes"
+	answeredByNonInteractive = "non_interactive"
+)
+
+// confirmationRecord is one line of the confirmations audit log
+type confirmationRecord struct {
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"`
+	Resource  string    `json:"resource"`
+	ID        string    `json:"id,omitempty"`
+	Confirmed bool      `json:"confirmed"`
+	Via       string    `json:"via"`
+}
+
+// ask answers c with --yes or the non-interactive policy, or else prompts on
+// the terminal, and records the answer.
+func (c confirmation) ask() (bool, error) {
+	confirmed, via, err := c.answer()
+	if via != "" {
+		auditConfirmation(c, confirmed, via)
+	}
+	return confirmed, err
+}
+
+// answer returns the answer to c and how it was given: by --yes, by the
+// non-interactive policy or at the prompt. The way is empty when c couldn't
+// be answered at all.
+func (c confirmation) answer() (bool, string, error) {
+	if skipConfirmation && !c.Policy {
+		return true, answeredByYesFlag, nil
+	}
+	if !nonInteractive {
+		confirmed, err := c.askWithIO(os.Stdin, os.Stderr)
+		if err != nil {
+			return false, "", err
+		}
+		return confirmed, answeredByPrompt, nil
+	}
+	if c.Policy {
+		return false, answeredByNonInteractive, nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return false, "", i18n.Errorf(i18n.FailedToLoadConfig, err)
+	}
+	switch cfg.NonInteractive {
+	case nonInteractiveYes:
+		return true, answeredByNonInteractive, nil
+	case "", nonInteractiveDeny:
+		return false, answeredByNonInteractive, &apierrors.ConfirmationRequiredError{
+			Action:   c.Action,
+			Resource: c.Resource,
+			ID:       c.ID,
+			Reason:   i18n.T(i18n.NonInteractiveDenied),
+		}
+	default:
+		return false, "", i18n.Errorf(i18n.InvalidNonInteractiveConfig, cfg.NonInteractive, nonInteractiveDeny, nonInteractiveYes)
+	}
+}
+
+// askWithIO prompts on out and reads the answer from in. An empty answer
+// takes the default shown in capitals; anything but yes (y, or its
+// translation) declines, so a typo never confirms.
+func (c confirmation) askWithIO(in io.Reader, out io.Writer) (bool, error) {
+	choice := i18n.T(i18n.ChoiceDefaultNo)
+	if c.DefaultYes {
+		choice = i18n.T(i18n.ChoiceDefaultYes)
+	}
+	if _, err := fmt.Fprintf(out, "%s %s: ", c.Question, choice); err != nil {
+		return false, i18n.Errorf(i18n.FailedToWritePrompt, err)
 	}
 
 	reader := bufio.NewReader(in)
 	response, err := reader.ReadString('\n')
 	if err != nil && err != io.EOF {
-		return false, fmt.Errorf("failed to read response: %w", err)
+		return false, i18n.Errorf(i18n.FailedToReadResponse, err)
 	}
 
 	response = strings.TrimSpace(strings.ToLower(response))
-	return response == "y" || response == "yes", nil
+	if response == "" {
+		return c.DefaultYes, nil
+	}
+	// English answers work in every language
+	return response == "y" || response == "yes" || slices.Contains(i18n.List(i18n.YesAnswers), response), nil
+}
+
+// auditConfirmation appends the answer to c to the confirmations log in the
+// config directory. The log is best-effort: failing to write it doesn't
+// fail the command.
+func auditConfirmation(c confirmation, confirmed bool, via string) {
+	configDir, err := config.Dir()
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(confirmationRecord{
+		Time:      time.Now().UTC(),
+		Action:    c.Action,
+		Resource:  c.Resource,
+		ID:        c.ID,
+		Confirmed: confirmed,
+		Via:       via,
+	})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(configDir, 0o700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(filepath.Join(configDir, config.ConfirmationsLogFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer func() { _ = f.Close() }()
+	_, _ = f.Write(append(data, '\n'))
+}
+
+// SetSkipConfirmation sets whether to skip confirmation prompts (for --yes flag).
+func SetSkipConfirmation(skip bool) {
+	skipConfirmation = skip
+}
+
+// deleteConfirmation asks to delete a resource. Defaults to "no".
+func deleteConfirmation(resource, id string) confirmation {
+	return confirmation{
+		Action:   "delete",
+		Resource: resource,
+		ID:       id,
+		Question: i18n.T(i18n.ConfirmDelete, resource, id),
+	}
+}
+
+// ConfirmAction prompts the user to confirm a destructive action.
+// Returns true if confirmed, false otherwise.
+func ConfirmAction(resource, id string) (bool, error) {
+	return deleteConfirmation(resource, id).ask()
+}
+
+// ConfirmActionWithIO is the testable version of ConfirmAction.
+func ConfirmActionWithIO(in io.Reader, out io.Writer, resource, id string) (bool, error) {
+	return deleteConfirmation(resource, id).askWithIO(in, out)
+}
+
+// replaceSessionConfirmation asks to stop the current session before
+// starting a new one. Defaults to "yes".
+func replaceSessionConfirmation(id string) confirmation {
+	return confirmation{
+		Action:     "stop",
+		Resource:   "current session",
+		ID:         id,
+		Question:   i18n.T(i18n.ConfirmReplaceSession, id),
+		DefaultYes: true,
+	}
 }
 
 // confirmReplaceSession prompts the user to confirm stopping an existing session before starting a new one.
 // Defaults to "yes" if user just presses Enter.
 func confirmReplaceSession(id string) (bool, error) {
-	if skipConfirmation {
-		return true, nil
-	}
-	return confirmReplaceSessionWithIO(os.Stdin, os.Stderr, id)
+	return replaceSessionConfirmation(id).ask()
 }
 
 // confirmReplaceSessionWithIO is the testable version of confirmReplaceSession.
 func confirmReplaceSessionWithIO(in io.Reader, out io.Writer, id string) (bool, error) {
-	if _, err := fmt.Fprintf(out, "Session %s is currently active. A new session will be created either way.\nStop the existing session before starting the new one? [Y/n]: ", id); err != nil {
-		return false, fmt.Errorf("failed to write prompt: %w", err)
-	}
+	return replaceSessionConfirmation(id).askWithIO(in, out)
+}
 
-	reader := bufio.NewReader(in)
-	response, err := reader.ReadString('\n')
-	if err != nil && err != io.EOF {
-		return false, fmt.Errorf("failed to read response: %w", err)
+// replaceAgentConfirmation asks to stop the current agent before starting a
+// new one. Defaults to "yes".
+func replaceAgentConfirmation(id string) confirmation {
+	return confirmation{
+		Action:     "stop",
+		Resource:   "current agent",
+		ID:         id,
+		Question:   i18n.T(i18n.ConfirmReplaceAgent, id),
+		DefaultYes: true,
 	}
-
-	response = strings.TrimSpace(strings.ToLower(response))
-	return response != "n" && response != "no", nil
 }
 
 // confirmReplaceAgent prompts the user to confirm stopping an existing agent before starting a new one.
 // Defaults to "yes" if user just presses Enter.
 func confirmReplaceAgent(id string) (bool, error) {
-	if skipConfirmation {
-		return true, nil
-	}
-	return confirmReplaceAgentWithIO(os.Stdin, os.Stderr, id)
+	return replaceAgentConfirmation(id).ask()
 }
 
 // confirmReplaceAgentWithIO is the testable version of confirmReplaceAgent.
 func confirmReplaceAgentWithIO(in io.Reader, out io.Writer, id string) (bool, error) {
-	if _, err := fmt.Fprintf(out, "Agent %s is currently active. A new agent will be started either way.\nStop the existing agent before starting the new one? [Y/n]: ", id); err != nil {
-		return false, fmt.Errorf("failed to write prompt: %w", err)
-	}
-
-	reader := bufio.NewReader(in)
-	response, err := reader.ReadString('\n')
-	if err != nil && err != io.EOF {
-		return false, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	response = strings.TrimSpace(strings.ToLower(response))
-	return response != "n" && response != "no", nil
+	return replaceAgentConfirmation(id).askWithIO(in, out)
 }
 
-// SetSkipConfirmation sets whether to skip confirmation prompts (for --yes flag).
-func SetSkipConfirmation(skip bool) {
-	skipConfirmation = skip
+// stopConfirmation asks to stop a resource. Defaults to "yes".
+func stopConfirmation(resource, id string) confirmation {
+	return confirmation{
+		Action:     "stop",
+		Resource:   resource,
+		ID:         id,
+		Question:   i18n.T(i18n.ConfirmStop, resource, id),
+		DefaultYes: true,
+	}
 }
 
 // ConfirmStop prompts the user to confirm stopping a resource.
 // Defaults to "yes" if user just presses Enter.
 // Returns true if confirmed, false otherwise.
 func ConfirmStop(resource, id string) (bool, error) {
-	if skipConfirmation {
-		return true, nil
-	}
-	return ConfirmStopWithIO(os.Stdin, os.Stderr, resource, id)
+	return stopConfirmation(resource, id).ask()
 }
 
 // ConfirmStopWithIO is the testable version of ConfirmStop.
 func ConfirmStopWithIO(in io.Reader, out io.Writer, resource, id string) (bool, error) {
-	if _, err := fmt.Fprintf(out, "Stop %s %s? This cannot be undone. [Y/n]: ", resource, id); err != nil {
-		return false, fmt.Errorf("failed to write prompt: %w", err)
-	}
+	return stopConfirmation(resource, id).askWithIO(in, out)
+}
 
-	reader := bufio.NewReader(in)
-	response, err := reader.ReadString('\n')
-	if err != nil && err != io.EOF {
-		return false, fmt.Errorf("failed to read response: %w", err)
+// agentStepsConfirmation asks to start an agent that is estimated to take
+// more steps than the policy allows. Like the rest of the policy, --yes
+// doesn't skip it, and it is always declined in non-interactive mode.
+// Defaults to "no".
+func agentStepsConfirmation(estimate string, limit int) confirmation {
+	return confirmation{
+		Action:   "start",
+		Resource: "agent",
+		Question: i18n.T(i18n.ConfirmAgentSteps, estimate, limit),
+		Policy:   true,
 	}
+}
 
-	response = strings.TrimSpace(strings.ToLower(response))
-	// Default to yes if empty, or accept explicit "y" or "yes"
-	// Only "n" or "no" will cancel
-	return response != "n" && response != "no", nil
+// confirmAgentSteps prompts the user to confirm starting an agent that is
+// estimated to take more steps than the policy allows.
+func confirmAgentSteps(estimate string, limit int) (bool, error) {
+	return agentStepsConfirmation(estimate, limit).ask()
+}
+
+// confirmAgentStepsWithIO is the testable version of confirmAgentSteps.
+func confirmAgentStepsWithIO(in io.Reader, out io.Writer, estimate string, limit int) (bool, error) {
+	return agentStepsConfirmation(estimate, limit).askWithIO(in, out)
 }
@@ -2,9 +2,17 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	apierrors "github.com/nottelabs/notte-cli/internal/errors"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/testutil"
 )
 
 type errWriter struct{}
@@ -20,6 +28,7 @@ func (errReader) Read(p []byte) (int, error) {
 }
 
 func TestConfirmAction_Skip(t *testing.T) {
+	testutil.SetupTestEnv(t)
 	SetSkipConfirmation(true)
 	t.Cleanup(func() { SetSkipConfirmation(false) })
 
@@ -63,6 +72,7 @@ func TestConfirmActionWithIO_Errors(t *testing.T) {
 }
 
 func TestConfirmReplaceSession_Skip(t *testing.T) {
+	testutil.SetupTestEnv(t)
 	SetSkipConfirmation(true)
 	t.Cleanup(func() { SetSkipConfirmation(false) })
 
@@ -123,6 +133,7 @@ func TestConfirmReplaceSessionWithIO_Errors(t *testing.T) {
 }
 
 func TestConfirmReplaceAgent_Skip(t *testing.T) {
+	testutil.SetupTestEnv(t)
 	SetSkipConfirmation(true)
 	t.Cleanup(func() { SetSkipConfirmation(false) })
 
@@ -181,3 +192,201 @@ func TestConfirmReplaceAgentWithIO_Errors(t *testing.T) {
 		t.Fatal("expected read error")
 	}
 }
+
+func TestNonInteractiveFromEnv(t *testing.T) {
+	tests := []struct {
+		name, env, ci string
+		want          bool
+	}{
+		{"unset", "", "", false},
+		{"env on", "1", "", true},
+		{"env true", "true", "", true},
+		{"CI", "", "true", true},
+		{"env off in CI", "0", "true", false},
+		{"invalid env", "maybe", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.EnvNonInteractive, tt.env)
+			t.Setenv("CI", tt.ci)
+			if got := nonInteractiveFromEnv(); got != tt.want {
+				t.Errorf("nonInteractiveFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func setupNonInteractiveTest(t *testing.T, policy string) {
+	t.Helper()
+	env := testutil.SetupTestEnv(t)
+	config.SetTestConfigDir(env.TempDir)
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+	SetNonInteractive(true)
+	t.Cleanup(func() { SetNonInteractive(false) })
+	if policy != "" {
+		if err := (&config.Config{NonInteractive: policy}).Save(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestConfirm_NonInteractiveDeny(t *testing.T) {
+	setupNonInteractiveTest(t, "")
+
+	prompts := map[string]func() (bool, error){
+		"ConfirmAction":         func() (bool, error) { return ConfirmAction("vault", "vault_123") },
+		"ConfirmStop":           func() (bool, error) { return ConfirmStop("session", "sess_123") },
+		"confirmReplaceSession": func() (bool, error) { return confirmReplaceSession("sess_123") },
+		"confirmReplaceAgent":   func() (bool, error) { return confirmReplaceAgent("agent_123") },
+	}
+	for name, prompt := range prompts {
+		ok, err := prompt()
+		var confirmErr *apierrors.ConfirmationRequiredError
+		if ok || !errors.As(err, &confirmErr) || !strings.Contains(err.Error(), "non-interactive mode; pass --yes") {
+			t.Errorf("%s: expected a denial, got %v, %v", name, ok, err)
+		}
+	}
+
+	_, err := ConfirmAction("vault", "vault_123")
+	var confirmErr *apierrors.ConfirmationRequiredError
+	if !errors.As(err, &confirmErr) || confirmErr.Action != "delete" || confirmErr.Resource != "vault" || confirmErr.ID != "vault_123" {
+		t.Errorf("unexpected error: %#v", err)
+	}
+
+	// --yes still confirms
+	SetSkipConfirmation(true)
+	t.Cleanup(func() { SetSkipConfirmation(false) })
+	if ok, err := ConfirmAction("vault", "vault_123"); !ok || err != nil {
+		t.Errorf("expected --yes to confirm, got %v, %v", ok, err)
+	}
+}
+
+func TestConfirm_NonInteractiveYes(t *testing.T) {
+	setupNonInteractiveTest(t, "yes")
+
+	if ok, err := ConfirmAction("vault", "vault_123"); !ok || err != nil {
+		t.Errorf("expected confirmation, got %v, %v", ok, err)
+	}
+	if ok, err := confirmReplaceSession("sess_123"); !ok || err != nil {
+		t.Errorf("expected confirmation, got %v, %v", ok, err)
+	}
+	// The agent step policy isn't skipped by --yes, so it is declined either way
+	if ok, err := confirmAgentSteps("~40 steps", 30); ok || err != nil {
+		t.Errorf("expected the step confirmation to be declined, got %v, %v", ok, err)
+	}
+}
+
+func TestConfirm_NonInteractiveInvalidPolicy(t *testing.T) {
+	setupNonInteractiveTest(t, "sometimes")
+
+	_, err := ConfirmStop("session", "sess_123")
+	if err == nil || !strings.Contains(err.Error(), `invalid non_interactive "sometimes"`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestConfirmationAskWithIO_Answers(t *testing.T) {
+	tests := []struct {
+		input      string
+		defaultYes bool
+		expected   bool
+	}{
+		{"\n", false, false},
+		{"\n", true, true},
+		{"y\n", false, true},
+		{" Yes \n", false, true},
+		{"n\n", true, false},
+		// Anything else declines, whatever the default
+		{"maybe\n", false, false},
+		{"maybe\n", true, false},
+		{"", true, true}, // EOF takes the default
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		c := confirmation{Action: "stop", Resource: "session", ID: "sess_123", Question: "Stop session sess_123?", DefaultYes: tt.defaultYes}
+		ok, err := c.askWithIO(strings.NewReader(tt.input), &out)
+		if err != nil {
+			t.Fatalf("input %q: unexpected error: %v", tt.input, err)
+		}
+		if ok != tt.expected {
+			t.Errorf("input %q (default yes %v): expected %v, got %v", tt.input, tt.defaultYes, tt.expected, ok)
+		}
+		want := "Stop session sess_123? [y/N]: "
+		if tt.defaultYes {
+			want = "Stop session sess_123? [Y/n]: "
+		}
+		if out.String() != want {
+			t.Errorf("prompt = %q, want %q", out.String(), want)
+		}
+	}
+}
+
+func readConfirmationLog(t *testing.T) []confirmationRecord {
+	t.Helper()
+	configDir, err := config.Dir()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(configDir, config.ConfirmationsLogFile))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var records []confirmationRecord
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var r confirmationRecord
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestConfirmation_Audit(t *testing.T) {
+	setupNonInteractiveTest(t, "")
+
+	_, _ = ConfirmStop("session", "sess_123")
+	SetSkipConfirmation(true)
+	t.Cleanup(func() { SetSkipConfirmation(false) })
+	_, _ = ConfirmAction("vault", "vault_123")
+	_, _ = confirmAgentSteps("~40 steps", 30)
+
+	records := readConfirmationLog(t)
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %+v", records)
+	}
+	want := []confirmationRecord{
+		{Action: "stop", Resource: "session", ID: "sess_123", Confirmed: false, Via: answeredByNonInteractive},
+		{Action: "delete", Resource: "vault", ID: "vault_123", Confirmed: true, Via: answeredByYesFlag},
+		{Action: "start", Resource: "agent", Confirmed: false, Via: answeredByNonInteractive},
+	}
+	for i, r := range records {
+		if r.Time.IsZero() {
+			t.Errorf("record %d has no time", i)
+		}
+		r.Time = want[i].Time
+		if r != want[i] {
+			t.Errorf("record %d = %+v, want %+v", i, r, want[i])
+		}
+	}
+}
+
+func TestConfirmationAskWithIO_Translated(t *testing.T) {
+	i18n.SetLocale("fr")
+	t.Cleanup(func() { i18n.SetLocale(i18n.DefaultLocale) })
+
+	tests := map[string]bool{"oui\n": true, "o\n": true, "y\n": true, "non\n": false, "\n": false}
+	for input, want := range tests {
+		var out bytes.Buffer
+		ok, err := ConfirmActionWithIO(strings.NewReader(input), &out, "vault", "vault_123")
+		if err != nil {
+			t.Fatalf("input %q: unexpected error: %v", input, err)
+		}
+		if ok != want {
+			t.Errorf("input %q: expected %v, got %v", input, want, ok)
+		}
+		if out.String() != "Supprimer vault vault_123 ? Cette action est irréversible. [o/N]: " {
+			t.Errorf("unexpected prompt %q", out.String())
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/auth"
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/daemon"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/output"
+)
+
+// daemonProbeTimeout bounds how long a command waits for the daemon before
+// falling back to talking to the API directly
+const daemonProbeTimeout = 250 * time.Millisecond
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run a local daemon that speeds up repeated commands",
+	Long: `Run a background daemon that keeps a warm connection pool to the API.
+
+While the daemon is running, commands send their requests to it over a local
+unix socket instead of opening a new TLS connection and looking up the API key
+on every invocation. This matters for rapid sequences of page commands.
+
+The daemon also caches the latest observe result per session, readable at
+GET /_daemon/observe/<session-id> on the socket, for editors and other tools.
+
+Commands given --ca-cert, --client-cert or --client-key talk to the API
+directly, since the daemon uses the TLS settings it was started with. Set
+NOTTE_NO_DAEMON=1 to bypass a running daemon.`,
+}
+
+var daemonStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the daemon in the background",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonStart,
+}
+
+var daemonRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run the daemon in the foreground",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonRun,
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the daemon",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonStop,
+}
+
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show daemon status",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+	daemonCmd.AddCommand(daemonStartCmd)
+	daemonCmd.AddCommand(daemonRunCmd)
+	daemonCmd.AddCommand(daemonStopCmd)
+	daemonCmd.AddCommand(daemonStatusCmd)
+}
+
+// connectDaemon returns a transport routed through the daemon if one is
+// running and proxies to baseURL. Any problem means "no daemon".
+func connectDaemon(baseURL string) (http.RoundTripper, *daemon.Status) {
+	if os.Getenv(config.EnvNoDaemon) != "" {
+		return nil, nil
+	}
+	// The daemon connects upstream with the TLS settings it was started
+	// with, so TLS flags given to this command only apply without it
+	if caCertFile != "" || clientCertFile != "" || clientKeyFile != "" {
+		return nil, nil
+	}
+	socketPath, err := daemon.SocketPath()
+	if err != nil {
+		return nil, nil
+	}
+	if _, err := os.Stat(socketPath); err != nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), daemonProbeTimeout)
+	defer cancel()
+	status, err := daemon.GetStatus(ctx, socketPath)
+	if err != nil || strings.TrimSuffix(status.Upstream, "/") != strings.TrimSuffix(baseURL, "/") {
+		return nil, nil
+	}
+	return daemon.Transport(socketPath), status
+}
+
+// daemonUpstream resolves the API URL the daemon should proxy to and the
+// transport it connects with
+func daemonUpstream() (string, http.RoundTripper, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", nil, err
+	}
+	transportOpts, err := transportOptions(cfg)
+	if err != nil {
+		return "", nil, err
+	}
+	transport, err := api.NewTransport(tlsOptions(cfg), transportOpts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	upstream := apiURL
+	if upstream == "" {
+		upstream = os.Getenv(config.EnvAPIURL)
+	}
+	if upstream == "" {
+		upstream = cfg.APIURL
+	}
+	if upstream == "" {
+		upstream = api.DefaultBaseURL
+	}
+	return upstream, transport, nil
+}
+
+func runDaemonRun(cmd *cobra.Command, args []string) error {
+	socketPath, err := daemon.SocketPath()
+	if err != nil {
+		return err
+	}
+	upstream, transport, err := daemonUpstream()
+	if err != nil {
+		return err
+	}
+
+	// Without a key the daemon still pools connections; the CLI then sends its own
+	apiKey, _, _ := auth.GetAPIKey("")
+
+	srv, err := daemon.NewServer(upstream, apiKey, transport)
+	if err != nil {
+		return err
+	}
+	ln, err := daemon.Listen(socketPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(socketPath) }()
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	PrintInfo(i18n.T(i18n.DaemonListening, socketPath, upstream))
+	return srv.Serve(ln)
+}
+
+func runDaemonStart(cmd *cobra.Command, args []string) error {
+	socketPath, err := daemon.SocketPath()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), daemonProbeTimeout)
+	status, err := daemon.GetStatus(ctx, socketPath)
+	cancel()
+	if err == nil {
+		return PrintResult(i18n.T(i18n.DaemonAlreadyRunningPID, status.PID), map[string]any{
+			"pid":     status.PID,
+			"socket":  socketPath,
+			"started": false,
+		})
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToLocateNotteExecutable, err)
+	}
+	logPath := filepath.Join(filepath.Dir(socketPath), "daemon.log")
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToOpenDaemonLog, err)
+	}
+	defer func() { _ = logFile.Close() }()
+
+	child := exec.Command(exe, "daemon", "run")
+	child.Stdout = logFile
+	child.Stderr = logFile
+	detachProcess(child)
+	if err := child.Start(); err != nil {
+		return i18n.Errorf(i18n.FailedToStartDaemon, err)
+	}
+	_ = child.Process.Release()
+
+	// Wait for the socket to come up
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		ctx, cancel := context.WithTimeout(cmd.Context(), daemonProbeTimeout)
+		status, err = daemon.GetStatus(ctx, socketPath)
+		cancel()
+		if err == nil {
+			return PrintResult(i18n.T(i18n.DaemonStartedPID, status.PID), map[string]any{
+				"pid":     status.PID,
+				"socket":  socketPath,
+				"started": true,
+			})
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return i18n.Errorf(i18n.DaemonStartTimeout, logPath)
+}
+
+func runDaemonStop(cmd *cobra.Command, args []string) error {
+	socketPath, err := daemon.SocketPath()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Second)
+	defer cancel()
+	if err := daemon.Stop(ctx, socketPath); err != nil {
+		return i18n.Errorf(i18n.NoDaemonRunning, err)
+	}
+	return PrintResult(i18n.T(i18n.DaemonStopped), map[string]any{"stopped": true})
+}
+
+func runDaemonStatus(cmd *cobra.Command, args []string) error {
+	socketPath, err := daemon.SocketPath()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Second)
+	defer cancel()
+	status, err := daemon.GetStatus(ctx, socketPath)
+	if err != nil {
+		if IsJSONOutput() {
+			return GetFormatter().Print(map[string]any{"running": false})
+		}
+		return PrintResult(i18n.T(i18n.DaemonIsNotRunning), nil)
+	}
+
+	if IsJSONOutput() {
+		return GetFormatter().Print(map[string]any{
+			"running": true,
+			"socket":  socketPath,
+			"status":  status,
+		})
+	}
+	return PrintResult(i18n.T(i18n.DaemonStatus,
+		status.PID, socketPath, status.Upstream, output.FormatTime(status.StartedAt, time.Now(), utcTimes), status.Requests), nil)
+}
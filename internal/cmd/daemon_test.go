@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/daemon"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+// setupDaemonTest points the config dir at a short temp dir (unix socket
+// paths are length-limited) and returns the daemon socket path
+func setupDaemonTest(t *testing.T) string {
+	t.Helper()
+
+	testutil.SetupTestEnv(t)
+	dir, err := os.MkdirTemp("", "nd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	config.SetTestConfigDir(dir)
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+
+	socketPath, err := daemon.SocketPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return socketPath
+}
+
+func TestConnectDaemon_NoSocket(t *testing.T) {
+	setupDaemonTest(t)
+
+	if rt, status := connectDaemon("https://api.notte.cc"); rt != nil || status != nil {
+		t.Error("expected no daemon connection without a socket")
+	}
+}
+
+func TestConnectDaemon(t *testing.T) {
+	socketPath := setupDaemonTest(t)
+
+	srv, err := daemon.NewServer("https://api.example.com", "daemon-key", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := daemon.Listen(socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+
+	rt, status := connectDaemon("https://api.example.com/")
+	if rt == nil || status == nil || !status.HasAPIKey {
+		t.Fatalf("connectDaemon() = %v, %+v; want daemon connection", rt, status)
+	}
+
+	if rt, _ := connectDaemon("https://other.example.com"); rt != nil {
+		t.Error("expected no daemon connection for a different upstream")
+	}
+
+	origCACert := caCertFile
+	caCertFile = "ca.pem"
+	rt, _ = connectDaemon("https://api.example.com")
+	caCertFile = origCACert
+	if rt != nil {
+		t.Error("expected --ca-cert to bypass the daemon")
+	}
+
+	t.Setenv(config.EnvNoDaemon, "1")
+	if rt, _ := connectDaemon("https://api.example.com"); rt != nil {
+		t.Errorf("expected %s to bypass the daemon", config.EnvNoDaemon)
+	}
+}
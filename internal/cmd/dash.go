@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// dashRecentFunctions is how many functions are polled for recent runs,
+// since runs can only be listed per function
+const dashRecentFunctions = 5
+
+const dashMaxRuns = 20
+
+var dashInterval time.Duration
+
+var dashCmd = &cobra.Command{
+	Use:   "dash",
+	Short: "Live dashboard of sessions, agents, function runs and usage",
+	Long: `Open a full-screen dashboard that polls active sessions, running agents,
+recent function runs and usage.
+
+Keys:
+  tab / shift+tab   Switch between sessions, agents and function runs
+  j / k             Move the selection
+  s                 Stop the selected item (asks first unless --yes)
+  i / enter         Show the selected item as JSON
+  v                 Open the selected session's viewer in the browser
+  r                 Refresh now
+  q                 Quit`,
+	Example: `  notte dash
+  notte dash --interval 2s`,
+	Args: cobra.NoArgs,
+	RunE: runDash,
+}
+
+func init() {
+	rootCmd.AddCommand(dashCmd)
+
+	dashCmd.Flags().DurationVar(&dashInterval, "interval", 5*time.Second, "How often to refresh")
+}
+
+// fetchDashSnapshot polls everything the dashboard shows. Failures are
+// recorded per section so one broken endpoint doesn't blank the screen.
+func fetchDashSnapshot(ctx context.Context, client *api.NotteClient) dashSnapshot {
+	snap := dashSnapshot{FetchedAt: time.Now()}
+	onlyActive := true
+
+	sessions, err := client.Client().ListSessionsWithResponse(ctx, &api.ListSessionsParams{OnlyActive: &onlyActive})
+	if err == nil {
+		err = HandleAPIResponse(sessions.HTTPResponse, sessions.Body)
+	}
+	if err != nil {
+		snap.Errors = append(snap.Errors, fmt.Sprintf("sessions: %v", err))
+	} else if sessions.JSON200 != nil {
+		snap.Sessions = sessions.JSON200.Items
+	}
+
+	agents, err := client.Client().ListAgentsWithResponse(ctx, &api.ListAgentsParams{OnlyActive: &onlyActive})
+	if err == nil {
+		err = HandleAPIResponse(agents.HTTPResponse, agents.Body)
+	}
+	if err != nil {
+		snap.Errors = append(snap.Errors, fmt.Sprintf("agents: %v", err))
+	} else if agents.JSON200 != nil {
+		snap.Agents = agents.JSON200.Items
+	}
+
+	runs, err := fetchRecentRuns(ctx, client)
+	if err != nil {
+		snap.Errors = append(snap.Errors, fmt.Sprintf("function runs: %v", err))
+	}
+	snap.Runs = runs
+
+	usage, err := client.Client().GetUsageWithResponse(ctx, &api.GetUsageParams{})
+	if err == nil {
+		err = HandleAPIResponse(usage.HTTPResponse, usage.Body)
+	}
+	if err != nil {
+		snap.Errors = append(snap.Errors, fmt.Sprintf("usage: %v", err))
+	} else {
+		snap.Usage = usage.JSON200
+	}
+
+	return snap
+}
+
+// fetchRecentRuns returns the newest runs of the most recent functions
+func fetchRecentRuns(ctx context.Context, client *api.NotteClient) ([]api.GetFunctionRunResponse, error) {
+	pageSize := dashRecentFunctions
+	functions, err := client.Client().ListFunctionsWithResponse(ctx, &api.ListFunctionsParams{PageSize: &pageSize})
+	if err == nil {
+		err = HandleAPIResponse(functions.HTTPResponse, functions.Body)
+	}
+	if err != nil || functions.JSON200 == nil {
+		return nil, err
+	}
+
+	var runs []api.GetFunctionRunResponse
+	runPageSize := dashMaxRuns
+	for _, fn := range functions.JSON200.Items {
+		resp, err := client.Client().ListFunctionRunsByFunctionIdWithResponse(ctx, fn.FunctionId, &api.ListFunctionRunsByFunctionIdParams{PageSize: &runPageSize})
+		if err == nil {
+			err = HandleAPIResponse(resp.HTTPResponse, resp.Body)
+		}
+		if err != nil {
+			return runs, err
+		}
+		if resp.JSON200 != nil {
+			runs = append(runs, resp.JSON200.Items...)
+		}
+	}
+
+	sort.Slice(runs, func(i, j int) bool { return runs[i].CreatedAt.After(runs[j].CreatedAt.Time) })
+	if len(runs) > dashMaxRuns {
+		runs = runs[:dashMaxRuns]
+	}
+	return runs, nil
+}
+
+// runDashAction performs a and returns the status line to show
+func runDashAction(ctx context.Context, client *api.NotteClient, a dashAction) string {
+	switch a.Kind {
+	case "viewer":
+		if a.ViewerURL == "" {
+			return "No viewer URL for this session."
+		}
+		if err := openBrowser(a.ViewerURL); err != nil {
+			return fmt.Sprintf("Error: failed to open browser: %v", err)
+		}
+		return "Opened viewer in browser."
+	case "stop":
+		if err := dashStop(ctx, client, a); err != nil {
+			return fmt.Sprintf("Error: %v", err)
+		}
+		return fmt.Sprintf("Stopped %s.", a.ID)
+	}
+	return ""
+}
+
+func dashStop(ctx context.Context, client *api.NotteClient, a dashAction) error {
+	// Same policy as the equivalent stop commands
+	stopCmds := map[dashSection]*cobra.Command{
+		dashSessions: sessionsStopCmd,
+		dashAgents:   agentsStopCmd,
+		dashRuns:     functionsRunStopCmd,
+	}
+	if err := checkForbidden(stopCmds[a.Section]); err != nil {
+		return err
+	}
+
+	switch a.Section {
+	case dashSessions:
+		if err := checkProtected("session", a.ID); err != nil {
+			return err
+		}
+		if err := stopSession(ctx, client, a.ID); err != nil {
+			return err
+		}
+	case dashAgents:
+		if err := checkProtected("agent", a.ID); err != nil {
+			return err
+		}
+		resp, err := client.Client().AgentStopWithResponse(ctx, a.ID, &api.AgentStopParams{SessionId: a.SessionID})
+		if err != nil {
+			return i18n.Errorf(i18n.APIRequestFailed, err)
+		}
+		if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return err
+		}
+		_ = clearCurrentAgentIfMatches(a.ID)
+	case dashRuns:
+		if err := checkProtected("function run", a.ID); err != nil {
+			return err
+		}
+		resp, err := client.Client().FunctionRunStopWithResponse(ctx, a.FunctionID, a.ID, &api.FunctionRunStopParams{})
+		if err != nil {
+			return i18n.Errorf(i18n.APIRequestFailed, err)
+		}
+		if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dashSnapshotMsg delivers a finished poll to the dashboard
+type dashSnapshotMsg dashSnapshot
+
+// dashTickMsg asks the dashboard to poll again
+type dashTickMsg struct{}
+
+// dashActionDoneMsg reports the outcome of a dashAction
+type dashActionDoneMsg struct {
+	action dashAction
+	status string
+}
+
+// dashProgram runs dashModel in Bubble Tea: it turns key presses and
+// resizes into model updates and runs polls and actions as commands
+type dashProgram struct {
+	model    *dashModel
+	ctx      context.Context
+	client   *api.NotteClient
+	fetching bool
+	width    int
+	height   int
+}
+
+func (p *dashProgram) Init() tea.Cmd {
+	return tea.Batch(p.refresh(), p.tick())
+}
+
+// refresh polls the API unless a poll is already running
+func (p *dashProgram) refresh() tea.Cmd {
+	if p.fetching {
+		return nil
+	}
+	p.fetching = true
+	return func() tea.Msg {
+		ctx, cancel := GetContextWithTimeout(p.ctx)
+		defer cancel()
+		return dashSnapshotMsg(fetchDashSnapshot(ctx, p.client))
+	}
+}
+
+func (p *dashProgram) tick() tea.Cmd {
+	return tea.Tick(p.model.interval, func(time.Time) tea.Msg { return dashTickMsg{} })
+}
+
+func (p *dashProgram) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width, p.height = msg.Width, msg.Height
+	case dashSnapshotMsg:
+		p.fetching = false
+		p.model.setSnapshot(dashSnapshot(msg))
+	case dashTickMsg:
+		return p, tea.Batch(p.refresh(), p.tick())
+	case dashActionDoneMsg:
+		p.model.status = msg.status
+		if msg.action.Kind == "stop" {
+			return p, p.refresh()
+		}
+	case tea.KeyMsg:
+		a := p.model.handleKey(msg.String())
+		if p.model.quit {
+			return p, tea.Quit
+		}
+		if a == nil {
+			return p, nil
+		}
+		if a.Kind == "refresh" {
+			return p, p.refresh()
+		}
+		action := *a
+		return p, func() tea.Msg {
+			ctx, cancel := GetContextWithTimeout(p.ctx)
+			defer cancel()
+			return dashActionDoneMsg{action: action, status: runDashAction(ctx, p.client, action)}
+		}
+	}
+	return p, nil
+}
+
+func (p *dashProgram) View() string {
+	return p.model.render(p.width, p.height, time.Now())
+}
+
+func runDash(cmd *cobra.Command, args []string) error {
+	if dashInterval < time.Second {
+		return i18n.Errorf(i18n.IntervalTooSmall)
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return i18n.Errorf(i18n.DashNeedsTerminal)
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	program := &dashProgram{
+		model: &dashModel{
+			skipConfirm: skipConfirmation,
+			color:       !noColor,
+			interval:    dashInterval,
+		},
+		ctx:    ctx,
+		client: client,
+	}
+	_, err = tea.NewProgram(program, tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil {
+		return nil
+	}
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToSetUpTerminal, err)
+	}
+	return nil
+}
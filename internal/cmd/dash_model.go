@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+)
+
+// dashSection is one of the lists shown by notte dash
+type dashSection int
+
+const (
+	dashSessions dashSection = iota
+	dashAgents
+	dashRuns
+	dashSectionCount
+)
+
+func (s dashSection) String() string {
+	switch s {
+	case dashSessions:
+		return "Sessions"
+	case dashAgents:
+		return "Agents"
+	case dashRuns:
+		return "Function runs"
+	default:
+		return "Unknown"
+	}
+}
+
+// dashSnapshot is one poll of everything the dashboard shows
+type dashSnapshot struct {
+	Sessions  []api.SessionResponse
+	Agents    []api.AgentResponse
+	Runs      []api.GetFunctionRunResponse
+	Usage     *api.UsageResponse
+	Errors    []string
+	FetchedAt time.Time
+}
+
+// dashAction is a side effect requested by a key press, run by dashProgram
+type dashAction struct {
+	Kind       string // stop, viewer or refresh
+	Section    dashSection
+	ID         string
+	SessionID  string // agent stop needs the agent's session
+	FunctionID string // run stop needs the run's function
+	ViewerURL  string
+}
+
+// dashModel is the dashboard state. It has no I/O so key handling and
+// rendering can be tested without a terminal.
+type dashModel struct {
+	snap        dashSnapshot
+	section     dashSection
+	cursor      [dashSectionCount]int
+	detail      string
+	confirm     *dashAction
+	status      string
+	skipConfirm bool
+	color       bool
+	interval    time.Duration
+	quit        bool
+}
+
+// rows returns the number of items in section
+func (m *dashModel) rows(section dashSection) int {
+	switch section {
+	case dashSessions:
+		return len(m.snap.Sessions)
+	case dashAgents:
+		return len(m.snap.Agents)
+	case dashRuns:
+		return len(m.snap.Runs)
+	default:
+		return 0
+	}
+}
+
+// setSnapshot replaces the data, keeping cursors in range
+func (m *dashModel) setSnapshot(s dashSnapshot) {
+	m.snap = s
+	for section := dashSection(0); section < dashSectionCount; section++ {
+		if n := m.rows(section); m.cursor[section] >= n {
+			m.cursor[section] = max(n-1, 0)
+		}
+	}
+}
+
+// selected returns the action target for the highlighted row, if any
+func (m *dashModel) selected() (dashAction, any, bool) {
+	i := m.cursor[m.section]
+	if i >= m.rows(m.section) {
+		return dashAction{}, nil, false
+	}
+	a := dashAction{Section: m.section}
+	switch m.section {
+	case dashSessions:
+		s := m.snap.Sessions[i]
+		a.ID = s.SessionId
+		if s.ViewerUrl != nil {
+			a.ViewerURL = *s.ViewerUrl
+		}
+		return a, s, true
+	case dashAgents:
+		ag := m.snap.Agents[i]
+		a.ID, a.SessionID = ag.AgentId, ag.SessionId
+		return a, ag, true
+	default:
+		r := m.snap.Runs[i]
+		a.ID, a.FunctionID = r.FunctionRunId, r.FunctionId
+		if r.SessionId != nil {
+			a.SessionID = *r.SessionId
+		}
+		return a, r, true
+	}
+}
+
+// handleKey updates the model for a key press and returns the action to
+// run, if the key asked for one
+func (m *dashModel) handleKey(key string) *dashAction {
+	if m.confirm != nil {
+		a := m.confirm
+		m.confirm = nil
+		if key == "y" || key == "Y" {
+			return a
+		}
+		m.status = "Cancelled."
+		return nil
+	}
+
+	switch key {
+	case "q", "ctrl+c":
+		m.quit = true
+	case "esc":
+		m.detail = ""
+	case "tab", "right", "l":
+		m.section = (m.section + 1) % dashSectionCount
+		m.detail = ""
+	case "shift+tab", "left", "h":
+		m.section = (m.section + dashSectionCount - 1) % dashSectionCount
+		m.detail = ""
+	case "down", "j":
+		if m.cursor[m.section] < m.rows(m.section)-1 {
+			m.cursor[m.section]++
+		}
+	case "up", "k":
+		if m.cursor[m.section] > 0 {
+			m.cursor[m.section]--
+		}
+	case "r":
+		return &dashAction{Kind: "refresh"}
+	case "i", "enter":
+		_, item, ok := m.selected()
+		if !ok {
+			return nil
+		}
+		data, err := json.MarshalIndent(item, "", "  ")
+		if err != nil {
+			m.status = fmt.Sprintf("Error: %v", err)
+			return nil
+		}
+		m.detail = string(data)
+	case "s":
+		a, _, ok := m.selected()
+		if !ok {
+			return nil
+		}
+		a.Kind = "stop"
+		if m.skipConfirm {
+			return &a
+		}
+		m.confirm = &a
+	case "v":
+		a, _, ok := m.selected()
+		if !ok || m.section != dashSessions {
+			m.status = "The viewer is only available for sessions."
+			return nil
+		}
+		a.Kind = "viewer"
+		return &a
+	}
+	return nil
+}
+
+// dashAge formats how long ago t was, coarsely
+func dashAge(t, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+func (m *dashModel) bold(s string) string {
+	if !m.color {
+		return s
+	}
+	return "\x1b[1m" + s + "\x1b[0m"
+}
+
+// sectionLines returns the table rows of the current section
+func (m *dashModel) sectionLines(now time.Time) []string {
+	var lines []string
+	row := func(i int, cols ...string) {
+		marker := "  "
+		if i == m.cursor[m.section] {
+			marker = "> "
+		}
+		lines = append(lines, marker+fmt.Sprintf("%-38s %-10s %-38s %s", cols[0], cols[1], cols[2], cols[3]))
+	}
+
+	switch m.section {
+	case dashSessions:
+		lines = append(lines, fmt.Sprintf("  %-38s %-10s %-38s %s", "SESSION", "STATUS", "BROWSER", "CREATED"))
+		for i, s := range m.snap.Sessions {
+			browser := "-"
+			if s.BrowserType != nil {
+				browser = string(*s.BrowserType)
+			}
+			row(i, s.SessionId, string(s.Status), browser, dashAge(s.CreatedAt.Time, now))
+		}
+	case dashAgents:
+		lines = append(lines, fmt.Sprintf("  %-38s %-10s %-38s %s", "AGENT", "STATUS", "SESSION", "CREATED"))
+		for i, a := range m.snap.Agents {
+			row(i, a.AgentId, string(a.Status), a.SessionId, dashAge(a.CreatedAt.Time, now))
+		}
+	case dashRuns:
+		lines = append(lines, fmt.Sprintf("  %-38s %-10s %-38s %s", "RUN", "STATUS", "FUNCTION", "CREATED"))
+		for i, r := range m.snap.Runs {
+			row(i, r.FunctionRunId, string(r.Status), r.FunctionId, dashAge(r.CreatedAt.Time, now))
+		}
+	}
+	if len(lines) == 1 {
+		lines = append(lines, "  (none)")
+	}
+	return lines
+}
+
+// render draws the whole screen for a terminal of the given size
+func (m *dashModel) render(width, height int, now time.Time) string {
+	var lines []string
+
+	updated := "loading..."
+	if !m.snap.FetchedAt.IsZero() {
+		updated = "updated " + m.snap.FetchedAt.Local().Format("15:04:05")
+	}
+	lines = append(lines,
+		m.bold("notte dash")+fmt.Sprintf("  %d sessions · %d agents · %d runs  %s (every %s)",
+			len(m.snap.Sessions), len(m.snap.Agents), len(m.snap.Runs), updated, m.interval))
+
+	if u := m.snap.Usage; u != nil {
+		lines = append(lines, fmt.Sprintf("Usage %s: $%.2f total · %d sessions · %d functions · plan %v",
+			u.Period, u.TotalCost, u.SessionCount, u.FunctionCount, u.PlanType))
+	} else {
+		lines = append(lines, "Usage: -")
+	}
+	lines = append(lines, "")
+
+	var tabs []string
+	for section := dashSection(0); section < dashSectionCount; section++ {
+		label := fmt.Sprintf("%s (%d)", section, m.rows(section))
+		if section == m.section {
+			label = m.bold("[" + label + "]")
+		} else {
+			label = " " + label + " "
+		}
+		tabs = append(tabs, label)
+	}
+	lines = append(lines, strings.Join(tabs, "  "), "")
+	lines = append(lines, m.sectionLines(now)...)
+
+	if m.detail != "" {
+		lines = append(lines, "", m.bold("Details")+" (esc to close)")
+		lines = append(lines, strings.Split(m.detail, "\n")...)
+	}
+
+	var footer []string
+	for _, e := range m.snap.Errors {
+		footer = append(footer, "Error: "+e)
+	}
+	switch {
+	case m.confirm != nil:
+		footer = append(footer, fmt.Sprintf("Stop %s %s? [y/N]", strings.TrimSuffix(strings.ToLower(m.confirm.Section.String()), "s"), m.confirm.ID))
+	case m.status != "":
+		footer = append(footer, m.status)
+	}
+	footer = append(footer, "tab switch · j/k move · s stop · i inspect · v viewer · r refresh · q quit")
+
+	// Keep the footer visible by cutting the body short
+	if height > 0 {
+		if room := height - len(footer) - 1; len(lines) > room {
+			lines = lines[:max(room, 0)]
+		}
+		for len(lines)+len(footer) < height {
+			lines = append(lines, "")
+		}
+	}
+	lines = append(lines, footer...)
+
+	if width > 0 {
+		for i, l := range lines {
+			if !strings.Contains(l, "\x1b") && len([]rune(l)) > width {
+				lines[i] = string([]rune(l)[:width])
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func newTestDashModel() *dashModel {
+	m := &dashModel{interval: 5 * time.Second}
+	m.setSnapshot(dashSnapshot{
+		Sessions: []api.SessionResponse{
+			{SessionId: "sess_1", Status: "active"},
+			{SessionId: "sess_2", Status: "active"},
+		},
+		Agents: []api.AgentResponse{{AgentId: "agent_1", SessionId: "sess_1", Status: "active"}},
+		Runs:   []api.GetFunctionRunResponse{{FunctionRunId: "run_1", FunctionId: "func_1", Status: "active"}},
+	})
+	return m
+}
+
+func TestDashModel_Navigation(t *testing.T) {
+	m := newTestDashModel()
+
+	m.handleKey("j")
+	m.handleKey("j") // already at the last row
+	if m.cursor[dashSessions] != 1 {
+		t.Errorf("cursor = %d, want 1", m.cursor[dashSessions])
+	}
+
+	m.handleKey("tab")
+	if m.section != dashAgents {
+		t.Errorf("section = %v, want agents", m.section)
+	}
+	m.handleKey("shift+tab")
+	m.handleKey("shift+tab")
+	if m.section != dashRuns {
+		t.Errorf("section = %v, want function runs", m.section)
+	}
+
+	m.handleKey("q")
+	if !m.quit {
+		t.Error("expected q to quit")
+	}
+}
+
+func TestDashModel_StopConfirms(t *testing.T) {
+	m := newTestDashModel()
+	m.handleKey("tab")
+
+	if a := m.handleKey("s"); a != nil {
+		t.Fatalf("stop should ask first, got action %+v", a)
+	}
+	if !strings.Contains(m.render(0, 0, time.Now()), "Stop agent agent_1? [y/N]") {
+		t.Errorf("expected confirmation prompt:\n%s", m.render(0, 0, time.Now()))
+	}
+	a := m.handleKey("y")
+	if a == nil || a.Kind != "stop" || a.ID != "agent_1" || a.SessionID != "sess_1" {
+		t.Fatalf("unexpected action %+v", a)
+	}
+
+	m.handleKey("s")
+	if a := m.handleKey("n"); a != nil || m.status != "Cancelled." {
+		t.Errorf("expected cancel, got action %+v status %q", a, m.status)
+	}
+
+	m.skipConfirm = true
+	if a := m.handleKey("s"); a == nil || a.Kind != "stop" {
+		t.Errorf("expected immediate stop with --yes, got %+v", a)
+	}
+}
+
+func TestDashModel_InspectAndViewer(t *testing.T) {
+	m := newTestDashModel()
+
+	m.handleKey("i")
+	if !strings.Contains(m.detail, `"session_id": "sess_1"`) {
+		t.Errorf("expected session JSON in detail, got %q", m.detail)
+	}
+	m.handleKey("esc")
+	if m.detail != "" {
+		t.Error("expected esc to close details")
+	}
+
+	if a := m.handleKey("v"); a == nil || a.Kind != "viewer" || a.ID != "sess_1" {
+		t.Errorf("unexpected viewer action %+v", a)
+	}
+	m.handleKey("tab")
+	if a := m.handleKey("v"); a != nil {
+		t.Errorf("viewer should only work for sessions, got %+v", a)
+	}
+}
+
+func TestDashProgram_Update(t *testing.T) {
+	p := &dashProgram{model: &dashModel{interval: 5 * time.Second}, ctx: context.Background()}
+
+	p.Update(tea.WindowSizeMsg{Width: 60, Height: 12})
+	p.fetching = true
+	p.Update(dashSnapshotMsg(newTestDashModel().snap))
+	if p.fetching || len(p.model.snap.Sessions) != 2 {
+		t.Fatalf("snapshot not applied: fetching=%v sessions=%d", p.fetching, len(p.model.snap.Sessions))
+	}
+	if lines := strings.Split(p.View(), "\n"); len(lines) != 12 {
+		t.Errorf("view has %d lines, want the window's 12", len(lines))
+	}
+
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if p.model.cursor[dashSessions] != 1 || p.model.section != dashAgents {
+		t.Errorf("keys not applied: cursor=%d section=%v", p.model.cursor[dashSessions], p.model.section)
+	}
+
+	p.fetching = true
+	if _, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}); cmd != nil {
+		t.Error("r should not poll again while a poll is running")
+	}
+
+	p.Update(dashActionDoneMsg{action: dashAction{Kind: "viewer"}, status: "Opened viewer in browser."})
+	if p.model.status != "Opened viewer in browser." {
+		t.Errorf("status = %q", p.model.status)
+	}
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if cmd == nil {
+		t.Fatal("expected q to quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("expected a quit message")
+	}
+}
+
+func TestDashModel_RenderKeepsFooter(t *testing.T) {
+	m := newTestDashModel()
+	for i := 0; i < 50; i++ {
+		m.snap.Sessions = append(m.snap.Sessions, api.SessionResponse{SessionId: "sess_more"})
+	}
+
+	lines := strings.Split(m.render(80, 10, time.Now()), "\n")
+	if len(lines) != 10 {
+		t.Errorf("rendered %d lines, want 10", len(lines))
+	}
+	if !strings.Contains(lines[len(lines)-1], "q quit") {
+		t.Errorf("expected key help on the last line, got %q", lines[len(lines)-1])
+	}
+	for _, l := range lines {
+		if !strings.Contains(l, "\x1b") && len([]rune(l)) > 80 {
+			t.Errorf("line wider than terminal: %q", l)
+		}
+	}
+}
+
+func TestFetchDashSnapshot(t *testing.T) {
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+
+	server := testutil.NewMockServer()
+	defer server.Close()
+	env.SetEnv("NOTTE_API_URL", server.URL())
+
+	server.AddResponse("/sessions", 200, `{"items":[{"session_id":"sess_1","status":"active","created_at":"2025-01-01T00:00:00Z","last_accessed_at":"2025-01-01T00:00:00Z","idle_timeout_minutes":5}],"page":1,"page_size":10,"has_next":false}`)
+	server.AddResponse("/agents", 403, `{"detail":"forbidden"}`)
+	server.AddResponse("/functions", 200, `{"items":[{"function_id":"func_1","status":"active","latest_version":"1","created_at":"2025-01-01T00:00:00Z"}],"page":1,"page_size":5,"has_next":false}`)
+	server.AddResponse("/functions/func_1/runs", 200, `{"items":[`+
+		`{"function_run_id":"run_old","function_id":"func_1","status":"closed","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"},`+
+		`{"function_run_id":"run_new","function_id":"func_1","status":"active","created_at":"2025-01-02T00:00:00Z","updated_at":"2025-01-02T00:00:00Z"}],"page":1,"page_size":20,"has_next":false}`)
+	server.AddResponse("/usage", 200, `{"period":"May 2025","plan_type":"free","total_cost":1.5}`)
+
+	config.SetTestConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+
+	client, err := GetClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	snap := fetchDashSnapshot(context.Background(), client)
+
+	if len(snap.Sessions) != 1 || snap.Sessions[0].SessionId != "sess_1" {
+		t.Errorf("unexpected sessions: %+v", snap.Sessions)
+	}
+	if len(snap.Runs) != 2 || snap.Runs[0].FunctionRunId != "run_new" {
+		t.Errorf("expected newest run first: %+v", snap.Runs)
+	}
+	if snap.Usage == nil || snap.Usage.Period != "May 2025" {
+		t.Errorf("unexpected usage: %+v", snap.Usage)
+	}
+	if len(snap.Errors) != 1 || !strings.HasPrefix(snap.Errors[0], "agents:") {
+		t.Errorf("expected only the agents error, got %v", snap.Errors)
+	}
+	if reqs := server.Requests("/sessions"); len(reqs) != 1 || !strings.Contains(reqs[0].Query, "only_active=true") {
+		t.Errorf("expected active sessions to be listed, got %+v", reqs)
+	}
+}
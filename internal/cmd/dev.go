@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/release"
+)
+
+var (
+	devManifestsVersion   string
+	devManifestsChecksums string
+	devManifestsOutputDir string
+	devManifestsDistDir   string
+)
+
+var devCmd = &cobra.Command{
+	Use:    "dev",
+	Short:  "Tools for maintainers",
+	Hidden: true,
+}
+
+var devPackageManifestsCmd = &cobra.Command{
+	Use:   "package-manifests",
+	Short: "Generate Homebrew, Scoop and deb/rpm (nfpm) manifests for a release",
+	Long: `Generate package-manager manifests for a release from goreleaser's checksums.txt.
+
+Writes notte.rb (Homebrew), notte.json (Scoop) and nfpm-<arch>.yaml (deb/rpm)
+into the output directory.`,
+	Example: `  notte dev package-manifests --version 0.0.25 --checksums dist/checksums.txt --output-dir dist/manifests`,
+	Args:    cobra.NoArgs,
+	RunE:    runDevPackageManifests,
+}
+
+func init() {
+	rootCmd.AddCommand(devCmd)
+	devCmd.AddCommand(devPackageManifestsCmd)
+
+	devPackageManifestsCmd.Flags().StringVar(&devManifestsVersion, "version", "", "Release version (defaults to the CLI version)")
+	devPackageManifestsCmd.Flags().StringVar(&devManifestsChecksums, "checksums", "dist/checksums.txt", "Path to goreleaser checksums.txt")
+	devPackageManifestsCmd.Flags().StringVar(&devManifestsOutputDir, "output-dir", "dist/manifests", "Directory to write manifests to")
+	devPackageManifestsCmd.Flags().StringVar(&devManifestsDistDir, "dist-dir", "dist", "goreleaser dist directory referenced by nfpm configs")
+}
+
+func runDevPackageManifests(cmd *cobra.Command, args []string) error {
+	version := strings.TrimPrefix(devManifestsVersion, "v")
+	if version == "" {
+		version = strings.TrimPrefix(Version, "v")
+	}
+	if version == "" || version == "dev" {
+		return i18n.Errorf(i18n.VersionRequiredForDev)
+	}
+
+	f, err := os.Open(devManifestsChecksums)
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToOpenChecksums, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	sums, err := release.ParseChecksums(f)
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToParseChecksums, err)
+	}
+
+	info := release.Info{Version: version, Checksums: sums, DistDir: devManifestsDistDir}
+
+	type manifest struct {
+		name   string
+		render func() ([]byte, error)
+	}
+	manifests := []manifest{
+		{"notte.rb", func() ([]byte, error) { return release.Homebrew(info) }},
+		{"notte.json", func() ([]byte, error) { return release.Scoop(info) }},
+	}
+	for _, arch := range release.NFPMArchitectures {
+		manifests = append(manifests, manifest{"nfpm-" + arch + ".yaml", func() ([]byte, error) { return release.NFPM(info, arch) }})
+	}
+
+	if err := os.MkdirAll(devManifestsOutputDir, 0o755); err != nil {
+		return i18n.Errorf(i18n.FailedToCreateOutputDirectory, err)
+	}
+
+	var written []string
+	for _, m := range manifests {
+		data, err := m.render()
+		if err != nil {
+			return i18n.Errorf(i18n.FailedToRender, m.name, err)
+		}
+		path := filepath.Join(devManifestsOutputDir, m.name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return i18n.Errorf(i18n.FailedToWrite, path, err)
+		}
+		written = append(written, path)
+	}
+
+	return PrintResult(i18n.T(i18n.WroteManifests, len(written), version, devManifestsOutputDir), map[string]any{
+		"version": version,
+		"files":   written,
+	})
+}
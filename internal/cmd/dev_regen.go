@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// regenDefaultAPIURL is where scripts/generate.sh fetches the spec from by
+// default: staging has the latest API features
+const regenDefaultAPIURL = "https://us-staging.notte.cc"
+
+// regenSpecPin is the pinned hash of the bundled spec, relative to the repo
+const regenSpecPin = "internal/api/openapi.json.sha256"
+
+// regenGeneratedGlobs are the files scripts/generate.sh writes
+var regenGeneratedGlobs = []string{
+	"internal/api/openapi.json",
+	regenSpecPin,
+	"internal/api/client.gen.go",
+	"internal/api/property_names.gen.go",
+	"internal/cmd/*_flags.gen.go",
+}
+
+var (
+	devRegenFrom   string
+	devRegenSHA256 string
+)
+
+var devRegenCmd = &cobra.Command{
+	Use:   "regen",
+	Short: "Regenerate the API client and flags from the latest OpenAPI spec",
+	Long: `Download the API's OpenAPI spec, check its hash, run scripts/generate.sh on
+it (the API client, property names and gen-flags) and report which generated
+files changed.
+
+Run it from a checkout of the CLI; oapi-codegen, jq and python3 must be
+installed, as for 'make generate'. The spec is fetched from $NOTTE_API_URL
+(staging by default); --from takes another URL or a local file. --sha256
+refuses a download whose hash isn't the expected one, to regenerate from a
+known spec. The bundled spec's own hash is recorded in
+internal/api/openapi.json.sha256, which a unit test checks.`,
+	Example: `  notte dev regen
+  notte dev regen --from https://api.notte.cc/openapi.json
+  notte dev regen --from openapi.json --sha256 58dac2e1a1c4...`,
+	Args: cobra.NoArgs,
+	RunE: runDevRegen,
+}
+
+func init() {
+	devCmd.AddCommand(devRegenCmd)
+
+	devRegenCmd.Flags().StringVar(&devRegenFrom, "from", "", "Spec URL or file (defaults to $NOTTE_API_URL/openapi.json, or staging)")
+	devRegenCmd.Flags().StringVar(&devRegenSHA256, "sha256", "", "Expected SHA-256 of the downloaded spec")
+}
+
+// findRepoRoot returns the closest directory above dir that holds the CLI's
+// generate script
+func findRepoRoot(dir string) (string, error) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "scripts", "generate.sh")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New(i18n.T(i18n.GenerateScriptNotFound))
+		}
+		dir = parent
+	}
+}
+
+// regenSource returns where to fetch the spec from
+func regenSource() string {
+	if devRegenFrom != "" {
+		return devRegenFrom
+	}
+	base := os.Getenv(config.EnvAPIURL)
+	if base == "" {
+		base = regenDefaultAPIURL
+	}
+	return strings.TrimRight(base, "/") + "/openapi.json"
+}
+
+// fetchSpec reads the spec from a URL or a local file
+func fetchSpec(ctx context.Context, source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.ReadFile(source)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, i18n.Errorf(i18n.FailedToCreateRequest, err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, i18n.Errorf(i18n.FailedToDownload, source, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, i18n.Errorf(i18n.FailedToDownloadReason, source, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// hashGeneratedFiles returns the SHA-256 of every generated file under root
+func hashGeneratedFiles(root string) (map[string]string, error) {
+	hashes := map[string]string{}
+	for _, pattern := range regenGeneratedGlobs {
+		matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(pattern)))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range matches {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			rel, _ := filepath.Rel(root, path)
+			sum := sha256.Sum256(data)
+			hashes[filepath.ToSlash(rel)] = hex.EncodeToString(sum[:])
+		}
+	}
+	return hashes, nil
+}
+
+// changedFiles lists the files whose hash differs between two snapshots,
+// including added and removed ones
+func changedFiles(before, after map[string]string) []string {
+	changed := []string{}
+	for name, sum := range after {
+		if before[name] != sum {
+			changed = append(changed, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+func readSpecPin(root string) string {
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(regenSpecPin)))
+	if err != nil {
+		return ""
+	}
+	return api.ParseSHA256Pin(string(data))
+}
+
+func runDevRegen(cmd *cobra.Command, args []string) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	root, err := findRepoRoot(wd)
+	if err != nil {
+		return err
+	}
+
+	source := regenSource()
+	PrintInfo(i18n.T(i18n.FetchingSpec, source))
+	spec, err := fetchSpec(cmd.Context(), source)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(spec)
+	sourceSHA := hex.EncodeToString(sum[:])
+	if devRegenSHA256 != "" && !strings.EqualFold(devRegenSHA256, sourceSHA) {
+		return i18n.Errorf(i18n.SpecChecksumMismatch, source, sourceSHA, devRegenSHA256)
+	}
+
+	specFile, err := os.CreateTemp("", "notte-openapi-*.json")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(specFile.Name()) }()
+	if _, err := specFile.Write(spec); err != nil {
+		_ = specFile.Close()
+		return i18n.Errorf(i18n.FailedToWriteSpec, err)
+	}
+	if err := specFile.Close(); err != nil {
+		return err
+	}
+
+	before, err := hashGeneratedFiles(root)
+	if err != nil {
+		return err
+	}
+	previousPin := readSpecPin(root)
+
+	// The script's progress goes to stderr, and with -o json is only shown
+	// when it fails
+	var scriptOutput bytes.Buffer
+	script := execCommand(cmd.Context(), "bash", filepath.Join("scripts", "generate.sh"))
+	script.Dir = root
+	script.Env = append(os.Environ(), "NOTTE_OPENAPI_FILE="+specFile.Name())
+	script.Stdout, script.Stderr = os.Stderr, os.Stderr
+	if IsJSONOutput() {
+		script.Stdout, script.Stderr = &scriptOutput, &scriptOutput
+	}
+	if err := script.Run(); err != nil {
+		if IsJSONOutput() {
+			_, _ = os.Stderr.Write(scriptOutput.Bytes())
+		}
+		return externalError("code generation", "scripts/generate.sh", err)
+	}
+
+	after, err := hashGeneratedFiles(root)
+	if err != nil {
+		return err
+	}
+	changed := changedFiles(before, after)
+	pin := readSpecPin(root)
+
+	message := fmt.Sprintf("Regenerated from %s (SHA-256 %s)", source, sourceSHA)
+	if len(changed) == 0 {
+		message += "\nGenerated code is unchanged"
+	} else {
+		if pin != previousPin {
+			message += fmt.Sprintf("\nBundled spec: %s -> %s", previousPin, pin)
+		}
+		message += fmt.Sprintf("\nChanged %d files:\n  %s", len(changed), strings.Join(changed, "\n  "))
+	}
+	return PrintResult(message, map[string]any{
+		"source":               source,
+		"source_sha256":        sourceSHA,
+		"spec_sha256":          pin,
+		"previous_spec_sha256": previousPin,
+		"changed":              changed,
+	})
+}
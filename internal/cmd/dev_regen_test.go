@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+// fakeGenerateScript stands in for scripts/generate.sh: it bundles the spec
+// it is given and pins its hash
+const fakeGenerateScript = `#!/usr/bin/env bash
+set -euo pipefail
+cp "$NOTTE_OPENAPI_FILE" internal/api/openapi.json
+(cd internal/api && sha256sum openapi.json > openapi.json.sha256)
+echo "fake generate.sh ran"
+`
+
+func setupDevRegenTest(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the generate script needs bash")
+	}
+	testutil.SetupTestEnv(t)
+	root := t.TempDir()
+	for _, dir := range []string{"scripts", "internal/api", "internal/cmd"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{
+		"scripts/generate.sh":                fakeGenerateScript,
+		"internal/api/openapi.json":          `{"openapi":"3.0.3"}`,
+		"internal/api/openapi.json.sha256":   "old  openapi.json\n",
+		"internal/cmd/agents_flags.gen.go":   "package cmd\n",
+		"internal/cmd/not_generated_test.go": "package cmd\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Run from below the repo root, as from a package directory
+	t.Chdir(filepath.Join(root, "internal", "cmd"))
+
+	origFrom, origSHA, origFormat := devRegenFrom, devRegenSHA256, outputFormat
+	t.Cleanup(func() { devRegenFrom, devRegenSHA256, outputFormat = origFrom, origSHA, origFormat })
+	devRegenFrom, devRegenSHA256, outputFormat = "", "", "json"
+	return root
+}
+
+func writeRegenSpec(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "openapi.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func newDevRegenTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	return cmd
+}
+
+func TestRunDevRegen(t *testing.T) {
+	root := setupDevRegenTest(t)
+	devRegenFrom = writeRegenSpec(t, `{"openapi":"3.1.0"}`)
+
+	stdout, stderr := testutil.CaptureOutput(func() {
+		if err := runDevRegen(newDevRegenTestCmd(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if strings.Contains(stdout, "fake generate.sh ran") || !strings.Contains(stderr, "Fetching the OpenAPI spec") {
+		t.Errorf("the script's output should stay off stdout in JSON mode: %q / %q", stdout, stderr)
+	}
+
+	var result struct {
+		SourceSHA256   string   `json:"source_sha256"`
+		SpecSHA256     string   `json:"spec_sha256"`
+		PreviousSHA256 string   `json:"previous_spec_sha256"`
+		Changed        []string `json:"changed"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout)
+	}
+	if result.PreviousSHA256 != "old" || result.SpecSHA256 != result.SourceSHA256 {
+		t.Errorf("unexpected hashes: %+v", result)
+	}
+	if want := []string{"internal/api/openapi.json", "internal/api/openapi.json.sha256"}; !slices.Equal(result.Changed, want) {
+		t.Errorf("changed = %v, want %v", result.Changed, want)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "internal", "api", "openapi.json")); string(data) != `{"openapi":"3.1.0"}` {
+		t.Errorf("spec not regenerated: %s", data)
+	}
+}
+
+func TestRunDevRegen_SHA256Mismatch(t *testing.T) {
+	root := setupDevRegenTest(t)
+	devRegenFrom = writeRegenSpec(t, `{"openapi":"3.1.0"}`)
+	devRegenSHA256 = strings.Repeat("0", 64)
+
+	var err error
+	_, _ = testutil.CaptureOutput(func() {
+		err = runDevRegen(newDevRegenTestCmd(), nil)
+	})
+	if err == nil || !strings.Contains(err.Error(), "expected "+devRegenSHA256) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "internal", "api", "openapi.json")); string(data) != `{"openapi":"3.0.3"}` {
+		t.Errorf("nothing should be generated from an unexpected spec, got %s", data)
+	}
+}
+
+func TestFindRepoRoot_Outside(t *testing.T) {
+	if _, err := findRepoRoot(t.TempDir()); err == nil || !strings.Contains(err.Error(), "checkout of notte-cli") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRegenSource(t *testing.T) {
+	env := testutil.SetupTestEnv(t)
+	origFrom := devRegenFrom
+	t.Cleanup(func() { devRegenFrom = origFrom })
+	devRegenFrom = ""
+
+	env.SetEnv("NOTTE_API_URL", "")
+	if got := regenSource(); got != "https://us-staging.notte.cc/openapi.json" {
+		t.Errorf("default source = %q", got)
+	}
+	env.SetEnv("NOTTE_API_URL", "http://localhost:8000/")
+	if got := regenSource(); got != "http://localhost:8000/openapi.json" {
+		t.Errorf("source = %q", got)
+	}
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func TestRunDevPackageManifests(t *testing.T) {
+	dir := t.TempDir()
+
+	var sums strings.Builder
+	for _, p := range []string{"darwin_amd64", "darwin_arm64", "linux_amd64", "linux_arm64"} {
+		sums.WriteString(strings.Repeat("a", 64) + "  notte-cli_1.2.3_" + p + ".tar.gz\n")
+	}
+	for _, p := range []string{"windows_amd64", "windows_arm64"} {
+		sums.WriteString(strings.Repeat("b", 64) + "  notte-cli_1.2.3_" + p + ".zip\n")
+	}
+	checksums := filepath.Join(dir, "checksums.txt")
+	if err := os.WriteFile(checksums, []byte(sums.String()), 0o644); err != nil {
+		t.Fatalf("write checksums: %v", err)
+	}
+
+	origVersion, origChecksums, origOut, origDist := devManifestsVersion, devManifestsChecksums, devManifestsOutputDir, devManifestsDistDir
+	t.Cleanup(func() {
+		devManifestsVersion, devManifestsChecksums, devManifestsOutputDir, devManifestsDistDir = origVersion, origChecksums, origOut, origDist
+	})
+	devManifestsVersion = "v1.2.3"
+	devManifestsChecksums = checksums
+	devManifestsOutputDir = filepath.Join(dir, "manifests")
+	devManifestsDistDir = "dist"
+
+	_, _ = testutil.CaptureOutput(func() {
+		if err := runDevPackageManifests(&cobra.Command{}, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	for _, name := range []string{"notte.rb", "notte.json", "nfpm-amd64.yaml", "nfpm-arm64.yaml"} {
+		data, err := os.ReadFile(filepath.Join(devManifestsOutputDir, name))
+		if err != nil {
+			t.Errorf("missing %s: %v", name, err)
+			continue
+		}
+		if !strings.Contains(string(data), "1.2.3") {
+			t.Errorf("%s does not mention version 1.2.3", name)
+		}
+	}
+}
+
+func TestRunDevPackageManifests_RequiresVersion(t *testing.T) {
+	origVersion, origFlag := Version, devManifestsVersion
+	t.Cleanup(func() { Version, devManifestsVersion = origVersion, origFlag })
+	Version, devManifestsVersion = "dev", ""
+
+	if err := runDevPackageManifests(&cobra.Command{}, nil); err == nil {
+		t.Error("expected error without a release version")
+	}
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/nottelabs/notte-cli/internal/auth"
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// envDefaultsSkipFlags can't come from environment defaults: the API URL
+// decides which environment is active in the first place
+var envDefaultsSkipFlags = map[string]bool{"api-url": true}
+
+// commandKey is cmd's path below the root joined with underscores, as used
+// in environment default keys, e.g. "sessions_start"
+func commandKey(cmd *cobra.Command) string {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name())
+	return strings.Join(strings.Fields(path), "_")
+}
+
+// applyEnvDefaults sets the flags configured for the active environment
+// under "env" in the config file. A key is either a flag name, applied to
+// every command that has the flag, or "<command>.<flag>" for one command
+// only (e.g. "sessions_start.headless"). Flags given on the command line
+// take precedence.
+func applyEnvDefaults(cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToLoadConfig, err)
+	}
+	if len(cfg.Env) == 0 {
+		return nil
+	}
+	label := auth.ResolveEnvLabel(auth.GetCurrentAPIURL())
+	defaults := cfg.Env[label]
+	key := commandKey(cmd)
+
+	// Command-specific keys are applied last so they win over bare ones
+	var names []string
+	for _, name := range sortedFlagNames(defaults) {
+		if !strings.Contains(name, ".") {
+			names = append(names, name)
+		}
+	}
+	for _, name := range sortedFlagNames(defaults) {
+		if scope, _, ok := strings.Cut(name, "."); ok && scope == key {
+			names = append(names, name)
+		}
+	}
+
+	explicit := map[string]bool{}
+	cmd.Flags().Visit(func(f *pflag.Flag) { explicit[f.Name] = true })
+
+	for _, name := range names {
+		flagName := name[strings.Index(name, ".")+1:]
+		if envDefaultsSkipFlags[flagName] {
+			return i18n.Errorf(i18n.EnvFlagNotAllowed, label, flagName)
+		}
+
+		f := cmd.Flags().Lookup(flagName)
+		if f == nil {
+			if flagName != name {
+				return i18n.Errorf(i18n.EnvUnknownFlag, label, name, flagName, strings.ReplaceAll(key, "_", " "))
+			}
+			continue
+		}
+		if explicit[f.Name] {
+			continue
+		}
+		if err := cmd.Flags().Set(flagName, defaults[name]); err != nil {
+			return i18n.Errorf(i18n.EnvValueError, label, name, err)
+		}
+	}
+	return nil
+}
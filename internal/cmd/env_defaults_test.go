@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/auth"
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+// newEnvDefaultsTestCmd returns "notte sessions start" with a few of its flags
+func newEnvDefaultsTestCmd() (root, start *cobra.Command) {
+	root = &cobra.Command{Use: "notte"}
+	root.PersistentFlags().Int("timeout", 60, "")
+	sessions := &cobra.Command{Use: "sessions"}
+	start = &cobra.Command{Use: "start"}
+	start.Flags().Bool("headless", true, "")
+	start.Flags().Int("idle-timeout-minutes", 0, "")
+	root.AddCommand(sessions)
+	sessions.AddCommand(start)
+	return root, start
+}
+
+func setupEnvDefaultsTest(t *testing.T, env map[string]map[string]string) {
+	t.Helper()
+	testutil.SetupTestEnv(t)
+	setupRecipesTest(t)
+	cfg := &config.Config{APIURL: "https://us-staging.notte.cc", Env: env}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { auth.SetAPIURLOverride("") })
+}
+
+func TestApplyEnvDefaults(t *testing.T) {
+	setupEnvDefaultsTest(t, map[string]map[string]string{
+		"staging": {
+			"timeout":                             "120",
+			"idle-timeout-minutes":                "5",
+			"sessions_start.idle-timeout-minutes": "10",
+			"sessions_start.headless":             "false",
+			"agents_start.max-steps":              "3",
+		},
+		"prod": {"timeout": "30"},
+	})
+
+	root, start := newEnvDefaultsTestCmd()
+	root.SetArgs([]string{"sessions", "start", "--headless=true"})
+	start.RunE = func(cmd *cobra.Command, args []string) error { return applyEnvDefaults(cmd) }
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	flags := start.Flags()
+	if v, _ := flags.GetInt("timeout"); v != 120 {
+		t.Errorf("timeout = %d, want the staging default 120", v)
+	}
+	if v, _ := flags.GetInt("idle-timeout-minutes"); v != 10 {
+		t.Errorf("idle-timeout-minutes = %d, want the command-specific 10", v)
+	}
+	if v, _ := flags.GetBool("headless"); !v {
+		t.Error("headless given on the command line should win over the default")
+	}
+}
+
+func TestApplyEnvDefaults_OtherEnvironment(t *testing.T) {
+	setupEnvDefaultsTest(t, map[string]map[string]string{"prod": {"timeout": "30"}})
+	auth.SetAPIURLOverride("https://us-dev.notte.cc")
+
+	root, start := newEnvDefaultsTestCmd()
+	root.SetArgs([]string{"sessions", "start"})
+	start.RunE = func(cmd *cobra.Command, args []string) error { return applyEnvDefaults(cmd) }
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := start.Flags().GetInt("timeout"); v != 60 {
+		t.Errorf("timeout = %d, prod defaults must not apply on dev", v)
+	}
+}
+
+func TestApplyEnvDefaults_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults map[string]string
+		want     string
+	}{
+		{"unknown flag", map[string]string{"sessions_start.headles": "false"}, `env.staging: "sessions_start.headles" sets unknown flag --headles for 'notte sessions start'`},
+		{"bad value", map[string]string{"timeout": "2m"}, `env.staging: "timeout"`},
+		{"api url", map[string]string{"api-url": "https://x"}, "--api-url can't be set per environment"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupEnvDefaultsTest(t, map[string]map[string]string{"staging": tt.defaults})
+
+			root, start := newEnvDefaultsTestCmd()
+			root.SetArgs([]string{"sessions", "start"})
+			root.SilenceUsage, root.SilenceErrors = true, true
+			start.RunE = func(cmd *cobra.Command, args []string) error { return applyEnvDefaults(cmd) }
+			err := root.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvDefaults_SetConfirmationFlags(t *testing.T) {
+	setupEnvDefaultsTest(t, map[string]map[string]string{
+		"staging": {"yes": "true", "non-interactive": "true"},
+	})
+	t.Setenv("NOTTE_NONINTERACTIVE", "")
+	t.Setenv("CI", "")
+	t.Cleanup(func() {
+		for _, name := range []string{"yes", "non-interactive"} {
+			f := rootCmd.PersistentFlags().Lookup(name)
+			_ = f.Value.Set(f.DefValue)
+			f.Changed = false
+		}
+		SetSkipConfirmation(false)
+		SetNonInteractive(false)
+	})
+
+	// Parsing merges the root's persistent flags into the command's flag set
+	if err := versionCmd.ParseFlags(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := rootCmd.PersistentPreRunE(versionCmd, nil); err != nil {
+		t.Fatalf("PersistentPreRunE() error = %v", err)
+	}
+	if !skipConfirmation {
+		t.Error("yes from the environment defaults should skip confirmation")
+	}
+	if !nonInteractive {
+		t.Error("non-interactive from the environment defaults should turn off prompts")
+	}
+}
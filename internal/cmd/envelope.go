@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	envelopeOutput bool
+
+	// envelopeKind is the kind of the running command's results, empty
+	// unless --envelope is set
+	envelopeKind string
+)
+
+// initEnvelopeOutput checks --envelope and names the kind of the command's
+// results
+func initEnvelopeOutput(cmd *cobra.Command) error {
+	envelopeKind = ""
+	if !envelopeOutput {
+		return nil
+	}
+	if (outputFormat != "json" && outputFormat != "yaml") || IsTemplateOutput() {
+		return errors.New(i18n.T(i18n.EnvelopeNeedsJSONOrYAML))
+	}
+	if rawOutput {
+		return errors.New(i18n.T(i18n.EnvelopeWithRaw))
+	}
+	envelopeKind = envelopeKindFor(cmd)
+	return nil
+}
+
+// envelopeKindFor names results after the command that prints them:
+// "sessions list" gives SessionList and "page scroll-until-end" gives
+// PageScrollUntilEnd
+func envelopeKindFor(cmd *cobra.Command) string {
+	words := strings.Fields(cmd.CommandPath())
+	if len(words) > 0 && cmd.Root() != cmd {
+		words = words[1:]
+	}
+	var kind strings.Builder
+	for i, word := range words {
+		// Command groups are plural nouns, kinds are singular
+		if i == 0 && len(words) > 1 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") {
+			word = strings.TrimSuffix(word, "s")
+		}
+		for _, part := range strings.Split(word, "-") {
+			if part != "" {
+				kind.WriteString(strings.ToUpper(part[:1]) + part[1:])
+			}
+		}
+	}
+	if kind.Len() == 0 {
+		return "Notte"
+	}
+	return kind.String()
+}
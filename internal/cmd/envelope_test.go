@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func TestEnvelopeKindFor(t *testing.T) {
+	tests := map[string]string{
+		"sessions list":         "SessionList",
+		"page scroll-until-end": "PageScrollUntilEnd",
+		"auth status":           "AuthStatus",
+		"status":                "Status",
+		"files list":            "FileList",
+		"version":               "Version",
+	}
+	for path, want := range tests {
+		cmd, _, err := rootCmd.Find(strings.Fields(path))
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if got := envelopeKindFor(cmd); got != want {
+			t.Errorf("envelopeKindFor(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestInitEnvelopeOutput(t *testing.T) {
+	origEnvelope, origFormat, origRaw := envelopeOutput, outputFormat, rawOutput
+	t.Cleanup(func() {
+		envelopeOutput, outputFormat, rawOutput = origEnvelope, origFormat, origRaw
+		envelopeKind = ""
+	})
+
+	envelopeOutput, outputFormat, rawOutput = true, "text", false
+	if err := initEnvelopeOutput(sessionsListCmd); err == nil {
+		t.Error("expected --envelope without -o json to be rejected")
+	}
+	outputFormat, rawOutput = "json", true
+	if err := initEnvelopeOutput(sessionsListCmd); err == nil {
+		t.Error("expected --raw to be rejected")
+	}
+	rawOutput = false
+	if err := initEnvelopeOutput(sessionsListCmd); err != nil || envelopeKind != "SessionList" {
+		t.Errorf("kind = %q, err = %v", envelopeKind, err)
+	}
+	envelopeOutput = false
+	if err := initEnvelopeOutput(sessionsListCmd); err != nil || envelopeKind != "" {
+		t.Errorf("expected no envelope, got kind %q, err %v", envelopeKind, err)
+	}
+}
+
+func TestRunSessionStatus_Envelope(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/"+sessionIDTest, 200, sessionJSON())
+	origFormat := outputFormat
+	t.Cleanup(func() { outputFormat, envelopeKind = origFormat, "" })
+	outputFormat, envelopeKind = "json", "SessionStatus"
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSessionStatus(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var env struct {
+		APIVersion string         `json:"api_version"`
+		Kind       string         `json:"kind"`
+		Item       map[string]any `json:"item"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &env); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout)
+	}
+	if env.APIVersion != "v1" || env.Kind != "SessionStatus" || env.Item["session_id"] != sessionIDTest {
+		t.Errorf("unexpected envelope: %+v", env)
+	}
+}
@@ -1,9 +1,19 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"net"
 	"net/http"
+	"strings"
+	"time"
 
-	"github.com/nottelabs/notte-cli/internal/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/config"
+	apierrors "github.com/nottelabs/notte-cli/internal/errors"
 )
 
 // HandleAPIResponse checks the response status and returns an appropriate error.
@@ -14,5 +24,114 @@ func HandleAPIResponse(resp *http.Response, body []byte) error {
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		return nil
 	}
-	return errors.ParseAPIError(resp, body)
+	return apierrors.ParseAPIError(resp, body)
+}
+
+// attachFieldFlags points the field errors of an API validation error at the
+// flags of cmd that set those fields, so they read "--max-steps: ..." rather
+// than "max_steps: ..."
+func attachFieldFlags(cmd *cobra.Command, err error) {
+	var apiErr *apierrors.APIError
+	if cmd == nil || !errors.As(err, &apiErr) || len(apiErr.Fields) == 0 {
+		return
+	}
+	messages := make([]string, len(apiErr.Fields))
+	for i := range apiErr.Fields {
+		fe := &apiErr.Fields[i]
+		if fe.Path != "" && (fe.Location == "body" || fe.Location == "query") {
+			fe.Flag = flagForField(cmd, fe.Path)
+		}
+		messages[i] = fe.String()
+	}
+	apiErr.Message = strings.Join(messages, "; ")
+}
+
+// flagForField returns the flag set to the field at path, or to its closest
+// parent, from the generated flags' field annotations. Commands without
+// generated flags fall back to a flag named after the top-level field.
+func flagForField(cmd *cobra.Command, path string) string {
+	best, bestLen := "", -1
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if f.Deprecated != "" {
+			return
+		}
+		for _, field := range f.Annotations[flagFieldAnnotation] {
+			if (path == field || strings.HasPrefix(path, field+".")) && len(field) > bestLen {
+				best, bestLen = f.Name, len(field)
+			}
+		}
+	})
+	if best != "" {
+		return best
+	}
+
+	top, _, _ := strings.Cut(path, ".")
+	name := strings.ReplaceAll(top, "_", "-")
+	if f := cmd.Flags().Lookup(name); f != nil && !f.Hidden {
+		return name
+	}
+	return ""
+}
+
+// classifyTimeout turns a request that timed out into a TimeoutError naming
+// the limit that ran out: the command's --timeout, one of the HTTP client's
+// transport timeouts, or the server's
+func classifyTimeout(err error) error {
+	if err == nil {
+		return nil
+	}
+	var timeoutErr *apierrors.TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return err
+	}
+	var apiErr *apierrors.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.StatusCode == http.StatusGatewayTimeout || apiErr.StatusCode == http.StatusRequestTimeout {
+			return &apierrors.TimeoutError{Source: apierrors.TimeoutServer, StatusCode: apiErr.StatusCode, Cause: err}
+		}
+		return err
+	}
+
+	// The client's own limits surface as net/http messages, and also match
+	// context.DeadlineExceeded, so they are checked first
+	limits := clientTimeouts()
+	clientTimeout := func(setting string, limit time.Duration) error {
+		return &apierrors.TimeoutError{Source: apierrors.TimeoutClient, Setting: setting, Limit: limit, Cause: err}
+	}
+	msg := err.Error()
+	var opErr *net.OpError
+	switch {
+	case strings.Contains(msg, "Client.Timeout exceeded"):
+		return clientTimeout("request_timeout", limits.RequestTimeout)
+	case strings.Contains(msg, "timeout awaiting response headers"):
+		return clientTimeout("response_header_timeout", limits.ResponseHeaderTimeout)
+	case errors.Is(err, context.DeadlineExceeded):
+		return &apierrors.TimeoutError{
+			Source: apierrors.TimeoutCommand,
+			Limit:  time.Duration(requestTimeout) * time.Second,
+			Cause:  err,
+		}
+	case errors.As(err, &opErr) && opErr.Op == "dial" && opErr.Timeout():
+		return clientTimeout("dial_timeout", limits.DialTimeout)
+	}
+	return err
+}
+
+// clientTimeouts returns the HTTP client's timeouts, from the config file or
+// the defaults
+func clientTimeouts() api.TransportOptions {
+	defaults := api.DefaultTransportOptions()
+	opts := defaults
+	if cfg, err := config.Load(); err == nil {
+		if configured, err := transportOptions(cfg); err == nil {
+			opts = configured
+		}
+	}
+	if opts.DialTimeout == 0 {
+		opts.DialTimeout = defaults.DialTimeout
+	}
+	if opts.RequestTimeout == 0 {
+		opts.RequestTimeout = defaults.RequestTimeout
+	}
+	return opts
 }
@@ -1,8 +1,23 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	apierrors "github.com/nottelabs/notte-cli/internal/errors"
+	"github.com/nottelabs/notte-cli/internal/testutil"
 )
 
 func TestHandleAPIResponse_Success(t *testing.T) {
@@ -53,3 +68,82 @@ func TestHandleAPIResponse_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestAttachFieldFlags(t *testing.T) {
+	cmd := &cobra.Command{}
+	RegisterSessionStartFlags(cmd)
+	cmd.Flags().String("custom-field", "", "a hand-written flag")
+
+	err := fmt.Errorf("start failed: %w", &apierrors.APIError{
+		StatusCode: 422,
+		Fields: []apierrors.FieldError{
+			{Location: "body", Path: "profile.id", Message: "Invalid profile"},
+			{Location: "body", Path: "viewport_width", Message: "Input should be greater than 0"},
+			{Location: "body", Path: "custom_field", Message: "Field required"},
+			{Location: "body", Path: "proxies.0.server", Message: "Field required"},
+			{Location: "path", Path: "headless", Message: "not a flag"},
+		},
+	})
+	attachFieldFlags(cmd, err)
+
+	var apiErr *apierrors.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatal("expected an APIError")
+	}
+	var flags []string
+	for _, fe := range apiErr.Fields {
+		flags = append(flags, fe.Flag)
+	}
+	if want := []string{"profile-id", "viewport-width", "custom-field", "", ""}; !slices.Equal(flags, want) {
+		t.Errorf("flags = %q, want %q", flags, want)
+	}
+	if !strings.HasPrefix(apiErr.Message, "--profile-id: Invalid profile; --viewport-width: ") {
+		t.Errorf("Message = %q, should name the flags", apiErr.Message)
+	}
+}
+
+func TestClassifyTimeout(t *testing.T) {
+	testutil.SetupTestEnv(t)
+	origTimeout := requestTimeout
+	t.Cleanup(func() { requestTimeout = origTimeout })
+	requestTimeout = 30
+
+	cfg := &config.Config{Transport: &config.TransportConfig{ResponseHeaderTimeout: "20s"}}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	urlErr := func(err error) error {
+		return fmt.Errorf("API request failed: %w", &url.Error{Op: "Post", URL: "https://api.notte.cc/sessions/start", Err: err})
+	}
+	tests := []struct {
+		name    string
+		err     error
+		source  string
+		setting string
+		limit   time.Duration
+	}{
+		{"command deadline", urlErr(context.DeadlineExceeded), apierrors.TimeoutCommand, "", 30 * time.Second},
+		{"client timeout", urlErr(errors.New("net/http: request canceled (Client.Timeout exceeded while awaiting headers)")), apierrors.TimeoutClient, "request_timeout", 5 * time.Minute},
+		{"response headers", urlErr(errors.New("net/http: timeout awaiting response headers")), apierrors.TimeoutClient, "response_header_timeout", 20 * time.Second},
+		{"dial", urlErr(&net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}), apierrors.TimeoutClient, "dial_timeout", 10 * time.Second},
+		{"gateway", &apierrors.APIError{StatusCode: http.StatusGatewayTimeout}, apierrors.TimeoutServer, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var timeoutErr *apierrors.TimeoutError
+			if !errors.As(classifyTimeout(tt.err), &timeoutErr) {
+				t.Fatalf("expected a TimeoutError for %v", tt.err)
+			}
+			if timeoutErr.Source != tt.source || timeoutErr.Setting != tt.setting || timeoutErr.Limit != tt.limit {
+				t.Errorf("got %+v", timeoutErr)
+			}
+		})
+	}
+
+	for _, err := range []error{nil, errors.New("boom"), &apierrors.APIError{StatusCode: 500}} {
+		if got := classifyTimeout(err); got != err {
+			t.Errorf("classifyTimeout(%v) = %v, want it unchanged", err, got)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/objstore"
+)
+
+// exportBundle collects the files of an `export` command in memory, so a
+// directory and a zip get exactly the same content. Artifacts that can't be
+// fetched are recorded in errors instead of failing the export.
+type exportBundle struct {
+	names  []string
+	files  map[string][]byte
+	errors map[string]string
+}
+
+func newExportBundle() *exportBundle {
+	return &exportBundle{files: map[string][]byte{}, errors: map[string]string{}}
+}
+
+func (b *exportBundle) add(name string, data []byte) {
+	b.names = append(b.names, name)
+	b.files[name] = data
+}
+
+func (b *exportBundle) addJSON(name string, v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		b.fail(name, err)
+		return
+	}
+	b.add(name, append(data, '\n'))
+}
+
+func (b *exportBundle) fail(name string, err error) {
+	b.errors[name] = err.Error()
+}
+
+// write adds manifest.json (meta plus the file list and errors) and writes
+// the bundle to output, as a zip when asZip is set. Output may be an s3://
+// or gs:// prefix. It returns the path written and the files in the bundle.
+func (b *exportBundle) write(ctx context.Context, output string, asZip bool, meta map[string]any) (string, []string, error) {
+	files := append(append([]string{}, b.names...), "manifest.json")
+	manifest := map[string]any{
+		"exported_at": time.Now().UTC().Format(time.RFC3339),
+		"files":       files,
+		"errors":      b.errors,
+	}
+	for k, v := range meta {
+		manifest[k] = v
+	}
+	b.addJSON("manifest.json", manifest)
+
+	if !asZip {
+		return output, files, b.writeDir(ctx, output)
+	}
+	output = strings.TrimSuffix(output, "/")
+	if !strings.HasSuffix(output, ".zip") {
+		output += ".zip"
+	}
+	return output, files, b.writeZip(ctx, output)
+}
+
+// writeDir writes the bundle files under dir. Bundles may hold cookies and
+// network traffic, so everything is private to the user.
+func (b *exportBundle) writeDir(ctx context.Context, dir string) error {
+	if objstore.IsURL(dir) {
+		for _, name := range b.names {
+			if _, err := writeOutputFile(ctx, objstore.Join(dir, name), b.files[name], 0o600); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, name := range b.names {
+		dest := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(dest), 0o700); err != nil {
+			return i18n.Errorf(i18n.FailedToCreateDirectory, err)
+		}
+		if err := os.WriteFile(dest, b.files[name], 0o600); err != nil {
+			return i18n.Errorf(i18n.FailedToWrite, name, err)
+		}
+	}
+	return nil
+}
+
+func (b *exportBundle) writeZip(ctx context.Context, dest string) error {
+	if dir := filepath.Dir(dest); dir != "." && !objstore.IsURL(dest) {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return i18n.Errorf(i18n.FailedToCreateDirectory, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range b.names {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(b.files[name]); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	if objstore.IsURL(dest) {
+		_, err := writeOutputFile(ctx, dest, buf.Bytes(), 0o600)
+		return err
+	}
+	if err := os.WriteFile(dest, buf.Bytes(), 0o600); err != nil {
+		return i18n.Errorf(i18n.FailedToWrite, dest, err)
+	}
+	return nil
+}
+
+// printExportResult summarises a written bundle
+func printExportResult(kind, id, output string, files []string, bundle *exportBundle) error {
+	msg := fmt.Sprintf("Exported %d files for %s %s to %s", len(files), kind, id, output)
+	if len(bundle.errors) > 0 {
+		msg += fmt.Sprintf(" (%d skipped, see manifest.json)", len(bundle.errors))
+	}
+	return PrintResult(msg, map[string]any{
+		kind + "_id": id,
+		"path":       output,
+		"files":      files,
+		"errors":     bundle.errors,
+	})
+}
+
+// addWorkflowCode adds the generated script and its JSON actions
+func (b *exportBundle) addWorkflowCode(code *api.AgentFunctionCodeResponse, err error) {
+	if err == nil && code == nil {
+		err = i18n.Errorf(i18n.UnexpectedEmptyWorkflowCode)
+	}
+	if err != nil {
+		b.fail("workflow.py", err)
+		return
+	}
+	b.add("workflow.py", []byte(code.PythonScript))
+	b.addJSON("workflow_actions.json", code.JsonActions)
+}
+
+// exportReplay adds the session's replay metadata and video
+func exportReplay(ctx context.Context, client *api.NotteClient, sessionID string, bundle *exportBundle) {
+	resp, err := client.Client().SessionReplayWithResponse(ctx, sessionID, &api.SessionReplayParams{})
+	if err == nil {
+		err = HandleAPIResponse(resp.HTTPResponse, resp.Body)
+	}
+	if err == nil && resp.JSON200 == nil {
+		err = i18n.Errorf(i18n.UnexpectedEmptyReplay)
+	}
+	if err != nil {
+		bundle.fail("replay.json", err)
+		return
+	}
+	bundle.addJSON("replay.json", resp.JSON200)
+
+	if resp.JSON200.Mp4Url == nil || *resp.JSON200.Mp4Url == "" {
+		bundle.fail("replay.mp4", i18n.Errorf(i18n.ReplayHasNoVideoURL))
+		return
+	}
+	video, err := downloadArtifact(ctx, *resp.JSON200.Mp4Url)
+	if err != nil {
+		bundle.fail("replay.mp4", err)
+		return
+	}
+	bundle.add("replay.mp4", video)
+}
+
+// exportScreenshot adds a screenshot of the session's current page, which
+// only succeeds while the session is open
+func exportScreenshot(ctx context.Context, client *api.NotteClient, sessionID string, bundle *exportBundle) {
+	data, err := fetchScreenshot(ctx, client, sessionID)
+	if err != nil {
+		bundle.fail("screenshot.jpg", err)
+		return
+	}
+	bundle.add("screenshot.jpg", data)
+}
+
+// downloadArtifact fetches a presigned URL without the API client, so the
+// API key is never sent to the storage host
+func downloadArtifact(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, i18n.Errorf(i18n.FailedToCreateRequest, err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, i18n.Errorf(i18n.FailedToDownload, path.Base(req.URL.Path), err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, i18n.Errorf(i18n.FailedToDownloadHTTP, path.Base(req.URL.Path), resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
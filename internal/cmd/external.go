@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// execCommand builds the process for an external tool (replaced in tests)
+var execCommand = exec.CommandContext
+
+// ansiEscape matches terminal color and cursor sequences in captured output
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// runExternal runs an external tool for a command. In text mode the tool is
+// wired to the terminal. In JSON mode its output is captured and wrapped in
+// a JSON result, so -o json never prints the tool's own output; stdin is
+// closed, so prompts abort instead of waiting on input nobody can see.
+func runExternal(cmd *cobra.Command, action, name string, args []string) error {
+	ext := execCommand(cmd.Context(), name, args...)
+
+	if !IsJSONOutput() {
+		ext.Stdout = os.Stdout
+		ext.Stderr = os.Stderr
+		ext.Stdin = os.Stdin
+		return externalError(action, name, ext.Run())
+	}
+
+	var stdout, stderr bytes.Buffer
+	ext.Stdout = &stdout
+	ext.Stderr = &stderr
+	ext.Env = append(ext.Environ(), "NO_COLOR=1", "FORCE_COLOR=0")
+	runErr := ext.Run()
+
+	exitCode := 0
+	var exitErr *exec.ExitError
+	if errors.As(runErr, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	}
+
+	result := map[string]any{
+		"success":   runErr == nil,
+		"command":   strings.Join(append([]string{name}, args...), " "),
+		"exit_code": exitCode,
+		"stdout":    ansiEscape.ReplaceAllString(stdout.String(), ""),
+		"stderr":    ansiEscape.ReplaceAllString(stderr.String(), ""),
+	}
+	if runErr != nil && exitErr == nil {
+		// The tool didn't start, so there is no output to report
+		return externalError(action, name, runErr)
+	}
+	if err := PrintResult(i18n.T(i18n.ActionFinished, action), result); err != nil {
+		return err
+	}
+	return externalError(action, name, runErr)
+}
+
+func externalError(action, name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return i18n.Errorf(i18n.FailedWithExitCode, action, exitErr.ExitCode())
+	}
+	return i18n.Errorf(i18n.FailedToRun, name, err)
+}
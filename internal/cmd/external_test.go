@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+// TestExternalHelperProcess stands in for the external tool when run by
+// fakeExternal
+func TestExternalHelperProcess(t *testing.T) {
+	if os.Getenv("NOTTE_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	fmt.Fprint(os.Stdout, "\x1b[32m✓ installed\x1b[0m\n")
+	fmt.Fprintf(os.Stderr, "NO_COLOR=%s\n", os.Getenv("NO_COLOR"))
+	code, _ := strconv.Atoi(os.Getenv("HELPER_EXIT_CODE"))
+	os.Exit(code)
+}
+
+// fakeExternal makes runExternal start the test helper instead of the real
+// tool, exiting with exitCode, and records the command lines it was given
+func fakeExternal(t *testing.T, exitCode int) *[]string {
+	t.Helper()
+	calls := &[]string{}
+	orig := execCommand
+	execCommand = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		*calls = append(*calls, strings.Join(append([]string{name}, args...), " "))
+		c := exec.CommandContext(ctx, os.Args[0], "-test.run=^TestExternalHelperProcess$")
+		c.Env = append(os.Environ(), "NOTTE_WANT_HELPER_PROCESS=1", "HELPER_EXIT_CODE="+strconv.Itoa(exitCode))
+		return c
+	}
+	t.Cleanup(func() { execCommand = orig })
+	return calls
+}
+
+func newExternalTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	return cmd
+}
+
+func TestRunExternal_JSONWrapsOutput(t *testing.T) {
+	fakeExternal(t, 0)
+	origFormat := outputFormat
+	outputFormat = "json"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	stdout, stderr := testutil.CaptureOutput(func() {
+		if err := runExternal(newExternalTestCmd(), "skill installation", "npx", []string{"skills", "add", "x"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Errorf("expected nothing on stderr, got %q", stderr)
+	}
+	var result map[string]any
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, stdout)
+	}
+	if result["success"] != true || result["exit_code"] != float64(0) || result["command"] != "npx skills add x" {
+		t.Errorf("unexpected result: %v", result)
+	}
+	if result["stdout"] != "✓ installed\n" {
+		t.Errorf("stdout = %q, want color codes stripped", result["stdout"])
+	}
+	if result["stderr"] != "NO_COLOR=1\n" {
+		t.Errorf("stderr = %q, want the tool run with NO_COLOR=1", result["stderr"])
+	}
+}
+
+func TestRunExternal_JSONFailure(t *testing.T) {
+	fakeExternal(t, 2)
+	origFormat := outputFormat
+	outputFormat = "json"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	var err error
+	stdout, _ := testutil.CaptureOutput(func() {
+		err = runExternal(newExternalTestCmd(), "skill removal", "npx", []string{"skills", "remove"})
+	})
+
+	if err == nil || err.Error() != "skill removal failed with exit code 2" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	var result map[string]any
+	if jsonErr := json.Unmarshal([]byte(stdout), &result); jsonErr != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", jsonErr, stdout)
+	}
+	if result["success"] != false || result["exit_code"] != float64(2) {
+		t.Errorf("unexpected result: %v", result)
+	}
+}
+
+func TestRunExternal_MissingTool(t *testing.T) {
+	origFormat := outputFormat
+	outputFormat = "json"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	var err error
+	stdout, _ := testutil.CaptureOutput(func() {
+		err = runExternal(newExternalTestCmd(), "skill installation", "notte-no-such-tool", nil)
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "failed to run notte-no-such-tool") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if stdout != "" {
+		t.Errorf("expected no result when the tool can't start, got %q", stdout)
+	}
+}
+
+func TestRunSkillAdd_NpxForwardsYes(t *testing.T) {
+	calls := fakeExternal(t, 0)
+	origFormat := outputFormat
+	origYes := yesFlag
+	origUpgrade := skillAddUpgrade
+	origNpx := skillUseNpx
+	outputFormat = "json"
+	yesFlag = true
+	skillAddUpgrade = false
+	skillUseNpx = true
+	t.Cleanup(func() {
+		outputFormat = origFormat
+		yesFlag = origYes
+		skillAddUpgrade = origUpgrade
+		skillUseNpx = origNpx
+	})
+
+	testutil.CaptureOutput(func() {
+		if err := runSkillAdd(newExternalTestCmd(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if len(*calls) != 1 || (*calls)[0] != "npx skills add "+skillSource+" -y" {
+		t.Errorf("calls = %v", *calls)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,16 +10,19 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
 var (
 	filesListUploadsFlag   bool
 	filesListDownloadsFlag bool
 	filesDownloadOutput    string
+	filesUploadForce       bool
 )
 
 var filesCmd = &cobra.Command{
@@ -28,8 +32,9 @@ var filesCmd = &cobra.Command{
 }
 
 var filesListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List uploaded files",
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List uploaded files",
 	Long: `List files in storage. Use --uploads to list uploaded files,
 or --downloads to list downloaded files from a session.`,
 	RunE: runFilesList,
@@ -38,9 +43,20 @@ or --downloads to list downloaded files from a session.`,
 var filesUploadCmd = &cobra.Command{
 	Use:   "upload <file-path>",
 	Short: "Upload a file",
-	Long:  "Upload a file to notte.cc storage.",
+	Long: `Upload a file to notte.cc storage.
+
+Uploads are skipped when storage already holds the same content under the
+same name. The CLI remembers the SHA-256 of every file it uploads, since
+storage doesn't report checksums; pass --force to upload regardless.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFilesUpload,
+}
+
+var filesInfoCmd = &cobra.Command{
+	Use:   "info <filename>",
+	Short: "Show an uploaded file's size and upload date",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runFilesUpload,
+	RunE:  runFilesInfo,
 }
 
 var filesDownloadCmd = &cobra.Command{
@@ -56,14 +72,18 @@ func init() {
 	filesCmd.AddCommand(filesListCmd)
 	filesCmd.AddCommand(filesUploadCmd)
 	filesCmd.AddCommand(filesDownloadCmd)
+	filesCmd.AddCommand(filesInfoCmd)
 
 	// List command flags
 	filesListCmd.Flags().BoolVar(&filesListUploadsFlag, "uploads", false, "List uploaded files")
 	filesListCmd.Flags().BoolVar(&filesListDownloadsFlag, "downloads", true, "List downloaded files from a session")
-	filesListCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
+	addSessionIDFlag(filesListCmd)
+
+	// Upload command flags
+	filesUploadCmd.Flags().BoolVar(&filesUploadForce, "force", false, "Upload even if the same content was already uploaded")
 
 	// Download command flags
-	filesDownloadCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
+	addSessionIDFlag(filesDownloadCmd)
 	filesDownloadCmd.Flags().StringVar(&filesDownloadOutput, "path", "", "Output file path (defaults to current directory)")
 }
 
@@ -83,7 +103,7 @@ func runFilesList(cmd *cobra.Command, args []string) error {
 		params := &api.FileListUploadsParams{}
 		resp, err := client.Client().FileListUploadsWithResponse(ctx, params)
 		if err != nil {
-			return fmt.Errorf("API request failed: %w", err)
+			return i18n.Errorf(i18n.APIRequestFailed, err)
 		}
 
 		if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -109,7 +129,8 @@ func runFilesList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Default: list downloads for a session
-	if err := RequireSessionID(); err != nil {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -119,7 +140,7 @@ func runFilesList(cmd *cobra.Command, args []string) error {
 	params := &api.FileListDownloadsParams{}
 	resp, err := client.Client().FileListDownloadsWithResponse(ctx, sessionID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -149,25 +170,118 @@ func runFilesList(cmd *cobra.Command, args []string) error {
 func runFilesUpload(cmd *cobra.Command, args []string) error {
 	filePath := args[0]
 
-	// Check if file exists
-	fileInfo, err := os.Stat(filePath)
+	if _, err := checkUploadFile(filePath); err != nil {
+		return err
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	// Get the filename to use in the API call
+	filename := filepath.Base(filePath)
+
+	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	defer cancel()
+
+	resp, sum, err := storeUpload(ctx, client, filePath, filesUploadForce)
 	if err != nil {
-		return fmt.Errorf("failed to access file: %w", err)
+		return err
+	}
+	if resp == nil {
+		return PrintResult(i18n.T(i18n.FileAlreadyUploaded, filename), map[string]any{
+			"filename": filename,
+			"sha256":   sum,
+			"skipped":  true,
+			"success":  true,
+		})
 	}
 
+	formatter := GetFormatter()
+	if resp.JSON200 != nil && resp.JSON200.Success {
+		if IsJSONOutput() {
+			return formatter.Print(resp.JSON200)
+		}
+		return PrintResult(i18n.T(i18n.FileUploaded, filename), map[string]any{
+			"filename": filename,
+			"success":  true,
+		})
+	}
+
+	return formatter.Print(resp.JSON200)
+}
+
+// maxUploadFileSize is the largest file the CLI uploads to storage. Uploads
+// are sent in a single request, so this also bounds the memory they take.
+const maxUploadFileSize = 100 << 20
+
+// checkUploadFile fails early, before any API call, when filePath can't be
+// uploaded: it is missing, not a regular file, or too large
+func checkUploadFile(filePath string) (os.FileInfo, error) {
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return nil, i18n.Errorf(i18n.FailedToAccessFile, err)
+	}
 	if fileInfo.IsDir() {
-		return fmt.Errorf("path is a directory, not a file: %s", filePath)
+		return nil, i18n.Errorf(i18n.PathIsDirectory, filePath)
+	}
+	if !fileInfo.Mode().IsRegular() {
+		return nil, i18n.Errorf(i18n.NotARegularFile, filePath)
+	}
+	if fileInfo.Size() > maxUploadFileSize {
+		return nil, i18n.Errorf(i18n.FileTooLargeToUpload, filePath, float64(fileInfo.Size())/(1<<20), maxUploadFileSize>>20)
 	}
+	return fileInfo, nil
+}
 
-	client, err := GetClient()
+// storeUpload uploads the file at filePath to storage under its base name,
+// unless storage already holds the same content under that name and force
+// is false, and records it in the upload manifest. The result is nil when
+// the upload was skipped; the file's SHA-256 is returned either way.
+func storeUpload(ctx context.Context, client *api.NotteClient, filePath string, force bool) (*api.FileUploadResult, string, error) {
+	filename := filepath.Base(filePath)
+	sum, size, err := hashFile(filePath)
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 
+	manifest, err := loadUploadManifest()
+	if err != nil {
+		PrintInfo(i18n.T(i18n.WarningIgnoringUploadManifest, err))
+		manifest = uploadManifest{}
+	}
+
+	// Skip files whose content is already in storage; if uploads can't be
+	// listed, upload anyway
+	if !force {
+		if _, ok := manifest.lookup(client.BaseURL(), filename); ok {
+			if remote, err := listUploads(ctx, client); err == nil && manifest.isUploaded(client.BaseURL(), filename, sum, remote) {
+				return nil, sum, nil
+			}
+		}
+	}
+
+	resp, err := uploadFile(ctx, client, filePath, filename)
+	if err != nil {
+		return nil, sum, err
+	}
+
+	if resp.JSON200 != nil && resp.JSON200.Success {
+		manifest.record(client.BaseURL(), filename, uploadRecord{SHA256: sum, Size: size, UploadedAt: time.Now().UTC()})
+		if err := manifest.save(); err != nil {
+			PrintInfo(i18n.T(i18n.WarningCouldNotUpdateUploadManifest, err))
+		}
+	}
+	return resp, sum, nil
+}
+
+// uploadFile uploads the file at filePath to storage as filename
+func uploadFile(ctx context.Context, client *api.NotteClient, filePath, filename string) (*api.FileUploadResult, error) {
 	// Open the file
 	file, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
+		return nil, i18n.Errorf(i18n.FailedToOpenFile, err)
 	}
 	defer func() { _ = file.Close() }()
 
@@ -175,23 +289,17 @@ func runFilesUpload(cmd *cobra.Command, args []string) error {
 	var buf bytes.Buffer
 	writer := multipart.NewWriter(&buf)
 
-	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	part, err := writer.CreateFormFile("file", filename)
 	if err != nil {
-		return fmt.Errorf("failed to create form file: %w", err)
+		return nil, i18n.Errorf(i18n.FailedToCreateFormFile, err)
 	}
 
 	if _, err := io.Copy(part, file); err != nil {
-		return fmt.Errorf("failed to copy file data: %w", err)
+		return nil, i18n.Errorf(i18n.FailedToCopyFileData, err)
 	}
 
 	_ = writer.Close()
 
-	// Get the filename to use in the API call
-	filename := filepath.Base(filePath)
-
-	ctx, cancel := GetContextWithTimeout(cmd.Context())
-	defer cancel()
-
 	params := &api.FileUploadParams{}
 	resp, err := client.Client().FileUploadWithBodyWithResponse(
 		ctx,
@@ -201,31 +309,20 @@ func runFilesUpload(cmd *cobra.Command, args []string) error {
 		&buf,
 	)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return nil, i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
-		return err
+		return nil, err
 	}
-
-	formatter := GetFormatter()
-	if resp.JSON200 != nil && resp.JSON200.Success {
-		if IsJSONOutput() {
-			return formatter.Print(resp.JSON200)
-		}
-		return PrintResult(fmt.Sprintf("File uploaded successfully: %s", filename), map[string]any{
-			"filename": filename,
-			"success":  true,
-		})
-	}
-
-	return formatter.Print(resp.JSON200)
+	return resp, nil
 }
 
 func runFilesDownload(cmd *cobra.Command, args []string) error {
 	filename := args[0]
 
-	if err := RequireSessionID(); err != nil {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -234,7 +331,26 @@ func runFilesDownload(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	// Determine output path
+	outputPath := filesDownloadOutput
+	if outputPath == "" {
+		outputPath = filename
+	}
+
+	if err := fetchSessionFile(cmd.Context(), client, sessionID, filename, outputPath); err != nil {
+		return err
+	}
+
+	return PrintResult(i18n.T(i18n.FileDownloaded, outputPath), map[string]any{
+		"filename": filename,
+		"path":     outputPath,
+		"success":  true,
+	})
+}
+
+// fetchSessionFile saves the session's downloaded file filename to outputPath
+func fetchSessionFile(ctx context.Context, client *api.NotteClient, sessionID, filename, outputPath string) error {
+	ctx, cancel := GetContextWithTimeout(ctx)
 	defer cancel()
 
 	params := &api.FileDownloadParams{}
@@ -245,7 +361,7 @@ func runFilesDownload(cmd *cobra.Command, args []string) error {
 		params,
 	)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -257,45 +373,58 @@ func runFilesDownload(cmd *cobra.Command, args []string) error {
 		URL string `json:"url"`
 	}
 	if err := json.Unmarshal(resp.Body, &downloadResp); err != nil {
-		return fmt.Errorf("failed to parse download response: %w", err)
+		return i18n.Errorf(i18n.FailedToParseDownloadResponse, err)
 	}
 
 	if downloadResp.URL == "" {
-		return fmt.Errorf("no download URL in response")
+		return i18n.Errorf(i18n.NoDownloadURLInResponse)
 	}
 
 	// Download the actual file from the presigned URL
 	httpResp, err := http.Get(downloadResp.URL)
 	if err != nil {
-		return fmt.Errorf("failed to download file: %w", err)
+		return i18n.Errorf(i18n.FailedToDownloadFile, err)
 	}
 	defer func() { _ = httpResp.Body.Close() }()
 
 	if httpResp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download file: HTTP %d", httpResp.StatusCode)
-	}
-
-	// Determine output path
-	outputPath := filesDownloadOutput
-	if outputPath == "" {
-		outputPath = filename
+		return i18n.Errorf(i18n.FailedToDownloadFileHTTP, httpResp.StatusCode)
 	}
 
 	// Create the output file
 	outFile, err := os.Create(outputPath)
 	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+		return i18n.Errorf(i18n.FailedToCreateFile, err)
 	}
 	defer func() { _ = outFile.Close() }()
 
 	// Copy the downloaded content to the file
 	if _, err := io.Copy(outFile, httpResp.Body); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+		return i18n.Errorf(i18n.FailedToWriteFile, err)
 	}
+	return nil
+}
 
-	return PrintResult(fmt.Sprintf("File downloaded successfully: %s", outputPath), map[string]any{
-		"filename": filename,
-		"path":     outputPath,
-		"success":  true,
-	})
+func runFilesInfo(cmd *cobra.Command, args []string) error {
+	filename := args[0]
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	defer cancel()
+
+	// Storage has no per-file endpoint, so look the file up in the listing
+	uploads, err := listUploads(ctx, client)
+	if err != nil {
+		return err
+	}
+	info, ok := uploads[filename]
+	if !ok {
+		return i18n.Errorf(i18n.NoUploadedFileNamed, filename)
+	}
+
+	return GetFormatter().Print(info)
 }
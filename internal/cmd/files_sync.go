@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var filesSyncDryRun bool
+
+var filesSyncCmd = &cobra.Command{
+	Use:   "sync <local-dir>",
+	Short: "Upload new and changed files from a directory",
+	Long: `Make uploaded files match a local directory.
+
+Files directly inside the directory are compared with uploads by name and
+SHA-256: new files are uploaded, changed files are uploaded again, and the
+rest are left alone. Subdirectories are skipped, since storage is flat.
+Uploads that have no local file are kept, as the API cannot delete uploads.
+
+Use --dry-run to print the plan without changing anything.`,
+	Example: `  notte files sync fixtures/ --dry-run
+  notte files sync fixtures/`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFilesSync,
+}
+
+func init() {
+	filesCmd.AddCommand(filesSyncCmd)
+
+	filesSyncCmd.Flags().BoolVar(&filesSyncDryRun, "dry-run", false, "Print the plan without uploading")
+}
+
+// syncAction is one planned change of a sync
+type syncAction struct {
+	Action string `json:"action"` // upload or update
+	Name   string `json:"name"`
+
+	path string
+	sum  string
+	size int64
+}
+
+// syncPlan compares the regular files in dir with the uploads
+func syncPlan(dir, baseURL string, manifest uploadManifest, remote map[string]api.FileInfo) ([]syncAction, int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, 0, i18n.Errorf(i18n.FailedToReadDirectory, err)
+	}
+
+	var actions []syncAction
+	unchanged := 0
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		name := e.Name()
+
+		path := filepath.Join(dir, name)
+		sum, size, err := hashFile(path)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if manifest.isUploaded(baseURL, name, sum, remote) {
+			unchanged++
+			continue
+		}
+		a := syncAction{Action: "upload", Name: name, path: path, sum: sum, size: size}
+		if _, exists := remote[name]; exists {
+			a.Action = "update"
+		}
+		actions = append(actions, a)
+	}
+
+	return actions, unchanged, nil
+}
+
+func runFilesSync(cmd *cobra.Command, args []string) error {
+	dir := args[0]
+	info, err := os.Stat(dir)
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToAccessDirectory, err)
+	}
+	if !info.IsDir() {
+		return i18n.Errorf(i18n.NotADirectory, dir)
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	defer cancel()
+
+	manifest, err := loadUploadManifest()
+	if err != nil {
+		PrintInfo(i18n.T(i18n.WarningIgnoringUploadManifest, err))
+		manifest = uploadManifest{}
+	}
+
+	remote, err := listUploads(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	actions, unchanged, err := syncPlan(dir, client.BaseURL(), manifest, remote)
+	if err != nil {
+		return err
+	}
+
+	if !filesSyncDryRun {
+		err = applySyncPlan(ctx, client, manifest, actions)
+		if saveErr := manifest.save(); saveErr != nil {
+			PrintInfo(i18n.T(i18n.WarningCouldNotUpdateUploadManifest, saveErr))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if IsJSONOutput() {
+		if actions == nil {
+			actions = []syncAction{}
+		}
+		return GetFormatter().Print(map[string]any{
+			"dry_run":   filesSyncDryRun,
+			"actions":   actions,
+			"unchanged": unchanged,
+		})
+	}
+
+	for _, a := range actions {
+		fmt.Printf("%-7s %s\n", a.Action, a.Name)
+	}
+	verb := "Synced"
+	if filesSyncDryRun {
+		verb = "Would sync"
+	}
+	fmt.Printf("%s %s: %d changed, %d unchanged\n", verb, dir, len(actions), unchanged)
+	return nil
+}
+
+// applySyncPlan runs the actions in order, recording uploads in manifest
+func applySyncPlan(ctx context.Context, client *api.NotteClient, manifest uploadManifest, actions []syncAction) error {
+	for _, a := range actions {
+		resp, err := uploadFile(ctx, client, a.path, a.Name)
+		if err != nil {
+			return i18n.Errorf(i18n.FailedToUploadError, a.Name, err)
+		}
+		if resp.JSON200 == nil || !resp.JSON200.Success {
+			return i18n.Errorf(i18n.FailedToUpload, a.Name)
+		}
+		manifest.record(client.BaseURL(), a.Name, uploadRecord{SHA256: a.sum, Size: a.size, UploadedAt: time.Now().UTC()})
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func setupFilesSyncTest(t *testing.T) (*testutil.MockServer, string) {
+	t.Helper()
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+
+	server := testutil.NewMockServer()
+	t.Cleanup(func() { server.Close() })
+	env.SetEnv("NOTTE_API_URL", server.URL())
+
+	config.SetTestConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+
+	origDryRun, origFormat := filesSyncDryRun, outputFormat
+	t.Cleanup(func() { filesSyncDryRun, outputFormat = origDryRun, origFormat })
+	filesSyncDryRun = false
+	outputFormat = "json"
+
+	// new.txt is new, same.txt was uploaded unchanged, stale.txt exists
+	// remotely without a manifest entry, and orphan.txt only exists remotely
+	dir := t.TempDir()
+	for name, content := range map[string]string{"new.txt": "new", "same.txt": "same", "stale.txt": "stale"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "nested"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	sum, size, err := hashFile(filepath.Join(dir, "same.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := uploadManifest{}
+	manifest.record(server.URL(), "same.txt", uploadRecord{SHA256: sum, Size: size})
+	if err := manifest.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	server.AddResponse("/storage/uploads", 200, `{"files":[
+		{"name":"same.txt","file_ext":".txt","size":4},
+		{"name":"stale.txt","file_ext":".txt","size":3},
+		{"name":"orphan.txt","file_ext":".txt","size":1}]}`)
+	for _, name := range []string{"new.txt", "stale.txt", "orphan.txt"} {
+		server.AddResponse("/storage/uploads/"+name, 200, `{"success":true}`)
+	}
+	return server, dir
+}
+
+func runSync(t *testing.T, dir string) (actions []syncAction, unchanged int) {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runFilesSync(cmd, []string{dir}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var out struct {
+		Actions   []syncAction `json:"actions"`
+		Unchanged int          `json:"unchanged"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", stdout, err)
+	}
+	return out.Actions, out.Unchanged
+}
+
+func TestRunFilesSync_DryRun(t *testing.T) {
+	server, dir := setupFilesSyncTest(t)
+	filesSyncDryRun = true
+
+	actions, unchanged := runSync(t, dir)
+
+	want := []syncAction{{Action: "upload", Name: "new.txt"}, {Action: "update", Name: "stale.txt"}}
+	if len(actions) != len(want) {
+		t.Fatalf("actions = %+v, want %+v", actions, want)
+	}
+	for i := range want {
+		if actions[i].Action != want[i].Action || actions[i].Name != want[i].Name {
+			t.Errorf("actions[%d] = %+v, want %+v", i, actions[i], want[i])
+		}
+	}
+	if unchanged != 1 {
+		t.Errorf("unchanged = %d, want 1", unchanged)
+	}
+	for _, name := range []string{"new.txt", "stale.txt", "orphan.txt"} {
+		if got := len(server.Requests("/storage/uploads/" + name)); got != 0 {
+			t.Errorf("dry run sent %d request(s) for %s", got, name)
+		}
+	}
+}
+
+func TestRunFilesSync_Apply(t *testing.T) {
+	server, dir := setupFilesSyncTest(t)
+
+	runSync(t, dir)
+
+	for _, name := range []string{"new.txt", "stale.txt"} {
+		reqs := server.Requests("/storage/uploads/" + name)
+		if len(reqs) != 1 || reqs[0].Method != "POST" {
+			t.Errorf("%s requests = %+v, want one upload", name, reqs)
+		}
+	}
+	if reqs := server.Requests("/storage/uploads/orphan.txt"); len(reqs) != 0 {
+		t.Errorf("orphan.txt requests = %+v, want it left alone", reqs)
+	}
+	if got := len(server.Requests("/storage/uploads/same.txt")); got != 0 {
+		t.Errorf("unchanged file was uploaded")
+	}
+
+	manifest, err := loadUploadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"new.txt", "same.txt", "stale.txt"} {
+		if _, ok := manifest.lookup(server.URL(), name); !ok {
+			t.Errorf("manifest is missing %s", name)
+		}
+	}
+
+	// Once storage reflects the sync, nothing is left to do
+	server.AddResponse("/storage/uploads", 200, `{"files":[
+		{"name":"new.txt","file_ext":".txt","size":3},
+		{"name":"same.txt","file_ext":".txt","size":4},
+		{"name":"stale.txt","file_ext":".txt","size":5},
+		{"name":"orphan.txt","file_ext":".txt","size":1}]}`)
+	if actions, unchanged := runSync(t, dir); len(actions) != 0 || unchanged != 3 {
+		t.Errorf("second sync: actions = %+v, unchanged = %d", actions, unchanged)
+	}
+}
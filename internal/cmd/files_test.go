@@ -24,13 +24,8 @@ func TestRunFilesListUploads(t *testing.T) {
 	server.AddResponse("/storage/uploads", 200, `{"files":[{"name":"a.txt","file_ext":".txt","size":100}]}`)
 
 	origUploadsFlag := filesListUploadsFlag
-	origSession := sessionID
-	t.Cleanup(func() {
-		filesListUploadsFlag = origUploadsFlag
-		sessionID = origSession
-	})
+	t.Cleanup(func() { filesListUploadsFlag = origUploadsFlag })
 	filesListUploadsFlag = true
-	sessionID = ""
 
 	origFormat := outputFormat
 	outputFormat = "json"
@@ -62,13 +57,8 @@ func TestRunFilesListUploadsEmpty(t *testing.T) {
 	server.AddResponse("/storage/uploads", 200, `{"files":[]}`)
 
 	origUploadsFlag := filesListUploadsFlag
-	origSession := sessionID
-	t.Cleanup(func() {
-		filesListUploadsFlag = origUploadsFlag
-		sessionID = origSession
-	})
+	t.Cleanup(func() { filesListUploadsFlag = origUploadsFlag })
 	filesListUploadsFlag = true
-	sessionID = ""
 
 	origFormat := outputFormat
 	outputFormat = "text"
@@ -100,13 +90,9 @@ func TestRunFilesListDownloads(t *testing.T) {
 	server.AddResponse("/storage/sess_123/downloads", 200, `{"files":[{"name":"b.txt","file_ext":".txt","size":200}]}`)
 
 	origDownloadsFlag := filesListDownloadsFlag
-	origSession := sessionID
-	t.Cleanup(func() {
-		filesListDownloadsFlag = origDownloadsFlag
-		sessionID = origSession
-	})
+	t.Cleanup(func() { filesListDownloadsFlag = origDownloadsFlag })
 	filesListDownloadsFlag = true
-	sessionID = "sess_123"
+	env.SetEnv("NOTTE_SESSION_ID", "sess_123")
 
 	origFormat := outputFormat
 	outputFormat = "json"
@@ -138,13 +124,8 @@ func TestRunFilesListDownloadsMissingSession(t *testing.T) {
 	t.Cleanup(func() { config.SetTestConfigDir("") })
 
 	origDownloadsFlag := filesListDownloadsFlag
-	origSession := sessionID
-	t.Cleanup(func() {
-		filesListDownloadsFlag = origDownloadsFlag
-		sessionID = origSession
-	})
+	t.Cleanup(func() { filesListDownloadsFlag = origDownloadsFlag })
 	filesListDownloadsFlag = true
-	sessionID = ""
 
 	cmd := &cobra.Command{}
 	cmd.SetContext(context.Background())
@@ -166,6 +147,9 @@ func TestRunFilesUpload(t *testing.T) {
 	defer server.Close()
 	env.SetEnv("NOTTE_API_URL", server.URL())
 
+	config.SetTestConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+
 	tmpFile, err := os.CreateTemp("", "upload-*.txt")
 	if err != nil {
 		t.Fatalf("failed to create temp file: %v", err)
@@ -229,13 +213,9 @@ func TestRunFilesDownload(t *testing.T) {
 	defer server.Close()
 	env.SetEnv("NOTTE_API_URL", server.URL())
 
-	origSession := sessionID
 	origOutput := filesDownloadOutput
-	t.Cleanup(func() {
-		sessionID = origSession
-		filesDownloadOutput = origOutput
-	})
-	sessionID = "sess_123"
+	t.Cleanup(func() { filesDownloadOutput = origOutput })
+	env.SetEnv("NOTTE_SESSION_ID", "sess_123")
 
 	outDir := t.TempDir()
 	outputPath := filepath.Join(outDir, "download.txt")
@@ -279,10 +259,6 @@ func TestRunFilesDownloadMissingSession(t *testing.T) {
 	config.SetTestConfigDir(tmpDir)
 	t.Cleanup(func() { config.SetTestConfigDir("") })
 
-	origSession := sessionID
-	t.Cleanup(func() { sessionID = origSession })
-	sessionID = ""
-
 	cmd := &cobra.Command{}
 	cmd.SetContext(context.Background())
 
@@ -294,3 +270,107 @@ func TestRunFilesDownloadMissingSession(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func setupFilesUploadTest(t *testing.T) (*testutil.MockServer, string) {
+	t.Helper()
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+
+	server := testutil.NewMockServer()
+	t.Cleanup(func() { server.Close() })
+	env.SetEnv("NOTTE_API_URL", server.URL())
+
+	config.SetTestConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+
+	origForce, origFormat := filesUploadForce, outputFormat
+	t.Cleanup(func() { filesUploadForce, outputFormat = origForce, origFormat })
+	filesUploadForce = false
+	outputFormat = "text"
+
+	path := filepath.Join(t.TempDir(), "fixture.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	server.AddResponse("/storage/uploads/fixture.txt", 200, `{"success":true}`)
+	return server, path
+}
+
+func uploadFixture(t *testing.T, path string) string {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runFilesUpload(cmd, []string{path}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	return stdout
+}
+
+func TestRunFilesUpload_SkipsDuplicate(t *testing.T) {
+	server, path := setupFilesUploadTest(t)
+	server.AddResponse("/storage/uploads", 200, `{"files":[{"name":"fixture.txt","file_ext":".txt","size":5}]}`)
+
+	uploadFixture(t, path)
+	if got := len(server.Requests("/storage/uploads/fixture.txt")); got != 1 {
+		t.Fatalf("uploads = %d, want 1", got)
+	}
+
+	if out := uploadFixture(t, path); !strings.Contains(out, "already uploaded") {
+		t.Errorf("expected skip message, got %q", out)
+	}
+	if got := len(server.Requests("/storage/uploads/fixture.txt")); got != 1 {
+		t.Errorf("duplicate was uploaded again: uploads = %d", got)
+	}
+
+	filesUploadForce = true
+	uploadFixture(t, path)
+	if got := len(server.Requests("/storage/uploads/fixture.txt")); got != 2 {
+		t.Errorf("--force should upload again: uploads = %d", got)
+	}
+}
+
+func TestRunFilesUpload_ReuploadsChangedOrMissing(t *testing.T) {
+	server, path := setupFilesUploadTest(t)
+	server.AddResponse("/storage/uploads", 200, `{"files":[{"name":"fixture.txt","file_ext":".txt","size":5}]}`)
+
+	uploadFixture(t, path)
+
+	// Same name and size, different content
+	if err := os.WriteFile(path, []byte("world"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	uploadFixture(t, path)
+	if got := len(server.Requests("/storage/uploads/fixture.txt")); got != 2 {
+		t.Errorf("changed file should be uploaded: uploads = %d", got)
+	}
+
+	// Same content, but storage no longer has the file
+	server.AddResponse("/storage/uploads", 200, `{"files":[]}`)
+	uploadFixture(t, path)
+	if got := len(server.Requests("/storage/uploads/fixture.txt")); got != 3 {
+		t.Errorf("file missing from storage should be uploaded: uploads = %d", got)
+	}
+}
+
+func TestRunFilesInfo(t *testing.T) {
+	server, _ := setupFilesUploadTest(t)
+	server.AddResponse("/storage/uploads", 200, `{"files":[{"name":"fixture.txt","file_ext":".txt","size":5,"updated_at":"2024-05-01T10:00:00Z"}]}`)
+	outputFormat = "json"
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runFilesInfo(cmd, []string{"fixture.txt"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, `"size":5`) || !strings.Contains(stdout, "2024-05-01T10:00:00Z") {
+		t.Errorf("expected size and upload date, got %q", stdout)
+	}
+
+	if err := runFilesInfo(cmd, []string{"missing.txt"}); err == nil || !strings.Contains(err.Error(), "missing.txt") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// flagContext holds the values of the ID flags shared by several command trees
+// (--session-id, --agent-id, --function-id). The values live in each
+// command's own flag set, so commands never observe each other's flag values
+// and nothing outlives the command that parsed them.
+type flagContext struct {
+	SessionID  string
+	AgentID    string
+	FunctionID string
+}
+
+// flagsFor returns the ID flag values of cmd. Subcommands inherit the values
+// of the nearest ancestor that registered persistent ID flags (e.g. page).
+func flagsFor(cmd *cobra.Command) *flagContext {
+	return &flagContext{
+		SessionID:  idFlagValue(cmd, "session-id"),
+		AgentID:    idFlagValue(cmd, "agent-id"),
+		FunctionID: idFlagValue(cmd, "function-id"),
+	}
+}
+
+// idFlagValue looks name up on cmd, then in the persistent flags of its
+// ancestors, which cobra only merges into cmd's flags once it parses them
+func idFlagValue(cmd *cobra.Command, name string) string {
+	if cmd == nil {
+		return ""
+	}
+	if f := cmd.Flags().Lookup(name); f != nil {
+		return f.Value.String()
+	}
+	for c := cmd.Parent(); c != nil; c = c.Parent() {
+		if f := c.PersistentFlags().Lookup(name); f != nil {
+			return f.Value.String()
+		}
+	}
+	return ""
+}
+
+// addSessionIDFlag registers --session-id on cmd
+func addSessionIDFlag(cmd *cobra.Command) {
+	cmd.Flags().String("session-id", "", "Session ID (uses current session if not specified)")
+	_ = cmd.RegisterFlagCompletionFunc("session-id", completeIDsFromHistory(idKindSession))
+}
+
+// addPersistentSessionIDFlag registers --session-id on cmd and all its subcommands
+func addPersistentSessionIDFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("session-id", "", "Session ID (uses current session if not specified)")
+	_ = cmd.RegisterFlagCompletionFunc("session-id", completeIDsFromHistory(idKindSession))
+}
+
+// addAgentIDFlag registers --agent-id on cmd
+func addAgentIDFlag(cmd *cobra.Command) {
+	cmd.Flags().String("agent-id", "", "Agent ID (uses current agent if not specified)")
+	_ = cmd.RegisterFlagCompletionFunc("agent-id", completeIDsFromHistory(idKindAgent))
+}
+
+// addFunctionIDFlag registers --function-id on cmd
+func addFunctionIDFlag(cmd *cobra.Command) {
+	cmd.Flags().String("function-id", "", "Function ID (uses current function if not specified)")
+}
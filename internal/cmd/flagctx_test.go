@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestFlagContext_IndependentPerCommand(t *testing.T) {
+	a := &cobra.Command{Use: "a"}
+	b := &cobra.Command{Use: "b"}
+	addSessionIDFlag(a)
+	addSessionIDFlag(b)
+
+	if err := a.Flags().Set("session-id", "sess_a"); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+
+	if got := flagsFor(a).SessionID; got != "sess_a" {
+		t.Errorf("flagsFor(a).SessionID = %q, want %q", got, "sess_a")
+	}
+	if got := flagsFor(b).SessionID; got != "" {
+		t.Errorf("flagsFor(b).SessionID = %q, want empty", got)
+	}
+}
+
+func TestFlagContext_InheritsPersistentFlag(t *testing.T) {
+	parent := &cobra.Command{Use: "parent"}
+	child := &cobra.Command{Use: "child"}
+	parent.AddCommand(child)
+	addPersistentSessionIDFlag(parent)
+
+	if err := parent.PersistentFlags().Set("session-id", "sess_parent"); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+
+	if got := flagsFor(child).SessionID; got != "sess_parent" {
+		t.Errorf("flagsFor(child).SessionID = %q, want %q", got, "sess_parent")
+	}
+}
+
+func TestFlagContext_ParsedPersistentFlag(t *testing.T) {
+	parent := &cobra.Command{Use: "parent"}
+	var got string
+	child := &cobra.Command{Use: "child", Run: func(cmd *cobra.Command, args []string) {
+		got = flagsFor(cmd).SessionID
+	}}
+	parent.AddCommand(child)
+	addPersistentSessionIDFlag(parent)
+
+	parent.SetArgs([]string{"child", "--session-id", "sess_parsed"})
+	if err := parent.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if got != "sess_parsed" {
+		t.Errorf("flagsFor(child).SessionID = %q, want %q", got, "sess_parsed")
+	}
+}
+
+func TestFlagContext_NilCommand(t *testing.T) {
+	if got := flagsFor(nil); got.SessionID != "" || got.AgentID != "" || got.FunctionID != "" {
+		t.Errorf("flagsFor(nil) = %+v, want empty context", got)
+	}
+}
@@ -133,3 +133,34 @@ func TestPaginatedListCommandsHaveFlags(t *testing.T) {
 		}
 	}
 }
+
+// TestNoDuplicateCommandRegistrations ensures no two sibling commands share a
+// name or alias. Cobra silently dispatches to the first match, so a duplicate
+// registration (e.g. an "agent" tree next to "agents") would shadow commands.
+func TestNoDuplicateCommandRegistrations(t *testing.T) {
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		seen := map[string]string{}
+		for _, sub := range cmd.Commands() {
+			for _, name := range append([]string{sub.Name()}, sub.Aliases...) {
+				if prev, ok := seen[name]; ok {
+					t.Errorf("%s: %q is registered by both %q and %q", cmd.CommandPath(), name, prev, sub.Name())
+					continue
+				}
+				seen[name] = sub.Name()
+			}
+			walk(sub)
+		}
+	}
+	walk(rootCmd)
+}
+
+func TestAgentsAlias(t *testing.T) {
+	found, _, err := rootCmd.Find([]string{"agent", "list"})
+	if err != nil {
+		t.Fatalf("Find(agent list) error = %v", err)
+	}
+	if found != agentsListCmd {
+		t.Errorf("notte agent list resolved to %q, want %q", found.CommandPath(), agentsListCmd.CommandPath())
+	}
+}
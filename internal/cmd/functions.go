@@ -16,6 +16,7 @@ import (
 
 	"github.com/nottelabs/notte-cli/internal/api"
 	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
 var (
@@ -26,7 +27,6 @@ var (
 )
 
 var (
-	functionID               string
 	functionUpdateFile       string
 	functionRunID            string
 	functionMetadataJSON     string
@@ -37,10 +37,10 @@ var (
 )
 
 // GetCurrentFunctionID returns the function ID from flag, env var, or file (in priority order)
-func GetCurrentFunctionID() string {
-	// 1. Check --function-id flag (already in functionID variable if set)
-	if functionID != "" {
-		return functionID
+func GetCurrentFunctionID(cmd *cobra.Command) string {
+	// 1. Check --function-id flag
+	if id := flagsFor(cmd).FunctionID; id != "" {
+		return id
 	}
 
 	// 2. Check NOTTE_FUNCTION_ID env var
@@ -86,13 +86,13 @@ func clearCurrentFunction() error {
 	return nil
 }
 
-// RequireFunctionID ensures a function ID is available from flag, env, or file
-func RequireFunctionID() error {
-	functionID = GetCurrentFunctionID()
-	if functionID == "" {
-		return errors.New("function ID required: use --function-id flag, set NOTTE_FUNCTION_ID env var, or create a function first")
+// RequireFunctionID resolves the function ID for cmd from flag, env, or file
+func RequireFunctionID(cmd *cobra.Command) (string, error) {
+	id := GetCurrentFunctionID(cmd)
+	if id == "" {
+		return "", errors.New(i18n.T(i18n.FunctionIDRequired))
 	}
-	return nil
+	return id, nil
 }
 
 var functionsCmd = &cobra.Command{
@@ -103,9 +103,10 @@ var functionsCmd = &cobra.Command{
 }
 
 var functionsListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List functions",
-	RunE:  runFunctionsList,
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List functions",
+	RunE:    runFunctionsList,
 }
 
 var functionsCreateCmd = &cobra.Command{
@@ -129,10 +130,11 @@ var functionsUpdateCmd = &cobra.Command{
 }
 
 var functionsDeleteCmd = &cobra.Command{
-	Use:   "delete",
-	Short: "Delete function",
-	Args:  cobra.NoArgs,
-	RunE:  runFunctionDelete,
+	Use:     "delete",
+	Aliases: []string{"rm"},
+	Short:   "Delete function",
+	Args:    cobra.NoArgs,
+	RunE:    runFunctionDelete,
 }
 
 var functionsRunCmd = &cobra.Command{
@@ -206,10 +208,11 @@ var functionSecretsCmd = &cobra.Command{
 }
 
 var functionSecretsListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List function environment secrets",
-	Args:  cobra.NoArgs,
-	RunE:  runFunctionSecretsList,
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List function environment secrets",
+	Args:    cobra.NoArgs,
+	RunE:    runFunctionSecretsList,
 }
 
 var functionSecretsGetCmd = &cobra.Command{
@@ -238,6 +241,7 @@ func init() {
 	rootCmd.AddCommand(functionsCmd)
 	functionsCmd.AddCommand(functionsListCmd)
 	registerPaginationFlags(functionsListCmd)
+	registerScopeFlag(functionsListCmd)
 	functionsListCmd.Flags().Bool("only-active", false, "Only return active functions")
 
 	functionsCmd.AddCommand(functionsCreateCmd)
@@ -269,50 +273,51 @@ func init() {
 	functionsCreateCmd.Flags().BoolVar(&functionsCreateShared, "shared", false, "Make function public")
 
 	// Show command flags
-	functionsShowCmd.Flags().StringVar(&functionID, "function-id", "", "Function ID (uses current function if not specified)")
+	addFunctionIDFlag(functionsShowCmd)
 
 	// Update command flags
-	functionsUpdateCmd.Flags().StringVar(&functionID, "function-id", "", "Function ID (uses current function if not specified)")
+	addFunctionIDFlag(functionsUpdateCmd)
 	functionsUpdateCmd.Flags().StringVar(&functionUpdateFile, "file", "", "Path to updated function file (required)")
 	_ = functionsUpdateCmd.MarkFlagRequired("file")
 
 	// Delete command flags
-	functionsDeleteCmd.Flags().StringVar(&functionID, "function-id", "", "Function ID (uses current function if not specified)")
+	addFunctionIDFlag(functionsDeleteCmd)
+	addStdinIDsFlag(functionsDeleteCmd, "function-id")
 
 	// Run command flags
-	functionsRunCmd.Flags().StringVar(&functionID, "function-id", "", "Function ID (uses current function if not specified)")
+	addFunctionIDFlag(functionsRunCmd)
 	functionsRunCmd.Flags().StringArrayVar(&functionRunVariables, "var", []string{}, "Variable as key=value pair (can be used multiple times)")
-	functionsRunCmd.Flags().StringVar(&functionRunVariablesJSON, "vars", "", "Variables as JSON object string")
+	functionsRunCmd.Flags().StringVar(&functionRunVariablesJSON, "vars", "", "Variables as a JSON object, @file, or '-' for stdin")
 
 	// Runs command flags
-	functionsRunsCmd.Flags().StringVar(&functionID, "function-id", "", "Function ID (uses current function if not specified)")
+	addFunctionIDFlag(functionsRunsCmd)
 
 	// Fork command flags
-	functionsForkCmd.Flags().StringVar(&functionID, "function-id", "", "Function ID (uses current function if not specified)")
+	addFunctionIDFlag(functionsForkCmd)
 
 	// Run-stop command flags
-	functionsRunStopCmd.Flags().StringVar(&functionID, "function-id", "", "Function ID (uses current function if not specified)")
+	addFunctionIDFlag(functionsRunStopCmd)
 	functionsRunStopCmd.Flags().StringVar(&functionRunID, "run-id", "", "Run ID (required)")
 	_ = functionsRunStopCmd.MarkFlagRequired("run-id")
 
 	// Run-metadata command flags
-	functionsRunMetadataCmd.Flags().StringVar(&functionID, "function-id", "", "Function ID (uses current function if not specified)")
+	addFunctionIDFlag(functionsRunMetadataCmd)
 	functionsRunMetadataCmd.Flags().StringVar(&functionRunID, "run-id", "", "Run ID (required)")
 	_ = functionsRunMetadataCmd.MarkFlagRequired("run-id")
 
 	// Run-metadata-update command flags
-	functionsRunMetadataUpdateCmd.Flags().StringVar(&functionID, "function-id", "", "Function ID (uses current function if not specified)")
+	addFunctionIDFlag(functionsRunMetadataUpdateCmd)
 	functionsRunMetadataUpdateCmd.Flags().StringVar(&functionRunID, "run-id", "", "Run ID (required)")
 	_ = functionsRunMetadataUpdateCmd.MarkFlagRequired("run-id")
 	functionsRunMetadataUpdateCmd.Flags().StringVar(&functionMetadataJSON, "data", "", "JSON metadata, @file, or '-' for stdin")
 
 	// Schedule command flags
-	functionsScheduleCmd.Flags().StringVar(&functionID, "function-id", "", "Function ID (uses current function if not specified)")
+	addFunctionIDFlag(functionsScheduleCmd)
 	functionsScheduleCmd.Flags().StringVar(&functionCronExpression, "cron", "", "Cron expression (required)")
 	_ = functionsScheduleCmd.MarkFlagRequired("cron")
 
 	// Unschedule command flags
-	functionsUnscheduleCmd.Flags().StringVar(&functionID, "function-id", "", "Function ID (uses current function if not specified)")
+	addFunctionIDFlag(functionsUnscheduleCmd)
 
 	// Function secrets command flags
 	functionSecretsSetCmd.Flags().StringVar(&functionSecretValue, "value", "", "Secret value")
@@ -335,9 +340,14 @@ func runFunctionsList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	scope, onlyCurrentToken, err := getScopeFlag(cmd)
+	if err != nil {
+		return err
+	}
 	params := &api.ListFunctionsParams{
-		Page:     page,
-		PageSize: pageSize,
+		Page:             page,
+		PageSize:         pageSize,
+		OnlyCurrentToken: onlyCurrentToken,
 	}
 	if cmd.Flags().Changed("only-active") {
 		v, _ := cmd.Flags().GetBool("only-active")
@@ -345,7 +355,7 @@ func runFunctionsList(cmd *cobra.Command, args []string) error {
 	}
 	resp, err := client.Client().ListFunctionsWithResponse(ctx, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -364,6 +374,33 @@ func runFunctionsList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if scope != "" {
+		owned, err := scopeOwnedIDs(scope, func(page *int) ([]string, bool, error) {
+			mine := *params
+			mine.Page, mine.OnlyCurrentToken = page, boolPtr(true)
+			r, err := client.Client().ListFunctionsWithResponse(ctx, &mine)
+			if err == nil {
+				err = HandleAPIResponse(r.HTTPResponse, r.Body)
+			}
+			if err != nil || r.JSON200 == nil {
+				return nil, false, err
+			}
+			ids := make([]string, len(r.JSON200.Items))
+			for i, item := range r.JSON200.Items {
+				ids[i] = item.FunctionId
+			}
+			return ids, r.JSON200.HasNext, nil
+		})
+		if err != nil {
+			return err
+		}
+		rows := make([]ownedFunction, len(items))
+		for i, item := range items {
+			rows[i] = ownedFunction{Owner: ownerOf(scope, owned, item.FunctionId), GetFunctionResponse: item}
+		}
+		return formatter.Print(rows)
+	}
+
 	return formatter.Print(items)
 }
 
@@ -376,7 +413,7 @@ func runFunctionsCreate(cmd *cobra.Command, args []string) error {
 	// Open the function file
 	file, err := os.Open(functionsCreateFile)
 	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
+		return i18n.Errorf(i18n.FailedToOpenFile, err)
 	}
 	defer func() { _ = file.Close() }()
 
@@ -387,26 +424,26 @@ func runFunctionsCreate(cmd *cobra.Command, args []string) error {
 	// Add file field
 	part, err := writer.CreateFormFile("file", filepath.Base(functionsCreateFile))
 	if err != nil {
-		return fmt.Errorf("failed to create form file: %w", err)
+		return i18n.Errorf(i18n.FailedToCreateFormFile, err)
 	}
 	if _, err := io.Copy(part, file); err != nil {
-		return fmt.Errorf("failed to copy file data: %w", err)
+		return i18n.Errorf(i18n.FailedToCopyFileData, err)
 	}
 
 	// Add optional fields
 	if functionsCreateName != "" {
 		if err := writer.WriteField("name", functionsCreateName); err != nil {
-			return fmt.Errorf("failed to write name field: %w", err)
+			return i18n.Errorf(i18n.FailedToWriteNameField, err)
 		}
 	}
 	if functionsCreateDescription != "" {
 		if err := writer.WriteField("description", functionsCreateDescription); err != nil {
-			return fmt.Errorf("failed to write description field: %w", err)
+			return i18n.Errorf(i18n.FailedToWriteDescriptionField, err)
 		}
 	}
 	if cmd.Flags().Changed("shared") {
 		if err := writer.WriteField("shared", fmt.Sprintf("%t", functionsCreateShared)); err != nil {
-			return fmt.Errorf("failed to write shared field: %w", err)
+			return i18n.Errorf(i18n.FailedToWriteSharedField, err)
 		}
 	}
 
@@ -423,7 +460,7 @@ func runFunctionsCreate(cmd *cobra.Command, args []string) error {
 		&buf,
 	)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -433,7 +470,7 @@ func runFunctionsCreate(cmd *cobra.Command, args []string) error {
 	// Save function ID as current function
 	if resp.JSON200 != nil && resp.JSON200.FunctionId != "" {
 		if err := setCurrentFunction(resp.JSON200.FunctionId); err != nil {
-			PrintInfo(fmt.Sprintf("Warning: could not save current function: %v", err))
+			PrintInfo(i18n.T(i18n.WarningCouldNotSaveCurrentFunction, err))
 		}
 	}
 
@@ -442,7 +479,8 @@ func runFunctionsCreate(cmd *cobra.Command, args []string) error {
 }
 
 func runFunctionShow(cmd *cobra.Command, args []string) error {
-	if err := RequireFunctionID(); err != nil {
+	functionID, err := RequireFunctionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -457,7 +495,7 @@ func runFunctionShow(cmd *cobra.Command, args []string) error {
 	params := &api.FunctionDownloadUrlParams{}
 	resp, err := client.Client().FunctionDownloadUrlWithResponse(ctx, functionID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -468,7 +506,8 @@ func runFunctionShow(cmd *cobra.Command, args []string) error {
 }
 
 func runFunctionUpdate(cmd *cobra.Command, args []string) error {
-	if err := RequireFunctionID(); err != nil {
+	functionID, err := RequireFunctionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -480,7 +519,7 @@ func runFunctionUpdate(cmd *cobra.Command, args []string) error {
 	// Open the function file
 	file, err := os.Open(functionUpdateFile)
 	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
+		return i18n.Errorf(i18n.FailedToOpenFile, err)
 	}
 	defer func() { _ = file.Close() }()
 
@@ -491,10 +530,10 @@ func runFunctionUpdate(cmd *cobra.Command, args []string) error {
 	// Add file field
 	part, err := writer.CreateFormFile("file", filepath.Base(functionUpdateFile))
 	if err != nil {
-		return fmt.Errorf("failed to create form file: %w", err)
+		return i18n.Errorf(i18n.FailedToCreateFormFile, err)
 	}
 	if _, err := io.Copy(part, file); err != nil {
-		return fmt.Errorf("failed to copy file data: %w", err)
+		return i18n.Errorf(i18n.FailedToCopyFileData, err)
 	}
 
 	_ = writer.Close()
@@ -511,7 +550,7 @@ func runFunctionUpdate(cmd *cobra.Command, args []string) error {
 		&buf,
 	)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -522,7 +561,12 @@ func runFunctionUpdate(cmd *cobra.Command, args []string) error {
 }
 
 func runFunctionDelete(cmd *cobra.Command, args []string) error {
-	if err := RequireFunctionID(); err != nil {
+	functionID, err := RequireFunctionID(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := checkProtected("function", functionID); err != nil {
 		return err
 	}
 
@@ -531,7 +575,7 @@ func runFunctionDelete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	if !confirmed {
-		return PrintResult("Cancelled.", map[string]any{"cancelled": true})
+		return PrintResult(i18n.T(i18n.Cancelled), map[string]any{"cancelled": true})
 	}
 
 	client, err := GetClient()
@@ -545,7 +589,7 @@ func runFunctionDelete(cmd *cobra.Command, args []string) error {
 	params := &api.FunctionDeleteParams{}
 	resp, err := client.Client().FunctionDeleteWithResponse(ctx, functionID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -561,14 +605,15 @@ func runFunctionDelete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	return PrintResult(fmt.Sprintf("Function %s deleted.", functionID), map[string]any{
+	return PrintResult(i18n.T(i18n.FunctionDeleted, functionID), map[string]any{
 		"id":     functionID,
 		"status": "deleted",
 	})
 }
 
 func runFunctionRun(cmd *cobra.Command, args []string) error {
-	if err := RequireFunctionID(); err != nil {
+	functionID, err := RequireFunctionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -585,8 +630,12 @@ func runFunctionRun(cmd *cobra.Command, args []string) error {
 
 	// First, parse JSON variables if provided
 	if functionRunVariablesJSON != "" {
-		if err := json.Unmarshal([]byte(functionRunVariablesJSON), &variables); err != nil {
-			return fmt.Errorf("failed to parse --vars JSON: %w", err)
+		data, err := readJSONInput(cmd, functionRunVariablesJSON, "vars")
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &variables); err != nil {
+			return i18n.Errorf(i18n.FailedToParseVarsJSON, err)
 		}
 	}
 
@@ -594,7 +643,7 @@ func runFunctionRun(cmd *cobra.Command, args []string) error {
 	for _, kv := range functionRunVariables {
 		parts := strings.SplitN(kv, "=", 2)
 		if len(parts) != 2 {
-			return fmt.Errorf("invalid variable format %q: expected key=value", kv)
+			return i18n.Errorf(i18n.InvalidVariableFormat, kv)
 		}
 		variables[parts[0]] = parts[1]
 	}
@@ -608,13 +657,13 @@ func runFunctionRun(cmd *cobra.Command, args []string) error {
 
 	bodyJSON, err := json.Marshal(requestBody)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request body: %w", err)
+		return i18n.Errorf(i18n.FailedToMarshalRequestBody, err)
 	}
 
 	url := fmt.Sprintf("%s/functions/%s/runs/start", client.BaseURL(), functionID)
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(bodyJSON))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return i18n.Errorf(i18n.FailedToCreateRequest, err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -622,13 +671,13 @@ func runFunctionRun(cmd *cobra.Command, args []string) error {
 
 	httpResp, err := client.HTTPClient().Do(req)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 	defer func() { _ = httpResp.Body.Close() }()
 
 	body, err := io.ReadAll(httpResp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return i18n.Errorf(i18n.FailedToReadResponseBody, err)
 	}
 
 	if err := HandleAPIResponse(httpResp, body); err != nil {
@@ -638,14 +687,15 @@ func runFunctionRun(cmd *cobra.Command, args []string) error {
 	// Parse and print the response
 	var result interface{}
 	if err := json.Unmarshal(body, &result); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+		return i18n.Errorf(i18n.FailedToParseResponse, err)
 	}
 
 	return GetFormatter().Print(result)
 }
 
 func runFunctionRuns(cmd *cobra.Command, args []string) error {
-	if err := RequireFunctionID(); err != nil {
+	functionID, err := RequireFunctionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -675,7 +725,7 @@ func runFunctionRuns(cmd *cobra.Command, args []string) error {
 	}
 	resp, err := client.Client().ListFunctionRunsByFunctionIdWithResponse(ctx, functionID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -696,7 +746,8 @@ func runFunctionRuns(cmd *cobra.Command, args []string) error {
 }
 
 func runFunctionFork(cmd *cobra.Command, args []string) error {
-	if err := RequireFunctionID(); err != nil {
+	functionID, err := RequireFunctionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -711,7 +762,7 @@ func runFunctionFork(cmd *cobra.Command, args []string) error {
 	params := &api.FunctionForkParams{}
 	resp, err := client.Client().FunctionForkWithResponse(ctx, functionID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -722,7 +773,12 @@ func runFunctionFork(cmd *cobra.Command, args []string) error {
 }
 
 func runFunctionRunStop(cmd *cobra.Command, args []string) error {
-	if err := RequireFunctionID(); err != nil {
+	functionID, err := RequireFunctionID(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := checkProtected("function run", functionRunID); err != nil {
 		return err
 	}
 
@@ -737,7 +793,7 @@ func runFunctionRunStop(cmd *cobra.Command, args []string) error {
 	params := &api.FunctionRunStopParams{}
 	resp, err := client.Client().FunctionRunStopWithResponse(ctx, functionID, functionRunID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -748,7 +804,8 @@ func runFunctionRunStop(cmd *cobra.Command, args []string) error {
 }
 
 func runFunctionRunMetadata(cmd *cobra.Command, args []string) error {
-	if err := RequireFunctionID(); err != nil {
+	functionID, err := RequireFunctionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -763,7 +820,7 @@ func runFunctionRunMetadata(cmd *cobra.Command, args []string) error {
 	params := &api.FunctionRunGetMetadataParams{}
 	resp, err := client.Client().FunctionRunGetMetadataWithResponse(ctx, functionID, functionRunID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -774,7 +831,8 @@ func runFunctionRunMetadata(cmd *cobra.Command, args []string) error {
 }
 
 func runFunctionRunMetadataUpdate(cmd *cobra.Command, args []string) error {
-	if err := RequireFunctionID(); err != nil {
+	functionID, err := RequireFunctionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -791,7 +849,7 @@ func runFunctionRunMetadataUpdate(cmd *cobra.Command, args []string) error {
 	// Parse the JSON metadata
 	var metadata api.FunctionRunUpdateMetadataJSONRequestBody
 	if err := json.Unmarshal(metadataPayload, &metadata); err != nil {
-		return fmt.Errorf("failed to parse JSON metadata: %w", err)
+		return i18n.Errorf(i18n.FailedToParseJSONMetadata, err)
 	}
 
 	ctx, cancel := GetContextWithTimeout(cmd.Context())
@@ -800,7 +858,7 @@ func runFunctionRunMetadataUpdate(cmd *cobra.Command, args []string) error {
 	params := &api.FunctionRunUpdateMetadataParams{}
 	resp, err := client.Client().FunctionRunUpdateMetadataWithResponse(ctx, functionID, functionRunID, params, metadata)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -811,7 +869,8 @@ func runFunctionRunMetadataUpdate(cmd *cobra.Command, args []string) error {
 }
 
 func runFunctionSchedule(cmd *cobra.Command, args []string) error {
-	if err := RequireFunctionID(); err != nil {
+	functionID, err := RequireFunctionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -832,21 +891,26 @@ func runFunctionSchedule(cmd *cobra.Command, args []string) error {
 	params := &api.FunctionScheduleSetParams{}
 	resp, err := client.Client().FunctionScheduleSetWithResponse(ctx, functionID, params, body)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
 		return err
 	}
 
-	return PrintResult(fmt.Sprintf("Function %s scheduled with cron expression: %s", functionID, functionCronExpression), map[string]any{
+	return PrintResult(i18n.T(i18n.FunctionScheduled, functionID, functionCronExpression), map[string]any{
 		"id":   functionID,
 		"cron": functionCronExpression,
 	})
 }
 
 func runFunctionUnschedule(cmd *cobra.Command, args []string) error {
-	if err := RequireFunctionID(); err != nil {
+	functionID, err := RequireFunctionID(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := checkProtected("function", functionID); err != nil {
 		return err
 	}
 
@@ -861,14 +925,14 @@ func runFunctionUnschedule(cmd *cobra.Command, args []string) error {
 	params := &api.FunctionScheduleDeleteParams{}
 	resp, err := client.Client().FunctionScheduleDeleteWithResponse(ctx, functionID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
 		return err
 	}
 
-	return PrintResult(fmt.Sprintf("Function %s schedule removed.", functionID), map[string]any{
+	return PrintResult(i18n.T(i18n.FunctionScheduleRemoved, functionID), map[string]any{
 		"id":     functionID,
 		"status": "unscheduled",
 	})
@@ -891,7 +955,7 @@ func runFunctionSecretsList(cmd *cobra.Command, args []string) error {
 	params := &api.ListSecretsParams{Namespace: &namespace}
 	resp, err := client.Client().ListSecretsWithResponse(ctx, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -923,7 +987,7 @@ func runFunctionSecretsGet(cmd *cobra.Command, args []string) error {
 	params := &api.GetSecretParams{Namespace: functionSecretsNamespace()}
 	resp, err := client.Client().GetSecretWithResponse(ctx, args[0], params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -939,7 +1003,7 @@ func runFunctionSecretsSet(cmd *cobra.Command, args []string) error {
 		value = args[1]
 	}
 	if value == "" && !cmd.Flags().Changed("value") {
-		return fmt.Errorf("secret value required: pass it as an argument or with --value")
+		return i18n.Errorf(i18n.SecretValueRequired)
 	}
 
 	client, err := GetClient()
@@ -958,7 +1022,7 @@ func runFunctionSecretsSet(cmd *cobra.Command, args []string) error {
 	params := &api.StoreSecretParams{}
 	resp, err := client.Client().StoreSecretWithResponse(ctx, params, body)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -971,12 +1035,16 @@ func runFunctionSecretsSet(cmd *cobra.Command, args []string) error {
 func runFunctionSecretsDelete(cmd *cobra.Command, args []string) error {
 	secretID := args[0]
 
+	if err := checkProtected("function environment secret", secretID); err != nil {
+		return err
+	}
+
 	confirmed, err := ConfirmAction("function environment secret", secretID)
 	if err != nil {
 		return err
 	}
 	if !confirmed {
-		return PrintResult("Cancelled.", map[string]any{"cancelled": true})
+		return PrintResult(i18n.T(i18n.Cancelled), map[string]any{"cancelled": true})
 	}
 
 	client, err := GetClient()
@@ -990,14 +1058,14 @@ func runFunctionSecretsDelete(cmd *cobra.Command, args []string) error {
 	params := &api.DeleteSecretParams{}
 	resp, err := client.Client().DeleteSecretWithResponse(ctx, secretID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
 		return err
 	}
 
-	return PrintResult(fmt.Sprintf("Function environment secret %s deleted.", secretID), map[string]any{
+	return PrintResult(i18n.T(i18n.FunctionEnvironmentSecretDeleted, secretID), map[string]any{
 		"id":     secretID,
 		"status": "deleted",
 	})
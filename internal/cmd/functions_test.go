@@ -28,14 +28,13 @@ func setupFunctionTest(t *testing.T) *testutil.MockServer {
 	t.Cleanup(func() { server.Close() })
 	env.SetEnv("NOTTE_API_URL", server.URL())
 
-	origFunctionID := functionID
+	env.SetEnv("NOTTE_FUNCTION_ID", functionIDTest)
+
 	origRunID := functionRunID
 	origSecretValue := functionSecretValue
-	functionID = functionIDTest
 	functionRunID = functionRunIDTest
 	functionSecretValue = ""
 	t.Cleanup(func() {
-		functionID = origFunctionID
 		functionRunID = origRunID
 		functionSecretValue = origSecretValue
 	})
@@ -663,35 +662,27 @@ func setupFunctionFileTest(t *testing.T) string {
 }
 
 func TestGetCurrentFunctionID_FromFlag(t *testing.T) {
-	origID := functionID
-	functionID = "flag_function"
-	t.Cleanup(func() { functionID = origID })
+	cmd := &cobra.Command{}
+	addFunctionIDFlag(cmd)
+	_ = cmd.Flags().Set("function-id", "flag_function")
 
-	got := GetCurrentFunctionID()
+	got := GetCurrentFunctionID(cmd)
 	if got != "flag_function" {
 		t.Errorf("GetCurrentFunctionID() = %q, want %q", got, "flag_function")
 	}
 }
 
 func TestGetCurrentFunctionID_FromEnvVar(t *testing.T) {
-	origID := functionID
-	functionID = ""
-	t.Cleanup(func() { functionID = origID })
-
 	env := testutil.SetupTestEnv(t)
 	env.SetEnv("NOTTE_FUNCTION_ID", "env_function")
 
-	got := GetCurrentFunctionID()
+	got := GetCurrentFunctionID(nil)
 	if got != "env_function" {
 		t.Errorf("GetCurrentFunctionID() = %q, want %q", got, "env_function")
 	}
 }
 
 func TestGetCurrentFunctionID_FromFile(t *testing.T) {
-	origID := functionID
-	functionID = ""
-	t.Cleanup(func() { functionID = origID })
-
 	env := testutil.SetupTestEnv(t)
 	env.SetEnv("NOTTE_FUNCTION_ID", "") // Ensure env var is empty
 
@@ -708,15 +699,15 @@ func TestGetCurrentFunctionID_FromFile(t *testing.T) {
 		t.Fatalf("failed to write function file: %v", err)
 	}
 
-	got := GetCurrentFunctionID()
+	got := GetCurrentFunctionID(nil)
 	if got != "file_function" {
 		t.Errorf("GetCurrentFunctionID() = %q, want %q", got, "file_function")
 	}
 }
 
 func TestGetCurrentFunctionID_Priority(t *testing.T) {
-	origID := functionID
-	t.Cleanup(func() { functionID = origID })
+	cmd := &cobra.Command{}
+	addFunctionIDFlag(cmd)
 
 	env := testutil.SetupTestEnv(t)
 	tmpDir := setupFunctionFileTest(t)
@@ -732,24 +723,24 @@ func TestGetCurrentFunctionID_Priority(t *testing.T) {
 	}
 
 	// Test: flag > env > file
-	functionID = "flag_function"
+	_ = cmd.Flags().Set("function-id", "flag_function")
 	env.SetEnv("NOTTE_FUNCTION_ID", "env_function")
 
-	got := GetCurrentFunctionID()
+	got := GetCurrentFunctionID(cmd)
 	if got != "flag_function" {
 		t.Errorf("flag should have highest priority: got %q, want %q", got, "flag_function")
 	}
 
 	// Test: env > file
-	functionID = ""
-	got = GetCurrentFunctionID()
+	_ = cmd.Flags().Set("function-id", "")
+	got = GetCurrentFunctionID(cmd)
 	if got != "env_function" {
 		t.Errorf("env should have priority over file: got %q, want %q", got, "env_function")
 	}
 
 	// Test: file as fallback
 	env.SetEnv("NOTTE_FUNCTION_ID", "")
-	got = GetCurrentFunctionID()
+	got = GetCurrentFunctionID(cmd)
 	if got != "file_function" {
 		t.Errorf("file should be fallback: got %q, want %q", got, "file_function")
 	}
@@ -813,15 +804,11 @@ func TestClearCurrentFunction_NoFile(t *testing.T) {
 }
 
 func TestRequireFunctionID_NoFunction(t *testing.T) {
-	origID := functionID
-	functionID = ""
-	t.Cleanup(func() { functionID = origID })
-
 	env := testutil.SetupTestEnv(t)
 	env.SetEnv("NOTTE_FUNCTION_ID", "")
 	_ = setupFunctionFileTest(t)
 
-	err := RequireFunctionID()
+	_, err := RequireFunctionID(nil)
 	if err == nil {
 		t.Fatal("RequireFunctionID() should error when no function ID available")
 	}
@@ -833,10 +820,6 @@ func TestRequireFunctionID_NoFunction(t *testing.T) {
 }
 
 func TestRequireFunctionID_FromFile(t *testing.T) {
-	origID := functionID
-	functionID = ""
-	t.Cleanup(func() { functionID = origID })
-
 	env := testutil.SetupTestEnv(t)
 	env.SetEnv("NOTTE_FUNCTION_ID", "")
 	tmpDir := setupFunctionFileTest(t)
@@ -851,13 +834,13 @@ func TestRequireFunctionID_FromFile(t *testing.T) {
 		t.Fatalf("failed to write function file: %v", err)
 	}
 
-	err := RequireFunctionID()
+	id, err := RequireFunctionID(nil)
 	if err != nil {
 		t.Fatalf("RequireFunctionID() error = %v", err)
 	}
 
-	if functionID != "file_function" {
-		t.Errorf("functionID = %q, want %q", functionID, "file_function")
+	if id != "file_function" {
+		t.Errorf("RequireFunctionID() = %q, want %q", id, "file_function")
 	}
 }
 
@@ -949,9 +932,7 @@ func TestFunctionDelete_ClearsCurrentFunction(t *testing.T) {
 
 	server.AddResponse("/functions/"+functionIDTest, 200, `{"message":"deleted","status":"deleted"}`)
 
-	origID := functionID
-	functionID = functionIDTest
-	t.Cleanup(func() { functionID = origID })
+	env.SetEnv("NOTTE_FUNCTION_ID", functionIDTest)
 
 	SetSkipConfirmation(true)
 	t.Cleanup(func() { SetSkipConfirmation(false) })
@@ -999,9 +980,7 @@ func TestFunctionDelete_DifferentFunction_DoesNotClearCurrentFunction(t *testing
 	// Delete a different function "fn_different"
 	server.AddResponse("/functions/fn_different", 200, `{"message":"deleted","status":"deleted"}`)
 
-	origID := functionID
-	functionID = "fn_different"
-	t.Cleanup(func() { functionID = origID })
+	env.SetEnv("NOTTE_FUNCTION_ID", "fn_different")
 
 	SetSkipConfirmation(true)
 	t.Cleanup(func() { SetSkipConfirmation(false) })
@@ -1052,12 +1031,7 @@ func TestFunctionShow_UsesCurrentFunction(t *testing.T) {
 
 	server.AddResponse("/functions/"+functionIDTest, 200, functionWithLinkJSON())
 
-	// Clear functionID to test file-based resolution
-	origID := functionID
-	functionID = ""
-	t.Cleanup(func() { functionID = origID })
-
-	// Clear env var too
+	// Clear env var so the current file is used
 	env.SetEnv("NOTTE_FUNCTION_ID", "")
 
 	origFormat := outputFormat
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// parseHTTPCredentials splits a "user:pass" value; the password may contain
+// colons
+func parseHTTPCredentials(value string) (user, pass string, err error) {
+	user, pass, ok := strings.Cut(value, ":")
+	if !ok || user == "" {
+		return "", "", i18n.Errorf(i18n.InvalidHTTPCredentials)
+	}
+	return user, pass, nil
+}
+
+// basicAuthHeader is the Authorization header value for HTTP basic auth
+func basicAuthHeader(user, pass string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+pass))
+}
+
+// urlWithCredentials puts user and pass in rawURL's userinfo, which the
+// browser answers a basic auth challenge with
+func urlWithCredentials(rawURL, user, pass string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", i18n.Errorf(i18n.HTTPCredentialsNeedAbsoluteURL, rawURL)
+	}
+	u.User = url.UserPassword(user, pass)
+	return u.String(), nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+)
+
+// idHistoryLimit caps how many IDs of each kind are remembered
+const idHistoryLimit = 50
+
+// ID kinds tracked in the local history
+const (
+	idKindSession = "session"
+	idKindAgent   = "agent"
+)
+
+// idHistoryEntry is one ID and when it was last used
+type idHistoryEntry struct {
+	ID       string    `json:"id"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// idHistory holds the IDs used locally, most recently used first, so shell
+// completion can offer them without calling the API
+type idHistory map[string][]idHistoryEntry
+
+func idHistoryPath() (string, error) {
+	configDir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, config.IDHistoryFile), nil
+}
+
+func loadIDHistory() idHistory {
+	path, err := idHistoryPath()
+	if err != nil {
+		return idHistory{}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return idHistory{}
+	}
+	var h idHistory
+	if err := json.Unmarshal(data, &h); err != nil || h == nil {
+		return idHistory{}
+	}
+	return h
+}
+
+// recordIDUse moves id to the front of the history for kind. Failures are
+// ignored: the history only feeds completion.
+func recordIDUse(kind, id string) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return
+	}
+	path, err := idHistoryPath()
+	if err != nil {
+		return
+	}
+
+	h := loadIDHistory()
+	entries := slices.DeleteFunc(h[kind], func(e idHistoryEntry) bool { return e.ID == id })
+	entries = append([]idHistoryEntry{{ID: id, LastUsed: time.Now().UTC()}}, entries...)
+	if len(entries) > idHistoryLimit {
+		entries = entries[:idHistoryLimit]
+	}
+	h[kind] = entries
+
+	data, err := json.Marshal(h)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
+
+// completeIDsFromHistory completes an ID flag from the local history, most
+// recently used first, without touching the network
+func completeIDsFromHistory(kind string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var out []string
+		now := time.Now()
+		for _, e := range loadIDHistory()[kind] {
+			if strings.HasPrefix(e.ID, toComplete) {
+				out = append(out, fmt.Sprintf("%s\tused %s", e.ID, dashAge(e.LastUsed, now)))
+			}
+		}
+		return out, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+func setupIDHistoryTest(t *testing.T) {
+	t.Helper()
+	config.SetTestConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+}
+
+func historyIDs(kind string) []string {
+	var ids []string
+	for _, e := range loadIDHistory()[kind] {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func TestRecordIDUse_MostRecentFirst(t *testing.T) {
+	setupIDHistoryTest(t)
+
+	recordIDUse(idKindSession, "sess_a")
+	recordIDUse(idKindSession, "sess_b")
+	recordIDUse(idKindSession, "sess_a")
+	recordIDUse(idKindAgent, "agent_a")
+	recordIDUse(idKindAgent, " ")
+
+	if got := strings.Join(historyIDs(idKindSession), ","); got != "sess_a,sess_b" {
+		t.Errorf("sessions = %s, want sess_a,sess_b", got)
+	}
+	if got := strings.Join(historyIDs(idKindAgent), ","); got != "agent_a" {
+		t.Errorf("agents = %s, want agent_a", got)
+	}
+}
+
+func TestRecordIDUse_Limit(t *testing.T) {
+	setupIDHistoryTest(t)
+
+	for i := range idHistoryLimit + 5 {
+		recordIDUse(idKindAgent, fmt.Sprintf("agent_%d", i))
+	}
+	ids := historyIDs(idKindAgent)
+	if len(ids) != idHistoryLimit {
+		t.Fatalf("expected %d entries, got %d", idHistoryLimit, len(ids))
+	}
+	if want := fmt.Sprintf("agent_%d", idHistoryLimit+4); ids[0] != want {
+		t.Errorf("first entry = %s, want %s", ids[0], want)
+	}
+}
+
+func TestRequireSessionID_RecordsHistory(t *testing.T) {
+	setupIDHistoryTest(t)
+
+	cmd := &cobra.Command{Use: "test"}
+	addSessionIDFlag(cmd)
+	_ = cmd.Flags().Set("session-id", "sess_flag")
+
+	if _, err := RequireSessionID(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := historyIDs(idKindSession); len(got) != 1 || got[0] != "sess_flag" {
+		t.Errorf("history = %v, want [sess_flag]", got)
+	}
+}
+
+func TestCompleteIDsFromHistory(t *testing.T) {
+	setupIDHistoryTest(t)
+
+	recordIDUse(idKindSession, "sess_old")
+	recordIDUse(idKindSession, "other")
+	recordIDUse(idKindSession, "sess_new")
+
+	comps, directive := completeIDsFromHistory(idKindSession)(nil, nil, "sess_")
+	if len(comps) != 2 || !strings.HasPrefix(comps[0], "sess_new\t") || !strings.HasPrefix(comps[1], "sess_old\t") {
+		t.Errorf("completions = %q", comps)
+	}
+	if directive&cobra.ShellCompDirectiveNoFileComp == 0 || directive&cobra.ShellCompDirectiveKeepOrder == 0 {
+		t.Errorf("directive = %d, want NoFileComp|KeepOrder", directive)
+	}
+}
+
+func TestCompleteSessionIDFlag(t *testing.T) {
+	setupIDHistoryTest(t)
+	recordIDUse(idKindSession, "sess_recent")
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(io.Discard)
+	rootCmd.SetArgs([]string{cobra.ShellCompRequestCmd, "sessions", "status", "--session-id", ""})
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		rootCmd.SetArgs(nil)
+		SetNonInteractive(false)
+		i18n.SetLocale(i18n.DefaultLocale)
+	})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("completion failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "sess_recent\tused ") {
+		t.Errorf("completion output missing history entry:\n%s", out.String())
+	}
+}
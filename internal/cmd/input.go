@@ -2,14 +2,19 @@ package cmd
 
 import (
 	"bytes"
-	"fmt"
 	"io"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
+// maxJSONInputSize bounds JSON read from a flag, file or stdin, so a wrong
+// path (a log, a disk image) fails fast instead of being sent to the API
+const maxJSONInputSize = 10 << 20
+
 // readJSONInput reads JSON input from a flag value, file, or stdin.
 // Supports: direct JSON, @file.json, @- for stdin, or - for stdin.
 func readJSONInput(cmd *cobra.Command, value string, flagName string) ([]byte, error) {
@@ -17,21 +22,24 @@ func readJSONInput(cmd *cobra.Command, value string, flagName string) ([]byte, e
 	if input == "" {
 		return readFromStdin(cmd, flagName)
 	}
+	if len(input) > maxJSONInputSize {
+		return nil, jsonInputTooLarge(flagName)
+	}
 
 	if strings.HasPrefix(input, "@") {
 		path := strings.TrimPrefix(input, "@")
 		if path == "" {
-			return nil, fmt.Errorf("invalid %s value: missing file path after @", flagName)
+			return nil, i18n.Errorf(i18n.MissingFilePathAfterAt, flagName)
 		}
 		if path == "-" {
 			return readFromStdin(cmd, flagName)
 		}
-		data, err := os.ReadFile(path)
+		data, err := readJSONInputFile(path, flagName)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read %s file %q: %w", flagName, path, err)
+			return nil, err
 		}
 		if len(bytes.TrimSpace(data)) == 0 {
-			return nil, fmt.Errorf("%s file %q is empty", flagName, path)
+			return nil, i18n.Errorf(i18n.FileIsEmpty, flagName, path)
 		}
 		return data, nil
 	}
@@ -43,18 +51,54 @@ func readJSONInput(cmd *cobra.Command, value string, flagName string) ([]byte, e
 	return []byte(input), nil
 }
 
+// readJSONFileInput is readJSONInput for flags that used to take a plain
+// file path (--file, --*-json): a value that isn't JSON, @file or - is read
+// as a file.
+func readJSONFileInput(cmd *cobra.Command, value string, flagName string) ([]byte, error) {
+	input := strings.TrimSpace(value)
+	if input != "" && input != "-" && !strings.HasPrefix(input, "@") &&
+		!strings.HasPrefix(input, "{") && !strings.HasPrefix(input, "[") {
+		input = "@" + input
+	}
+	return readJSONInput(cmd, input, flagName)
+}
+
+func readJSONInputFile(path string, flagName string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, i18n.Errorf(i18n.FailedToReadNamedFile, flagName, path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxJSONInputSize+1))
+	if err != nil {
+		return nil, i18n.Errorf(i18n.FailedToReadNamedFile, flagName, path, err)
+	}
+	if len(data) > maxJSONInputSize {
+		return nil, jsonInputTooLarge(flagName)
+	}
+	return data, nil
+}
+
+func jsonInputTooLarge(flagName string) error {
+	return i18n.Errorf(i18n.InputTooLarge, flagName, maxJSONInputSize>>20)
+}
+
 func readFromStdin(cmd *cobra.Command, flagName string) ([]byte, error) {
 	in := cmd.InOrStdin()
 	if !stdinHasData(in) {
-		return nil, fmt.Errorf("%s is required (use --%s, --%s @file, or pipe JSON via stdin)", flagName, flagName, flagName)
+		return nil, i18n.Errorf(i18n.JSONInputRequired, flagName, flagName, flagName)
 	}
 
-	data, err := io.ReadAll(in)
+	data, err := io.ReadAll(io.LimitReader(in, maxJSONInputSize+1))
 	if err != nil {
-		return nil, fmt.Errorf("failed to read %s from stdin: %w", flagName, err)
+		return nil, i18n.Errorf(i18n.FailedToReadFromStdin, flagName, err)
+	}
+	if len(data) > maxJSONInputSize {
+		return nil, jsonInputTooLarge(flagName)
 	}
 	if len(bytes.TrimSpace(data)) == 0 {
-		return nil, fmt.Errorf("%s input is empty", flagName)
+		return nil, i18n.Errorf(i18n.InputIsEmpty, flagName)
 	}
 	return data, nil
 }
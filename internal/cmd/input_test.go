@@ -100,6 +100,41 @@ func TestReadJSONInput_MissingFile(t *testing.T) {
 	}
 }
 
+func TestReadJSONInput_TooLarge(t *testing.T) {
+	big := bytes.Repeat([]byte(" "), maxJSONInputSize+1)
+	path := filepath.Join(t.TempDir(), "big.json")
+	if err := os.WriteFile(path, big, 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	for name, value := range map[string]string{"file": "@" + path, "stdin": "-"} {
+		cmd := &cobra.Command{}
+		cmd.SetIn(bytes.NewReader(big))
+		_, err := readJSONInput(cmd, value, "data")
+		if err == nil || err.Error() != "data input is larger than the 10 MB limit" {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+}
+
+func TestReadJSONFileInput_BarePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cookies.json")
+	if err := os.WriteFile(path, []byte(`[{"name":"a"}]`), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	for _, value := range []string{path, "@" + path, `[{"name":"a"}]`} {
+		data, err := readJSONFileInput(cmd, value, "file")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", value, err)
+		}
+		if string(data) != `[{"name":"a"}]` {
+			t.Errorf("%s: unexpected data: %s", value, data)
+		}
+	}
+}
+
 func TestStdinHasData(t *testing.T) {
 	if !stdinHasData(bytes.NewBufferString("x")) {
 		t.Fatal("expected true for non-file reader")
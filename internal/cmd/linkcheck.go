@@ -0,0 +1,417 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+const linkcheckUserAgent = "notte-cli-linkcheck"
+
+var (
+	linkcheckConcurrency int
+	linkcheckTimeout     time.Duration
+	linkcheckRobots      bool
+	linkcheckSameHost    bool
+	linkcheckJUnit       string
+)
+
+var linkcheckCmd = &cobra.Command{
+	Use:   "linkcheck <url>",
+	Short: "Find broken links on a page",
+	Long: `Open a URL in the current session, collect every link on the rendered page
+and check each one with a HEAD request (falling back to GET when HEAD isn't
+allowed). Links answering 4xx or 5xx, or not answering at all, are broken.
+
+Links are checked from this machine, not from the browser session. Paths
+disallowed for all user agents by the site's robots.txt are skipped unless
+--robots=false. The command exits non-zero when a link is broken.`,
+	Example: `  notte linkcheck https://example.com
+  notte linkcheck https://example.com --same-host --concurrency 16
+  notte linkcheck https://example.com --junit linkcheck.xml
+  notte linkcheck https://example.com -o json | jq '.links[] | select(.broken)'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLinkcheck,
+}
+
+func init() {
+	rootCmd.AddCommand(linkcheckCmd)
+	addSessionIDFlag(linkcheckCmd)
+	addWaitLoadFlag(linkcheckCmd)
+	addCallbackURLFlag(linkcheckCmd)
+
+	linkcheckCmd.Flags().IntVar(&linkcheckConcurrency, "concurrency", 8, "Number of links checked at the same time")
+	linkcheckCmd.Flags().DurationVar(&linkcheckTimeout, "link-timeout", 10*time.Second, "Timeout for each link")
+	linkcheckCmd.Flags().BoolVar(&linkcheckRobots, "robots", true, "Skip links disallowed by the site's robots.txt")
+	linkcheckCmd.Flags().BoolVar(&linkcheckSameHost, "same-host", false, "Only check links to the page's host")
+	linkcheckCmd.Flags().StringVar(&linkcheckJUnit, "junit", "", "Also write the results as a JUnit XML report to this path")
+}
+
+// pageLinksJS returns the absolute URL of every link on the page
+const pageLinksJS = `JSON.stringify(Array.from(document.querySelectorAll('a[href],area[href]')).map((a) => a.href))`
+
+// linkResult is the outcome of checking one link
+type linkResult struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Broken     bool   `json:"broken"`
+	Skipped    string `json:"skipped,omitempty"`
+}
+
+// linkcheckReport is what `linkcheck` prints
+type linkcheckReport struct {
+	URL     string       `json:"url"`
+	Checked int          `json:"checked"`
+	Broken  int          `json:"broken"`
+	Skipped int          `json:"skipped"`
+	Links   []linkResult `json:"links"`
+}
+
+func runLinkcheck(cmd *cobra.Command, args []string) error {
+	if linkcheckConcurrency < 1 {
+		return i18n.Errorf(i18n.ConcurrencyTooSmall)
+	}
+	state, _ := cmd.Flags().GetString("wait-load")
+	if state == "" {
+		state = loadStateDOMContentLoaded
+	}
+
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := sendPageAction(cmd, map[string]any{"type": "goto", "url": args[0]})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return i18n.Errorf(i18n.FailedToOpen, args[0], resp.Message)
+	}
+	if err := waitForLoadState(cmd, state); err != nil {
+		return err
+	}
+
+	out, err := evalPageJS(cmd.Context(), client, sessionID, pageLinksJS)
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToCollectLinks, err)
+	}
+	var hrefs []string
+	if err := decodeJSResult(out, &hrefs); err != nil {
+		return i18n.Errorf(i18n.UnexpectedLinkList, err)
+	}
+
+	links := collectLinks(args[0], hrefs, linkcheckSameHost)
+	PrintInfo(i18n.T(i18n.CheckingLinks, len(links)))
+	checker := &linkChecker{
+		client:      &http.Client{Timeout: linkcheckTimeout},
+		robots:      linkcheckRobots,
+		robotsRules: map[string]*robotsRules{},
+	}
+	results := checker.checkAll(cmd.Context(), links, linkcheckConcurrency)
+
+	report := linkcheckReport{URL: args[0], Links: results}
+	for _, r := range results {
+		switch {
+		case r.Skipped != "":
+			report.Skipped++
+		case r.Broken:
+			report.Broken++
+			report.Checked++
+		default:
+			report.Checked++
+		}
+	}
+
+	recordCallbackResult(report)
+
+	if linkcheckJUnit != "" {
+		if err := writeLinkcheckJUnit(linkcheckJUnit, report); err != nil {
+			return i18n.Errorf(i18n.FailedToWriteJUnitReport, err)
+		}
+	}
+
+	if IsJSONOutput() {
+		if err := GetFormatter().Print(report); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Broken {
+				fmt.Printf("%s  %s\n", linkStatus(r), r.URL)
+			}
+		}
+		fmt.Printf("Checked %d links: %d broken, %d skipped\n", report.Checked, report.Broken, report.Skipped)
+	}
+
+	if report.Broken > 0 {
+		return i18n.Errorf(i18n.BrokenLinks, report.Broken, args[0])
+	}
+	return nil
+}
+
+// linkStatus is the status code of a result, or its error
+func linkStatus(r linkResult) string {
+	if r.Error != "" {
+		return r.Error
+	}
+	return fmt.Sprint(r.StatusCode)
+}
+
+// collectLinks keeps the unique http(s) links, without fragments, in the
+// order they appear on the page
+func collectLinks(pageURL string, hrefs []string, sameHost bool) []string {
+	var host string
+	if u, err := url.Parse(pageURL); err == nil {
+		host = u.Hostname()
+	}
+	seen := map[string]bool{}
+	var links []string
+	for _, href := range hrefs {
+		u, err := url.Parse(href)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		if sameHost && u.Hostname() != host {
+			continue
+		}
+		u.Fragment = ""
+		link := u.String()
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// linkChecker checks links, fetching each host's robots.txt once
+type linkChecker struct {
+	client *http.Client
+	robots bool
+
+	mu          sync.Mutex
+	robotsRules map[string]*robotsRules
+}
+
+// checkAll checks links with up to concurrency requests in flight and
+// returns the results in the same order
+func (c *linkChecker) checkAll(ctx context.Context, links []string, concurrency int) []linkResult {
+	results := make([]linkResult, len(links))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, link := range links {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = c.check(ctx, link)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func (c *linkChecker) check(ctx context.Context, link string) linkResult {
+	result := linkResult{URL: link}
+	u, err := url.Parse(link)
+	if err != nil {
+		result.Broken = true
+		result.Error = err.Error()
+		return result
+	}
+	if c.robots && !c.rulesFor(ctx, u).allowed(u.EscapedPath()) {
+		result.Skipped = "disallowed by robots.txt"
+		return result
+	}
+
+	status, err := c.fetchStatus(ctx, http.MethodHead, link)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = c.fetchStatus(ctx, http.MethodGet, link)
+	}
+	if err != nil {
+		result.Broken = true
+		result.Error = err.Error()
+		return result
+	}
+	result.StatusCode = status
+	result.Broken = status >= 400
+	return result
+}
+
+func (c *linkChecker) fetchStatus(ctx context.Context, method, link string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", linkcheckUserAgent)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	// Drain a little so the connection can be reused
+	_, _ = io.CopyN(io.Discard, resp.Body, 4096)
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// rulesFor returns the robots.txt rules of u's site, fetching them once
+func (c *linkChecker) rulesFor(ctx context.Context, u *url.URL) *robotsRules {
+	site := u.Scheme + "://" + u.Host
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if rules, ok := c.robotsRules[site]; ok {
+		return rules
+	}
+
+	rules := &robotsRules{}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, site+"/robots.txt", nil)
+	if err == nil {
+		req.Header.Set("User-Agent", linkcheckUserAgent)
+		if resp, err := c.client.Do(req); err == nil {
+			if resp.StatusCode == http.StatusOK {
+				rules = parseRobots(io.LimitReader(resp.Body, 512*1024))
+			}
+			_ = resp.Body.Close()
+		}
+	}
+	c.robotsRules[site] = rules
+	return rules
+}
+
+// robotsRules are the Allow and Disallow path prefixes that robots.txt sets
+// for all user agents
+type robotsRules struct {
+	allow    []string
+	disallow []string
+}
+
+// parseRobots reads the "User-agent: *" groups of a robots.txt
+func parseRobots(r io.Reader) *robotsRules {
+	rules := &robotsRules{}
+	inGroup, groupHasRules := false, false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		switch key {
+		case "user-agent":
+			// Consecutive User-agent lines share the rules that follow
+			if groupHasRules {
+				inGroup, groupHasRules = false, false
+			}
+			if value == "*" {
+				inGroup = true
+			}
+		case "allow", "disallow":
+			groupHasRules = true
+			if !inGroup || value == "" {
+				continue
+			}
+			if key == "allow" {
+				rules.allow = append(rules.allow, value)
+			} else {
+				rules.disallow = append(rules.disallow, value)
+			}
+		}
+	}
+	return rules
+}
+
+// allowed applies the longest matching prefix, with Allow winning ties
+func (r *robotsRules) allowed(path string) bool {
+	if path == "" {
+		path = "/"
+	}
+	longest := func(prefixes []string) int {
+		n := -1
+		for _, p := range prefixes {
+			if strings.HasPrefix(path, p) && len(p) > n {
+				n = len(p)
+			}
+		}
+		return n
+	}
+	return longest(r.allow) >= longest(r.disallow)
+}
+
+// JUnit report elements
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+func writeLinkcheckJUnit(path string, report linkcheckReport) error {
+	suite := junitTestSuite{
+		Name:     "linkcheck " + report.URL,
+		Tests:    len(report.Links),
+		Failures: report.Broken,
+		Skipped:  report.Skipped,
+	}
+	for _, r := range report.Links {
+		tc := junitTestCase{Name: r.URL, ClassName: "linkcheck"}
+		if u, err := url.Parse(r.URL); err == nil {
+			tc.ClassName = u.Host
+		}
+		switch {
+		case r.Skipped != "":
+			tc.Skipped = &junitMessage{Message: r.Skipped}
+		case r.Broken:
+			tc.Failure = &junitMessage{Message: linkStatus(r)}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	sort.SliceStable(suite.TestCases, func(i, j int) bool {
+		return suite.TestCases[i].ClassName < suite.TestCases[j].ClassName
+	})
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+	path, err = prepareOutputPath(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(longPath(path), append([]byte(xml.Header), append(data, '\n')...), 0o644)
+}
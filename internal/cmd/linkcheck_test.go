@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+func TestCollectLinks(t *testing.T) {
+	hrefs := []string{
+		"https://example.com/a",
+		"https://example.com/a#section",
+		"mailto:hi@example.com",
+		"javascript:void(0)",
+		"https://other.com/b",
+		"http://example.com/c",
+	}
+
+	got := collectLinks("https://example.com/", hrefs, false)
+	want := []string{"https://example.com/a", "https://other.com/b", "http://example.com/c"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("collectLinks() = %v, want %v", got, want)
+	}
+
+	got = collectLinks("https://example.com/", hrefs, true)
+	want = []string{"https://example.com/a", "http://example.com/c"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("collectLinks(sameHost) = %v, want %v", got, want)
+	}
+}
+
+func TestParseRobots(t *testing.T) {
+	rules := parseRobots(strings.NewReader(`# comment
+User-agent: googlebot
+Disallow: /
+
+User-agent: other
+User-agent: *
+Disallow: /private
+Allow: /private/public # still fine
+Disallow:
+`))
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/", true},
+		{"/about", true},
+		{"/private", false},
+		{"/private/secret", false},
+		{"/private/public/page", true},
+	}
+	for _, tt := range tests {
+		if got := rules.allowed(tt.path); got != tt.want {
+			t.Errorf("allowed(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+// linkTestServer serves a few pages with known status codes
+func linkTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("User-agent: *\nDisallow: /admin\n"))
+	})
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/get-only", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/admin", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("robots.txt disallowed path was requested")
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLinkChecker_CheckAll(t *testing.T) {
+	site := linkTestServer(t)
+	links := []string{site.URL + "/ok", site.URL + "/missing", site.URL + "/get-only", site.URL + "/admin"}
+
+	checker := &linkChecker{
+		client:      &http.Client{Timeout: 5 * time.Second},
+		robots:      true,
+		robotsRules: map[string]*robotsRules{},
+	}
+	results := checker.checkAll(context.Background(), links, 2)
+
+	want := []linkResult{
+		{URL: links[0], StatusCode: 200},
+		{URL: links[1], StatusCode: 404, Broken: true},
+		{URL: links[2], StatusCode: 200},
+		{URL: links[3], Skipped: "disallowed by robots.txt"},
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("result %d = %+v, want %+v", i, results[i], want[i])
+		}
+	}
+}
+
+func TestRunLinkcheck(t *testing.T) {
+	server := setupPageTest(t)
+	site := linkTestServer(t)
+
+	path := "/sessions/" + pageSessionIDTest + "/page/execute"
+	server.AddResponse(path, 200, pageExecResponse())
+	hrefs, _ := json.Marshal([]string{site.URL + "/ok", site.URL + "/missing#top", site.URL + "/admin"})
+	server.AddMatchedResponse(mockserver.Match{Path: path, BodyContains: "readyState"}, loadStateResponse("complete:1"))
+	server.AddMatchedResponse(mockserver.Match{Path: path, BodyContains: "document.querySelectorAll"}, mockserver.JSONResponse(200, evalJSResponse(string(hrefs))))
+
+	junit := filepath.Join(t.TempDir(), "report.xml")
+	origJUnit := linkcheckJUnit
+	linkcheckJUnit = junit
+	t.Cleanup(func() { linkcheckJUnit = origJUnit })
+
+	cmd := newPageTestCmd()
+	addWaitLoadFlag(cmd)
+
+	var err error
+	stdout, _ := testutil.CaptureOutput(func() {
+		err = runLinkcheck(cmd, []string{site.URL})
+	})
+	if err == nil || !strings.Contains(err.Error(), "1 broken link") {
+		t.Fatalf("expected broken link error, got %v", err)
+	}
+
+	var report linkcheckReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("failed to parse output %q: %v", stdout, err)
+	}
+	if report.Checked != 2 || report.Broken != 1 || report.Skipped != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	data, err := os.ReadFile(junit)
+	if err != nil {
+		t.Fatalf("expected JUnit report: %v", err)
+	}
+	for _, want := range []string{`<testsuite name="linkcheck ` + site.URL + `" tests="3" failures="1" skipped="1">`, `<failure message="404">`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JUnit report missing %q:\n%s", want, data)
+		}
+	}
+}
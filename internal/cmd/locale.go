@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// initLocale selects the language of messages: locale in the config, or
+// else the usual locale environment variables. Languages without a
+// translation fall back to English; only a configured one is warned about.
+func initLocale() {
+	tag := ""
+	if cfg, err := config.Load(); err == nil {
+		tag = cfg.Locale
+	}
+	if tag == "" {
+		i18n.SetLocale(i18n.FromEnv(os.Getenv))
+		return
+	}
+	if !i18n.SetLocale(tag) {
+		PrintInfo(i18n.T(i18n.WarningNoTranslation, tag, strings.Join(i18n.Locales(), ", ")))
+	}
+}
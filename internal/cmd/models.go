@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var modelsCmd = &cobra.Command{
+	Use:   "models",
+	Short: "List the models agents can use",
+}
+
+var modelsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the values accepted by agents start --reasoning-model",
+	Long: `List the reasoning models an agent can be started with, as given to
+notte agents start --reasoning-model.
+
+The API has no endpoint listing models, so the list comes from the API spec
+this CLI was built with. It doesn't describe model capabilities or prices; the
+provider is the service the model is run on, so the same model can be listed
+for several providers. The API also accepts other model strings, such as models
+added after this release.`,
+	Example: `  notte models list
+  notte agents start --task "..." --reasoning-model "$(notte models list -o json | jq -r '.[0].model')"`,
+	Args: cobra.NoArgs,
+	RunE: runModelsList,
+}
+
+func init() {
+	rootCmd.AddCommand(modelsCmd)
+	modelsCmd.AddCommand(modelsListCmd)
+}
+
+// reasoningModel is one accepted --reasoning-model value
+type reasoningModel struct {
+	Model    string `json:"model"`
+	Provider string `json:"provider"`
+	Name     string `json:"name"`
+}
+
+// reasoningModels reads the known reasoning models from the API spec. The
+// second result reports whether other strings are accepted as well.
+func reasoningModels(spec *api.Spec) ([]reasoningModel, bool, error) {
+	req := spec.Components.Schemas["ApiAgentStartRequest"]
+	if req == nil || req.Properties["reasoning_model"] == nil {
+		return nil, false, errors.New(i18n.T(i18n.NoReasoningModelsInSpec))
+	}
+	field := spec.Resolve(unwrapAllOf(req.Properties["reasoning_model"]))
+	variants := unionVariants(field)
+	if len(variants) == 0 {
+		variants = []*api.Schema{field}
+	}
+
+	models := []reasoningModel{}
+	openEnded := false
+	for _, v := range variants {
+		v = spec.Resolve(v)
+		if v == nil {
+			continue
+		}
+		if len(v.Enum) == 0 && v.Type == "string" {
+			openEnded = true
+			continue
+		}
+		for _, e := range v.Enum {
+			id := fmt.Sprint(e)
+			provider, name, ok := strings.Cut(id, "/")
+			if !ok {
+				provider, name = "", id
+			}
+			models = append(models, reasoningModel{Model: id, Provider: provider, Name: name})
+		}
+	}
+	return models, openEnded, nil
+}
+
+func runModelsList(cmd *cobra.Command, args []string) error {
+	spec, err := api.LoadSpec()
+	if err != nil {
+		return err
+	}
+	models, openEnded, err := reasoningModels(spec)
+	if err != nil {
+		return err
+	}
+
+	if IsJSONOutput() {
+		return GetFormatter().Print(models)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "MODEL\tPROVIDER\tNAME")
+	for _, m := range models {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", m.Model, m.Provider, m.Name)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if openEnded {
+		PrintInfo(i18n.T(i18n.OtherModelsAccepted))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func TestReasoningModels(t *testing.T) {
+	spec, err := api.LoadSpec()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	models, openEnded, err := reasoningModels(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !openEnded {
+		t.Error("reasoning_model also accepts any string")
+	}
+
+	// The list must match the values suggested for the generated flag
+	var ids []string
+	for _, m := range models {
+		ids = append(ids, m.Model)
+	}
+	suggested := agentsStartCmd.Flags().Lookup("reasoning-model").Annotations[flagSuggestionsAnnotation]
+	if !slices.Equal(slices.Sorted(slices.Values(ids)), slices.Sorted(slices.Values(suggested))) {
+		t.Errorf("models %v don't match --reasoning-model suggestions %v", ids, suggested)
+	}
+
+	i := slices.IndexFunc(models, func(m reasoningModel) bool { return m.Model == "openrouter/google/gemma-3-27b-it" })
+	if i < 0 {
+		t.Fatal("expected openrouter/google/gemma-3-27b-it")
+	}
+	if models[i].Provider != "openrouter" || models[i].Name != "google/gemma-3-27b-it" {
+		t.Errorf("unexpected split: %+v", models[i])
+	}
+}
+
+func TestReasoningModels_MissingFromSpec(t *testing.T) {
+	spec := &api.Spec{}
+	spec.Components.Schemas = map[string]*api.Schema{}
+	if _, _, err := reasoningModels(spec); err == nil {
+		t.Error("expected an error when the spec has no reasoning_model")
+	}
+}
+
+func TestRunModelsList(t *testing.T) {
+	origFormat := outputFormat
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	outputFormat = "json"
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runModelsList(modelsListCmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var models []reasoningModel
+	if err := json.Unmarshal([]byte(stdout), &models); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout, err)
+	}
+	if !slices.Contains(models, reasoningModel{Model: "openai/gpt-4o", Provider: "openai", Name: "gpt-4o"}) {
+		t.Errorf("expected openai/gpt-4o in %+v", models)
+	}
+
+	outputFormat = "text"
+	stdout, _ = testutil.CaptureOutput(func() {
+		if err := runModelsList(modelsListCmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.HasPrefix(stdout, "MODEL") || !strings.Contains(stdout, "openai/gpt-4o") {
+		t.Errorf("unexpected text output: %s", stdout)
+	}
+	if !strings.Contains(stdout, "Other model strings are accepted") {
+		t.Errorf("expected a note about other models, got %q", stdout)
+	}
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var pageFindFresh bool
+
+var pageLastObserveCmd = &cobra.Command{
+	Use:   "last-observe",
+	Short: "Reprint the last observed page state without calling the API",
+	Long: `Reprint the page state from the last "page observe" of the session.
+
+The snapshot is dropped whenever a page action runs, so it never describes a
+page that has since changed.`,
+	Args: cobra.NoArgs,
+	RunE: runPageLastObserve,
+}
+
+var pageFindCmd = &cobra.Command{
+	Use:   "find <text>",
+	Short: "Find elements in the observed page state by text",
+	Long: `Search the interactive elements of the page for text (case-insensitive).
+
+Uses the last observed page state when there is one; pass --fresh to observe
+the page again first.`,
+	Example: `  notte page find "sign in"
+  notte page click $(notte page find "sign in" -o json | jq -r '.[0].id')`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPageFind,
+}
+
+func init() {
+	pageCmd.AddCommand(pageLastObserveCmd)
+	pageCmd.AddCommand(pageFindCmd)
+
+	pageFindCmd.Flags().BoolVar(&pageFindFresh, "fresh", false, "Observe the page again instead of using the last snapshot")
+}
+
+// observeSnapshot is the cached result of an observe, minus the screenshot
+type observeSnapshot struct {
+	SessionID   string               `json:"session_id"`
+	StartedAt   time.Time            `json:"started_at"`
+	EndedAt     time.Time            `json:"ended_at"`
+	Metadata    api.SnapshotMetadata `json:"metadata"`
+	Description string               `json:"description"`
+}
+
+func observeSnapshotPath(sessionID string) (string, error) {
+	configDir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, config.ObserveCacheDir, sessionID+".json"), nil
+}
+
+func saveObserveSnapshot(snap *observeSnapshot) error {
+	path, err := observeSnapshotPath(snap.SessionID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func loadObserveSnapshot(sessionID string) (*observeSnapshot, error) {
+	path, err := observeSnapshotPath(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, i18n.Errorf(i18n.NoObservedPageState, sessionID)
+		}
+		return nil, err
+	}
+	var snap observeSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, i18n.Errorf(i18n.FailedToParseObserveSnapshot, err)
+	}
+	return &snap, nil
+}
+
+// clearObserveSnapshot drops the cached page state after the page may have
+// changed
+func clearObserveSnapshot(sessionID string) {
+	if path, err := observeSnapshotPath(sessionID); err == nil {
+		_ = os.Remove(path)
+	}
+}
+
+// observeSession observes the page and caches the result
+func observeSession(cmd *cobra.Command, sessionID string) (*observeSnapshot, error) {
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	defer cancel()
+
+	body := api.PageObserveJSONRequestBody{}
+
+	params := &api.PageObserveParams{}
+	resp, err := client.Client().PageObserveWithResponse(ctx, sessionID, params, body)
+	if err != nil {
+		return nil, i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+
+	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return nil, err
+	}
+
+	snap := &observeSnapshot{
+		SessionID:   sessionID,
+		StartedAt:   resp.JSON200.StartedAt,
+		EndedAt:     resp.JSON200.EndedAt,
+		Metadata:    resp.JSON200.Metadata,
+		Description: resp.JSON200.Space.Description,
+	}
+	// Caching is best effort; the observe itself succeeded
+	_ = saveObserveSnapshot(snap)
+	return snap, nil
+}
+
+// loadObservation returns the cached page state, observing first when fresh
+// is set or nothing is cached
+func loadObservation(cmd *cobra.Command, sessionID string, fresh bool) (*observeSnapshot, error) {
+	if !fresh {
+		if snap, err := loadObserveSnapshot(sessionID); err == nil {
+			return snap, nil
+		}
+	}
+	return observeSession(cmd, sessionID)
+}
+
+// printObservation prints page state the way `page observe` does
+func printObservation(snap *observeSnapshot) error {
+	// JSON mode: return filtered response (exclude screenshot and space.actions)
+	if IsJSONOutput() {
+		filtered := map[string]any{
+			"ended_at":   snap.EndedAt,
+			"metadata":   snap.Metadata,
+			"started_at": snap.StartedAt,
+			"space": map[string]any{
+				"description": snap.Description,
+			},
+		}
+		return GetFormatter().Print(filtered)
+	}
+
+	// Text mode: return only the page description
+	fmt.Println(snap.Description)
+	return nil
+}
+
+func runPageLastObserve(cmd *cobra.Command, args []string) error {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return err
+	}
+
+	snap, err := loadObserveSnapshot(sessionID)
+	if err != nil {
+		return err
+	}
+	return printObservation(snap)
+}
+
+// elementIDPattern finds element IDs such as B3 or I12 in description lines
+var elementIDPattern = regexp.MustCompile(`\b[IBLMFO]\d+\b`)
+
+// elementMatch is one element whose description line matched a query
+type elementMatch struct {
+	ID   string `json:"id"`
+	Line string `json:"line"`
+}
+
+// findElements returns the description lines that mention an element ID and
+// contain query, case-insensitively
+func findElements(description, query string) []elementMatch {
+	query = strings.ToLower(query)
+	var matches []elementMatch
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.Contains(strings.ToLower(line), query) {
+			continue
+		}
+		if id := elementIDPattern.FindString(line); id != "" {
+			matches = append(matches, elementMatch{ID: id, Line: line})
+		}
+	}
+	return matches
+}
+
+func runPageFind(cmd *cobra.Command, args []string) error {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return err
+	}
+
+	snap, err := loadObservation(cmd, sessionID, pageFindFresh)
+	if err != nil {
+		return err
+	}
+
+	matches := findElements(snap.Description, args[0])
+	if empty, err := PrintListOrEmpty(matches, fmt.Sprintf("No elements match %q.", args[0])); err != nil || empty {
+		return err
+	}
+	if IsJSONOutput() {
+		return GetFormatter().Print(matches)
+	}
+	for _, m := range matches {
+		fmt.Println(m.Line)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+const observeDescription = `* I1: Search query input
+* B1: Sign in button
+* B2: Sign up button
+Some static text`
+
+func observeResponseJSON(description string) string {
+	desc, _ := json.Marshal(description)
+	return `{"metadata":{"tabs":[{"tab_id":1,"title":"Tab","url":"https://example.com"}],"title":"Tab","url":"https://example.com"},"screenshot":{"raw":"aGVsbG8="},"session":{"session_id":"` + pageSessionIDTest + `","status":"ACTIVE"},"space":{"category":"page","description":` + string(desc) + `,"interaction_actions":[]}}`
+}
+
+func observeRequests(server *testutil.MockServer) int {
+	return len(server.Requests("/sessions/" + pageSessionIDTest + "/page/observe"))
+}
+
+func newPageTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	return cmd
+}
+
+func TestRunPageLastObserve(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/observe", 200, observeResponseJSON(observeDescription))
+	outputFormat = "text"
+
+	if err := runPageLastObserve(newPageTestCmd(), nil); err == nil || !strings.Contains(err.Error(), "page observe") {
+		t.Fatalf("expected error before any observe, got %v", err)
+	}
+
+	testutil.CaptureOutput(func() {
+		if err := runSessionObserve(newPageTestCmd(), nil); err != nil {
+			t.Fatalf("observe: %v", err)
+		}
+	})
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runPageLastObserve(newPageTestCmd(), nil); err != nil {
+			t.Fatalf("last-observe: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "Sign in button") {
+		t.Errorf("expected cached description, got %q", stdout)
+	}
+	if got := observeRequests(server); got != 1 {
+		t.Errorf("observe requests = %d, want 1", got)
+	}
+}
+
+func TestPageAction_InvalidatesObserveCache(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/observe", 200, observeResponseJSON(observeDescription))
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, pageExecResponse())
+
+	testutil.CaptureOutput(func() {
+		if err := runSessionObserve(newPageTestCmd(), nil); err != nil {
+			t.Fatalf("observe: %v", err)
+		}
+		if err := runPageClick(newPageTestCmd(), []string{"B1"}); err != nil {
+			t.Fatalf("click: %v", err)
+		}
+	})
+
+	if _, err := loadObserveSnapshot(pageSessionIDTest); err == nil {
+		t.Error("expected page action to drop the cached observe")
+	}
+}
+
+func TestEvalPageJS_KeepsObserveCache(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/observe", 200, observeResponseJSON(observeDescription))
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, evalJSResponse("complete"))
+
+	client, err := GetClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.CaptureOutput(func() {
+		if err := runSessionObserve(newPageTestCmd(), nil); err != nil {
+			t.Fatalf("observe: %v", err)
+		}
+	})
+	if _, err := evalPageJS(context.Background(), client, pageSessionIDTest, "document.readyState"); err != nil {
+		t.Fatalf("eval: %v", err)
+	}
+
+	if _, err := loadObserveSnapshot(pageSessionIDTest); err != nil {
+		t.Errorf("reading the page should keep the cached observe: %v", err)
+	}
+}
+
+func TestRunPageFind(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/observe", 200, observeResponseJSON(observeDescription))
+
+	origFresh := pageFindFresh
+	t.Cleanup(func() { pageFindFresh = origFresh })
+	pageFindFresh = false
+
+	find := func(query string) []elementMatch {
+		t.Helper()
+		stdout, _ := testutil.CaptureOutput(func() {
+			if err := runPageFind(newPageTestCmd(), []string{query}); err != nil {
+				t.Fatalf("find %q: %v", query, err)
+			}
+		})
+		var matches []elementMatch
+		if err := json.Unmarshal([]byte(stdout), &matches); err != nil {
+			t.Fatalf("invalid JSON output %q: %v", stdout, err)
+		}
+		return matches
+	}
+
+	// Nothing cached yet: observes once, then reuses the snapshot
+	if got := find("sign"); len(got) != 2 || got[0].ID != "B1" || got[1].ID != "B2" {
+		t.Errorf("find(sign) = %+v", got)
+	}
+	if got := find("SEARCH"); len(got) != 1 || got[0].ID != "I1" {
+		t.Errorf("find(SEARCH) = %+v", got)
+	}
+	if got := find("static"); len(got) != 0 {
+		t.Errorf("lines without an element ID should not match, got %+v", got)
+	}
+	if got := observeRequests(server); got != 1 {
+		t.Errorf("observe requests = %d, want 1", got)
+	}
+
+	pageFindFresh = true
+	find("sign")
+	if got := observeRequests(server); got != 2 {
+		t.Errorf("observe requests with --fresh = %d, want 2", got)
+	}
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// openTargets are the resources "notte open" knows the web URL of
+var openTargets = []string{"session", "agent", "dashboard", "docs"}
+
+var openPrintOnly bool
+
+var openCmd = &cobra.Command{
+	Use:   "open [session|agent|dashboard|docs]",
+	Short: "Open the web page of the current resource in the browser",
+	Long: `Open a web page in the default browser:
+
+  session    live viewer of the current session (or --session-id)
+  agent      live viewer of the session the current agent (or --agent-id) runs on
+  dashboard  the Notte console (default)
+  docs       the Notte documentation
+
+Use --print to only print the URL.`,
+	Example: `  notte open
+  notte open session
+  notte open agent --agent-id agent_123
+  notte open docs --print`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: openTargets,
+	RunE:      runOpen,
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+
+	addSessionIDFlag(openCmd)
+	addAgentIDFlag(openCmd)
+	openCmd.Flags().BoolVar(&openPrintOnly, "print", false, "Print the URL instead of opening it")
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	target := "dashboard"
+	if len(args) > 0 {
+		target = args[0]
+	}
+
+	result := map[string]any{"target": target}
+	var url string
+	switch target {
+	case "session":
+		sessionID, err := RequireSessionID(cmd)
+		if err != nil {
+			return err
+		}
+		result["session_id"] = sessionID
+		if url, err = sessionViewerURL(cmd, sessionID); err != nil {
+			return err
+		}
+	case "agent":
+		agentID, err := RequireAgentID(cmd)
+		if err != nil {
+			return err
+		}
+		sessionID, err := agentSessionID(cmd, agentID)
+		if err != nil {
+			return err
+		}
+		result["agent_id"] = agentID
+		result["session_id"] = sessionID
+		if url, err = sessionViewerURL(cmd, sessionID); err != nil {
+			return err
+		}
+	case "dashboard":
+		url = config.GetConsoleURL()
+	case "docs":
+		url = config.DocsURL
+	}
+	result["url"] = url
+
+	if openPrintOnly {
+		return PrintResult(url, result)
+	}
+	if err := openBrowser(url); err != nil {
+		return i18n.Errorf(i18n.FailedToOpenBrowser, err)
+	}
+	return PrintResult(i18n.T(i18n.OpenedInBrowser, target, url), result)
+}
+
+// sessionViewerURL returns the viewer URL saved when the session was
+// started, or asks the API for it
+func sessionViewerURL(cmd *cobra.Command, sessionID string) (string, error) {
+	if sessionID == readCurrentSessionFile() {
+		if url := getCurrentViewerURL(); url != "" {
+			return url, nil
+		}
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return "", err
+	}
+	return fetchViewerURL(cmd.Context(), client, sessionID)
+}
+
+// agentSessionID looks up the session an agent runs on
+func agentSessionID(cmd *cobra.Command, agentID string) (string, error) {
+	client, err := GetClient()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	defer cancel()
+
+	params := &api.AgentStatusParams{}
+	resp, err := client.Client().AgentStatusWithResponse(ctx, agentID, params)
+	if err != nil {
+		return "", i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return "", err
+	}
+	if resp.JSON200 == nil || resp.JSON200.SessionId == "" {
+		return "", i18n.Errorf(i18n.NoSessionFoundForAgent, agentID)
+	}
+	return resp.JSON200.SessionId, nil
+}
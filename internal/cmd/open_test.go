@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func setupOpenTest(t *testing.T) (*testutil.MockServer, *[]string) {
+	t.Helper()
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+
+	server := testutil.NewMockServer()
+	t.Cleanup(func() { server.Close() })
+	env.SetEnv("NOTTE_API_URL", server.URL())
+
+	config.SetTestConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+
+	origFormat := outputFormat
+	origPrint := openPrintOnly
+	origOpen := openBrowser
+	outputFormat = "json"
+	openPrintOnly = false
+	t.Cleanup(func() {
+		outputFormat = origFormat
+		openPrintOnly = origPrint
+		openBrowser = origOpen
+	})
+
+	opened := &[]string{}
+	openBrowser = func(url string) error {
+		*opened = append(*opened, url)
+		return nil
+	}
+	return server, opened
+}
+
+func newOpenTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	addSessionIDFlag(cmd)
+	addAgentIDFlag(cmd)
+	return cmd
+}
+
+func runOpenJSON(t *testing.T, cmd *cobra.Command, args ...string) map[string]any {
+	t.Helper()
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runOpen(cmd, args); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var result map[string]any
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", stdout, err)
+	}
+	return result
+}
+
+func TestRunOpen_DefaultsToDashboard(t *testing.T) {
+	_, opened := setupOpenTest(t)
+
+	result := runOpenJSON(t, newOpenTestCmd())
+	if result["target"] != "dashboard" || result["url"] != config.GetConsoleURL() {
+		t.Errorf("unexpected result: %v", result)
+	}
+	if len(*opened) != 1 || (*opened)[0] != config.GetConsoleURL() {
+		t.Errorf("opened = %v", *opened)
+	}
+}
+
+func TestRunOpen_DocsPrintOnly(t *testing.T) {
+	_, opened := setupOpenTest(t)
+	openPrintOnly = true
+
+	result := runOpenJSON(t, newOpenTestCmd(), "docs")
+	if result["url"] != config.DocsURL {
+		t.Errorf("url = %v, want %s", result["url"], config.DocsURL)
+	}
+	if len(*opened) != 0 {
+		t.Errorf("--print should not open the browser, opened %v", *opened)
+	}
+}
+
+func TestRunOpen_SessionUsesSavedViewerURL(t *testing.T) {
+	server, opened := setupOpenTest(t)
+	if err := setCurrentSession("sess_saved"); err != nil {
+		t.Fatal(err)
+	}
+	if err := setCurrentViewerURL("https://viewer.notte.cc/saved"); err != nil {
+		t.Fatal(err)
+	}
+
+	result := runOpenJSON(t, newOpenTestCmd(), "session")
+	if result["session_id"] != "sess_saved" || result["url"] != "https://viewer.notte.cc/saved" {
+		t.Errorf("unexpected result: %v", result)
+	}
+	if len(*opened) != 1 {
+		t.Errorf("opened = %v", *opened)
+	}
+	if reqs := server.Requests("/sessions/sess_saved"); len(reqs) != 0 {
+		t.Errorf("expected no API call, got %d", len(reqs))
+	}
+}
+
+func TestRunOpen_SessionFlagIgnoresSavedViewerURL(t *testing.T) {
+	server, _ := setupOpenTest(t)
+	_ = setCurrentSession("sess_saved")
+	_ = setCurrentViewerURL("https://viewer.notte.cc/saved")
+	server.AddResponse("/sessions/sess_other", 200, `{"session_id":"sess_other","status":"ACTIVE","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","timeout_minutes":0,"viewer_url":"https://viewer.notte.cc/other"}`)
+
+	cmd := newOpenTestCmd()
+	_ = cmd.Flags().Set("session-id", "sess_other")
+
+	result := runOpenJSON(t, cmd, "session")
+	if result["url"] != "https://viewer.notte.cc/other" {
+		t.Errorf("url = %v, want the other session's viewer", result["url"])
+	}
+}
+
+func TestRunOpen_Agent(t *testing.T) {
+	server, opened := setupOpenTest(t)
+	server.AddResponse("/agents/agent_9", 200, `{"agent_id":"agent_9","session_id":"sess_9","status":"RUNNING","created_at":"2020-01-01T00:00:00Z","replay_start_offset":0,"replay_stop_offset":0}`)
+	server.AddResponse("/sessions/sess_9", 200, `{"session_id":"sess_9","status":"ACTIVE","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","timeout_minutes":0,"viewer_url":"https://viewer.notte.cc/sess_9"}`)
+
+	cmd := newOpenTestCmd()
+	_ = cmd.Flags().Set("agent-id", "agent_9")
+
+	result := runOpenJSON(t, cmd, "agent")
+	if result["agent_id"] != "agent_9" || result["session_id"] != "sess_9" {
+		t.Errorf("unexpected result: %v", result)
+	}
+	if len(*opened) != 1 || (*opened)[0] != "https://viewer.notte.cc/sess_9" {
+		t.Errorf("opened = %v", *opened)
+	}
+}
+
+func TestOpenCmd_RejectsUnknownTarget(t *testing.T) {
+	err := openCmd.Args(openCmd, []string{"billing"})
+	if err == nil || !strings.Contains(err.Error(), "invalid argument") {
+		t.Errorf("expected invalid argument error, got %v", err)
+	}
+}
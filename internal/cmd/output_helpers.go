@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -8,17 +10,20 @@ import (
 	"strings"
 
 	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
 // IsJSONOutput returns true if the global output format is set to JSON.
+// -o yaml and --template render the same result objects, so they count as
+// JSON too.
 func IsJSONOutput() bool {
-	return outputFormat == "json"
+	return outputFormat == "json" || outputFormat == "yaml" || IsTemplateOutput()
 }
 
 // PrintInfo prints an informational message to stdout in text mode,
-// or to stderr in JSON mode to keep stdout clean for machine parsing.
+// or to stderr in JSON and CSV modes to keep stdout clean for machine parsing.
 func PrintInfo(message string) {
-	if IsJSONOutput() {
+	if IsJSONOutput() || IsCSVOutput() {
 		_, _ = fmt.Fprintln(os.Stderr, message)
 		return
 	}
@@ -84,6 +89,13 @@ func PrintListOrEmpty(items any, emptyMsg string) (bool, error) {
 // returns the extracted structured data directly. In text mode without instructions,
 // returns just the markdown.
 func PrintScrapeResponse(resp *api.DataSpace, hasInstructions bool) error {
+	if IsCSVOutput() {
+		table, err := scrapeTable(resp)
+		if err != nil {
+			return err
+		}
+		return table.writeCSV(os.Stdout)
+	}
 	if IsJSONOutput() {
 		if hasInstructions {
 			data, err := extractScrapeStructuredData(resp)
@@ -128,23 +140,88 @@ func PrintScrapeResponse(resp *api.DataSpace, hasInstructions bool) error {
 	return nil
 }
 
-// extractScrapeStructuredData returns the direct payload from structured scrape
-// responses, matching the SDK default for instruction-based scrapes.
-func extractScrapeStructuredData(resp *api.DataSpace) (any, error) {
-	if resp == nil || resp.Structured == nil {
-		return nil, fmt.Errorf("scrape did not return structured data")
+const scrapePathUsage = "Save the result to a file, or an s3:// or gs:// URL, instead of printing it"
+
+const scrapeValidateUsage = "Check the structured result against a JSON Schema (inline, @file, or '-' for stdin) and fail with a per-field report if it doesn't conform"
+
+const scrapeXLSXUsage = "Save structured data (a list of rows) as an Excel workbook to this file, or an s3:// or gs:// URL"
+
+// saveScrapeXLSX writes the structured data of a scrape to dest as an
+// Excel workbook
+func saveScrapeXLSX(ctx context.Context, resp *api.DataSpace, sessionID, dest string) error {
+	table, err := scrapeTable(resp)
+	if err != nil {
+		return err
 	}
-	if resp.Structured.Success != nil && !*resp.Structured.Success {
-		if resp.Structured.Error != nil && *resp.Structured.Error != "" {
-			return nil, fmt.Errorf("%s", *resp.Structured.Error)
+	content, err := table.xlsx()
+	if err != nil {
+		return err
+	}
+	if isOutputDir(dest) {
+		dest = joinOutputPath(dest, "notte-scrape-"+sessionID+".xlsx")
+	}
+	dest, err = writeOutputFile(ctx, dest, content, 0o644)
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToWriteScrapeResult, err)
+	}
+	return PrintResult(i18n.T(i18n.ScrapeSavedRows, dest, len(table.rows)), map[string]any{
+		"path":       dest,
+		"rows":       len(table.rows),
+		"session_id": sessionID,
+		"success":    true,
+	})
+}
+
+// saveScrapeResponse writes the scraped markdown, or the structured data
+// when there were instructions, to dest
+func saveScrapeResponse(ctx context.Context, resp *api.DataSpace, hasInstructions bool, sessionID, dest string) error {
+	var content []byte
+	ext := ".md"
+	if IsCSVOutput() {
+		table, err := scrapeTable(resp)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := table.writeCSV(&buf); err != nil {
+			return err
+		}
+		content, ext = buf.Bytes(), ".csv"
+	} else if hasInstructions {
+		data, err := extractScrapeStructuredData(resp)
+		if err != nil {
+			return err
 		}
-		return nil, fmt.Errorf("scrape failed")
+		if content, err = json.MarshalIndent(data, "", "  "); err != nil {
+			return err
+		}
+		content = append(content, '\n')
+		ext = ".json"
+	} else {
+		if resp == nil {
+			return i18n.Errorf(i18n.UnexpectedEmptyScrape)
+		}
+		content = []byte(resp.Markdown)
 	}
-	if resp.Structured.Data == nil {
-		return nil, fmt.Errorf("scrape did not return structured data")
+
+	if isOutputDir(dest) {
+		dest = joinOutputPath(dest, "notte-scrape-"+sessionID+ext)
 	}
+	dest, err := writeOutputFile(ctx, dest, content, 0o644)
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToWriteScrapeResult, err)
+	}
+	return PrintResult(i18n.T(i18n.ScrapeSaved, dest), map[string]any{
+		"path":       dest,
+		"session_id": sessionID,
+		"success":    true,
+	})
+}
 
-	raw, err := json.Marshal(resp.Structured.Data)
+// extractScrapeStructuredData returns the direct payload from structured scrape
+// responses, matching the SDK default for instruction-based scrapes.
+func extractScrapeStructuredData(resp *api.DataSpace) (any, error) {
+	raw, err := scrapeStructuredJSON(resp)
 	if err != nil {
 		return nil, err
 	}
@@ -155,6 +232,34 @@ func extractScrapeStructuredData(resp *api.DataSpace) (any, error) {
 	return data, nil
 }
 
+// scrapeTable lays out the structured data of a scrape as a table
+func scrapeTable(resp *api.DataSpace) (*dataTable, error) {
+	raw, err := scrapeStructuredJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+	return structuredTable(raw)
+}
+
+// scrapeStructuredJSON returns the structured data of a scrape as sent by
+// the API, keys in their original order
+func scrapeStructuredJSON(resp *api.DataSpace) ([]byte, error) {
+	if resp == nil || resp.Structured == nil {
+		return nil, i18n.Errorf(i18n.ScrapeNotStructured)
+	}
+	if resp.Structured.Success != nil && !*resp.Structured.Success {
+		if resp.Structured.Error != nil && *resp.Structured.Error != "" {
+			return nil, fmt.Errorf("%s", *resp.Structured.Error)
+		}
+		return nil, i18n.Errorf(i18n.ScrapeFailed)
+	}
+	if resp.Structured.Data == nil {
+		return nil, i18n.Errorf(i18n.ScrapeNotStructured)
+	}
+
+	return json.Marshal(resp.Structured.Data)
+}
+
 // printSessionStatus formats session status output with simplified Steps display.
 // In JSON mode, returns the full response. In text mode, formats Steps as a simple list.
 func printSessionStatus(resp *api.SessionResponse) error {
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// a11yCheckMissingLabels flags controls and images without an accessible name
+const a11yCheckMissingLabels = "missing-labels"
+
+var a11yChecks = []string{a11yCheckMissingLabels}
+
+var pageA11yFailOn []string
+
+var pageA11yCmd = &cobra.Command{
+	Use:   "a11y",
+	Short: "Print the accessibility tree of the page",
+	Long: `Print the role, accessible name and state of each landmark, heading, image
+and interactive element on the page, in document order.
+
+The tree is computed in the page, following the usual name sources:
+aria-labelledby, aria-label, <label>, alt, title, then the element's text.
+Hidden elements are skipped.
+
+With --fail-on missing-labels, the command exits non-zero when a control or
+image has no accessible name.`,
+	Example: `  notte page a11y
+  notte page a11y --fail-on missing-labels
+  notte page a11y -o json | jq '.[] | select(.role == "button")'`,
+	Args: cobra.NoArgs,
+	RunE: runPageA11y,
+}
+
+func init() {
+	pageCmd.AddCommand(pageA11yCmd)
+
+	pageA11yCmd.Flags().StringSliceVar(&pageA11yFailOn, "fail-on", nil, "Exit non-zero when a check finds problems: missing-labels")
+	_ = pageA11yCmd.Flags().SetAnnotation("fail-on", flagEnumAnnotation, a11yChecks)
+}
+
+// a11yNode is one element of the accessibility tree
+type a11yNode struct {
+	Role         string   `json:"role"`
+	Name         string   `json:"name"`
+	States       []string `json:"states,omitempty"`
+	Tag          string   `json:"tag"`
+	Level        int      `json:"level,omitempty"`
+	MissingLabel bool     `json:"missing_label,omitempty"`
+}
+
+// a11yNeedsName lists the roles that are unusable without a name
+var a11yNeedsName = map[string]bool{
+	"button": true, "link": true, "textbox": true, "searchbox": true,
+	"checkbox": true, "radio": true, "combobox": true, "listbox": true,
+	"slider": true, "spinbutton": true, "switch": true, "img": true,
+	"menuitem": true, "tab": true,
+}
+
+// a11yTreeJS walks the DOM and returns the nodes as a JSON string
+const a11yTreeJS = `(() => {
+  const sel = 'a[href],area[href],button,input:not([type=hidden]),select,textarea,img,svg[role],[role],[tabindex],h1,h2,h3,h4,h5,h6,main,nav,header,footer,aside,form,dialog,table';
+  const implicit = (el) => {
+    const tag = el.tagName.toLowerCase();
+    const type = (el.getAttribute('type') || 'text').toLowerCase();
+    switch (tag) {
+      case 'a': case 'area': return 'link';
+      case 'button': return 'button';
+      case 'select': return el.multiple || el.size > 1 ? 'listbox' : 'combobox';
+      case 'textarea': return 'textbox';
+      case 'img': return el.getAttribute('alt') === '' ? 'presentation' : 'img';
+      case 'main': return 'main';
+      case 'nav': return 'navigation';
+      case 'header': return 'banner';
+      case 'footer': return 'contentinfo';
+      case 'aside': return 'complementary';
+      case 'form': return 'form';
+      case 'dialog': return 'dialog';
+      case 'table': return 'table';
+      case 'input':
+        if (['button', 'submit', 'reset', 'image'].includes(type)) return 'button';
+        if (type === 'checkbox' || type === 'radio') return type;
+        if (type === 'range') return 'slider';
+        if (type === 'number') return 'spinbutton';
+        if (type === 'search') return 'searchbox';
+        return 'textbox';
+    }
+    if (/^h[1-6]$/.test(tag)) return 'heading';
+    return 'generic';
+  };
+  const text = (el) => (el.innerText || el.textContent || '').replace(/\s+/g, ' ').trim();
+  const name = (el) => {
+    const ids = el.getAttribute('aria-labelledby');
+    if (ids) {
+      const n = ids.split(/\s+/).map((id) => document.getElementById(id)).filter(Boolean).map(text).join(' ').trim();
+      if (n) return n;
+    }
+    const aria = (el.getAttribute('aria-label') || '').trim();
+    if (aria) return aria;
+    if (el.labels && el.labels.length) {
+      const n = Array.from(el.labels).map(text).join(' ').trim();
+      if (n) return n;
+    }
+    const tag = el.tagName.toLowerCase();
+    if (tag === 'img' || (tag === 'input' && el.type === 'image')) {
+      const alt = (el.getAttribute('alt') || '').trim();
+      if (alt) return alt;
+    }
+    if (tag === 'input' && ['button', 'submit', 'reset'].includes(el.type)) return el.value || '';
+    if (!['input', 'select', 'textarea', 'img'].includes(tag)) {
+      const n = text(el);
+      if (n) return n.slice(0, 200);
+    }
+    return (el.getAttribute('title') || el.getAttribute('placeholder') || '').trim();
+  };
+  const hidden = (el) => {
+    if (el.closest('[aria-hidden=true],[hidden]')) return true;
+    const s = getComputedStyle(el);
+    return s.display === 'none' || s.visibility === 'hidden';
+  };
+  const nodes = [];
+  for (const el of document.querySelectorAll(sel)) {
+    if (nodes.length >= 2000) break;
+    if (hidden(el)) continue;
+    const role = (el.getAttribute('role') || '').split(/\s+/)[0] || implicit(el);
+    if (role === 'presentation' || role === 'none' || role === 'generic') continue;
+    const states = [];
+    if (el.disabled || el.getAttribute('aria-disabled') === 'true') states.push('disabled');
+    if (el.checked || el.getAttribute('aria-checked') === 'true') states.push('checked');
+    if (el.getAttribute('aria-expanded')) states.push(el.getAttribute('aria-expanded') === 'true' ? 'expanded' : 'collapsed');
+    if (el.getAttribute('aria-selected') === 'true') states.push('selected');
+    if (el.required || el.getAttribute('aria-required') === 'true') states.push('required');
+    if (el.readOnly) states.push('readonly');
+    if (el === document.activeElement) states.push('focused');
+    const node = { role, name: name(el), states, tag: el.tagName.toLowerCase() };
+    const level = /^h([1-6])$/i.exec(el.tagName);
+    if (level) node.level = Number(level[1]);
+    else if (el.getAttribute('aria-level')) node.level = Number(el.getAttribute('aria-level'));
+    nodes.push(node);
+  }
+  return JSON.stringify(nodes);
+})()`
+
+func parseA11yTree(out string) ([]a11yNode, error) {
+	var nodes []a11yNode
+	if err := decodeJSResult(out, &nodes); err != nil {
+		return nil, i18n.Errorf(i18n.UnexpectedAccessibilityTree, err)
+	}
+	return nodes, nil
+}
+
+// markMissingLabels flags nodes that need a name but have none and returns
+// how many there are
+func markMissingLabels(nodes []a11yNode) int {
+	n := 0
+	for i := range nodes {
+		if a11yNeedsName[nodes[i].Role] && strings.TrimSpace(nodes[i].Name) == "" {
+			nodes[i].MissingLabel = true
+			n++
+		}
+	}
+	return n
+}
+
+func formatA11yNode(n a11yNode) string {
+	line := n.Role
+	if n.Level > 0 {
+		line += fmt.Sprintf(" (level %d)", n.Level)
+	}
+	line += fmt.Sprintf(" %q", n.Name)
+	if len(n.States) > 0 {
+		line += " [" + strings.Join(n.States, ", ") + "]"
+	}
+	if n.MissingLabel {
+		line += "  <- missing label"
+	}
+	return line
+}
+
+func runPageA11y(cmd *cobra.Command, args []string) error {
+	for _, check := range pageA11yFailOn {
+		if !slices.Contains(a11yChecks, check) {
+			return i18n.Errorf(i18n.InvalidFailOn, check, strings.Join(a11yChecks, ", "))
+		}
+	}
+
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	out, err := evalPageJS(cmd.Context(), client, sessionID, a11yTreeJS)
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToReadAccessibilityTree, err)
+	}
+	nodes, err := parseA11yTree(out)
+	if err != nil {
+		return err
+	}
+	missing := markMissingLabels(nodes)
+
+	if empty, err := PrintListOrEmpty(nodes, "No accessible elements found."); err != nil || empty {
+		return err
+	}
+	if IsJSONOutput() {
+		if err := GetFormatter().Print(nodes); err != nil {
+			return err
+		}
+	} else {
+		for _, n := range nodes {
+			fmt.Println(formatA11yNode(n))
+		}
+	}
+
+	if missing > 0 && slices.Contains(pageA11yFailOn, a11yCheckMissingLabels) {
+		return i18n.Errorf(i18n.ElementsWithoutAccessibleName, missing)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+const a11yTreeTest = `[{"role":"heading","name":"Welcome","tag":"h1","level":1},{"role":"button","name":"Sign in","states":["disabled"],"tag":"button"},{"role":"img","name":"","tag":"img"}]`
+
+func evalJSResponse(markdown string) string {
+	md, _ := json.Marshal(markdown)
+	return `{"action":{"type":"evaluate_js"},"success":true,"message":"ok","data":{"markdown":` + string(md) + `},"started_at":"2020-01-01T00:00:00Z","ended_at":"2020-01-01T00:00:00Z"}`
+}
+
+func TestParseA11yTree(t *testing.T) {
+	escaped, _ := json.Marshal(a11yTreeTest)
+	for _, out := range []string{a11yTreeTest, strings.Trim(string(escaped), `"`)} {
+		nodes, err := parseA11yTree(out)
+		if err != nil {
+			t.Fatalf("parseA11yTree(%q): unexpected error: %v", out, err)
+		}
+		if len(nodes) != 3 || nodes[1].Name != "Sign in" || nodes[0].Level != 1 {
+			t.Errorf("unexpected nodes: %+v", nodes)
+		}
+	}
+
+	if _, err := parseA11yTree("undefined"); err == nil {
+		t.Error("expected error for non-JSON result")
+	}
+}
+
+func TestFormatA11yNode(t *testing.T) {
+	tests := []struct {
+		node a11yNode
+		want string
+	}{
+		{a11yNode{Role: "heading", Name: "Welcome", Level: 1}, `heading (level 1) "Welcome"`},
+		{a11yNode{Role: "button", Name: "Sign in", States: []string{"disabled", "focused"}}, `button "Sign in" [disabled, focused]`},
+		{a11yNode{Role: "img", MissingLabel: true}, `img ""  <- missing label`},
+	}
+	for _, tt := range tests {
+		if got := formatA11yNode(tt.node); got != tt.want {
+			t.Errorf("formatA11yNode(%+v) = %q, want %q", tt.node, got, tt.want)
+		}
+	}
+}
+
+func TestRunPageA11y_FailOnMissingLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		failOn  []string
+		wantErr bool
+	}{
+		{"report only", nil, false},
+		{"fail on missing labels", []string{a11yCheckMissingLabels}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupPageTest(t)
+			server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, evalJSResponse(a11yTreeTest))
+
+			orig := pageA11yFailOn
+			pageA11yFailOn = tt.failOn
+			t.Cleanup(func() { pageA11yFailOn = orig })
+
+			var err error
+			stdout, _ := testutil.CaptureOutput(func() {
+				err = runPageA11y(newPageTestCmd(), nil)
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(stdout, `"missing_label":true`) {
+				t.Errorf("expected the unlabeled image to be flagged, got %s", stdout)
+			}
+		})
+	}
+}
+
+func TestRunPageA11y_InvalidCheck(t *testing.T) {
+	server := setupPageTest(t)
+
+	orig := pageA11yFailOn
+	pageA11yFailOn = []string{"contrast"}
+	t.Cleanup(func() { pageA11yFailOn = orig })
+
+	if err := runPageA11y(newPageTestCmd(), nil); err == nil || !strings.Contains(err.Error(), "invalid --fail-on") {
+		t.Fatalf("expected invalid check error, got %v", err)
+	}
+	if got := len(server.Requests("/sessions/" + pageSessionIDTest + "/page/execute")); got != 0 {
+		t.Errorf("expected no request, got %d", got)
+	}
+}
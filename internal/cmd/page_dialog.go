@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var pageDialogText string
+
+var pageDialogCmd = &cobra.Command{
+	Use:   "dialog",
+	Short: "Answer JavaScript alerts, confirms and prompts automatically",
+	Long: `Make the page answer its own alert(), confirm() and prompt() dialogs, so a
+dialog no one expected doesn't stall an automation.
+
+"accept" and "dismiss" replace the page's window.alert, window.confirm and
+window.prompt: alerts return at once, confirms return true (accept) or false
+(dismiss) and prompts return --text, their default value or null. Every
+dialog answered is recorded, and "log" lists them.
+
+The API has no dialog action, so the handler lives in the page itself: it
+only answers dialogs opened after it is installed, and it is gone once the
+page navigates or reloads. Run it again after each navigation.`,
+	Example: `  notte page dialog accept
+  notte page dialog accept --text "ok"
+  notte page dialog dismiss
+  notte page dialog log
+  notte page dialog reset`,
+}
+
+var pageDialogAcceptCmd = &cobra.Command{
+	Use:   "accept",
+	Short: "Accept the page's dialogs: confirms return true, prompts --text",
+	Args:  cobra.NoArgs,
+	RunE:  runPageDialogAccept,
+}
+
+var pageDialogDismissCmd = &cobra.Command{
+	Use:   "dismiss",
+	Short: "Dismiss the page's dialogs: confirms return false, prompts null",
+	Args:  cobra.NoArgs,
+	RunE:  runPageDialogDismiss,
+}
+
+var pageDialogLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "List the dialogs answered on the current page",
+	Args:  cobra.NoArgs,
+	RunE:  runPageDialogLog,
+}
+
+var pageDialogResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Restore the page's own dialogs",
+	Args:  cobra.NoArgs,
+	RunE:  runPageDialogReset,
+}
+
+func init() {
+	pageCmd.AddCommand(pageDialogCmd)
+	pageDialogCmd.AddCommand(pageDialogAcceptCmd)
+	pageDialogCmd.AddCommand(pageDialogDismissCmd)
+	pageDialogCmd.AddCommand(pageDialogLogCmd)
+	pageDialogCmd.AddCommand(pageDialogResetCmd)
+
+	pageDialogAcceptCmd.Flags().StringVar(&pageDialogText, "text", "", "Text to answer prompts with (defaults to the prompt's default value)")
+}
+
+// pageDialogState is where the handler keeps its settings, its log and the
+// page's own dialog functions
+const pageDialogState = "__notteDialog"
+
+// dialogHandlerJS installs the handler, or updates the answers of one that
+// is already installed; text is null to answer prompts with their default
+const dialogHandlerJS = `(() => {
+  const w = window;
+  const state = w[%[1]q] || (w[%[1]q] = { log: [], native: { alert: w.alert, confirm: w.confirm, prompt: w.prompt } });
+  state.accept = %[2]t;
+  state.text = %[3]s;
+  const answer = (type, message, fallback) => {
+    let response = null;
+    if (type === 'confirm') response = state.accept;
+    if (type === 'prompt' && state.accept) response = state.text !== null ? state.text : String(fallback ?? '');
+    state.log.push({ type, message: String(message ?? ''), response, at: new Date().toISOString() });
+    return response;
+  };
+  w.alert = (message) => { answer('alert', message); };
+  w.confirm = (message) => answer('confirm', message);
+  w.prompt = (message, fallback) => answer('prompt', message, fallback);
+  return JSON.stringify({ answered: state.log.length });
+})()`
+
+// dialogLogJS returns the dialogs answered so far, or null when no handler
+// is installed
+const dialogLogJS = `JSON.stringify(window[%q] ? window[%[1]q].log : null)`
+
+// dialogResetJS puts the page's own dialogs back and returns the log
+const dialogResetJS = `(() => {
+  const w = window;
+  const state = w[%[1]q];
+  if (!state) return JSON.stringify(null);
+  Object.assign(w, state.native);
+  delete w[%[1]q];
+  return JSON.stringify(state.log);
+})()`
+
+// dialogRecord is a dialog the handler answered
+type dialogRecord struct {
+	Type     string `json:"type"`
+	Message  string `json:"message"`
+	Response any    `json:"response"`
+	At       string `json:"at"`
+}
+
+// dialogHandlerCode returns dialogHandlerJS for accepting or dismissing;
+// a nil text answers prompts with their default value
+func dialogHandlerCode(accept bool, text *string) string {
+	textJS := "null"
+	if text != nil {
+		data, _ := json.Marshal(*text)
+		textJS = string(data)
+	}
+	return fmt.Sprintf(dialogHandlerJS, pageDialogState, accept, textJS)
+}
+
+func runPageDialogAccept(cmd *cobra.Command, args []string) error {
+	var text *string
+	if cmd.Flags().Changed("text") {
+		text = &pageDialogText
+	}
+	return installDialogHandler(cmd, true, text)
+}
+
+func runPageDialogDismiss(cmd *cobra.Command, args []string) error {
+	return installDialogHandler(cmd, false, nil)
+}
+
+func installDialogHandler(cmd *cobra.Command, accept bool, text *string) error {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	out, err := evalPageJS(cmd.Context(), client, sessionID, dialogHandlerCode(accept, text))
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToInstallDialogHandler, err)
+	}
+	var installed struct {
+		Answered int `json:"answered"`
+	}
+	if err := decodeJSResult(out, &installed); err != nil {
+		return i18n.Errorf(i18n.UnexpectedDialogHandlerResult, err)
+	}
+
+	policy, message := "dismiss", "Dialogs on this page will be dismissed: confirms return false, prompts null"
+	if accept {
+		policy, message = "accept", "Dialogs on this page will be accepted: confirms return true, prompts their default value"
+		if text != nil {
+			message = fmt.Sprintf("Dialogs on this page will be accepted: confirms return true, prompts %q", *text)
+		}
+	}
+	data := map[string]any{
+		"session_id": sessionID,
+		"policy":     policy,
+		"answered":   installed.Answered,
+	}
+	if text != nil {
+		data["text"] = *text
+	}
+	return PrintResult(message+"\nRun this again after the page navigates or reloads", data)
+}
+
+// readDialogLog evaluates code, which returns a dialog log or null when no
+// handler is installed
+func readDialogLog(cmd *cobra.Command, code string) ([]dialogRecord, bool, error) {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return nil, false, err
+	}
+	client, err := GetClient()
+	if err != nil {
+		return nil, false, err
+	}
+
+	out, err := evalPageJS(cmd.Context(), client, sessionID, code)
+	if err != nil {
+		return nil, false, err
+	}
+	var records *[]dialogRecord
+	if err := decodeJSResult(out, &records); err != nil {
+		return nil, false, i18n.Errorf(i18n.UnexpectedDialogLog, err)
+	}
+	if records == nil {
+		return []dialogRecord{}, false, nil
+	}
+	return *records, true, nil
+}
+
+func runPageDialogLog(cmd *cobra.Command, args []string) error {
+	records, installed, err := readDialogLog(cmd, fmt.Sprintf(dialogLogJS, pageDialogState))
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToReadDialogLog, err)
+	}
+	if IsJSONOutput() {
+		return GetFormatter().Print(records)
+	}
+	if !installed {
+		fmt.Println("No dialog handler on this page; run 'notte page dialog accept' or 'dismiss'")
+		return nil
+	}
+	printDialogLog(records)
+	return nil
+}
+
+func runPageDialogReset(cmd *cobra.Command, args []string) error {
+	records, installed, err := readDialogLog(cmd, fmt.Sprintf(dialogResetJS, pageDialogState))
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToRemoveDialogHandler, err)
+	}
+	message := "No dialog handler on this page"
+	if installed {
+		message = fmt.Sprintf("Restored the page's dialogs (%d answered)", len(records))
+	}
+	return PrintResult(message, map[string]any{
+		"removed":  installed,
+		"answered": len(records),
+	})
+}
+
+func printDialogLog(records []dialogRecord) {
+	if len(records) == 0 {
+		fmt.Println("No dialogs answered yet")
+		return
+	}
+	for _, r := range records {
+		response := "-"
+		if r.Type != "alert" {
+			data, _ := json.Marshal(r.Response)
+			response = string(data)
+		}
+		fmt.Printf("%-8s %-45q -> %s\n", r.Type, truncate(r.Message, 40), response)
+	}
+}
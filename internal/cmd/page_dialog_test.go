@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func TestDialogHandlerCode(t *testing.T) {
+	text := `say "hi"`
+	code := dialogHandlerCode(true, &text)
+	for _, want := range []string{`window`, `w["__notteDialog"]`, "state.accept = true;", `state.text = "say \"hi\"";`} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected %q in:\n%s", want, code)
+		}
+	}
+	if code := dialogHandlerCode(false, nil); !strings.Contains(code, "state.accept = false;") || !strings.Contains(code, "state.text = null;") {
+		t.Errorf("unexpected dismiss handler:\n%s", code)
+	}
+}
+
+func TestRunPageDialogAccept(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, evalJSResponse(`{"answered":2}`))
+
+	t.Cleanup(func() { pageDialogText = "" })
+	cmd := newPageTestCmd()
+	cmd.Flags().StringVar(&pageDialogText, "text", "", "")
+	_ = cmd.Flags().Set("text", "ok")
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runPageDialogAccept(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var result map[string]any
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout)
+	}
+	if result["policy"] != "accept" || result["text"] != "ok" || result["answered"] != float64(2) {
+		t.Errorf("unexpected result: %v", result)
+	}
+	reqs := server.Requests("/sessions/" + pageSessionIDTest + "/page/execute")
+	if len(reqs) != 1 || !strings.Contains(reqs[0].Body, `evaluate_js`) || !strings.Contains(reqs[0].Body, `state.text = \"ok\"`) {
+		t.Errorf("unexpected requests: %+v", reqs)
+	}
+}
+
+func TestRunPageDialogDismiss_Text(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, evalJSResponse(`{"answered":0}`))
+	outputFormat = "text"
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runPageDialogDismiss(newPageTestCmd(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.HasPrefix(stdout, "Dialogs on this page will be dismissed") || !strings.Contains(stdout, "after the page navigates") {
+		t.Errorf("unexpected output: %q", stdout)
+	}
+}
+
+func TestRunPageDialogLog(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, evalJSResponse(
+		`[{"type":"confirm","message":"Delete\nthis item?","response":true,"at":"2026-01-01T00:00:00Z"},{"type":"alert","message":"Saved","response":null,"at":"2026-01-01T00:00:01Z"}]`))
+	outputFormat = "text"
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runPageDialogLog(newPageTestCmd(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], `confirm  "Delete this item?"`) || !strings.HasSuffix(lines[0], "-> true") || !strings.HasSuffix(lines[1], "-> -") {
+		t.Errorf("unexpected log:\n%s", stdout)
+	}
+}
+
+func TestRunPageDialogLog_NoHandler(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, evalJSResponse("null"))
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runPageDialogLog(newPageTestCmd(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if strings.TrimSpace(stdout) != "[]" {
+		t.Errorf("expected an empty JSON list, got %q", stdout)
+	}
+}
+
+func TestRunPageDialogReset(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, evalJSResponse(`[{"type":"prompt","message":"Name?","response":"ok","at":"2026-01-01T00:00:00Z"}]`))
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runPageDialogReset(newPageTestCmd(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var result map[string]any
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout)
+	}
+	if result["removed"] != true || result["answered"] != float64(1) {
+		t.Errorf("unexpected result: %v", result)
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	pageDownloadWait        bool
+	pageDownloadSaveTo      string
+	pageDownloadWaitTimeout time.Duration
+)
+
+// downloadPollInterval is how often the session's files are listed while
+// waiting for a download
+var downloadPollInterval = time.Second
+
+func init() {
+	pageDownloadCmd.Flags().BoolVar(&pageDownloadWait, "wait", false, "Wait until the download shows up in the session's files")
+	pageDownloadCmd.Flags().StringVar(&pageDownloadSaveTo, "save-to", "", "Wait for the download, then save it to this directory")
+	pageDownloadCmd.Flags().DurationVar(&pageDownloadWaitTimeout, "wait-timeout", 2*time.Minute, "How long --wait waits for the download")
+}
+
+// arrivedDownload is a file that appeared in the session's downloads after
+// the click
+type arrivedDownload struct {
+	Name string `json:"name"`
+	Size int    `json:"size"`
+	Path string `json:"path,omitempty"`
+}
+
+// listSessionDownloads returns the session's downloaded files by name
+func listSessionDownloads(ctx context.Context, client *api.NotteClient, sessionID string) (map[string]api.FileInfo, error) {
+	ctx, cancel := GetContextWithTimeout(ctx)
+	defer cancel()
+
+	resp, err := client.Client().FileListDownloadsWithResponse(ctx, sessionID, &api.FileListDownloadsParams{})
+	if err != nil {
+		return nil, i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return nil, err
+	}
+	files := map[string]api.FileInfo{}
+	if resp.JSON200 != nil {
+		for _, f := range resp.JSON200.Files {
+			files[f.Name] = f
+		}
+	}
+	return files, nil
+}
+
+// newDownloads lists the files of after that aren't in before, or that
+// changed since, as when a download replaces a file of the same name
+func newDownloads(before, after map[string]api.FileInfo) []api.FileInfo {
+	var files []api.FileInfo
+	for name, f := range after {
+		old, ok := before[name]
+		if !ok || old.Size != f.Size || !equalPtr(old.UpdatedAt, f.UpdatedAt) {
+			files = append(files, f)
+		}
+	}
+	slices.SortFunc(files, func(a, b api.FileInfo) int { return strings.Compare(a.Name, b.Name) })
+	return files
+}
+
+func equalPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// waitForNewDownloads lists the session's files until some that aren't in
+// before show up, or until --wait-timeout
+func waitForNewDownloads(ctx context.Context, client *api.NotteClient, sessionID string, before map[string]api.FileInfo) ([]api.FileInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, pageDownloadWaitTimeout)
+	defer cancel()
+
+	for {
+		after, err := listSessionDownloads(ctx, client, sessionID)
+		if err != nil && ctx.Err() == nil {
+			return nil, i18n.Errorf(i18n.FailedToListSessionDownloads, err)
+		}
+		if err == nil {
+			if files := newDownloads(before, after); len(files) > 0 {
+				return files, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, i18n.Errorf(i18n.TimedOutWaitingForDownload, pageDownloadWaitTimeout)
+		case <-time.After(downloadPollInterval):
+		}
+	}
+}
+
+// runPageDownloadWait clicks the element and waits for the file it
+// downloads, saving it locally with --save-to
+func runPageDownloadWait(cmd *cobra.Command, action map[string]any) error {
+	if pageDownloadWaitTimeout <= 0 {
+		return errors.New(i18n.T(i18n.WaitTimeoutMustBePositive))
+	}
+	if sessionIDs, err := fanOutSessionIDs(cmd); err != nil || sessionIDs != nil {
+		if err != nil {
+			return err
+		}
+		return errors.New(i18n.T(i18n.WaitWithSessions))
+	}
+	if pageDownloadSaveTo != "" {
+		if err := os.MkdirAll(pageDownloadSaveTo, 0o755); err != nil {
+			return i18n.Errorf(i18n.FailedToCreate, pageDownloadSaveTo, err)
+		}
+	}
+
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	// Files downloaded earlier in the session aren't the one to wait for
+	before, err := listSessionDownloads(ctx, client, sessionID)
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToListSessionDownloads, err)
+	}
+
+	resp, err := sendPageActionTo(ctx, client, sessionID, action)
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return executeFailure(resp)
+	}
+	printScreenshotAfter(cmd, action)
+
+	PrintInfo(i18n.T(i18n.WaitingForDownload))
+	files, err := waitForNewDownloads(ctx, client, sessionID, before)
+	if err != nil {
+		return err
+	}
+
+	downloads := make([]arrivedDownload, 0, len(files))
+	for _, f := range files {
+		d := arrivedDownload{Name: f.Name, Size: f.Size}
+		if pageDownloadSaveTo != "" {
+			d.Path = filepath.Join(pageDownloadSaveTo, sanitizeFilename(f.Name))
+			if err := fetchSessionFile(ctx, client, sessionID, f.Name, d.Path); err != nil {
+				return i18n.Errorf(i18n.FailedToSave, f.Name, err)
+			}
+		}
+		downloads = append(downloads, d)
+	}
+
+	if IsJSONOutput() {
+		return GetFormatter().Print(map[string]any{
+			"session_id": sessionID,
+			"files":      downloads,
+		})
+	}
+	for _, d := range downloads {
+		if d.Path != "" {
+			fmt.Printf("Downloaded %s (%s) to %s\n", d.Name, formatByteSize(int64(d.Size)), d.Path)
+		} else {
+			fmt.Printf("Downloaded %s (%s); fetch it with: notte files download %s\n", d.Name, formatByteSize(int64(d.Size)), d.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+func setupPageDownloadWaitTest(t *testing.T) *testutil.MockServer {
+	t.Helper()
+	server := setupPageTest(t)
+
+	origWait, origSaveTo, origTimeout, origInterval := pageDownloadWait, pageDownloadSaveTo, pageDownloadWaitTimeout, downloadPollInterval
+	t.Cleanup(func() {
+		pageDownloadWait, pageDownloadSaveTo, pageDownloadWaitTimeout, downloadPollInterval = origWait, origSaveTo, origTimeout, origInterval
+	})
+	pageDownloadWait, pageDownloadSaveTo, pageDownloadWaitTimeout, downloadPollInterval = true, "", time.Second, time.Millisecond
+	return server
+}
+
+func TestNewDownloads(t *testing.T) {
+	earlier, later := "2026-01-01T00:00:00Z", "2026-01-01T00:01:00Z"
+	before := map[string]api.FileInfo{
+		"old.txt":    {Name: "old.txt", Size: 10},
+		"report.csv": {Name: "report.csv", Size: 20, UpdatedAt: &earlier},
+	}
+	after := map[string]api.FileInfo{
+		"old.txt":    {Name: "old.txt", Size: 10},
+		"report.csv": {Name: "report.csv", Size: 20, UpdatedAt: &later},
+		"new.pdf":    {Name: "new.pdf", Size: 30},
+	}
+	files := newDownloads(before, after)
+	if len(files) != 2 || files[0].Name != "new.pdf" || files[1].Name != "report.csv" {
+		t.Errorf("unexpected new downloads: %+v", files)
+	}
+	if files := newDownloads(before, before); len(files) != 0 {
+		t.Errorf("expected nothing new, got %+v", files)
+	}
+}
+
+func TestRunPageDownload_SaveTo(t *testing.T) {
+	server := setupPageDownloadWaitTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, pageExecResponse())
+	server.AddSequence("/storage/"+pageSessionIDTest+"/downloads",
+		mockserver.JSONResponse(200, `{"files":[{"name":"old.txt","file_ext":".txt","size":10}]}`),
+		mockserver.JSONResponse(200, `{"files":[{"name":"old.txt","file_ext":".txt","size":10}]}`),
+		mockserver.JSONResponse(200, `{"files":[{"name":"old.txt","file_ext":".txt","size":10},{"name":"invoice.pdf","file_ext":".pdf","size":7}]}`),
+	)
+	fileServer := testutil.NewMockServer()
+	t.Cleanup(func() { fileServer.Close() })
+	fileServer.AddResponse("/invoice.pdf", 200, "pdfdata")
+	server.AddResponse("/storage/"+pageSessionIDTest+"/downloads/invoice.pdf", 200, `{"url":"`+fileServer.URL()+`/invoice.pdf"}`)
+	pageDownloadSaveTo = filepath.Join(t.TempDir(), "downloads")
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runPageDownload(newPageTestCmd(), []string{"B4"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var result struct {
+		Files []arrivedDownload `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout)
+	}
+	want := filepath.Join(pageDownloadSaveTo, "invoice.pdf")
+	if len(result.Files) != 1 || result.Files[0].Name != "invoice.pdf" || result.Files[0].Path != want {
+		t.Errorf("unexpected files: %+v", result.Files)
+	}
+	if data, err := os.ReadFile(want); err != nil || string(data) != "pdfdata" {
+		t.Errorf("file not saved: %q, %v", data, err)
+	}
+	if n := len(server.Requests("/sessions/" + pageSessionIDTest + "/page/execute")); n != 1 {
+		t.Errorf("expected one click, got %d", n)
+	}
+}
+
+func TestRunPageDownload_WaitTimeout(t *testing.T) {
+	server := setupPageDownloadWaitTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, pageExecResponse())
+	server.AddResponse("/storage/"+pageSessionIDTest+"/downloads", 200, `{"files":[]}`)
+	pageDownloadWaitTimeout = 20 * time.Millisecond
+
+	var err error
+	_, _ = testutil.CaptureOutput(func() {
+		err = runPageDownload(newPageTestCmd(), []string{"B4"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "--wait-timeout") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRunPageDownload_WaitActionFailed(t *testing.T) {
+	server := setupPageDownloadWaitTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, `{"message":"element not found","success":false}`)
+	server.AddResponse("/storage/"+pageSessionIDTest+"/downloads", 200, `{"files":[]}`)
+
+	err := runPageDownload(newPageTestCmd(), []string{"B4"})
+	if err == nil || !strings.Contains(err.Error(), "element not found") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if n := len(server.Requests("/storage/" + pageSessionIDTest + "/downloads")); n != 1 {
+		t.Errorf("expected no waiting after a failed click, got %d listings", n)
+	}
+}
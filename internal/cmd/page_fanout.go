@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/validate"
+)
+
+func addFanOutSessionsFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("sessions", nil, "Run the action on these sessions concurrently (comma-separated IDs) and report each result")
+	_ = cmd.RegisterFlagCompletionFunc("sessions", completeIDsFromHistory(idKindSession))
+	addMaxParallelFlag(cmd, "sessions to run the action on")
+}
+
+// validateSessionIDList checks every ID of a --sessions value
+func validateSessionIDList(value string) error {
+	for _, id := range strings.Split(value, ",") {
+		if err := validate.SessionID(strings.TrimSpace(id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fanOutSessionIDs returns the sessions given with --sessions, or nil when
+// the action targets a single session
+func fanOutSessionIDs(cmd *cobra.Command) ([]string, error) {
+	if cmd.Flags().Lookup("sessions") == nil || !cmd.Flags().Changed("sessions") {
+		return nil, nil
+	}
+	if cmd.Flags().Changed("session-id") {
+		return nil, errors.New(i18n.T(i18n.SessionsWithSessionID))
+	}
+	values, err := cmd.Flags().GetStringSlice("sessions")
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, id := range values {
+		if id = strings.TrimSpace(id); id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, errors.New(i18n.T(i18n.SessionsNeedsID))
+	}
+	return ids, nil
+}
+
+// pageSessionResult is the outcome of an action on one session of a fan-out
+type pageSessionResult struct {
+	SessionID string                    `json:"session_id"`
+	Success   bool                      `json:"success"`
+	Message   string                    `json:"message,omitempty"`
+	Error     string                    `json:"error,omitempty"`
+	Response  *api.ApiExecutionResponse `json:"response,omitempty"`
+	// Screenshot is where --screenshot-after saved the page
+	Screenshot string `json:"screenshot,omitempty"`
+}
+
+// fanOutPageAction runs action on every session concurrently, up to
+// --max-parallel at once, and prints one result per session, in the order
+// given. afterEach, if set, runs on each session whose action succeeded
+// (e.g. waiting for the page to load). With --screenshot-after, each session
+// that ran the action is screenshotted. It fails if the action failed on any
+// session.
+func fanOutPageAction(cmd *cobra.Command, sessionIDs []string, action map[string]any,
+	afterEach func(ctx context.Context, client *api.NotteClient, sessionID string) error,
+) error {
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+	for _, id := range sessionIDs {
+		recordIDUse(idKindSession, id)
+	}
+
+	maxParallel, _ := cmd.Flags().GetInt("max-parallel")
+	if maxParallel < 0 {
+		return errors.New(i18n.T(i18n.MaxParallelCannotBeNegative))
+	}
+	limit := newConcurrencyLimit(maxParallel)
+	screenshotDir := screenshotAfterDir(cmd)
+
+	results := make([]pageSessionResult, len(sessionIDs))
+	var wg sync.WaitGroup
+	for i, sessionID := range sessionIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runFanOutAction(cmd.Context(), client, limit, sessionID, action, afterEach)
+			if screenshotDir != "" && results[i].Response != nil {
+				path, err := screenshotAfterAction(cmd.Context(), client, screenshotDir, sessionID, action)
+				if err != nil {
+					PrintInfo(i18n.T(i18n.WarningSessionCouldNotSaveScreenshot, sessionID, err))
+				}
+				results[i].Screenshot = path
+			}
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, r := range results {
+		if !r.Success {
+			failed++
+		}
+	}
+
+	if IsJSONOutput() {
+		if err := GetFormatter().Print(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Success {
+				fmt.Printf("%s  ok      %s\n", r.SessionID, r.Message)
+			} else {
+				fmt.Printf("%s  failed  %s\n", r.SessionID, r.Error)
+			}
+			if r.Screenshot != "" {
+				fmt.Printf("%s  screenshot saved: %s\n", r.SessionID, r.Screenshot)
+			}
+		}
+	}
+
+	if failed > 0 {
+		return i18n.Errorf(i18n.ActionFailedOnOfSessions, failed, len(sessionIDs))
+	}
+	return nil
+}
+
+func runFanOutAction(ctx context.Context, client *api.NotteClient, limit *concurrencyLimit, sessionID string, action map[string]any,
+	afterEach func(ctx context.Context, client *api.NotteClient, sessionID string) error,
+) pageSessionResult {
+	result := pageSessionResult{SessionID: sessionID}
+	var resp *api.ApiExecutionResponse
+	err := withConcurrencyLimit(ctx, limit, sessionID, func() error {
+		var err error
+		resp, err = sendPageActionTo(ctx, client, sessionID, action)
+		return err
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Response = resp
+	result.Message = resp.Message
+	if !resp.Success {
+		result.Error = executeFailure(resp).Error()
+		return result
+	}
+	if afterEach != nil {
+		if err := afterEach(ctx, client, sessionID); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+	}
+	result.Success = true
+	return result
+}
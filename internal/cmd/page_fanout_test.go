@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+func newFanOutTestCmd(t *testing.T, sessions string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.Flags().String("session-id", "", "")
+	addFanOutSessionsFlag(cmd)
+	if err := cmd.Flags().Set("sessions", sessions); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestRunPageGoto_FanOut(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/sess_a/page/execute", 200, pageExecResponse())
+	server.AddResponse("/sessions/sess_b/page/execute", 403, `{"detail":"forbidden"}`)
+
+	cmd := newFanOutTestCmd(t, "sess_a,sess_b,sess_a")
+	addWaitLoadFlag(cmd)
+
+	var err error
+	stdout, _ := testutil.CaptureOutput(func() {
+		err = runPageGoto(cmd, []string{"https://example.com"})
+	})
+	if err == nil || err.Error() != "action failed on 1 of 2 sessions" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	var results []pageSessionResult
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected one result per unique session, got %d", len(results))
+	}
+	if results[0].SessionID != "sess_a" || !results[0].Success || results[0].Response == nil {
+		t.Errorf("sess_a result = %+v", results[0])
+	}
+	if results[1].SessionID != "sess_b" || results[1].Success || results[1].Error == "" {
+		t.Errorf("sess_b result = %+v", results[1])
+	}
+
+	// The same action went to both sessions, and only once each
+	for _, id := range []string{"sess_a", "sess_b"} {
+		reqs := server.Requests("/sessions/" + id + "/page/execute")
+		if len(reqs) != 1 {
+			t.Fatalf("%s: expected 1 request, got %d", id, len(reqs))
+		}
+		if !strings.Contains(reqs[0].Body, `"url":"https://example.com"`) {
+			t.Errorf("%s: unexpected body %s", id, reqs[0].Body)
+		}
+	}
+}
+
+func TestRunPageGoto_FanOutQueuesOverConcurrencyLimit(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/sess_a/page/execute", 200, pageExecResponse())
+	server.AddSequence("/sessions/sess_b/page/execute", mockserver.RateLimitResponse(0), mockserver.JSONResponse(200, pageExecResponse()))
+
+	cmd := newFanOutTestCmd(t, "sess_a,sess_b")
+	addWaitLoadFlag(cmd)
+	if err := cmd.Flags().Set("max-parallel", "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = testutil.CaptureOutput(func() {
+		if err := runPageGoto(cmd, []string{"https://example.com"}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	if n := len(server.Requests("/sessions/sess_b/page/execute")); n != 2 {
+		t.Errorf("expected sess_b to be retried after the concurrency limit, got %d requests", n)
+	}
+}
+
+func TestRunPageClick_FanOutText(t *testing.T) {
+	server := setupPageTest(t)
+	outputFormat = "text"
+	server.AddResponse("/sessions/sess_a/page/execute", 200, pageExecResponse())
+	server.AddResponse("/sessions/sess_b/page/execute", 200, pageExecResponse())
+
+	cmd := newFanOutTestCmd(t, "sess_a,sess_b")
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runPageClick(cmd, []string{"B1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	want := "sess_a  ok      ok\nsess_b  ok      ok\n"
+	if stdout != want {
+		t.Errorf("output = %q, want %q", stdout, want)
+	}
+}
+
+func TestFanOutSessionIDs_Conflict(t *testing.T) {
+	cmd := newFanOutTestCmd(t, "sess_a")
+	_ = cmd.Flags().Set("session-id", "sess_b")
+	if _, err := fanOutSessionIDs(cmd); err == nil || !strings.Contains(err.Error(), "can't be used together") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateSessionIDList(t *testing.T) {
+	if err := validateSessionIDList("sess_a, sess_b"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateSessionIDList("sess_a,not a session"); err == nil {
+		t.Error("expected an error for a malformed ID")
+	}
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func FuzzParseSelector(f *testing.F) {
+	for _, seed := range []string{"B1", "@B3", "@", "", "#btn", "button:has-text('OK') >> nth=0", "I999999999999999999999", "@@", "b1"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, arg string) {
+		id, selector, err := parseSelector(arg)
+		if err != nil {
+			if id != "" || selector != "" {
+				t.Fatalf("parseSelector(%q) returned values alongside error", arg)
+			}
+			return
+		}
+
+		if (id == "") == (selector == "") {
+			t.Fatalf("parseSelector(%q) = (%q, %q): expected exactly one of id or selector", arg, id, selector)
+		}
+		if strings.HasPrefix(arg, "@") {
+			if id != arg[1:] {
+				t.Fatalf("parseSelector(%q) id = %q, want %q", arg, id, arg[1:])
+			}
+			return
+		}
+		if id != "" && !idPattern.MatchString(id) {
+			t.Fatalf("parseSelector(%q) returned non-ID %q as id", arg, id)
+		}
+		if id+selector != arg {
+			t.Fatalf("parseSelector(%q) altered input: id=%q selector=%q", arg, id, selector)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// Load states accepted by --wait-load
+const (
+	loadStateDOMContentLoaded = "domcontentloaded"
+	loadStateNetworkIdle      = "networkidle"
+)
+
+var loadStates = []string{loadStateDOMContentLoaded, loadStateNetworkIdle}
+
+// waitLoadTimeout bounds --wait-load, like the 30s cap of `page wait`
+const waitLoadTimeout = 30 * time.Second
+
+// waitLoadPollInterval is how often the page is checked. For networkidle it
+// is also how long no new requests may start.
+var waitLoadPollInterval = 500 * time.Millisecond
+
+// loadStateJS reports the document's readyState and how many resources the
+// page has fetched so far
+const loadStateJS = `document.readyState + ":" + performance.getEntriesByType("resource").length`
+
+func addWaitLoadFlag(cmd *cobra.Command) {
+	cmd.Flags().String("wait-load", "", "After navigating, wait until the page reaches this state: domcontentloaded or networkidle")
+	_ = cmd.Flags().SetAnnotation("wait-load", flagEnumAnnotation, loadStates)
+}
+
+// executeNavigation runs a navigation action and, with --wait-load, waits
+// for the new page before printing the result
+func executeNavigation(cmd *cobra.Command, action map[string]any) error {
+	state, _ := cmd.Flags().GetString("wait-load")
+	if state != "" && !slices.Contains(loadStates, state) {
+		return i18n.Errorf(i18n.InvalidWaitLoad, state)
+	}
+
+	if sessionIDs, err := fanOutSessionIDs(cmd); err != nil || sessionIDs != nil {
+		if err != nil {
+			return err
+		}
+		var wait func(context.Context, *api.NotteClient, string) error
+		if state != "" {
+			wait = func(ctx context.Context, client *api.NotteClient, sessionID string) error {
+				return waitForSessionLoadState(ctx, client, sessionID, state)
+			}
+		}
+		return fanOutPageAction(cmd, sessionIDs, action, wait)
+	}
+
+	resp, err := sendPageAction(cmd, action)
+	if err != nil {
+		return err
+	}
+	if state != "" && resp.Success {
+		if err := waitForLoadState(cmd, state); err != nil {
+			return err
+		}
+	}
+	err = printExecuteResponse(resp)
+	printScreenshotAfter(cmd, action)
+	return err
+}
+
+// waitForLoadState polls the page until it reaches state. The API has no
+// load-state option on actions, so this checks document.readyState and
+// treats the network as idle once no new resources appear between polls.
+func waitForLoadState(cmd *cobra.Command, state string) error {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	return waitForSessionLoadState(cmd.Context(), client, sessionID, state)
+}
+
+// waitForSessionLoadState is waitForLoadState for a given session
+func waitForSessionLoadState(ctx context.Context, client *api.NotteClient, sessionID, state string) error {
+	ctx, cancel := context.WithTimeout(ctx, waitLoadTimeout)
+	defer cancel()
+
+	lastResources := -1
+	for {
+		readyState, resources, err := pageLoadState(ctx, client, sessionID)
+		if err != nil && ctx.Err() == nil {
+			return i18n.Errorf(i18n.FailedToCheckPageLoadState, err)
+		}
+		if err == nil {
+			switch state {
+			case loadStateDOMContentLoaded:
+				if readyState != "loading" {
+					return nil
+				}
+			case loadStateNetworkIdle:
+				if readyState == "complete" && resources == lastResources {
+					return nil
+				}
+				lastResources = resources
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return i18n.Errorf(i18n.TimedOutWaitingForPage, waitLoadTimeout, state)
+		case <-time.After(waitLoadPollInterval):
+		}
+	}
+}
+
+// pageLoadState evaluates loadStateJS on the page
+func pageLoadState(ctx context.Context, client *api.NotteClient, sessionID string) (string, int, error) {
+	out, err := evalPageJS(ctx, client, sessionID, loadStateJS)
+	if err != nil {
+		return "", 0, err
+	}
+	readyState, count, ok := strings.Cut(out, ":")
+	resources, err := strconv.Atoi(count)
+	if !ok || err != nil {
+		return "", 0, i18n.Errorf(i18n.UnexpectedLoadState, out)
+	}
+	return readyState, resources, nil
+}
+
+// evalPageJS evaluates code on the page and returns the result as text
+func evalPageJS(ctx context.Context, client *api.NotteClient, sessionID, code string) (string, error) {
+	actionJSON, err := json.Marshal(map[string]any{
+		"type": "evaluate_js",
+		"code": code,
+	})
+	if err != nil {
+		return "", i18n.Errorf(i18n.FailedToMarshalAction, err)
+	}
+
+	// Only reads the page, so the last observe stays valid
+	resp, err := postPageAction(ctx, client, sessionID, actionJSON, false)
+	if err != nil {
+		return "", err
+	}
+	if !resp.JSON200.Success || resp.JSON200.Data == nil {
+		return "", i18n.Errorf(i18n.PageEvaluationFailed, resp.JSON200.Message)
+	}
+	return strings.Trim(strings.TrimSpace(resp.JSON200.Data.Markdown), `"`), nil
+}
+
+// decodeJSResult decodes JSON returned by evalPageJS, which may come back as
+// the JSON text itself or as an escaped string
+func decodeJSResult(out string, v any) error {
+	if err := json.Unmarshal([]byte(out), v); err == nil {
+		return nil
+	}
+	unquoted, err := strconv.Unquote(`"` + out + `"`)
+	if err != nil {
+		return i18n.Errorf(i18n.NotJSON, out)
+	}
+	return json.Unmarshal([]byte(unquoted), v)
+}
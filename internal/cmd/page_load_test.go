@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+func loadStateResponse(state string) mockserver.Response {
+	return mockserver.JSONResponse(200, `{"action":{"type":"evaluate_js"},"success":true,"message":"ok","data":{"markdown":"`+state+`"},"started_at":"2020-01-01T00:00:00Z","ended_at":"2020-01-01T00:00:00Z"}`)
+}
+
+func newWaitLoadCmd(t *testing.T, state string) *cobra.Command {
+	t.Helper()
+	orig := waitLoadPollInterval
+	waitLoadPollInterval = time.Millisecond
+	t.Cleanup(func() { waitLoadPollInterval = orig })
+
+	cmd := newPageTestCmd()
+	addWaitLoadFlag(cmd)
+	_ = cmd.Flags().Set("wait-load", state)
+	return cmd
+}
+
+func evalRequests(server *testutil.MockServer) int {
+	n := 0
+	for _, r := range server.Requests("/sessions/" + pageSessionIDTest + "/page/execute") {
+		if strings.Contains(r.Body, "evaluate_js") {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRunPageGoto_WaitLoad(t *testing.T) {
+	tests := []struct {
+		state     string
+		sequence  []string
+		wantEvals int
+	}{
+		{state: loadStateDOMContentLoaded, sequence: []string{"loading:0", "interactive:2"}, wantEvals: 2},
+		{state: loadStateNetworkIdle, sequence: []string{"interactive:2", "complete:4", "complete:6", "complete:6"}, wantEvals: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			server := setupPageTest(t)
+			path := "/sessions/" + pageSessionIDTest + "/page/execute"
+			server.AddResponse(path, 200, pageExecResponse())
+			var responses []mockserver.Response
+			for _, s := range tt.sequence {
+				responses = append(responses, loadStateResponse(s))
+			}
+			server.AddMatchedResponse(mockserver.Match{Path: path, BodyContains: "evaluate_js"}, responses...)
+
+			cmd := newWaitLoadCmd(t, tt.state)
+			testutil.CaptureOutput(func() {
+				if err := runPageGoto(cmd, []string{"https://example.com"}); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			})
+
+			if got := evalRequests(server); got != tt.wantEvals {
+				t.Errorf("load state checks = %d, want %d", got, tt.wantEvals)
+			}
+		})
+	}
+}
+
+func TestRunPageReload_WaitLoadInvalid(t *testing.T) {
+	server := setupPageTest(t)
+
+	cmd := newWaitLoadCmd(t, "load")
+	err := runPageReload(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid --wait-load") {
+		t.Fatalf("expected invalid state error, got %v", err)
+	}
+	if got := len(server.Requests("/sessions/" + pageSessionIDTest + "/page/execute")); got != 0 {
+		t.Errorf("expected no request, got %d", got)
+	}
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var pageMetaCmd = &cobra.Command{
+	Use:   "meta",
+	Short: "Extract the page's metadata: title, canonical URL, OpenGraph, JSON-LD, hreflang",
+	Long: `Read the metadata of the current page straight from the DOM, without LLM
+scraping: title, description, canonical URL, language, robots directives,
+OpenGraph and Twitter card tags, JSON-LD blocks and hreflang alternates.
+
+When a meta tag appears several times, the first value is kept.`,
+	Example: `  notte page meta
+  notte page meta -o json | jq '.opengraph["og:image"]'
+  notte page meta -o json | jq '.json_ld[] | .["@type"]'`,
+	Args: cobra.NoArgs,
+	RunE: runPageMeta,
+}
+
+func init() {
+	pageCmd.AddCommand(pageMetaCmd)
+}
+
+// pageMeta is the metadata printed by `page meta`
+type pageMeta struct {
+	Title       string            `json:"title"`
+	Description string            `json:"description,omitempty"`
+	URL         string            `json:"url"`
+	Canonical   string            `json:"canonical,omitempty"`
+	Lang        string            `json:"lang,omitempty"`
+	Robots      string            `json:"robots,omitempty"`
+	OpenGraph   map[string]string `json:"opengraph"`
+	Twitter     map[string]string `json:"twitter"`
+	JSONLD      []json.RawMessage `json:"json_ld"`
+	Hreflang    []hreflangLink    `json:"hreflang"`
+}
+
+type hreflangLink struct {
+	Lang string `json:"lang"`
+	Href string `json:"href"`
+}
+
+// pageMetaJS collects the metadata as a JSON string. JSON-LD blocks that
+// don't parse are returned as {"error", "raw"} so nothing is silently lost.
+const pageMetaJS = `(() => {
+  const attr = (sel, name) => { const el = document.querySelector(sel); return el ? (el.getAttribute(name) || '').trim() : ''; };
+  const tags = (prefix) => {
+    const out = {};
+    for (const el of document.querySelectorAll('meta[property],meta[name]')) {
+      const key = (el.getAttribute('property') || el.getAttribute('name') || '').trim();
+      if (key.toLowerCase().startsWith(prefix) && !(key in out)) out[key] = (el.getAttribute('content') || '').trim();
+    }
+    return out;
+  };
+  const jsonLD = Array.from(document.querySelectorAll('script[type="application/ld+json"]')).map((s) => {
+    try { return JSON.parse(s.textContent); } catch (e) { return { error: String(e), raw: s.textContent.trim().slice(0, 1000) }; }
+  });
+  const canonical = document.querySelector('link[rel~="canonical"]');
+  return JSON.stringify({
+    title: document.title,
+    description: attr('meta[name="description"]', 'content'),
+    url: location.href,
+    canonical: canonical ? canonical.href : '',
+    lang: document.documentElement.lang || '',
+    robots: attr('meta[name="robots"]', 'content'),
+    opengraph: tags('og:'),
+    twitter: tags('twitter:'),
+    json_ld: jsonLD,
+    hreflang: Array.from(document.querySelectorAll('link[rel~="alternate"][hreflang]')).map((l) => ({ lang: l.getAttribute('hreflang'), href: l.href })),
+  });
+})()`
+
+func runPageMeta(cmd *cobra.Command, args []string) error {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	out, err := evalPageJS(cmd.Context(), client, sessionID, pageMetaJS)
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToReadPageMetadata, err)
+	}
+	var meta pageMeta
+	if err := decodeJSResult(out, &meta); err != nil {
+		return i18n.Errorf(i18n.UnexpectedPageMetadata, err)
+	}
+	if meta.OpenGraph == nil {
+		meta.OpenGraph = map[string]string{}
+	}
+	if meta.Twitter == nil {
+		meta.Twitter = map[string]string{}
+	}
+	if meta.JSONLD == nil {
+		meta.JSONLD = []json.RawMessage{}
+	}
+	if meta.Hreflang == nil {
+		meta.Hreflang = []hreflangLink{}
+	}
+
+	if IsJSONOutput() {
+		return GetFormatter().Print(meta)
+	}
+	printPageMeta(meta)
+	return nil
+}
+
+func printPageMeta(meta pageMeta) {
+	for _, f := range []struct{ label, value string }{
+		{"Title", meta.Title},
+		{"Description", meta.Description},
+		{"URL", meta.URL},
+		{"Canonical", meta.Canonical},
+		{"Language", meta.Lang},
+		{"Robots", meta.Robots},
+	} {
+		if f.value != "" {
+			fmt.Printf("%-12s %s\n", f.label+":", f.value)
+		}
+	}
+	printMetaTags("OpenGraph", meta.OpenGraph)
+	printMetaTags("Twitter", meta.Twitter)
+
+	if len(meta.JSONLD) > 0 {
+		fmt.Printf("JSON-LD:     %d block(s)\n", len(meta.JSONLD))
+		for _, block := range meta.JSONLD {
+			fmt.Printf("  %s\n", jsonLDType(block))
+		}
+	}
+	if len(meta.Hreflang) > 0 {
+		fmt.Println("Hreflang:")
+		for _, h := range meta.Hreflang {
+			fmt.Printf("  %-10s %s\n", h.Lang, h.Href)
+		}
+	}
+}
+
+func printMetaTags(label string, tags map[string]string) {
+	if len(tags) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Printf("%s:\n", label)
+	for _, k := range keys {
+		fmt.Printf("  %-20s %s\n", k, tags[k])
+	}
+}
+
+// jsonLDType describes a JSON-LD block by its @type
+func jsonLDType(block json.RawMessage) string {
+	var v struct {
+		Type  any    `json:"@type"`
+		Graph []any  `json:"@graph"`
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(block, &v) != nil {
+		return "(array)"
+	}
+	switch {
+	case v.Error != "":
+		return "(invalid: " + v.Error + ")"
+	case v.Type != nil:
+		return fmt.Sprint(v.Type)
+	case v.Graph != nil:
+		return fmt.Sprintf("@graph with %d item(s)", len(v.Graph))
+	}
+	return "(no @type)"
+}
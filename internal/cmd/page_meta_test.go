@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+const pageMetaTest = `{"title":"Pricing","description":"Plans","url":"https://example.com/pricing?x=1","canonical":"https://example.com/pricing","lang":"en","opengraph":{"og:title":"Pricing","og:image":"https://example.com/og.png"},"twitter":{"twitter:card":"summary"},"json_ld":[{"@type":"Product","name":"Pro"},{"error":"SyntaxError","raw":"{"}],"hreflang":[{"lang":"fr","href":"https://example.com/fr/pricing"}]}`
+
+func TestJSONLDType(t *testing.T) {
+	tests := []struct {
+		block string
+		want  string
+	}{
+		{`{"@type":"Product"}`, "Product"},
+		{`{"@type":["Organization","Brand"]}`, "[Organization Brand]"},
+		{`{"@graph":[{},{}]}`, "@graph with 2 item(s)"},
+		{`{"error":"SyntaxError","raw":"{"}`, "(invalid: SyntaxError)"},
+		{`[{"@type":"Product"}]`, "(array)"},
+		{`{"name":"x"}`, "(no @type)"},
+	}
+	for _, tt := range tests {
+		if got := jsonLDType(json.RawMessage(tt.block)); got != tt.want {
+			t.Errorf("jsonLDType(%s) = %q, want %q", tt.block, got, tt.want)
+		}
+	}
+}
+
+func TestRunPageMeta(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, evalJSResponse(pageMetaTest))
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runPageMeta(newPageTestCmd(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var meta pageMeta
+	if err := json.Unmarshal([]byte(stdout), &meta); err != nil {
+		t.Fatalf("failed to parse output %q: %v", stdout, err)
+	}
+	if meta.Canonical != "https://example.com/pricing" || meta.OpenGraph["og:image"] != "https://example.com/og.png" {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+	if len(meta.JSONLD) != 2 || len(meta.Hreflang) != 1 {
+		t.Errorf("expected 2 JSON-LD blocks and 1 hreflang, got %+v", meta)
+	}
+}
+
+func TestRunPageMeta_Text(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, evalJSResponse(`{"title":"Empty","url":"about:blank"}`))
+	outputFormat = "text"
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runPageMeta(newPageTestCmd(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if want := "Title:       Empty\nURL:         about:blank\n"; stdout != want {
+		t.Errorf("output = %q, want %q", stdout, want)
+	}
+	if strings.Contains(stdout, "OpenGraph") {
+		t.Error("expected empty sections to be omitted")
+	}
+}
@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	pageRunStep    bool
+	pageRunObserve bool
+)
+
+var pageRunCmd = &cobra.Command{
+	Use:   "run <script.json>",
+	Short: "Run a script of page actions in order",
+	Long: `Run the page actions of a script one after the other, stopping at the
+first one that fails.
+
+The script is a JSON array of actions, as taken by 'page execute' and
+exported as workflow_actions.json by 'agents export', or one action per line.
+Every action is checked before the first one runs.
+
+--step turns the run into a debugger: before each action it is printed and
+the command waits for an answer on stdin:
+  Enter    run the action
+  s        skip it
+  o        observe the page and show what changed since the last observe
+  q        abort the script
+A failed action doesn't end a stepped run, so it can be skipped past.
+--observe shows the observe diff before every action without asking.`,
+	Example: `  notte page run script.json
+  notte page run script.json --step
+  notte page run script.json --step --observe`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPageRun,
+}
+
+func init() {
+	pageCmd.AddCommand(pageRunCmd)
+
+	pageRunCmd.Flags().BoolVar(&pageRunStep, "step", false, "Pause before each action and ask whether to run, skip or abort")
+	pageRunCmd.Flags().BoolVar(&pageRunObserve, "observe", false, "With --step, show what changed on the page before each action")
+}
+
+// pageRunResult is the outcome of one action of a script
+type pageRunResult struct {
+	Index   int            `json:"index"`
+	Action  map[string]any `json:"action"`
+	Success bool           `json:"success"`
+	Skipped bool           `json:"skipped,omitempty"`
+	Message string         `json:"message,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// parseActionScript reads a script as a JSON array of actions or as one
+// action per line
+func parseActionScript(data []byte) ([]map[string]any, error) {
+	data = bytes.TrimSpace(data)
+	var actions []map[string]any
+	if bytes.HasPrefix(data, []byte("[")) {
+		if err := json.Unmarshal(data, &actions); err != nil {
+			return nil, i18n.Errorf(i18n.InvalidScript, err)
+		}
+	} else {
+		for n, line := range bytes.Split(data, []byte("\n")) {
+			if line = bytes.TrimSpace(line); len(line) == 0 {
+				continue
+			}
+			var action map[string]any
+			if err := json.Unmarshal(line, &action); err != nil {
+				return nil, i18n.Errorf(i18n.InvalidScriptLine, n+1, err)
+			}
+			actions = append(actions, action)
+		}
+	}
+	if len(actions) == 0 {
+		return nil, errors.New(i18n.T(i18n.ScriptHasNoActions))
+	}
+
+	for i, action := range actions {
+		data, err := json.Marshal(action)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkAction(data); err != nil {
+			return nil, i18n.Errorf(i18n.ActionError, i+1, err)
+		}
+	}
+	return actions, nil
+}
+
+// Answers to the --step prompt
+const (
+	stepRun     = "run"
+	stepSkip    = "skip"
+	stepObserve = "observe"
+	stepAbort   = "abort"
+)
+
+// parseStepAnswer maps a line typed at the --step prompt to what to do,
+// or "" when it isn't an answer
+func parseStepAnswer(line string) string {
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "", "r", "run":
+		return stepRun
+	case "s", "skip":
+		return stepSkip
+	case "o", "observe":
+		return stepObserve
+	case "q", "quit", "a", "abort":
+		return stepAbort
+	}
+	return ""
+}
+
+// observeDiff lists the lines of the page description that appeared (+)
+// and disappeared (-) between two observations
+func observeDiff(before, after string) []string {
+	oldLines := strings.Split(before, "\n")
+	newLines := strings.Split(after, "\n")
+	var diff []string
+	for _, line := range oldLines {
+		if strings.TrimSpace(line) != "" && !slices.Contains(newLines, line) {
+			diff = append(diff, "- "+line)
+		}
+	}
+	for _, line := range newLines {
+		if strings.TrimSpace(line) != "" && !slices.Contains(oldLines, line) {
+			diff = append(diff, "+ "+line)
+		}
+	}
+	return diff
+}
+
+// scriptStepper drives the --step prompt
+type scriptStepper struct {
+	cmd       *cobra.Command
+	sessionID string
+	in        *bufio.Reader
+	out       io.Writer
+	// observed is the page description of the last observe, if any
+	observed *string
+}
+
+// showObserveDiff observes the page and prints what changed since the
+// previous observe of the run
+func (s *scriptStepper) showObserveDiff() error {
+	snap, err := observeSession(s.cmd, s.sessionID)
+	if err != nil {
+		return err
+	}
+	if s.observed == nil {
+		_, _ = fmt.Fprintf(s.out, "Page:\n%s\n", snap.Description)
+	} else if diff := observeDiff(*s.observed, snap.Description); len(diff) == 0 {
+		_, _ = fmt.Fprintln(s.out, "Page unchanged since the last observe")
+	} else {
+		_, _ = fmt.Fprintf(s.out, "Page changes since the last observe:\n%s\n", strings.Join(diff, "\n"))
+	}
+	s.observed = &snap.Description
+	return nil
+}
+
+// ask prints action and waits for what to do with it
+func (s *scriptStepper) ask(index, total int, action map[string]any) (string, error) {
+	data, err := json.Marshal(action)
+	if err != nil {
+		return "", err
+	}
+	_, _ = fmt.Fprintf(s.out, "[%d/%d] %s\n", index, total, data)
+	if pageRunObserve {
+		if err := s.showObserveDiff(); err != nil {
+			_, _ = fmt.Fprintf(s.out, "Could not observe the page: %v\n", err)
+		}
+	}
+	for {
+		_, _ = fmt.Fprint(s.out, "Run? [Enter] run, [s]kip, [o]bserve, [q]uit: ")
+		line, err := s.in.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", i18n.Errorf(i18n.FailedToReadResponse, err)
+		}
+		if err == io.EOF && line == "" {
+			// Input closed: nothing can confirm the remaining actions
+			_, _ = fmt.Fprintln(s.out)
+			return stepAbort, nil
+		}
+		switch answer := parseStepAnswer(line); answer {
+		case stepObserve:
+			if err := s.showObserveDiff(); err != nil {
+				_, _ = fmt.Fprintf(s.out, "Could not observe the page: %v\n", err)
+			}
+		case "":
+			_, _ = fmt.Fprintf(s.out, "Unknown answer %q\n", strings.TrimSpace(line))
+		default:
+			return answer, nil
+		}
+	}
+}
+
+func runPageRun(cmd *cobra.Command, args []string) error {
+	if pageRunObserve && !pageRunStep {
+		return errors.New(i18n.T(i18n.ObserveNeedsStep))
+	}
+	if pageRunStep {
+		if nonInteractive {
+			return errors.New(i18n.T(i18n.StepNonInteractive))
+		}
+		if input := strings.TrimSpace(args[0]); input == "-" || input == "@-" {
+			return errors.New(i18n.T(i18n.StepWithStdinScript))
+		}
+	}
+
+	data, err := readJSONFileInput(cmd, args[0], "script")
+	if err != nil {
+		return err
+	}
+	actions, err := parseActionScript(data)
+	if err != nil {
+		return err
+	}
+
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	var stepper *scriptStepper
+	if pageRunStep {
+		stepper = &scriptStepper{cmd: cmd, sessionID: sessionID, in: bufio.NewReader(cmd.InOrStdin()), out: os.Stderr}
+	}
+
+	results := []pageRunResult{}
+	failed, aborted := 0, false
+	for i, action := range actions {
+		result := pageRunResult{Index: i + 1, Action: action}
+		if stepper != nil {
+			answer, err := stepper.ask(i+1, len(actions), action)
+			if err != nil {
+				return err
+			}
+			if answer == stepAbort {
+				aborted = true
+				break
+			}
+			if answer == stepSkip {
+				result.Skipped = true
+				results = append(results, result)
+				if !IsJSONOutput() {
+					printPageRunResult(result, len(actions))
+				}
+				continue
+			}
+		}
+
+		resp, err := sendPageActionTo(cmd.Context(), client, sessionID, action)
+		switch {
+		case err != nil:
+			result.Error = err.Error()
+		case !resp.Success:
+			result.Message = resp.Message
+			result.Error = executeFailure(resp).Error()
+		default:
+			result.Message = resp.Message
+			result.Success = true
+		}
+		results = append(results, result)
+		if !IsJSONOutput() {
+			printPageRunResult(result, len(actions))
+		} else if stepper != nil && !result.Success {
+			_, _ = fmt.Fprintf(stepper.out, "Failed: %s\n", result.Error)
+		}
+		if !result.Success {
+			failed++
+			if stepper == nil {
+				break
+			}
+		}
+	}
+
+	if IsJSONOutput() {
+		if err := GetFormatter().Print(results); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case aborted:
+		return i18n.Errorf(i18n.ScriptAborted, len(results)+1, len(actions))
+	case failed > 0 && stepper == nil:
+		return i18n.Errorf(i18n.ScriptStopped, len(results), len(actions))
+	case failed > 0:
+		return i18n.Errorf(i18n.ActionsFailed, failed, len(actions))
+	}
+	return nil
+}
+
+func printPageRunResult(r pageRunResult, total int) {
+	kind, _ := r.Action["type"].(string)
+	switch {
+	case r.Skipped:
+		fmt.Printf("[%d/%d] %s  skipped\n", r.Index, total, kind)
+	case r.Success:
+		fmt.Printf("[%d/%d] %s  ok      %s\n", r.Index, total, kind, r.Message)
+	default:
+		fmt.Printf("[%d/%d] %s  failed  %s\n", r.Index, total, kind, r.Error)
+	}
+}
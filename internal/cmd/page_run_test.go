@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+func TestParseActionScript(t *testing.T) {
+	actions, err := parseActionScript([]byte(`[{"type":"goto","url":"https://example.com"},{"type":"click","id":"B1"}]`))
+	if err != nil || len(actions) != 2 || actions[1]["type"] != "click" {
+		t.Errorf("array: %v, %v", actions, err)
+	}
+
+	actions, err = parseActionScript([]byte("{\"type\":\"goto\",\"url\":\"https://example.com\"}\n\n{\"type\":\"click\",\"id\":\"B1\"}\n"))
+	if err != nil || len(actions) != 2 {
+		t.Errorf("one per line: %v, %v", actions, err)
+	}
+
+	if _, err := parseActionScript([]byte(`[]`)); err == nil || !strings.Contains(err.Error(), "no actions") {
+		t.Errorf("expected an empty script error, got %v", err)
+	}
+	if _, err := parseActionScript([]byte("{\"type\":\"click\",\"id\":\"B1\"}\nnot json")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected the bad line, got %v", err)
+	}
+	if _, err := parseActionScript([]byte(`[{"type":"click","id":"B1"},{"type":"clik","id":"B1"}]`)); err == nil || !strings.Contains(err.Error(), "action 2") {
+		t.Errorf("expected the bad action to be reported before running, got %v", err)
+	}
+}
+
+func TestParseStepAnswer(t *testing.T) {
+	tests := map[string]string{
+		"\n":      stepRun,
+		" run\n":  stepRun,
+		"S\n":     stepSkip,
+		"o":       stepObserve,
+		"q\n":     stepAbort,
+		"abort\n": stepAbort,
+		"maybe\n": "",
+	}
+	for line, want := range tests {
+		if got := parseStepAnswer(line); got != want {
+			t.Errorf("parseStepAnswer(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
+
+func TestObserveDiff(t *testing.T) {
+	diff := observeDiff("* B1: Sign in\n* I1: Email", "* I1: Email\n* B2: Sign out\n")
+	want := []string{"- * B1: Sign in", "+ * B2: Sign out"}
+	if !slices.Equal(diff, want) {
+		t.Errorf("diff = %q, want %q", diff, want)
+	}
+	if diff := observeDiff("same", "same"); len(diff) != 0 {
+		t.Errorf("expected no diff, got %q", diff)
+	}
+}
+
+func setupPageRunTest(t *testing.T, script string) (*mockserver.Server, string) {
+	t.Helper()
+	server := setupPageTest(t)
+
+	origStep, origObserve := pageRunStep, pageRunObserve
+	t.Cleanup(func() { pageRunStep, pageRunObserve = origStep, origObserve })
+	pageRunStep, pageRunObserve = false, false
+
+	path := filepath.Join(t.TempDir(), "script.json")
+	if err := os.WriteFile(path, []byte(script), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return server, path
+}
+
+const pageRunScriptTest = `[{"type":"click","id":"B1"},{"type":"click","id":"B2"},{"type":"click","id":"B3"}]`
+
+func TestRunPageRun(t *testing.T) {
+	server, path := setupPageRunTest(t, pageRunScriptTest)
+	server.AddSequence("/sessions/"+pageSessionIDTest+"/page/execute",
+		mockserver.JSONResponse(200, pageExecResponse()),
+		mockserver.JSONResponse(200, `{"message":"element not found","success":false}`),
+	)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	var err error
+	stdout, _ := testutil.CaptureOutput(func() {
+		err = runPageRun(cmd, []string{path})
+	})
+	if err == nil || err.Error() != "script stopped at action 2 of 3" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	var results []pageRunResult
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout)
+	}
+	if len(results) != 2 || !results[0].Success || results[1].Success || results[1].Error == "" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if n := len(server.Requests("/sessions/" + pageSessionIDTest + "/page/execute")); n != 2 {
+		t.Errorf("expected the run to stop after the failure, got %d actions sent", n)
+	}
+}
+
+func TestRunPageRun_Step(t *testing.T) {
+	server, path := setupPageRunTest(t, pageRunScriptTest)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, pageExecResponse())
+	pageRunStep = true
+	outputFormat = "text"
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.SetIn(strings.NewReader("s\nhuh\n\nq\n"))
+	var err error
+	stdout, stderr := testutil.CaptureOutput(func() {
+		err = runPageRun(cmd, []string{path})
+	})
+	if err == nil || err.Error() != "script aborted before action 3 of 3" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	reqs := server.Requests("/sessions/" + pageSessionIDTest + "/page/execute")
+	if len(reqs) != 1 || !strings.Contains(reqs[0].Body, `"B2"`) {
+		t.Errorf("expected only the second action to run, got %+v", reqs)
+	}
+	if !strings.Contains(stdout, "[1/3] click  skipped") || !strings.Contains(stdout, "[2/3] click  ok") {
+		t.Errorf("unexpected stdout: %s", stdout)
+	}
+	if !strings.Contains(stderr, `[1/3] {"id":"B1","type":"click"}`) || !strings.Contains(stderr, `Unknown answer "huh"`) {
+		t.Errorf("unexpected prompts: %s", stderr)
+	}
+}
+
+func TestRunPageRun_StepObserve(t *testing.T) {
+	server, path := setupPageRunTest(t, `[{"type":"click","id":"B1"},{"type":"click","id":"B2"}]`)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, pageExecResponse())
+	server.AddSequence("/sessions/"+pageSessionIDTest+"/page/observe",
+		mockserver.JSONResponse(200, observeResponseJSON("* B1: Next")),
+		mockserver.JSONResponse(200, observeResponseJSON("* B2: Done")),
+	)
+	pageRunStep, pageRunObserve = true, true
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.SetIn(strings.NewReader("\n\n"))
+	var err error
+	_, stderr := testutil.CaptureOutput(func() {
+		err = runPageRun(cmd, []string{path})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stderr, "Page:\n* B1: Next") || !strings.Contains(stderr, "- * B1: Next\n+ * B2: Done") {
+		t.Errorf("expected the page and then its changes, got %s", stderr)
+	}
+}
+
+func TestRunPageRun_StepNonInteractive(t *testing.T) {
+	_, path := setupPageRunTest(t, pageRunScriptTest)
+	pageRunStep = true
+	SetNonInteractive(true)
+	t.Cleanup(func() { SetNonInteractive(false) })
+
+	err := runPageRun(&cobra.Command{}, []string{path})
+	if err == nil || !strings.Contains(err.Error(), "non-interactive") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+func addScreenshotAfterFlag(cmd *cobra.Command) {
+	cmd.Flags().String("screenshot-after", "", "After the action, save a screenshot of the page into this run directory (or s3:// / gs:// prefix)")
+}
+
+// screenshotAfterDir returns the --screenshot-after directory, or "" when
+// no screenshot was asked for
+func screenshotAfterDir(cmd *cobra.Command) string {
+	dir, _ := cmd.Flags().GetString("screenshot-after")
+	return dir
+}
+
+// screenshotAfterName names a step's screenshot so a run directory sorts
+// in the order the actions ran, e.g.
+// 20250102T150405.000Z-sess_123-click.jpg
+func screenshotAfterName(sessionID string, action map[string]any, at time.Time) (string, error) {
+	actionType, _ := action["type"].(string)
+	if actionType == "" {
+		actionType = "action"
+	}
+	return expandScreenshotName("{timestamp}-{session}-"+actionType+".jpg", screenshotNameVars{Time: at, SessionID: sessionID})
+}
+
+// screenshotAfterAction saves a screenshot of the session's page into dir
+// once action has run, whether it succeeded or not, and returns its path
+func screenshotAfterAction(ctx context.Context, client *api.NotteClient, dir, sessionID string, action map[string]any) (string, error) {
+	name, err := screenshotAfterName(sessionID, action, time.Now())
+	if err != nil {
+		return "", err
+	}
+	data, err := fetchScreenshot(ctx, client, sessionID)
+	if err != nil {
+		return "", err
+	}
+	path, err := writeOutputFile(ctx, joinOutputPath(dir, name), data, 0o644)
+	if err != nil {
+		return "", i18n.Errorf(i18n.FailedToWriteScreenshot, err)
+	}
+	return path, nil
+}
+
+// printScreenshotAfter takes the --screenshot-after screenshot of a single
+// session action. A failed screenshot is a warning: the action already ran.
+func printScreenshotAfter(cmd *cobra.Command, action map[string]any) {
+	dir := screenshotAfterDir(cmd)
+	if dir == "" {
+		return
+	}
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return
+	}
+	client, err := GetClient()
+	if err != nil {
+		return
+	}
+	path, err := screenshotAfterAction(cmd.Context(), client, dir, sessionID, action)
+	if err != nil {
+		PrintInfo(i18n.T(i18n.WarningCouldNotSaveScreenshot, err))
+		return
+	}
+	PrintInfo(i18n.T(i18n.ScreenshotSaved, path))
+}
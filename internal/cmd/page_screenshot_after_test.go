@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func TestScreenshotAfterName(t *testing.T) {
+	at := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	name, err := screenshotAfterName("sess_1", map[string]any{"type": "click"}, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "20250102T150405.000Z-sess_1-click.jpg" {
+		t.Errorf("name = %q", name)
+	}
+
+	name, err = screenshotAfterName("sess_1", map[string]any{}, at)
+	if err != nil || name != "20250102T150405.000Z-sess_1-action.jpg" {
+		t.Errorf("name = %q, %v", name, err)
+	}
+}
+
+func newScreenshotAfterTestCmd(t *testing.T, dir string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	addScreenshotAfterFlag(cmd)
+	if err := cmd.Flags().Set("screenshot-after", dir); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestRunPageClick_ScreenshotAfter(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, pageExecResponse())
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/screenshot", 200, "jpeg-bytes")
+
+	dir := filepath.Join(t.TempDir(), "run")
+	cmd := newScreenshotAfterTestCmd(t, dir)
+	_, stderr := testutil.CaptureOutput(func() {
+		if err := runPageClick(cmd, []string{"B3"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*-"+pageSessionIDTest+"-click.jpg"))
+	if len(files) != 1 {
+		t.Fatalf("expected one screenshot in %s, got %v", dir, files)
+	}
+	if data, _ := os.ReadFile(files[0]); string(data) != "jpeg-bytes" {
+		t.Errorf("unexpected screenshot content %q", data)
+	}
+	if !strings.Contains(stderr, "Screenshot saved: "+files[0]) {
+		t.Errorf("expected the path on stderr, got %q", stderr)
+	}
+}
+
+func TestRunPageClick_ScreenshotAfterFailedAction(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200,
+		`{"action":{"type":"click"},"message":"element not found","session":{"session_id":"`+pageSessionIDTest+`","status":"ACTIVE"},"success":false}`)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/screenshot", 200, "jpeg-bytes")
+	outputFormat = "text"
+
+	dir := t.TempDir()
+	cmd := newScreenshotAfterTestCmd(t, dir)
+	var err error
+	_, _ = testutil.CaptureOutput(func() {
+		err = runPageClick(cmd, []string{"B3"})
+	})
+	if err == nil {
+		t.Fatal("expected the action's failure")
+	}
+	// The page is captured when the action fails too
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.jpg")); len(files) != 1 {
+		t.Errorf("expected one screenshot, got %v", files)
+	}
+}
+
+func TestRunPageClick_ScreenshotAfterUnavailable(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, pageExecResponse())
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/screenshot", 404, `{"detail":"session closed"}`)
+
+	cmd := newScreenshotAfterTestCmd(t, t.TempDir())
+	_, stderr := testutil.CaptureOutput(func() {
+		if err := runPageClick(cmd, []string{"B3"}); err != nil {
+			t.Fatalf("a failed screenshot should not fail the action: %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "could not save the screenshot") {
+		t.Errorf("expected a warning, got %q", stderr)
+	}
+}
+
+func TestRunPageClick_FanOutScreenshotAfter(t *testing.T) {
+	server := setupPageTest(t)
+	for _, id := range []string{"sess_a", "sess_b"} {
+		server.AddResponse("/sessions/"+id+"/page/execute", 200, pageExecResponse())
+		server.AddResponse("/sessions/"+id+"/page/screenshot", 200, "jpeg-"+id)
+	}
+
+	dir := t.TempDir()
+	cmd := newFanOutTestCmd(t, "sess_a,sess_b")
+	addScreenshotAfterFlag(cmd)
+	_ = cmd.Flags().Set("screenshot-after", dir)
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runPageClick(cmd, []string{"B3"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var results []pageSessionResult
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout)
+	}
+	for _, r := range results {
+		if filepath.Dir(r.Screenshot) != dir || !strings.HasSuffix(r.Screenshot, "-"+r.SessionID+"-click.jpg") {
+			t.Errorf("unexpected screenshot for %s: %q", r.SessionID, r.Screenshot)
+			continue
+		}
+		if data, _ := os.ReadFile(r.Screenshot); string(data) != "jpeg-"+r.SessionID {
+			t.Errorf("screenshot of %s has content %q", r.SessionID, data)
+		}
+	}
+}
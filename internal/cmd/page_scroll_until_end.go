@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	pageScrollMaxIterations int
+	pageScrollAmount        int
+	pageScrollWaitLoad      string
+	pageScrollInstructions  string
+)
+
+// scrollWaitNone turns off waiting for the page between scrolls
+const scrollWaitNone = "none"
+
+var pageScrollUntilEndCmd = &cobra.Command{
+	Use:   "scroll-until-end",
+	Short: "Scroll down until an infinite-scroll page stops growing",
+	Long: `Scroll down the page over and over, letting it load what each scroll
+brings in, until the page is at its bottom and no longer grows, or until
+--max-iterations scrolls.
+
+After each scroll the command waits for the network to go idle
+(--wait-load, "none" to skip). With --instructions the page is also
+scraped after each scroll and the structured results are merged: lists are
+concatenated without duplicates, so a feed's items are collected once each
+even though every scrape sees the earlier ones too.`,
+	Example: `  notte page scroll-until-end
+  notte page scroll-until-end --max-iterations 50 --amount 2000
+  notte page scroll-until-end --instructions "Extract every post's author and title" -o json`,
+	Args: cobra.NoArgs,
+	RunE: runPageScrollUntilEnd,
+}
+
+func init() {
+	pageCmd.AddCommand(pageScrollUntilEndCmd)
+
+	pageScrollUntilEndCmd.Flags().IntVar(&pageScrollMaxIterations, "max-iterations", 20, "Stop after this many scrolls")
+	pageScrollUntilEndCmd.Flags().IntVar(&pageScrollAmount, "amount", 0, "Pixels per scroll (defaults to the API's, about a screen)")
+	pageScrollUntilEndCmd.Flags().StringVar(&pageScrollWaitLoad, "wait-load", loadStateNetworkIdle, "After each scroll, wait until the page reaches this state: domcontentloaded, networkidle or none")
+	_ = pageScrollUntilEndCmd.Flags().SetAnnotation("wait-load", flagEnumAnnotation, append(slices.Clone(loadStates), scrollWaitNone))
+	pageScrollUntilEndCmd.Flags().StringVar(&pageScrollInstructions, "instructions", "", "Scrape the page with these instructions after each scroll and merge the results")
+}
+
+// Why scroll-until-end stopped
+const (
+	scrollStoppedEnd           = "end"
+	scrollStoppedMaxIterations = "max_iterations"
+)
+
+// scrollUntilEndResult is the outcome of scroll-until-end
+type scrollUntilEndResult struct {
+	Iterations int    `json:"iterations"`
+	Stopped    string `json:"stopped"`
+	PageHeight int    `json:"page_height"`
+	Items      int    `json:"items,omitempty"`
+	Data       any    `json:"data,omitempty"`
+}
+
+// mergeScrapeData adds the items of next to acc: lists are concatenated
+// without repeating items, objects are merged key by key and anything else
+// is replaced by its newer value
+func mergeScrapeData(acc, next any) any {
+	switch a := acc.(type) {
+	case []any:
+		if n, ok := next.([]any); ok {
+			return appendNewItems(a, n)
+		}
+	case map[string]any:
+		if n, ok := next.(map[string]any); ok {
+			for key, value := range n {
+				if existing, ok := a[key]; ok {
+					a[key] = mergeScrapeData(existing, value)
+				} else {
+					a[key] = value
+				}
+			}
+			return a
+		}
+	}
+	return next
+}
+
+func appendNewItems(items, next []any) []any {
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		seen[itemKey(item)] = true
+	}
+	for _, item := range next {
+		if key := itemKey(item); !seen[key] {
+			seen[key] = true
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// itemKey identifies an item by its JSON, which has sorted keys
+func itemKey(item any) string {
+	data, _ := json.Marshal(item)
+	return string(data)
+}
+
+// countScrapeItems counts the list items of merged scrape data
+func countScrapeItems(data any) int {
+	switch d := data.(type) {
+	case []any:
+		return len(d)
+	case map[string]any:
+		n := 0
+		for _, v := range d {
+			n += countScrapeItems(v)
+		}
+		return n
+	}
+	return 0
+}
+
+// scrapeStructured scrapes the page with instructions and returns its data
+func scrapeStructured(ctx context.Context, client *api.NotteClient, sessionID, instructions string) (any, error) {
+	ctx, cancel := GetContextWithTimeout(ctx)
+	defer cancel()
+
+	body := api.PageScrapeJSONRequestBody{Instructions: &instructions}
+	resp, err := client.Client().PageScrapeWithResponse(ctx, sessionID, &api.PageScrapeParams{}, body)
+	if err != nil {
+		return nil, i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return nil, err
+	}
+	return extractScrapeStructuredData(resp.JSON200)
+}
+
+func runPageScrollUntilEnd(cmd *cobra.Command, args []string) error {
+	if pageScrollMaxIterations < 1 {
+		return errors.New(i18n.T(i18n.MaxIterationsTooSmall))
+	}
+	if pageScrollAmount < 0 {
+		return errors.New(i18n.T(i18n.AmountCannotBeNegative))
+	}
+	if pageScrollWaitLoad != scrollWaitNone && !slices.Contains(loadStates, pageScrollWaitLoad) {
+		return i18n.Errorf(i18n.InvalidWaitLoadOrNone, pageScrollWaitLoad)
+	}
+
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return err
+	}
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	state, err := readScrollState(ctx, client, sessionID)
+	if err != nil {
+		return err
+	}
+	result := scrollUntilEndResult{Stopped: scrollStoppedMaxIterations, PageHeight: state.PageHeight}
+	if pageScrollInstructions != "" {
+		if result.Data, err = scrapeStructured(ctx, client, sessionID, pageScrollInstructions); err != nil {
+			return i18n.Errorf(i18n.ScrapeBeforeScrolling, err)
+		}
+		result.Items = countScrapeItems(result.Data)
+	}
+
+	action := map[string]any{"type": "scroll_down"}
+	if pageScrollAmount > 0 {
+		action["amount"] = pageScrollAmount
+	}
+	for result.Iterations < pageScrollMaxIterations {
+		resp, err := sendPageActionTo(ctx, client, sessionID, action)
+		if err != nil {
+			return err
+		}
+		if !resp.Success {
+			return i18n.Errorf(i18n.ScrollError, result.Iterations+1, executeFailure(resp))
+		}
+		result.Iterations++
+
+		if pageScrollWaitLoad != scrollWaitNone {
+			// A page that never settles is still worth scrolling
+			if err := waitForSessionLoadState(ctx, client, sessionID, pageScrollWaitLoad); err != nil {
+				PrintInfo(i18n.T(i18n.WarningError, err))
+			}
+		}
+
+		previous := state
+		if state, err = readScrollState(ctx, client, sessionID); err != nil {
+			return err
+		}
+		result.PageHeight = state.PageHeight
+
+		progress := fmt.Sprintf("[%d/%d] page height %d (%+d)", result.Iterations, pageScrollMaxIterations,
+			state.PageHeight, state.PageHeight-previous.PageHeight)
+		if pageScrollInstructions != "" {
+			data, err := scrapeStructured(ctx, client, sessionID, pageScrollInstructions)
+			if err != nil {
+				return i18n.Errorf(i18n.ScrapeAfterScroll, result.Iterations, err)
+			}
+			result.Data = mergeScrapeData(result.Data, data)
+			items := countScrapeItems(result.Data)
+			progress += fmt.Sprintf(", %d items (%+d)", items, items-result.Items)
+			result.Items = items
+		}
+		PrintInfo(progress)
+
+		if state.AtBottom && state.PageHeight <= previous.PageHeight {
+			result.Stopped = scrollStoppedEnd
+			break
+		}
+	}
+
+	if IsJSONOutput() {
+		return GetFormatter().Print(result)
+	}
+	if result.Stopped == scrollStoppedEnd {
+		fmt.Printf("Reached the end of the page after %d scrolls (height %d)\n", result.Iterations, result.PageHeight)
+	} else {
+		fmt.Printf("Stopped after %d scrolls (--max-iterations) before the page stopped growing (height %d)\n", result.Iterations, result.PageHeight)
+	}
+	if result.Data != nil {
+		data, err := json.MarshalIndent(result.Data, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\nMerged %d items:\n%s\n", result.Items, data)
+	}
+	return nil
+}
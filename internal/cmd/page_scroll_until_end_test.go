@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+func TestMergeScrapeData(t *testing.T) {
+	var acc any = map[string]any{"title": "Feed", "posts": []any{map[string]any{"id": 1.0}, map[string]any{"id": 2.0}}}
+	acc = mergeScrapeData(acc, map[string]any{"title": "Feed (2)", "posts": []any{map[string]any{"id": 2.0}, map[string]any{"id": 3.0}}})
+
+	want := map[string]any{"title": "Feed (2)", "posts": []any{map[string]any{"id": 1.0}, map[string]any{"id": 2.0}, map[string]any{"id": 3.0}}}
+	if !reflect.DeepEqual(acc, want) {
+		t.Errorf("merged = %v, want %v", acc, want)
+	}
+	if n := countScrapeItems(acc); n != 3 {
+		t.Errorf("countScrapeItems = %d, want 3", n)
+	}
+
+	if got := mergeScrapeData([]any{"a", "b"}, []any{"b", "c"}); !reflect.DeepEqual(got, []any{"a", "b", "c"}) {
+		t.Errorf("merged list = %v", got)
+	}
+	if got := mergeScrapeData(nil, []any{"a"}); !reflect.DeepEqual(got, []any{"a"}) {
+		t.Errorf("merge into nothing = %v", got)
+	}
+}
+
+func setupScrollUntilEndTest(t *testing.T) *testutil.MockServer {
+	t.Helper()
+	server := setupPageTest(t)
+	origMax, origAmount, origWait, origInstructions := pageScrollMaxIterations, pageScrollAmount, pageScrollWaitLoad, pageScrollInstructions
+	t.Cleanup(func() {
+		pageScrollMaxIterations, pageScrollAmount, pageScrollWaitLoad, pageScrollInstructions = origMax, origAmount, origWait, origInstructions
+	})
+	pageScrollMaxIterations, pageScrollAmount, pageScrollWaitLoad, pageScrollInstructions = 10, 0, scrollWaitNone, ""
+
+	execPath := "/sessions/" + pageSessionIDTest + "/page/execute"
+	server.AddMatchedResponse(mockserver.Match{Path: execPath, BodyContains: "scroll_down"}, mockserver.JSONResponse(200, pageExecResponse()))
+	return server
+}
+
+// scrollHeights serves the page's scroll state at each check: scrolled to
+// the given offsets of pages of the given heights
+func scrollHeights(server *testutil.MockServer, states ...[2]int) {
+	responses := make([]mockserver.Response, len(states))
+	for i, s := range states {
+		responses[i] = scrollStateResponse(s[0], s[1])
+	}
+	server.AddMatchedResponse(mockserver.Match{Path: "/sessions/" + pageSessionIDTest + "/page/execute", BodyContains: "scrollHeight"}, responses...)
+}
+
+func runScrollUntilEndTest(t *testing.T) (scrollUntilEndResult, error) {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	var err error
+	stdout, _ := testutil.CaptureOutput(func() {
+		err = runPageScrollUntilEnd(cmd, nil)
+	})
+	var result scrollUntilEndResult
+	if err == nil {
+		if jsonErr := json.Unmarshal([]byte(stdout), &result); jsonErr != nil {
+			t.Fatalf("output is not JSON: %v\n%s", jsonErr, stdout)
+		}
+	}
+	return result, err
+}
+
+func TestRunPageScrollUntilEnd(t *testing.T) {
+	server := setupScrollUntilEndTest(t)
+	// The feed loads more twice, then the bottom stops growing
+	scrollHeights(server, [2]int{0, 1500}, [2]int{780, 2500}, [2]int{1780, 3500}, [2]int{2780, 3500})
+
+	result, err := runScrollUntilEndTest(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Iterations != 3 || result.Stopped != scrollStoppedEnd || result.PageHeight != 3500 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	scrolls := 0
+	for _, r := range server.Requests("/sessions/" + pageSessionIDTest + "/page/execute") {
+		if strings.Contains(r.Body, "scroll_down") {
+			scrolls++
+		}
+	}
+	if scrolls != 3 {
+		t.Errorf("expected 3 scrolls, got %d", scrolls)
+	}
+}
+
+func TestRunPageScrollUntilEnd_MaxIterations(t *testing.T) {
+	server := setupScrollUntilEndTest(t)
+	pageScrollMaxIterations = 2
+	scrollHeights(server, [2]int{0, 1000}, [2]int{280, 2000}, [2]int{1280, 3000})
+
+	result, err := runScrollUntilEndTest(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Iterations != 2 || result.Stopped != scrollStoppedMaxIterations {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestRunPageScrollUntilEnd_Instructions(t *testing.T) {
+	server := setupScrollUntilEndTest(t)
+	pageScrollInstructions = "Extract the posts"
+	scrollHeights(server, [2]int{0, 1000}, [2]int{280, 1000})
+	scrape := func(ids ...int) mockserver.Response {
+		posts := make([]string, len(ids))
+		for i, id := range ids {
+			posts[i] = fmt.Sprintf(`{"id":%d}`, id)
+		}
+		return mockserver.JSONResponse(200, `{"markdown":"","structured":{"data":{"posts":[`+strings.Join(posts, ",")+`]},"success":true}}`)
+	}
+	server.AddSequence("/sessions/"+pageSessionIDTest+"/page/scrape", scrape(1, 2), scrape(2, 3))
+
+	result, err := runScrollUntilEndTest(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := json.Marshal(result.Data)
+	if result.Items != 3 || string(data) != `{"posts":[{"id":1},{"id":2},{"id":3}]}` {
+		t.Errorf("unexpected merged data (%d items): %s", result.Items, data)
+	}
+	if reqs := server.Requests("/sessions/" + pageSessionIDTest + "/page/scrape"); len(reqs) != 2 || !strings.Contains(reqs[0].Body, "Extract the posts") {
+		t.Errorf("expected a scrape before and after the scroll, got %+v", reqs)
+	}
+}
+
+func TestRunPageScrollUntilEnd_InvalidWaitLoad(t *testing.T) {
+	_ = setupScrollUntilEndTest(t)
+	pageScrollWaitLoad = "idle"
+
+	if _, err := runScrollUntilEndTest(t); err == nil || !strings.Contains(err.Error(), "--wait-load") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
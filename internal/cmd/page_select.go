@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// Ways page select can match dropdown options
+const (
+	selectByValue = "value"
+	selectByLabel = "label"
+	selectByIndex = "index"
+)
+
+var selectByModes = []string{selectByValue, selectByLabel, selectByIndex}
+
+// selectOptionsJS selects the options of a <select> matching the given
+// values, labels or indexes, replacing the current selection, and fires the
+// input and change events a user's choice would. It throws when an option is
+// missing so the action fails instead of silently selecting nothing.
+const selectOptionsJS = `(function (sel, by, values) {
+  const el = document.querySelector(sel);
+  if (!el) throw new Error('no element matches ' + sel);
+  if (el.tagName !== 'SELECT') throw new Error(sel + ' is not a <select> element');
+  if (values.length > 1 && !el.multiple) throw new Error(sel + ' only allows one option to be selected');
+  const options = Array.from(el.options);
+  const picked = values.map(function (v) {
+    let option;
+    if (by === 'label') option = options.find(function (o) { return o.label.trim() === v || o.text.trim() === v; });
+    else if (by === 'index') option = options[Number(v)];
+    else option = options.find(function (o) { return o.value === v; });
+    if (!option) throw new Error('no option with ' + by + ' ' + JSON.stringify(v) + ' in ' + sel);
+    return option;
+  });
+  options.forEach(function (o) { o.selected = picked.includes(o); });
+  el.dispatchEvent(new Event('input', { bubbles: true }));
+  el.dispatchEvent(new Event('change', { bubbles: true }));
+  return JSON.stringify(picked.map(function (o) { return o.value; }));
+})(%s, %s, %s)`
+
+// selectOptionsAction returns the page action selecting values in the
+// dropdown at target. A single value is selected with the API's
+// select_dropdown_option action, which also takes element IDs; labels,
+// indexes and multiple values are selected in the page, so they need a CSS
+// selector.
+func selectOptionsAction(target, by string, values []string) (map[string]any, error) {
+	if by == "" {
+		by = selectByValue
+	}
+	if !slices.Contains(selectByModes, by) {
+		return nil, i18n.Errorf(i18n.InvalidBy, by, strings.Join(selectByModes, ", "))
+	}
+	id, selector, err := parseSelector(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if by == selectByValue && len(values) == 1 {
+		action := map[string]any{"type": "select_dropdown_option", "value": values[0]}
+		if id != "" {
+			action["id"] = id
+		} else {
+			action["selector"] = selector
+		}
+		return action, nil
+	}
+
+	if id != "" {
+		return nil, i18n.Errorf(i18n.ElementIDNeedsSingleValue, target)
+	}
+	if by == selectByIndex {
+		for _, v := range values {
+			if i, err := strconv.Atoi(v); err != nil || i < 0 {
+				return nil, i18n.Errorf(i18n.InvalidOptionIndex, v)
+			}
+		}
+	}
+	args := make([]string, 0, 3)
+	for _, arg := range []any{selector, by, values} {
+		data, err := json.Marshal(arg)
+		if err != nil {
+			return nil, i18n.Errorf(i18n.FailedToMarshalSelectArguments, err)
+		}
+		args = append(args, string(data))
+	}
+	return map[string]any{
+		"type": "evaluate_js",
+		"code": fmt.Sprintf(selectOptionsJS, args[0], args[1], args[2]),
+	}, nil
+}
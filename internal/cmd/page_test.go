@@ -2,11 +2,17 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
+	"github.com/nottelabs/notte-cli/internal/api"
 	"github.com/nottelabs/notte-cli/internal/config"
 	"github.com/nottelabs/notte-cli/internal/testutil"
 )
@@ -22,9 +28,11 @@ func setupPageTest(t *testing.T) *testutil.MockServer {
 	t.Cleanup(func() { server.Close() })
 	env.SetEnv("NOTTE_API_URL", server.URL())
 
-	origID := sessionID
-	sessionID = pageSessionIDTest
-	t.Cleanup(func() { sessionID = origID })
+	// Keep cached page state out of the real config dir
+	config.SetTestConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+
+	env.SetEnv("NOTTE_SESSION_ID", pageSessionIDTest)
 
 	origFormat := outputFormat
 	outputFormat = "json"
@@ -271,6 +279,70 @@ func TestRunPageSelect(t *testing.T) {
 	}
 }
 
+func TestRunPageSelect_ByLabel(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, pageExecResponse())
+	origBy := pageSelectBy
+	t.Cleanup(func() { pageSelectBy = origBy })
+	pageSelectBy = selectByLabel
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	_, _ = testutil.CaptureOutput(func() {
+		if err := runPageSelect(cmd, []string{"#toppings", "Extra cheese", "Olives"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	reqs := server.Requests("/sessions/" + pageSessionIDTest + "/page/execute")
+	if len(reqs) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(reqs))
+	}
+	var action map[string]any
+	if err := json.Unmarshal([]byte(reqs[0].Body), &action); err != nil {
+		t.Fatalf("invalid request body: %v", err)
+	}
+	code, _ := action["code"].(string)
+	if action["type"] != "evaluate_js" || !strings.Contains(code, `("#toppings", "label", ["Extra cheese","Olives"])`) {
+		t.Errorf("unexpected action: %v", action)
+	}
+}
+
+func TestSelectOptionsAction(t *testing.T) {
+	action, err := selectOptionsAction("I4", "", []string{"US"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if action["type"] != "select_dropdown_option" || action["id"] != "I4" || action["value"] != "US" {
+		t.Errorf("a single value should use the API action, got %v", action)
+	}
+
+	action, err = selectOptionsAction("#country", selectByIndex, []string{"2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if action["type"] != "evaluate_js" {
+		t.Errorf("an index should be selected in the page, got %v", action)
+	}
+
+	errorTests := []struct {
+		target, by string
+		values     []string
+		want       string
+	}{
+		{"#country", "text", []string{"US"}, `invalid --by "text"`},
+		{"I4", selectByLabel, []string{"United States"}, "element IDs only work with a single value"},
+		{"I4", selectByValue, []string{"a", "b"}, "element IDs only work with a single value"},
+		{"#country", selectByIndex, []string{"-1"}, `invalid option index "-1"`},
+		{"#country", selectByIndex, []string{"first"}, `invalid option index "first"`},
+	}
+	for _, tt := range errorTests {
+		if _, err := selectOptionsAction(tt.target, tt.by, tt.values); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("selectOptionsAction(%q, %q, %v): expected %q, got %v", tt.target, tt.by, tt.values, tt.want, err)
+		}
+	}
+}
+
 func TestRunPageDownload(t *testing.T) {
 	server := setupPageTest(t)
 	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, pageExecResponse())
@@ -293,15 +365,20 @@ func TestRunPageDownload(t *testing.T) {
 func TestRunPageUpload(t *testing.T) {
 	server := setupPageTest(t)
 	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, pageExecResponse())
+	server.AddResponse("/storage/uploads/file.pdf", 200, `{"success":true}`)
 
+	path := filepath.Join(t.TempDir(), "file.pdf")
+	if err := os.WriteFile(path, []byte("%PDF-1.4"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
 	origFile := pageUploadFile
-	pageUploadFile = "/path/to/file.pdf"
+	pageUploadFile = path
 	t.Cleanup(func() { pageUploadFile = origFile })
 
 	cmd := &cobra.Command{}
 	cmd.SetContext(context.Background())
 
-	stdout, _ := testutil.CaptureOutput(func() {
+	stdout, stderr := testutil.CaptureOutput(func() {
 		err := runPageUpload(cmd, []string{"#file-input"})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -311,6 +388,83 @@ func TestRunPageUpload(t *testing.T) {
 	if stdout == "" {
 		t.Error("expected output, got empty string")
 	}
+	if !strings.Contains(stderr, "Uploaded file.pdf to storage") {
+		t.Errorf("expected an upload message, got %q", stderr)
+	}
+	if n := len(server.Requests("/storage/uploads/file.pdf")); n != 1 {
+		t.Errorf("expected the file to be uploaded to storage first, got %d uploads", n)
+	}
+	reqs := server.Requests("/sessions/" + pageSessionIDTest + "/page/execute")
+	if len(reqs) != 1 || !strings.Contains(reqs[0].Body, `"file_path":"file.pdf"`) {
+		t.Errorf("expected the action to use the storage name, got %+v", reqs)
+	}
+}
+
+func TestRunPageUpload_StorageName(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, pageExecResponse())
+	server.AddResponse("/storage/uploads", 200, `{"files":[{"name":"resume.pdf","file_ext":".pdf","size":5}]}`)
+
+	origFile := pageUploadFile
+	t.Cleanup(func() { pageUploadFile = origFile })
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	pageUploadFile = "resume.pdf"
+	_, _ = testutil.CaptureOutput(func() {
+		if err := runPageUpload(cmd, []string{"I3"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	reqs := server.Requests("/sessions/" + pageSessionIDTest + "/page/execute")
+	if len(reqs) != 1 || !strings.Contains(reqs[0].Body, `"file_path":"resume.pdf"`) {
+		t.Errorf("expected the uploaded file to be used, got %+v", reqs)
+	}
+
+	pageUploadFile = "resmue.pdf"
+	err := runPageUpload(cmd, []string{"I3"})
+	if err == nil || !strings.Contains(err.Error(), "file not found: resmue.pdf") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if n := len(server.Requests("/sessions/" + pageSessionIDTest + "/page/execute")); n != 1 {
+		t.Errorf("a missing file should fail before the action, got %d actions", n)
+	}
+}
+
+func TestRunPageUpload_InvalidFile(t *testing.T) {
+	server := setupPageTest(t)
+	dir := t.TempDir()
+	large := filepath.Join(dir, "large.bin")
+	f, err := os.Create(large)
+	if err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	if err := f.Truncate(maxUploadFileSize + 1); err != nil {
+		t.Fatalf("failed to size file: %v", err)
+	}
+	_ = f.Close()
+
+	origFile := pageUploadFile
+	t.Cleanup(func() { pageUploadFile = origFile })
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	tests := []struct {
+		file, want string
+	}{
+		{filepath.Join(dir, "missing.pdf"), "file not found"},
+		{dir, "path is a directory"},
+		{large, "above the 100 MB upload limit"},
+	}
+	for _, tt := range tests {
+		pageUploadFile = tt.file
+		if err := runPageUpload(cmd, []string{"#file-input"}); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("--file %s: expected %q, got %v", tt.file, tt.want, err)
+		}
+	}
+	if n := len(server.AllRequests()); n != 0 {
+		t.Errorf("invalid files should fail before any API call, got %d", n)
+	}
 }
 
 // Navigation Actions Tests
@@ -526,6 +680,48 @@ func TestRunPageSwitchTab(t *testing.T) {
 	}
 }
 
+func TestListTabs(t *testing.T) {
+	snap := &observeSnapshot{Metadata: api.SnapshotMetadata{
+		Title: "Cart",
+		Url:   "https://shop.example.com/cart",
+		Tabs: []api.TabsData{
+			{TabId: 3, Title: "Home", Url: "https://shop.example.com"},
+			{TabId: 7, Title: "Cart", Url: "https://shop.example.com/cart"},
+		},
+	}}
+
+	tabs := listTabs(snap)
+	if len(tabs) != 2 || tabs[1].Index != 1 || tabs[1].URL != "https://shop.example.com/cart" {
+		t.Fatalf("unexpected tabs: %+v", tabs)
+	}
+	if tabs[0].Active || !tabs[1].Active {
+		t.Errorf("expected the observed page's tab to be active: %+v", tabs)
+	}
+}
+
+func TestRunPageTabs(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/observe", 200, observeResponseJSON("page"))
+
+	origFresh := pageTabsFresh
+	t.Cleanup(func() { pageTabsFresh = origFresh })
+	pageTabsFresh = false
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runPageTabs(newPageTestCmd(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var tabs []pageTab
+	if err := json.Unmarshal([]byte(stdout), &tabs); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", stdout, err)
+	}
+	if len(tabs) != 1 || tabs[0].Index != 0 || tabs[0].Title != "Tab" || !tabs[0].Active {
+		t.Errorf("unexpected tabs: %+v", tabs)
+	}
+}
+
 func TestRunPageSwitchTab_InvalidIndex(t *testing.T) {
 	_ = setupPageTest(t)
 
@@ -692,6 +888,30 @@ func TestRunPageFormFill(t *testing.T) {
 	}
 }
 
+func TestRunPageFormFill_FromStdin(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, pageExecResponse())
+
+	origData := pageFormFillData
+	pageFormFillData = "-"
+	t.Cleanup(func() { pageFormFillData = origData })
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.SetIn(strings.NewReader(`{"first_name": "John"}`))
+
+	testutil.CaptureOutput(func() {
+		if err := runPageFormFill(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	reqs := server.Requests("/sessions/" + pageSessionIDTest + "/page/execute")
+	if len(reqs) != 1 || !strings.Contains(reqs[0].Body, `"first_name":"John"`) {
+		t.Errorf("unexpected requests: %+v", reqs)
+	}
+}
+
 func TestRunPageFormFill_InvalidKeys(t *testing.T) {
 	_ = setupPageTest(t)
 
@@ -748,11 +968,6 @@ func TestPageCommand_NoSessionID(t *testing.T) {
 	config.SetTestConfigDir(env.TempDir)
 	t.Cleanup(func() { config.SetTestConfigDir("") })
 
-	// Clear sessionID
-	origID := sessionID
-	sessionID = ""
-	t.Cleanup(func() { sessionID = origID })
-
 	cmd := &cobra.Command{}
 	cmd.SetContext(context.Background())
 
@@ -764,3 +979,53 @@ func TestPageCommand_NoSessionID(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestDeprecatedSessionsPageCommands(t *testing.T) {
+	for _, tt := range []struct {
+		old, page *cobra.Command
+	}{
+		{sessionsObserveCmd, pageObserveCmd},
+		{sessionsExecuteCmd, pageExecuteCmd},
+		{sessionsScrapeCmd, pageScrapeCmd},
+	} {
+		if tt.old.Deprecated == "" || !strings.Contains(tt.old.Deprecated, "notte page "+tt.page.Name()) {
+			t.Errorf("sessions %s: deprecation = %q", tt.old.Name(), tt.old.Deprecated)
+		}
+		// --session-id is the one flag each defines its own way
+		names := func(c *cobra.Command) []string {
+			var names []string
+			c.LocalFlags().VisitAll(func(f *pflag.Flag) {
+				if f.Name != "session-id" {
+					names = append(names, f.Name)
+				}
+			})
+			return names
+		}
+		oldFlags, pageFlags := names(tt.old), names(tt.page)
+		if !slices.Equal(oldFlags, pageFlags) {
+			t.Errorf("sessions %s flags %v differ from page %s flags %v", tt.old.Name(), oldFlags, tt.page.Name(), pageFlags)
+		}
+	}
+}
+
+func TestRunPageExecute(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddResponse("/sessions/"+pageSessionIDTest+"/page/execute", 200, pageExecResponse())
+
+	origAction := sessionExecuteAction
+	t.Cleanup(func() { sessionExecuteAction = origAction })
+	sessionExecuteAction = `{"type":"scroll_down","amount":300}`
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSessionExecute(newPageTestCmd(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, `"success":true`) {
+		t.Errorf("unexpected output: %s", stdout)
+	}
+	reqs := server.Requests("/sessions/" + pageSessionIDTest + "/page/execute")
+	if len(reqs) != 1 || !strings.Contains(reqs[0].Body, `"amount":300`) {
+		t.Errorf("unexpected requests: %+v", reqs)
+	}
+}
@@ -1,7 +1,7 @@
 package cmd
 
 import (
-	"fmt"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 
 	"github.com/spf13/cobra"
 )
@@ -15,7 +15,7 @@ func getPageFlag(cmd *cobra.Command) (*int, error) {
 	if cmd.Flags().Changed("page") {
 		v, _ := cmd.Flags().GetInt("page")
 		if v < 1 {
-			return nil, fmt.Errorf("--page must be >= 1 (got %d)", v)
+			return nil, i18n.Errorf(i18n.PageTooSmall, v)
 		}
 		return &v, nil
 	}
@@ -26,7 +26,7 @@ func getPageSizeFlag(cmd *cobra.Command) (*int, error) {
 	if cmd.Flags().Changed("page-size") {
 		v, _ := cmd.Flags().GetInt("page-size")
 		if v < 1 {
-			return nil, fmt.Errorf("--page-size must be >= 1 (got %d)", v)
+			return nil, i18n.Errorf(i18n.PageSizeTooSmall, v)
 		}
 		return &v, nil
 	}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/objstore"
+)
+
+// windowsMaxPath is MAX_PATH; longer paths need the \\?\ prefix on Windows
+const windowsMaxPath = 260
+
+// windowsReservedNames can't be used as file names on Windows, with or
+// without an extension
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// sanitizeFilename turns a server-provided name into a safe file name for
+// this OS: no directories, drive letters or traversal, and on Windows no
+// characters or names that NTFS rejects
+func sanitizeFilename(filename string) string {
+	return sanitizeFilenameFor(runtime.GOOS, filename)
+}
+
+func sanitizeFilenameFor(goos, filename string) string {
+	// Keep only the last element, whichever separator the server used
+	if i := strings.LastIndexAny(filename, `/\`); i >= 0 {
+		filename = filename[i+1:]
+	}
+
+	if goos == "windows" {
+		// A leading drive letter ("C:name") is relative to that drive's cwd
+		if len(filename) >= 2 && filename[1] == ':' && isASCIILetter(filename[0]) {
+			filename = filename[2:]
+		}
+		filename = strings.Map(func(r rune) rune {
+			if r < 0x20 || strings.ContainsRune(`<>:"|?*`, r) {
+				return '_'
+			}
+			return r
+		}, filename)
+		// Explorer and most APIs silently drop trailing dots and spaces
+		filename = strings.TrimRight(filename, ". ")
+		stem, _, _ := strings.Cut(filename, ".")
+		if windowsReservedNames[strings.ToUpper(strings.TrimSpace(stem))] {
+			filename = "_" + filename
+		}
+	}
+
+	if filename == "" || filename == "." || filename == ".." {
+		return "unnamed"
+	}
+	return filename
+}
+
+func isASCIILetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// prepareOutputPath cleans a user-given output file path and creates its
+// parent directory. Open the result through longPath.
+func prepareOutputPath(path string) (string, error) {
+	path = filepath.Clean(path)
+	if err := os.MkdirAll(longPath(filepath.Dir(path)), 0o755); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// longPath returns path in a form Windows accepts past MAX_PATH. Other
+// systems have no such limit, so the path is returned as is.
+func longPath(path string) string {
+	if runtime.GOOS != "windows" {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return extendedLengthPath(abs)
+}
+
+// extendedLengthPath adds the \\?\ prefix to an absolute Windows path that
+// is too long for the regular APIs
+func extendedLengthPath(abs string) string {
+	if len(abs) < windowsMaxPath || strings.HasPrefix(abs, `\\?\`) {
+		return abs
+	}
+	if strings.HasPrefix(abs, `\\`) {
+		return `\\?\UNC\` + abs[2:]
+	}
+	return `\\?\` + abs
+}
+
+// writeOutputFile writes data to a local path, or uploads it when dest is an
+// s3:// or gs:// URL, and returns where the data went
+func writeOutputFile(ctx context.Context, dest string, data []byte, perm os.FileMode) (string, error) {
+	if objstore.IsURL(dest) {
+		return dest, objstore.Put(ctx, dest, bytes.NewReader(data), mime.TypeByExtension(path.Ext(dest)))
+	}
+	dest, err := prepareOutputPath(dest)
+	if err != nil {
+		return "", i18n.Errorf(i18n.FailedToCreateDirectory, err)
+	}
+	if err := os.WriteFile(longPath(dest), data, perm); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// writeOutputStream is writeOutputFile for data read from r, which is
+// copied to the file or uploaded as it's read rather than held in memory.
+// A local file left incomplete by a failed read is removed.
+func writeOutputStream(ctx context.Context, dest string, r io.Reader, perm os.FileMode) (string, error) {
+	if objstore.IsURL(dest) {
+		return dest, objstore.Put(ctx, dest, r, mime.TypeByExtension(path.Ext(dest)))
+	}
+	dest, err := prepareOutputPath(dest)
+	if err != nil {
+		return "", i18n.Errorf(i18n.FailedToCreateDirectory, err)
+	}
+	f, err := os.OpenFile(longPath(dest), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(longPath(dest))
+		return "", err
+	}
+	return dest, nil
+}
+
+// joinOutputPath is filepath.Join for local directories and object URL
+// prefixes alike
+func joinOutputPath(dir, name string) string {
+	if objstore.IsURL(dir) {
+		return objstore.Join(dir, name)
+	}
+	return filepath.Join(dir, name)
+}
+
+// isOutputDir reports whether dest names an object URL prefix rather than
+// a file, so a default file name should be added
+func isOutputDir(dest string) bool {
+	return objstore.IsURL(dest) && objstore.IsPrefix(dest)
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSanitizeFilenameFor(t *testing.T) {
+	tests := []struct {
+		goos string
+		in   string
+		want string
+	}{
+		{"linux", "batch-1.json", "batch-1.json"},
+		{"linux", "../../etc/passwd", "passwd"},
+		{"linux", `..\..\Windows\win.ini`, "win.ini"},
+		{"linux", "logs/2025-01-01T10:00:00.json", "2025-01-01T10:00:00.json"},
+		{"linux", "..", "unnamed"},
+		{"linux", "dir/", "unnamed"},
+		{"windows", "logs/2025-01-01T10:00:00.json", "2025-01-01T10_00_00.json"},
+		{"windows", `C:\Users\me\file.json`, "file.json"},
+		{"windows", "C:file.json", "file.json"},
+		{"windows", `what?<is>"this"|*.har`, "what__is__this___.har"},
+		{"windows", "CON", "_CON"},
+		{"windows", "nul.json", "_nul.json"},
+		{"windows", "com1.tar.gz", "_com1.tar.gz"},
+		{"windows", "console.json", "console.json"},
+		{"windows", "trailing. . ", "trailing"},
+		{"windows", "tab\there", "tab_here"},
+	}
+	for _, tt := range tests {
+		if got := sanitizeFilenameFor(tt.goos, tt.in); got != tt.want {
+			t.Errorf("sanitizeFilenameFor(%s, %q) = %q, want %q", tt.goos, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExtendedLengthPath(t *testing.T) {
+	long := `C:\` + strings.Repeat("a", windowsMaxPath)
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`C:\short\file.json`, `C:\short\file.json`},
+		{long, `\\?\` + long},
+		{`\\?\` + long, `\\?\` + long},
+		{`\\server\share\` + strings.Repeat("a", windowsMaxPath), `\\?\UNC\server\share\` + strings.Repeat("a", windowsMaxPath)},
+	}
+	for _, tt := range tests {
+		if got := extendedLengthPath(tt.in); got != tt.want {
+			t.Errorf("extendedLengthPath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPrepareOutputPath(t *testing.T) {
+	dir := t.TempDir()
+
+	got, err := prepareOutputPath(filepath.Join(dir, "a", "..", "b", "shot.jpg"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join(dir, "b", "shot.jpg"); got != want {
+		t.Errorf("path = %q, want %q", got, want)
+	}
+	if info, err := os.Stat(filepath.Join(dir, "b")); err != nil || !info.IsDir() {
+		t.Errorf("expected parent directory to be created: %v", err)
+	}
+}
+
+func TestJoinOutputPath(t *testing.T) {
+	if got := joinOutputPath("s3://bucket/shots/", "a.jpg"); got != "s3://bucket/shots/a.jpg" {
+		t.Errorf("object URL join = %q", got)
+	}
+	if got := joinOutputPath("shots", "a.jpg"); got != filepath.Join("shots", "a.jpg") {
+		t.Errorf("local join = %q", got)
+	}
+	if !isOutputDir("gs://bucket/shots/") || isOutputDir("gs://bucket/shot.jpg") || isOutputDir("shots/") {
+		t.Error("isOutputDir misclassified a destination")
+	}
+}
+
+func TestWriteOutputStream(t *testing.T) {
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "replays", "a.mp4")
+
+	got, err := writeOutputStream(context.Background(), dest, strings.NewReader("video"), 0o644)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(got); string(data) != "video" {
+		t.Errorf("file holds %q, want video", data)
+	}
+
+	failing := io.MultiReader(strings.NewReader("vid"), iotest.ErrReader(errors.New("connection reset")))
+	if _, err := writeOutputStream(context.Background(), dest, failing, 0o644); err == nil {
+		t.Fatal("expected the read error")
+	}
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Errorf("an incomplete file should be removed, got %v", err)
+	}
+}
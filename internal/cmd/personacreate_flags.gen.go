@@ -19,7 +19,9 @@ var (
 // RegisterPersonaCreateFlags registers all flags for PersonaCreate command
 func RegisterPersonaCreateFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&PersonaCreateCreatePhoneNumber, "create-phone-number", false, "Whether to create a phone number for the persona")
+	_ = cmd.Flags().SetAnnotation("create-phone-number", flagFieldAnnotation, []string{"create_phone_number"})
 	cmd.Flags().BoolVar(&PersonaCreateCreateVault, "create-vault", false, "Whether to create a vault for the persona")
+	_ = cmd.Flags().SetAnnotation("create-vault", flagFieldAnnotation, []string{"create_vault"})
 }
 
 // BuildPersonaCreateRequest builds the API request from CLI flags
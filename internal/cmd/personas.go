@@ -1,11 +1,10 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
 var personaID string
@@ -17,9 +16,10 @@ var personasCmd = &cobra.Command{
 }
 
 var personasListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all personas",
-	RunE:  runPersonasList,
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all personas",
+	RunE:    runPersonasList,
 }
 
 var personasCreateCmd = &cobra.Command{
@@ -36,10 +36,11 @@ var personasShowCmd = &cobra.Command{
 }
 
 var personasDeleteCmd = &cobra.Command{
-	Use:   "delete",
-	Short: "Delete the persona",
-	Args:  cobra.NoArgs,
-	RunE:  runPersonaDelete,
+	Use:     "delete",
+	Aliases: []string{"rm"},
+	Short:   "Delete the persona",
+	Args:    cobra.NoArgs,
+	RunE:    runPersonaDelete,
 }
 
 var personasEmailsCmd = &cobra.Command{
@@ -60,6 +61,7 @@ func init() {
 	rootCmd.AddCommand(personasCmd)
 	personasCmd.AddCommand(personasListCmd)
 	registerPaginationFlags(personasListCmd)
+	registerScopeFlag(personasListCmd)
 	personasListCmd.Flags().Bool("only-active", false, "Only return active personas")
 
 	personasCmd.AddCommand(personasCreateCmd)
@@ -78,6 +80,7 @@ func init() {
 	// Delete command flags
 	personasDeleteCmd.Flags().StringVar(&personaID, "persona-id", "", "Persona ID (required)")
 	_ = personasDeleteCmd.MarkFlagRequired("persona-id")
+	addStdinIDsFlag(personasDeleteCmd, "persona-id")
 
 	// Emails command flags
 	personasEmailsCmd.Flags().StringVar(&personaID, "persona-id", "", "Persona ID (required)")
@@ -105,9 +108,14 @@ func runPersonasList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	scope, onlyCurrentToken, err := getScopeFlag(cmd)
+	if err != nil {
+		return err
+	}
 	params := &api.ListPersonasParams{
-		Page:     page,
-		PageSize: pageSize,
+		Page:             page,
+		PageSize:         pageSize,
+		OnlyCurrentToken: onlyCurrentToken,
 	}
 	if cmd.Flags().Changed("only-active") {
 		v, _ := cmd.Flags().GetBool("only-active")
@@ -115,7 +123,7 @@ func runPersonasList(cmd *cobra.Command, args []string) error {
 	}
 	resp, err := client.Client().ListPersonasWithResponse(ctx, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -134,6 +142,33 @@ func runPersonasList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if scope != "" {
+		owned, err := scopeOwnedIDs(scope, func(page *int) ([]string, bool, error) {
+			mine := *params
+			mine.Page, mine.OnlyCurrentToken = page, boolPtr(true)
+			r, err := client.Client().ListPersonasWithResponse(ctx, &mine)
+			if err == nil {
+				err = HandleAPIResponse(r.HTTPResponse, r.Body)
+			}
+			if err != nil || r.JSON200 == nil {
+				return nil, false, err
+			}
+			ids := make([]string, len(r.JSON200.Items))
+			for i, item := range r.JSON200.Items {
+				ids[i] = item.PersonaId
+			}
+			return ids, r.JSON200.HasNext, nil
+		})
+		if err != nil {
+			return err
+		}
+		rows := make([]ownedPersona, len(items))
+		for i, item := range items {
+			rows[i] = ownedPersona{Owner: ownerOf(scope, owned, item.PersonaId), PersonaResponse: item}
+		}
+		return formatter.Print(rows)
+	}
+
 	return formatter.Print(items)
 }
 
@@ -155,7 +190,7 @@ func runPersonasCreate(cmd *cobra.Command, args []string) error {
 	params := &api.PersonaCreateParams{}
 	resp, err := client.Client().PersonaCreateWithResponse(ctx, params, *body)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -178,7 +213,7 @@ func runPersonaShow(cmd *cobra.Command, args []string) error {
 	params := &api.PersonaGetParams{}
 	resp, err := client.Client().PersonaGetWithResponse(ctx, personaID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -189,12 +224,16 @@ func runPersonaShow(cmd *cobra.Command, args []string) error {
 }
 
 func runPersonaDelete(cmd *cobra.Command, args []string) error {
+	if err := checkProtected("persona", personaID); err != nil {
+		return err
+	}
+
 	confirmed, err := ConfirmAction("persona", personaID)
 	if err != nil {
 		return err
 	}
 	if !confirmed {
-		return PrintResult("Cancelled.", map[string]any{"cancelled": true})
+		return PrintResult(i18n.T(i18n.Cancelled), map[string]any{"cancelled": true})
 	}
 
 	client, err := GetClient()
@@ -208,14 +247,14 @@ func runPersonaDelete(cmd *cobra.Command, args []string) error {
 	params := &api.PersonaDeleteParams{}
 	resp, err := client.Client().PersonaDeleteWithResponse(ctx, personaID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
 		return err
 	}
 
-	return PrintResult(fmt.Sprintf("Persona %s deleted.", personaID), map[string]any{
+	return PrintResult(i18n.T(i18n.PersonaDeleted, personaID), map[string]any{
 		"id":     personaID,
 		"status": "deleted",
 	})
@@ -233,7 +272,7 @@ func runPersonaEmails(cmd *cobra.Command, args []string) error {
 	params := &api.PersonaEmailsListParams{}
 	resp, err := client.Client().PersonaEmailsListWithResponse(ctx, personaID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -255,7 +294,7 @@ func runPersonaSms(cmd *cobra.Command, args []string) error {
 	params := &api.PersonaSmsListParams{}
 	resp, err := client.Client().PersonaSmsListWithResponse(ctx, personaID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
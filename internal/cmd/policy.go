@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// loadPolicy returns the policy from the config file, or nil when there is none
+func loadPolicy() (*config.PolicyConfig, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, i18n.Errorf(i18n.FailedToLoadConfig, err)
+	}
+	return cfg.Policy, nil
+}
+
+// checkForbidden fails if the policy forbids cmd or one of its parent groups.
+// Aliases resolve to the canonical command, so "vaults rm" matches
+// "vaults delete".
+func checkForbidden(cmd *cobra.Command) error {
+	policy, err := loadPolicy()
+	if err != nil || policy == nil {
+		return err
+	}
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	for _, rule := range policy.Forbid {
+		rule = strings.Join(strings.Fields(rule), " ")
+		if rule != "" && (path == rule || strings.HasPrefix(path, rule+" ")) {
+			return i18n.Errorf(i18n.CommandForbiddenByPolicy, path, rule)
+		}
+	}
+	return nil
+}
+
+// checkProtected fails if the policy protects id. Destructive commands call
+// it before asking for confirmation, so --yes doesn't bypass it.
+func checkProtected(resource, id string) error {
+	policy, err := loadPolicy()
+	if err != nil || policy == nil {
+		return err
+	}
+	if slices.Contains(policy.Protect, id) {
+		return i18n.Errorf(i18n.ProtectedByPolicy, resource, id)
+	}
+	return nil
+}
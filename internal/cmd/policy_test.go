@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func setTestPolicy(t *testing.T, policy *config.PolicyConfig) {
+	t.Helper()
+	config.SetTestConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+
+	cfg := &config.Config{Policy: policy}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+}
+
+func TestCheckForbidden(t *testing.T) {
+	setTestPolicy(t, &config.PolicyConfig{Forbid: []string{"vaults delete", " functions  secrets "}})
+
+	if err := checkForbidden(vaultsDeleteCmd); err == nil || !strings.Contains(err.Error(), "notte vaults delete") {
+		t.Errorf("expected vaults delete to be forbidden, got %v", err)
+	}
+	if err := checkForbidden(functionSecretsDeleteCmd); err == nil {
+		t.Error("expected a forbidden group to cover its subcommands")
+	}
+	if err := checkForbidden(vaultsListCmd); err != nil {
+		t.Errorf("unexpected error for vaults list: %v", err)
+	}
+}
+
+func TestCheckForbidden_NoPolicy(t *testing.T) {
+	setTestPolicy(t, nil)
+
+	if err := checkForbidden(vaultsDeleteCmd); err != nil {
+		t.Errorf("unexpected error without a policy: %v", err)
+	}
+	if err := checkProtected("persona", "persona_1"); err != nil {
+		t.Errorf("unexpected error without a policy: %v", err)
+	}
+}
+
+func TestRunPersonaDelete_Protected(t *testing.T) {
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+
+	server := testutil.NewMockServer()
+	defer server.Close()
+	env.SetEnv("NOTTE_API_URL", server.URL())
+	server.AddResponse("/personas/persona_keep", 200, `{}`)
+
+	setTestPolicy(t, &config.PolicyConfig{Protect: []string{"persona_keep"}})
+
+	// --yes must not bypass the policy
+	SetSkipConfirmation(true)
+	t.Cleanup(func() { SetSkipConfirmation(false) })
+
+	origID := personaID
+	personaID = "persona_keep"
+	t.Cleanup(func() { personaID = origID })
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	err := runPersonaDelete(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "persona persona_keep is protected") {
+		t.Fatalf("expected protected persona error, got %v", err)
+	}
+	if reqs := server.Requests("/personas/persona_keep"); len(reqs) != 0 {
+		t.Errorf("expected no API request, got %d", len(reqs))
+	}
+}
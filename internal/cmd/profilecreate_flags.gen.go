@@ -16,6 +16,7 @@ var (
 // RegisterProfileCreateFlags registers all flags for ProfileCreate command
 func RegisterProfileCreateFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&ProfileCreateName, "name", "", "Name of the profile")
+	_ = cmd.Flags().SetAnnotation("name", flagFieldAnnotation, []string{"name"})
 }
 
 // BuildProfileCreateRequest builds the API request from CLI flags
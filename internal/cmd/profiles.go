@@ -1,11 +1,10 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
 var profileID string
@@ -17,9 +16,10 @@ var profilesCmd = &cobra.Command{
 }
 
 var profilesListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List profiles",
-	RunE:  runProfilesList,
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List profiles",
+	RunE:    runProfilesList,
 }
 
 var profilesCreateCmd = &cobra.Command{
@@ -36,16 +36,18 @@ var profilesShowCmd = &cobra.Command{
 }
 
 var profilesDeleteCmd = &cobra.Command{
-	Use:   "delete",
-	Short: "Delete profile",
-	Args:  cobra.NoArgs,
-	RunE:  runProfileDelete,
+	Use:     "delete",
+	Aliases: []string{"rm"},
+	Short:   "Delete profile",
+	Args:    cobra.NoArgs,
+	RunE:    runProfileDelete,
 }
 
 func init() {
 	rootCmd.AddCommand(profilesCmd)
 	profilesCmd.AddCommand(profilesListCmd)
 	registerPaginationFlags(profilesListCmd)
+	registerScopeFlag(profilesListCmd)
 	profilesListCmd.Flags().String("name", "", "Filter profiles by name")
 
 	profilesCmd.AddCommand(profilesCreateCmd)
@@ -62,6 +64,7 @@ func init() {
 	// Delete command flags
 	profilesDeleteCmd.Flags().StringVar(&profileID, "profile-id", "", "Profile ID (required)")
 	_ = profilesDeleteCmd.MarkFlagRequired("profile-id")
+	addStdinIDsFlag(profilesDeleteCmd, "profile-id")
 }
 
 func runProfilesList(cmd *cobra.Command, args []string) error {
@@ -81,9 +84,14 @@ func runProfilesList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	scope, onlyCurrentToken, err := getScopeFlag(cmd)
+	if err != nil {
+		return err
+	}
 	params := &api.ProfileListParams{
-		Page:     page,
-		PageSize: pageSize,
+		Page:             page,
+		PageSize:         pageSize,
+		OnlyCurrentToken: onlyCurrentToken,
 	}
 	if cmd.Flags().Changed("name") {
 		v, _ := cmd.Flags().GetString("name")
@@ -91,7 +99,7 @@ func runProfilesList(cmd *cobra.Command, args []string) error {
 	}
 	resp, err := client.Client().ProfileListWithResponse(ctx, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -110,6 +118,33 @@ func runProfilesList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if scope != "" {
+		owned, err := scopeOwnedIDs(scope, func(page *int) ([]string, bool, error) {
+			mine := *params
+			mine.Page, mine.OnlyCurrentToken = page, boolPtr(true)
+			r, err := client.Client().ProfileListWithResponse(ctx, &mine)
+			if err == nil {
+				err = HandleAPIResponse(r.HTTPResponse, r.Body)
+			}
+			if err != nil || r.JSON200 == nil {
+				return nil, false, err
+			}
+			ids := make([]string, len(r.JSON200.Items))
+			for i, item := range r.JSON200.Items {
+				ids[i] = item.ProfileId
+			}
+			return ids, r.JSON200.HasNext, nil
+		})
+		if err != nil {
+			return err
+		}
+		rows := make([]ownedProfile, len(items))
+		for i, item := range items {
+			rows[i] = ownedProfile{Owner: ownerOf(scope, owned, item.ProfileId), ProfileResponse: item}
+		}
+		return formatter.Print(rows)
+	}
+
 	return formatter.Print(items)
 }
 
@@ -131,7 +166,7 @@ func runProfilesCreate(cmd *cobra.Command, args []string) error {
 	params := &api.ProfileCreateParams{}
 	resp, err := client.Client().ProfileCreateWithResponse(ctx, params, *body)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -154,7 +189,7 @@ func runProfileShow(cmd *cobra.Command, args []string) error {
 	params := &api.ProfileGetParams{}
 	resp, err := client.Client().ProfileGetWithResponse(ctx, profileID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -165,12 +200,16 @@ func runProfileShow(cmd *cobra.Command, args []string) error {
 }
 
 func runProfileDelete(cmd *cobra.Command, args []string) error {
+	if err := checkProtected("profile", profileID); err != nil {
+		return err
+	}
+
 	confirmed, err := ConfirmAction("profile", profileID)
 	if err != nil {
 		return err
 	}
 	if !confirmed {
-		return PrintResult("Cancelled.", map[string]any{"cancelled": true})
+		return PrintResult(i18n.T(i18n.Cancelled), map[string]any{"cancelled": true})
 	}
 
 	client, err := GetClient()
@@ -184,14 +223,14 @@ func runProfileDelete(cmd *cobra.Command, args []string) error {
 	params := &api.ProfileDeleteParams{}
 	resp, err := client.Client().ProfileDeleteWithResponse(ctx, profileID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
 		return err
 	}
 
-	return PrintResult(fmt.Sprintf("Profile %s deleted.", profileID), map[string]any{
+	return PrintResult(i18n.T(i18n.ProfileDeleted, profileID), map[string]any{
 		"id":     profileID,
 		"status": "deleted",
 	})
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	apierrors "github.com/nottelabs/notte-cli/internal/errors"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// maxQueuedAttempts is how many times a batch job is tried before a
+// concurrency limit error from the API is reported as its failure
+const maxQueuedAttempts = 5
+
+// addMaxParallelFlag registers --max-parallel on a batch command
+func addMaxParallelFlag(cmd *cobra.Command, what string) {
+	cmd.Flags().Int("max-parallel", 0, fmt.Sprintf("Most %s at once (0: as many as the account allows)", what))
+}
+
+// concurrencyLimit caps how many jobs of a batch command run at once. The
+// API doesn't report an account's concurrency limit, so the cap starts at
+// --max-parallel (0 is no cap) and is lowered to the number of jobs in
+// flight whenever the API refuses one for being over the limit. The refused
+// job is queued until a slot frees up rather than failed.
+type concurrencyLimit struct {
+	mu     sync.Mutex
+	max    int
+	active int
+	// freed is closed, and replaced, whenever a slot is released
+	freed chan struct{}
+}
+
+func newConcurrencyLimit(maxParallel int) *concurrencyLimit {
+	return &concurrencyLimit{max: maxParallel, freed: make(chan struct{})}
+}
+
+// tryAcquire takes a slot if one is free
+func (l *concurrencyLimit) tryAcquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.max > 0 && l.active >= l.max {
+		return false
+	}
+	l.active++
+	return true
+}
+
+// acquire waits for a free slot
+func (l *concurrencyLimit) acquire(ctx context.Context) error {
+	for {
+		if l.tryAcquire() {
+			return nil
+		}
+		l.mu.Lock()
+		freed := l.freed
+		l.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-freed:
+		}
+	}
+}
+
+func (l *concurrencyLimit) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.active--
+	close(l.freed)
+	l.freed = make(chan struct{})
+}
+
+// limitReached lowers the cap to the jobs still running after the API
+// refused one, and returns that number. With none running, the cap is left
+// alone: the account's slots are taken by something else.
+func (l *concurrencyLimit) limitReached() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active > 0 && (l.max == 0 || l.active < l.max) {
+		l.max = l.active
+	}
+	return l.active
+}
+
+// concurrencyLimitError returns the API's refusal when err is one
+func concurrencyLimitError(err error) (*apierrors.RateLimitError, bool) {
+	var rl *apierrors.RateLimitError
+	if errors.As(err, &rl) {
+		return rl, true
+	}
+	return nil, false
+}
+
+// withConcurrencyLimit runs job in a slot of l. When the API refuses it for
+// being over the concurrency limit, the job waits for another job to finish,
+// or for the API's retry delay when none is running, and tries again.
+func withConcurrencyLimit(ctx context.Context, l *concurrencyLimit, name string, job func() error) error {
+	for attempt := 1; ; attempt++ {
+		if err := l.acquire(ctx); err != nil {
+			return err
+		}
+		err := job()
+		l.release()
+		rl, ok := concurrencyLimitError(err)
+		if !ok || attempt >= maxQueuedAttempts {
+			return err
+		}
+
+		if running := l.limitReached(); running > 0 {
+			PrintInfo(i18n.T(i18n.ConcurrencyLimitQueued, name, running))
+			continue
+		}
+		PrintInfo(i18n.T(i18n.ConcurrencyLimitRetrying, name, rl.RetryAfter))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(rl.RetryAfter):
+		}
+	}
+}
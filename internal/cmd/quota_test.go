@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	apierrors "github.com/nottelabs/notte-cli/internal/errors"
+)
+
+func TestConcurrencyLimit(t *testing.T) {
+	l := newConcurrencyLimit(2)
+	if !l.tryAcquire() || !l.tryAcquire() {
+		t.Fatal("expected two free slots")
+	}
+	if l.tryAcquire() {
+		t.Fatal("expected the limit to be reached")
+	}
+	l.release()
+	if got := l.limitReached(); got != 1 {
+		t.Errorf("limitReached = %d, want 1", got)
+	}
+	if l.tryAcquire() {
+		t.Error("the cap should have been lowered to the one running job")
+	}
+	l.release()
+	if got := l.limitReached(); got != 0 {
+		t.Errorf("limitReached = %d, want 0", got)
+	}
+	if !l.tryAcquire() {
+		t.Error("with nothing running, a job must be able to start")
+	}
+
+	unlimited := newConcurrencyLimit(0)
+	for range 10 {
+		if !unlimited.tryAcquire() {
+			t.Fatal("0 means no cap")
+		}
+	}
+}
+
+func TestWithConcurrencyLimit_QueuesRefusedJobs(t *testing.T) {
+	l := newConcurrencyLimit(0)
+	var mu sync.Mutex
+	running, peak, refused := 0, 0, 0
+
+	// The "API" accepts two jobs at a time
+	job := func() error {
+		mu.Lock()
+		if running >= 2 {
+			refused++
+			mu.Unlock()
+			return &apierrors.RateLimitError{RetryAfter: time.Millisecond}
+		}
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 6)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = withConcurrencyLimit(context.Background(), l, "job", job)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("job %d: unexpected error: %v", i, err)
+		}
+	}
+	if peak > 2 {
+		t.Errorf("peak = %d, want at most 2", peak)
+	}
+	if refused > 4*maxQueuedAttempts {
+		t.Errorf("too many refused attempts: %d", refused)
+	}
+}
+
+func TestWithConcurrencyLimit_GivesUp(t *testing.T) {
+	attempts := 0
+	err := withConcurrencyLimit(context.Background(), newConcurrencyLimit(0), "job", func() error {
+		attempts++
+		return &apierrors.RateLimitError{}
+	})
+	if _, ok := concurrencyLimitError(err); !ok {
+		t.Errorf("expected the concurrency limit error, got %v", err)
+	}
+	if attempts != maxQueuedAttempts {
+		t.Errorf("attempts = %d, want %d", attempts, maxQueuedAttempts)
+	}
+
+	attempts = 0
+	other := errors.New("boom")
+	if err := withConcurrencyLimit(context.Background(), newConcurrencyLimit(0), "job", func() error {
+		attempts++
+		return other
+	}); err != other || attempts != 1 {
+		t.Errorf("other errors should not be retried: %v after %d attempts", err, attempts)
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+)
+
+var (
+	rawOutput bool
+
+	// rawCapture is nil unless --raw is set
+	rawCapture *api.ResponseCapture
+	// rawStdout is the real stdout while command output is held back
+	rawStdout *os.File
+	rawHeld   *os.File
+)
+
+// initRawOutput starts capturing API responses and holds back everything
+// the command prints, so --raw works the same for every command
+func initRawOutput() error {
+	rawCapture = nil
+	if !rawOutput {
+		return nil
+	}
+
+	held, err := os.CreateTemp("", "notte-raw-*")
+	if err != nil {
+		return err
+	}
+	rawCapture = api.NewResponseCapture()
+	rawStdout, rawHeld = os.Stdout, held
+	os.Stdout = held
+	return nil
+}
+
+// flushRawOutput restores stdout and writes the body of the last API
+// response to it byte for byte. Commands that made no API call print their
+// normal output instead.
+func flushRawOutput() {
+	if rawHeld == nil {
+		return
+	}
+	held := rawHeld
+	os.Stdout = rawStdout
+	rawStdout, rawHeld = nil, nil
+	defer func() {
+		_ = held.Close()
+		_ = os.Remove(held.Name())
+	}()
+
+	if rawCapture != nil {
+		if body, ok := rawCapture.Last(); ok {
+			_, _ = os.Stdout.Write(body)
+			return
+		}
+	}
+	if _, err := held.Seek(0, io.SeekStart); err == nil {
+		_, _ = io.Copy(os.Stdout, held)
+	}
+}
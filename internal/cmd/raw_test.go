@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+// runRaw runs fn the way Execute does with --raw and returns stdout
+func runRaw(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+
+	origRaw := rawOutput
+	t.Cleanup(func() {
+		rawOutput = origRaw
+		rawCapture = nil
+	})
+	rawOutput = true
+
+	var err error
+	stdout, _ := testutil.CaptureOutput(func() {
+		if initErr := initRawOutput(); initErr != nil {
+			t.Fatalf("initRawOutput() error = %v", initErr)
+		}
+		err = fn()
+		flushRawOutput()
+	})
+	return stdout, err
+}
+
+func TestRawOutput_PrintsResponseBody(t *testing.T) {
+	server := setupSessionTest(t)
+	body := `{"session_id":"` + sessionIDTest + `",   "status":"ACTIVE","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","timeout_minutes":0,"extra":"kept"}`
+	server.AddResponse("/sessions/"+sessionIDTest, 200, body)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, err := runRaw(t, func() error { return runSessionStatus(cmd, nil) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stdout != body {
+		t.Errorf("stdout = %q, want the response body unchanged", stdout)
+	}
+}
+
+func TestRawOutput_PrintsErrorBody(t *testing.T) {
+	server := setupSessionTest(t)
+	body := `{"detail":"session not found"}`
+	server.AddResponse("/sessions/"+sessionIDTest, 404, body)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, err := runRaw(t, func() error { return runSessionStatus(cmd, nil) })
+	if err == nil {
+		t.Error("expected the command to still fail")
+	}
+	if stdout != body {
+		t.Errorf("stdout = %q, want the error body", stdout)
+	}
+}
+
+func TestRawOutput_NoAPICall(t *testing.T) {
+	stdout, err := runRaw(t, func() error {
+		fmt.Println("local output")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stdout != "local output\n" {
+		t.Errorf("stdout = %q, want the command's own output", stdout)
+	}
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var sessionsStartFromRecipe string
+
+var recipesCmd = &cobra.Command{
+	Use:   "recipes",
+	Short: "Manage reusable session start presets",
+	Long: `Save the flags of a "sessions start" invocation under a name and reuse them
+with "notte sessions start --from-recipe <name>".
+
+Recipes are stored in the config file. Flags that can hold secrets (proxy
+passwords, client secrets, HTTP credentials, extra HTTP headers and CDP URLs)
+are never saved.`,
+}
+
+var recipesSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save the flags of the last successful sessions start as a recipe",
+	Example: `  notte sessions start --headless=false --proxy-country us --idle-timeout-minutes 10
+  notte recipes save us-headful
+  notte sessions start --from-recipe us-headful`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRecipesSave,
+}
+
+var recipesListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List saved recipes",
+	Args:    cobra.NoArgs,
+	RunE:    runRecipesList,
+}
+
+var recipesShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show the flags stored in a recipe",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRecipesShow,
+}
+
+var recipesDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a recipe",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runRecipesDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(recipesCmd)
+	recipesCmd.AddCommand(recipesSaveCmd)
+	recipesCmd.AddCommand(recipesListCmd)
+	recipesCmd.AddCommand(recipesShowCmd)
+	recipesCmd.AddCommand(recipesDeleteCmd)
+}
+
+// recipeSecretFlags are flags whose values can carry tokens under names
+// that don't say so: request headers and CDP URLs
+var recipeSecretFlags = map[string]bool{
+	"extra-http-headers": true,
+	"cdp-url":            true,
+}
+
+// recipeSkipFlag reports whether a flag must never be stored in a recipe
+func recipeSkipFlag(name string) bool {
+	return name == "from-recipe" || recipeSecretFlags[name] || strings.Contains(name, "password") ||
+		strings.Contains(name, "secret") || strings.Contains(name, "credentials")
+}
+
+// flagValueString returns a value that pflag can parse back with Set
+func flagValueString(f *pflag.Flag) string {
+	if sv, ok := f.Value.(pflag.SliceValue); ok {
+		return strings.Join(sv.GetSlice(), ",")
+	}
+	return f.Value.String()
+}
+
+// lastSessionStart is the last successful sessions start: the session it
+// started and its explicitly set flags, less those that can hold secrets
+type lastSessionStart struct {
+	SessionID string            `json:"session_id"`
+	Flags     map[string]string `json:"flags"`
+}
+
+// recordLastSessionStart remembers the explicitly set flags of a successful
+// sessions start so they can be saved as a recipe, and so agents started on
+// the session can record its settings
+func recordLastSessionStart(cmd *cobra.Command, sessionID string) error {
+	last := lastSessionStart{SessionID: sessionID, Flags: map[string]string{}}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if !recipeSkipFlag(f.Name) {
+			last.Flags[f.Name] = flagValueString(f)
+		}
+	})
+
+	configDir, err := config.Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(configDir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(last, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(configDir, config.LastSessionStartFile), data, 0o600)
+}
+
+func loadLastSessionStart() (*lastSessionStart, error) {
+	configDir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(configDir, config.LastSessionStartFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errors.New(i18n.T(i18n.NoPreviousSessionStart))
+		}
+		return nil, err
+	}
+	var last lastSessionStart
+	if err := json.Unmarshal(data, &last); err != nil {
+		return nil, i18n.Errorf(i18n.FailedToParseLastSessionStart, err)
+	}
+	if last.Flags == nil {
+		// Written by an older version, as the bare flags
+		if err := json.Unmarshal(data, &last.Flags); err != nil {
+			return nil, i18n.Errorf(i18n.FailedToParseLastSessionStart, err)
+		}
+	}
+	return &last, nil
+}
+
+// applyRecipe sets the recipe's flags on cmd. Flags given explicitly on the
+// command line take precedence over the recipe.
+func applyRecipe(cmd *cobra.Command, name string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToLoadConfig, err)
+	}
+	recipe, ok := cfg.Recipes[name]
+	if !ok {
+		return i18n.Errorf(i18n.RecipeNotFoundHint, name)
+	}
+
+	for _, flagName := range sortedFlagNames(recipe) {
+		f := cmd.Flags().Lookup(flagName)
+		if f == nil {
+			return i18n.Errorf(i18n.RecipeUnknownFlag, name, flagName)
+		}
+		if f.Changed {
+			continue
+		}
+		if err := cmd.Flags().Set(flagName, recipe[flagName]); err != nil {
+			return i18n.Errorf(i18n.RecipeError, name, err)
+		}
+	}
+	return nil
+}
+
+func sortedFlagNames(flags map[string]string) []string {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// recipeCommandLine renders a recipe as the equivalent sessions start flags
+func recipeCommandLine(flags map[string]string) string {
+	parts := []string{"notte sessions start"}
+	for _, name := range sortedFlagNames(flags) {
+		parts = append(parts, fmt.Sprintf("--%s=%q", name, flags[name]))
+	}
+	return strings.Join(parts, " ")
+}
+
+func runRecipesSave(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	last, err := loadLastSessionStart()
+	if err != nil {
+		return err
+	}
+	flags := last.Flags
+
+	cfg, err := config.Load()
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToLoadConfig, err)
+	}
+	if cfg.Recipes == nil {
+		cfg.Recipes = map[string]map[string]string{}
+	}
+	cfg.Recipes[name] = flags
+	if err := cfg.Save(); err != nil {
+		return i18n.Errorf(i18n.FailedToSaveConfig, err)
+	}
+
+	return PrintResult(i18n.T(i18n.SavedRecipe, name, len(flags)), map[string]any{
+		"name":  name,
+		"flags": flags,
+	})
+}
+
+func runRecipesList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToLoadConfig, err)
+	}
+
+	names := make([]string, 0, len(cfg.Recipes))
+	for name := range cfg.Recipes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if IsJSONOutput() {
+		return GetFormatter().Print(names)
+	}
+	if len(names) == 0 {
+		return PrintResult(i18n.T(i18n.NoRecipesSaved), nil)
+	}
+	return PrintResult(strings.Join(names, "\n"), nil)
+}
+
+func runRecipesShow(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToLoadConfig, err)
+	}
+	recipe, ok := cfg.Recipes[args[0]]
+	if !ok {
+		return i18n.Errorf(i18n.RecipeNotFound, args[0])
+	}
+
+	if IsJSONOutput() {
+		return GetFormatter().Print(recipe)
+	}
+	return PrintResult(recipeCommandLine(recipe), nil)
+}
+
+func runRecipesDelete(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToLoadConfig, err)
+	}
+	if _, ok := cfg.Recipes[args[0]]; !ok {
+		return i18n.Errorf(i18n.RecipeNotFound, args[0])
+	}
+	delete(cfg.Recipes, args[0])
+	if err := cfg.Save(); err != nil {
+		return i18n.Errorf(i18n.FailedToSaveConfig, err)
+	}
+
+	return PrintResult(i18n.T(i18n.DeletedRecipe, args[0]), map[string]any{
+		"name":    args[0],
+		"deleted": true,
+	})
+}
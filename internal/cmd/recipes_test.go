@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func setupRecipesTest(t *testing.T) {
+	t.Helper()
+	config.SetTestConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+}
+
+// newRecipeTestCmd returns a command with a subset of the sessions start flags
+func newRecipeTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("headless", true, "")
+	cmd.Flags().Int("idle-timeout-minutes", 0, "")
+	cmd.Flags().String("proxy-country", "", "")
+	cmd.Flags().String("proxy-external-password", "", "")
+	cmd.Flags().String("extra-http-headers", "", "")
+	cmd.Flags().String("cdp-url", "", "")
+	cmd.Flags().StringSlice("chrome-args", nil, "")
+	return cmd
+}
+
+func TestRecipes_SaveAndApply(t *testing.T) {
+	setupRecipesTest(t)
+
+	last := newRecipeTestCmd()
+	_ = last.Flags().Set("headless", "false")
+	_ = last.Flags().Set("idle-timeout-minutes", "10")
+	_ = last.Flags().Set("proxy-country", "us")
+	_ = last.Flags().Set("proxy-external-password", "hunter2")
+	_ = last.Flags().Set("extra-http-headers", `{"Authorization": "Bearer tok"}`)
+	_ = last.Flags().Set("cdp-url", "wss://cdp.example.com/?token=tok")
+	_ = last.Flags().Set("chrome-args", "--a,--b")
+	if err := recordLastSessionStart(last, "sess_1"); err != nil {
+		t.Fatalf("recordLastSessionStart() error = %v", err)
+	}
+
+	_, _ = testutil.CaptureOutput(func() {
+		if err := runRecipesSave(&cobra.Command{}, []string{"team"}); err != nil {
+			t.Fatalf("runRecipesSave() error = %v", err)
+		}
+	})
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+	for _, name := range []string{"proxy-external-password", "extra-http-headers", "cdp-url"} {
+		if _, ok := cfg.Recipes["team"][name]; ok {
+			t.Errorf("secret flag --%s must not be stored in recipes", name)
+		}
+	}
+
+	next := newRecipeTestCmd()
+	_ = next.Flags().Set("idle-timeout-minutes", "3") // explicit flag wins
+	if err := applyRecipe(next, "team"); err != nil {
+		t.Fatalf("applyRecipe() error = %v", err)
+	}
+
+	want := map[string]string{
+		"headless":             "false",
+		"idle-timeout-minutes": "3",
+		"proxy-country":        "us",
+		"chrome-args":          "[--a,--b]",
+	}
+	for name, v := range want {
+		if got := next.Flags().Lookup(name).Value.String(); got != v {
+			t.Errorf("--%s = %q, want %q", name, got, v)
+		}
+	}
+	if !next.Flags().Changed("proxy-country") {
+		t.Error("recipe flags should be marked as changed so request builders pick them up")
+	}
+}
+
+func TestRecipes_ApplyUnknown(t *testing.T) {
+	setupRecipesTest(t)
+
+	err := applyRecipe(newRecipeTestCmd(), "missing")
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestRecipes_SaveWithoutLastStart(t *testing.T) {
+	setupRecipesTest(t)
+
+	if err := runRecipesSave(&cobra.Command{}, []string{"team"}); err == nil {
+		t.Fatal("expected error when no session has been started")
+	}
+}
+
+func TestRecipes_Delete(t *testing.T) {
+	setupRecipesTest(t)
+
+	cfg, _ := config.Load()
+	cfg.Recipes = map[string]map[string]string{"old": {"headless": "false"}}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	_, _ = testutil.CaptureOutput(func() {
+		if err := runRecipesDelete(&cobra.Command{}, []string{"old"}); err != nil {
+			t.Fatalf("runRecipesDelete() error = %v", err)
+		}
+	})
+
+	cfg, _ = config.Load()
+	if _, ok := cfg.Recipes["old"]; ok {
+		t.Error("recipe should have been deleted")
+	}
+}
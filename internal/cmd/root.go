@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"context"
-	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -11,20 +13,32 @@ import (
 	"github.com/nottelabs/notte-cli/internal/api"
 	"github.com/nottelabs/notte-cli/internal/auth"
 	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/daemon"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 	"github.com/nottelabs/notte-cli/internal/output"
+	"github.com/nottelabs/notte-cli/internal/timing"
 	"github.com/nottelabs/notte-cli/internal/update"
+	"github.com/nottelabs/notte-cli/internal/validate"
 )
 
 var (
 	// Global flags
 	outputFormat   string
 	noColor        bool
+	utcTimes       bool
 	verbose        bool
 	requestTimeout int
 	yesFlag        bool // Skip confirmation prompts
+	apiURL         string
+	caCertFile     string
+	clientCertFile string
+	clientKeyFile  string
+	asCurl         bool // Print each API request as a curl command
 
-	// Version set at build time
-	Version = "dev"
+	// Build information set at build time
+	Version   = "dev"
+	Commit    = ""
+	BuildDate = ""
 )
 
 // rootCmd is the base command
@@ -53,12 +67,26 @@ func Execute() {
 		go checker.Run(ctx)
 	}
 
-	err := rootCmd.Execute()
+	installIDFlagValidation(rootCmd)
+	installCommandSuggestions(rootCmd)
+	if cfg, err := config.Load(); err == nil && len(cfg.Aliases) > 0 {
+		rootCmd.SetArgs(expandUserAlias(rootCmd, os.Args[1:], cfg.Aliases))
+	}
+	executed, err := rootCmd.ExecuteC()
+	attachFieldFlags(executed, err)
+	err = classifyTimeout(err)
+	flushRawOutput()
+	reportTimings(os.Stderr)
 
 	// Show update notification after command output
 	if checker != nil {
 		if result := checker.GetResult(); result != nil {
-			update.PrintUpdateNotification(result, os.Stderr, os.Stdin, yesFlag, IsJSONOutput(), noColor)
+			// Non-interactive runs only get the notice; they neither prompt nor upgrade
+			var in io.Reader = os.Stdin
+			if nonInteractive {
+				in = strings.NewReader("")
+			}
+			update.PrintUpdateNotification(result, os.Stderr, in, yesFlag && !nonInteractive, IsJSONOutput(), noColor)
 		}
 	}
 
@@ -72,36 +100,85 @@ func Execute() {
 func init() {
 	// Hide completion command from help output (still accessible via `notte completion`)
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
+	rootCmd.SetFlagErrorFunc(flagErrorWithSuggestions)
 
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "Output format (text, json)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "Output format (text, json, yaml, csv for structured scrapes)")
+	_ = rootCmd.PersistentFlags().SetAnnotation("output", flagEnumAnnotation, []string{"text", "json", "yaml", "csv"})
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable color output")
+	rootCmd.PersistentFlags().BoolVar(&utcTimes, "utc", false, "Print timestamps in UTC rather than local time (text output)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
 	rootCmd.PersistentFlags().IntVar(&requestTimeout, "timeout", 60, "API request timeout in seconds")
 	rootCmd.PersistentFlags().BoolVarP(&yesFlag, "yes", "y", false, "Skip confirmation prompts")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractiveFlag, "non-interactive", false, "Never prompt; fail where confirmation is needed (env NOTTE_NONINTERACTIVE, on when CI=true)")
+	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "", "API base URL for this invocation (overrides NOTTE_API_URL and config)")
+	rootCmd.PersistentFlags().StringVar(&caCertFile, "ca-cert", "", "PEM CA bundle to trust for the API (config: ca_cert)")
+	rootCmd.PersistentFlags().StringVar(&clientCertFile, "client-cert", "", "PEM client certificate for mutual TLS (config: client_cert)")
+	rootCmd.PersistentFlags().StringVar(&clientKeyFile, "client-key", "", "PEM private key for --client-cert (config: client_key)")
+	rootCmd.PersistentFlags().BoolVar(&showTimings, "timings", false, "Print time spent in auth, requests, retries and formatting to stderr")
+	rootCmd.PersistentFlags().StringVar(&outputTemplate, "template", "", "Format results with a Go template, e.g. '{{.SessionId}}' (one line per list item)")
+	rootCmd.PersistentFlags().BoolVar(&envelopeOutput, "envelope", false, "Wrap -o json results in a versioned envelope (api_version, kind, items or item)")
+	rootCmd.PersistentFlags().BoolVar(&rawOutput, "raw", false, "Print the last API response body exactly as received, with no formatting")
+	rootCmd.PersistentFlags().BoolVar(&asCurl, "as-curl", false, "Print each API request as an equivalent curl command to stderr (API key and secrets redacted)")
+	rootCmd.PersistentFlags().DurationVar(&latencyBudget, "latency-budget", 0, "Warn when an API call takes longer than this (e.g. 2s; env NOTTE_LATENCY_BUDGET)")
 
-	// Set up confirmation state before each command
-	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+	// Set up confirmation, timing and raw output state before each command
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		initLocale()
+		if apiURL != "" {
+			if err := validate.URL(apiURL); err != nil {
+				return i18n.Errorf(i18n.InvalidAPIURL, err)
+			}
+		}
+		// Keyring lookups are qualified by environment, so they must see the flag too
+		auth.SetAPIURLOverride(apiURL)
+		if err := applyEnvDefaults(cmd); err != nil {
+			return err
+		}
+		// After the environment defaults, which may set --yes or --non-interactive
 		SetSkipConfirmation(yesFlag)
+		SetNonInteractive(nonInteractiveFlag || nonInteractiveFromEnv())
+		if err := checkForbidden(cmd); err != nil {
+			return err
+		}
+		if err := validate.OutputFormat(outputFormat); err != nil {
+			return err
+		}
+		if err := checkCSVOutput(cmd); err != nil {
+			return err
+		}
+		if err := initTemplateOutput(); err != nil {
+			return err
+		}
+		if err := initEnvelopeOutput(cmd); err != nil {
+			return err
+		}
+		if err := initTimings(); err != nil {
+			return err
+		}
+		return initRawOutput()
 	}
-
-	// Version command
-	rootCmd.AddCommand(&cobra.Command{
-		Use:   "version",
-		Short: "Print version information",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return PrintResult(fmt.Sprintf("notte version %s", Version), map[string]any{
-				"version": Version,
-			})
-		},
-	})
 }
 
 // GetFormatter returns the appropriate formatter based on flags
 func GetFormatter() output.Formatter {
-	format := output.Format(outputFormat)
-	f := output.NewFormatter(format, os.Stdout)
+	if stdinBatch != nil {
+		return stdinBatch
+	}
+	var f output.Formatter
+	if IsTemplateOutput() {
+		f = &output.TemplateFormatter{Writer: os.Stdout, Template: parsedTemplate}
+	} else {
+		f = output.NewFormatter(output.Format(outputFormat), os.Stdout)
+	}
 	if tf, ok := f.(*output.TextFormatter); ok {
 		tf.NoColor = noColor
+		tf.UTC = utcTimes
+	}
+	if envelopeKind != "" {
+		f = &output.EnvelopeFormatter{Formatter: f, Kind: envelopeKind}
+	}
+	if timings != nil {
+		return timedFormatter{f}
 	}
 	return f
 }
@@ -111,19 +188,29 @@ func IsVerbose() bool {
 	return verbose
 }
 
+// IsOffline returns whether offline mode was forced via NOTTE_OFFLINE.
+// Commands that only touch local state keep working; API calls fail fast.
+func IsOffline() bool {
+	return os.Getenv(config.EnvOffline) != ""
+}
+
 // GetClient creates an authenticated API client
 func GetClient() (*api.NotteClient, error) {
-	apiKey, _, err := auth.GetAPIKey("")
+	mockDir, mockMode, err := getMockConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	baseURL := os.Getenv(config.EnvAPIURL)
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := apiURL
+	if baseURL == "" {
+		baseURL = os.Getenv(config.EnvAPIURL)
+	}
 	if baseURL == "" {
-		cfg, err := config.Load()
-		if err != nil {
-			return nil, err
-		}
 		baseURL = cfg.APIURL
 	}
 
@@ -132,13 +219,128 @@ func GetClient() (*api.NotteClient, error) {
 	}
 
 	var opts []api.NotteClientOption
+
+	// Route through the local daemon when one is serving this API
+	var daemonStatus *daemon.Status
+	if mockDir == "" && !IsOffline() {
+		var rt http.RoundTripper
+		if rt, daemonStatus = connectDaemon(baseURL); rt != nil {
+			opts = append(opts, api.WithBaseTransport(rt))
+		}
+	}
+
+	stopAuth := timings.Start(timing.PhaseAuth)
+	var apiKey string
+	if daemonStatus != nil && daemonStatus.HasAPIKey && os.Getenv(auth.EnvAPIKey) == "" {
+		// The daemon already holds credentials; skip the keyring lookup
+		apiKey = daemon.DelegatedAPIKey
+	} else {
+		apiKey, _, err = auth.GetAPIKey("")
+		if err != nil {
+			// Replaying recordings never hits the API, so no key is needed
+			if mockDir == "" || mockMode != api.MockReplay {
+				return nil, err
+			}
+			apiKey = "mock"
+		}
+	}
+	stopAuth()
+
 	if origin := os.Getenv(config.EnvRequestOrigin); origin != "" {
 		opts = append(opts, api.WithRequestOrigin(origin))
 	}
+	if mockDir != "" {
+		opts = append(opts, api.WithMock(mockDir, mockMode))
+	}
+	if timings != nil {
+		opts = append(opts, api.WithTimings(timings))
+	}
+	if rawCapture != nil {
+		opts = append(opts, api.WithResponseCapture(rawCapture))
+	}
+	if asCurl {
+		opts = append(opts, api.WithCurlOutput(os.Stderr))
+	}
+	transportOpts, err := transportOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, api.WithTLS(tlsOptions(cfg)), api.WithTransportOptions(transportOpts))
+	if IsOffline() && mockMode != api.MockReplay {
+		opts = append(opts, api.WithOffline(true))
+	}
 
 	return api.NewClientWithURL(apiKey, baseURL, Version, opts...)
 }
 
+// tlsOptions resolves TLS settings; flags take precedence over config
+func tlsOptions(cfg *config.Config) api.TLSOptions {
+	opts := api.TLSOptions{CACert: cfg.CACert, ClientCert: cfg.ClientCert, ClientKey: cfg.ClientKey}
+	if caCertFile != "" {
+		opts.CACert = caCertFile
+	}
+	if clientCertFile != "" {
+		opts.ClientCert = clientCertFile
+	}
+	if clientKeyFile != "" {
+		opts.ClientKey = clientKeyFile
+	}
+	return opts
+}
+
+// transportOptions converts the config's transport section
+func transportOptions(cfg *config.Config) (api.TransportOptions, error) {
+	var opts api.TransportOptions
+	tc := cfg.Transport
+	if tc == nil {
+		return opts, nil
+	}
+
+	opts.MaxIdleConns = tc.MaxIdleConns
+	opts.MaxIdleConnsPerHost = tc.MaxIdleConnsPerHost
+	opts.MaxConnsPerHost = tc.MaxConnsPerHost
+	opts.DisableHTTP2 = tc.HTTP2 != nil && !*tc.HTTP2
+
+	for _, d := range []struct {
+		name  string
+		value string
+		dst   *time.Duration
+	}{
+		{"dial_timeout", tc.DialTimeout, &opts.DialTimeout},
+		{"response_header_timeout", tc.ResponseHeaderTimeout, &opts.ResponseHeaderTimeout},
+		{"request_timeout", tc.RequestTimeout, &opts.RequestTimeout},
+	} {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil || v < 0 {
+			return opts, i18n.Errorf(i18n.InvalidTransportConfig, d.name, d.value)
+		}
+		*d.dst = v
+	}
+	return opts, nil
+}
+
+// getMockConfig reads NOTTE_MOCK (recordings directory) and NOTTE_MOCK_MODE
+// (replay or record, default replay)
+func getMockConfig() (string, api.MockMode, error) {
+	dir := os.Getenv(config.EnvMock)
+	if dir == "" {
+		return "", "", nil
+	}
+
+	mode := api.MockMode(os.Getenv(config.EnvMockMode))
+	switch mode {
+	case "":
+		mode = api.MockReplay
+	case api.MockReplay, api.MockRecord:
+	default:
+		return "", "", i18n.Errorf(i18n.InvalidChoice, config.EnvMockMode, mode, api.MockReplay, api.MockRecord)
+	}
+	return dir, mode, nil
+}
+
 // GetContextWithTimeout wraps the provided context with a timeout
 func GetContextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(ctx, time.Duration(requestTimeout)*time.Second)
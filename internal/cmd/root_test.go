@@ -2,12 +2,21 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/exec"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/auth"
+	"github.com/nottelabs/notte-cli/internal/config"
+	notteErrors "github.com/nottelabs/notte-cli/internal/errors"
 	"github.com/nottelabs/notte-cli/internal/output"
+	"github.com/nottelabs/notte-cli/internal/testutil"
 )
 
 func TestGetFormatter_NoColor(t *testing.T) {
@@ -31,6 +40,48 @@ func TestGetFormatter_NoColor(t *testing.T) {
 	}
 }
 
+func TestOutputFormatFlag(t *testing.T) {
+	_ = testutil.SetupTestEnv(t)
+	origFormat := outputFormat
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	outputFormat = "xml"
+	if err := rootCmd.PersistentPreRunE(rootCmd, nil); err == nil || !strings.Contains(err.Error(), "invalid output format") {
+		t.Fatalf("expected -o xml to be rejected, got %v", err)
+	}
+
+	outputFormat = "yaml"
+	if err := rootCmd.PersistentPreRunE(rootCmd, nil); err != nil {
+		t.Fatalf("PersistentPreRunE() error = %v", err)
+	}
+	if _, ok := GetFormatter().(*output.YAMLFormatter); !ok {
+		t.Fatalf("expected YAMLFormatter, got %T", GetFormatter())
+	}
+	if !IsJSONOutput() {
+		t.Error("expected -o yaml to count as structured output")
+	}
+}
+
+func TestRunSessionStatus_YAML(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/"+sessionIDTest, 200, sessionJSON())
+	origFormat := outputFormat
+	t.Cleanup(func() { outputFormat = origFormat })
+	outputFormat = "yaml"
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSessionStatus(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !strings.HasPrefix(stdout, "---\n") || !strings.Contains(stdout, "\nsession_id: "+sessionIDTest+"\n") {
+		t.Errorf("expected a YAML document, got:\n%s", stdout)
+	}
+}
+
 func TestIsVerbose(t *testing.T) {
 	origVerbose := verbose
 	t.Cleanup(func() { verbose = origVerbose })
@@ -41,6 +92,46 @@ func TestIsVerbose(t *testing.T) {
 	}
 }
 
+func TestOfflineModeFailsFast(t *testing.T) {
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+	env.SetEnv("NOTTE_OFFLINE", "1")
+
+	server := testutil.NewMockServer()
+	t.Cleanup(func() { server.Close() })
+	env.SetEnv("NOTTE_API_URL", server.URL())
+	server.AddResponse("/sessions", 200, `{"items":[]}`)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	err := runSessionsList(cmd, nil)
+	var offlineErr *notteErrors.OfflineError
+	if !errors.As(err, &offlineErr) {
+		t.Fatalf("expected OfflineError, got %v", err)
+	}
+	if len(server.AllRequests()) != 0 {
+		t.Fatal("expected no requests in offline mode")
+	}
+}
+
+func TestGetClient_MockReplayWithoutAPIKey(t *testing.T) {
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("HOME", t.TempDir())
+	env.SetEnv("NOTTE_MOCK", t.TempDir())
+	auth.SetKeyring(&stubKeyring{})
+	t.Cleanup(func() { auth.ResetKeyring() })
+
+	if _, err := GetClient(); err != nil {
+		t.Fatalf("expected replay mode to work without an API key, got %v", err)
+	}
+
+	env.SetEnv("NOTTE_MOCK_MODE", "bogus")
+	if _, err := GetClient(); err == nil {
+		t.Fatal("expected error for invalid NOTTE_MOCK_MODE")
+	}
+}
+
 func TestGetContextWithTimeout(t *testing.T) {
 	origTimeout := requestTimeout
 	t.Cleanup(func() { requestTimeout = origTimeout })
@@ -79,3 +170,83 @@ func TestExecute_ErrorExit(t *testing.T) {
 	}
 	t.Fatalf("unexpected error: %v", err)
 }
+
+func TestAPIURLFlag(t *testing.T) {
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_URL", "https://us-staging.notte.cc")
+
+	keyring := testutil.NewMockKeyring()
+	_ = keyring.Set(auth.KeyringKeyForEnv("dev"), "dev-key")
+	_ = keyring.Set(auth.KeyringKeyForEnv("staging"), "staging-key")
+	auth.SetKeyring(keyring)
+
+	origURL := apiURL
+	t.Cleanup(func() {
+		apiURL = origURL
+		auth.SetAPIURLOverride("")
+		auth.ResetKeyring()
+	})
+
+	apiURL = "not a url"
+	if err := rootCmd.PersistentPreRunE(rootCmd, nil); err == nil {
+		t.Fatal("expected error for invalid --api-url")
+	}
+
+	apiURL = "https://us-dev.notte.cc"
+	if err := rootCmd.PersistentPreRunE(rootCmd, nil); err != nil {
+		t.Fatalf("PersistentPreRunE() error = %v", err)
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		t.Fatalf("GetClient() error = %v", err)
+	}
+	if client.BaseURL() != "https://us-dev.notte.cc" {
+		t.Errorf("BaseURL() = %q, want --api-url value", client.BaseURL())
+	}
+	if client.APIKey() != "dev-key" {
+		t.Errorf("APIKey() = %q, want the key stored for the --api-url environment", client.APIKey())
+	}
+}
+
+func TestTLSOptions_FlagsOverrideConfig(t *testing.T) {
+	origCA, origCert, origKey := caCertFile, clientCertFile, clientKeyFile
+	t.Cleanup(func() { caCertFile, clientCertFile, clientKeyFile = origCA, origCert, origKey })
+
+	cfg := &config.Config{CACert: "config-ca.pem", ClientCert: "config.crt", ClientKey: "config.key"}
+	caCertFile, clientCertFile, clientKeyFile = "", "", ""
+	if got := tlsOptions(cfg); got != (api.TLSOptions{CACert: "config-ca.pem", ClientCert: "config.crt", ClientKey: "config.key"}) {
+		t.Errorf("tlsOptions() without flags = %+v", got)
+	}
+
+	caCertFile = "flag-ca.pem"
+	if got := tlsOptions(cfg); got.CACert != "flag-ca.pem" || got.ClientCert != "config.crt" {
+		t.Errorf("tlsOptions() with --ca-cert = %+v", got)
+	}
+}
+
+func TestTransportOptions_FromConfig(t *testing.T) {
+	opts, err := transportOptions(&config.Config{})
+	if err != nil || opts != (api.TransportOptions{}) {
+		t.Fatalf("transportOptions() without config = %+v, %v", opts, err)
+	}
+
+	http2 := false
+	opts, err = transportOptions(&config.Config{Transport: &config.TransportConfig{
+		MaxConnsPerHost: 16,
+		HTTP2:           &http2,
+		DialTimeout:     "5s",
+		RequestTimeout:  "2m",
+	}})
+	if err != nil {
+		t.Fatalf("transportOptions() error = %v", err)
+	}
+	want := api.TransportOptions{MaxConnsPerHost: 16, DisableHTTP2: true, DialTimeout: 5 * time.Second, RequestTimeout: 2 * time.Minute}
+	if opts != want {
+		t.Errorf("transportOptions() = %+v, want %+v", opts, want)
+	}
+
+	if _, err := transportOptions(&config.Config{Transport: &config.TransportConfig{DialTimeout: "soon"}}); err == nil {
+		t.Error("expected error for invalid duration")
+	}
+}
@@ -2,12 +2,12 @@ package cmd
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	"github.com/spf13/cobra"
 
 	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
 // APIFunc is a function that calls the API and returns a response and error.
@@ -32,7 +32,7 @@ func RunAPICommand[T any](cmd *cobra.Command, apiFn APIFunc[T]) error {
 
 	result, httpResp, body, err := apiFn(ctx, client.Client())
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(httpResp, body); err != nil {
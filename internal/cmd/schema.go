@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// Flag annotations listing accepted values, set by generated flag code.
+// Suggestions are known values of a flag that also accepts other strings.
+// The field annotation is the dotted path of the request field a flag sets.
+const (
+	flagEnumAnnotation        = "notte_enum"
+	flagSuggestionsAnnotation = "notte_suggestions"
+	flagFieldAnnotation       = "notte_field"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "__schema",
+	Short: "Print a JSON description of all commands and flags",
+	Long: `Print every command, flag, type, default and enum value as JSON.
+
+This is meant for tools that build on the CLI (docs, TUIs, wrappers) so they
+don't have to parse --help output. "make schema" writes it to commands.json.`,
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE:   runSchema,
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
+
+// cliSchema is the machine-readable description of the CLI
+type cliSchema struct {
+	Name        string          `json:"name"`
+	Version     string          `json:"version"`
+	GlobalFlags []schemaFlag    `json:"global_flags"`
+	Commands    []schemaCommand `json:"commands"`
+}
+
+type schemaCommand struct {
+	Path     string       `json:"path"`
+	Use      string       `json:"use"`
+	Short    string       `json:"short"`
+	Long     string       `json:"long,omitempty"`
+	Example  string       `json:"example,omitempty"`
+	Aliases  []string     `json:"aliases,omitempty"`
+	Runnable bool         `json:"runnable"`
+	Flags    []schemaFlag `json:"flags"`
+}
+
+type schemaFlag struct {
+	Name        string   `json:"name"`
+	Shorthand   string   `json:"shorthand,omitempty"`
+	Type        string   `json:"type"`
+	Default     string   `json:"default"`
+	Usage       string   `json:"usage"`
+	Required    bool     `json:"required"`
+	Persistent  bool     `json:"persistent,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	Suggestions []string `json:"suggestions,omitempty"`
+	Deprecated  string   `json:"deprecated,omitempty"`
+}
+
+// buildSchema describes root and every visible command below it
+func buildSchema(root *cobra.Command) cliSchema {
+	s := cliSchema{
+		Name:        root.Name(),
+		Version:     Version,
+		GlobalFlags: schemaFlags(root.PersistentFlags(), nil, true),
+		Commands:    []schemaCommand{},
+	}
+
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		for _, sub := range c.Commands() {
+			if sub.Hidden || sub.Name() == "help" {
+				continue
+			}
+			s.Commands = append(s.Commands, describeCommand(root, sub))
+			walk(sub)
+		}
+	}
+	walk(root)
+
+	sort.Slice(s.Commands, func(i, j int) bool { return s.Commands[i].Path < s.Commands[j].Path })
+	return s
+}
+
+func describeCommand(root, c *cobra.Command) schemaCommand {
+	global := root.PersistentFlags()
+
+	// Own flags plus persistent flags inherited from parents other than root,
+	// which are listed once under global_flags
+	flags := schemaFlags(c.LocalFlags(), global, false)
+	for _, f := range schemaFlags(c.InheritedFlags(), global, false) {
+		f.Persistent = true
+		flags = append(flags, f)
+	}
+	persistent := c.PersistentFlags()
+	for i := range flags {
+		if persistent.Lookup(flags[i].Name) != nil {
+			flags[i].Persistent = true
+		}
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+
+	return schemaCommand{
+		Path:     c.CommandPath(),
+		Use:      c.Use,
+		Short:    c.Short,
+		Long:     c.Long,
+		Example:  c.Example,
+		Aliases:  c.Aliases,
+		Runnable: c.Runnable(),
+		Flags:    flags,
+	}
+}
+
+// schemaFlags lists the flags in fs that aren't in skip. Hidden flags are
+// left out unless they are deprecated, so tooling can still map old names.
+func schemaFlags(fs, skip *pflag.FlagSet, persistent bool) []schemaFlag {
+	flags := []schemaFlag{}
+	fs.VisitAll(func(f *pflag.Flag) {
+		if skip != nil && skip.Lookup(f.Name) != nil {
+			return
+		}
+		if f.Hidden && f.Deprecated == "" {
+			return
+		}
+		_, required := f.Annotations[cobra.BashCompOneRequiredFlag]
+		flags = append(flags, schemaFlag{
+			Name:        f.Name,
+			Shorthand:   f.Shorthand,
+			Type:        f.Value.Type(),
+			Default:     f.DefValue,
+			Usage:       f.Usage,
+			Required:    required,
+			Persistent:  persistent,
+			Enum:        f.Annotations[flagEnumAnnotation],
+			Suggestions: f.Annotations[flagSuggestionsAnnotation],
+			Deprecated:  f.Deprecated,
+		})
+	})
+	return flags
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	data, err := json.MarshalIndent(buildSchema(cmd.Root()), "", "  ")
+	if err != nil {
+		return i18n.Errorf(i18n.FailedToEncodeSchema, err)
+	}
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	return err
+}
@@ -0,0 +1,360 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+)
+
+// skipSchemaCheck sends raw JSON payloads without checking them against the
+// API schema first (--no-validate)
+var skipSchemaCheck bool
+
+// schemaProblem is one field of a JSON payload that doesn't match the API
+// schema the client was generated from
+type schemaProblem struct {
+	Path string
+	Msg  string
+}
+
+// schemaError lists every problem found in a payload, so they can all be
+// fixed before the next attempt instead of one API round trip each
+type schemaError struct {
+	what     string
+	problems []schemaProblem
+}
+
+func (e *schemaError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s does not match the API schema:", e.what)
+	for _, p := range e.problems {
+		path := p.Path
+		if path == "" {
+			path = "(root)"
+		}
+		fmt.Fprintf(&b, "\n  %s: %s", path, p.Msg)
+	}
+	b.WriteString("\nUse --no-validate to send it anyway.")
+	return b.String()
+}
+
+func newSchemaError(what string, problems []schemaProblem) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return &schemaError{what: what, problems: problems}
+}
+
+// checkPayload checks that data decodes into the generated API type t.
+// Malformed JSON is left for the caller to report.
+func checkPayload(what string, data []byte, t reflect.Type) error {
+	var v any
+	if skipSchemaCheck || json.Unmarshal(data, &v) != nil {
+		return nil
+	}
+	return newSchemaError(what, checkSchema("", v, t))
+}
+
+// checkAction checks an action payload against the schema of its "type"
+func checkAction(data []byte) error {
+	var v any
+	if skipSchemaCheck || json.Unmarshal(data, &v) != nil {
+		return nil
+	}
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return newSchemaError("action", []schemaProblem{{"", "expected an object, got " + jsonKind(v)}})
+	}
+
+	types := actionTypes()
+	kind, ok := obj["type"].(string)
+	if !ok {
+		if _, present := obj["type"]; present {
+			return newSchemaError("action", []schemaProblem{{"type", "expected a string, got " + jsonKind(obj["type"])}})
+		}
+		return newSchemaError("action", []schemaProblem{{"type", "required field is missing"}})
+	}
+	t, ok := types[kind]
+	if !ok {
+		msg := fmt.Sprintf("unknown action type %q", kind)
+		if s := closestName(kind, slices.Collect(maps.Keys(types))); s != "" {
+			msg += fmt.Sprintf(" (did you mean %q?)", s)
+		}
+		return newSchemaError("action", []schemaProblem{{"type", msg}})
+	}
+	return newSchemaError("action", checkSchema("", v, t))
+}
+
+var (
+	actionTypesOnce sync.Once
+	actionTypesMap  map[string]reflect.Type
+)
+
+// actionTypes maps each action "type" to its generated model, found by
+// asking the generated discriminator about every As<Model> accessor
+func actionTypes() map[string]reflect.Type {
+	actionTypesOnce.Do(func() {
+		actionTypesMap = map[string]reflect.Type{}
+		union := reflect.TypeOf(api.ApiExecutionResponse_Action{})
+		for i := range union.NumMethod() {
+			model, ok := strings.CutPrefix(union.Method(i).Name, "As")
+			if !ok {
+				continue
+			}
+			name := strings.TrimSuffix(strings.TrimSuffix(model, "Output"), "Action")
+			kind := snakeCase(name)
+
+			var probe api.ApiExecutionResponse_Action
+			if err := probe.UnmarshalJSON([]byte(`{"type":"` + kind + `"}`)); err != nil {
+				continue
+			}
+			if v, err := probe.ValueByDiscriminator(); err == nil {
+				actionTypesMap[kind] = reflect.TypeOf(v)
+			}
+		}
+	})
+	return actionTypesMap
+}
+
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// checkSchema compares a decoded JSON value with the generated type t.
+// Unions and other types with their own UnmarshalJSON are taken as is.
+func checkSchema(path string, v any, t reflect.Type) []schemaProblem {
+	if t.Kind() == reflect.Pointer {
+		if v == nil {
+			return nil
+		}
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Interface || reflect.PointerTo(t).Implements(jsonUnmarshaler) {
+		return nil
+	}
+	if v == nil {
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+			return nil
+		}
+		return []schemaProblem{{path, "must not be null"}}
+	}
+
+	mismatch := func(want string) []schemaProblem {
+		return []schemaProblem{{path, fmt.Sprintf("expected %s, got %s", want, jsonKind(v))}}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			return mismatch("a string")
+		}
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			return mismatch("a boolean")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := v.(float64); !ok || n != float64(int64(n)) {
+			return mismatch("an integer")
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := v.(float64); !ok {
+			return mismatch("a number")
+		}
+	case reflect.Slice:
+		items, ok := v.([]any)
+		if !ok {
+			return mismatch("an array")
+		}
+		var problems []schemaProblem
+		for i, item := range items {
+			problems = append(problems, checkSchema(fmt.Sprintf("%s[%d]", path, i), item, t.Elem())...)
+		}
+		return problems
+	case reflect.Map:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return mismatch("an object")
+		}
+		var problems []schemaProblem
+		for _, key := range sortedKeys(obj) {
+			problems = append(problems, checkSchema(joinPath(path, key), obj[key], t.Elem())...)
+		}
+		return problems
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return mismatch("an object")
+		}
+		return checkObject(path, obj, t)
+	}
+	return nil
+}
+
+func checkObject(path string, obj map[string]any, t reflect.Type) []schemaProblem {
+	var problems []schemaProblem
+	fields := map[string]reflect.Type{}
+	var names []string
+	open := false
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			// oapi-codegen keeps additionalProperties in a json:"-" map
+			open = open || f.Name == "AdditionalProperties"
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+		names = append(names, name)
+		required := f.Type.Kind() != reflect.Pointer && !strings.Contains(opts, "omitempty")
+		if _, ok := obj[name]; !ok && required {
+			problems = append(problems, schemaProblem{joinPath(path, name), "required field is missing"})
+		}
+	}
+
+	for _, key := range sortedKeys(obj) {
+		ft, ok := fields[key]
+		if !ok {
+			if open {
+				continue
+			}
+			msg := "unknown field"
+			if s := closestName(key, names); s != "" {
+				msg += fmt.Sprintf(" (did you mean %q?)", s)
+			}
+			problems = append(problems, schemaProblem{joinPath(path, key), msg})
+			continue
+		}
+		problems = append(problems, checkSchema(joinPath(path, key), obj[key], ft)...)
+	}
+	return problems
+}
+
+// jsonSchemaTypes are the type names a JSON Schema may use
+var jsonSchemaTypes = []string{"array", "boolean", "integer", "null", "number", "object", "string"}
+
+// checkResponseFormat checks the shape of an agent response format, which
+// the API takes as a JSON Schema object
+func checkResponseFormat(v any) error {
+	if skipSchemaCheck || v == nil {
+		return nil
+	}
+	return newSchemaError("response format", checkJSONSchema("", v))
+}
+
+func checkJSONSchema(path string, v any) []schemaProblem {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return []schemaProblem{{path, "expected a JSON Schema object, got " + jsonKind(v)}}
+	}
+
+	var problems []schemaProblem
+	if t, ok := obj["type"]; ok {
+		var kinds []any
+		if list, isList := t.([]any); isList {
+			kinds = list
+		} else {
+			kinds = []any{t}
+		}
+		for _, k := range kinds {
+			if s, _ := k.(string); !slices.Contains(jsonSchemaTypes, s) {
+				problems = append(problems, schemaProblem{joinPath(path, "type"),
+					fmt.Sprintf("expected one of %s, got %v", strings.Join(jsonSchemaTypes, ", "), k)})
+			}
+		}
+	}
+	if props, ok := obj["properties"]; ok {
+		propsObj, isObj := props.(map[string]any)
+		if !isObj {
+			problems = append(problems, schemaProblem{joinPath(path, "properties"), "expected an object, got " + jsonKind(props)})
+		}
+		for _, key := range sortedKeys(propsObj) {
+			problems = append(problems, checkJSONSchema(joinPath(joinPath(path, "properties"), key), propsObj[key])...)
+		}
+	}
+	if items, ok := obj["items"]; ok {
+		if _, isBool := items.(bool); !isBool {
+			problems = append(problems, checkJSONSchema(joinPath(path, "items"), items)...)
+		}
+	}
+	if req, ok := obj["required"]; ok {
+		list, isList := req.([]any)
+		for _, r := range list {
+			if _, isString := r.(string); !isString {
+				isList = false
+			}
+		}
+		if !isList {
+			problems = append(problems, schemaProblem{joinPath(path, "required"), "expected an array of property names"})
+		}
+	}
+	return problems
+}
+
+func jsonKind(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case float64:
+		return "a number"
+	case []any:
+		return "an array"
+	case map[string]any:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// closestName returns the candidate a typo most likely meant, or ""
+func closestName(typed string, candidates []string) string {
+	best, bestDist := "", 3
+	for _, c := range candidates {
+		if d := editDistance(typed, c); d < bestDist || (d == bestDist && c < best) {
+			best, bestDist = c, d
+		}
+	}
+	if bestDist > 2 || (len(typed) <= 3 && bestDist > 1) {
+		return ""
+	}
+	return best
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func TestCheckAction(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		wantErr []string
+	}{
+		{"valid", `{"type":"goto","url":"https://example.com"}`, nil},
+		{"optional fields", `{"type":"scrape","only_main_content":true,"ignored_tags":["nav"]}`, nil},
+		{"not JSON is left to the caller", `{`, nil},
+		{"missing type", `{"url":"x"}`, []string{"type: required field is missing"}},
+		{"unknown type", `{"type":"gotoo"}`, []string{`unknown action type "gotoo" (did you mean "goto"?)`}},
+		{"missing field", `{"type":"goto"}`, []string{"url: required field is missing"}},
+		{"wrong type", `{"type":"wait","time_ms":"1s"}`, []string{"time_ms: expected an integer, got a string"}},
+		{"typo", `{"type":"goto","url":"x","descripton":"y"}`, []string{`descripton: unknown field (did you mean "description"?)`}},
+		{"nested", `{"type":"scrape","ignored_tags":["nav",3]}`, []string{"ignored_tags[1]: expected a string, got a number"}},
+		{"not an object", `[]`, []string{"(root): expected an object, got an array"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkAction([]byte(tt.action))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckAction_ListsEveryProblem(t *testing.T) {
+	err := checkAction([]byte(`{"type":"switch_tab","tab_idx":1,"category":false}`))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	want := "action does not match the API schema:\n" +
+		"  tab_index: required field is missing\n" +
+		"  category: expected a string, got a boolean\n" +
+		"  tab_idx: unknown field (did you mean \"tab_index\"?)\n" +
+		"Use --no-validate to send it anyway."
+	if err.Error() != want {
+		t.Errorf("error =\n%s\nwant\n%s", err, want)
+	}
+}
+
+func TestCheckPayload_Cookies(t *testing.T) {
+	body := reflect.TypeOf(api.SessionCookiesSetJSONRequestBody{})
+
+	valid := `{"cookies":[{"name":"sid","value":"1","domain":"example.com","path":"/","httpOnly":true,"expires":1.7e9}]}`
+	if err := checkPayload("cookies", []byte(valid), body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := checkPayload("cookies", []byte(`[{"name":"sid"}]`), body)
+	if err == nil || !strings.Contains(err.Error(), "(root): expected an object, got an array") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = checkPayload("cookies", []byte(`{"cookies":[{"name":"sid","value":"1","domain":"x","path":"/"}]}`), body)
+	if err == nil || !strings.Contains(err.Error(), "cookies[0].httpOnly: required field is missing") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckResponseFormat(t *testing.T) {
+	valid := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"price": map[string]any{"type": []any{"number", "null"}}},
+		"required":   []any{"price"},
+	}
+	if err := checkResponseFormat(valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalid := map[string]any{
+		"type":       "obj",
+		"properties": map[string]any{"price": "number"},
+		"required":   "price",
+	}
+	err := checkResponseFormat(invalid)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{
+		"type: expected one of array, boolean, integer, null, number, object, string, got obj",
+		"properties.price: expected a JSON Schema object, got a string",
+		"required: expected an array of property names",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestRunSessionExecute_SchemaErrorNotSent(t *testing.T) {
+	server := setupSessionTest(t)
+	path := "/sessions/" + sessionIDTest + "/page/execute"
+	server.AddResponse(path, 200, `{"success":true,"message":"ok"}`)
+
+	origAction, origSkip := sessionExecuteAction, skipSchemaCheck
+	t.Cleanup(func() { sessionExecuteAction, skipSchemaCheck = origAction, origSkip })
+	sessionExecuteAction = `{"type":"goto","ulr":"https://example.com"}`
+	skipSchemaCheck = false
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	err := runSessionExecute(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), `ulr: unknown field (did you mean "url"?)`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := len(server.Requests(path)); got != 0 {
+		t.Errorf("expected no API call, got %d", got)
+	}
+
+	// --no-validate sends it as is
+	skipSchemaCheck = true
+	testutil.CaptureOutput(func() {
+		if err := runSessionExecute(cmd, nil); err != nil {
+			t.Fatalf("unexpected error with --no-validate: %v", err)
+		}
+	})
+	if got := len(server.Requests(path)); got != 1 {
+		t.Errorf("expected 1 API call with --no-validate, got %d", got)
+	}
+}
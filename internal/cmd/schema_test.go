@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func findSchemaCommand(t *testing.T, s cliSchema, path string) schemaCommand {
+	t.Helper()
+	for _, c := range s.Commands {
+		if c.Path == path {
+			return c
+		}
+	}
+	t.Fatalf("command %q not in schema", path)
+	return schemaCommand{}
+}
+
+func findSchemaFlag(t *testing.T, c schemaCommand, name string) schemaFlag {
+	t.Helper()
+	for _, f := range c.Flags {
+		if f.Name == name {
+			return f
+		}
+	}
+	t.Fatalf("flag --%s not in schema for %q", name, c.Path)
+	return schemaFlag{}
+}
+
+func TestRunSchema(t *testing.T) {
+	// The schema is larger than a pipe buffer, so capture it directly
+	var stdout bytes.Buffer
+	schemaCmd.SetOut(&stdout)
+	t.Cleanup(func() { schemaCmd.SetOut(nil) })
+
+	if err := runSchema(schemaCmd, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var s cliSchema
+	if err := json.Unmarshal(stdout.Bytes(), &s); err != nil {
+		t.Fatalf("schema is not valid JSON: %v\n%s", err, stdout.String())
+	}
+	if s.Name != "notte" {
+		t.Errorf("name = %q, want notte", s.Name)
+	}
+
+	var output *schemaFlag
+	for i, f := range s.GlobalFlags {
+		if f.Name == "output" {
+			output = &s.GlobalFlags[i]
+		}
+	}
+	if output == nil || output.Shorthand != "o" || output.Default != "text" || !output.Persistent {
+		t.Errorf("unexpected global --output flag: %+v", output)
+	}
+
+	start := findSchemaCommand(t, s, "notte agents start")
+	if !start.Runnable {
+		t.Error("agents start should be runnable")
+	}
+	task := findSchemaFlag(t, start, "task")
+	if !task.Required || task.Type != "string" {
+		t.Errorf("unexpected --task flag: %+v", task)
+	}
+	if model := findSchemaFlag(t, start, "reasoning-model"); len(model.Suggestions) == 0 || len(model.Enum) != 0 {
+		t.Errorf("expected --reasoning-model suggestions only: %+v", model)
+	}
+	for _, f := range start.Flags {
+		if f.Name == "output" {
+			t.Error("global flags should not be repeated per command")
+		}
+	}
+
+	sessionStart := findSchemaCommand(t, s, "notte sessions start")
+	browser := findSchemaFlag(t, sessionStart, "browser-type")
+	if len(browser.Enum) == 0 || browser.Enum[0] != "chromium" {
+		t.Errorf("expected --browser-type enum values: %+v", browser)
+	}
+
+	// Persistent flags of a parent show up on its subcommands
+	add := findSchemaCommand(t, s, "notte vaults credentials add")
+	if vaultID := findSchemaFlag(t, add, "vault-id"); !vaultID.Persistent || !vaultID.Required {
+		t.Errorf("unexpected inherited --vault-id flag: %+v", vaultID)
+	}
+
+	for _, c := range s.Commands {
+		if c.Path == "notte __schema" || c.Path == "notte dev" {
+			t.Errorf("hidden command %q should not be in schema", c.Path)
+		}
+	}
+}
@@ -0,0 +1,447 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// dataValidationError lists every place where data doesn't conform to a
+// user-supplied JSON Schema
+type dataValidationError struct {
+	what     string
+	problems []schemaProblem
+}
+
+func (e *dataValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s does not match the schema (%d problem(s)):", e.what, len(e.problems))
+	for _, p := range e.problems {
+		path := p.Path
+		if path == "" {
+			path = "(root)"
+		}
+		fmt.Fprintf(&b, "\n  %s: %s", path, p.Msg)
+	}
+	return b.String()
+}
+
+// loadValidationSchema reads the JSON Schema given to flagName as inline
+// JSON, @file or '-' for stdin
+func loadValidationSchema(cmd *cobra.Command, value, flagName string) (map[string]any, error) {
+	data, err := readJSONInput(cmd, value, flagName)
+	if err != nil {
+		return nil, err
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, i18n.Errorf(i18n.InvalidJSONSchema, flagName, err)
+	}
+	if problems := checkJSONSchema("", schema); len(problems) > 0 {
+		return nil, &dataValidationError{what: "--" + flagName + " schema", problems: problems}
+	}
+	return schema, nil
+}
+
+// validateData checks v, decoded from JSON, against schema and returns a
+// *dataValidationError listing every mismatch, or nil
+func validateData(what string, schema map[string]any, v any) error {
+	sv := &schemaValidator{root: schema}
+	if problems := sv.validate("", schema, v); len(problems) > 0 {
+		return &dataValidationError{what: what, problems: problems}
+	}
+	return nil
+}
+
+// schemaValidator validates values against a JSON Schema. It covers the
+// keywords extraction schemas use (types, properties, items, enums, bounds,
+// patterns, common formats, combinators and local $refs); others are
+// ignored.
+type schemaValidator struct {
+	root map[string]any
+}
+
+func (sv *schemaValidator) validate(path string, schema any, v any) []schemaProblem {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			return []schemaProblem{{path, "no value is allowed here"}}
+		}
+		return nil
+	case map[string]any:
+		return sv.validateObject(path, s, v)
+	}
+	return nil
+}
+
+func (sv *schemaValidator) validateObject(path string, s map[string]any, v any) []schemaProblem {
+	if ref, ok := s["$ref"].(string); ok {
+		target, err := sv.resolve(ref)
+		if err != nil {
+			return []schemaProblem{{path, err.Error()}}
+		}
+		return sv.validate(path, target, v)
+	}
+
+	if t, ok := s["type"]; ok {
+		if msg := checkJSONType(t, v); msg != "" {
+			return []schemaProblem{{path, msg}}
+		}
+	}
+
+	var problems []schemaProblem
+	add := func(msg string, args ...any) {
+		problems = append(problems, schemaProblem{path, fmt.Sprintf(msg, args...)})
+	}
+
+	if enum, ok := s["enum"].([]any); ok && !containsJSON(enum, v) {
+		add("expected one of %s, got %s", jsonList(enum), jsonText(v))
+	}
+	if c, ok := s["const"]; ok && !reflect.DeepEqual(c, v) {
+		add("expected %s, got %s", jsonText(c), jsonText(v))
+	}
+
+	switch val := v.(type) {
+	case string:
+		n := utf8.RuneCountInString(val)
+		if lo, ok := schemaNumber(s, "minLength"); ok && float64(n) < lo {
+			add("expected at least %s characters, got %d", formatNumber(lo), n)
+		}
+		if hi, ok := schemaNumber(s, "maxLength"); ok && float64(n) > hi {
+			add("expected at most %s characters, got %d", formatNumber(hi), n)
+		}
+		if pattern, ok := s["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				add("schema pattern %q is not a valid regular expression", pattern)
+			} else if !re.MatchString(val) {
+				add("%s does not match pattern %q", jsonText(val), pattern)
+			}
+		}
+		if format, ok := s["format"].(string); ok && !matchesFormat(format, val) {
+			add("%s is not a valid %s", jsonText(val), format)
+		}
+	case float64:
+		problems = append(problems, checkNumberBounds(path, s, val)...)
+	case []any:
+		problems = append(problems, sv.validateArray(path, s, val)...)
+	case map[string]any:
+		problems = append(problems, sv.validateProperties(path, s, val)...)
+	}
+
+	if all, ok := s["allOf"].([]any); ok {
+		for _, sub := range all {
+			problems = append(problems, sv.validate(path, sub, v)...)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]any); ok && sv.countMatches(path, anyOf, v) == 0 {
+		add("does not match any of the %d allowed schemas", len(anyOf))
+	}
+	if oneOf, ok := s["oneOf"].([]any); ok {
+		if n := sv.countMatches(path, oneOf, v); n != 1 {
+			add("expected to match exactly one of %d schemas, matched %d", len(oneOf), n)
+		}
+	}
+	if not, ok := s["not"]; ok && len(sv.validate(path, not, v)) == 0 {
+		add("must not match the schema under \"not\"")
+	}
+	return problems
+}
+
+func (sv *schemaValidator) countMatches(path string, schemas []any, v any) int {
+	n := 0
+	for _, sub := range schemas {
+		if len(sv.validate(path, sub, v)) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func (sv *schemaValidator) validateArray(path string, s map[string]any, items []any) []schemaProblem {
+	var problems []schemaProblem
+	if lo, ok := schemaNumber(s, "minItems"); ok && float64(len(items)) < lo {
+		problems = append(problems, schemaProblem{path, fmt.Sprintf("expected at least %s items, got %d", formatNumber(lo), len(items))})
+	}
+	if hi, ok := schemaNumber(s, "maxItems"); ok && float64(len(items)) > hi {
+		problems = append(problems, schemaProblem{path, fmt.Sprintf("expected at most %s items, got %d", formatNumber(hi), len(items))})
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+		for i := range items {
+			for j := range i {
+				if reflect.DeepEqual(items[i], items[j]) {
+					problems = append(problems, schemaProblem{fmt.Sprintf("%s[%d]", path, i), fmt.Sprintf("duplicate of item %d", j)})
+					break
+				}
+			}
+		}
+	}
+
+	// prefixItems (or an items array, before 2020-12) checks items by
+	// position; items then applies to the rest
+	var prefix []any
+	rest, hasRest := s["items"]
+	if p, ok := s["prefixItems"].([]any); ok {
+		prefix = p
+	} else if p, ok := rest.([]any); ok {
+		prefix, rest, hasRest = p, s["additionalItems"], s["additionalItems"] != nil
+	}
+	for i, item := range items {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if i < len(prefix) {
+			problems = append(problems, sv.validate(itemPath, prefix[i], item)...)
+		} else if hasRest {
+			problems = append(problems, sv.validate(itemPath, rest, item)...)
+		}
+	}
+	return problems
+}
+
+func (sv *schemaValidator) validateProperties(path string, s map[string]any, obj map[string]any) []schemaProblem {
+	var problems []schemaProblem
+	if required, ok := s["required"].([]any); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, present := obj[name]; !present {
+					problems = append(problems, schemaProblem{joinPath(path, name), "required field is missing"})
+				}
+			}
+		}
+	}
+	if lo, ok := schemaNumber(s, "minProperties"); ok && float64(len(obj)) < lo {
+		problems = append(problems, schemaProblem{path, fmt.Sprintf("expected at least %s properties, got %d", formatNumber(lo), len(obj))})
+	}
+	if hi, ok := schemaNumber(s, "maxProperties"); ok && float64(len(obj)) > hi {
+		problems = append(problems, schemaProblem{path, fmt.Sprintf("expected at most %s properties, got %d", formatNumber(hi), len(obj))})
+	}
+
+	props, _ := s["properties"].(map[string]any)
+	patterns, _ := s["patternProperties"].(map[string]any)
+	additional, hasAdditional := s["additionalProperties"]
+	for _, key := range sortedKeys(obj) {
+		keyPath := joinPath(path, key)
+		matched := false
+		if sub, ok := props[key]; ok {
+			matched = true
+			problems = append(problems, sv.validate(keyPath, sub, obj[key])...)
+		}
+		for _, pattern := range sortedKeys(patterns) {
+			if re, err := regexp.Compile(pattern); err == nil && re.MatchString(key) {
+				matched = true
+				problems = append(problems, sv.validate(keyPath, patterns[pattern], obj[key])...)
+			}
+		}
+		if matched || !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok && !allowed {
+			msg := "unknown field"
+			if s := closestName(key, sortedKeys(props)); s != "" {
+				msg += fmt.Sprintf(" (did you mean %q?)", s)
+			}
+			problems = append(problems, schemaProblem{keyPath, msg})
+			continue
+		}
+		problems = append(problems, sv.validate(keyPath, additional, obj[key])...)
+	}
+	return problems
+}
+
+// resolve follows a local reference such as "#/$defs/Item"
+func (sv *schemaValidator) resolve(ref string) (any, error) {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, i18n.Errorf(i18n.SchemaReferenceNotLocal, ref)
+	}
+	var node any = sv.root
+	for _, part := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if part == "" {
+			continue
+		}
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		obj, ok := node.(map[string]any)
+		if !ok {
+			return nil, i18n.Errorf(i18n.SchemaReferenceNotFound, ref)
+		}
+		if node, ok = obj[part]; !ok {
+			return nil, i18n.Errorf(i18n.SchemaReferenceNotFound, ref)
+		}
+	}
+	return node, nil
+}
+
+// checkJSONType returns why v isn't of the schema type t, or ""
+func checkJSONType(t any, v any) string {
+	var kinds []string
+	switch tt := t.(type) {
+	case string:
+		kinds = []string{tt}
+	case []any:
+		for _, k := range tt {
+			if s, ok := k.(string); ok {
+				kinds = append(kinds, s)
+			}
+		}
+	}
+	for _, k := range kinds {
+		if isJSONType(k, v) {
+			return ""
+		}
+	}
+	want := make([]string, len(kinds))
+	for i, k := range kinds {
+		want[i] = jsonTypeArticle(k)
+	}
+	return fmt.Sprintf("expected %s, got %s", strings.Join(want, " or "), jsonKind(v))
+}
+
+func isJSONType(kind string, v any) bool {
+	switch kind {
+	case "null":
+		return v == nil
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	case "integer":
+		n, ok := v.(float64)
+		return ok && n == math.Trunc(n)
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	}
+	return false
+}
+
+func jsonTypeArticle(kind string) string {
+	switch kind {
+	case "null":
+		return "null"
+	case "array", "object", "integer":
+		return "an " + kind
+	}
+	return "a " + kind
+}
+
+func checkNumberBounds(path string, s map[string]any, n float64) []schemaProblem {
+	var problems []schemaProblem
+	add := func(msg string) {
+		problems = append(problems, schemaProblem{path, fmt.Sprintf("%s, got %s", msg, formatNumber(n))})
+	}
+	// Draft 4 marks exclusive bounds with booleans next to minimum/maximum
+	exclMin, _ := s["exclusiveMinimum"].(bool)
+	exclMax, _ := s["exclusiveMaximum"].(bool)
+	if lo, ok := schemaNumber(s, "minimum"); ok {
+		if exclMin && n <= lo {
+			add("expected more than " + formatNumber(lo))
+		} else if n < lo {
+			add("expected at least " + formatNumber(lo))
+		}
+	}
+	if hi, ok := schemaNumber(s, "maximum"); ok {
+		if exclMax && n >= hi {
+			add("expected less than " + formatNumber(hi))
+		} else if n > hi {
+			add("expected at most " + formatNumber(hi))
+		}
+	}
+	if lo, ok := schemaNumber(s, "exclusiveMinimum"); ok && n <= lo {
+		add("expected more than " + formatNumber(lo))
+	}
+	if hi, ok := schemaNumber(s, "exclusiveMaximum"); ok && n >= hi {
+		add("expected less than " + formatNumber(hi))
+	}
+	if m, ok := schemaNumber(s, "multipleOf"); ok && m > 0 {
+		if q := n / m; math.Abs(q-math.Round(q)) > 1e-9 {
+			add("expected a multiple of " + formatNumber(m))
+		}
+	}
+	return problems
+}
+
+// matchesFormat checks the string formats extraction schemas commonly use;
+// unknown formats always match
+func matchesFormat(format, s string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	case "date":
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	case "time":
+		_, err := time.Parse("15:04:05Z07:00", s)
+		if err != nil {
+			_, err = time.Parse(time.TimeOnly, s)
+		}
+		return err == nil
+	case "email":
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	case "uri", "url":
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != "" && (u.Host != "" || u.Opaque != "")
+	case "uuid":
+		return uuidPattern.MatchString(s)
+	}
+	return true
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func schemaNumber(s map[string]any, key string) (float64, bool) {
+	n, ok := s[key].(float64)
+	return n, ok
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func containsJSON(list []any, v any) bool {
+	for _, item := range list {
+		if reflect.DeepEqual(item, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonText(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if r := []rune(string(data)); len(r) > 60 {
+		return string(r[:57]) + "..."
+	}
+	return string(data)
+}
+
+func jsonList(list []any) string {
+	parts := make([]string, len(list))
+	for i, item := range list {
+		parts[i] = jsonText(item)
+	}
+	return strings.Join(parts, ", ")
+}
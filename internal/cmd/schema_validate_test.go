@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+const productSchemaTest = `{
+  "type": "object",
+  "required": ["products"],
+  "properties": {
+    "products": {
+      "type": "array",
+      "minItems": 1,
+      "items": {"$ref": "#/$defs/Product"}
+    },
+    "currency": {"enum": ["USD", "EUR"]}
+  },
+  "$defs": {
+    "Product": {
+      "type": "object",
+      "required": ["name", "price"],
+      "additionalProperties": false,
+      "properties": {
+        "name": {"type": "string", "minLength": 1},
+        "price": {"type": "number", "minimum": 0},
+        "url": {"type": "string", "format": "uri"},
+        "rating": {"anyOf": [{"type": "integer", "maximum": 5}, {"type": "null"}]}
+      }
+    }
+  }
+}`
+
+func validateTestData(t *testing.T, schemaJSON, dataJSON string) []schemaProblem {
+	t.Helper()
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(schemaJSON), &schema); err != nil {
+		t.Fatalf("bad schema: %v", err)
+	}
+	var data any
+	if err := json.Unmarshal([]byte(dataJSON), &data); err != nil {
+		t.Fatalf("bad data: %v", err)
+	}
+	err := validateData("data", schema, data)
+	if err == nil {
+		return nil
+	}
+	var verr *dataValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("unexpected error type: %v", err)
+	}
+	return verr.problems
+}
+
+func TestValidateData(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "valid",
+			data: `{"products":[{"name":"Mug","price":9.5,"url":"https://shop.test/mug","rating":null}],"currency":"USD"}`,
+		},
+		{
+			name: "missing root field",
+			data: `{}`,
+			want: []string{"products: required field is missing"},
+		},
+		{
+			name: "per item problems",
+			data: `{"products":[{"name":"","price":-1,"colour":"red"},{"price":"3","url":"mug","rating":7}],"currency":"GBP"}`,
+			want: []string{
+				`currency: expected one of "USD", "EUR", got "GBP"`,
+				"products[0].name: expected at least 1 characters, got 0",
+				"products[0].price: expected at least 0, got -1",
+				`products[0].colour: unknown field`,
+				"products[1].name: required field is missing",
+				"products[1].price: expected a number, got a string",
+				"products[1].rating: does not match any of the 2 allowed schemas",
+				`products[1].url: "mug" is not a valid uri`,
+			},
+		},
+		{
+			name: "empty array",
+			data: `{"products":[]}`,
+			want: []string{"products: expected at least 1 items, got 0"},
+		},
+		{
+			name: "wrong root type",
+			data: `[1]`,
+			want: []string{"(root): expected an object, got an array"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, p := range validateTestData(t, productSchemaTest, tt.data) {
+				path := p.Path
+				if path == "" {
+					path = "(root)"
+				}
+				got = append(got, path+": "+p.Msg)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got problems %q, want %q", got, tt.want)
+			}
+			for _, w := range tt.want {
+				found := false
+				for _, g := range got {
+					found = found || g == w
+				}
+				if !found {
+					t.Errorf("missing problem %q in %q", w, got)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateData_Keywords(t *testing.T) {
+	tests := []struct {
+		schema string
+		data   string
+		ok     bool
+	}{
+		{`{"type":"integer"}`, `3`, true},
+		{`{"type":"integer"}`, `3.5`, false},
+		{`{"type":["string","null"]}`, `null`, true},
+		{`{"const":"a"}`, `"b"`, false},
+		{`{"pattern":"^[A-Z]{3}$"}`, `"USD"`, true},
+		{`{"pattern":"^[A-Z]{3}$"}`, `"usd"`, false},
+		{`{"format":"date"}`, `"2024-02-30"`, false},
+		{`{"format":"date-time"}`, `"2024-02-01T10:00:00Z"`, true},
+		{`{"format":"email"}`, `"a@b.test"`, true},
+		{`{"exclusiveMinimum":0}`, `0`, false},
+		{`{"minimum":0,"exclusiveMinimum":true}`, `0`, false},
+		{`{"multipleOf":0.01}`, `1.23`, true},
+		{`{"uniqueItems":true}`, `[1,2,1]`, false},
+		{`{"prefixItems":[{"type":"string"}],"items":{"type":"number"}}`, `["a",1,2]`, true},
+		{`{"prefixItems":[{"type":"string"}],"items":{"type":"number"}}`, `["a","b"]`, false},
+		{`{"oneOf":[{"type":"number"},{"type":"integer"}]}`, `1`, false},
+		{`{"not":{"type":"null"}}`, `null`, false},
+		{`{"allOf":[{"minLength":2},{"maxLength":3}]}`, `"abcd"`, false},
+		{`{"additionalProperties":{"type":"string"}}`, `{"a":"x","b":1}`, false},
+		{`{"patternProperties":{"^x-":{"type":"string"}},"additionalProperties":false}`, `{"x-a":"1"}`, true},
+		{`{"$ref":"#/definitions/missing"}`, `1`, false},
+		{`false`, `1`, false},
+	}
+	for _, tt := range tests {
+		var schema any
+		if err := json.Unmarshal([]byte(tt.schema), &schema); err != nil {
+			t.Fatalf("bad schema %s: %v", tt.schema, err)
+		}
+		var data any
+		_ = json.Unmarshal([]byte(tt.data), &data)
+		root, _ := schema.(map[string]any)
+		problems := (&schemaValidator{root: root}).validate("", schema, data)
+		if ok := len(problems) == 0; ok != tt.ok {
+			t.Errorf("%s on %s: ok = %v, want %v (%v)", tt.schema, tt.data, ok, tt.ok, problems)
+		}
+	}
+}
+
+func TestRunSessionScrape_Validate(t *testing.T) {
+	server := setupSessionTest(t)
+	scrapeResp := fmt.Sprintf(`{"markdown":"hi","structured":{"data":{"products":[{"name":"Mug","price":"9.50"}]},"success":true},"session":%s}`, sessionJSON())
+	server.AddResponse("/sessions/"+sessionIDTest+"/page/scrape", 200, scrapeResp)
+
+	schemaPath := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(productSchemaTest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	origInstructions, origValidate := sessionScrapeInstructions, sessionScrapeValidate
+	t.Cleanup(func() { sessionScrapeInstructions, sessionScrapeValidate = origInstructions, origValidate })
+	sessionScrapeInstructions = "extract the products"
+	sessionScrapeValidate = "@" + schemaPath
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		err := runSessionScrape(cmd, nil)
+		if err == nil || !strings.Contains(err.Error(), "products[0].price: expected a number, got a string") {
+			t.Errorf("expected a per-field report, got %v", err)
+		}
+	})
+	if stdout != "" {
+		t.Errorf("expected no data output on failure, got %q", stdout)
+	}
+
+	sessionScrapeInstructions = ""
+	if err := runSessionScrape(cmd, nil); err == nil || !strings.Contains(err.Error(), "--instructions") {
+		t.Errorf("expected --instructions error, got %v", err)
+	}
+}
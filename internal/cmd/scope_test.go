@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+func TestGetScopeFlag(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantScope string
+		wantOnly  *bool
+		wantErr   string
+	}{
+		{value: "", wantScope: ""},
+		{value: "me", wantScope: scopeMe, wantOnly: boolPtr(true)},
+		{value: "org", wantScope: scopeOrg, wantOnly: boolPtr(false)},
+		{value: "team", wantErr: "not supported"},
+		{value: "all", wantErr: "invalid --scope"},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{}
+		registerScopeFlag(cmd)
+		if tt.value != "" {
+			_ = cmd.Flags().Set("scope", tt.value)
+		}
+
+		scope, only, err := getScopeFlag(cmd)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("--scope %q: expected error containing %q, got %v", tt.value, tt.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("--scope %q: unexpected error: %v", tt.value, err)
+		}
+		if scope != tt.wantScope || (only == nil) != (tt.wantOnly == nil) || (only != nil && *only != *tt.wantOnly) {
+			t.Errorf("--scope %q: got (%q, %v), want (%q, %v)", tt.value, scope, only, tt.wantScope, tt.wantOnly)
+		}
+	}
+}
+
+func TestRunSessionsList_ScopeOrg(t *testing.T) {
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+
+	server := testutil.NewMockServer()
+	defer server.Close()
+	env.SetEnv("NOTTE_API_URL", server.URL())
+
+	session := func(id string) string {
+		return `{"session_id":"` + id + `","status":"active","created_at":"2025-01-01T00:00:00Z","last_accessed_at":"2025-01-01T00:00:00Z","idle_timeout_minutes":5}`
+	}
+	server.AddMatchedResponse(mockserver.Match{Path: "/sessions", Query: map[string]string{"only_current_token": "false"}},
+		mockserver.JSONResponse(200, `{"items":[`+session("sess_mine")+`,`+session("sess_theirs")+`],"page":1,"page_size":10,"has_next":false}`))
+	server.AddMatchedResponse(mockserver.Match{Path: "/sessions", Query: map[string]string{"only_current_token": "true"}},
+		mockserver.JSONResponse(200, `{"items":[`+session("sess_mine")+`],"page":1,"page_size":10,"has_next":false}`))
+
+	config.SetTestConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+
+	origFormat := outputFormat
+	outputFormat = "json"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	cmd := &cobra.Command{}
+	registerPaginationFlags(cmd)
+	registerScopeFlag(cmd)
+	_ = cmd.Flags().Set("scope", "org")
+	cmd.SetContext(context.Background())
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSessionsList(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var rows []struct {
+		Owner     string `json:"owner"`
+		SessionID string `json:"session_id"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &rows); err != nil {
+		t.Fatalf("failed to parse output %q: %v", stdout, err)
+	}
+	owners := map[string]string{}
+	for _, r := range rows {
+		owners[r.SessionID] = r.Owner
+	}
+	if owners["sess_mine"] != ownerMe || owners["sess_theirs"] != ownerOther {
+		t.Errorf("unexpected owners: %v", owners)
+	}
+}
+
+func TestRunSessionsList_ScopeTeamRejected(t *testing.T) {
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+
+	cmd := &cobra.Command{}
+	registerScopeFlag(cmd)
+	_ = cmd.Flags().Set("scope", "team")
+	cmd.SetContext(context.Background())
+
+	err := runSessionsList(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--scope org") {
+		t.Errorf("expected --scope team to be rejected, got %v", err)
+	}
+}
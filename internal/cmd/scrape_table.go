@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// csvOutputAnnotation marks commands that can print with -o csv
+const csvOutputAnnotation = "notte/csv-output"
+
+// IsCSVOutput returns true if the global output format is set to CSV.
+func IsCSVOutput() bool {
+	return outputFormat == "csv"
+}
+
+// checkCSVOutput rejects -o csv for commands that have no table to print
+func checkCSVOutput(cmd *cobra.Command) error {
+	if IsCSVOutput() && cmd.Annotations[csvOutputAnnotation] == "" {
+		return i18n.Errorf(i18n.CSVNeedsScrapeInstructions)
+	}
+	return nil
+}
+
+// dataTable is structured data laid out as rows of columns. Cells hold
+// decoded JSON scalars; nested values are kept as their JSON text.
+type dataTable struct {
+	headers []string
+	rows    [][]any
+}
+
+// structuredTable lays out structured scrape data as a table. The data must
+// be an array of objects (or scalars), or an object whose only field is
+// such an array, as extraction schemas often wrap their list. Columns are
+// the union of the objects' keys, in the order they first appear.
+func structuredTable(raw []byte) (*dataTable, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '{' {
+		keys, values, err := orderedObject(raw)
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) != 1 || !bytes.HasPrefix(bytes.TrimSpace(values[0]), []byte("[")) {
+			return nil, errors.New(i18n.T(i18n.StructuredDataIsObject))
+		}
+		raw = values[0]
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, errors.New(i18n.T(i18n.StructuredDataNotRows))
+	}
+
+	t := &dataTable{}
+	seen := map[string]bool{}
+	addColumn := func(key string) {
+		if !seen[key] {
+			seen[key] = true
+			t.headers = append(t.headers, key)
+		}
+	}
+	records := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		rec := map[string]any{}
+		item = bytes.TrimSpace(item)
+		if len(item) == 0 || item[0] != '{' {
+			cell, err := tableCell(item)
+			if err != nil {
+				return nil, err
+			}
+			addColumn("value")
+			rec["value"] = cell
+			records = append(records, rec)
+			continue
+		}
+		keys, values, err := orderedObject(item)
+		if err != nil {
+			return nil, err
+		}
+		for i, key := range keys {
+			cell, err := tableCell(values[i])
+			if err != nil {
+				return nil, err
+			}
+			addColumn(key)
+			rec[key] = cell
+		}
+		records = append(records, rec)
+	}
+
+	for _, rec := range records {
+		row := make([]any, len(t.headers))
+		for i, key := range t.headers {
+			row[i] = rec[key]
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t, nil
+}
+
+// orderedObject splits a JSON object into its keys and raw values, keeping
+// the order they were sent in
+func orderedObject(raw []byte) ([]string, []json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, nil, errors.New(i18n.T(i18n.ExpectedJSONObject))
+	}
+	var keys []string
+	var values []json.RawMessage
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return keys, values, nil
+}
+
+// tableCell decodes a scalar cell; objects and arrays stay JSON text
+func tableCell(raw json.RawMessage) (any, error) {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	switch v.(type) {
+	case map[string]any, []any:
+		return string(bytes.TrimSpace(raw)), nil
+	}
+	return v, nil
+}
+
+// cellText renders a cell the same way in every row: numbers without
+// exponents, booleans as true/false and null as empty
+func cellText(v any) string {
+	switch c := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return c
+	case float64:
+		return strconv.FormatFloat(c, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(c)
+	}
+	return fmt.Sprint(v)
+}
+
+// writeCSV writes the table with a header row. Text cells that a
+// spreadsheet would run as a formula are prefixed with a quote, since
+// scraped pages are untrusted input.
+func (t *dataTable) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.headers); err != nil {
+		return err
+	}
+	for _, row := range t.rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			record[i] = cellText(cell)
+			if s, ok := cell.(string); ok && isFormulaLike(s) {
+				record[i] = "'" + s
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func isFormulaLike(s string) bool {
+	if s == "" || !strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return false
+	}
+	_, err := strconv.ParseFloat(s, 64)
+	return err != nil
+}
+
+// xlsxNumber returns the number a text cell holds, so it can be stored as
+// a numeric cell. Values with leading zeros (IDs, zip codes) stay text.
+func xlsxNumber(s string) (float64, bool) {
+	trimmed := strings.TrimLeft(s, "-")
+	if len(trimmed) > 1 && trimmed[0] == '0' && trimmed[1] != '.' {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || strings.ContainsAny(s, "eEnNiI") {
+		return 0, false
+	}
+	return n, true
+}
+
+// xlsx renders the table as a single-sheet Excel workbook. Numbers and
+// booleans, including numeric text, become typed cells.
+func (t *dataTable) xlsx() ([]byte, error) {
+	var sheet strings.Builder
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	writeRow := func(r int, cells []any) {
+		fmt.Fprintf(&sheet, `<row r="%d">`, r)
+		for c, cell := range cells {
+			ref := xlsxColumn(c) + strconv.Itoa(r)
+			switch v := cell.(type) {
+			case nil:
+				continue
+			case float64:
+				fmt.Fprintf(&sheet, `<c r="%s"><v>%s</v></c>`, ref, cellText(v))
+			case bool:
+				b := 0
+				if v {
+					b = 1
+				}
+				fmt.Fprintf(&sheet, `<c r="%s" t="b"><v>%d</v></c>`, ref, b)
+			default:
+				s := cellText(v)
+				if n, ok := xlsxNumber(s); ok && r > 1 {
+					fmt.Fprintf(&sheet, `<c r="%s"><v>%s</v></c>`, ref, cellText(n))
+					continue
+				}
+				fmt.Fprintf(&sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlText(s))
+			}
+		}
+		sheet.WriteString(`</row>`)
+	}
+	headers := make([]any, len(t.headers))
+	for i, h := range t.headers {
+		headers[i] = h
+	}
+	writeRow(1, headers)
+	for i, row := range t.rows {
+		writeRow(i+2, row)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	files := []struct{ name, body string }{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Scrape" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`},
+		{"xl/worksheets/sheet1.xml", sheet.String()},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(w, f.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// xlsxColumn converts a zero-based column index to its letters (A, Z, AA)
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// xmlText escapes s for an XML text node, dropping characters XML can't hold
+func xmlText(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, s)
+	return html.EscapeString(s)
+}
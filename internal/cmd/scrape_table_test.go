@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func TestStructuredTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		headers []string
+		rows    [][]any
+		wantErr bool
+	}{
+		{
+			name:    "array of objects, header union in order",
+			data:    `[{"name":"Mug","price":9.5},{"price":3,"name":"Cup","stock":true}]`,
+			headers: []string{"name", "price", "stock"},
+			rows:    [][]any{{"Mug", 9.5, nil}, {"Cup", float64(3), true}},
+		},
+		{
+			name:    "wrapped list",
+			data:    `{"products":[{"name":"Mug","tags":["a","b"]}]}`,
+			headers: []string{"name", "tags"},
+			rows:    [][]any{{"Mug", `["a","b"]`}},
+		},
+		{
+			name:    "scalars",
+			data:    `["a","b"]`,
+			headers: []string{"value"},
+			rows:    [][]any{{"a"}, {"b"}},
+		},
+		{name: "object", data: `{"title":"x","count":1}`, wantErr: true},
+		{name: "scalar", data: `"x"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := structuredTable([]byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fmt.Sprint(table.headers) != fmt.Sprint(tt.headers) {
+				t.Errorf("headers = %v, want %v", table.headers, tt.headers)
+			}
+			if fmt.Sprintf("%#v", table.rows) != fmt.Sprintf("%#v", tt.rows) {
+				t.Errorf("rows = %#v, want %#v", table.rows, tt.rows)
+			}
+		})
+	}
+}
+
+func TestDataTableWriteCSV(t *testing.T) {
+	table, err := structuredTable([]byte(`[{"name":"=HYPERLINK(\"x\")","price":1000000,"ok":false,"delta":"-5","note":null},{"name":"A, B"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := table.writeCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "name,price,ok,delta,note\n\"'=HYPERLINK(\"\"x\"\")\",1000000,false,-5,\n\"A, B\",,,,\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestXLSXNumber(t *testing.T) {
+	tests := map[string]bool{"12": true, "-3.5": true, "0.25": true, "0": true, "007": false, "1e5": false, "NaN": false, "$5": false, "": false}
+	for in, want := range tests {
+		if _, ok := xlsxNumber(in); ok != want {
+			t.Errorf("xlsxNumber(%q) ok = %v, want %v", in, ok, want)
+		}
+	}
+}
+
+func TestXLSXColumn(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumn(i); got != want {
+			t.Errorf("xlsxColumn(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestDataTableXLSX(t *testing.T) {
+	table, err := structuredTable([]byte(`[{"name":"Mug & <Co>","price":"9.50","in_stock":true,"zip":"02134"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := table.xlsx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("not a zip: %v", err)
+	}
+	var sheet string
+	for _, f := range zr.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, _ := f.Open()
+			b, _ := io.ReadAll(rc)
+			_ = rc.Close()
+			sheet = string(b)
+		}
+	}
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">name</t></is></c>`,
+		`<c r="A2" t="inlineStr"><is><t xml:space="preserve">Mug &amp; &lt;Co&gt;</t></is></c>`,
+		`<c r="B2"><v>9.5</v></c>`,
+		`<c r="C2" t="b"><v>1</v></c>`,
+		`<c r="D2" t="inlineStr"><is><t xml:space="preserve">02134</t></is></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %s:\n%s", want, sheet)
+		}
+	}
+}
+
+func TestRunSessionScrape_CSV(t *testing.T) {
+	server := setupSessionTest(t)
+	scrapeResp := fmt.Sprintf(`{"markdown":"hi","structured":{"data":{"items":[{"title":"B","rank":2},{"title":"A","rank":1}]},"success":true},"session":%s}`, sessionJSON())
+	server.AddResponse("/sessions/"+sessionIDTest+"/page/scrape", 200, scrapeResp)
+
+	origInstructions := sessionScrapeInstructions
+	sessionScrapeInstructions = "extract"
+	t.Cleanup(func() { sessionScrapeInstructions = origInstructions })
+
+	origFormat := outputFormat
+	outputFormat = "csv"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSessionScrape(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if stdout != "title,rank\nB,2\nA,1\n" {
+		t.Errorf("unexpected CSV: %q", stdout)
+	}
+
+	sessionScrapeInstructions = ""
+	if err := runSessionScrape(cmd, nil); err == nil || !strings.Contains(err.Error(), "--instructions") {
+		t.Errorf("expected --instructions error, got %v", err)
+	}
+}
+
+func TestRunSessionScrape_XLSX(t *testing.T) {
+	server := setupSessionTest(t)
+	scrapeResp := fmt.Sprintf(`{"markdown":"hi","structured":{"data":[{"title":"A"},{"title":"B"}],"success":true},"session":%s}`, sessionJSON())
+	server.AddResponse("/sessions/"+sessionIDTest+"/page/scrape", 200, scrapeResp)
+
+	origInstructions, origXLSX := sessionScrapeInstructions, sessionScrapeXLSX
+	t.Cleanup(func() { sessionScrapeInstructions, sessionScrapeXLSX = origInstructions, origXLSX })
+	sessionScrapeInstructions = "extract"
+	sessionScrapeXLSX = filepath.Join(t.TempDir(), "out.xlsx")
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSessionScrape(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "2 rows") {
+		t.Errorf("unexpected output: %q", stdout)
+	}
+	data, err := os.ReadFile(sessionScrapeXLSX)
+	if err != nil {
+		t.Fatalf("expected workbook: %v", err)
+	}
+	if _, err := zip.NewReader(bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Errorf("workbook is not a zip: %v", err)
+	}
+}
+
+func TestCheckCSVOutput(t *testing.T) {
+	origFormat := outputFormat
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	outputFormat = "csv"
+	if err := checkCSVOutput(sessionsListCmd); err == nil {
+		t.Error("expected -o csv to be rejected for sessions list")
+	}
+	if err := checkCSVOutput(pageScrapeCmd); err != nil {
+		t.Errorf("unexpected error for page scrape: %v", err)
+	}
+	outputFormat = "json"
+	if err := checkCSVOutput(sessionsListCmd); err != nil {
+		t.Errorf("unexpected error for json: %v", err)
+	}
+}
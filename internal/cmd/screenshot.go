@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/objstore"
+)
+
+// screenshotPlaceholder matches {name} in --name-template
+var screenshotPlaceholder = regexp.MustCompile(`\{([a-z-]+)\}`)
+
+// screenshotNameVars are the values available to --name-template
+type screenshotNameVars struct {
+	Time      time.Time
+	SessionID string
+	URL       string
+	Hash      string
+}
+
+// expandScreenshotName fills the placeholders of a --name-template:
+// {timestamp}, {unix}, {session}, {url-slug} and {hash}
+func expandScreenshotName(template string, vars screenshotNameVars) (string, error) {
+	var unknown []string
+	name := screenshotPlaceholder.ReplaceAllStringFunc(template, func(m string) string {
+		switch m[1 : len(m)-1] {
+		case "timestamp":
+			// No colons so the name is valid on Windows
+			return vars.Time.UTC().Format("20060102T150405.000Z")
+		case "unix":
+			return fmt.Sprint(vars.Time.Unix())
+		case "session":
+			return vars.SessionID
+		case "url-slug":
+			return urlSlug(vars.URL)
+		case "hash":
+			return vars.Hash[:12]
+		default:
+			unknown = append(unknown, m)
+			return m
+		}
+	})
+	if len(unknown) > 0 {
+		return "", i18n.Errorf(i18n.UnknownNamePlaceholder, unknown[0])
+	}
+	return sanitizeFilename(name), nil
+}
+
+// templateUses reports whether template contains {placeholder}
+func templateUses(template, placeholder string) bool {
+	return strings.Contains(template, "{"+placeholder+"}")
+}
+
+// slugUnsafe matches runs of characters that don't belong in a slug
+var slugUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+const maxSlugLength = 60
+
+// urlSlug turns a page URL into a short file-name friendly form, e.g.
+// https://www.example.com/a/b?c=1 -> example-com-a-b
+func urlSlug(raw string) string {
+	s := raw
+	if u, err := url.Parse(raw); err == nil && u.Host != "" {
+		s = strings.TrimPrefix(u.Hostname(), "www.") + u.Path
+	}
+	s = strings.Trim(slugUnsafe.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(s) > maxSlugLength {
+		s = strings.TrimRight(s[:maxSlugLength], "-")
+	}
+	if s == "" {
+		return "page"
+	}
+	return s
+}
+
+// fetchScreenshot takes a JPEG screenshot of the session's current page
+func fetchScreenshot(ctx context.Context, client *api.NotteClient, sessionID string) ([]byte, error) {
+	ctx, cancel := GetContextWithTimeout(ctx)
+	defer cancel()
+
+	// Construct the URL manually since this endpoint isn't in the generated client yet
+	endpoint := fmt.Sprintf("%s/sessions/%s/page/screenshot", client.BaseURL(), sessionID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return nil, i18n.Errorf(i18n.FailedToCreateRequest, err)
+	}
+
+	// Execute the request through the client's HTTP client (which has auth and retry)
+	resp, err := client.HTTPClient().Do(req)
+	if err != nil {
+		return nil, i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, i18n.Errorf(i18n.FailedToReadResponseBody, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if err := HandleAPIResponse(resp, data); err != nil {
+			return nil, err
+		}
+		return nil, i18n.Errorf(i18n.UnexpectedStatusCode, resp.StatusCode)
+	}
+	return data, nil
+}
+
+func imageHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// lastScreenshot remembers the previous screenshot of a session for --dedupe
+type lastScreenshot struct {
+	SHA256 string `json:"sha256"`
+	Path   string `json:"path"`
+}
+
+func lastScreenshotPath(sessionID string) (string, error) {
+	configDir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, config.ScreenshotStateDir, sessionID+".json"), nil
+}
+
+// loadLastScreenshot returns the previous screenshot of the session, if it
+// is still on disk
+func loadLastScreenshot(sessionID string) *lastScreenshot {
+	path, err := lastScreenshotPath(sessionID)
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var last lastScreenshot
+	if json.Unmarshal(data, &last) != nil {
+		return nil
+	}
+	// Uploaded frames can't be checked cheaply, so trust the record
+	if !objstore.IsURL(last.Path) {
+		if _, err := os.Stat(longPath(last.Path)); err != nil {
+			return nil
+		}
+	}
+	return &last
+}
+
+func saveLastScreenshot(sessionID string, last lastScreenshot) error {
+	path, err := lastScreenshotPath(sessionID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(last)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func clearLastScreenshot(sessionID string) {
+	if path, err := lastScreenshotPath(sessionID); err == nil {
+		_ = os.Remove(path)
+	}
+}
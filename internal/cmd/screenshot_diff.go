@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg" // decode JPEG screenshots and baselines
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// diffPixelTolerance is how far a color channel may drift (out of 255)
+// before a pixel counts as changed, so JPEG noise isn't reported
+const diffPixelTolerance = 24
+
+// imageDiff is the result of comparing a screenshot with its baseline
+type imageDiff struct {
+	Changed      int
+	Total        int
+	SizeMismatch bool
+	// Visual shows the screenshot faded, with changed pixels in red
+	Visual *image.RGBA
+}
+
+// Percent is the share of changed pixels, from 0 to 100
+func (d imageDiff) Percent() float64 {
+	if d.Total == 0 {
+		return 0
+	}
+	return float64(d.Changed) * 100 / float64(d.Total)
+}
+
+func decodeImageFile(path string) (image.Image, error) {
+	data, err := os.ReadFile(longPath(path))
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, i18n.Errorf(i18n.NotPNGOrJPEG, path, err)
+	}
+	return img, nil
+}
+
+// diffImages compares current against baseline pixel by pixel. When the
+// sizes differ, pixels covered by only one of the images count as changed.
+func diffImages(baseline, current image.Image) imageDiff {
+	bb, cb := baseline.Bounds(), current.Bounds()
+	w := max(bb.Dx(), cb.Dx())
+	h := max(bb.Dy(), cb.Dy())
+
+	d := imageDiff{
+		Total:        w * h,
+		SizeMismatch: bb.Dx() != cb.Dx() || bb.Dy() != cb.Dy(),
+		Visual:       image.NewRGBA(image.Rect(0, 0, w, h)),
+	}
+	draw.Draw(d.Visual, d.Visual.Bounds(), image.White, image.Point{}, draw.Src)
+
+	changed := color.RGBA{R: 255, A: 255}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			inBase := x < bb.Dx() && y < bb.Dy()
+			inCur := x < cb.Dx() && y < cb.Dy()
+			if inCur {
+				d.Visual.Set(x, y, fadedGray(current.At(cb.Min.X+x, cb.Min.Y+y)))
+			}
+			if !inBase || !inCur || pixelChanged(baseline.At(bb.Min.X+x, bb.Min.Y+y), current.At(cb.Min.X+x, cb.Min.Y+y)) {
+				d.Changed++
+				d.Visual.Set(x, y, changed)
+			}
+		}
+	}
+	return d
+}
+
+func pixelChanged(a, b color.Color) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	for _, pair := range [][2]uint32{{ar, br}, {ag, bg}, {ab, bb}, {aa, ba}} {
+		// RGBA returns 16-bit channels
+		delta := int(pair[0]>>8) - int(pair[1]>>8)
+		if delta > diffPixelTolerance || -delta > diffPixelTolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// fadedGray lightens c to a pale gray so changes stand out on top of it
+func fadedGray(c color.Color) color.Color {
+	g := color.GrayModel.Convert(c).(color.Gray)
+	return color.Gray{Y: 255 - (255-g.Y)/3}
+}
+
+// defaultDiffPath puts the visual diff next to the screenshot
+func defaultDiffPath(screenshotPath string) string {
+	return strings.TrimSuffix(screenshotPath, filepath.Ext(screenshotPath)) + ".diff.png"
+}
+
+func writeDiffImage(ctx context.Context, path string, img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return writeOutputFile(ctx, path, buf.Bytes(), 0o644)
+}
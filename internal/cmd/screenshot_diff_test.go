@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+// solidImage returns a w x h image of c with the top-left n pixels of the
+// first row painted black
+func solidImage(w, h int, c color.Color, n int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+	for x := 0; x < n; x++ {
+		img.Set(x, 0, color.Black)
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDiffImages(t *testing.T) {
+	white := color.RGBA{255, 255, 255, 255}
+	tests := []struct {
+		name         string
+		baseline     image.Image
+		current      image.Image
+		wantChanged  int
+		wantMismatch bool
+	}{
+		{"identical", solidImage(10, 10, white, 0), solidImage(10, 10, white, 0), 0, false},
+		{"within tolerance", solidImage(10, 10, white, 0), solidImage(10, 10, color.RGBA{240, 250, 255, 255}, 0), 0, false},
+		{"changed pixels", solidImage(10, 10, white, 0), solidImage(10, 10, white, 5), 5, false},
+		{"size mismatch", solidImage(10, 10, white, 0), solidImage(10, 12, white, 0), 20, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := diffImages(tt.baseline, tt.current)
+			if d.Changed != tt.wantChanged {
+				t.Errorf("changed = %d, want %d", d.Changed, tt.wantChanged)
+			}
+			if d.SizeMismatch != tt.wantMismatch {
+				t.Errorf("size mismatch = %v, want %v", d.SizeMismatch, tt.wantMismatch)
+			}
+		})
+	}
+
+	d := diffImages(solidImage(10, 10, white, 0), solidImage(10, 10, white, 5))
+	if d.Percent() != 5 {
+		t.Errorf("percent = %v, want 5", d.Percent())
+	}
+	if got := d.Visual.RGBAAt(0, 0); got != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("changed pixel = %v, want red", got)
+	}
+}
+
+func TestRunPageScreenshot_Diff(t *testing.T) {
+	white := color.RGBA{255, 255, 255, 255}
+	tests := []struct {
+		name      string
+		threshold float64
+		wantErr   bool
+	}{
+		{"over threshold", 0, true},
+		{"within threshold", 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupPageTest(t)
+			server.AddSequence("/sessions/"+pageSessionIDTest+"/page/screenshot",
+				mockserver.Response{StatusCode: 200, Body: string(encodePNG(t, solidImage(10, 10, white, 5)))},
+			)
+
+			dir := t.TempDir()
+			baseline := filepath.Join(dir, "baseline.png")
+			if err := os.WriteFile(baseline, encodePNG(t, solidImage(10, 10, white, 0)), 0o644); err != nil {
+				t.Fatalf("failed to write baseline: %v", err)
+			}
+
+			origDiff, origThreshold := pageScreenshotDiff, pageScreenshotThreshold
+			pageScreenshotDiff, pageScreenshotThreshold = baseline, tt.threshold
+			t.Cleanup(func() { pageScreenshotDiff, pageScreenshotThreshold = origDiff, origThreshold })
+
+			var err error
+			stdout, _ := testutil.CaptureOutput(func() {
+				err = runPageScreenshot(newPageTestCmd(), []string{filepath.Join(dir, "shot.png")})
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(stdout, `"diff_percent":5`) {
+				t.Errorf("expected diff percent in output, got %s", stdout)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "shot.diff.png")); err != nil {
+				t.Errorf("expected diff image: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunPageScreenshot_DiffMissingBaseline(t *testing.T) {
+	server := setupPageTest(t)
+
+	origDiff := pageScreenshotDiff
+	pageScreenshotDiff = filepath.Join(t.TempDir(), "missing.png")
+	t.Cleanup(func() { pageScreenshotDiff = origDiff })
+
+	if err := runPageScreenshot(newPageTestCmd(), nil); err == nil || !strings.Contains(err.Error(), "baseline") {
+		t.Fatalf("expected baseline error, got %v", err)
+	}
+	if got := len(server.Requests("/sessions/" + pageSessionIDTest + "/page/screenshot")); got != 0 {
+		t.Errorf("expected no screenshot request, got %d", got)
+	}
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+func TestExpandScreenshotName(t *testing.T) {
+	vars := screenshotNameVars{
+		Time:      time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC),
+		SessionID: "sess_1",
+		URL:       "https://www.example.com/pricing?plan=pro",
+		Hash:      strings.Repeat("ab", 32),
+	}
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"{timestamp}-{url-slug}.jpg", "20250102T150405.000Z-example-com-pricing.jpg"},
+		{"{session}-{unix}.jpg", "sess_1-1735830245.jpg"},
+		{"{hash}.jpg", "abababababab.jpg"},
+		{"../{session}.jpg", "sess_1.jpg"},
+	}
+	for _, tt := range tests {
+		got, err := expandScreenshotName(tt.template, vars)
+		if err != nil {
+			t.Fatalf("expandScreenshotName(%q): unexpected error: %v", tt.template, err)
+		}
+		if got != tt.want {
+			t.Errorf("expandScreenshotName(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+
+	if _, err := expandScreenshotName("{date}.jpg", vars); err == nil || !strings.Contains(err.Error(), "{date}") {
+		t.Errorf("expected unknown placeholder error, got %v", err)
+	}
+}
+
+func TestURLSlug(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://www.example.com/a/b?c=1", "example-com-a-b"},
+		{"https://example.com/", "example-com"},
+		{"about:blank", "about-blank"},
+		{"", "page"},
+		{"https://example.com/" + strings.Repeat("x", 100), "example-com-" + strings.Repeat("x", 48)},
+	}
+	for _, tt := range tests {
+		if got := urlSlug(tt.in); got != tt.want {
+			t.Errorf("urlSlug(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRunPageScreenshot_TemplateDedupe(t *testing.T) {
+	server := setupPageTest(t)
+	server.AddSequence("/sessions/"+pageSessionIDTest+"/page/screenshot",
+		mockserver.Response{StatusCode: 200, Body: "frame-1"},
+		mockserver.Response{StatusCode: 200, Body: "frame-1"},
+		mockserver.Response{StatusCode: 200, Body: "frame-2"},
+	)
+
+	dir := t.TempDir()
+	origTemplate, origDedupe := pageScreenshotNameTemplate, pageScreenshotDedupe
+	pageScreenshotNameTemplate, pageScreenshotDedupe = "{hash}.jpg", true
+	t.Cleanup(func() { pageScreenshotNameTemplate, pageScreenshotDedupe = origTemplate, origDedupe })
+
+	var outputs []string
+	for range 3 {
+		stdout, _ := testutil.CaptureOutput(func() {
+			if err := runPageScreenshot(newPageTestCmd(), []string{dir}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+		outputs = append(outputs, stdout)
+	}
+
+	if !strings.Contains(outputs[1], `"skipped":true`) {
+		t.Errorf("expected identical frame to be skipped, got %s", outputs[1])
+	}
+	if strings.Contains(outputs[2], `"skipped":true`) {
+		t.Errorf("expected changed frame to be saved, got %s", outputs[2])
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read dir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(entries))
+	}
+	data, err := os.ReadFile(filepath.Join(dir, imageHash([]byte("frame-2"))[:12]+".jpg"))
+	if err != nil || string(data) != "frame-2" {
+		t.Errorf("expected second frame on disk, got %q (%v)", data, err)
+	}
+}
+
+func TestRunPageScreenshot_TemplateInvalid(t *testing.T) {
+	server := setupPageTest(t)
+
+	origTemplate := pageScreenshotNameTemplate
+	pageScreenshotNameTemplate = "{nope}.jpg"
+	t.Cleanup(func() { pageScreenshotNameTemplate = origTemplate })
+
+	if err := runPageScreenshot(newPageTestCmd(), nil); err == nil {
+		t.Fatal("expected error for unknown placeholder")
+	}
+	if got := len(server.Requests("/sessions/" + pageSessionIDTest + "/page/screenshot")); got != 0 {
+		t.Errorf("expected no screenshot request, got %d", got)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
 var (
@@ -52,13 +53,13 @@ func init() {
 func runSearch(cmd *cobra.Command, args []string) error {
 	query := strings.TrimSpace(strings.Join(args, " "))
 	if query == "" {
-		return fmt.Errorf("search query cannot be empty")
+		return i18n.Errorf(i18n.SearchQueryEmpty)
 	}
 	if searchDepth != "" && !validSearchDepths[searchDepth] {
-		return fmt.Errorf("invalid --depth %q: must be standard, fast, or deep", searchDepth)
+		return i18n.Errorf(i18n.InvalidDepth, searchDepth)
 	}
 	if searchOutputType != "" && !validSearchOutputTypes[searchOutputType] {
-		return fmt.Errorf("invalid --output-type %q: must be searchResults, sourcedAnswer, or structured", searchOutputType)
+		return i18n.Errorf(i18n.InvalidOutputType, searchOutputType)
 	}
 
 	client, err := GetClient()
@@ -79,7 +80,7 @@ func runSearch(cmd *cobra.Command, args []string) error {
 
 	resp, err := client.Client().SearchWebWithResponse(ctx, &api.SearchWebParams{}, body)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +12,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"sync"
@@ -19,6 +22,7 @@ import (
 
 	"github.com/nottelabs/notte-cli/internal/api"
 	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
 // Manual flags for proxies and extra headers (union types not auto-generated)
@@ -31,13 +35,18 @@ var (
 	sessionsStartProxyTailClientID     string
 	sessionsStartProxyTailClientSecret string
 	sessionsStartExtraHttpHeaders      string
+	sessionsStartHTTPCredentials       string
 )
 
 var (
-	sessionID                 string
 	sessionExecuteAction      string
+	sessionExecuteStream      bool
+	sessionExecuteStopOnError bool
 	sessionScrapeInstructions string
 	sessionScrapeOnlyMain     bool
+	sessionScrapePath         string
+	sessionScrapeValidate     string
+	sessionScrapeXLSX         string
 	sessionCookiesSetFile     string
 	sessionNetworkURLsOnly    bool
 	sessionNetworkPath        string
@@ -45,10 +54,10 @@ var (
 )
 
 // GetCurrentSessionID returns the session ID from flag, env var, or file (in priority order)
-func GetCurrentSessionID() string {
-	// 1. Check --session-id flag (already in sessionID variable if set)
-	if sessionID != "" {
-		return sessionID
+func GetCurrentSessionID(cmd *cobra.Command) string {
+	// 1. Check --session-id flag
+	if id := flagsFor(cmd).SessionID; id != "" {
+		return id
 	}
 
 	// 2. Check NOTTE_SESSION_ID env var
@@ -57,15 +66,7 @@ func GetCurrentSessionID() string {
 	}
 
 	// 3. Check current_session file
-	configDir, err := config.Dir()
-	if err != nil {
-		return ""
-	}
-	data, err := os.ReadFile(filepath.Join(configDir, config.CurrentSessionFile))
-	if err != nil {
-		return ""
-	}
-	return strings.TrimSpace(string(data))
+	return readCurrentSessionFile()
 }
 
 // setCurrentSession saves the session ID to the current_session file
@@ -78,7 +79,11 @@ func setCurrentSession(id string) error {
 	if err := os.MkdirAll(configDir, 0o700); err != nil {
 		return err
 	}
-	return os.WriteFile(filepath.Join(configDir, config.CurrentSessionFile), []byte(id), 0o600)
+	if err := os.WriteFile(filepath.Join(configDir, config.CurrentSessionFile), []byte(id), 0o600); err != nil {
+		return err
+	}
+	recordIDUse(idKindSession, id)
+	return nil
 }
 
 // clearCurrentSession removes the current_session file
@@ -94,6 +99,20 @@ func clearCurrentSession() error {
 	return nil
 }
 
+// readCurrentSessionFile returns the session ID saved in the current_session
+// file, ignoring --session-id and NOTTE_SESSION_ID
+func readCurrentSessionFile() string {
+	configDir, err := config.Dir()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(configDir, config.CurrentSessionFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
 // setCurrentViewerURL saves the viewer URL to the current_viewer_url file
 func setCurrentViewerURL(url string) error {
 	configDir, err := config.Dir()
@@ -170,25 +189,28 @@ func clearCurrentSessionExpiry() error {
 	return nil
 }
 
-// RequireSessionID ensures a session ID is available from flag, env, or file
-func RequireSessionID() error {
-	sessionID = GetCurrentSessionID()
-	if sessionID == "" {
-		return errors.New("session ID required: use --session-id flag, set NOTTE_SESSION_ID env var, or start a session first")
+// RequireSessionID resolves the session ID for cmd from flag, env, or file
+func RequireSessionID(cmd *cobra.Command) (string, error) {
+	id := GetCurrentSessionID(cmd)
+	if id == "" {
+		return "", errors.New(i18n.T(i18n.SessionIDRequired))
 	}
-	return nil
+	recordIDUse(idKindSession, id)
+	return id, nil
 }
 
 var sessionsCmd = &cobra.Command{
-	Use:   "sessions",
-	Short: "Manage browser sessions",
-	Long:  "List, create, and operate on browser sessions.",
+	Use:     "sessions",
+	Aliases: []string{"s"},
+	Short:   "Manage browser sessions",
+	Long:    "List, create, and operate on browser sessions.",
 }
 
 var sessionsListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List active sessions",
-	RunE:  runSessionsList,
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List active sessions",
+	RunE:    runSessionsList,
 }
 
 var sessionsStartCmd = &cobra.Command{
@@ -205,47 +227,55 @@ var sessionsStatusCmd = &cobra.Command{
 }
 
 var sessionsStopCmd = &cobra.Command{
-	Use:   "stop",
-	Short: "Stop the session",
-	Args:  cobra.NoArgs,
-	RunE:  runSessionStop,
+	Use:     "stop",
+	Aliases: []string{"rm"},
+	Short:   "Stop the session, or every session with --all",
+	Long: `Stop the current session, or the one given with --session-id.
+
+With --all, every session listed for your account is stopped, after a single
+confirmation summarizing them; --filter narrows the list. Sessions protected
+by the policy in your config are skipped.
+
+With --stdin, the sessions to stop are read from stdin: session IDs one per
+line, or the JSON printed by "notte sessions list -o json". Each one is
+stopped in turn and its result printed as a line of JSON.`,
+	Example: `  notte sessions stop
+  notte sessions stop --all --filter only-active
+  notte sessions stop --all --filter only-active --filter mine --yes
+  notte sessions list -o json | jq '[.[] | select(.browser_type == "firefox")]' | notte sessions stop --stdin --yes`,
+	Args: cobra.NoArgs,
+	RunE: runSessionStop,
 }
 
+// The page-action commands below moved under "notte page", which shares
+// their implementation and flags; they stay for existing scripts
+
 var sessionsObserveCmd = &cobra.Command{
-	Use:    "observe",
-	Short:  "Observe page state and available actions",
-	Args:   cobra.NoArgs,
-	RunE:   runSessionObserve,
-	Hidden: true, // Use "notte page observe" instead
+	Use:        "observe",
+	Short:      "Observe page state and available actions",
+	Args:       cobra.NoArgs,
+	RunE:       runSessionObserve,
+	Hidden:     true,
+	Deprecated: `use "notte page observe" instead`,
 }
 
 var sessionsExecuteCmd = &cobra.Command{
-	Use:   "execute",
-	Short: "Execute an action on the page",
-	Args:  cobra.NoArgs,
-	Example: `  # Direct JSON
-  notte sessions execute --session-id <session-id> --action '{"type": "goto", "url": "https://example.com"}'
-
-  # From file
-  notte sessions execute --session-id <session-id> --action @action.json
-
-  # From stdin
-  echo '{"type": "goto", "url": "https://example.com"}' | notte sessions execute --session-id <session-id>
-
-  # Using heredoc
-  notte sessions execute --session-id "27ac8eea-1afc-4cad-aa23-bf122ed2390f" << 'EOF'
-  {"type": "fill", "id": "I1", "value": "my text"}
-  EOF`,
-	RunE:   runSessionExecute,
-	Hidden: true, // Use "notte page <action>" instead
+	Use:        "execute",
+	Short:      "Execute an action on the page",
+	Args:       cobra.NoArgs,
+	RunE:       runSessionExecute,
+	Hidden:     true,
+	Deprecated: `use "notte page execute" instead`,
 }
 
 var sessionsScrapeCmd = &cobra.Command{
-	Use:    "scrape",
-	Short:  "Scrape content from the page",
-	Args:   cobra.NoArgs,
-	RunE:   runSessionScrape,
-	Hidden: true, // Use "notte page scrape" instead
+	Use:         "scrape",
+	Short:       "Scrape content from the page",
+	Args:        cobra.NoArgs,
+	RunE:        runSessionScrape,
+	Hidden:      true,
+	Deprecated:  `use "notte page scrape" instead`,
+	Annotations: map[string]string{csvOutputAnnotation: "true"},
 }
 
 var sessionsCookiesCmd = &cobra.Command{
@@ -273,8 +303,21 @@ var sessionsDebugCmd = &cobra.Command{
 var sessionsNetworkCmd = &cobra.Command{
 	Use:   "network",
 	Short: "Get network logs for the session",
-	Args:  cobra.NoArgs,
-	RunE:  runSessionNetwork,
+	Long: `Download the network log batches of the session, or with --follow, poll
+them and print each new request (method, status, size, URL) as it happens.
+
+--filter narrows what --follow prints: a status code (404) or class (4xx),
+or a URL substring or glob (*api*). Status and URL filters must both match
+when given; several of one kind match if any does.
+
+--only, --url-pattern and --max-size narrow a download to the matching
+requests, each saved as its own JSON file instead of whole batches. Every
+download writes an index.json listing the files saved.`,
+	Example: `  notte sessions network
+  notte sessions network --only 'status>=400' --url-pattern '/api/' --max-size 1MB
+  notte sessions network --follow --filter 4xx --filter '*/api/*'`,
+	Args: cobra.NoArgs,
+	RunE: runSessionNetwork,
 }
 
 var sessionsReplayCmd = &cobra.Command{
@@ -292,8 +335,17 @@ Examples:
 var sessionsOffsetCmd = &cobra.Command{
 	Use:   "offset",
 	Short: "Get session offset info",
-	Args:  cobra.NoArgs,
-	RunE:  runSessionOffset,
+	Long: `Get the session's offset: the current step of its trajectory.
+
+--follow instead tracks the page: its scroll offsets, viewport and full size
+are printed whenever they change (one JSON object per line with -o json),
+with "bottom" once the page is scrolled to the end. --until-bottom stops
+there, so a scroll-based scraping loop can wait on it.`,
+	Example: `  notte sessions offset
+  notte sessions offset --follow
+  notte sessions offset --follow --until-bottom --interval 500ms`,
+	Args: cobra.NoArgs,
+	RunE: runSessionOffset,
 }
 
 var sessionsWorkflowCodeCmd = &cobra.Command{
@@ -321,6 +373,7 @@ func init() {
 	rootCmd.AddCommand(sessionsCmd)
 	sessionsCmd.AddCommand(sessionsListCmd)
 	registerPaginationFlags(sessionsListCmd)
+	registerScopeFlag(sessionsListCmd)
 	sessionsListCmd.Flags().Bool("only-active", false, "Only return active sessions")
 
 	sessionsCmd.AddCommand(sessionsStartCmd)
@@ -339,67 +392,56 @@ func init() {
 	sessionsCmd.AddCommand(sessionsCodeCmd)
 	sessionsCmd.AddCommand(sessionsViewerCmd)
 
-	// Start command flags (auto-generated + manual proxy)
-	RegisterSessionStartFlags(sessionsStartCmd)
-	// Manual flags for proxies (union type: bool | array of proxy objects)
-	sessionsStartCmd.Flags().BoolVar(&sessionsStartProxy, "proxy", false, "Use default proxies")
-	sessionsStartCmd.Flags().StringVar(&sessionsStartProxyCountry, "proxy-country", "", "Proxy country code (e.g. us, gb, fr). Implies --proxy")
-	sessionsStartCmd.Flags().StringVar(&sessionsStartProxyExtServer, "proxy-external-server", "", "External proxy server URL (e.g. http://proxy:8080). Enables external proxy")
-	sessionsStartCmd.Flags().StringVar(&sessionsStartProxyExtUsername, "proxy-external-username", "", "External proxy username")
-	sessionsStartCmd.Flags().StringVar(&sessionsStartProxyExtPassword, "proxy-external-password", "", "External proxy password")
-	sessionsStartCmd.Flags().StringVar(&sessionsStartProxyTailClientID, "proxy-tailnet-client-id", "", "Tailnet OAuth client ID. Enables Tailscale proxy")
-	sessionsStartCmd.Flags().StringVar(&sessionsStartProxyTailClientSecret, "proxy-tailnet-client-secret", "", "Tailnet OAuth client secret")
-	// Manual flag for extra HTTP headers (map type not auto-generated)
-	sessionsStartCmd.Flags().StringVar(&sessionsStartExtraHttpHeaders, "extra-http-headers", "", `Extra HTTP headers as JSON (e.g. '{"Authorization": "Bearer xxx"}')`)
+	registerSessionsStartFlags(sessionsStartCmd)
 
 	// Status command flags
-	sessionsStatusCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
+	addSessionIDFlag(sessionsStatusCmd)
+	registerWaitForFlags(sessionsStatusCmd, sessionStatuses)
+	addCallbackURLFlag(sessionsStatusCmd)
+	addStdinIDsFlag(sessionsStatusCmd, "session-id")
 
 	// Stop command flags
-	sessionsStopCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
+	addSessionIDFlag(sessionsStopCmd)
 
-	// Observe command flags
-	sessionsObserveCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
-	// Execute command flags
-	sessionsExecuteCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
-	sessionsExecuteCmd.Flags().StringVar(&sessionExecuteAction, "action", "", "Action JSON, @file, or '-' for stdin")
-
-	// Scrape command flags
-	sessionsScrapeCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
-	sessionsScrapeCmd.Flags().StringVar(&sessionScrapeInstructions, "instructions", "", "Extraction instructions")
-	sessionsScrapeCmd.Flags().BoolVar(&sessionScrapeOnlyMain, "only-main-content", false, "Only scrape main content")
+	// Observe, execute and scrape command flags, shared with their page commands
+	addSessionIDFlag(sessionsObserveCmd)
+	addSessionIDFlag(sessionsExecuteCmd)
+	addExecuteFlags(sessionsExecuteCmd)
+	addSessionIDFlag(sessionsScrapeCmd)
+	addScrapeFlags(sessionsScrapeCmd)
 
 	// Cookies command flags
-	sessionsCookiesCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
+	addSessionIDFlag(sessionsCookiesCmd)
 
 	// Cookies-set command flags
-	sessionsCookiesSetCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
-	sessionsCookiesSetCmd.Flags().StringVar(&sessionCookiesSetFile, "file", "", "JSON file containing cookies array (required)")
+	addSessionIDFlag(sessionsCookiesSetCmd)
+	sessionsCookiesSetCmd.Flags().StringVar(&sessionCookiesSetFile, "file", "", `JSON object with a "cookies" array: a file path, @file, inline JSON, or '-' for stdin (required)`)
+	sessionsCookiesSetCmd.Flags().BoolVar(&skipSchemaCheck, "no-validate", false, "Send the cookies without checking them against the API schema first")
 	_ = sessionsCookiesSetCmd.MarkFlagRequired("file")
 
 	// Debug command flags
-	sessionsDebugCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
+	addSessionIDFlag(sessionsDebugCmd)
 
 	// Network command flags
-	sessionsNetworkCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
+	addSessionIDFlag(sessionsNetworkCmd)
 	sessionsNetworkCmd.Flags().BoolVar(&sessionNetworkURLsOnly, "urls-only", false, "Only show download URLs without downloading")
 	sessionsNetworkCmd.Flags().StringVar(&sessionNetworkPath, "path", "", "Output directory for downloaded files (defaults to temp directory)")
 
 	// Replay command flags
-	sessionsReplayCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
+	addSessionIDFlag(sessionsReplayCmd)
 	sessionsReplayCmd.Flags().StringVar(&sessionReplayOutput, "path", "", "Output path for the replay video (defaults to temp directory)")
 
 	// Offset command flags
-	sessionsOffsetCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
+	addSessionIDFlag(sessionsOffsetCmd)
 
 	// Workflow-code command flags
-	sessionsWorkflowCodeCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
+	addSessionIDFlag(sessionsWorkflowCodeCmd)
 
 	// Code command flags
-	sessionsCodeCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
+	addSessionIDFlag(sessionsCodeCmd)
 
 	// Viewer command flags
-	sessionsViewerCmd.Flags().StringVar(&sessionID, "session-id", "", "Session ID (uses current session if not specified)")
+	addSessionIDFlag(sessionsViewerCmd)
 }
 
 func runSessionsList(cmd *cobra.Command, args []string) error {
@@ -419,9 +461,14 @@ func runSessionsList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	scope, onlyCurrentToken, err := getScopeFlag(cmd)
+	if err != nil {
+		return err
+	}
 	params := &api.ListSessionsParams{
-		Page:     page,
-		PageSize: pageSize,
+		Page:             page,
+		PageSize:         pageSize,
+		OnlyCurrentToken: onlyCurrentToken,
 	}
 	if cmd.Flags().Changed("only-active") {
 		v, _ := cmd.Flags().GetBool("only-active")
@@ -429,7 +476,7 @@ func runSessionsList(cmd *cobra.Command, args []string) error {
 	}
 	resp, err := client.Client().ListSessionsWithResponse(ctx, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -448,12 +495,57 @@ func runSessionsList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if scope != "" {
+		owned, err := scopeOwnedIDs(scope, func(page *int) ([]string, bool, error) {
+			mine := *params
+			mine.Page, mine.OnlyCurrentToken = page, boolPtr(true)
+			r, err := client.Client().ListSessionsWithResponse(ctx, &mine)
+			if err == nil {
+				err = HandleAPIResponse(r.HTTPResponse, r.Body)
+			}
+			if err != nil || r.JSON200 == nil {
+				return nil, false, err
+			}
+			ids := make([]string, len(r.JSON200.Items))
+			for i, item := range r.JSON200.Items {
+				ids[i] = item.SessionId
+			}
+			return ids, r.JSON200.HasNext, nil
+		})
+		if err != nil {
+			return err
+		}
+		rows := make([]ownedSession, len(items))
+		for i, item := range items {
+			rows[i] = ownedSession{Owner: ownerOf(scope, owned, item.SessionId), SessionResponse: item}
+		}
+		return formatter.Print(rows)
+	}
+
 	return formatter.Print(items)
 }
 
+// registerSessionsStartFlags registers the sessions start flags on cmd:
+// the generated ones and the proxy and header flags handled by hand
+func registerSessionsStartFlags(cmd *cobra.Command) {
+	RegisterSessionStartFlags(cmd)
+	// Manual flags for proxies (union type: bool | array of proxy objects)
+	cmd.Flags().BoolVar(&sessionsStartProxy, "proxy", false, "Use default proxies")
+	cmd.Flags().StringVar(&sessionsStartProxyCountry, "proxy-country", "", "Proxy country code (e.g. us, gb, fr). Implies --proxy")
+	cmd.Flags().StringVar(&sessionsStartProxyExtServer, "proxy-external-server", "", "External proxy server URL (e.g. http://proxy:8080). Enables external proxy")
+	cmd.Flags().StringVar(&sessionsStartProxyExtUsername, "proxy-external-username", "", "External proxy username")
+	cmd.Flags().StringVar(&sessionsStartProxyExtPassword, "proxy-external-password", "", "External proxy password")
+	cmd.Flags().StringVar(&sessionsStartProxyTailClientID, "proxy-tailnet-client-id", "", "Tailnet OAuth client ID. Enables Tailscale proxy")
+	cmd.Flags().StringVar(&sessionsStartProxyTailClientSecret, "proxy-tailnet-client-secret", "", "Tailnet OAuth client secret")
+	// Manual flag for extra HTTP headers (map type not auto-generated)
+	cmd.Flags().StringVar(&sessionsStartFromRecipe, "from-recipe", "", "Start from a saved recipe (see 'notte recipes'); explicit flags override it")
+	cmd.Flags().StringVar(&sessionsStartHTTPCredentials, "http-credentials", "", "HTTP basic auth credentials (user:pass), sent as an Authorization header on every request")
+	cmd.Flags().StringVar(&sessionsStartExtraHttpHeaders, "extra-http-headers", "", `Extra HTTP headers as JSON (e.g. '{"Authorization": "Bearer xxx"}'), @file, or '-' for stdin`)
+}
+
 func runSessionsStart(cmd *cobra.Command, args []string) error {
 	// Check if there's already a current session
-	existingSessionID := GetCurrentSessionID()
+	existingSessionID := GetCurrentSessionID(cmd)
 	if existingSessionID != "" {
 		// Check if the session has expired based on stored max expiry
 		if expiry, err := getCurrentSessionExpiry(); err == nil && !expiry.IsZero() && time.Now().UTC().After(expiry) {
@@ -481,7 +573,7 @@ func runSessionsStart(cmd *cobra.Command, args []string) error {
 			_, stopErr := stopClient.Client().SessionStopWithResponse(ctx, existingSessionID, params)
 			cancel()
 			if stopErr != nil {
-				PrintInfo(fmt.Sprintf("Warning: could not stop session %s: %v", existingSessionID, stopErr))
+				PrintInfo(i18n.T(i18n.WarningCouldNotStopSession, existingSessionID, stopErr))
 			}
 			_ = clearCurrentSession()
 			_ = clearCurrentViewerURL()
@@ -498,10 +590,48 @@ func runSessionsStart(cmd *cobra.Command, args []string) error {
 	ctx, cancel := GetContextWithTimeout(cmd.Context())
 	defer cancel()
 
+	if sessionsStartFromRecipe != "" {
+		if err := applyRecipe(cmd, sessionsStartFromRecipe); err != nil {
+			return err
+		}
+	}
+
+	body, err := buildSessionsStartRequest(cmd)
+	if err != nil {
+		return err
+	}
+
+	params := &api.SessionStartParams{}
+	resp, err := client.Client().SessionStartWithResponse(ctx, params, *body)
+	if err != nil {
+		return i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+
+	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	// Save session ID as current session
+	var sessionID string
+	if resp.JSON200 != nil {
+		sessionID = resp.JSON200.SessionId
+		rememberStartedSession(resp.JSON200)
+	}
+	if err := recordLastSessionStart(cmd, sessionID); err != nil {
+		PrintInfo(i18n.T(i18n.WarningCouldNotRecordSessionStart, err))
+	}
+
+	formatter := GetFormatter()
+	return formatter.Print(resp.JSON200)
+}
+
+// buildSessionsStartRequest builds the session start request for the flags
+// set on cmd
+func buildSessionsStartRequest(cmd *cobra.Command) (*api.ApiSessionStartRequest, error) {
 	// Build request body from generated flags
 	body, err := BuildSessionStartRequest(cmd)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Handle proxies manually (union type: bool | array of proxy objects).
@@ -513,7 +643,7 @@ func runSessionsStart(cmd *cobra.Command, args []string) error {
 		}
 	}
 	if len(setProxyFlags) > 1 {
-		return fmt.Errorf("proxy flags are mutually exclusive, got: %s", strings.Join(setProxyFlags, ", "))
+		return nil, i18n.Errorf(i18n.ProxyFlagsExclusive, strings.Join(setProxyFlags, ", "))
 	}
 
 	var proxyItems api.ApiSessionStartRequestProxies0
@@ -523,7 +653,7 @@ func runSessionsStart(cmd *cobra.Command, args []string) error {
 		notteProxy := api.NotteProxy{Country: &country}
 		var item api.ApiSessionStartRequest_Proxies_0_Item
 		if err := item.FromNotteProxy(notteProxy); err != nil {
-			return fmt.Errorf("failed to create notte proxy: %w", err)
+			return nil, i18n.Errorf(i18n.FailedToCreateNotteProxy, err)
 		}
 		proxyItems = append(proxyItems, item)
 	}
@@ -538,7 +668,7 @@ func runSessionsStart(cmd *cobra.Command, args []string) error {
 		}
 		var item api.ApiSessionStartRequest_Proxies_0_Item
 		if err := item.FromExternalProxy(ext); err != nil {
-			return fmt.Errorf("failed to create external proxy: %w", err)
+			return nil, i18n.Errorf(i18n.FailedToCreateExternalProxy, err)
 		}
 		proxyItems = append(proxyItems, item)
 	}
@@ -550,7 +680,7 @@ func runSessionsStart(cmd *cobra.Command, args []string) error {
 		}
 		var item api.ApiSessionStartRequest_Proxies_0_Item
 		if err := item.FromTailnetProxy(tail); err != nil {
-			return fmt.Errorf("failed to create tailnet proxy: %w", err)
+			return nil, i18n.Errorf(i18n.FailedToCreateTailnetProxy, err)
 		}
 		proxyItems = append(proxyItems, item)
 	}
@@ -558,62 +688,75 @@ func runSessionsStart(cmd *cobra.Command, args []string) error {
 	if len(proxyItems) > 0 {
 		var proxies api.ApiSessionStartRequest_Proxies
 		if err := proxies.FromApiSessionStartRequestProxies0(proxyItems); err != nil {
-			return fmt.Errorf("failed to set proxies: %w", err)
+			return nil, i18n.Errorf(i18n.FailedToSetProxies, err)
 		}
 		body.Proxies = &proxies
 	} else if cmd.Flags().Changed("proxy") {
 		var proxies api.ApiSessionStartRequest_Proxies
 		if err := proxies.FromApiSessionStartRequestProxies1(sessionsStartProxy); err != nil {
-			return fmt.Errorf("failed to set proxies: %w", err)
+			return nil, i18n.Errorf(i18n.FailedToSetProxies, err)
 		}
 		body.Proxies = &proxies
 	}
 
 	// Handle extra HTTP headers (map type not auto-generated)
 	if cmd.Flags().Changed("extra-http-headers") {
+		data, err := readJSONInput(cmd, sessionsStartExtraHttpHeaders, "extra-http-headers")
+		if err != nil {
+			return nil, err
+		}
 		var headers map[string]interface{}
-		if err := json.Unmarshal([]byte(sessionsStartExtraHttpHeaders), &headers); err != nil {
-			return fmt.Errorf("invalid JSON for --extra-http-headers: %w", err)
+		if err := json.Unmarshal(data, &headers); err != nil {
+			return nil, i18n.Errorf(i18n.InvalidExtraHTTPHeaders, err)
 		}
 		body.ExtraHttpHeaders = &headers
 	}
-
-	params := &api.SessionStartParams{}
-	resp, err := client.Client().SessionStartWithResponse(ctx, params, *body)
-	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
-	}
-
-	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
-		return err
-	}
-
-	// Save session ID as current session
-	if resp.JSON200 != nil {
-		if err := setCurrentSession(resp.JSON200.SessionId); err != nil {
-			PrintInfo(fmt.Sprintf("Warning: could not save current session: %v", err))
+	if cmd.Flags().Changed("http-credentials") {
+		user, pass, err := parseHTTPCredentials(sessionsStartHTTPCredentials)
+		if err != nil {
+			return nil, err
 		}
-		// Store session expiry if max duration is set
-		if resp.JSON200.MaxDurationMinutes != nil && !resp.JSON200.CreatedAt.IsZero() {
-			expiry := resp.JSON200.CreatedAt.Add(time.Duration(*resp.JSON200.MaxDurationMinutes) * time.Minute)
-			if err := setCurrentSessionExpiry(expiry); err != nil {
-				PrintInfo(fmt.Sprintf("Warning: could not save session expiry: %v", err))
-			}
+		if body.ExtraHttpHeaders == nil {
+			body.ExtraHttpHeaders = &map[string]interface{}{}
 		}
-		// Store viewer URL if available
-		if resp.JSON200.ViewerUrl != nil && *resp.JSON200.ViewerUrl != "" {
-			if err := setCurrentViewerURL(*resp.JSON200.ViewerUrl); err != nil {
-				PrintInfo(fmt.Sprintf("Warning: could not save viewer URL: %v", err))
+		for name := range *body.ExtraHttpHeaders {
+			if strings.EqualFold(name, "Authorization") {
+				return nil, i18n.Errorf(i18n.HTTPCredentialsConflict)
 			}
 		}
+		(*body.ExtraHttpHeaders)["Authorization"] = basicAuthHeader(user, pass)
 	}
+	return body, nil
+}
 
-	formatter := GetFormatter()
-	return formatter.Print(resp.JSON200)
+// rememberStartedSession makes a newly started session the current one,
+// along with its expiry and viewer URL
+func rememberStartedSession(session *api.SessionResponse) {
+	if err := setCurrentSession(session.SessionId); err != nil {
+		PrintInfo(i18n.T(i18n.WarningCouldNotSaveCurrentSession, err))
+	}
+	// Store session expiry if max duration is set
+	if session.MaxDurationMinutes != nil && !session.CreatedAt.IsZero() {
+		expiry := session.CreatedAt.Add(time.Duration(*session.MaxDurationMinutes) * time.Minute)
+		if err := setCurrentSessionExpiry(expiry); err != nil {
+			PrintInfo(i18n.T(i18n.WarningCouldNotSaveSessionExpiry, err))
+		}
+	}
+	// Store viewer URL if available
+	if session.ViewerUrl != nil && *session.ViewerUrl != "" {
+		if err := setCurrentViewerURL(*session.ViewerUrl); err != nil {
+			PrintInfo(i18n.T(i18n.WarningCouldNotSaveViewerURL, err))
+		}
+	}
 }
 
 func runSessionStatus(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return err
+	}
+	waitFor, waitTimeout, err := getWaitForFlags(cmd, sessionStatuses)
+	if err != nil {
 		return err
 	}
 	client, err := GetClient()
@@ -621,24 +764,56 @@ func runSessionStatus(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx, cancel := GetContextWithTimeout(cmd.Context())
-	defer cancel()
+	var session *api.SessionResponse
+	fetch := func(ctx context.Context) (string, error) {
+		ctx, cancel := GetContextWithTimeout(ctx)
+		defer cancel()
 
-	params := &api.SessionStatusParams{}
-	resp, err := client.Client().SessionStatusWithResponse(ctx, sessionID, params)
-	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		params := &api.SessionStatusParams{}
+		resp, err := client.Client().SessionStatusWithResponse(ctx, sessionID, params)
+		if err != nil {
+			return "", i18n.Errorf(i18n.APIRequestFailed, err)
+		}
+
+		if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return "", err
+		}
+		if resp.JSON200 == nil {
+			return "", i18n.Errorf(i18n.EmptySessionStatusResponse)
+		}
+		session = resp.JSON200
+		return string(session.Status), nil
 	}
 
-	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+	if waitFor != "" {
+		err = waitForStatus(cmd.Context(), "session", sessionID, waitFor, waitTimeout, fetch)
+	} else {
+		_, err = fetch(cmd.Context())
+	}
+	if session != nil {
+		recordCallbackResult(session)
+	}
+	if err != nil {
 		return err
 	}
 
-	return printSessionStatus(resp.JSON200)
+	return printSessionStatus(session)
 }
 
 func runSessionStop(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
+	if sessionsStopAll {
+		return runSessionStopAll(cmd)
+	}
+	if len(sessionsStopFilters) > 0 {
+		return errors.New(i18n.T(i18n.FilterNeedsAll))
+	}
+
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := checkProtected("session", sessionID); err != nil {
 		return err
 	}
 
@@ -647,7 +822,7 @@ func runSessionStop(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	if !confirmed {
-		return PrintResult("Cancelled.", map[string]any{"cancelled": true})
+		return PrintResult(i18n.T(i18n.Cancelled), map[string]any{"cancelled": true})
 	}
 
 	client, err := GetClient()
@@ -655,82 +830,50 @@ func runSessionStop(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx, cancel := GetContextWithTimeout(cmd.Context())
-	defer cancel()
-
-	params := &api.SessionStopParams{}
-	resp, err := client.Client().SessionStopWithResponse(ctx, sessionID, params)
-	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
-	}
-
-	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+	if err := stopSession(cmd.Context(), client, sessionID); err != nil {
 		return err
 	}
 
-	// Clear current session only if it matches the stopped session
-	configDir, _ := config.Dir()
-	if configDir != "" {
-		data, _ := os.ReadFile(filepath.Join(configDir, config.CurrentSessionFile))
-		if strings.TrimSpace(string(data)) == sessionID {
-			_ = clearCurrentSession()
-			_ = clearCurrentViewerURL()
-			_ = clearCurrentAgent()
-			_ = clearCurrentSessionExpiry()
-		}
-	}
-
-	return PrintResult(fmt.Sprintf("Session %s stopped.", sessionID), map[string]any{
+	return PrintResult(i18n.T(i18n.SessionStopped, sessionID), map[string]any{
 		"id":     sessionID,
 		"status": "stopped",
 	})
 }
 
-func runSessionObserve(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
-		return err
-	}
+// addExecuteFlags registers the flags of page execute and sessions execute
+func addExecuteFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&sessionExecuteAction, "action", "", "Action JSON, @file, or '-' for stdin")
+	cmd.Flags().BoolVar(&sessionExecuteStream, "stream", false, "Read newline-delimited action JSON from stdin and print one NDJSON result per action")
+	cmd.Flags().BoolVar(&sessionExecuteStopOnError, "stop-on-error", false, "With --stream, stop at the first failed action")
+	cmd.Flags().BoolVar(&skipSchemaCheck, "no-validate", false, "Send actions without checking them against the API schema first")
+	cmd.MarkFlagsMutuallyExclusive("stream", "action")
+}
 
-	client, err := GetClient()
+// addScrapeFlags registers the flags of page scrape and sessions scrape
+func addScrapeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&sessionScrapeInstructions, "instructions", "", "Extraction instructions")
+	cmd.Flags().BoolVar(&sessionScrapeOnlyMain, "only-main-content", false, "Only scrape main content")
+	cmd.Flags().StringVar(&sessionScrapePath, "path", "", scrapePathUsage)
+	cmd.Flags().StringVar(&sessionScrapeValidate, "validate", "", scrapeValidateUsage)
+	cmd.Flags().StringVar(&sessionScrapeXLSX, "xlsx", "", scrapeXLSXUsage)
+}
+
+func runSessionObserve(cmd *cobra.Command, args []string) error {
+	sessionID, err := RequireSessionID(cmd)
 	if err != nil {
 		return err
 	}
 
-	ctx, cancel := GetContextWithTimeout(cmd.Context())
-	defer cancel()
-
-	body := api.PageObserveJSONRequestBody{}
-
-	params := &api.PageObserveParams{}
-	resp, err := client.Client().PageObserveWithResponse(ctx, sessionID, params, body)
+	snap, err := observeSession(cmd, sessionID)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
-	}
-
-	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
 		return err
 	}
-
-	// JSON mode: return filtered response (exclude screenshot and space.actions)
-	if IsJSONOutput() {
-		filtered := map[string]any{
-			"ended_at":   resp.JSON200.EndedAt,
-			"metadata":   resp.JSON200.Metadata,
-			"started_at": resp.JSON200.StartedAt,
-			"space": map[string]any{
-				"description": resp.JSON200.Space.Description,
-			},
-		}
-		return GetFormatter().Print(filtered)
-	}
-
-	// Text mode: return only the page description
-	fmt.Println(resp.JSON200.Space.Description)
-	return nil
+	return printObservation(snap)
 }
 
 func runSessionExecute(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -739,8 +882,9 @@ func runSessionExecute(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx, cancel := GetContextWithTimeout(cmd.Context())
-	defer cancel()
+	if sessionExecuteStream {
+		return runSessionExecuteStream(cmd, client, sessionID)
+	}
 
 	actionPayload, err := readJSONInput(cmd, sessionExecuteAction, "action")
 	if err != nil {
@@ -750,24 +894,94 @@ func runSessionExecute(cmd *cobra.Command, args []string) error {
 	// Validate action JSON
 	var actionData json.RawMessage
 	if err := json.Unmarshal(actionPayload, &actionData); err != nil {
-		return fmt.Errorf("invalid action JSON: %w", err)
+		return i18n.Errorf(i18n.InvalidActionJSONError, err)
+	}
+	if err := checkAction(actionData); err != nil {
+		return err
 	}
 
-	params := &api.PageExecuteParams{}
-	resp, err := client.Client().PageExecuteWithBodyWithResponse(ctx, sessionID, params, "application/json", bytes.NewReader(actionData))
+	resp, err := postPageAction(cmd.Context(), client, sessionID, actionData, true)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return err
 	}
+	return printExecuteResponse(resp.JSON200)
+}
 
-	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+// executeStreamResult is one NDJSON line emitted by `page execute --stream`
+type executeStreamResult struct {
+	Index  int             `json:"index"`
+	OK     bool            `json:"ok"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// maxStreamLineSize bounds a single action line read from stdin
+const maxStreamLineSize = 10 * 1024 * 1024
+
+// runSessionExecuteStream executes newline-delimited actions from stdin in
+// order, writing one NDJSON result per action as soon as it completes so a
+// driving program can react before sending the next action.
+func runSessionExecuteStream(cmd *cobra.Command, client *api.NotteClient, sessionID string) error {
+	scanner := bufio.NewScanner(cmd.InOrStdin())
+	scanner.Buffer(make([]byte, 64*1024), maxStreamLineSize)
+	enc := json.NewEncoder(os.Stdout)
+
+	index, failed := 0, 0
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		result := executeStreamResult{Index: index}
+		index++
+
+		if err := executeStreamAction(cmd, client, sessionID, line, &result); err != nil {
+			result.Error = err.Error()
+			failed++
+		} else {
+			result.OK = true
+		}
+
+		if err := enc.Encode(result); err != nil {
+			return i18n.Errorf(i18n.FailedToWriteResult, err)
+		}
+		if !result.OK && sessionExecuteStopOnError {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return i18n.Errorf(i18n.FailedToReadActionsFromStdin, err)
+	}
+
+	if failed > 0 {
+		return i18n.Errorf(i18n.ActionsFailed, failed, index)
+	}
+	return nil
+}
+
+func executeStreamAction(cmd *cobra.Command, client *api.NotteClient, sessionID string, action []byte, result *executeStreamResult) error {
+	if !json.Valid(action) {
+		return i18n.Errorf(i18n.InvalidActionJSON)
+	}
+	if err := checkAction(action); err != nil {
 		return err
 	}
 
-	return printExecuteResponse(resp.JSON200)
+	resp, err := postPageAction(cmd.Context(), client, sessionID, action, true)
+	if err != nil {
+		return err
+	}
+
+	if json.Valid(resp.Body) {
+		result.Result = resp.Body
+	}
+	return nil
 }
 
 func runSessionScrape(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -784,6 +998,23 @@ func runSessionScrape(cmd *cobra.Command, args []string) error {
 	if hasInstructions {
 		body.Instructions = &sessionScrapeInstructions
 	}
+
+	if (IsCSVOutput() || sessionScrapeXLSX != "") && !hasInstructions {
+		return i18n.Errorf(i18n.TableNeedsInstructions)
+	}
+	if sessionScrapeXLSX != "" && sessionScrapePath != "" {
+		return i18n.Errorf(i18n.XlsxWithPath)
+	}
+
+	var validationSchema map[string]any
+	if sessionScrapeValidate != "" {
+		if !hasInstructions {
+			return i18n.Errorf(i18n.ValidateNeedsInstructions)
+		}
+		if validationSchema, err = loadValidationSchema(cmd, sessionScrapeValidate, "validate"); err != nil {
+			return err
+		}
+	}
 	if sessionScrapeOnlyMain {
 		body.OnlyMainContent = &sessionScrapeOnlyMain
 	}
@@ -791,18 +1022,35 @@ func runSessionScrape(cmd *cobra.Command, args []string) error {
 	params := &api.PageScrapeParams{}
 	resp, err := client.Client().PageScrapeWithResponse(ctx, sessionID, params, body)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
 		return err
 	}
 
+	if validationSchema != nil {
+		data, err := extractScrapeStructuredData(resp.JSON200)
+		if err != nil {
+			return err
+		}
+		if err := validateData("scraped data", validationSchema, data); err != nil {
+			return err
+		}
+	}
+
+	if sessionScrapeXLSX != "" {
+		return saveScrapeXLSX(ctx, resp.JSON200, sessionID, sessionScrapeXLSX)
+	}
+	if sessionScrapePath != "" {
+		return saveScrapeResponse(ctx, resp.JSON200, hasInstructions, sessionID, sessionScrapePath)
+	}
 	return PrintScrapeResponse(resp.JSON200, hasInstructions)
 }
 
 func runSessionCookies(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -817,7 +1065,7 @@ func runSessionCookies(cmd *cobra.Command, args []string) error {
 	params := &api.SessionCookiesGetParams{}
 	resp, err := client.Client().SessionCookiesGetWithResponse(ctx, sessionID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -828,7 +1076,8 @@ func runSessionCookies(cmd *cobra.Command, args []string) error {
 }
 
 func runSessionCookiesSet(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -840,22 +1089,25 @@ func runSessionCookiesSet(cmd *cobra.Command, args []string) error {
 	ctx, cancel := GetContextWithTimeout(cmd.Context())
 	defer cancel()
 
-	// Read cookies from JSON file
-	fileData, err := os.ReadFile(sessionCookiesSetFile)
+	fileData, err := readJSONFileInput(cmd, sessionCookiesSetFile, "cookies")
 	if err != nil {
-		return fmt.Errorf("failed to read cookies file: %w", err)
+		return err
+	}
+
+	if err := checkPayload("cookies", fileData, reflect.TypeOf(api.SessionCookiesSetJSONRequestBody{})); err != nil {
+		return err
 	}
 
 	// Parse the cookies JSON
 	var body api.SessionCookiesSetJSONRequestBody
 	if err := json.Unmarshal(fileData, &body); err != nil {
-		return fmt.Errorf("failed to parse cookies JSON: %w", err)
+		return i18n.Errorf(i18n.FailedToParseCookiesJSON, err)
 	}
 
 	params := &api.SessionCookiesSetParams{}
 	resp, err := client.Client().SessionCookiesSetWithResponse(ctx, sessionID, params, body)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -866,7 +1118,8 @@ func runSessionCookiesSet(cmd *cobra.Command, args []string) error {
 }
 
 func runSessionDebug(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -881,7 +1134,7 @@ func runSessionDebug(cmd *cobra.Command, args []string) error {
 	params := &api.SessionDebugInfoParams{}
 	resp, err := client.Client().SessionDebugInfoWithResponse(ctx, sessionID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -892,10 +1145,25 @@ func runSessionDebug(cmd *cobra.Command, args []string) error {
 }
 
 func runSessionNetwork(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
 		return err
 	}
 
+	filter, err := sessionNetworkFilter()
+	if err != nil {
+		return err
+	}
+	if sessionNetworkFollow {
+		return followSessionNetwork(cmd, sessionID, filter)
+	}
+	if len(sessionNetworkFilters) > 0 {
+		return i18n.Errorf(i18n.FilterNeedsFollow)
+	}
+	if filter != nil && sessionNetworkURLsOnly {
+		return i18n.Errorf(i18n.DownloadFiltersWithURLsOnly)
+	}
+
 	client, err := GetClient()
 	if err != nil {
 		return err
@@ -911,7 +1179,7 @@ func runSessionNetwork(cmd *cobra.Command, args []string) error {
 	}
 	resp, err := client.Client().SessionNetworkLogsWithResponse(ctx, sessionID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -930,36 +1198,43 @@ func runSessionNetwork(cmd *cobra.Command, args []string) error {
 
 	// Default: download files to folder
 	if resp.JSON200 != nil {
-		return downloadNetworkLogs(resp.JSON200, sessionNetworkPath)
+		return downloadNetworkLogs(ctx, resp.JSON200, sessionNetworkPath, filter)
 	}
 
 	return GetFormatter().Print(resp.JSON200)
 }
 
-// downloadNetworkLogs downloads all network log files in parallel to a folder
-func downloadNetworkLogs(logs *api.NetworkLogsResponse, outputPath string) error {
+// downloadNetworkLogs downloads all network log files in parallel to a
+// folder, or with a filter, only the matching requests, and writes an
+// index.json of what was saved
+func downloadNetworkLogs(ctx context.Context, logs *api.NetworkLogsResponse, outputPath string, filter *networkFilter) error {
 	var outDir string
 	var err error
 
 	if outputPath != "" {
 		// Use specified path
-		outDir = outputPath
-		if err := os.MkdirAll(outDir, 0o755); err != nil {
-			return fmt.Errorf("failed to create output directory: %w", err)
+		outDir = filepath.Clean(outputPath)
+		if err := os.MkdirAll(longPath(outDir), 0o755); err != nil {
+			return i18n.Errorf(i18n.FailedToCreateOutputDirectory, err)
 		}
 	} else {
 		// Create temp directory
 		outDir, err = os.MkdirTemp("", fmt.Sprintf("notte-network-%s-*", logs.SessionId))
 		if err != nil {
-			return fmt.Errorf("failed to create temp directory: %w", err)
+			return i18n.Errorf(i18n.FailedToCreateTempDirectory, err)
 		}
 	}
 
+	if filter != nil {
+		return captureNetworkRequests(ctx, logs, outDir, filter)
+	}
+
 	// Collect all download tasks
 	type downloadTask struct {
 		url      string
 		filename string
 		dir      string
+		batch    api.NetworkBatchFile
 	}
 	var tasks []downloadTask
 
@@ -969,12 +1244,13 @@ func downloadNetworkLogs(logs *api.NetworkLogsResponse, outputPath string) error
 				url:      *batch.DownloadUrl,
 				filename: sanitizeFilename(batch.Key),
 				dir:      outDir,
+				batch:    batch,
 			})
 		}
 	}
 
 	if len(tasks) == 0 {
-		return PrintResult(fmt.Sprintf("No network logs to download for session %s", logs.SessionId), map[string]any{
+		return PrintResult(i18n.T(i18n.NoNetworkLogs, logs.SessionId), map[string]any{
 			"session_id": logs.SessionId,
 			"path":       outDir,
 			"count":      0,
@@ -986,17 +1262,19 @@ func downloadNetworkLogs(logs *api.NetworkLogsResponse, outputPath string) error
 	errChan := make(chan error, len(tasks))
 	successCount := 0
 	var successMu sync.Mutex
+	downloaded := make([]bool, len(tasks))
 
-	for _, task := range tasks {
+	for i, task := range tasks {
 		wg.Add(1)
 		go func(t downloadTask) {
 			defer wg.Done()
 			if err := downloadFile(t.url, filepath.Join(t.dir, t.filename)); err != nil {
-				errChan <- fmt.Errorf("failed to download %s: %w", t.filename, err)
+				errChan <- i18n.Errorf(i18n.FailedToDownload, t.filename, err)
 				return
 			}
 			successMu.Lock()
 			successCount++
+			downloaded[i] = true
 			successMu.Unlock()
 		}(task)
 	}
@@ -1013,15 +1291,27 @@ func downloadNetworkLogs(logs *api.NetworkLogsResponse, outputPath string) error
 	if len(errs) > 0 {
 		// Print warning but don't fail if some downloads succeeded
 		if successCount > 0 {
-			PrintInfo(fmt.Sprintf("Warning: %d download(s) failed", len(errs)))
+			PrintInfo(i18n.T(i18n.WarningDownloadsFailed, len(errs)))
 		} else {
-			return fmt.Errorf("all downloads failed: %v", errs[0])
+			return i18n.Errorf(i18n.AllDownloadsFailed, errs[0])
+		}
+	}
+
+	index := networkIndex{SessionID: logs.SessionId, Files: []networkIndexFile{}}
+	for i, t := range tasks {
+		if downloaded[i] {
+			index.Files = append(index.Files, networkIndexFile{File: t.filename, Batch: t.batch.Key, Size: int64(t.batch.Size)})
 		}
 	}
+	indexPath, err := writeNetworkIndex(outDir, index)
+	if err != nil {
+		return err
+	}
 
-	return PrintResult(fmt.Sprintf("Downloaded %d network logs to %s", successCount, outDir), map[string]any{
+	return PrintResult(i18n.T(i18n.DownloadedNetworkLogs, successCount, outDir), map[string]any{
 		"session_id": logs.SessionId,
 		"path":       outDir,
+		"index":      indexPath,
 		"count":      successCount,
 		"batches":    len(logs.Batches),
 	})
@@ -1030,12 +1320,6 @@ func downloadNetworkLogs(logs *api.NetworkLogsResponse, outputPath string) error
 // httpClient is a shared HTTP client with timeout for downloading files
 var httpClient = &http.Client{Timeout: 60 * time.Second}
 
-// sanitizeFilename removes path traversal components from a filename
-func sanitizeFilename(filename string) string {
-	// Get only the base name to prevent directory traversal
-	return filepath.Base(filename)
-}
-
 // downloadFile downloads a file from the given URL to the given path
 func downloadFile(url, destPath string) error {
 	resp, err := httpClient.Get(url)
@@ -1048,7 +1332,7 @@ func downloadFile(url, destPath string) error {
 		return fmt.Errorf("HTTP %d", resp.StatusCode)
 	}
 
-	out, err := os.Create(destPath)
+	out, err := os.Create(longPath(destPath))
 	if err != nil {
 		return err
 	}
@@ -1080,7 +1364,8 @@ func printNetworkURLs(logs *api.NetworkLogsResponse) error {
 }
 
 func runSessionReplay(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -1095,7 +1380,7 @@ func runSessionReplay(cmd *cobra.Command, args []string) error {
 	params := &api.SessionReplayParams{}
 	resp, err := client.Client().SessionReplayWithResponse(ctx, sessionID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -1103,14 +1388,14 @@ func runSessionReplay(cmd *cobra.Command, args []string) error {
 	}
 
 	if resp.JSON200 == nil {
-		return fmt.Errorf("unexpected empty response from replay API")
+		return i18n.Errorf(i18n.UnexpectedEmptyReplay)
 	}
 
 	replay := resp.JSON200
 
 	// If no mp4_url, return the raw response data
 	if replay.Mp4Url == nil || *replay.Mp4Url == "" {
-		return PrintResult("No replay video available for this session.", map[string]any{
+		return PrintResult(i18n.T(i18n.NoReplayVideo), map[string]any{
 			"session_id": sessionID,
 			"success":    false,
 		})
@@ -1122,46 +1407,32 @@ func runSessionReplay(cmd *cobra.Command, args []string) error {
 		// Default to temp directory
 		tmpDir := os.TempDir()
 		outputPath = filepath.Join(tmpDir, fmt.Sprintf("notte-replay-%s.mp4", sessionID))
-	}
-
-	// Clean the path to resolve any ".." components
-	outputPath = filepath.Clean(outputPath)
-
-	// Create directory if it doesn't exist
-	dir := filepath.Dir(outputPath)
-	if dir != "." && dir != "/" {
-		if err := os.MkdirAll(dir, 0o755); err != nil {
-			return fmt.Errorf("failed to create directory: %w", err)
-		}
+	} else if isOutputDir(outputPath) {
+		outputPath = joinOutputPath(outputPath, fmt.Sprintf("notte-replay-%s.mp4", sessionID))
 	}
 
 	// Download the replay video from the presigned URL
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, *replay.Mp4Url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create download request: %w", err)
+		return i18n.Errorf(i18n.FailedToCreateDownloadRequest, err)
 	}
 	httpResp, err := http.DefaultClient.Do(req) //nolint:gosec // URL is from trusted API response
 	if err != nil {
-		return fmt.Errorf("failed to download replay video: %w", err)
+		return i18n.Errorf(i18n.FailedToDownloadReplayVideo, err)
 	}
 	defer func() { _ = httpResp.Body.Close() }()
 
 	if httpResp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download replay video: HTTP %d", httpResp.StatusCode)
-	}
-
-	videoData, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read replay video: %w", err)
+		return i18n.Errorf(i18n.FailedToDownloadReplayVideoHTTP, httpResp.StatusCode)
 	}
 
-	// Write the replay video file
-	err = os.WriteFile(outputPath, videoData, 0o644)
+	// Stream the replay video to the file or bucket
+	outputPath, err = writeOutputStream(ctx, outputPath, httpResp.Body, 0o644)
 	if err != nil {
-		return fmt.Errorf("failed to write replay video: %w", err)
+		return i18n.Errorf(i18n.FailedToWriteReplayVideo, err)
 	}
 
-	return PrintResult(fmt.Sprintf("Replay video saved: %s", outputPath), map[string]any{
+	return PrintResult(i18n.T(i18n.ReplayVideoSaved, outputPath), map[string]any{
 		"path":       outputPath,
 		"session_id": sessionID,
 		"success":    true,
@@ -1169,9 +1440,16 @@ func runSessionReplay(cmd *cobra.Command, args []string) error {
 }
 
 func runSessionOffset(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
+	if sessionOffsetUntilBottom && !sessionOffsetFollow {
+		return errors.New(i18n.T(i18n.UntilBottomNeedsFollow))
+	}
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
 		return err
 	}
+	if sessionOffsetFollow {
+		return followSessionOffset(cmd, sessionID)
+	}
 
 	client, err := GetClient()
 	if err != nil {
@@ -1184,7 +1462,7 @@ func runSessionOffset(cmd *cobra.Command, args []string) error {
 	params := &api.SessionOffsetParams{}
 	resp, err := client.Client().SessionOffsetWithResponse(ctx, sessionID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -1195,7 +1473,8 @@ func runSessionOffset(cmd *cobra.Command, args []string) error {
 }
 
 func runSessionWorkflowCode(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -1213,7 +1492,7 @@ func runSessionWorkflowCode(cmd *cobra.Command, args []string) error {
 	}
 	resp, err := client.Client().GetSessionScriptWithResponse(ctx, sessionID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -1234,7 +1513,8 @@ func runSessionWorkflowCode(cmd *cobra.Command, args []string) error {
 }
 
 func runSessionCode(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -1251,7 +1531,7 @@ func runSessionCode(cmd *cobra.Command, args []string) error {
 	}
 	resp, err := client.Client().GetSessionScriptWithResponse(ctx, sessionID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -1272,57 +1552,84 @@ func runSessionCode(cmd *cobra.Command, args []string) error {
 }
 
 func runSessionViewer(cmd *cobra.Command, args []string) error {
-	if err := RequireSessionID(); err != nil {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
 		return err
 	}
 
-	viewerURL := getCurrentViewerURL()
+	viewerURL, err := sessionViewerURL(cmd, sessionID)
+	if err != nil {
+		return err
+	}
 
-	// Fallback: fetch viewer URL from session status if not stored locally
-	if viewerURL == "" {
-		client, err := GetClient()
-		if err != nil {
-			return err
-		}
+	if !IsJSONOutput() {
+		PrintInfo(i18n.T(i18n.OpeningViewerInBrowser, viewerURL))
+	}
+	if err := openBrowser(viewerURL); err != nil {
+		return i18n.Errorf(i18n.FailedToOpenBrowser, err)
+	}
 
-		ctx, cancel := GetContextWithTimeout(cmd.Context())
-		defer cancel()
+	return PrintResult(i18n.T(i18n.OpenedViewerInBrowser), map[string]any{
+		"session_id": sessionID,
+		"viewer_url": viewerURL,
+		"success":    true,
+	})
+}
 
-		params := &api.SessionStatusParams{}
-		resp, err := client.Client().SessionStatusWithResponse(ctx, sessionID, params)
-		if err != nil {
-			return fmt.Errorf("API request failed: %w", err)
-		}
+// fetchViewerURL reads the viewer URL of a session from its status
+func fetchViewerURL(ctx context.Context, client *api.NotteClient, sessionID string) (string, error) {
+	ctx, cancel := GetContextWithTimeout(ctx)
+	defer cancel()
 
-		if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
-			return err
-		}
+	params := &api.SessionStatusParams{}
+	resp, err := client.Client().SessionStatusWithResponse(ctx, sessionID, params)
+	if err != nil {
+		return "", i18n.Errorf(i18n.APIRequestFailed, err)
+	}
 
-		if resp.JSON200 != nil && resp.JSON200.ViewerUrl != nil {
-			viewerURL = *resp.JSON200.ViewerUrl
-		}
+	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return "", err
 	}
 
-	if viewerURL == "" {
-		return fmt.Errorf("no viewer URL available for this session")
+	if resp.JSON200 == nil || resp.JSON200.ViewerUrl == nil || *resp.JSON200.ViewerUrl == "" {
+		return "", i18n.Errorf(i18n.NoViewerURL)
 	}
+	return *resp.JSON200.ViewerUrl, nil
+}
 
-	if !IsJSONOutput() {
-		PrintInfo(fmt.Sprintf("Opening viewer in browser: %s", viewerURL))
+// stopSession stops a session and forgets the local state kept about it
+func stopSession(ctx context.Context, client *api.NotteClient, sessionID string) error {
+	ctx, cancel := GetContextWithTimeout(ctx)
+	defer cancel()
+
+	resp, err := client.Client().SessionStopWithResponse(ctx, sessionID, &api.SessionStopParams{})
+	if err != nil {
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
-	if err := openBrowser(viewerURL); err != nil {
-		return fmt.Errorf("failed to open browser: %w", err)
+	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
 	}
+	forgetStoppedSession(sessionID)
+	return nil
+}
 
-	return PrintResult("Opened viewer in browser.", map[string]any{
-		"session_id": sessionID,
-		"viewer_url": viewerURL,
-		"success":    true,
-	})
+// forgetStoppedSession drops local state that refers to a stopped session
+func forgetStoppedSession(sessionID string) {
+	clearObserveSnapshot(sessionID)
+	clearLastScreenshot(sessionID)
+
+	// Clear current session only if it matches the stopped session
+	if readCurrentSessionFile() == sessionID {
+		_ = clearCurrentSession()
+		_ = clearCurrentViewerURL()
+		_ = clearCurrentAgent()
+		_ = clearCurrentSessionExpiry()
+	}
 }
 
-// openBrowser opens the specified URL in the default browser
-func openBrowser(url string) error {
+// openBrowser opens the specified URL in the default browser. It is a
+// variable so tests don't launch a real browser.
+var openBrowser = func(url string) error {
 	var cmd *exec.Cmd
 
 	switch runtime.GOOS {
@@ -1333,7 +1640,7 @@ func openBrowser(url string) error {
 	case "windows":
 		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
 	default:
-		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+		return i18n.Errorf(i18n.UnsupportedPlatform, runtime.GOOS)
 	}
 
 	if err := cmd.Start(); err != nil {
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/validate"
+)
+
+var (
+	sessionCloneCookies bool
+	sessionCloneStorage bool
+)
+
+var sessionsCloneCmd = &cobra.Command{
+	Use:   "clone [session-id]",
+	Short: "Start a new session with another session's configuration",
+	Long: `Start a new session with the browser, headless, timeout, viewport, user
+agent, captcha and file storage settings of an existing session (the current
+session if none is given). The clone becomes the current session.
+
+With --cookies, the source session's cookies are copied into the clone. With
+--storage, the clone opens the source's current page and gets a copy of its
+localStorage and sessionStorage.
+
+The API only reports whether a session used proxies, not which ones, so a
+source with proxies is cloned with the default Notte proxies.`,
+	Example: `  notte sessions clone
+  notte sessions clone sess_123 --cookies --storage`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSessionClone,
+}
+
+func init() {
+	sessionsCmd.AddCommand(sessionsCloneCmd)
+
+	sessionsCloneCmd.Flags().BoolVar(&sessionCloneCookies, "cookies", false, "Copy the source session's cookies")
+	sessionsCloneCmd.Flags().BoolVar(&sessionCloneStorage, "storage", false, "Open the source's current page and copy its localStorage and sessionStorage")
+}
+
+// storageStateJS reads the current page's URL and web storage
+const storageStateJS = `JSON.stringify({url: location.href, local: Object.assign({}, localStorage), session: Object.assign({}, sessionStorage)})`
+
+// storageState is a page's URL and web storage, as read by storageStateJS
+type storageState struct {
+	URL     string            `json:"url"`
+	Local   map[string]string `json:"local"`
+	Session map[string]string `json:"session"`
+}
+
+// cloneStartRequest builds a session start request with src's settings
+func cloneStartRequest(src *api.SessionResponse) (api.ApiSessionStartRequest, error) {
+	body := api.ApiSessionStartRequest{
+		Headless:           src.Headless,
+		MaxDurationMinutes: src.MaxDurationMinutes,
+		SolveCaptchas:      src.SolveCaptchas,
+		UseFileStorage:     src.UseFileStorage,
+		UserAgent:          src.UserAgent,
+		ViewportHeight:     src.ViewportHeight,
+		ViewportWidth:      src.ViewportWidth,
+		WebBotAuth:         src.WebBotAuth,
+	}
+	if src.IdleTimeoutMinutes > 0 {
+		body.IdleTimeoutMinutes = &src.IdleTimeoutMinutes
+	}
+	if src.BrowserType != nil {
+		browser := api.ApiSessionStartRequestBrowserType(*src.BrowserType)
+		body.BrowserType = &browser
+	}
+	if src.Proxies != nil && *src.Proxies {
+		var proxies api.ApiSessionStartRequest_Proxies
+		if err := proxies.FromApiSessionStartRequestProxies1(true); err != nil {
+			return body, i18n.Errorf(i18n.FailedToSetProxies, err)
+		}
+		body.Proxies = &proxies
+	}
+	return body, nil
+}
+
+func runSessionClone(cmd *cobra.Command, args []string) error {
+	var sourceID string
+	if len(args) > 0 {
+		if err := validate.SessionID(args[0]); err != nil {
+			return err
+		}
+		sourceID = args[0]
+		recordIDUse(idKindSession, sourceID)
+	} else {
+		id, err := RequireSessionID(cmd)
+		if err != nil {
+			return err
+		}
+		sourceID = id
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	defer cancel()
+
+	statusResp, err := client.Client().SessionStatusWithResponse(ctx, sourceID, &api.SessionStatusParams{})
+	if err != nil {
+		return i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	if err := HandleAPIResponse(statusResp.HTTPResponse, statusResp.Body); err != nil {
+		return err
+	}
+	if statusResp.JSON200 == nil {
+		return i18n.Errorf(i18n.EmptySessionStatusResponse)
+	}
+	source := statusResp.JSON200
+
+	// Read everything from the source before starting the clone, so a
+	// failure doesn't leave a half-copied session running
+	var cookies []api.Cookie
+	if sessionCloneCookies {
+		resp, err := client.Client().SessionCookiesGetWithResponse(ctx, sourceID, &api.SessionCookiesGetParams{})
+		if err != nil {
+			return i18n.Errorf(i18n.APIRequestFailed, err)
+		}
+		if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return err
+		}
+		if resp.JSON200 != nil {
+			cookies = resp.JSON200.Cookies
+		}
+	}
+	var storage *storageState
+	if sessionCloneStorage {
+		out, err := evalPageJS(ctx, client, sourceID, storageStateJS)
+		if err != nil {
+			return i18n.Errorf(i18n.FailedToReadSourceStorage, err)
+		}
+		storage = &storageState{}
+		if err := decodeJSResult(out, storage); err != nil {
+			return i18n.Errorf(i18n.FailedToReadSourceStorage, err)
+		}
+	}
+
+	body, err := cloneStartRequest(source)
+	if err != nil {
+		return err
+	}
+	startResp, err := client.Client().SessionStartWithResponse(ctx, &api.SessionStartParams{}, body)
+	if err != nil {
+		return i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	if err := HandleAPIResponse(startResp.HTTPResponse, startResp.Body); err != nil {
+		return err
+	}
+	if startResp.JSON200 == nil {
+		return i18n.Errorf(i18n.EmptySessionStartResponse)
+	}
+	clone := startResp.JSON200
+	rememberStartedSession(clone)
+	recordIDUse(idKindSession, clone.SessionId)
+
+	PrintInfo(i18n.T(i18n.ClonedSessionInto, sourceID, clone.SessionId))
+	if body.Proxies != nil {
+		PrintInfo(i18n.T(i18n.CloneUsesDefaultProxies))
+	}
+
+	if len(cookies) > 0 {
+		resp, err := client.Client().SessionCookiesSetWithResponse(ctx, clone.SessionId, &api.SessionCookiesSetParams{},
+			api.SessionCookiesSetJSONRequestBody{Cookies: cookies})
+		if err != nil {
+			return i18n.Errorf(i18n.CloneCookiesFailed, clone.SessionId, err)
+		}
+		if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return i18n.Errorf(i18n.CloneCookiesFailed, clone.SessionId, err)
+		}
+		PrintInfo(i18n.T(i18n.CopiedCookies, len(cookies)))
+	}
+
+	if storage != nil {
+		if err := restoreStorageState(cmd.Context(), client, clone.SessionId, storage); err != nil {
+			return i18n.Errorf(i18n.CloneStorageFailed, clone.SessionId, err)
+		}
+		PrintInfo(i18n.T(i18n.CopiedStorageItems,
+			len(storage.Local), len(storage.Session), storage.URL))
+	}
+
+	return GetFormatter().Print(clone)
+}
+
+// restoreStorageState opens state's page in the session and writes its web
+// storage back, since storage belongs to the page's origin
+func restoreStorageState(ctx context.Context, client *api.NotteClient, sessionID string, state *storageState) error {
+	if state.URL == "" || (len(state.Local) == 0 && len(state.Session) == 0) {
+		return nil
+	}
+	resp, err := sendPageActionTo(ctx, client, sessionID, map[string]any{"type": "goto", "url": state.URL})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return executeFailure(resp)
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	code := fmt.Sprintf(`(function (s) {
+  for (const [k, v] of Object.entries(s.local || {})) localStorage.setItem(k, v);
+  for (const [k, v] of Object.entries(s.session || {})) sessionStorage.setItem(k, v);
+  return "ok";
+})(%s)`, data)
+	_, err = evalPageJS(ctx, client, sessionID, code)
+	return err
+}
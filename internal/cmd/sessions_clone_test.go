@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+func TestRunSessionClone(t *testing.T) {
+	server := setupSessionTest(t)
+	origFormat := outputFormat
+	t.Cleanup(func() { outputFormat = origFormat })
+	outputFormat = "json"
+
+	origCookies, origStorage := sessionCloneCookies, sessionCloneStorage
+	t.Cleanup(func() { sessionCloneCookies, sessionCloneStorage = origCookies, origStorage })
+	sessionCloneCookies, sessionCloneStorage = true, true
+
+	const cloneID = "sess_clone_1"
+	server.AddMatchedResponse(mockserver.Match{Method: "GET", Path: "/sessions/" + sessionIDTest},
+		mockserver.JSONResponse(200, `{"session_id":"`+sessionIDTest+`","status":"active","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z",`+
+			`"idle_timeout_minutes":7,"headless":false,"browser_type":"firefox","viewport_width":1280,"user_agent":"UA","proxies":true}`))
+	server.AddMatchedResponse(mockserver.Match{Method: "GET", Path: "/sessions/" + sessionIDTest + "/cookies"},
+		mockserver.JSONResponse(200, `{"cookies":[{"name":"sid","value":"1","domain":"example.com","path":"/","httpOnly":true}]}`))
+	storage, _ := json.Marshal(storageState{URL: "https://example.com/app", Local: map[string]string{"token": "abc"}})
+	server.AddMatchedResponse(mockserver.Match{Path: "/sessions/" + sessionIDTest + "/page/execute", BodyContains: "localStorage"},
+		mockserver.JSONResponse(200, evalJSResponse(string(storage))))
+	server.AddResponse("/sessions/start", 200,
+		`{"session_id":"`+cloneID+`","status":"active","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","idle_timeout_minutes":7}`)
+	server.AddMatchedResponse(mockserver.Match{Method: "POST", Path: "/sessions/" + cloneID + "/cookies"},
+		mockserver.JSONResponse(200, `{"success":true,"message":"ok"}`))
+	server.AddResponse("/sessions/"+cloneID+"/page/execute", 200, evalJSResponse("ok"))
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	stdout, stderr := testutil.CaptureOutput(func() {
+		if err := runSessionClone(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, cloneID) {
+		t.Errorf("expected the clone in the output, got %s", stdout)
+	}
+	if !strings.Contains(stderr, "Copied 1 cookies") || !strings.Contains(stderr, "default Notte proxies") {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+
+	starts := server.Requests("/sessions/start")
+	if len(starts) != 1 {
+		t.Fatalf("expected 1 start request, got %d", len(starts))
+	}
+	var body map[string]any
+	if err := json.Unmarshal([]byte(starts[0].Body), &body); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"browser_type": "firefox", "headless": false, "idle_timeout_minutes": 7.0, "viewport_width": 1280.0, "user_agent": "UA", "proxies": true}
+	for k, v := range want {
+		if body[k] != v {
+			t.Errorf("start request %s = %v, want %v", k, body[k], v)
+		}
+	}
+
+	cookieReqs := server.Requests("/sessions/" + cloneID + "/cookies")
+	if len(cookieReqs) != 1 || !strings.Contains(cookieReqs[0].Body, `"name":"sid"`) {
+		t.Errorf("cookies were not copied: %+v", cookieReqs)
+	}
+
+	actions := server.Requests("/sessions/" + cloneID + "/page/execute")
+	if len(actions) != 2 {
+		t.Fatalf("expected goto + storage restore on the clone, got %d requests", len(actions))
+	}
+	if !strings.Contains(actions[0].Body, `"url":"https://example.com/app"`) || !strings.Contains(actions[1].Body, `token`) {
+		t.Errorf("unexpected storage restore requests: %s / %s", actions[0].Body, actions[1].Body)
+	}
+
+	if got := readCurrentSessionFile(); got != cloneID {
+		t.Errorf("current session file = %q, want the clone", got)
+	}
+}
+
+func TestRunSessionClone_SourceNotFound(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/"+sessionIDTest, 403, `{"detail":"forbidden"}`)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	if err := runSessionClone(cmd, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := len(server.Requests("/sessions/start")); got != 0 {
+		t.Errorf("no session should be started, got %d start requests", got)
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	sessionExportOutput string
+	sessionExportZip    bool
+)
+
+var sessionsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Archive the session's status, cookies, network logs, replay, and code",
+	Long: `Collect a complete record of a session run into one directory (or zip).
+
+The bundle contains:
+  status.json             Session status as returned by the API
+  cookies.json            Browser cookies
+  network.json            Network log batches
+  network/<batch>         Network log files, as recorded by the session
+  downloads.json          Files downloaded during the session
+  replay.json             Replay metadata
+  replay.mp4              Replay video
+  workflow.py             Workflow code generated from the session's actions
+  workflow_actions.json   The same actions as JSON
+  manifest.json           What was exported, and why anything is missing
+
+Only the session status is required; artifacts that can't be fetched are
+listed under "errors" in manifest.json.`,
+	Example: `  notte sessions export
+  notte sessions export --session-id sess_123 --path records/sess_123
+  notte sessions export --zip`,
+	Args: cobra.NoArgs,
+	RunE: runSessionExport,
+}
+
+func init() {
+	sessionsCmd.AddCommand(sessionsExportCmd)
+
+	addSessionIDFlag(sessionsExportCmd)
+	sessionsExportCmd.Flags().StringVar(&sessionExportOutput, "path", "", "Output directory, or zip file with --zip (default: notte-session-<session-id>)")
+	sessionsExportCmd.Flags().BoolVar(&sessionExportZip, "zip", false, "Write a zip archive instead of a directory")
+	addStdinIDsFlag(sessionsExportCmd, "session-id")
+	sessionsExportCmd.MarkFlagsMutuallyExclusive(stdinIDsFlag, "path")
+}
+
+func runSessionExport(cmd *cobra.Command, args []string) error {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	defer cancel()
+
+	statusResp, err := client.Client().SessionStatusWithResponse(ctx, sessionID, &api.SessionStatusParams{})
+	if err != nil {
+		return i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	if err := HandleAPIResponse(statusResp.HTTPResponse, statusResp.Body); err != nil {
+		return err
+	}
+	if statusResp.JSON200 == nil {
+		return i18n.Errorf(i18n.UnexpectedEmptySessionStatus)
+	}
+	status := statusResp.JSON200
+
+	bundle := newExportBundle()
+	bundle.addJSON("status.json", status)
+
+	cookiesResp, err := client.Client().SessionCookiesGetWithResponse(ctx, sessionID, &api.SessionCookiesGetParams{})
+	if err == nil {
+		err = HandleAPIResponse(cookiesResp.HTTPResponse, cookiesResp.Body)
+	}
+	if err != nil {
+		bundle.fail("cookies.json", err)
+	} else {
+		bundle.addJSON("cookies.json", cookiesResp.JSON200)
+	}
+
+	download := true
+	networkResp, err := client.Client().SessionNetworkLogsWithResponse(ctx, sessionID, &api.SessionNetworkLogsParams{Download: &download})
+	if err == nil {
+		err = HandleAPIResponse(networkResp.HTTPResponse, networkResp.Body)
+	}
+	if err == nil && networkResp.JSON200 == nil {
+		err = i18n.Errorf(i18n.UnexpectedEmptyNetworkLogs)
+	}
+	if err != nil {
+		bundle.fail("network.json", err)
+	} else {
+		bundle.addJSON("network.json", networkResp.JSON200)
+		for _, batch := range networkResp.JSON200.Batches {
+			name := "network/" + sanitizeFilename(batch.Key)
+			if batch.DownloadUrl == nil || *batch.DownloadUrl == "" {
+				bundle.fail(name, i18n.Errorf(i18n.NoDownloadURL))
+				continue
+			}
+			data, err := downloadArtifact(ctx, *batch.DownloadUrl)
+			if err != nil {
+				bundle.fail(name, err)
+				continue
+			}
+			bundle.add(name, data)
+		}
+	}
+
+	downloadsResp, err := client.Client().FileListDownloadsWithResponse(ctx, sessionID, &api.FileListDownloadsParams{})
+	if err == nil {
+		err = HandleAPIResponse(downloadsResp.HTTPResponse, downloadsResp.Body)
+	}
+	if err != nil {
+		bundle.fail("downloads.json", err)
+	} else {
+		bundle.addJSON("downloads.json", downloadsResp.JSON200)
+	}
+
+	exportReplay(ctx, client, sessionID, bundle)
+
+	params := &api.GetSessionScriptParams{
+		AsWorkflow:          true,
+		InferResponseFormat: boolPtr(true),
+	}
+	var code *api.AgentFunctionCodeResponse
+	codeResp, err := client.Client().GetSessionScriptWithResponse(ctx, sessionID, params)
+	if err == nil {
+		err = HandleAPIResponse(codeResp.HTTPResponse, codeResp.Body)
+		code = codeResp.JSON200
+	}
+	bundle.addWorkflowCode(code, err)
+
+	output := sessionExportOutput
+	if output == "" {
+		output = "notte-session-" + sessionID
+	}
+	output, files, err := bundle.write(ctx, output, sessionExportZip, map[string]any{
+		"session_id": sessionID,
+		"status":     status.Status,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printExportResult("session", sessionID, output, files, bundle)
+}
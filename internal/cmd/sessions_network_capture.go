@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	sessionNetworkOnly        []string
+	sessionNetworkURLPatterns []string
+	sessionNetworkMaxSize     string
+)
+
+// networkIndexName is the summary written next to downloaded network logs
+const networkIndexName = "index.json"
+
+func init() {
+	sessionsNetworkCmd.Flags().StringArrayVar(&sessionNetworkOnly, "only", nil, "Only keep requests whose status matches, e.g. 'status>=400' (operators: = != < <= > >=); repeatable, all must match")
+	sessionsNetworkCmd.Flags().StringArrayVar(&sessionNetworkURLPatterns, "url-pattern", nil, "Only keep requests whose URL matches (substring, or glob with *); repeatable")
+	sessionsNetworkCmd.Flags().StringVar(&sessionNetworkMaxSize, "max-size", "", "Skip requests larger than this size (e.g. 500KB, 2MB)")
+}
+
+// statusCondition compares a response status against a value
+type statusCondition struct {
+	op    string
+	value int
+}
+
+var statusConditionPattern = regexp.MustCompile(`^status\s*(>=|<=|!=|==|=|>|<)\s*([1-5][0-9]{2})$`)
+
+func parseStatusCondition(expr string) (statusCondition, error) {
+	m := statusConditionPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(expr)))
+	if m == nil {
+		return statusCondition{}, i18n.Errorf(i18n.InvalidOnly, expr)
+	}
+	value, _ := strconv.Atoi(m[2])
+	return statusCondition{op: m[1], value: value}, nil
+}
+
+// match reports whether status satisfies the condition; an unknown status
+// (0) never does
+func (c statusCondition) match(status int) bool {
+	if status == 0 {
+		return false
+	}
+	switch c.op {
+	case ">=":
+		return status >= c.value
+	case "<=":
+		return status <= c.value
+	case ">":
+		return status > c.value
+	case "<":
+		return status < c.value
+	case "!=":
+		return status != c.value
+	default:
+		return status == c.value
+	}
+}
+
+// parseByteSize reads sizes like 512, 500KB or 2MB (1 KB = 1024 bytes)
+func parseByteSize(s string) (int64, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(v, unit.suffix) {
+			v, multiplier = strings.TrimSpace(strings.TrimSuffix(v, unit.suffix)), unit.size
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil || n < 0 {
+		return 0, i18n.Errorf(i18n.InvalidSize, s)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// sessionNetworkFilter combines --filter, --only, --url-pattern and
+// --max-size. It returns nil when none of them is set.
+func sessionNetworkFilter() (*networkFilter, error) {
+	if len(sessionNetworkFilters) == 0 && len(sessionNetworkOnly) == 0 &&
+		len(sessionNetworkURLPatterns) == 0 && sessionNetworkMaxSize == "" {
+		return nil, nil
+	}
+	f := parseNetworkFilters(sessionNetworkFilters)
+	for _, expr := range sessionNetworkOnly {
+		c, err := parseStatusCondition(expr)
+		if err != nil {
+			return nil, err
+		}
+		f.conditions = append(f.conditions, c)
+	}
+	for _, p := range sessionNetworkURLPatterns {
+		if p = strings.TrimSpace(p); p != "" {
+			f.urls = append(f.urls, urlPattern(p))
+		}
+	}
+	if sessionNetworkMaxSize != "" {
+		size, err := parseByteSize(sessionNetworkMaxSize)
+		if err != nil {
+			return nil, i18n.Errorf(i18n.InvalidMaxSize, err)
+		}
+		f.maxSize = size
+	}
+	return f, nil
+}
+
+// networkIndex summarizes a network log download in index.json
+type networkIndex struct {
+	SessionID string             `json:"session_id"`
+	Filters   map[string]any     `json:"filters,omitempty"`
+	Files     []networkIndexFile `json:"files"`
+	Skipped   int                `json:"skipped,omitempty"`
+}
+
+// networkIndexFile is one downloaded file: a whole log batch, or with
+// filters, one matching request
+type networkIndexFile struct {
+	File   string `json:"file"`
+	Batch  string `json:"batch"`
+	Method string `json:"method,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Status int    `json:"status,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+}
+
+func writeNetworkIndex(dir string, index networkIndex) (string, error) {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, networkIndexName)
+	if err := os.WriteFile(longPath(path), append(data, '\n'), 0o644); err != nil {
+		return "", i18n.Errorf(i18n.FailedToWrite, networkIndexName, err)
+	}
+	return path, nil
+}
+
+// networkFilterFlags lists the filters in effect, for index.json
+func networkFilterFlags() map[string]any {
+	filters := map[string]any{}
+	if len(sessionNetworkOnly) > 0 {
+		filters["only"] = sessionNetworkOnly
+	}
+	if len(sessionNetworkURLPatterns) > 0 {
+		filters["url_pattern"] = sessionNetworkURLPatterns
+	}
+	if sessionNetworkMaxSize != "" {
+		filters["max_size"] = sessionNetworkMaxSize
+	}
+	return filters
+}
+
+// captureNetworkRequests downloads the log batches, keeps the requests
+// matching filter and writes each one to its own file in outDir, along with
+// index.json
+func captureNetworkRequests(ctx context.Context, logs *api.NetworkLogsResponse, outDir string, filter *networkFilter) error {
+	index := networkIndex{SessionID: logs.SessionId, Filters: networkFilterFlags(), Files: []networkIndexFile{}}
+	failed := 0
+	for _, batch := range logs.Batches {
+		if batch.DownloadUrl == nil || *batch.DownloadUrl == "" {
+			continue
+		}
+		data, err := fetchNetworkBatch(ctx, *batch.DownloadUrl)
+		if err != nil {
+			PrintInfo(i18n.T(i18n.WarningCouldNotReadNetworkBatch, batch.Key, err))
+			failed++
+			continue
+		}
+		for _, rec := range parseNetworkRecords(data) {
+			e, ok := networkEntryFrom(rec)
+			if !ok {
+				continue
+			}
+			if !filter.match(e) {
+				index.Skipped++
+				continue
+			}
+			name := fmt.Sprintf("%04d-%s-%d.json", len(index.Files)+1, sanitizeFilename(strings.ToUpper(e.Method)), e.Status)
+			content, err := json.MarshalIndent(rec, "", "  ")
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(longPath(filepath.Join(outDir, name)), append(content, '\n'), 0o644); err != nil {
+				return i18n.Errorf(i18n.FailedToWrite, name, err)
+			}
+			index.Files = append(index.Files, networkIndexFile{
+				File: name, Batch: batch.Key, Method: e.Method, URL: e.URL, Status: e.Status, Size: e.Size,
+			})
+		}
+	}
+	if failed > 0 && failed == len(logs.Batches) {
+		return i18n.Errorf(i18n.AllNetworkLogDownloadsFailed)
+	}
+
+	indexPath, err := writeNetworkIndex(outDir, index)
+	if err != nil {
+		return err
+	}
+	return PrintResult(i18n.T(i18n.SavedMatchingRequests, len(index.Files), index.Skipped, outDir), map[string]any{
+		"session_id": logs.SessionId,
+		"path":       outDir,
+		"index":      indexPath,
+		"count":      len(index.Files),
+		"skipped":    index.Skipped,
+	})
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func TestParseStatusCondition(t *testing.T) {
+	tests := []struct {
+		expr   string
+		status int
+		want   bool
+	}{
+		{"status>=400", 404, true},
+		{"status>=400", 200, false},
+		{"status >= 400", 500, true},
+		{"status<300", 204, true},
+		{"status=404", 404, true},
+		{"status==404", 403, false},
+		{"status!=200", 301, true},
+		{"STATUS>499", 500, true},
+		{"status>=400", 0, false},
+	}
+	for _, tt := range tests {
+		c, err := parseStatusCondition(tt.expr)
+		if err != nil {
+			t.Fatalf("parseStatusCondition(%q): %v", tt.expr, err)
+		}
+		if got := c.match(tt.status); got != tt.want {
+			t.Errorf("%q on %d = %v, want %v", tt.expr, tt.status, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"status>=", "code>=400", "status~400", "status>=4000"} {
+		if _, err := parseStatusCondition(bad); err == nil {
+			t.Errorf("parseStatusCondition(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := map[string]int64{
+		"512":    512,
+		"512B":   512,
+		"500KB":  500 << 10,
+		"1.5 mb": 3 << 19,
+		"2G":     2 << 30,
+	}
+	for in, want := range tests {
+		got, err := parseByteSize(in)
+		if err != nil {
+			t.Fatalf("parseByteSize(%q): %v", in, err)
+		}
+		if got != want {
+			t.Errorf("parseByteSize(%q) = %d, want %d", in, got, want)
+		}
+	}
+	for _, bad := range []string{"", "big", "-1KB"} {
+		if _, err := parseByteSize(bad); err == nil {
+			t.Errorf("parseByteSize(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func setNetworkCaptureFlags(t *testing.T, only, patterns []string, maxSize, path string) {
+	t.Helper()
+	origOnly, origPatterns, origMax, origPath := sessionNetworkOnly, sessionNetworkURLPatterns, sessionNetworkMaxSize, sessionNetworkPath
+	sessionNetworkOnly, sessionNetworkURLPatterns, sessionNetworkMaxSize, sessionNetworkPath = only, patterns, maxSize, path
+	t.Cleanup(func() {
+		sessionNetworkOnly, sessionNetworkURLPatterns, sessionNetworkMaxSize, sessionNetworkPath = origOnly, origPatterns, origMax, origPath
+	})
+}
+
+func readNetworkIndex(t *testing.T, dir string) networkIndex {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, networkIndexName))
+	if err != nil {
+		t.Fatalf("failed to read index: %v", err)
+	}
+	var index networkIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("invalid index: %v", err)
+	}
+	return index
+}
+
+func TestRunSessionNetwork_CaptureFilters(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/batches/b1.json", 200, `[
+		{"method":"GET","url":"https://a.test/api/users","status":200,"size":100},
+		{"method":"POST","url":"https://a.test/api/orders","status":500,"size":300,"body":"boom"},
+		{"method":"GET","url":"https://a.test/logo.png","status":404,"size":50},
+		{"method":"GET","url":"https://a.test/api/huge","status":502,"size":5000000}
+	]`)
+	server.AddResponse("/sessions/"+sessionIDTest+"/network/logs", 200,
+		`{"session_id":"`+sessionIDTest+`","total_batch_count":1,"batches":[{"key":"b1","size":10,"download_url":"`+server.URL()+`/batches/b1.json"}]}`)
+
+	dir := t.TempDir()
+	setNetworkCaptureFlags(t, []string{"status>=400"}, []string{"/api/"}, "1MB", dir)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	_, _ = testutil.CaptureOutput(func() {
+		if err := runSessionNetwork(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	index := readNetworkIndex(t, dir)
+	if len(index.Files) != 1 || index.Skipped != 3 {
+		t.Fatalf("expected 1 file and 3 skipped, got %+v", index)
+	}
+	f := index.Files[0]
+	if f.URL != "https://a.test/api/orders" || f.Status != 500 || f.Batch != "b1" {
+		t.Errorf("unexpected index entry: %+v", f)
+	}
+	if index.Filters["max_size"] != "1MB" {
+		t.Errorf("expected filters in index, got %v", index.Filters)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, f.File))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", f.File, err)
+	}
+	if !strings.Contains(string(data), `"boom"`) {
+		t.Errorf("expected the full record, got %s", data)
+	}
+}
+
+func TestRunSessionNetwork_WritesIndex(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/batches/b1.json", 200, `[]`)
+	server.AddResponse("/sessions/"+sessionIDTest+"/network/logs", 200,
+		`{"session_id":"`+sessionIDTest+`","total_batch_count":1,"batches":[{"key":"b1.json","size":2,"download_url":"`+server.URL()+`/batches/b1.json"}]}`)
+
+	dir := t.TempDir()
+	setNetworkCaptureFlags(t, nil, nil, "", dir)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	_, _ = testutil.CaptureOutput(func() {
+		if err := runSessionNetwork(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	index := readNetworkIndex(t, dir)
+	if len(index.Files) != 1 || index.Files[0].File != "b1.json" || index.Files[0].Size != 2 {
+		t.Errorf("unexpected index: %+v", index)
+	}
+}
+
+func TestRunSessionNetwork_CaptureWithURLsOnly(t *testing.T) {
+	_ = setupSessionTest(t)
+	setNetworkCaptureFlags(t, []string{"status>=400"}, nil, "", "")
+
+	origURLsOnly := sessionNetworkURLsOnly
+	sessionNetworkURLsOnly = true
+	t.Cleanup(func() { sessionNetworkURLsOnly = origURLsOnly })
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	err := runSessionNetwork(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--urls-only") {
+		t.Fatalf("expected --urls-only error, got %v", err)
+	}
+}
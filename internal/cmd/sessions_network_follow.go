@@ -0,0 +1,366 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	sessionNetworkFollow   bool
+	sessionNetworkInterval time.Duration
+	sessionNetworkFilters  []string
+)
+
+// maxNetworkBatchSize bounds how much of one log batch is read while following
+const maxNetworkBatchSize = 64 * 1024 * 1024
+
+func init() {
+	sessionsNetworkCmd.Flags().BoolVarP(&sessionNetworkFollow, "follow", "f", false, "Poll the network logs and print new requests as they happen (Ctrl-C to stop)")
+	sessionsNetworkCmd.Flags().DurationVar(&sessionNetworkInterval, "interval", 2*time.Second, "How often --follow polls for new requests")
+	sessionsNetworkCmd.Flags().StringArrayVar(&sessionNetworkFilters, "filter", nil, "With --follow, only show requests whose status (404, 4xx) or URL (substring, or glob with *) matches; repeatable")
+}
+
+// networkEntry is one request from a network log batch
+type networkEntry struct {
+	Method string `json:"method"`
+	Status int    `json:"status,omitempty"`
+	URL    string `json:"url"`
+	Size   int64  `json:"size,omitempty"`
+}
+
+// networkFilter keeps the entries matching any of its status filters, any
+// of its URL filters, all of its status conditions and its size limit
+type networkFilter struct {
+	statuses   []string
+	urls       []*regexp.Regexp
+	conditions []statusCondition
+	maxSize    int64
+}
+
+var statusFilterPattern = regexp.MustCompile(`^[1-5]([0-9]{2}|xx)$`)
+
+func parseNetworkFilters(values []string) *networkFilter {
+	f := &networkFilter{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		switch {
+		case v == "":
+		case statusFilterPattern.MatchString(strings.ToLower(v)):
+			f.statuses = append(f.statuses, strings.ToLower(v))
+		default:
+			f.urls = append(f.urls, urlPattern(v))
+		}
+	}
+	return f
+}
+
+// urlPattern matches URLs containing v, or the whole URL when v is a glob
+// with *
+func urlPattern(v string) *regexp.Regexp {
+	pattern := regexp.QuoteMeta(v)
+	if strings.Contains(v, "*") {
+		pattern = "^" + strings.ReplaceAll(pattern, `\*`, ".*") + "$"
+	}
+	return regexp.MustCompile(pattern)
+}
+
+func (f *networkFilter) match(e networkEntry) bool {
+	for _, c := range f.conditions {
+		if !c.match(e.Status) {
+			return false
+		}
+	}
+	if f.maxSize > 0 && e.Size > f.maxSize {
+		return false
+	}
+	if len(f.statuses) > 0 {
+		status := strconv.Itoa(e.Status)
+		ok := false
+		for _, s := range f.statuses {
+			if s == status || (strings.HasSuffix(s, "xx") && e.Status > 0 && s[0] == status[0]) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(f.urls) > 0 {
+		for _, re := range f.urls {
+			if re.MatchString(e.URL) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// parseNetworkBatch reads the requests of a log batch, which may be a HAR
+// file, a JSON array or newline-delimited JSON records
+func parseNetworkBatch(data []byte) []networkEntry {
+	var entries []networkEntry
+	for _, rec := range parseNetworkRecords(data) {
+		if e, ok := networkEntryFrom(rec); ok {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// parseNetworkRecords returns the raw records of a log batch
+func parseNetworkRecords(data []byte) []map[string]any {
+	var records []map[string]any
+
+	trimmed := bytes.TrimSpace(data)
+	var doc any
+	if json.Unmarshal(trimmed, &doc) == nil {
+		switch v := doc.(type) {
+		case []any:
+			records = appendRecords(records, v)
+		case map[string]any:
+			if log, ok := v["log"].(map[string]any); ok {
+				entries, _ := log["entries"].([]any)
+				records = appendRecords(records, entries)
+			} else if entries, ok := v["entries"].([]any); ok {
+				records = appendRecords(records, entries)
+			} else {
+				records = append(records, v)
+			}
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+		scanner.Buffer(make([]byte, 64*1024), maxStreamLineSize)
+		for scanner.Scan() {
+			var rec map[string]any
+			if json.Unmarshal(scanner.Bytes(), &rec) == nil {
+				records = append(records, rec)
+			}
+		}
+	}
+	return records
+}
+
+func appendRecords(records []map[string]any, items []any) []map[string]any {
+	for _, item := range items {
+		if rec, ok := item.(map[string]any); ok {
+			records = append(records, rec)
+		}
+	}
+	return records
+}
+
+// networkEntryFrom picks the request fields out of a log record, looking at
+// the top level first and then at HAR-style request/response objects
+func networkEntryFrom(rec map[string]any) (networkEntry, bool) {
+	req, _ := rec["request"].(map[string]any)
+	resp, _ := rec["response"].(map[string]any)
+
+	e := networkEntry{
+		Method: firstString(rec["method"], req["method"]),
+		URL:    firstString(rec["url"], req["url"], resp["url"]),
+		Status: int(firstNumber(rec["status"], rec["status_code"], resp["status"], resp["status_code"])),
+		Size: int64(firstNumber(rec["size"], rec["response_size"], rec["encoded_data_length"],
+			resp["bodySize"], resp["body_size"], resp["encoded_data_length"])),
+	}
+	if content, ok := resp["content"].(map[string]any); ok && e.Size <= 0 {
+		e.Size = int64(firstNumber(content["size"]))
+	}
+	if e.Size < 0 {
+		e.Size = 0
+	}
+	return e, e.URL != ""
+}
+
+func firstString(values ...any) string {
+	for _, v := range values {
+		if s, ok := v.(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func firstNumber(values ...any) float64 {
+	for _, v := range values {
+		switch n := v.(type) {
+		case float64:
+			return n
+		case string:
+			if f, err := strconv.ParseFloat(n, 64); err == nil {
+				return f
+			}
+		}
+	}
+	return 0
+}
+
+// formatByteSize renders n bytes for humans, e.g. 12.3 KB
+func formatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// networkFollower prints the requests of log batches it hasn't seen yet
+type networkFollower struct {
+	client    *api.NotteClient
+	sessionID string
+	filter    *networkFilter
+	out       io.Writer
+	seen      map[string]bool
+}
+
+// poll fetches the batch list and prints the requests of new batches. With
+// print false, new batches are only marked as seen.
+func (nf *networkFollower) poll(ctx context.Context, print bool) error {
+	reqCtx, cancel := GetContextWithTimeout(ctx)
+	defer cancel()
+
+	download := true
+	resp, err := nf.client.Client().SessionNetworkLogsWithResponse(reqCtx, nf.sessionID, &api.SessionNetworkLogsParams{Download: &download})
+	if err != nil {
+		return i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return nil
+	}
+
+	var fresh []api.NetworkBatchFile
+	for _, batch := range resp.JSON200.Batches {
+		if !nf.seen[batch.Key] {
+			fresh = append(fresh, batch)
+		}
+	}
+	sort.Slice(fresh, func(i, j int) bool { return fresh[i].Key < fresh[j].Key })
+
+	for _, batch := range fresh {
+		if !print {
+			nf.seen[batch.Key] = true
+			continue
+		}
+		if batch.DownloadUrl == nil || *batch.DownloadUrl == "" {
+			continue // not downloadable yet; try again next poll
+		}
+		data, err := fetchNetworkBatch(reqCtx, *batch.DownloadUrl)
+		if err != nil {
+			PrintInfo(i18n.T(i18n.WarningCouldNotReadNetworkBatch, batch.Key, err))
+			continue
+		}
+		nf.seen[batch.Key] = true
+		for _, e := range parseNetworkBatch(data) {
+			if nf.filter.match(e) {
+				nf.print(e)
+			}
+		}
+	}
+	return nil
+}
+
+func (nf *networkFollower) print(e networkEntry) {
+	if IsJSONOutput() {
+		_ = json.NewEncoder(nf.out).Encode(e)
+		return
+	}
+	status, size := "-", "-"
+	if e.Status > 0 {
+		status = strconv.Itoa(e.Status)
+	}
+	if e.Size > 0 {
+		size = formatByteSize(e.Size)
+	}
+	_, _ = fmt.Fprintf(nf.out, "%-7s %-3s %9s  %s\n", e.Method, status, size, e.URL)
+}
+
+func fetchNetworkBatch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, i18n.Errorf(i18n.DownloadFailedWithStatus, resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxNetworkBatchSize))
+}
+
+// followSessionNetwork prints requests from log batches written after it
+// starts, until interrupted
+func followSessionNetwork(cmd *cobra.Command, sessionID string, filter *networkFilter) error {
+	if sessionNetworkInterval <= 0 {
+		return i18n.Errorf(i18n.IntervalMustBePositive)
+	}
+	if filter == nil {
+		filter = &networkFilter{}
+	}
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	nf := &networkFollower{
+		client:    client,
+		sessionID: sessionID,
+		filter:    filter,
+		out:       os.Stdout,
+		seen:      map[string]bool{},
+	}
+	// Only show traffic from now on; older batches are what plain
+	// `sessions network` downloads
+	if err := nf.poll(ctx, false); err != nil {
+		return err
+	}
+	PrintInfo(i18n.T(i18n.FollowingNetworkRequests, sessionID))
+
+	ticker := time.NewTicker(sessionNetworkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if err := nf.poll(ctx, true); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func TestParseNetworkBatch(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []networkEntry
+	}{
+		{
+			name: "json array",
+			data: `[{"method":"GET","url":"https://a.test/","status":200,"size":1234}]`,
+			want: []networkEntry{{Method: "GET", URL: "https://a.test/", Status: 200, Size: 1234}},
+		},
+		{
+			name: "har",
+			data: `{"log":{"entries":[{"request":{"method":"POST","url":"https://a.test/api"},"response":{"status":404,"bodySize":-1,"content":{"size":52}}}]}}`,
+			want: []networkEntry{{Method: "POST", URL: "https://a.test/api", Status: 404, Size: 52}},
+		},
+		{
+			name: "ndjson",
+			data: "{\"method\":\"GET\",\"url\":\"https://a.test/1\",\"status_code\":301}\n{\"url\":\"\"}\n{\"method\":\"GET\",\"url\":\"https://a.test/2\"}\n",
+			want: []networkEntry{{Method: "GET", URL: "https://a.test/1", Status: 301}, {Method: "GET", URL: "https://a.test/2"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseNetworkBatch([]byte(tt.data))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d entries, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("entry %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNetworkFilter(t *testing.T) {
+	ok := networkEntry{URL: "https://a.test/api/users", Status: 200}
+	missing := networkEntry{URL: "https://a.test/logo.png", Status: 404}
+	broken := networkEntry{URL: "https://a.test/api/orders", Status: 500}
+
+	tests := []struct {
+		filters []string
+		want    []bool
+	}{
+		{nil, []bool{true, true, true}},
+		{[]string{"4xx"}, []bool{false, true, false}},
+		{[]string{"4xx", "5XX"}, []bool{false, true, true}},
+		{[]string{"200"}, []bool{true, false, false}},
+		{[]string{"/api/"}, []bool{true, false, true}},
+		{[]string{"*.png"}, []bool{false, true, false}},
+		{[]string{"/api/", "5xx"}, []bool{false, false, true}},
+	}
+	for _, tt := range tests {
+		f := parseNetworkFilters(tt.filters)
+		for i, e := range []networkEntry{ok, missing, broken} {
+			if got := f.match(e); got != tt.want[i] {
+				t.Errorf("filters %v on %s (%d) = %v, want %v", tt.filters, e.URL, e.Status, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	tests := map[int64]string{
+		0:         "0 B",
+		512:       "512 B",
+		1536:      "1.5 KB",
+		3 << 20:   "3.0 MB",
+		5<<30 + 1: "5.0 GB",
+	}
+	for n, want := range tests {
+		if got := formatByteSize(n); got != want {
+			t.Errorf("formatByteSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestNetworkFollowerPoll(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/batches/b2.json", 200,
+		`[{"method":"GET","url":"https://a.test/","status":200,"size":2048},{"method":"GET","url":"https://a.test/missing","status":404}]`)
+	server.AddResponse("/sessions/"+sessionIDTest+"/network/logs", 200,
+		`{"session_id":"`+sessionIDTest+`","total_batch_count":2,"batches":[`+
+			`{"key":"b2","size":10,"download_url":"`+server.URL()+`/batches/b2.json"},`+
+			`{"key":"b1","size":10,"download_url":"`+server.URL()+`/batches/b1.json"}]}`)
+
+	origFormat := outputFormat
+	outputFormat = "text"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	client, err := GetClient()
+	if err != nil {
+		t.Fatalf("GetClient: %v", err)
+	}
+	var out bytes.Buffer
+	nf := &networkFollower{
+		client:    client,
+		sessionID: sessionIDTest,
+		filter:    parseNetworkFilters([]string{"2xx"}),
+		out:       &out,
+		seen:      map[string]bool{"b1": true},
+	}
+
+	if err := nf.poll(context.Background(), true); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	got := out.String()
+	if !strings.Contains(got, "GET") || !strings.Contains(got, "200") || !strings.Contains(got, "2.0 KB") || !strings.Contains(got, "https://a.test/") {
+		t.Errorf("unexpected output: %q", got)
+	}
+	if strings.Contains(got, "missing") {
+		t.Errorf("filtered request printed: %q", got)
+	}
+	if len(server.Requests("/batches/b1.json")) != 0 {
+		t.Error("already seen batch was downloaded again")
+	}
+
+	// A second poll with nothing new prints nothing
+	out.Reset()
+	if err := nf.poll(context.Background(), true); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no output, got %q", out.String())
+	}
+}
+
+func TestRunSessionNetwork_FilterWithoutFollow(t *testing.T) {
+	_ = setupSessionTest(t)
+
+	origFilters := sessionNetworkFilters
+	sessionNetworkFilters = []string{"4xx"}
+	t.Cleanup(func() { sessionNetworkFilters = origFilters })
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	_, _ = testutil.CaptureOutput(func() {
+		err := runSessionNetwork(cmd, nil)
+		if err == nil || !strings.Contains(err.Error(), "--follow") {
+			t.Fatalf("expected --follow error, got %v", err)
+		}
+	})
+}
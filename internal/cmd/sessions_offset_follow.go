@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	sessionOffsetFollow      bool
+	sessionOffsetInterval    time.Duration
+	sessionOffsetUntilBottom bool
+)
+
+// scrollBottomSlack is how many pixels short of the end of the page still
+// count as the bottom, for pages whose height isn't a whole number of pixels
+const scrollBottomSlack = 2
+
+// scrollStateJS reports the page's scroll offsets, viewport and full size
+const scrollStateJS = `JSON.stringify({
+  scroll_x: Math.round(window.scrollX),
+  scroll_y: Math.round(window.scrollY),
+  viewport_width: window.innerWidth,
+  viewport_height: window.innerHeight,
+  page_width: document.documentElement.scrollWidth,
+  page_height: document.documentElement.scrollHeight
+})`
+
+func init() {
+	sessionsOffsetCmd.Flags().BoolVarP(&sessionOffsetFollow, "follow", "f", false, "Track the page's scroll offsets and viewport, printing them whenever they change (Ctrl-C to stop)")
+	sessionsOffsetCmd.Flags().DurationVar(&sessionOffsetInterval, "interval", time.Second, "How often --follow checks the page")
+	sessionsOffsetCmd.Flags().BoolVar(&sessionOffsetUntilBottom, "until-bottom", false, "With --follow, stop once the page is scrolled to the bottom")
+}
+
+// scrollState is where the page is scrolled to
+type scrollState struct {
+	ScrollX        int  `json:"scroll_x"`
+	ScrollY        int  `json:"scroll_y"`
+	ViewportWidth  int  `json:"viewport_width"`
+	ViewportHeight int  `json:"viewport_height"`
+	PageWidth      int  `json:"page_width"`
+	PageHeight     int  `json:"page_height"`
+	AtBottom       bool `json:"at_bottom"`
+}
+
+// scrolledPercent is how much of the page has been in view, from 0 to 100
+func (s scrollState) scrolledPercent() int {
+	if s.PageHeight <= 0 {
+		return 100
+	}
+	return min(100, (s.ScrollY+s.ViewportHeight)*100/s.PageHeight)
+}
+
+// readScrollState evaluates scrollStateJS on the page
+func readScrollState(ctx context.Context, client *api.NotteClient, sessionID string) (scrollState, error) {
+	var state scrollState
+	out, err := evalPageJS(ctx, client, sessionID, scrollStateJS)
+	if err != nil {
+		return state, err
+	}
+	if err := decodeJSResult(out, &state); err != nil {
+		return state, i18n.Errorf(i18n.UnexpectedScrollState, err)
+	}
+	state.AtBottom = state.ScrollY+state.ViewportHeight >= state.PageHeight-scrollBottomSlack
+	return state, nil
+}
+
+func printScrollState(out io.Writer, s scrollState) {
+	if IsJSONOutput() {
+		_ = json.NewEncoder(out).Encode(s)
+		return
+	}
+	bottom := ""
+	if s.AtBottom {
+		bottom = "  bottom"
+	}
+	_, _ = fmt.Fprintf(out, "scroll %d,%d  viewport %dx%d  page %dx%d  %3d%%%s\n",
+		s.ScrollX, s.ScrollY, s.ViewportWidth, s.ViewportHeight, s.PageWidth, s.PageHeight, s.scrolledPercent(), bottom)
+}
+
+// followSessionOffset prints the page's scroll state whenever it changes,
+// until interrupted or, with --until-bottom, until the bottom is reached
+func followSessionOffset(cmd *cobra.Command, sessionID string) error {
+	if sessionOffsetInterval <= 0 {
+		return errors.New(i18n.T(i18n.IntervalMustBePositive))
+	}
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	PrintInfo(i18n.T(i18n.FollowingScrollPosition, sessionID))
+	var last *scrollState
+	for {
+		state, err := readScrollState(ctx, client, sessionID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if last == nil || state != *last {
+			printScrollState(os.Stdout, state)
+			last = &state
+		}
+		if sessionOffsetUntilBottom && state.AtBottom {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(sessionOffsetInterval):
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+// scrollStateResponse is an evaluate_js result for scrollStateJS, escaped as
+// the API returns it
+func scrollStateResponse(scrollY, pageHeight int) mockserver.Response {
+	state := fmt.Sprintf(`{\"scroll_x\":0,\"scroll_y\":%d,\"viewport_width\":1280,\"viewport_height\":720,\"page_width\":1280,\"page_height\":%d}`, scrollY, pageHeight)
+	return mockserver.JSONResponse(200, `{"action":{"type":"evaluate_js"},"success":true,"message":"ok","data":{"markdown":"`+state+`"},"started_at":"2020-01-01T00:00:00Z","ended_at":"2020-01-01T00:00:00Z"}`)
+}
+
+func setupOffsetFollowTest(t *testing.T) *testutil.MockServer {
+	t.Helper()
+	server := setupSessionTest(t)
+	origFollow, origInterval, origUntil, origFormat := sessionOffsetFollow, sessionOffsetInterval, sessionOffsetUntilBottom, outputFormat
+	t.Cleanup(func() {
+		sessionOffsetFollow, sessionOffsetInterval, sessionOffsetUntilBottom, outputFormat = origFollow, origInterval, origUntil, origFormat
+	})
+	sessionOffsetFollow, sessionOffsetInterval, sessionOffsetUntilBottom, outputFormat = true, time.Millisecond, true, "text"
+	return server
+}
+
+func TestRunSessionOffset_FollowUntilBottom(t *testing.T) {
+	server := setupOffsetFollowTest(t)
+	server.AddSequence("/sessions/"+sessionIDTest+"/page/execute",
+		scrollStateResponse(0, 3000),
+		scrollStateResponse(0, 3000),
+		scrollStateResponse(1500, 3000),
+		scrollStateResponse(2280, 3000),
+	)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSessionOffset(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	want := []string{
+		"Following the scroll position of session " + sessionIDTest + " (Ctrl-C to stop)",
+		"scroll 0,0  viewport 1280x720  page 1280x3000   24%",
+		"scroll 0,1500  viewport 1280x720  page 1280x3000   74%",
+		"scroll 0,2280  viewport 1280x720  page 1280x3000  100%  bottom",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected only the changes, got:\n%s", stdout)
+	}
+	if n := len(server.Requests("/sessions/" + sessionIDTest + "/page/execute")); n != 4 {
+		t.Errorf("expected to stop at the bottom after 4 checks, got %d", n)
+	}
+}
+
+func TestRunSessionOffset_FollowJSON(t *testing.T) {
+	server := setupOffsetFollowTest(t)
+	server.AddSequence("/sessions/"+sessionIDTest+"/page/execute", scrollStateResponse(0, 700))
+	outputFormat = "json"
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSessionOffset(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	want := `{"scroll_x":0,"scroll_y":0,"viewport_width":1280,"viewport_height":720,"page_width":1280,"page_height":700,"at_bottom":true}`
+	if strings.TrimSpace(stdout) != want {
+		t.Errorf("got %s, want %s", stdout, want)
+	}
+}
+
+func TestRunSessionOffset_UntilBottomWithoutFollow(t *testing.T) {
+	_ = setupOffsetFollowTest(t)
+	sessionOffsetFollow = false
+
+	err := runSessionOffset(&cobra.Command{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "--follow") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
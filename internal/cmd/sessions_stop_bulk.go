@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	sessionsStopAll     bool
+	sessionsStopFilters []string
+)
+
+// Filters of sessions stop --all
+const (
+	stopFilterOnlyActive = "only-active"
+	stopFilterMine       = "mine"
+)
+
+var stopFilters = []string{stopFilterOnlyActive, stopFilterMine}
+
+// stopAllMaxPages bounds how many pages of sessions --all reads
+const stopAllMaxPages = 50
+
+// stopSummaryIDs is how many session IDs the bulk confirmation names
+const stopSummaryIDs = 5
+
+func init() {
+	sessionsStopCmd.Flags().BoolVar(&sessionsStopAll, "all", false, "Stop every session listed for your account")
+	sessionsStopCmd.Flags().StringSliceVar(&sessionsStopFilters, "filter", nil, "With --all, only stop sessions matching this filter: only-active, mine (started with the current API key); repeatable")
+	_ = sessionsStopCmd.Flags().SetAnnotation("filter", flagEnumAnnotation, stopFilters)
+	addStdinIDsFlag(sessionsStopCmd, "session-id")
+	sessionsStopCmd.MarkFlagsMutuallyExclusive("all", stdinIDsFlag)
+}
+
+// sessionStopResult is the outcome of stopping one session of a bulk stop
+type sessionStopResult struct {
+	SessionID string `json:"session_id"`
+	Stopped   bool   `json:"stopped"`
+	Error     string `json:"error,omitempty"`
+}
+
+// runSessionStopAll stops every session listed for the account after a
+// single confirmation
+func runSessionStopAll(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("session-id") {
+		return errors.New(i18n.T(i18n.SessionIDWithAll))
+	}
+	for _, filter := range sessionsStopFilters {
+		if !slices.Contains(stopFilters, filter) {
+			return i18n.Errorf(i18n.InvalidFilter, filter, strings.Join(stopFilters, " or "))
+		}
+	}
+
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+	ids, err := listSessionsToStop(cmd.Context(), client, sessionsStopFilters)
+	if err != nil {
+		return err
+	}
+
+	policy, err := loadPolicy()
+	if err != nil {
+		return err
+	}
+	if policy != nil {
+		ids = slices.DeleteFunc(ids, func(id string) bool {
+			if slices.Contains(policy.Protect, id) {
+				PrintInfo(i18n.T(i18n.SkippingProtectedSession, id))
+				return true
+			}
+			return false
+		})
+	}
+	if len(ids) == 0 {
+		return PrintResult(i18n.T(i18n.NoSessionsToStop), map[string]any{"sessions": []sessionStopResult{}})
+	}
+
+	confirmed, err := ConfirmStop(fmt.Sprintf("%d session(s):", len(ids)), summarizeIDs(ids, stopSummaryIDs))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return PrintResult(i18n.T(i18n.Cancelled), map[string]any{"cancelled": true})
+	}
+
+	results := make([]sessionStopResult, 0, len(ids))
+	failed := 0
+	for _, id := range ids {
+		result := sessionStopResult{SessionID: id, Stopped: true}
+		if err := stopSession(cmd.Context(), client, id); err != nil {
+			result.Stopped, result.Error = false, err.Error()
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	if IsJSONOutput() {
+		if err := GetFormatter().Print(map[string]any{"sessions": results}); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Stopped {
+				fmt.Printf("%s  stopped\n", r.SessionID)
+			} else {
+				fmt.Printf("%s  failed  %s\n", r.SessionID, r.Error)
+			}
+		}
+		fmt.Printf("Stopped %d of %d sessions.\n", len(results)-failed, len(results))
+	}
+	if failed > 0 {
+		return i18n.Errorf(i18n.FailedToStopSessions, failed, len(results))
+	}
+	return nil
+}
+
+// listSessionsToStop returns the IDs of the account's active sessions that
+// match filters. Sessions that are already closed are left out whatever the
+// filters, as there is nothing to stop.
+func listSessionsToStop(ctx context.Context, client *api.NotteClient, filters []string) ([]string, error) {
+	params := &api.ListSessionsParams{}
+	if slices.Contains(filters, stopFilterOnlyActive) {
+		params.OnlyActive = boolPtr(true)
+	}
+	if slices.Contains(filters, stopFilterMine) {
+		params.OnlyCurrentToken = boolPtr(true)
+	}
+
+	var ids []string
+	for page := 1; page <= stopAllMaxPages; page++ {
+		params.Page = &page
+		items, hasNext, err := listSessionsPage(ctx, client, params)
+		if err != nil {
+			return nil, i18n.Errorf(i18n.FailedToListSessions, err)
+		}
+		for _, s := range items {
+			if s.Status == api.SessionResponseStatusActive && !slices.Contains(ids, s.SessionId) {
+				ids = append(ids, s.SessionId)
+			}
+		}
+		if !hasNext {
+			return ids, nil
+		}
+	}
+	PrintInfo(i18n.T(i18n.WarningSessionPagesTruncated, stopAllMaxPages))
+	return ids, nil
+}
+
+func listSessionsPage(ctx context.Context, client *api.NotteClient, params *api.ListSessionsParams) ([]api.SessionResponse, bool, error) {
+	ctx, cancel := GetContextWithTimeout(ctx)
+	defer cancel()
+
+	resp, err := client.Client().ListSessionsWithResponse(ctx, params)
+	if err != nil {
+		return nil, false, i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return nil, false, err
+	}
+	if resp.JSON200 == nil {
+		return nil, false, nil
+	}
+	return resp.JSON200.Items, resp.JSON200.HasNext, nil
+}
+
+// summarizeIDs names the first n IDs and counts the rest
+func summarizeIDs(ids []string, n int) string {
+	if len(ids) <= n {
+		return strings.Join(ids, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(ids[:n], ", "), len(ids)-n)
+}
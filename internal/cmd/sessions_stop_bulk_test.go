@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+func setStopAllFlags(t *testing.T, filters ...string) {
+	t.Helper()
+	origAll, origFilters := sessionsStopAll, sessionsStopFilters
+	t.Cleanup(func() { sessionsStopAll, sessionsStopFilters = origAll, origFilters })
+	sessionsStopAll, sessionsStopFilters = true, filters
+}
+
+func TestRunSessionStop_All(t *testing.T) {
+	server := setupSessionTest(t)
+	setTestPolicy(t, &config.PolicyConfig{Protect: []string{"sess_keep"}})
+	server.AddSequence("/sessions",
+		mockserver.JSONResponse(200, `{"items":[{"session_id":"sess_a","status":"active"},{"session_id":"sess_old","status":"closed"}],"page":1,"page_size":2,"has_next":true}`),
+		mockserver.JSONResponse(200, `{"items":[{"session_id":"sess_b","status":"active"},{"session_id":"sess_keep","status":"active"}],"page":2,"page_size":2,"has_next":false}`),
+	)
+	server.AddResponse("/sessions/sess_a/stop", 200, sessionJSON())
+	server.AddResponse("/sessions/sess_b/stop", 500, `{"detail":"boom"}`)
+
+	setStopAllFlags(t, stopFilterOnlyActive, stopFilterMine)
+	origFormat := outputFormat
+	outputFormat = "json"
+	t.Cleanup(func() { outputFormat = origFormat })
+	SetSkipConfirmation(true)
+	t.Cleanup(func() { SetSkipConfirmation(false) })
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	var err error
+	stdout, stderr := testutil.CaptureOutput(func() {
+		err = runSessionStop(cmd, nil)
+	})
+	if err == nil || !strings.Contains(err.Error(), "failed to stop 1 of 2 sessions") {
+		t.Fatalf("expected a partial failure, got %v", err)
+	}
+	if !strings.Contains(stdout, `{"session_id":"sess_a","stopped":true}`) {
+		t.Errorf("expected sess_a to be stopped, got %q", stdout)
+	}
+	if !strings.Contains(stdout, `{"session_id":"sess_b","stopped":false,"error":`) {
+		t.Errorf("expected sess_b to fail, got %q", stdout)
+	}
+	if strings.Contains(stdout, "sess_old") || strings.Contains(stdout, `"session_id":"sess_keep"`) {
+		t.Errorf("closed and protected sessions should be left alone, got %q", stdout)
+	}
+	if !strings.Contains(stderr, "Skipping session sess_keep") {
+		t.Errorf("expected a warning about the protected session, got %q", stderr)
+	}
+
+	reqs := server.Requests("/sessions")
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 list requests, got %d", len(reqs))
+	}
+	for _, want := range []string{"only_active=true", "only_current_token=true", "page=2"} {
+		if !strings.Contains(reqs[1].Query, want) {
+			t.Errorf("expected %s in %q", want, reqs[1].Query)
+		}
+	}
+	if n := len(server.Requests("/sessions/sess_keep/stop")); n != 0 {
+		t.Errorf("protected session was stopped %d times", n)
+	}
+}
+
+func TestRunSessionStop_AllCancelled(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions", 200, `{"items":[{"session_id":"sess_a","status":"active"}],"has_next":false}`)
+
+	setStopAllFlags(t)
+	origFormat := outputFormat
+	outputFormat = "text"
+	t.Cleanup(func() { outputFormat = origFormat })
+	SetNonInteractive(true)
+	t.Cleanup(func() { SetNonInteractive(false) })
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	var err error
+	_, _ = testutil.CaptureOutput(func() {
+		err = runSessionStop(cmd, nil)
+	})
+	if err == nil || !strings.Contains(err.Error(), "confirmation required") {
+		t.Fatalf("expected the confirmation to be required, got %v", err)
+	}
+	if n := len(server.Requests("/sessions/sess_a/stop")); n != 0 {
+		t.Errorf("session was stopped without confirmation")
+	}
+}
+
+func TestSummarizeIDs(t *testing.T) {
+	if got := summarizeIDs([]string{"a", "b"}, 3); got != "a, b" {
+		t.Errorf("got %q", got)
+	}
+	if got := summarizeIDs([]string{"a", "b", "c", "d"}, 2); got != "a, b and 2 more" {
+		t.Errorf("got %q", got)
+	}
+}
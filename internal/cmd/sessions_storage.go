@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	sessionStorageKey     string
+	sessionStorageValue   string
+	sessionStorageFile    string
+	sessionStorageSession bool
+)
+
+var sessionsStorageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Read and write the current page's localStorage and sessionStorage",
+	Long: `Read and write web storage for the origin of the session's current page.
+Storage belongs to an origin, so navigate there first (notte page goto).
+Commands use localStorage unless --session-storage is given.`,
+}
+
+var sessionsStorageGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show all storage items, or one with --key",
+	Example: `  notte sessions storage get
+  notte sessions storage get --key token
+  notte sessions storage get --session-storage`,
+	Args: cobra.NoArgs,
+	RunE: runSessionStorageGet,
+}
+
+var sessionsStorageSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set a storage item, or several from a JSON object",
+	Long: `Set a storage item with --key and --value, or several at once with --file,
+a JSON object of keys to values (inline, @file.json, or '-' for stdin).
+Values that aren't strings are stored as their JSON text.`,
+	Example: `  notte sessions storage set --key token --value abc123
+  notte sessions storage set --file @auth-state.json`,
+	Args: cobra.NoArgs,
+	RunE: runSessionStorageSet,
+}
+
+var sessionsStorageRemoveCmd = &cobra.Command{
+	Use:     "remove",
+	Short:   "Remove a storage item",
+	Example: `  notte sessions storage remove --key token`,
+	Args:    cobra.NoArgs,
+	RunE:    runSessionStorageRemove,
+}
+
+func init() {
+	sessionsCmd.AddCommand(sessionsStorageCmd)
+	sessionsStorageCmd.AddCommand(sessionsStorageGetCmd)
+	sessionsStorageCmd.AddCommand(sessionsStorageSetCmd)
+	sessionsStorageCmd.AddCommand(sessionsStorageRemoveCmd)
+
+	for _, c := range []*cobra.Command{sessionsStorageGetCmd, sessionsStorageSetCmd, sessionsStorageRemoveCmd} {
+		addSessionIDFlag(c)
+		c.Flags().BoolVar(&sessionStorageSession, "session-storage", false, "Use sessionStorage instead of localStorage")
+	}
+
+	sessionsStorageGetCmd.Flags().StringVar(&sessionStorageKey, "key", "", "Only show this key")
+	sessionsStorageSetCmd.Flags().StringVar(&sessionStorageKey, "key", "", "Key to set")
+	sessionsStorageSetCmd.Flags().StringVar(&sessionStorageValue, "value", "", "Value to set")
+	sessionsStorageSetCmd.Flags().StringVar(&sessionStorageFile, "file", "", "JSON object of items to set (inline, @file, or '-' for stdin)")
+	sessionsStorageRemoveCmd.Flags().StringVar(&sessionStorageKey, "key", "", "Key to remove")
+	_ = sessionsStorageRemoveCmd.MarkFlagRequired("key")
+}
+
+// storageArea is the JS name of the storage the command works on
+func storageArea() string {
+	if sessionStorageSession {
+		return "sessionStorage"
+	}
+	return "localStorage"
+}
+
+// storageResult is what the storage scripts return
+type storageResult struct {
+	Origin string            `json:"origin"`
+	Items  map[string]string `json:"items"`
+	Value  *string           `json:"value"`
+}
+
+// runStorageScript runs code on the session's current page; code is a
+// function body that receives the storage area as s and the input as arg,
+// and returns fields of storageResult
+func runStorageScript(cmd *cobra.Command, code string, arg any) (*storageResult, error) {
+	sessionID, err := RequireSessionID(cmd)
+	if err != nil {
+		return nil, err
+	}
+	client, err := GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	argJSON, err := json.Marshal(arg)
+	if err != nil {
+		return nil, err
+	}
+	js := fmt.Sprintf(`(function (s, arg) {
+  if (location.origin === "null") return JSON.stringify({origin: "null"});
+  return JSON.stringify(Object.assign({origin: location.origin}, (function () { %s })()));
+})(%s, %s)`, code, storageArea(), argJSON)
+
+	out, err := evalPageJS(cmd.Context(), client, sessionID, js)
+	if err != nil {
+		return nil, err
+	}
+	var result storageResult
+	if err := decodeJSResult(out, &result); err != nil {
+		return nil, i18n.Errorf(i18n.FailedToRead, storageArea(), err)
+	}
+	if result.Origin == "null" || result.Origin == "" {
+		return nil, errors.New(i18n.T(i18n.PageHasNoOrigin))
+	}
+	return &result, nil
+}
+
+func runSessionStorageGet(cmd *cobra.Command, args []string) error {
+	if sessionStorageKey != "" {
+		result, err := runStorageScript(cmd, `return {value: s.getItem(arg)};`, sessionStorageKey)
+		if err != nil {
+			return err
+		}
+		if result.Value == nil {
+			return i18n.Errorf(i18n.StorageKeyNotFound, storageArea(), sessionStorageKey, result.Origin)
+		}
+		return PrintResult(*result.Value, map[string]any{
+			"origin":  result.Origin,
+			"storage": storageArea(),
+			"key":     sessionStorageKey,
+			"value":   *result.Value,
+		})
+	}
+
+	result, err := runStorageScript(cmd, `return {items: Object.assign({}, s)};`, nil)
+	if err != nil {
+		return err
+	}
+	if result.Items == nil {
+		result.Items = map[string]string{}
+	}
+	if IsJSONOutput() {
+		return GetFormatter().Print(map[string]any{
+			"origin":  result.Origin,
+			"storage": storageArea(),
+			"items":   result.Items,
+		})
+	}
+	if len(result.Items) == 0 {
+		PrintInfo(i18n.T(i18n.StorageEmpty, storageArea(), result.Origin))
+		return nil
+	}
+	for _, key := range slices.Sorted(maps.Keys(result.Items)) {
+		fmt.Printf("%s=%s\n", key, result.Items[key])
+	}
+	return nil
+}
+
+// storageItemsToSet reads the items given with --key/--value or --file
+func storageItemsToSet(cmd *cobra.Command) (map[string]string, error) {
+	hasKey := cmd.Flags().Changed("key")
+	hasFile := cmd.Flags().Changed("file")
+	switch {
+	case hasKey && hasFile:
+		return nil, errors.New(i18n.T(i18n.KeyValueWithFile))
+	case hasKey:
+		if sessionStorageKey == "" {
+			return nil, errors.New(i18n.T(i18n.KeyCannotBeEmpty))
+		}
+		return map[string]string{sessionStorageKey: sessionStorageValue}, nil
+	case hasFile:
+		data, err := readJSONInput(cmd, sessionStorageFile, "file")
+		if err != nil {
+			return nil, err
+		}
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, i18n.Errorf(i18n.InvalidFileJSON, err)
+		}
+		items := make(map[string]string, len(raw))
+		for k, v := range raw {
+			var s string
+			if json.Unmarshal(v, &s) == nil {
+				items[k] = s
+			} else {
+				items[k] = string(v)
+			}
+		}
+		return items, nil
+	default:
+		return nil, errors.New(i18n.T(i18n.NothingToSet))
+	}
+}
+
+func runSessionStorageSet(cmd *cobra.Command, args []string) error {
+	items, err := storageItemsToSet(cmd)
+	if err != nil {
+		return err
+	}
+	result, err := runStorageScript(cmd,
+		`for (const [k, v] of Object.entries(arg)) s.setItem(k, v); return {};`, items)
+	if err != nil {
+		return err
+	}
+	return PrintResult(i18n.T(i18n.SetStorageItems, len(items), storageArea(), result.Origin), map[string]any{
+		"origin":  result.Origin,
+		"storage": storageArea(),
+		"keys":    slices.Sorted(maps.Keys(items)),
+	})
+}
+
+func runSessionStorageRemove(cmd *cobra.Command, args []string) error {
+	result, err := runStorageScript(cmd,
+		`const value = s.getItem(arg); s.removeItem(arg); return {value: value};`, sessionStorageKey)
+	if err != nil {
+		return err
+	}
+	if result.Value == nil {
+		return i18n.Errorf(i18n.StorageKeyNotFound, storageArea(), sessionStorageKey, result.Origin)
+	}
+	return PrintResult(i18n.T(i18n.RemovedStorageItem, sessionStorageKey, storageArea(), result.Origin), map[string]any{
+		"origin":  result.Origin,
+		"storage": storageArea(),
+		"key":     sessionStorageKey,
+	})
+}
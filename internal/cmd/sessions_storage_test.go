@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func newStorageTestCmd(t *testing.T, flags map[string]string) *cobra.Command {
+	t.Helper()
+	origKey, origValue, origFile, origSession := sessionStorageKey, sessionStorageValue, sessionStorageFile, sessionStorageSession
+	t.Cleanup(func() {
+		sessionStorageKey, sessionStorageValue, sessionStorageFile, sessionStorageSession = origKey, origValue, origFile, origSession
+	})
+	sessionStorageKey, sessionStorageValue, sessionStorageFile, sessionStorageSession = "", "", "", false
+
+	cmd := &cobra.Command{}
+	cmd.Flags().StringVar(&sessionStorageKey, "key", "", "")
+	cmd.Flags().StringVar(&sessionStorageValue, "value", "", "")
+	cmd.Flags().StringVar(&sessionStorageFile, "file", "", "")
+	cmd.Flags().BoolVar(&sessionStorageSession, "session-storage", false, "")
+	for name, value := range flags {
+		if err := cmd.Flags().Set(name, value); err != nil {
+			t.Fatalf("set --%s: %v", name, err)
+		}
+	}
+	cmd.SetContext(context.Background())
+	return cmd
+}
+
+func TestRunSessionStorageGet(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/"+sessionIDTest+"/page/execute", 200,
+		evalJSResponse(`{"origin":"https://app.test","items":{"token":"abc","theme":"dark"}}`))
+
+	origFormat := outputFormat
+	outputFormat = "text"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	cmd := newStorageTestCmd(t, nil)
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSessionStorageGet(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if stdout != "theme=dark\ntoken=abc\n" {
+		t.Errorf("unexpected output: %q", stdout)
+	}
+
+	reqs := server.Requests("/sessions/" + sessionIDTest + "/page/execute")
+	if len(reqs) != 1 || !strings.Contains(reqs[0].Body, "localStorage") {
+		t.Errorf("expected a localStorage script, got %+v", reqs)
+	}
+}
+
+func TestRunSessionStorageGet_Key(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/"+sessionIDTest+"/page/execute", 200,
+		evalJSResponse(`{"origin":"https://app.test","value":"abc"}`))
+
+	cmd := newStorageTestCmd(t, map[string]string{"key": "token", "session-storage": "true"})
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSessionStorageGet(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "abc") {
+		t.Errorf("expected the value, got %q", stdout)
+	}
+	reqs := server.Requests("/sessions/" + sessionIDTest + "/page/execute")
+	if len(reqs) != 1 || !strings.Contains(reqs[0].Body, "sessionStorage") || !strings.Contains(reqs[0].Body, `\"token\"`) {
+		t.Errorf("expected a sessionStorage lookup of token, got %+v", reqs)
+	}
+}
+
+func TestRunSessionStorageGet_MissingKey(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/"+sessionIDTest+"/page/execute", 200,
+		evalJSResponse(`{"origin":"https://app.test","value":null}`))
+
+	cmd := newStorageTestCmd(t, map[string]string{"key": "token"})
+	err := runSessionStorageGet(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), `no key "token"`) {
+		t.Fatalf("expected missing key error, got %v", err)
+	}
+}
+
+func TestRunSessionStorageGet_NoOrigin(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/"+sessionIDTest+"/page/execute", 200, evalJSResponse(`{"origin":"null"}`))
+
+	cmd := newStorageTestCmd(t, nil)
+	err := runSessionStorageGet(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "page goto") {
+		t.Fatalf("expected no origin error, got %v", err)
+	}
+}
+
+func TestRunSessionStorageSet_File(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/"+sessionIDTest+"/page/execute", 200, evalJSResponse(`{"origin":"https://app.test"}`))
+
+	cmd := newStorageTestCmd(t, map[string]string{"file": `{"token":"abc","user":{"id":1}}`})
+	_, _ = testutil.CaptureOutput(func() {
+		if err := runSessionStorageSet(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	reqs := server.Requests("/sessions/" + sessionIDTest + "/page/execute")
+	if len(reqs) != 1 || !strings.Contains(reqs[0].Body, `\"token\":\"abc\"`) || !strings.Contains(reqs[0].Body, `\"user\":\"{\\\"id\\\":1}\"`) {
+		t.Errorf("expected both items, objects as JSON text, got %+v", reqs)
+	}
+}
+
+func TestRunSessionStorageSet_NeedsInput(t *testing.T) {
+	_ = setupSessionTest(t)
+
+	if err := runSessionStorageSet(newStorageTestCmd(t, nil), nil); err == nil {
+		t.Error("expected an error without --key or --file")
+	}
+	cmd := newStorageTestCmd(t, map[string]string{"key": "a", "file": `{"b":"c"}`})
+	if err := runSessionStorageSet(cmd, nil); err == nil || !strings.Contains(err.Error(), "not both") {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/nottelabs/notte-cli/internal/config"
 	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
 )
 
 const sessionIDTest = "sess_123"
@@ -27,9 +28,11 @@ func setupSessionTest(t *testing.T) *testutil.MockServer {
 	t.Cleanup(func() { server.Close() })
 	env.SetEnv("NOTTE_API_URL", server.URL())
 
-	origID := sessionID
-	sessionID = sessionIDTest
-	t.Cleanup(func() { sessionID = origID })
+	// Keep cached page state out of the real config dir
+	config.SetTestConfigDir(t.TempDir())
+	t.Cleanup(func() { config.SetTestConfigDir("") })
+
+	env.SetEnv("NOTTE_SESSION_ID", sessionIDTest)
 
 	return server
 }
@@ -349,7 +352,7 @@ func TestRunSessionExecute(t *testing.T) {
 	server.AddResponse("/sessions/"+sessionIDTest+"/page/execute", 200, execResp)
 
 	origAction := sessionExecuteAction
-	sessionExecuteAction = `{"type":"noop"}`
+	sessionExecuteAction = `{"type":"reload"}`
 	t.Cleanup(func() { sessionExecuteAction = origAction })
 
 	origFormat := outputFormat
@@ -390,6 +393,78 @@ func TestRunSessionExecute_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestRunSessionExecute_Stream(t *testing.T) {
+	server := setupSessionTest(t)
+	path := "/sessions/" + sessionIDTest + "/page/execute"
+	server.AddResponse(path, 200, `{"success":true,"message":"ok"}`)
+	server.AddMatchedResponse(mockserver.Match{Path: path, BodyContains: `"bad"`},
+		mockserver.JSONResponse(422, `{"detail":"unknown action"}`))
+
+	origStream, origStop := sessionExecuteStream, sessionExecuteStopOnError
+	t.Cleanup(func() { sessionExecuteStream, sessionExecuteStopOnError = origStream, origStop })
+	sessionExecuteStream = true
+	sessionExecuteStopOnError = false
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.SetIn(strings.NewReader("{\"type\":\"goto\",\"url\":\"https://example.com\"}\n\n{\"type\":\"goto\",\"url\":\"bad\"}\nnot json\n{\"type\":\"scroll_down\"}\n"))
+
+	var runErr error
+	stdout, _ := testutil.CaptureOutput(func() {
+		runErr = runSessionExecute(cmd, nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "2 of 4 actions failed") {
+		t.Fatalf("expected summary error, got %v", runErr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 NDJSON lines, got %d: %q", len(lines), stdout)
+	}
+	wantOK := []bool{true, false, false, true}
+	for i, line := range lines {
+		var res executeStreamResult
+		if err := json.Unmarshal([]byte(line), &res); err != nil {
+			t.Fatalf("line %d is not JSON: %q", i, line)
+		}
+		if res.Index != i || res.OK != wantOK[i] {
+			t.Errorf("line %d = %+v, want index %d ok %v", i, res, i, wantOK[i])
+		}
+	}
+
+	if got := len(server.Requests(path)); got != 3 {
+		t.Errorf("expected 3 API calls (invalid JSON is not sent), got %d", got)
+	}
+}
+
+func TestRunSessionExecute_StreamStopOnError(t *testing.T) {
+	server := setupSessionTest(t)
+	path := "/sessions/" + sessionIDTest + "/page/execute"
+	server.AddResponse(path, 422, `{"detail":"unknown action"}`)
+
+	origStream, origStop := sessionExecuteStream, sessionExecuteStopOnError
+	t.Cleanup(func() { sessionExecuteStream, sessionExecuteStopOnError = origStream, origStop })
+	sessionExecuteStream = true
+	sessionExecuteStopOnError = true
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.SetIn(strings.NewReader("{\"type\":\"reload\"}\n{\"type\":\"go_back\"}\n"))
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSessionExecute(cmd, nil); err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	if n := strings.Count(strings.TrimSpace(stdout), "\n") + 1; n != 1 {
+		t.Errorf("expected a single result line, got %d: %q", n, stdout)
+	}
+	if got := len(server.Requests(path)); got != 1 {
+		t.Errorf("expected 1 API call, got %d", got)
+	}
+}
+
 func TestRunSessionScrape(t *testing.T) {
 	server := setupSessionTest(t)
 	scrapeResp := fmt.Sprintf(`{"markdown":"hi","structured":{"data":{"result":"hi"},"success":true},"session":%s}`, sessionJSON())
@@ -423,6 +498,46 @@ func TestRunSessionScrape(t *testing.T) {
 	}
 }
 
+func TestRunSessionScrape_Path(t *testing.T) {
+	server := setupSessionTest(t)
+	scrapeResp := fmt.Sprintf(`{"markdown":"content","structured":{"data":{"title":"Extracted Title"},"success":true},"session":%s}`, sessionJSON())
+	server.AddResponse("/sessions/"+sessionIDTest+"/page/scrape", 200, scrapeResp)
+
+	origInstructions, origPath := sessionScrapeInstructions, sessionScrapePath
+	t.Cleanup(func() { sessionScrapeInstructions, sessionScrapePath = origInstructions, origPath })
+
+	tests := []struct {
+		instructions string
+		want         string
+	}{
+		{"", "content"},
+		{"extract the title", "{\n  \"title\": \"Extracted Title\"\n}\n"},
+	}
+	for _, tt := range tests {
+		sessionScrapeInstructions = tt.instructions
+		sessionScrapePath = filepath.Join(t.TempDir(), "out", "scrape.txt")
+
+		cmd := &cobra.Command{}
+		cmd.SetContext(context.Background())
+		stdout, _ := testutil.CaptureOutput(func() {
+			if err := runSessionScrape(cmd, nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+
+		data, err := os.ReadFile(sessionScrapePath)
+		if err != nil {
+			t.Fatalf("expected scrape file: %v", err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("file = %q, want %q", data, tt.want)
+		}
+		if !strings.Contains(stdout, "Scrape saved") {
+			t.Errorf("expected save message, got %q", stdout)
+		}
+	}
+}
+
 func TestRunSessionScrape_Defaults(t *testing.T) {
 	server := setupSessionTest(t)
 	scrapeResp := fmt.Sprintf(`{"markdown":"hi","structured":{},"session":%s}`, sessionJSON())
@@ -856,35 +971,27 @@ func setupSessionFileTest(t *testing.T) string {
 }
 
 func TestGetCurrentSessionID_FromFlag(t *testing.T) {
-	origID := sessionID
-	sessionID = "flag_session"
-	t.Cleanup(func() { sessionID = origID })
+	cmd := &cobra.Command{}
+	addSessionIDFlag(cmd)
+	_ = cmd.Flags().Set("session-id", "flag_session")
 
-	got := GetCurrentSessionID()
+	got := GetCurrentSessionID(cmd)
 	if got != "flag_session" {
 		t.Errorf("GetCurrentSessionID() = %q, want %q", got, "flag_session")
 	}
 }
 
 func TestGetCurrentSessionID_FromEnvVar(t *testing.T) {
-	origID := sessionID
-	sessionID = ""
-	t.Cleanup(func() { sessionID = origID })
-
 	env := testutil.SetupTestEnv(t)
 	env.SetEnv("NOTTE_SESSION_ID", "env_session")
 
-	got := GetCurrentSessionID()
+	got := GetCurrentSessionID(nil)
 	if got != "env_session" {
 		t.Errorf("GetCurrentSessionID() = %q, want %q", got, "env_session")
 	}
 }
 
 func TestGetCurrentSessionID_FromFile(t *testing.T) {
-	origID := sessionID
-	sessionID = ""
-	t.Cleanup(func() { sessionID = origID })
-
 	env := testutil.SetupTestEnv(t)
 	env.SetEnv("NOTTE_SESSION_ID", "") // Ensure env var is empty
 
@@ -901,15 +1008,15 @@ func TestGetCurrentSessionID_FromFile(t *testing.T) {
 		t.Fatalf("failed to write session file: %v", err)
 	}
 
-	got := GetCurrentSessionID()
+	got := GetCurrentSessionID(nil)
 	if got != "file_session" {
 		t.Errorf("GetCurrentSessionID() = %q, want %q", got, "file_session")
 	}
 }
 
 func TestGetCurrentSessionID_Priority(t *testing.T) {
-	origID := sessionID
-	t.Cleanup(func() { sessionID = origID })
+	cmd := &cobra.Command{}
+	addSessionIDFlag(cmd)
 
 	env := testutil.SetupTestEnv(t)
 	tmpDir := setupSessionFileTest(t)
@@ -925,24 +1032,24 @@ func TestGetCurrentSessionID_Priority(t *testing.T) {
 	}
 
 	// Test: flag > env > file
-	sessionID = "flag_session"
+	_ = cmd.Flags().Set("session-id", "flag_session")
 	env.SetEnv("NOTTE_SESSION_ID", "env_session")
 
-	got := GetCurrentSessionID()
+	got := GetCurrentSessionID(cmd)
 	if got != "flag_session" {
 		t.Errorf("flag should have highest priority: got %q, want %q", got, "flag_session")
 	}
 
 	// Test: env > file
-	sessionID = ""
-	got = GetCurrentSessionID()
+	_ = cmd.Flags().Set("session-id", "")
+	got = GetCurrentSessionID(cmd)
 	if got != "env_session" {
 		t.Errorf("env should have priority over file: got %q, want %q", got, "env_session")
 	}
 
 	// Test: file as fallback
 	env.SetEnv("NOTTE_SESSION_ID", "")
-	got = GetCurrentSessionID()
+	got = GetCurrentSessionID(cmd)
 	if got != "file_session" {
 		t.Errorf("file should be fallback: got %q, want %q", got, "file_session")
 	}
@@ -1006,15 +1113,11 @@ func TestClearCurrentSession_NoFile(t *testing.T) {
 }
 
 func TestRequireSessionID_NoSession(t *testing.T) {
-	origID := sessionID
-	sessionID = ""
-	t.Cleanup(func() { sessionID = origID })
-
 	env := testutil.SetupTestEnv(t)
 	env.SetEnv("NOTTE_SESSION_ID", "")
 	_ = setupSessionFileTest(t)
 
-	err := RequireSessionID()
+	_, err := RequireSessionID(nil)
 	if err == nil {
 		t.Fatal("RequireSessionID() should error when no session ID available")
 	}
@@ -1026,10 +1129,6 @@ func TestRequireSessionID_NoSession(t *testing.T) {
 }
 
 func TestRequireSessionID_FromFile(t *testing.T) {
-	origID := sessionID
-	sessionID = ""
-	t.Cleanup(func() { sessionID = origID })
-
 	env := testutil.SetupTestEnv(t)
 	env.SetEnv("NOTTE_SESSION_ID", "")
 	tmpDir := setupSessionFileTest(t)
@@ -1044,13 +1143,13 @@ func TestRequireSessionID_FromFile(t *testing.T) {
 		t.Fatalf("failed to write session file: %v", err)
 	}
 
-	err := RequireSessionID()
+	id, err := RequireSessionID(nil)
 	if err != nil {
 		t.Fatalf("RequireSessionID() error = %v", err)
 	}
 
-	if sessionID != "file_session" {
-		t.Errorf("sessionID = %q, want %q", sessionID, "file_session")
+	if id != "file_session" {
+		t.Errorf("RequireSessionID() = %q, want %q", id, "file_session")
 	}
 }
 
@@ -1119,9 +1218,7 @@ func TestSessionStop_ClearsCurrentSession(t *testing.T) {
 
 	server.AddResponse("/sessions/"+sessionIDTest+"/stop", 200, sessionJSON())
 
-	origID := sessionID
-	sessionID = sessionIDTest
-	t.Cleanup(func() { sessionID = origID })
+	env.SetEnv("NOTTE_SESSION_ID", sessionIDTest)
 
 	SetSkipConfirmation(true)
 	t.Cleanup(func() { SetSkipConfirmation(false) })
@@ -1169,9 +1266,7 @@ func TestSessionStop_DifferentSession_DoesNotClearCurrentSession(t *testing.T) {
 	// Stop a different session "sess_different"
 	server.AddResponse("/sessions/sess_different/stop", 200, `{"session_id":"sess_different","status":"STOPPED"}`)
 
-	origID := sessionID
-	sessionID = "sess_different"
-	t.Cleanup(func() { sessionID = origID })
+	env.SetEnv("NOTTE_SESSION_ID", "sess_different")
 
 	SetSkipConfirmation(true)
 	t.Cleanup(func() { SetSkipConfirmation(false) })
@@ -1342,10 +1437,6 @@ func TestSessionsStart_AutoClearsExpiredSession(t *testing.T) {
 	outputFormat = "json"
 	t.Cleanup(func() { outputFormat = origFormat })
 
-	// Reset sessionID flag so file-based resolution is used
-	origID := sessionID
-	sessionID = ""
-	t.Cleanup(func() { sessionID = origID })
 	env.SetEnv("NOTTE_SESSION_ID", "")
 
 	cmd := &cobra.Command{}
@@ -1399,10 +1490,6 @@ func TestSessionsStart_DoesNotAutoClearNonExpiredSession(t *testing.T) {
 		t.Fatalf("failed to write expiry file: %v", err)
 	}
 
-	// Reset sessionID flag so file-based resolution is used
-	origID := sessionID
-	sessionID = ""
-	t.Cleanup(func() { sessionID = origID })
 	env.SetEnv("NOTTE_SESSION_ID", "")
 
 	// Set up stop endpoint (for the confirmation path) and start endpoint
@@ -1462,12 +1549,7 @@ func TestSessionStatus_UsesCurrentSession(t *testing.T) {
 
 	server.AddResponse("/sessions/"+sessionIDTest, 200, sessionJSON())
 
-	// Clear sessionID to test file-based resolution
-	origID := sessionID
-	sessionID = ""
-	t.Cleanup(func() { sessionID = origID })
-
-	// Clear env var too
+	// Clear env var so the current file is used
 	env.SetEnv("NOTTE_SESSION_ID", "")
 
 	origFormat := outputFormat
@@ -1488,3 +1570,65 @@ func TestSessionStatus_UsesCurrentSession(t *testing.T) {
 		t.Error("expected output, got empty string")
 	}
 }
+
+func TestRunSessionExport(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/"+sessionIDTest, 200, sessionJSON())
+	server.AddResponse("/sessions/"+sessionIDTest+"/cookies", 200, `{"cookies":[{"domain":"example.com","httpOnly":true,"name":"a","path":"/","value":"b"}]}`)
+	server.AddResponse("/sessions/"+sessionIDTest+"/network/logs", 200, `{"batches":[{"key":"logs/batch-1.har","size":4,"download_url":"`+server.URL()+`/batch-1.har"},{"key":"logs/batch-2.har","size":4}],"session_id":"`+sessionIDTest+`","total_batch_count":2}`)
+	server.AddResponse("/batch-1.har", 200, `{"log":{}}`)
+	server.AddResponse("/storage/"+sessionIDTest+"/downloads", 200, `{"files":[{"name":"b.txt","file_ext":".txt","size":200}]}`)
+	server.AddResponse("/sessions/"+sessionIDTest+"/replay", 200, `{"mp4_url":"`+server.URL()+`/replay-video.mp4","expires_at":"2099-01-01T00:00:00Z"}`)
+	server.AddResponse("/replay-video.mp4", 200, "fake-video-data")
+	server.AddResponse("/sessions/"+sessionIDTest+"/workflow/code", 200, `{"json_actions":[{"type":"noop"}],"python_script":"print('hi')"}`)
+
+	origOutput, origZip, origFormat := sessionExportOutput, sessionExportZip, outputFormat
+	t.Cleanup(func() {
+		sessionExportOutput, sessionExportZip, outputFormat = origOutput, origZip, origFormat
+	})
+	dir := filepath.Join(t.TempDir(), "record")
+	sessionExportOutput = dir
+	sessionExportZip = false
+	outputFormat = "json"
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	testutil.CaptureOutput(func() {
+		if err := runSessionExport(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	for _, name := range []string{"status.json", "cookies.json", "network.json", "downloads.json", "replay.json", "workflow.py", "workflow_actions.json", "manifest.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("missing %s: %v", name, err)
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "network", "batch-1.har")); err != nil || string(data) != `{"log":{}}` {
+		t.Errorf("network/batch-1.har = %q, %v", data, err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "replay.mp4")); err != nil || string(data) != "fake-video-data" {
+		t.Errorf("replay.mp4 = %q, %v", data, err)
+	}
+	if info, err := os.Stat(filepath.Join(dir, "cookies.json")); err == nil && info.Mode().Perm() != 0o600 {
+		t.Errorf("cookies.json mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	var manifest struct {
+		SessionID string            `json:"session_id"`
+		Errors    map[string]string `json:"errors"`
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("invalid manifest: %v", err)
+	}
+	if manifest.SessionID != sessionIDTest {
+		t.Errorf("session_id = %q", manifest.SessionID)
+	}
+	if _, ok := manifest.Errors["network/batch-2.har"]; !ok || len(manifest.Errors) != 1 {
+		t.Errorf("errors = %v, want only network/batch-2.har", manifest.Errors)
+	}
+}
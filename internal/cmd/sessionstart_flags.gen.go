@@ -64,23 +64,42 @@ var (
 // RegisterSessionStartFlags registers all flags for SessionStart command
 func RegisterSessionStartFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&SessionStartAspectRatio, "aspect-ratio", "", "Viewport shape preset. When set, the backend fits the largest rectangle of this aspect ratio inside the sampled available screen area. Cannot be combined with explicit viewport_width/viewport_height.")
+	_ = cmd.Flags().SetAnnotation("aspect-ratio", flagFieldAnnotation, []string{"aspect_ratio"})
 	cmd.Flags().StringVar(&SessionStartBrowserType, "browser-type", "", "The browser type to use. Can be chromium, chrome or firefox. (chromium, chrome, firefox, chrome-nightly, chrome-turbo)")
+	_ = cmd.Flags().SetAnnotation("browser-type", flagFieldAnnotation, []string{"browser_type"})
+	_ = cmd.Flags().SetAnnotation("browser-type", flagEnumAnnotation, []string{"chromium", "chrome", "firefox", "chrome-nightly", "chrome-turbo"})
 	cmd.Flags().StringVar(&SessionStartCdpUrl, "cdp-url", "", "The CDP URL of another remote session provider.")
+	_ = cmd.Flags().SetAnnotation("cdp-url", flagFieldAnnotation, []string{"cdp_url"})
 	cmd.Flags().StringSliceVar(&SessionStartChromeArgs, "chrome-args", []string{}, "Overwrite the chrome instance arguments (repeatable)")
+	_ = cmd.Flags().SetAnnotation("chrome-args", flagFieldAnnotation, []string{"chrome_args"})
 	cmd.Flags().BoolVar(&SessionStartHeadless, "headless", false, "Whether to run the session in headless mode.")
+	_ = cmd.Flags().SetAnnotation("headless", flagFieldAnnotation, []string{"headless"})
 	cmd.Flags().IntVar(&SessionStartIdleTimeoutMinutes, "idle-timeout-minutes", 0, "Idle timeout in minutes. Session closes after this period of inactivity (resets on each operation).")
+	_ = cmd.Flags().SetAnnotation("idle-timeout-minutes", flagFieldAnnotation, []string{"idle_timeout_minutes"})
 	cmd.Flags().IntVar(&SessionStartMaxDurationMinutes, "max-duration-minutes", 0, "Maximum session lifetime in minutes (absolute maximum, not affected by activity).")
+	_ = cmd.Flags().SetAnnotation("max-duration-minutes", flagFieldAnnotation, []string{"max_duration_minutes"})
 	// profile (flattened object)
 	cmd.Flags().StringVar(&SessionStartProfileId, "profile-id", "", "Profile ID to use for this session")
+	_ = cmd.Flags().SetAnnotation("profile-id", flagFieldAnnotation, []string{"profile.id"})
 	cmd.Flags().BoolVar(&SessionStartProfilePersist, "profile-persist", false, "Whether to save browser state to profile on session close")
+	_ = cmd.Flags().SetAnnotation("profile-persist", flagFieldAnnotation, []string{"profile.persist"})
 	cmd.Flags().StringVar(&SessionStartScreenshotType, "screenshot-type", "", "The type of screenshot to use for the session. (raw, full, last_action)")
+	_ = cmd.Flags().SetAnnotation("screenshot-type", flagFieldAnnotation, []string{"screenshot_type"})
+	_ = cmd.Flags().SetAnnotation("screenshot-type", flagEnumAnnotation, []string{"raw", "full", "last_action"})
 	cmd.Flags().BoolVar(&SessionStartSolveCaptchas, "solve-captchas", false, "Whether to try to automatically solve captchas")
+	_ = cmd.Flags().SetAnnotation("solve-captchas", flagFieldAnnotation, []string{"solve_captchas"})
 	cmd.Flags().BoolVar(&SessionStartUseFileStorage, "use-file-storage", false, "Whether FileStorage should be attached to the session.")
+	_ = cmd.Flags().SetAnnotation("use-file-storage", flagFieldAnnotation, []string{"use_file_storage"})
 	cmd.Flags().StringVar(&SessionStartUserAgent, "user-agent", "", "The user agent to use for the session")
+	_ = cmd.Flags().SetAnnotation("user-agent", flagFieldAnnotation, []string{"user_agent"})
 	cmd.Flags().StringVar(&SessionStartVaultId, "vault-id", "", "The vault to use for the session")
+	_ = cmd.Flags().SetAnnotation("vault-id", flagFieldAnnotation, []string{"vault_id"})
 	cmd.Flags().IntVar(&SessionStartViewportHeight, "viewport-height", 0, "The height of the viewport")
+	_ = cmd.Flags().SetAnnotation("viewport-height", flagFieldAnnotation, []string{"viewport_height"})
 	cmd.Flags().IntVar(&SessionStartViewportWidth, "viewport-width", 0, "The width of the viewport")
+	_ = cmd.Flags().SetAnnotation("viewport-width", flagFieldAnnotation, []string{"viewport_width"})
 	cmd.Flags().BoolVar(&SessionStartWebBotAuth, "web-bot-auth", false, "Whether to use web bot authentication.")
+	_ = cmd.Flags().SetAnnotation("web-bot-auth", flagFieldAnnotation, []string{"web_bot_auth"})
 }
 
 // BuildSessionStartRequest builds the API request from CLI flags
@@ -3,9 +3,13 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/skills"
 )
 
 // Skill source / skill name. The skill files live in the nottelabs/notte-skills
@@ -15,9 +19,19 @@ import (
 const (
 	skillSource = "nottelabs/notte-skills"
 	skillName   = "notte-browser"
+	// skillRepoPath is the skill directory inside skillSource
+	skillRepoPath = "plugins/notte-cli/skills/" + skillName
 )
 
-var skillAddUpgrade bool
+var (
+	skillAddUpgrade bool
+	skillGlobal     bool
+	skillAgents     []string
+	skillUseNpx     bool
+	skillRef        string
+	skillSubdir     string
+	skillPick       string
+)
 
 var skillCmd = &cobra.Command{
 	Use:   "skill",
@@ -29,62 +43,377 @@ through natural language commands.`,
 }
 
 var skillAddCmd = &cobra.Command{
-	Use:   "add",
-	Short: "Install the Notte skill for your AI coding assistant",
-	Long: `Install the Notte browser automation skill using npx.
+	Use:   "add [source]",
+	Short: "Install the Notte skill (or another skill) for your AI coding assistant",
+	Long: `Install the Notte browser automation skill, or a skill from source.
+
+Without a source, the notte-browser skill is downloaded from
+github.com/nottelabs/notte-skills. A source can also be:
 
-This command runs: npx skills add nottelabs/notte-skills
+  ./my-skill                                  a local directory
+  acme/skills                                 a GitHub repository
+  https://github.com/acme/skills/tree/v2/x    a ref and directory on GitHub
+  https://git.acme.dev/skills.git             any URL git can clone
 
-With --upgrade (or -f), it runs: npx skills update notte-browser
-to refresh an already-installed skill to the latest version.
+When a repository holds several skills, pick one with --skill (its name) or
+--subdir (its directory). --ref installs a branch, tag or commit; the ref is
+recorded and reused by later --upgrade runs, so a pinned skill stays on its
+version until another --ref is given.
 
-npx skills prompts interactively to pick which AI assistants to install
-to. Pass --yes (-y) to skip that prompt and install to all detected
-assistants — required when running non-interactively (CI, scripts).
+The skill is written to .agents/skills/<name>, plus the skills directory of
+each detected assistant (Claude Code: .claude/skills, Cursor:
+.cursor/skills). Files go in the current directory, or in your home
+directory with --global. Use --agent to choose the assistants instead of
+detecting them.
+
+A skill installed from the same source is left alone unless --upgrade (or
+-f) is set, which replaces it with the latest version of its ref. Local
+directories are copied every time.
+
+With --npx, the npx skills tool is used instead (requires Node.js): it runs
+"npx skills add <source>", or "npx skills update notte-browser" with
+--upgrade. It prompts for the assistants to install to unless --yes (-y) is
+set, and with -o json its output is returned in a JSON result.
 
 The skill enables AI coding assistants (like Cursor, Claude Code, etc.)
 to control browser sessions through natural language commands.`,
+	Example: `  notte skill add
+  notte skill add --global --agent claude-code
+  notte skill add --upgrade
+  notte skill add ./skills/deploy-checklist
+  notte skill add acme/agent-skills --skill release-notes --ref v1.4.0`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runSkillAdd,
 }
 
+var skillListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List installed skills",
+	Long: `List the skills installed in the current directory and in your home
+directory, with the assistants they are installed for and, for skills
+installed by notte, their source and pinned ref.`,
+	Args: cobra.NoArgs,
+	RunE: runSkillList,
+}
+
 var skillRemoveCmd = &cobra.Command{
-	Use:     "remove",
+	Use:     "remove [name]",
 	Aliases: []string{"rm"},
-	Short:   "Remove the Notte skill from your AI coding assistant",
-	Long: `Remove the Notte browser automation skill using npx.
+	Short:   "Remove the Notte skill (or another skill) from your AI coding assistant",
+	Long: `Remove a skill (default: notte-browser) from .agents/skills and the
+skills directory of every supported assistant, in the current directory or
+in your home directory with --global.
 
-This command runs: npx skills remove --skill notte-browser`,
+With --npx, this runs: npx skills remove --skill <name>`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runSkillRemove,
 }
 
 func init() {
 	rootCmd.AddCommand(skillCmd)
 	skillCmd.AddCommand(skillAddCmd)
+	skillCmd.AddCommand(skillListCmd)
 	skillCmd.AddCommand(skillRemoveCmd)
 
 	skillAddCmd.Flags().BoolVarP(&skillAddUpgrade, "upgrade", "f", false,
 		"Force a reinstall by updating an already-installed skill to the latest version")
+	skillAddCmd.Flags().StringVar(&skillRef, "ref", "", "Branch, tag or commit to install and pin")
+	skillAddCmd.Flags().StringVar(&skillSubdir, "subdir", "", "Directory of the skill inside the source")
+	skillAddCmd.Flags().StringVar(&skillPick, "skill", "", "Name of the skill to install when the source holds several")
+	for _, c := range []*cobra.Command{skillAddCmd, skillRemoveCmd} {
+		c.Flags().BoolVarP(&skillGlobal, "global", "g", false, "Use the skills directories in your home directory instead of the current directory")
+		c.Flags().StringSliceVar(&skillAgents, "agent", nil, "Assistants to target: "+strings.Join(skills.AssistantNames(), ", ")+" (default: detected)")
+		_ = c.Flags().SetAnnotation("agent", flagEnumAnnotation, skills.AssistantNames())
+		c.Flags().BoolVar(&skillUseNpx, "npx", false, "Use the npx skills tool instead of the built-in installer")
+	}
+}
+
+// skillRoots returns the home directory and the directory skills are
+// installed under: the current directory, or home with --global
+func skillRoots() (home, root string, err error) {
+	if home, err = os.UserHomeDir(); err != nil {
+		return "", "", err
+	}
+	if skillGlobal {
+		return home, home, nil
+	}
+	root, err = os.Getwd()
+	return home, root, err
+}
+
+// skillDirs returns the directories skills are installed to under root: the
+// shared .agents directory and those of the given assistants
+func skillDirs(root string, assistants []skills.Assistant) []string {
+	dirs := []string{filepath.Join(root, filepath.FromSlash(skills.UniversalDir))}
+	for _, a := range assistants {
+		dirs = append(dirs, a.SkillsDir(root))
+	}
+	return dirs
+}
+
+// skillAssistants returns the assistants named by --agent or, without it,
+// the fallback
+func skillAssistants(fallback []skills.Assistant) ([]skills.Assistant, error) {
+	if len(skillAgents) == 0 {
+		return fallback, nil
+	}
+	var assistants []skills.Assistant
+	for _, name := range skillAgents {
+		a, ok := skills.Lookup(name)
+		if !ok {
+			return nil, i18n.Errorf(i18n.UnknownAgent, name, strings.Join(skills.AssistantNames(), ", "))
+		}
+		assistants = append(assistants, a)
+	}
+	return assistants, nil
+}
+
+// skillAddSource returns the source to install from: args[0], or the Notte
+// skill by default, with the --ref and --subdir overrides applied
+func skillAddSource(args []string) (skills.Source, error) {
+	src := skills.Source{Kind: skills.SourceGitHub, Location: skillSource, Subdir: skillRepoPath}
+	if len(args) > 0 {
+		var err error
+		if src, err = skills.ParseSource(args[0]); err != nil {
+			return skills.Source{}, err
+		}
+	}
+	if skillRef != "" {
+		if err := skills.CheckRef(skillRef); err != nil {
+			return skills.Source{}, err
+		}
+		src.Ref = skillRef
+	}
+	if skillSubdir != "" {
+		src.Subdir = skillSubdir
+	}
+	return src, nil
+}
+
+// installedFrom returns the manifest of a skill installed from src in all
+// of dirs (matching --skill when set), or nil when any of them lacks it
+func installedFrom(dirs []string, src skills.Source) *skills.Manifest {
+	var found *skills.Manifest
+	for _, dir := range dirs {
+		var match *skills.Manifest
+		for _, name := range skills.List(dir) {
+			m := skills.ReadManifest(dir, name)
+			if m != nil && m.Source == src.String() && (src.Subdir == "" || m.Subdir == src.Subdir) && (skillPick == "" || m.Name == skillPick) {
+				match = m
+				break
+			}
+		}
+		if match == nil {
+			return nil
+		}
+		found = match
+	}
+	return found
 }
 
 func runSkillAdd(cmd *cobra.Command, args []string) error {
-	var npxArgs []string
-	if skillAddUpgrade {
-		PrintInfo("Upgrading Notte skill via npx...")
-		npxArgs = []string{"skills", "update", skillName}
-	} else {
-		PrintInfo("Installing Notte skill via npx...")
-		npxArgs = []string{"skills", "add", skillSource}
+	if skillUseNpx {
+		var npxArgs []string
+		if skillAddUpgrade {
+			printSkillProgress("Upgrading Notte skill via npx...")
+			npxArgs = []string{"skills", "update", skillName}
+		} else {
+			printSkillProgress("Installing Notte skill via npx...")
+			source := skillSource
+			if len(args) > 0 {
+				source = args[0]
+			}
+			npxArgs = []string{"skills", "add", source}
+		}
+		return runNpx(cmd, "skill installation", npxArgs)
+	}
+
+	src, err := skillAddSource(args)
+	if err != nil {
+		return err
+	}
+	home, root, err := skillRoots()
+	if err != nil {
+		return err
+	}
+	assistants, err := skillAssistants(skills.Detect(home, root))
+	if err != nil {
+		return err
+	}
+	dirs := skillDirs(root, assistants)
+	agents := []string{}
+	for _, a := range assistants {
+		agents = append(agents, a.Name)
+	}
+
+	if src.Kind != skills.SourceLocal {
+		if m := installedFrom(dirs, src); m != nil {
+			// Keep a pinned skill on its ref unless a new one is given
+			if skillRef == "" {
+				src.Ref = m.Ref
+			}
+			if !skillAddUpgrade && src.Ref == m.Ref {
+				return PrintResult(i18n.T(i18n.SkillAlreadyInstalled, m.Name), map[string]any{
+					"skill":     m.Name,
+					"source":    m.Source,
+					"ref":       m.Ref,
+					"agents":    agents,
+					"paths":     skillPaths(dirs, m.Name),
+					"installed": false,
+				})
+			}
+		}
+	}
+
+	printSkillProgress(fmt.Sprintf("Fetching skill from %s...", src))
+	skill, err := skills.Load(cmd.Context(), httpClient, src, skillPick)
+	if err != nil {
+		return err
 	}
 
-	return runNpx(cmd, "skill installation", npxArgs)
+	var paths []string
+	for _, dir := range dirs {
+		path, err := skills.Install(skill, dir)
+		if err != nil {
+			return i18n.Errorf(i18n.FailedToInstallSkill, err)
+		}
+		paths = append(paths, path)
+	}
+
+	msg := fmt.Sprintf("Installed skill %s to %s", skill.Name, strings.Join(paths, ", "))
+	if src.Ref != "" {
+		msg = fmt.Sprintf("Installed skill %s (pinned to %s) to %s", skill.Name, src.Ref, strings.Join(paths, ", "))
+	}
+	if len(agents) == 0 {
+		msg += "\nNo Claude Code or Cursor config found; use --agent to install for a specific assistant."
+	}
+	return PrintResult(msg, map[string]any{
+		"skill":     skill.Name,
+		"source":    src.String(),
+		"ref":       src.Ref,
+		"agents":    agents,
+		"paths":     paths,
+		"installed": true,
+	})
+}
+
+func skillPaths(dirs []string, name string) []string {
+	paths := make([]string, len(dirs))
+	for i, dir := range dirs {
+		paths[i] = filepath.Join(dir, name)
+	}
+	return paths
+}
+
+// installedSkill is one row of "skill list"
+type installedSkill struct {
+	Name   string   `json:"name"`
+	Scope  string   `json:"scope"`
+	Agents []string `json:"agents"`
+	Source string   `json:"source,omitempty"`
+	Ref    string   `json:"ref,omitempty"`
+	Path   string   `json:"path"`
+}
+
+func runSkillList(cmd *cobra.Command, args []string) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	scopes := []struct{ name, root string }{{"project", cwd}, {"global", home}}
+	if cwd == home {
+		scopes = scopes[1:]
+	}
+
+	// "agents" stands for the shared .agents directory
+	locations := append([]skills.Assistant{{Name: "agents", ConfigDir: ".agents"}}, skills.Assistants...)
+
+	var rows []installedSkill
+	for _, scope := range scopes {
+		byName := map[string]*installedSkill{}
+		for _, a := range locations {
+			dir := a.SkillsDir(scope.root)
+			for _, name := range skills.List(dir) {
+				row, ok := byName[name]
+				if !ok {
+					rows = append(rows, installedSkill{Name: name, Scope: scope.name, Path: filepath.Join(dir, name)})
+					row = &rows[len(rows)-1]
+					byName[name] = row
+				}
+				row.Agents = append(row.Agents, a.Name)
+				if m := skills.ReadManifest(dir, name); m != nil && row.Source == "" {
+					row.Source, row.Ref = m.Source, m.Ref
+				}
+			}
+		}
+	}
+
+	if printed, err := PrintListOrEmpty(rows, "No skills installed."); err != nil {
+		return err
+	} else if printed {
+		return nil
+	}
+	return GetFormatter().Print(rows)
+}
+
+// printSkillProgress says what is about to run. JSON mode reports it in the
+// result instead, so stderr only carries the error if the tool fails.
+func printSkillProgress(msg string) {
+	if !IsJSONOutput() {
+		PrintInfo(msg)
+	}
 }
 
 func runSkillRemove(cmd *cobra.Command, args []string) error {
-	PrintInfo("Removing Notte skill via npx...")
-	return runNpx(cmd, "skill removal", []string{"skills", "remove", "--skill", skillName})
+	name := skillName
+	if len(args) > 0 {
+		name = args[0]
+	}
+	if !skills.ValidName(name) {
+		return i18n.Errorf(i18n.InvalidSkillName, name)
+	}
+	if skillUseNpx {
+		printSkillProgress("Removing skill via npx...")
+		return runNpx(cmd, "skill removal", []string{"skills", "remove", "--skill", name})
+	}
+
+	_, root, err := skillRoots()
+	if err != nil {
+		return err
+	}
+	assistants, err := skillAssistants(skills.Assistants)
+	if err != nil {
+		return err
+	}
+	removed := []string{}
+	for _, dir := range skillDirs(root, assistants) {
+		ok, err := skills.Remove(dir, name)
+		if err != nil {
+			return i18n.Errorf(i18n.FailedToRemoveSkill, err)
+		}
+		if ok {
+			removed = append(removed, filepath.Join(dir, name))
+		}
+	}
+
+	msg := fmt.Sprintf("Removed skill %s from %s", name, strings.Join(removed, ", "))
+	if len(removed) == 0 {
+		msg = fmt.Sprintf("Skill %s is not installed.", name)
+	}
+	return PrintResult(msg, map[string]any{
+		"skill":   name,
+		"removed": removed,
+	})
 }
 
-// runNpx executes `npx <args>` wired to the current stdio.
+// runNpx executes `npx <args>`, wired to the current stdio in text mode and
+// wrapped in a JSON result with -o json.
 //
 // `npx skills` prompts interactively (an agent picker for `add`, a scope
 // prompt for `update`/`remove`). With no terminal to answer them those
@@ -95,19 +424,5 @@ func runNpx(cmd *cobra.Command, action string, args []string) error {
 	if yesFlag {
 		args = append(args, "-y")
 	}
-
-	npxCmd := exec.CommandContext(cmd.Context(), "npx", args...)
-
-	npxCmd.Stdout = os.Stdout
-	npxCmd.Stderr = os.Stderr
-	npxCmd.Stdin = os.Stdin
-
-	if err := npxCmd.Run(); err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return fmt.Errorf("%s failed with exit code %d", action, exitErr.ExitCode())
-		}
-		return fmt.Errorf("failed to run npx: %w", err)
-	}
-
-	return nil
+	return runExternal(cmd, action, "npx", args)
 }
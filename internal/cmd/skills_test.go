@@ -1,7 +1,19 @@
 package cmd
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/nottelabs/notte-cli/internal/skills"
+	"github.com/nottelabs/notte-cli/internal/testutil"
 )
 
 func TestSkillCommandStructure(t *testing.T) {
@@ -23,8 +35,8 @@ func TestSkillAddCommandStructure(t *testing.T) {
 		t.Fatal("skillAddCmd is nil")
 	}
 
-	if skillAddCmd.Use != "add" {
-		t.Errorf("expected Use to be 'add', got %s", skillAddCmd.Use)
+	if skillAddCmd.Name() != "add" {
+		t.Errorf("expected name to be 'add', got %s", skillAddCmd.Name())
 	}
 
 	if skillAddCmd.Short == "" {
@@ -54,8 +66,8 @@ func TestSkillRemoveCommandStructure(t *testing.T) {
 		t.Fatal("skillRemoveCmd is nil")
 	}
 
-	if skillRemoveCmd.Use != "remove" {
-		t.Errorf("expected Use to be 'remove', got %s", skillRemoveCmd.Use)
+	if skillRemoveCmd.Name() != "remove" {
+		t.Errorf("expected name to be 'remove', got %s", skillRemoveCmd.Name())
 	}
 
 	if skillRemoveCmd.Short == "" {
@@ -81,7 +93,7 @@ func TestSkillRemoveCommandStructure(t *testing.T) {
 func TestSkillSubcommands(t *testing.T) {
 	subcommands := make(map[string]bool)
 	for _, cmd := range skillCmd.Commands() {
-		subcommands[cmd.Use] = true
+		subcommands[cmd.Name()] = true
 	}
 
 	if !subcommands["add"] {
@@ -91,6 +103,10 @@ func TestSkillSubcommands(t *testing.T) {
 	if !subcommands["remove"] {
 		t.Error("'remove' command should be a subcommand of 'skill'")
 	}
+
+	if !subcommands["list"] {
+		t.Error("'list' command should be a subcommand of 'skill'")
+	}
 }
 
 func TestSkillSourcePointsToSkillsRepo(t *testing.T) {
@@ -106,3 +122,245 @@ func TestSkillSourcePointsToSkillsRepo(t *testing.T) {
 		t.Errorf("skillName should be 'notte-browser', got %q", skillName)
 	}
 }
+
+// setupSkillTest serves a fake notte-skills archive and isolates the home
+// and working directories. It returns them and the archive request count.
+func setupSkillTest(t *testing.T) (home, project string, downloads *int) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range map[string]string{
+		"notte-skills-main/" + skillRepoPath + "/SKILL.md":           "# notte-browser",
+		"notte-skills-main/" + skillRepoPath + "/templates/login.sh": "notte page goto",
+	} {
+		_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg})
+		_, _ = tw.Write([]byte(body))
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+
+	downloads = new(int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/"+skillSource+"/archive/") {
+			http.NotFound(w, r)
+			return
+		}
+		*downloads++
+		_, _ = w.Write(buf.Bytes())
+	}))
+	t.Cleanup(srv.Close)
+
+	home, project = t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Chdir(project)
+
+	origURL := skills.GitHubBaseURL
+	origFormat := outputFormat
+	origUpgrade, origGlobal, origAgents, origNpx := skillAddUpgrade, skillGlobal, skillAgents, skillUseNpx
+	origRef, origSubdir, origPick := skillRef, skillSubdir, skillPick
+	skills.GitHubBaseURL = srv.URL
+	outputFormat = "json"
+	skillAddUpgrade, skillGlobal, skillAgents, skillUseNpx = false, false, nil, false
+	skillRef, skillSubdir, skillPick = "", "", ""
+	t.Cleanup(func() {
+		skills.GitHubBaseURL = origURL
+		outputFormat = origFormat
+		skillAddUpgrade, skillGlobal, skillAgents, skillUseNpx = origUpgrade, origGlobal, origAgents, origNpx
+		skillRef, skillSubdir, skillPick = origRef, origSubdir, origPick
+	})
+	return home, project, downloads
+}
+
+func runSkillJSON(t *testing.T, run func() error) map[string]any {
+	t.Helper()
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := run(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var result map[string]any
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", stdout, err)
+	}
+	return result
+}
+
+func TestRunSkillAdd_Native(t *testing.T) {
+	home, project, downloads := setupSkillTest(t)
+	if err := os.Mkdir(filepath.Join(home, ".claude"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	result := runSkillJSON(t, func() error { return runSkillAdd(newExternalTestCmd(), nil) })
+	if result["installed"] != true {
+		t.Errorf("unexpected result: %v", result)
+	}
+	if agents, _ := result["agents"].([]any); len(agents) != 1 || agents[0] != "claude-code" {
+		t.Errorf("agents = %v, want [claude-code]", result["agents"])
+	}
+	for _, dir := range []string{".agents", ".claude"} {
+		if data, err := os.ReadFile(filepath.Join(project, dir, "skills", skillName, "SKILL.md")); err != nil || string(data) != "# notte-browser" {
+			t.Errorf("%s: SKILL.md = %q, %v", dir, data, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(project, ".cursor")); !os.IsNotExist(err) {
+		t.Error("should not install for an assistant that isn't detected")
+	}
+
+	// Installed everywhere: nothing is downloaded again unless --upgrade
+	result = runSkillJSON(t, func() error { return runSkillAdd(newExternalTestCmd(), nil) })
+	if result["installed"] != false || *downloads != 1 {
+		t.Errorf("second add: result %v, %d downloads", result, *downloads)
+	}
+	skillAddUpgrade = true
+	runSkillJSON(t, func() error { return runSkillAdd(newExternalTestCmd(), nil) })
+	if *downloads != 2 {
+		t.Errorf("--upgrade should download again, got %d downloads", *downloads)
+	}
+}
+
+func TestRunSkillAdd_GlobalAgent(t *testing.T) {
+	home, project, _ := setupSkillTest(t)
+	skillGlobal = true
+	skillAgents = []string{"cursor"}
+
+	runSkillJSON(t, func() error { return runSkillAdd(newExternalTestCmd(), nil) })
+	if _, err := os.Stat(filepath.Join(home, ".cursor", "skills", skillName, "templates", "login.sh")); err != nil {
+		t.Errorf("expected the skill in ~/.cursor/skills: %v", err)
+	}
+	if entries, _ := os.ReadDir(project); len(entries) != 0 {
+		t.Errorf("--global should not write to the current directory, found %d entries", len(entries))
+	}
+}
+
+func TestRunSkillAdd_UnknownAgent(t *testing.T) {
+	setupSkillTest(t)
+	skillAgents = []string{"emacs"}
+
+	err := runSkillAdd(newExternalTestCmd(), nil)
+	if err == nil || err.Error() != `unknown --agent "emacs": expected one of claude-code, cursor` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRunSkillRemove_Native(t *testing.T) {
+	_, project, _ := setupSkillTest(t)
+	skillAgents = []string{"claude-code", "cursor"}
+	runSkillJSON(t, func() error { return runSkillAdd(newExternalTestCmd(), nil) })
+
+	skillAgents = nil
+	result := runSkillJSON(t, func() error { return runSkillRemove(newExternalTestCmd(), nil) })
+	if removed, _ := result["removed"].([]any); len(removed) != 3 {
+		t.Errorf("removed = %v, want 3 paths", result["removed"])
+	}
+	for _, dir := range []string{".agents", ".claude", ".cursor"} {
+		if _, err := os.Stat(filepath.Join(project, dir, "skills", skillName)); !os.IsNotExist(err) {
+			t.Errorf("%s: skill still installed", dir)
+		}
+	}
+
+	result = runSkillJSON(t, func() error { return runSkillRemove(newExternalTestCmd(), nil) })
+	if removed, _ := result["removed"].([]any); len(removed) != 0 {
+		t.Errorf("second remove: removed = %v", result["removed"])
+	}
+}
+
+func TestRunSkillAdd_PinnedRef(t *testing.T) {
+	_, project, downloads := setupSkillTest(t)
+	skillRef = "v1.2.0"
+
+	result := runSkillJSON(t, func() error { return runSkillAdd(newExternalTestCmd(), nil) })
+	if result["ref"] != "v1.2.0" || result["source"] != "github.com/"+skillSource {
+		t.Errorf("unexpected result: %v", result)
+	}
+	m := skills.ReadManifest(filepath.Join(project, ".agents", "skills"), skillName)
+	if m == nil || m.Ref != "v1.2.0" || m.Subdir != skillRepoPath {
+		t.Fatalf("manifest = %+v", m)
+	}
+
+	// An upgrade without --ref stays on the pinned ref
+	skillRef = ""
+	skillAddUpgrade = true
+	result = runSkillJSON(t, func() error { return runSkillAdd(newExternalTestCmd(), nil) })
+	if result["ref"] != "v1.2.0" || *downloads != 2 {
+		t.Errorf("upgrade: result %v, %d downloads", result, *downloads)
+	}
+}
+
+func TestRunSkillAdd_LocalDir(t *testing.T) {
+	_, project, downloads := setupSkillTest(t)
+	src := filepath.Join(t.TempDir(), "deploy-checklist")
+	if err := os.MkdirAll(src, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("# v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := runSkillJSON(t, func() error { return runSkillAdd(newExternalTestCmd(), []string{src}) })
+	if result["skill"] != "deploy-checklist" || *downloads != 0 {
+		t.Errorf("unexpected result: %v", result)
+	}
+
+	// Local skills are copied again on every add
+	if err := os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("# v2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runSkillJSON(t, func() error { return runSkillAdd(newExternalTestCmd(), []string{src}) })
+	if data, _ := os.ReadFile(filepath.Join(project, ".agents", "skills", "deploy-checklist", "SKILL.md")); string(data) != "# v2" {
+		t.Errorf("SKILL.md = %q, want the updated copy", data)
+	}
+}
+
+func TestRunSkillList(t *testing.T) {
+	home, project, _ := setupSkillTest(t)
+	skillAgents = []string{"claude-code"}
+	runSkillJSON(t, func() error { return runSkillAdd(newExternalTestCmd(), nil) })
+	// A skill installed by another tool has no manifest
+	if err := os.MkdirAll(filepath.Join(home, ".cursor", "skills", "other"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".cursor", "skills", "other", "SKILL.md"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSkillList(newExternalTestCmd(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var rows []installedSkill
+	if err := json.Unmarshal([]byte(stdout), &rows); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", stdout, err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %+v, want 2", rows)
+	}
+	if r := rows[0]; r.Name != skillName || r.Scope != "project" || strings.Join(r.Agents, ",") != "agents,claude-code" ||
+		r.Source != "github.com/"+skillSource || r.Path != filepath.Join(project, ".agents", "skills", skillName) {
+		t.Errorf("rows[0] = %+v", r)
+	}
+	if r := rows[1]; r.Name != "other" || r.Scope != "global" || r.Source != "" {
+		t.Errorf("rows[1] = %+v", r)
+	}
+}
+
+func TestRunSkillRemove_InvalidName(t *testing.T) {
+	_, project, _ := setupSkillTest(t)
+	skillAgents = []string{"claude-code"}
+	runSkillJSON(t, func() error { return runSkillAdd(newExternalTestCmd(), nil) })
+
+	for _, name := range []string{".", "..", "../x", "a/b"} {
+		if err := runSkillRemove(newExternalTestCmd(), []string{name}); err == nil || !strings.Contains(err.Error(), "invalid skill name") {
+			t.Errorf("remove %q: expected an invalid name error, got %v", name, err)
+		}
+	}
+	for _, dir := range []string{".agents", ".claude"} {
+		if _, err := os.Stat(filepath.Join(project, dir, "skills", skillName, "SKILL.md")); err != nil {
+			t.Errorf("%s: skills directory was touched: %v", dir, err)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	statusPageURL    string
+	statusMaxLatency time.Duration
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check platform status",
+	Long: `Check that the Notte platform is reachable and your credentials work.
+
+Runs the API health check and a lightweight authenticated request, reporting
+the latency of each. Exits non-zero if any check fails or is slower than
+--max-latency, so it can be used as a CI preflight step.`,
+	Example: `  notte status
+  notte status --max-latency 500ms
+  notte status --status-page https://status.notte.cc`,
+	RunE: runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().StringVar(&statusPageURL, "status-page", "", "Also query a public status page (Statuspage-compatible base URL)")
+	statusCmd.Flags().DurationVar(&statusMaxLatency, "max-latency", 2*time.Second, "Treat checks slower than this as degraded (0 to disable)")
+}
+
+// statusCheck is the result of a single platform check
+type statusCheck struct {
+	Name      string `json:"name"`
+	OK        bool   `json:"ok"`
+	LatencyMs int64  `json:"latency_ms"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// statusReport aggregates all checks
+type statusReport struct {
+	Healthy bool          `json:"healthy"`
+	Checks  []statusCheck `json:"checks"`
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	client, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	defer cancel()
+
+	report := statusReport{Healthy: true}
+	report.add(timeCheck("api", func() (string, error) {
+		resp, err := client.Client().HealthCheckWithResponse(ctx)
+		if err != nil {
+			return "", err
+		}
+		if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return "", err
+		}
+		return "healthy", nil
+	}))
+	report.add(timeCheck("auth", func() (string, error) {
+		pageSize := 1
+		resp, err := client.Client().ListSessionsWithResponse(ctx, &api.ListSessionsParams{PageSize: &pageSize})
+		if err != nil {
+			return "", err
+		}
+		if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return "", err
+		}
+		return "authenticated", nil
+	}))
+	if statusPageURL != "" {
+		report.add(timeCheck("status-page", func() (string, error) {
+			return fetchStatusPage(ctx, statusPageURL)
+		}))
+	}
+
+	if err := printStatusReport(report); err != nil {
+		return err
+	}
+
+	if !report.Healthy {
+		var failed []string
+		for _, c := range report.Checks {
+			if !c.OK {
+				failed = append(failed, c.Name)
+			}
+		}
+		return i18n.Errorf(i18n.PlatformDegraded, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func (r *statusReport) add(c statusCheck) {
+	if c.OK && statusMaxLatency > 0 && time.Duration(c.LatencyMs)*time.Millisecond > statusMaxLatency {
+		c.OK = false
+		c.Detail = fmt.Sprintf("%s (slower than %s)", c.Detail, statusMaxLatency)
+	}
+	if !c.OK {
+		r.Healthy = false
+	}
+	r.Checks = append(r.Checks, c)
+}
+
+func timeCheck(name string, fn func() (string, error)) statusCheck {
+	start := time.Now()
+	detail, err := fn()
+	c := statusCheck{
+		Name:      name,
+		OK:        err == nil,
+		LatencyMs: time.Since(start).Milliseconds(),
+		Detail:    detail,
+	}
+	if err != nil {
+		c.Detail = err.Error()
+	}
+	return c
+}
+
+// fetchStatusPage queries a Statuspage-compatible /api/v2/status.json endpoint
+func fetchStatusPage(ctx context.Context, baseURL string) (string, error) {
+	url := strings.TrimSuffix(baseURL, "/") + "/api/v2/status.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", i18n.Errorf(i18n.InvalidStatusPageURL, err)
+	}
+
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", i18n.Errorf(i18n.StatusPageReturned, resp.Status)
+	}
+
+	var page struct {
+		Status struct {
+			Indicator   string `json:"indicator"`
+			Description string `json:"description"`
+		} `json:"status"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return "", i18n.Errorf(i18n.FailedToParseStatusPage, err)
+	}
+
+	if page.Status.Indicator != "" && page.Status.Indicator != "none" {
+		return "", errors.New(page.Status.Description)
+	}
+	return page.Status.Description, nil
+}
+
+func printStatusReport(report statusReport) error {
+	if IsJSONOutput() {
+		return GetFormatter().Print(report)
+	}
+
+	for _, c := range report.Checks {
+		mark := "✓"
+		if !c.OK {
+			mark = "✗"
+		}
+		if _, err := fmt.Fprintf(os.Stdout, "%s %-12s %6dms  %s\n", mark, c.Name, c.LatencyMs, c.Detail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
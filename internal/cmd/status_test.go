@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func setupStatusTest(t *testing.T) *testutil.MockServer {
+	t.Helper()
+
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "test-key")
+
+	server := testutil.NewMockServer()
+	t.Cleanup(func() { server.Close() })
+	env.SetEnv("NOTTE_API_URL", server.URL())
+
+	origFormat, origPage, origLatency := outputFormat, statusPageURL, statusMaxLatency
+	t.Cleanup(func() {
+		outputFormat, statusPageURL, statusMaxLatency = origFormat, origPage, origLatency
+	})
+	outputFormat = "json"
+	statusPageURL = ""
+	statusMaxLatency = 2 * time.Second
+
+	return server
+}
+
+func runStatusJSON(t *testing.T) (statusReport, error) {
+	t.Helper()
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	var runErr error
+	stdout, _ := testutil.CaptureOutput(func() {
+		runErr = runStatus(cmd, nil)
+	})
+
+	var report statusReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", stdout, err)
+	}
+	return report, runErr
+}
+
+func TestRunStatus_Healthy(t *testing.T) {
+	server := setupStatusTest(t)
+	server.AddResponse("/health", 200, `{"status": "ok"}`)
+	server.AddResponse("/sessions", 200, `{"items": [], "page": 1, "page_size": 1, "has_next": false, "has_previous": false}`)
+
+	report, err := runStatusJSON(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.Healthy || len(report.Checks) != 2 {
+		t.Fatalf("report = %+v, want 2 healthy checks", report)
+	}
+
+	reqs := server.Requests("/sessions")
+	if len(reqs) != 1 || !strings.Contains(reqs[0].Query, "page_size=1") {
+		t.Errorf("auth check requests = %+v, want a single page_size=1 request", reqs)
+	}
+}
+
+func TestRunStatus_AuthFailureIsDegraded(t *testing.T) {
+	server := setupStatusTest(t)
+	server.AddResponse("/health", 200, `{"status": "ok"}`)
+	server.AddResponse("/sessions", 401, `{"detail": "invalid api key"}`)
+
+	report, err := runStatusJSON(t)
+	if err == nil || !strings.Contains(err.Error(), "auth") {
+		t.Fatalf("expected degraded error naming auth, got %v", err)
+	}
+	if report.Healthy {
+		t.Error("report should not be healthy")
+	}
+}
+
+func TestRunStatus_StatusPage(t *testing.T) {
+	server := setupStatusTest(t)
+	server.AddResponse("/health", 200, `{"status": "ok"}`)
+	server.AddResponse("/sessions", 200, `{"items": [], "page": 1, "page_size": 1, "has_next": false, "has_previous": false}`)
+	server.AddResponse("/api/v2/status.json", 200, `{"status": {"indicator": "major", "description": "Major outage"}}`)
+	statusPageURL = server.URL()
+
+	report, err := runStatusJSON(t)
+	if err == nil {
+		t.Fatal("expected error for major outage")
+	}
+	last := report.Checks[len(report.Checks)-1]
+	if last.Name != "status-page" || last.OK || last.Detail != "Major outage" {
+		t.Errorf("status page check = %+v", last)
+	}
+}
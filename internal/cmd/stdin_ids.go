@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// stdinIDsFlag makes a command that takes a resource ID run on every ID
+// read from stdin
+const stdinIDsFlag = "stdin"
+
+// addStdinIDsFlag registers --stdin on cmd, whose ID is set with idFlag.
+// With --stdin the command runs once per ID read from stdin, and prints one
+// JSON result per line. Call it once cmd's RunE and idFlag are set up.
+func addStdinIDsFlag(cmd *cobra.Command, idFlag string) {
+	resource := strings.TrimSuffix(idFlag, "-id")
+	cmd.Flags().Bool(stdinIDsFlag, false, fmt.Sprintf("Run on every %s ID read from stdin (one per line, or the JSON of a list command) and print one JSON result per line", resource))
+
+	// A required ID may come from stdin instead, so it is checked here
+	required := false
+	if flag := cmd.Flags().Lookup(idFlag); flag != nil {
+		if _, ok := flag.Annotations[cobra.BashCompOneRequiredFlag]; ok {
+			required = true
+			delete(flag.Annotations, cobra.BashCompOneRequiredFlag)
+		}
+	}
+
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if on, _ := cmd.Flags().GetBool(stdinIDsFlag); on {
+			return runForStdinIDs(cmd, args, idFlag, run)
+		}
+		if required && !cmd.Flags().Changed(idFlag) {
+			return i18n.Errorf(i18n.RequiredFlagsNotSet, idFlag)
+		}
+		return run(cmd, args)
+	}
+}
+
+// stdinIDResult is the outcome of running a command on one ID of --stdin
+type stdinIDResult struct {
+	ID     string `json:"id"`
+	OK     bool   `json:"ok"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// stdinBatch collects what a command prints while it runs on one ID of
+// --stdin; GetFormatter returns it while set
+var stdinBatch *resultRecorder
+
+// resultRecorder is a formatter that keeps the results printed to it
+type resultRecorder struct {
+	results []any
+}
+
+func (r *resultRecorder) Print(data any) error {
+	r.results = append(r.results, data)
+	return nil
+}
+
+func (r *resultRecorder) PrintError(err error) {}
+
+// result is the single result printed, or all of them in order
+func (r *resultRecorder) result() any {
+	switch len(r.results) {
+	case 0:
+		return nil
+	case 1:
+		return r.results[0]
+	}
+	return r.results
+}
+
+// runForStdinIDs runs run once per ID read from stdin, with idFlag set to
+// it, and prints each outcome as a JSON line. Results are collected as -o
+// json would print them. Stdin holds the IDs, so prompts can't be answered
+// and confirmations are handled as in non-interactive mode (pass --yes).
+func runForStdinIDs(cmd *cobra.Command, args []string, idFlag string, run func(*cobra.Command, []string) error) error {
+	if cmd.Flags().Changed(idFlag) {
+		return i18n.Errorf(i18n.StdinWithFlag, idFlag)
+	}
+	ids, err := readStdinIDs(cmd.InOrStdin(), strings.ReplaceAll(idFlag, "-", "_"))
+	if err != nil {
+		return err
+	}
+
+	origFormat, origNonInteractive := outputFormat, nonInteractive
+	outputFormat = "json"
+	SetNonInteractive(true)
+	defer func() {
+		outputFormat, stdinBatch = origFormat, nil
+		SetNonInteractive(origNonInteractive)
+	}()
+
+	enc := json.NewEncoder(os.Stdout)
+	failed := 0
+	for _, id := range ids {
+		stdinBatch = &resultRecorder{}
+		line := stdinIDResult{ID: id}
+		err := cmd.Flags().Set(idFlag, id)
+		if err == nil {
+			err = run(cmd, args)
+		}
+		if err != nil {
+			line.Error = err.Error()
+			failed++
+		} else {
+			line.OK = true
+			line.Result = stdinBatch.result()
+		}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return i18n.Errorf(i18n.FailedForIDs, failed, len(ids))
+	}
+	return nil
+}
+
+// readStdinIDs reads resource IDs from r: IDs separated by whitespace, or
+// JSON holding them, such as the output of a list command with -o json
+// (with or without --envelope) or a list of IDs. In JSON, objects name
+// their ID with idKey (e.g. session_id).
+func readStdinIDs(r io.Reader, idKey string) ([]string, error) {
+	if !stdinHasData(r) {
+		return nil, errors.New(i18n.T(i18n.StdinNeedsIDs))
+	}
+	data, err := io.ReadAll(io.LimitReader(r, maxJSONInputSize+1))
+	if err != nil {
+		return nil, i18n.Errorf(i18n.FailedToReadIDsFromStdin, err)
+	}
+	if len(data) > maxJSONInputSize {
+		return nil, jsonInputTooLarge("--stdin")
+	}
+
+	var found []string
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && (data[0] == '[' || data[0] == '{') {
+		var v any
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, i18n.Errorf(i18n.InvalidJSONOnStdin, err)
+		}
+		found = idsIn(v, idKey)
+	} else {
+		found = strings.Fields(string(data))
+	}
+
+	var ids []string
+	for _, id := range found {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, errors.New(i18n.T(i18n.NoIDsOnStdin))
+	}
+	return ids, nil
+}
+
+// idsIn collects the IDs of decoded JSON: strings of a list, and the idKey
+// of objects, looking inside envelopes' items and item
+func idsIn(v any, idKey string) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var ids []string
+		for _, item := range v {
+			ids = append(ids, idsIn(item, idKey)...)
+		}
+		return ids
+	case map[string]any:
+		if id, ok := v[idKey].(string); ok {
+			return []string{id}
+		}
+		for _, key := range []string{"items", "item"} {
+			if inner, ok := v[key]; ok {
+				return idsIn(inner, idKey)
+			}
+		}
+	}
+	return nil
+}
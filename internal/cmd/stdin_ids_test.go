@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+// newStdinTestCmd returns a command like sessions status or stop, with
+// --stdin, reading IDs from input
+func newStdinTestCmd(t *testing.T, run func(*cobra.Command, []string) error, input string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test", RunE: run}
+	cmd.SetContext(context.Background())
+	addSessionIDFlag(cmd)
+	registerWaitForFlags(cmd, sessionStatuses)
+	addStdinIDsFlag(cmd, "session-id")
+	if err := cmd.Flags().Set(stdinIDsFlag, "true"); err != nil {
+		t.Fatal(err)
+	}
+	cmd.SetIn(strings.NewReader(input))
+	return cmd
+}
+
+func decodeNDJSON(t *testing.T, out string) []stdinIDResult {
+	t.Helper()
+	var lines []stdinIDResult
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var r stdinIDResult
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("not a JSON line: %q", line)
+		}
+		lines = append(lines, r)
+	}
+	return lines
+}
+
+func TestStdinIDs_Status(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/sess_a", 200, `{"session_id":"sess_a","status":"active","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","idle_timeout_minutes":5}`)
+	server.AddResponse("/sessions/sess_b", 404, `{"detail":"Session not found"}`)
+
+	origFormat := outputFormat
+	t.Cleanup(func() { outputFormat = origFormat })
+	outputFormat = "text"
+
+	cmd := newStdinTestCmd(t, runSessionStatus, "sess_a\nsess_b\n")
+	var err error
+	stdout, _ := testutil.CaptureOutput(func() {
+		err = cmd.RunE(cmd, nil)
+	})
+	if err == nil || !strings.Contains(err.Error(), "failed for 1 of 2 IDs") {
+		t.Fatalf("expected one failure, got %v", err)
+	}
+
+	lines := decodeNDJSON(t, stdout)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", len(lines), stdout)
+	}
+	result, _ := lines[0].Result.(map[string]any)
+	if lines[0].ID != "sess_a" || !lines[0].OK || result["status"] != "active" {
+		t.Errorf("unexpected first line: %+v", lines[0])
+	}
+	if lines[1].ID != "sess_b" || lines[1].OK || !strings.Contains(lines[1].Error, "404") {
+		t.Errorf("unexpected second line: %+v", lines[1])
+	}
+	if outputFormat != "text" || stdinBatch != nil {
+		t.Error("expected output settings to be restored")
+	}
+}
+
+func TestStdinIDs_StopNeedsYes(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/sess_a/stop", 200, sessionJSON())
+
+	// Stdin holds the IDs, so the confirmation can't be asked
+	cmd := newStdinTestCmd(t, runSessionStop, `[{"session_id":"sess_a"}]`)
+	var err error
+	stdout, _ := testutil.CaptureOutput(func() {
+		err = cmd.RunE(cmd, nil)
+	})
+	if err == nil {
+		t.Fatal("expected the stop to fail without --yes")
+	}
+	if lines := decodeNDJSON(t, stdout); len(lines) != 1 || !strings.Contains(lines[0].Error, "confirmation required") {
+		t.Errorf("expected a confirmation error, got:\n%s", stdout)
+	}
+	if n := len(server.Requests("/sessions/sess_a/stop")); n != 0 {
+		t.Fatalf("session was stopped without confirmation")
+	}
+
+	SetSkipConfirmation(true)
+	t.Cleanup(func() { SetSkipConfirmation(false) })
+	cmd = newStdinTestCmd(t, runSessionStop, `[{"session_id":"sess_a"}]`)
+	stdout, _ = testutil.CaptureOutput(func() {
+		err = cmd.RunE(cmd, nil)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := decodeNDJSON(t, stdout)
+	result, _ := lines[0].Result.(map[string]any)
+	if !lines[0].OK || result["status"] != "stopped" {
+		t.Errorf("unexpected result: %+v", lines[0])
+	}
+}
+
+func TestStdinIDs_RequiredIDFlag(t *testing.T) {
+	var id string
+	ran := ""
+	cmd := &cobra.Command{Use: "delete", RunE: func(cmd *cobra.Command, args []string) error {
+		ran = id
+		return nil
+	}}
+	cmd.Flags().StringVar(&id, "persona-id", "", "Persona ID (required)")
+	_ = cmd.MarkFlagRequired("persona-id")
+	addStdinIDsFlag(cmd, "persona-id")
+
+	if err := cmd.ValidateRequiredFlags(); err != nil {
+		t.Fatalf("--persona-id should not be required up front: %v", err)
+	}
+	if err := cmd.RunE(cmd, nil); err == nil || !strings.Contains(err.Error(), `"persona-id" not set`) {
+		t.Fatalf("expected --persona-id to be required without --stdin, got %v", err)
+	}
+
+	_ = cmd.Flags().Set(stdinIDsFlag, "true")
+	cmd.SetIn(strings.NewReader(`{"items":[{"persona_id":"persona_1"}]}`))
+	_, _ = testutil.CaptureOutput(func() {
+		if err := cmd.RunE(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if ran != "persona_1" {
+		t.Errorf("ran with %q, want persona_1", ran)
+	}
+
+	// --stdin and the ID flag don't mix
+	if err := cmd.RunE(cmd, nil); err == nil || !strings.Contains(err.Error(), "--stdin can't be combined with --persona-id") {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+}
+
+func TestReadStdinIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{"lines", "sess_a\nsess_b\n\nsess_a\n", "sess_a,sess_b", ""},
+		{"list of IDs", `["sess_a","sess_b"]`, "sess_a,sess_b", ""},
+		{"list output", `[{"session_id":"sess_a","status":"active"},{"session_id":"sess_b"}]`, "sess_a,sess_b", ""},
+		{"envelope", `{"api_version":"v1","kind":"SessionList","items":[{"session_id":"sess_a"}]}`, "sess_a", ""},
+		{"invalid JSON", `[{"session_id":`, "", "invalid JSON"},
+		{"empty", "  \n", "", "no IDs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := readStdinIDs(strings.NewReader(tt.input), "session_id")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := strings.Join(ids, ","); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
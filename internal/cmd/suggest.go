@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// maxSuggestions caps how many did-you-mean candidates are shown
+const maxSuggestions = 3
+
+// installCommandSuggestions makes every command group report unknown
+// subcommands with did-you-mean hints. Cobra only suggests at the top level and
+// silently prints help for an unknown nested subcommand, so `notte sessions
+// strat` would never point at `sessions start`. Safe to call more than once.
+func installCommandSuggestions(cmd *cobra.Command) {
+	if cmd.HasSubCommands() && !cmd.Runnable() {
+		if !cmd.HasParent() {
+			// Skip cobra's legacy top-level check so runCommandGroup handles it
+			cmd.Args = cobra.ArbitraryArgs
+		}
+		cmd.RunE = runCommandGroup
+	}
+	for _, sub := range cmd.Commands() {
+		installCommandSuggestions(sub)
+	}
+}
+
+// runCommandGroup shows help for a bare command group and an error with
+// suggestions for anything else
+func runCommandGroup(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return cmd.Help()
+	}
+
+	var typed []string
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		typed = append([]string{c.Name()}, typed...)
+	}
+	return unknownCommandError(cmd.Root(), cmd, args[0], append(typed, args...))
+}
+
+func unknownCommandError(root, parent *cobra.Command, arg string, typed []string) error {
+	msg := fmt.Sprintf("unknown command %q for %q", arg, parent.CommandPath())
+	if suggestions := suggestCommands(root, typed); len(suggestions) > 0 {
+		msg += "\n\nDid you mean this?"
+		for _, s := range suggestions {
+			msg += "\n\t" + root.Name() + " " + s
+		}
+	}
+	msg += fmt.Sprintf("\n\nRun '%s --help' for usage.", parent.CommandPath())
+	return errors.New(msg)
+}
+
+// suggestCommands fuzzy-matches the typed words against every visible command
+// path, preferring the deepest paths that match and then the closest ones.
+func suggestCommands(root *cobra.Command, typed []string) []string {
+	type candidate struct {
+		path  string
+		depth int
+		dist  int
+	}
+
+	var candidates []candidate
+	var walk func(cmd *cobra.Command, names []string)
+	walk = func(cmd *cobra.Command, names []string) {
+		for _, c := range cmd.Commands() {
+			if !c.IsAvailableCommand() {
+				continue
+			}
+			words := append(append([]string{}, names...), c.Name())
+			if len(words) > len(typed) {
+				continue
+			}
+
+			total, ok := 0, true
+			for i, w := range words {
+				d, match := wordMatches(typed[i], w)
+				if !match {
+					ok = false
+					break
+				}
+				total += d
+			}
+			if ok {
+				candidates = append(candidates, candidate{strings.Join(words, " "), len(words), total})
+				walk(c, words)
+			}
+		}
+	}
+	walk(root, nil)
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].depth != candidates[j].depth {
+			return candidates[i].depth > candidates[j].depth
+		}
+		return candidates[i].dist < candidates[j].dist
+	})
+
+	var out []string
+	for _, c := range candidates {
+		if len(out) == maxSuggestions || c.depth < candidates[0].depth {
+			break
+		}
+		out = append(out, c.path)
+	}
+	return out
+}
+
+// wordMatches reports whether typed is close enough to want to be a typo of it
+func wordMatches(typed, want string) (int, bool) {
+	typed, want = strings.ToLower(typed), strings.ToLower(want)
+	if typed == want {
+		return 0, true
+	}
+	if len(typed) >= 2 && strings.HasPrefix(want, typed) {
+		return len(want) - len(typed), true
+	}
+	d := editDistance(typed, want)
+	limit := 2
+	if len(want) <= 3 {
+		limit = 1
+	}
+	return d, d <= limit
+}
+
+// editDistance is the optimal string alignment distance: Levenshtein plus
+// adjacent transpositions, so "strat" is one edit away from "start".
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
+
+// flagErrorWithSuggestions adds did-you-mean hints to unknown flag errors
+func flagErrorWithSuggestions(cmd *cobra.Command, err error) error {
+	const prefix = "unknown flag: --"
+	msg := err.Error()
+	if !strings.HasPrefix(msg, prefix) {
+		return err
+	}
+	typed := strings.TrimPrefix(msg, prefix)
+
+	var suggestions []string
+	visit := func(f *pflag.Flag) {
+		if f.Hidden || len(suggestions) == maxSuggestions {
+			return
+		}
+		if _, ok := wordMatches(typed, f.Name); ok {
+			suggestions = append(suggestions, "--"+f.Name)
+		}
+	}
+	cmd.LocalFlags().VisitAll(visit)
+	cmd.InheritedFlags().VisitAll(visit)
+
+	if len(suggestions) == 0 {
+		return err
+	}
+	return i18n.Errorf(i18n.DidYouMean, err, strings.Join(suggestions, "\n\t"))
+}
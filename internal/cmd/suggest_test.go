@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRunCommandGroup_Suggestions(t *testing.T) {
+	installCommandSuggestions(rootCmd)
+
+	tests := []struct {
+		args    []string
+		suggest string
+	}{
+		{[]string{"session", "strat"}, "notte sessions start"},
+		{[]string{"sessions", "strat"}, "notte sessions start"},
+		{[]string{"s", "lsit"}, "notte sessions list"},
+		{[]string{"page", "clik"}, "notte page click"},
+		{[]string{"agnts"}, "notte agents"},
+		{[]string{"zzzzzz"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			cmd, rest, err := rootCmd.Find(tt.args)
+			if err != nil {
+				t.Fatalf("Find(%v) error = %v", tt.args, err)
+			}
+			if cmd.RunE == nil {
+				t.Fatalf("%s has no RunE installed", cmd.CommandPath())
+			}
+
+			err = cmd.RunE(cmd, rest)
+			if err == nil || !strings.HasPrefix(err.Error(), "unknown command") {
+				t.Fatalf("expected unknown command error, got %v", err)
+			}
+			if tt.suggest == "" {
+				if strings.Contains(err.Error(), "Did you mean") {
+					t.Errorf("unexpected suggestion in %q", err)
+				}
+				return
+			}
+			if !strings.Contains(err.Error(), "\t"+tt.suggest+"\n") {
+				t.Errorf("error %q does not suggest %q", err, tt.suggest)
+			}
+		})
+	}
+}
+
+func TestSuggestCommands_HidesHiddenCommands(t *testing.T) {
+	for _, s := range suggestCommands(rootCmd, []string{"sessions", "observ"}) {
+		if s == "sessions observe" {
+			t.Errorf("suggested hidden command %q", s)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"start", "start", 0},
+		{"strat", "start", 1},
+		{"sesions", "sessions", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFlagErrorWithSuggestions(t *testing.T) {
+	base := errors.New("unknown flag: --page-sise")
+	err := flagErrorWithSuggestions(sessionsListCmd, base)
+	if !errors.Is(err, base) {
+		t.Errorf("error should wrap the original flag error")
+	}
+	if !strings.Contains(err.Error(), "--page-size") {
+		t.Errorf("error %q should suggest --page-size", err)
+	}
+
+	other := errors.New("invalid argument \"x\" for \"--page-size\"")
+	if got := flagErrorWithSuggestions(sessionsListCmd, other); got != other {
+		t.Errorf("non-unknown-flag errors should pass through unchanged, got %v", got)
+	}
+}
@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var (
+	agentsStartTaskTemplate string
+	agentsStartVars         []string
+)
+
+// taskTemplateExt is the extension of task template files
+const taskTemplateExt = ".tmpl"
+
+// taskTemplateStarter is written to new templates opened with templates edit
+const taskTemplateStarter = `{{/* Describe the agent's task below. Use {{.name}} for a value passed
+with --var name=value to notte agents start --task-template. */}}
+`
+
+var taskTemplateName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Manage agent task templates",
+	Long: `Keep long agent tasks in reusable templates instead of pasting them into --task.
+
+Templates are Go text templates stored in ~/.notte/cli/templates. Values for
+their variables are given with --var when the agent starts:
+
+  notte templates edit checkout
+  notte agents start --task-template checkout --var product_url=https://shop.test/mug`,
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List task templates and the variables they use",
+	Args:    cobra.NoArgs,
+	RunE:    runTemplatesList,
+}
+
+var templatesEditCmd = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "Create or edit a task template in $EDITOR",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTemplatesEdit,
+}
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+	templatesCmd.AddCommand(templatesListCmd)
+	templatesCmd.AddCommand(templatesEditCmd)
+
+	agentsStartCmd.Flags().StringVar(&agentsStartTaskTemplate, "task-template", "", "Render the task from a saved template instead of --task (see notte templates)")
+	agentsStartCmd.Flags().StringArrayVar(&agentsStartVars, "var", []string{}, "Template variable as key=value pair (can be used multiple times)")
+	agentsStartCmd.PreRunE = applyTaskTemplate
+}
+
+func taskTemplatesDir() (string, error) {
+	configDir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, config.TaskTemplatesDir), nil
+}
+
+// taskTemplatePath returns the file of the named template
+func taskTemplatePath(name string) (string, error) {
+	if !taskTemplateName.MatchString(name) {
+		return "", i18n.Errorf(i18n.InvalidTemplateName, name)
+	}
+	dir, err := taskTemplatesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+taskTemplateExt), nil
+}
+
+// loadTaskTemplate reads and parses the named template. Variables without a
+// --var value are an error rather than rendering as "<no value>".
+func loadTaskTemplate(name string) (*template.Template, error) {
+	path, err := taskTemplatePath(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, i18n.Errorf(i18n.TemplateNotFound, name, name)
+		}
+		return nil, err
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, i18n.Errorf(i18n.InvalidTemplate, name, err)
+	}
+	return tmpl, nil
+}
+
+// parseTemplateVars parses --var key=value pairs
+func parseTemplateVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, kv := range pairs {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || key == "" {
+			return nil, i18n.Errorf(i18n.InvalidVariableFormat, kv)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// renderTaskTemplate renders the named template with vars
+func renderTaskTemplate(name string, vars map[string]string) (string, error) {
+	tmpl, err := loadTaskTemplate(name)
+	if err != nil {
+		return "", err
+	}
+	var task strings.Builder
+	if err := tmpl.Execute(&task, vars); err != nil {
+		if missing := slices.DeleteFunc(templateVariables(tmpl), func(v string) bool {
+			_, ok := vars[v]
+			return ok
+		}); len(missing) > 0 {
+			return "", i18n.Errorf(i18n.TemplateNeedsVarFor, name, strings.Join(missing, ", "))
+		}
+		return "", i18n.Errorf(i18n.TemplateError, name, err)
+	}
+	rendered := strings.TrimSpace(task.String())
+	if rendered == "" {
+		return "", i18n.Errorf(i18n.TemplateRendersEmptyTask, name)
+	}
+	return rendered, nil
+}
+
+// applyTaskTemplate renders --task-template into --task before the
+// required flags are checked
+func applyTaskTemplate(cmd *cobra.Command, args []string) error {
+	if !cmd.Flags().Changed("task-template") {
+		if len(agentsStartVars) > 0 {
+			return errors.New(i18n.T(i18n.VarNeedsTaskTemplate))
+		}
+		return nil
+	}
+	if cmd.Flags().Changed("task") {
+		return errors.New(i18n.T(i18n.TaskWithTaskTemplate))
+	}
+	vars, err := parseTemplateVars(agentsStartVars)
+	if err != nil {
+		return err
+	}
+	task, err := renderTaskTemplate(agentsStartTaskTemplate, vars)
+	if err != nil {
+		return err
+	}
+	return cmd.Flags().Set("task", task)
+}
+
+// templateVariables returns the top-level fields a template reads, in the
+// order they first appear. Fields inside range and with blocks belong to
+// the ranged value, so only their pipelines are looked at.
+func templateVariables(tmpl *template.Template) []string {
+	vars := []string{}
+	var walk func(node parse.Node)
+	walkPipe := func(pipe *parse.PipeNode) {
+		if pipe == nil {
+			return
+		}
+		for _, c := range pipe.Cmds {
+			for _, arg := range c.Args {
+				walk(arg)
+			}
+		}
+	}
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n != nil {
+				for _, child := range n.Nodes {
+					walk(child)
+				}
+			}
+		case *parse.ActionNode:
+			walkPipe(n.Pipe)
+		case *parse.PipeNode:
+			walkPipe(n)
+		case *parse.IfNode:
+			walkPipe(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walkPipe(n.Pipe)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walkPipe(n.Pipe)
+			walk(n.ElseList)
+		case *parse.FieldNode:
+			if !slices.Contains(vars, n.Ident[0]) {
+				vars = append(vars, n.Ident[0])
+			}
+		}
+	}
+	if tmpl.Tree != nil {
+		walk(tmpl.Root)
+	}
+	return vars
+}
+
+// taskTemplateInfo is a template as shown by templates list
+type taskTemplateInfo struct {
+	Name      string   `json:"name"`
+	Variables []string `json:"variables"`
+	Path      string   `json:"path"`
+}
+
+func runTemplatesList(cmd *cobra.Command, args []string) error {
+	dir, err := taskTemplatesDir()
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	templates := []taskTemplateInfo{}
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), taskTemplateExt)
+		if e.IsDir() || !ok || !taskTemplateName.MatchString(name) {
+			continue
+		}
+		info := taskTemplateInfo{Name: name, Variables: []string{}, Path: filepath.Join(dir, e.Name())}
+		if tmpl, err := loadTaskTemplate(name); err == nil {
+			info.Variables = templateVariables(tmpl)
+		}
+		templates = append(templates, info)
+	}
+
+	if IsJSONOutput() {
+		return GetFormatter().Print(templates)
+	}
+	if len(templates) == 0 {
+		return PrintResult(i18n.T(i18n.NoTaskTemplates), nil)
+	}
+	for _, t := range templates {
+		if len(t.Variables) == 0 {
+			fmt.Println(t.Name)
+			continue
+		}
+		fmt.Printf("%s (%s)\n", t.Name, strings.Join(t.Variables, ", "))
+	}
+	return nil
+}
+
+// editorCommand returns the user's editor and its arguments
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+func runTemplatesEdit(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	path, err := taskTemplatePath(name)
+	if err != nil {
+		return err
+	}
+	if nonInteractive {
+		return i18n.Errorf(i18n.TemplatesEditNonInteractive, path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if err := os.WriteFile(path, []byte(taskTemplateStarter), 0o600); err != nil {
+			return err
+		}
+	}
+
+	editor := editorCommand()
+	ext := execCommand(cmd.Context(), editor[0], append(editor[1:], path)...)
+	ext.Stdin, ext.Stdout, ext.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := ext.Run(); err != nil {
+		return i18n.Errorf(i18n.EditorFailed, editor[0], err)
+	}
+
+	tmpl, err := loadTaskTemplate(name)
+	if err != nil {
+		return err
+	}
+	return PrintResult(i18n.T(i18n.SavedTemplate, name), map[string]any{
+		"name":      name,
+		"variables": templateVariables(tmpl),
+		"path":      path,
+	})
+}
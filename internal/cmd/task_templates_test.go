@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func writeTaskTemplate(t *testing.T, name, body string) string {
+	t.Helper()
+	path, err := taskTemplatePath(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func setupTaskTemplateTest(t *testing.T) {
+	t.Helper()
+	config.SetTestConfigDir(t.TempDir())
+	origTask, origTemplate, origVars := AgentStartTask, agentsStartTaskTemplate, agentsStartVars
+	t.Cleanup(func() {
+		config.SetTestConfigDir("")
+		AgentStartTask, agentsStartTaskTemplate, agentsStartVars = origTask, origTemplate, origVars
+	})
+}
+
+// newTaskTemplateCmd returns a command with the agents start flags the
+// template is rendered from, parsed from args
+func newTaskTemplateCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().StringVar(&AgentStartTask, "task", "", "")
+	cmd.Flags().StringVar(&agentsStartTaskTemplate, "task-template", "", "")
+	cmd.Flags().StringArrayVar(&agentsStartVars, "var", []string{}, "")
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestApplyTaskTemplate(t *testing.T) {
+	setupTaskTemplateTest(t)
+	writeTaskTemplate(t, "checkout", taskTemplateStarter+"Open {{.product_url}} and buy {{.qty}}.\n{{if .coupon}}Use coupon {{.coupon}}.{{end}}\n")
+
+	cmd := newTaskTemplateCmd(t, "--task-template", "checkout", "--var", "product_url=https://shop.test/?a=b", "--var", "qty=2", "--var", "coupon=")
+	if err := applyTaskTemplate(cmd, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "Open https://shop.test/?a=b and buy 2."; AgentStartTask != want {
+		t.Errorf("task = %q, want %q", AgentStartTask, want)
+	}
+	if !cmd.Flags().Changed("task") {
+		t.Error("expected --task to be set so the required flag check passes")
+	}
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--task-template", "checkout", "--var", "qty=2"}, "needs --var for: product_url, coupon"},
+		{[]string{"--task-template", "checkout", "--task", "x"}, "not both"},
+		{[]string{"--task-template", "missing"}, "not found"},
+		{[]string{"--task-template", "../etc"}, "invalid template name"},
+		{[]string{"--task-template", "checkout", "--var", "qty"}, "expected key=value"},
+		{[]string{"--task", "x", "--var", "a=b"}, "only used with --task-template"},
+	}
+	for _, tt := range tests {
+		err := applyTaskTemplate(newTaskTemplateCmd(t, tt.args...), nil)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: expected error containing %q, got %v", tt.args, tt.want, err)
+		}
+	}
+
+	if err := applyTaskTemplate(newTaskTemplateCmd(t, "--task", "plain"), nil); err != nil || AgentStartTask != "plain" {
+		t.Errorf("plain --task changed: task=%q err=%v", AgentStartTask, err)
+	}
+}
+
+func TestTemplateVariables(t *testing.T) {
+	setupTaskTemplateTest(t)
+	writeTaskTemplate(t, "vars", `{{.a}} {{if .b}}{{.c}}{{else}}{{.a}}{{end}} {{range .items}}{{.name}}{{end}} {{printf "%s" .d}}`)
+	tmpl, err := loadTaskTemplate("vars")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(templateVariables(tmpl), ","); got != "a,b,c,items,d" {
+		t.Errorf("variables = %s", got)
+	}
+}
+
+func TestRunTemplatesList(t *testing.T) {
+	setupTaskTemplateTest(t)
+	origFormat := outputFormat
+	outputFormat = "text"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runTemplatesList(&cobra.Command{}, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "No task templates") {
+		t.Errorf("unexpected output: %q", stdout)
+	}
+
+	writeTaskTemplate(t, "checkout", "Buy {{.product_url}}")
+	writeTaskTemplate(t, "plain", "Say hi")
+	stdout, _ = testutil.CaptureOutput(func() {
+		if err := runTemplatesList(&cobra.Command{}, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if stdout != "checkout (product_url)\nplain\n" {
+		t.Errorf("unexpected output: %q", stdout)
+	}
+}
+
+func TestRunTemplatesEdit(t *testing.T) {
+	setupTaskTemplateTest(t)
+	calls := fakeExternal(t, 0)
+	origFormat := outputFormat
+	outputFormat = "text"
+	t.Cleanup(func() { outputFormat = origFormat })
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runTemplatesEdit(newExternalTestCmd(), []string{"checkout"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	path, _ := taskTemplatePath("checkout")
+	if len(*calls) != 1 || (*calls)[0] != "code --wait "+path {
+		t.Errorf("unexpected editor calls: %v", *calls)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != taskTemplateStarter {
+		t.Errorf("expected starter template, got %q (%v)", data, err)
+	}
+	if !strings.Contains(stdout, `Saved template "checkout"`) {
+		t.Errorf("unexpected output: %q", stdout)
+	}
+}
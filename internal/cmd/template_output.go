@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"errors"
+	"text/template"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/output"
+)
+
+var (
+	outputTemplate string
+
+	// parsedTemplate is nil unless --template is set
+	parsedTemplate *template.Template
+)
+
+// IsTemplateOutput returns true if results are rendered with --template.
+func IsTemplateOutput() bool {
+	return parsedTemplate != nil
+}
+
+// initTemplateOutput parses --template up front, so a typo fails before
+// any API call is made. A template takes precedence over -o text/json.
+func initTemplateOutput() error {
+	parsedTemplate = nil
+	if outputTemplate == "" {
+		return nil
+	}
+	if IsCSVOutput() {
+		return errors.New(i18n.T(i18n.TemplateWithCSV))
+	}
+	if rawOutput {
+		return errors.New(i18n.T(i18n.TemplateWithRaw))
+	}
+	tmpl, err := output.ParseTemplate(outputTemplate)
+	if err != nil {
+		return i18n.Errorf(i18n.InvalidTemplateFlag, err)
+	}
+	parsedTemplate = tmpl
+	return nil
+}
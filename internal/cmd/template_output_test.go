@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func setTemplateOutput(t *testing.T, text string) error {
+	t.Helper()
+	origTemplate, origFormat, origRaw := outputTemplate, outputFormat, rawOutput
+	t.Cleanup(func() {
+		outputTemplate, outputFormat, rawOutput = origTemplate, origFormat, origRaw
+		parsedTemplate = nil
+	})
+	outputTemplate, outputFormat, rawOutput = text, "text", false
+	return initTemplateOutput()
+}
+
+func TestInitTemplateOutput(t *testing.T) {
+	if err := setTemplateOutput(t, "{{.SessionId"); err == nil {
+		t.Error("expected a parse error")
+	}
+	if IsTemplateOutput() {
+		t.Error("template output should be off after a parse error")
+	}
+
+	if err := setTemplateOutput(t, ""); err != nil || IsTemplateOutput() {
+		t.Errorf("expected no template, got err=%v", err)
+	}
+
+	outputTemplate, outputFormat = "{{.}}", "csv"
+	if err := initTemplateOutput(); err == nil {
+		t.Error("expected -o csv to be rejected")
+	}
+	outputFormat, rawOutput = "text", true
+	if err := initTemplateOutput(); err == nil {
+		t.Error("expected --raw to be rejected")
+	}
+}
+
+func TestRunSessionStatus_Template(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/"+sessionIDTest, 200, sessionJSON())
+	if err := setTemplateOutput(t, "{{.SessionId}} {{.Status}}"); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSessionStatus(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if want := sessionIDTest + " ACTIVE\n"; stdout != want {
+		t.Errorf("got %q, want %q", stdout, want)
+	}
+}
+
+func TestPrintResult_Template(t *testing.T) {
+	if err := setTemplateOutput(t, "{{.key}}={{.value}}"); err != nil {
+		t.Fatal(err)
+	}
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := PrintResult("ignored", map[string]any{"key": "a", "value": "b"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if stdout != "a=b\n" {
+		t.Errorf("got %q", stdout)
+	}
+}
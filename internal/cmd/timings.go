@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/output"
+	"github.com/nottelabs/notte-cli/internal/timing"
+)
+
+var (
+	showTimings   bool
+	latencyBudget time.Duration
+
+	// timings is nil unless --timings or a latency budget is set
+	timings   *timing.Recorder
+	startedAt time.Time
+)
+
+// initTimings enables recording when --timings or a latency budget
+// (flag or NOTTE_LATENCY_BUDGET) is set
+func initTimings() error {
+	if latencyBudget == 0 {
+		if env := os.Getenv(config.EnvLatencyBudget); env != "" {
+			d, err := time.ParseDuration(env)
+			if err != nil {
+				return i18n.Errorf(i18n.InvalidValue, config.EnvLatencyBudget, env, err)
+			}
+			latencyBudget = d
+		}
+	}
+	if latencyBudget < 0 {
+		return i18n.Errorf(i18n.LatencyBudgetNegative)
+	}
+
+	timings = nil
+	if showTimings || latencyBudget > 0 {
+		timings = timing.New()
+		startedAt = time.Now()
+	}
+	return nil
+}
+
+// reportTimings writes slow-call warnings and, with --timings, a breakdown
+// of where the command spent its time
+func reportTimings(w io.Writer) {
+	if timings == nil {
+		return
+	}
+
+	for _, c := range timings.SlowCalls(latencyBudget) {
+		_, _ = fmt.Fprintf(w, "warning: %s %s took %s, over the %s latency budget\n",
+			c.Method, c.Path, roundDuration(c.Duration), latencyBudget)
+	}
+
+	if !showTimings {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "timings:")
+	for _, p := range timing.Phases {
+		t := timings.Total(p)
+		_, _ = fmt.Fprintf(w, "  %-8s %10s  (%d)\n", p, roundDuration(t.Duration), t.Count)
+	}
+	_, _ = fmt.Fprintf(w, "  %-8s %10s\n", "total", roundDuration(time.Since(startedAt)))
+}
+
+func roundDuration(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(10 * time.Millisecond)
+	}
+	return d.Round(100 * time.Microsecond)
+}
+
+// timedFormatter records time spent rendering output
+type timedFormatter struct {
+	output.Formatter
+}
+
+func (f timedFormatter) Print(data any) error {
+	defer timings.Start(timing.PhaseFormat)()
+	return f.Formatter.Print(data)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/timing"
+)
+
+func setupTimingsTest(t *testing.T) {
+	t.Helper()
+
+	origShow, origBudget, origTimings := showTimings, latencyBudget, timings
+	t.Cleanup(func() {
+		showTimings, latencyBudget, timings = origShow, origBudget, origTimings
+	})
+	showTimings, latencyBudget, timings = false, 0, nil
+}
+
+func TestInitTimings_DisabledByDefault(t *testing.T) {
+	setupTimingsTest(t)
+	t.Setenv(config.EnvLatencyBudget, "")
+
+	if err := initTimings(); err != nil {
+		t.Fatalf("initTimings() error = %v", err)
+	}
+	if timings != nil {
+		t.Error("expected no recorder without --timings or a budget")
+	}
+	if _, ok := GetFormatter().(timedFormatter); ok {
+		t.Error("expected plain formatter without timings")
+	}
+}
+
+func TestInitTimings_BudgetFromEnv(t *testing.T) {
+	setupTimingsTest(t)
+	t.Setenv(config.EnvLatencyBudget, "1500ms")
+
+	if err := initTimings(); err != nil {
+		t.Fatalf("initTimings() error = %v", err)
+	}
+	if latencyBudget != 1500*time.Millisecond || timings == nil {
+		t.Errorf("latencyBudget = %s, recorder = %v", latencyBudget, timings)
+	}
+
+	t.Setenv(config.EnvLatencyBudget, "soon")
+	latencyBudget = 0
+	if err := initTimings(); err == nil {
+		t.Error("expected error for invalid budget")
+	}
+}
+
+func TestReportTimings(t *testing.T) {
+	setupTimingsTest(t)
+	t.Setenv(config.EnvLatencyBudget, "")
+	showTimings = true
+	latencyBudget = time.Second
+	if err := initTimings(); err != nil {
+		t.Fatal(err)
+	}
+
+	timings.Add(timing.PhaseAuth, 800*time.Millisecond)
+	timings.AddCall(timing.Call{Method: "GET", Path: "/sessions", Duration: 200 * time.Millisecond})
+	timings.AddCall(timing.Call{Method: "POST", Path: "/sessions/start", Duration: 2500 * time.Millisecond})
+
+	var buf bytes.Buffer
+	reportTimings(&buf)
+	out := buf.String()
+
+	if !strings.Contains(out, "warning: POST /sessions/start took 2.5s, over the 1s latency budget") {
+		t.Errorf("missing slow call warning:\n%s", out)
+	}
+	if strings.Contains(out, "GET /sessions took") {
+		t.Errorf("fast call should not warn:\n%s", out)
+	}
+	for _, want := range []string{"auth", "800ms", "request", "retry", "format", "total"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("timings output missing %q:\n%s", want, out)
+		}
+	}
+}
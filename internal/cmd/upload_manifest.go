@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/config"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// uploadRecord is what the upload manifest remembers about an uploaded file
+type uploadRecord struct {
+	SHA256     string    `json:"sha256"`
+	Size       int64     `json:"size"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+// uploadManifest maps API base URL to file name to the last upload of that
+// name. Storage doesn't expose checksums, so this is how the CLI knows
+// whether a remote file has the same content as a local one.
+type uploadManifest map[string]map[string]uploadRecord
+
+func uploadManifestPath() (string, error) {
+	configDir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, config.UploadManifestFile), nil
+}
+
+// loadUploadManifest reads the manifest; a missing file is an empty manifest
+func loadUploadManifest() (uploadManifest, error) {
+	path, err := uploadManifestPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return uploadManifest{}, nil
+		}
+		return nil, err
+	}
+	m := uploadManifest{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, i18n.Errorf(i18n.FailedToParseUploadManifest, err)
+	}
+	return m, nil
+}
+
+func (m uploadManifest) save() error {
+	path, err := uploadManifestPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func (m uploadManifest) lookup(baseURL, name string) (uploadRecord, bool) {
+	rec, ok := m[baseURL][name]
+	return rec, ok
+}
+
+func (m uploadManifest) record(baseURL, name string, rec uploadRecord) {
+	if m[baseURL] == nil {
+		m[baseURL] = map[string]uploadRecord{}
+	}
+	m[baseURL][name] = rec
+}
+
+// hashFile returns the hex SHA-256 and size of the file at path
+func hashFile(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, i18n.Errorf(i18n.FailedToOpenFile, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, i18n.Errorf(i18n.FailedToReadFile, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// listUploads returns the uploaded files by name
+func listUploads(ctx context.Context, client *api.NotteClient) (map[string]api.FileInfo, error) {
+	resp, err := client.Client().FileListUploadsWithResponse(ctx, &api.FileListUploadsParams{})
+	if err != nil {
+		return nil, i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return nil, err
+	}
+
+	files := map[string]api.FileInfo{}
+	if resp.JSON200 != nil {
+		for _, f := range resp.JSON200.Files {
+			files[f.Name] = f
+		}
+	}
+	return files, nil
+}
+
+// isUploaded reports whether name was last uploaded with content sum and
+// storage still holds a file of that name and size
+func (m uploadManifest) isUploaded(baseURL, name, sum string, remote map[string]api.FileInfo) bool {
+	rec, ok := m.lookup(baseURL, name)
+	if !ok || rec.SHA256 != sum {
+		return false
+	}
+	f, found := remote[name]
+	return found && int64(f.Size) == rec.Size
+}
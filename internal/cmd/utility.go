@@ -1,7 +1,7 @@
 package cmd
 
 import (
-	"fmt"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 
 	"github.com/spf13/cobra"
 )
@@ -28,7 +28,7 @@ func runHealth(cmd *cobra.Command, args []string) error {
 
 	resp, err := client.Client().HealthCheckWithResponse(ctx)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
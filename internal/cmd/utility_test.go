@@ -2,12 +2,17 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
 
 	"github.com/nottelabs/notte-cli/internal/auth"
 	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/internal/update"
 )
 
 func TestRunHealth_Success(t *testing.T) {
@@ -77,3 +82,75 @@ func TestRunHealth_APIError(t *testing.T) {
 		t.Error("expected error for non-2xx response")
 	}
 }
+
+func TestRunVersion_JSON(t *testing.T) {
+	origFormat := outputFormat
+	outputFormat = "json"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	origVersion, origCommit, origDate := Version, Commit, BuildDate
+	Version, Commit, BuildDate = "1.2.3", "abc1234", "2024-01-02T03:04:05Z"
+	t.Cleanup(func() { Version, Commit, BuildDate = origVersion, origCommit, origDate })
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runVersion(&cobra.Command{}, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var info map[string]string
+	if err := json.Unmarshal([]byte(stdout), &info); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", stdout, err)
+	}
+	want := map[string]string{
+		"version":    "1.2.3",
+		"commit":     "abc1234",
+		"build_date": "2024-01-02T03:04:05Z",
+		"go_version": runtime.Version(),
+	}
+	for k, v := range want {
+		if info[k] != v {
+			t.Errorf("%s = %q, want %q", k, info[k], v)
+		}
+	}
+	if !strings.HasPrefix(info["module"], "github.com/nottelabs/notte-cli") {
+		t.Errorf("module = %q, want github.com/nottelabs/notte-cli", info["module"])
+	}
+}
+
+func TestRunVersion_Check(t *testing.T) {
+	origFormat := outputFormat
+	outputFormat = "json"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	origVersion, origCheck, origLookup := Version, versionCheck, checkLatestRelease
+	t.Cleanup(func() { Version, versionCheck, checkLatestRelease = origVersion, origCheck, origLookup })
+
+	Version = "1.0.0"
+	versionCheck = true
+	checkLatestRelease = func(ctx context.Context, _ *http.Client) (*update.ReleaseInfo, error) {
+		return &update.ReleaseInfo{TagName: "v1.1.0", HTMLURL: "https://example.com/v1.1.0"}, nil
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runVersion(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var info buildInfo
+	if err := json.Unmarshal([]byte(stdout), &info); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", stdout, err)
+	}
+	if info.LatestVersion != "1.1.0" {
+		t.Errorf("latest_version = %q, want %q", info.LatestVersion, "1.1.0")
+	}
+	if info.UpdateAvailable == nil || !*info.UpdateAvailable {
+		t.Errorf("update_available = %v, want true", info.UpdateAvailable)
+	}
+	if info.Platform != runtime.GOOS+"/"+runtime.GOARCH {
+		t.Errorf("platform = %q", info.Platform)
+	}
+}
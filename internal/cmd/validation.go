@@ -2,6 +2,9 @@
 package cmd
 
 import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
 	"github.com/nottelabs/notte-cli/internal/validate"
 )
 
@@ -67,3 +70,44 @@ func ValidateOutputFormat(format string) func() error {
 		return validate.OutputFormat(format)
 	}
 }
+
+// idFlagValidators maps ID flag names to the validator applied when they are parsed
+var idFlagValidators = map[string]func(string) error{
+	"session-id": validate.SessionID,
+	"agent-id":   validate.AgentID,
+	"sessions":   validateSessionIDList,
+}
+
+// validatedValue wraps a flag value so its input is checked at parse time
+type validatedValue struct {
+	pflag.Value
+	validate func(string) error
+}
+
+func (v *validatedValue) Set(s string) error {
+	if err := v.validate(s); err != nil {
+		return err
+	}
+	return v.Value.Set(s)
+}
+
+// installIDFlagValidation wraps every --session-id/--agent-id flag in the
+// command tree so malformed IDs are rejected before any API call is made
+func installIDFlagValidation(cmd *cobra.Command) {
+	wrap := func(f *pflag.Flag) {
+		validator, ok := idFlagValidators[f.Name]
+		if !ok {
+			return
+		}
+		if _, done := f.Value.(*validatedValue); done {
+			return
+		}
+		f.Value = &validatedValue{Value: f.Value, validate: validator}
+	}
+
+	cmd.Flags().VisitAll(wrap)
+	cmd.PersistentFlags().VisitAll(wrap)
+	for _, sub := range cmd.Commands() {
+		installIDFlagValidation(sub)
+	}
+}
@@ -1,6 +1,11 @@
 package cmd
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
 
 func TestValidateFlags(t *testing.T) {
 	t.Run("all pass", func(t *testing.T) {
@@ -43,6 +48,7 @@ func TestValidateSessionID(t *testing.T) {
 		wantErr bool
 	}{
 		{"valid session id", "sess_abc123", false},
+		{"valid uuid", "27ac8eea-f7ab-4a68-a8c5-2e0a7d3c9d51", false},
 		{"empty id", "", true},
 		{"invalid prefix", "invalid_123", true},
 	}
@@ -169,3 +175,57 @@ func TestValidateOutputFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestInstallIDFlagValidation(t *testing.T) {
+	var sid, aid string
+	root := &cobra.Command{Use: "root"}
+	page := &cobra.Command{Use: "page", RunE: func(cmd *cobra.Command, args []string) error { return nil }}
+	page.PersistentFlags().StringVar(&sid, "session-id", "", "")
+	status := &cobra.Command{Use: "status", RunE: func(cmd *cobra.Command, args []string) error { return nil }}
+	status.Flags().StringVar(&aid, "agent-id", "", "")
+	root.AddCommand(page, status)
+
+	installIDFlagValidation(root)
+	installIDFlagValidation(root) // idempotent
+
+	root.SetArgs([]string{"page", "--session-id", "27ac8eea-f7ab-4a68-a8c5-2e0a7d3c9d51"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sid != "27ac8eea-f7ab-4a68-a8c5-2e0a7d3c9d51" {
+		t.Errorf("session-id not set, got %q", sid)
+	}
+
+	root.SetArgs([]string{"status", "--agent-id", "not-an-id"})
+	err := root.Execute()
+	if err == nil {
+		t.Fatal("expected invalid agent ID to be rejected")
+	}
+	if !strings.Contains(err.Error(), "--agent-id") || !strings.Contains(err.Error(), "notte agents list") {
+		t.Errorf("expected actionable flag error, got %v", err)
+	}
+}
+
+func TestInstallIDFlagValidation_RealTree(t *testing.T) {
+	installIDFlagValidation(rootCmd)
+
+	for _, path := range [][]string{{"sessions", "status"}, {"agents", "stop"}, {"page"}} {
+		cmd, _, err := rootCmd.Find(path)
+		if err != nil {
+			t.Fatalf("find %v: %v", path, err)
+		}
+		flag := cmd.Flags().Lookup("session-id")
+		if flag == nil {
+			flag = cmd.PersistentFlags().Lookup("session-id")
+		}
+		if flag == nil {
+			flag = cmd.Flags().Lookup("agent-id")
+		}
+		if flag == nil {
+			t.Fatalf("%v has no ID flag", path)
+		}
+		if _, ok := flag.Value.(*validatedValue); !ok {
+			t.Errorf("%v --%s is not validated", path, flag.Name)
+		}
+	}
+}
@@ -16,6 +16,7 @@ var (
 // RegisterVaultCreateFlags registers all flags for VaultCreate command
 func RegisterVaultCreateFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&VaultCreateName, "name", "", "Name of the vault")
+	_ = cmd.Flags().SetAnnotation("name", flagFieldAnnotation, []string{"name"})
 }
 
 // BuildVaultCreateRequest builds the API request from CLI flags
@@ -24,10 +24,18 @@ var (
 func RegisterVaultCredentialsAddFlags(cmd *cobra.Command) {
 	// credentials (flattened object)
 	cmd.Flags().StringVar(&VaultCredentialsAddCredentialsEmail, "email", "", "email")
+	_ = cmd.Flags().SetAnnotation("email", flagFieldAnnotation, []string{"credentials.email"})
 	cmd.Flags().StringVar(&VaultCredentialsAddCredentialsMfaSecret, "mfa-secret", "", "mfa-secret")
+	_ = cmd.Flags().SetAnnotation("mfa-secret", flagFieldAnnotation, []string{"credentials.mfa_secret"})
 	cmd.Flags().StringVar(&VaultCredentialsAddCredentialsPassword, "password", "", "password")
+	_ = cmd.Flags().SetAnnotation("password", flagFieldAnnotation, []string{"credentials.password"})
 	cmd.Flags().StringVar(&VaultCredentialsAddCredentialsUsername, "username", "", "username")
+	_ = cmd.Flags().SetAnnotation("username", flagFieldAnnotation, []string{"credentials.username"})
 	cmd.Flags().StringVar(&VaultCredentialsAddUrl, "url", "", "url")
+	_ = cmd.Flags().SetAnnotation("url", flagFieldAnnotation, []string{"url"})
+
+	_ = cmd.MarkFlagRequired("password")
+	_ = cmd.MarkFlagRequired("url")
 }
 
 // BuildVaultCredentialsAddRequest builds the API request from CLI flags
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"net/mail"
 	"net/url"
 	"strings"
@@ -9,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
 var (
@@ -25,9 +25,10 @@ var vaultsCmd = &cobra.Command{
 }
 
 var vaultsListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all vaults",
-	RunE:  runVaultsList,
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all vaults",
+	RunE:    runVaultsList,
 }
 
 var vaultsCreateCmd = &cobra.Command{
@@ -44,10 +45,11 @@ var vaultsUpdateCmd = &cobra.Command{
 }
 
 var vaultsDeleteCmd = &cobra.Command{
-	Use:   "delete",
-	Short: "Delete the vault",
-	Args:  cobra.NoArgs,
-	RunE:  runVaultDelete,
+	Use:     "delete",
+	Aliases: []string{"rm"},
+	Short:   "Delete the vault",
+	Args:    cobra.NoArgs,
+	RunE:    runVaultDelete,
 }
 
 var vaultsCredentialsCmd = &cobra.Command{
@@ -57,10 +59,11 @@ var vaultsCredentialsCmd = &cobra.Command{
 }
 
 var vaultsCredentialsListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all credentials in the vault",
-	Args:  cobra.NoArgs,
-	RunE:  runVaultCredentialsList,
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all credentials in the vault",
+	Args:    cobra.NoArgs,
+	RunE:    runVaultCredentialsList,
 }
 
 var vaultsCredentialsAddCmd = &cobra.Command{
@@ -89,16 +92,18 @@ var vaultsCredentialsGetCmd = &cobra.Command{
 }
 
 var vaultsCredentialsDeleteCmd = &cobra.Command{
-	Use:   "delete",
-	Short: "Delete credentials for a specific URL",
-	Args:  cobra.NoArgs,
-	RunE:  runVaultCredentialsDelete,
+	Use:     "delete",
+	Aliases: []string{"rm"},
+	Short:   "Delete credentials for a specific URL",
+	Args:    cobra.NoArgs,
+	RunE:    runVaultCredentialsDelete,
 }
 
 func init() {
 	rootCmd.AddCommand(vaultsCmd)
 	vaultsCmd.AddCommand(vaultsListCmd)
 	registerPaginationFlags(vaultsListCmd)
+	registerScopeFlag(vaultsListCmd)
 	vaultsListCmd.Flags().Bool("only-active", false, "Only return active vaults")
 
 	vaultsCmd.AddCommand(vaultsCreateCmd)
@@ -127,11 +132,10 @@ func init() {
 	// Delete command flags
 	vaultsDeleteCmd.Flags().StringVar(&vaultID, "vault-id", "", "Vault ID (required)")
 	_ = vaultsDeleteCmd.MarkFlagRequired("vault-id")
+	addStdinIDsFlag(vaultsDeleteCmd, "vault-id")
 
 	// Credentials add command flags (auto-generated)
 	RegisterVaultCredentialsAddFlags(vaultsCredentialsAddCmd)
-	_ = vaultsCredentialsAddCmd.MarkFlagRequired("url")
-	_ = vaultsCredentialsAddCmd.MarkFlagRequired("password")
 
 	// Credentials get command flags
 	vaultsCredentialsGetCmd.Flags().StringVar(&vaultCredentialsGetURL, "url", "", "URL to get credentials for (required)")
@@ -159,9 +163,14 @@ func runVaultsList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	scope, onlyCurrentToken, err := getScopeFlag(cmd)
+	if err != nil {
+		return err
+	}
 	params := &api.ListVaultsParams{
-		Page:     page,
-		PageSize: pageSize,
+		Page:             page,
+		PageSize:         pageSize,
+		OnlyCurrentToken: onlyCurrentToken,
 	}
 	if cmd.Flags().Changed("only-active") {
 		v, _ := cmd.Flags().GetBool("only-active")
@@ -169,7 +178,7 @@ func runVaultsList(cmd *cobra.Command, args []string) error {
 	}
 	resp, err := client.Client().ListVaultsWithResponse(ctx, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -188,6 +197,33 @@ func runVaultsList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if scope != "" {
+		owned, err := scopeOwnedIDs(scope, func(page *int) ([]string, bool, error) {
+			mine := *params
+			mine.Page, mine.OnlyCurrentToken = page, boolPtr(true)
+			r, err := client.Client().ListVaultsWithResponse(ctx, &mine)
+			if err == nil {
+				err = HandleAPIResponse(r.HTTPResponse, r.Body)
+			}
+			if err != nil || r.JSON200 == nil {
+				return nil, false, err
+			}
+			ids := make([]string, len(r.JSON200.Items))
+			for i, item := range r.JSON200.Items {
+				ids[i] = item.VaultId
+			}
+			return ids, r.JSON200.HasNext, nil
+		})
+		if err != nil {
+			return err
+		}
+		rows := make([]ownedVault, len(items))
+		for i, item := range items {
+			rows[i] = ownedVault{Owner: ownerOf(scope, owned, item.VaultId), Vault: item}
+		}
+		return formatter.Print(rows)
+	}
+
 	return formatter.Print(items)
 }
 
@@ -209,7 +245,7 @@ func runVaultsCreate(cmd *cobra.Command, args []string) error {
 	params := &api.VaultCreateParams{}
 	resp, err := client.Client().VaultCreateWithResponse(ctx, params, *body)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -236,7 +272,7 @@ func runVaultUpdate(cmd *cobra.Command, args []string) error {
 	params := &api.VaultUpdateParams{}
 	resp, err := client.Client().VaultUpdateWithResponse(ctx, vaultID, params, body)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -247,13 +283,17 @@ func runVaultUpdate(cmd *cobra.Command, args []string) error {
 }
 
 func runVaultDelete(cmd *cobra.Command, args []string) error {
+	if err := checkProtected("vault", vaultID); err != nil {
+		return err
+	}
+
 	// Confirm before deletion
 	confirmed, err := ConfirmAction("vault", vaultID)
 	if err != nil {
 		return err
 	}
 	if !confirmed {
-		return PrintResult("Cancelled.", map[string]any{"cancelled": true})
+		return PrintResult(i18n.T(i18n.Cancelled), map[string]any{"cancelled": true})
 	}
 
 	client, err := GetClient()
@@ -267,14 +307,14 @@ func runVaultDelete(cmd *cobra.Command, args []string) error {
 	params := &api.VaultDeleteParams{}
 	resp, err := client.Client().VaultDeleteWithResponse(ctx, vaultID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
 		return err
 	}
 
-	return PrintResult(fmt.Sprintf("Vault %s deleted.", vaultID), map[string]any{
+	return PrintResult(i18n.T(i18n.VaultDeleted, vaultID), map[string]any{
 		"id":     vaultID,
 		"status": "deleted",
 	})
@@ -292,7 +332,7 @@ func runVaultCredentialsList(cmd *cobra.Command, args []string) error {
 	params := &api.VaultCredentialsListParams{}
 	resp, err := client.Client().VaultCredentialsListWithResponse(ctx, vaultID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -325,18 +365,18 @@ func runVaultCredentialsAdd(cmd *cobra.Command, args []string) error {
 
 	// Validate URL format
 	if _, err := url.Parse(VaultCredentialsAddUrl); err != nil {
-		return fmt.Errorf("invalid URL format: %w", err)
+		return i18n.Errorf(i18n.InvalidURLFormat, err)
 	}
 
 	// Validate password not empty
 	if strings.TrimSpace(VaultCredentialsAddCredentialsPassword) == "" {
-		return fmt.Errorf("password cannot be empty or whitespace")
+		return i18n.Errorf(i18n.PasswordEmpty)
 	}
 
 	// Validate email format if provided
 	if VaultCredentialsAddCredentialsEmail != "" {
 		if _, err := mail.ParseAddress(VaultCredentialsAddCredentialsEmail); err != nil {
-			return fmt.Errorf("invalid email format: %w", err)
+			return i18n.Errorf(i18n.InvalidEmailFormat, err)
 		}
 	}
 
@@ -349,7 +389,7 @@ func runVaultCredentialsAdd(cmd *cobra.Command, args []string) error {
 	params := &api.VaultCredentialsAddParams{}
 	resp, err := client.Client().VaultCredentialsAddWithResponse(ctx, vaultID, params, *body)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -374,7 +414,7 @@ func runVaultCredentialsGet(cmd *cobra.Command, args []string) error {
 
 	resp, err := client.Client().VaultCredentialsGetWithResponse(ctx, vaultID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
@@ -385,12 +425,16 @@ func runVaultCredentialsGet(cmd *cobra.Command, args []string) error {
 }
 
 func runVaultCredentialsDelete(cmd *cobra.Command, args []string) error {
+	if err := checkProtected("vault", vaultID); err != nil {
+		return err
+	}
+
 	confirmed, err := ConfirmAction("credentials for", vaultCredentialsDeleteURL)
 	if err != nil {
 		return err
 	}
 	if !confirmed {
-		return PrintResult("Cancelled.", map[string]any{"cancelled": true})
+		return PrintResult(i18n.T(i18n.Cancelled), map[string]any{"cancelled": true})
 	}
 
 	client, err := GetClient()
@@ -407,14 +451,14 @@ func runVaultCredentialsDelete(cmd *cobra.Command, args []string) error {
 
 	resp, err := client.Client().VaultCredentialsDeleteWithResponse(ctx, vaultID, params)
 	if err != nil {
-		return fmt.Errorf("API request failed: %w", err)
+		return i18n.Errorf(i18n.APIRequestFailed, err)
 	}
 
 	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
 		return err
 	}
 
-	return PrintResult(fmt.Sprintf("Credentials for URL %s deleted from vault %s.", vaultCredentialsDeleteURL, vaultID), map[string]any{
+	return PrintResult(i18n.T(i18n.CredentialsDeleted, vaultCredentialsDeleteURL, vaultID), map[string]any{
 		"id":  vaultID,
 		"url": vaultCredentialsDeleteURL,
 	})
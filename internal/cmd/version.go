@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
+	"github.com/nottelabs/notte-cli/internal/update"
+)
+
+// modulePath is the canonical import path of this module
+const modulePath = "github.com/nottelabs/notte-cli"
+
+var versionCheck bool
+
+// checkLatestRelease looks up the latest published release (overridable in tests)
+var checkLatestRelease = update.CheckLatestVersion
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version information",
+	Long: `Print version and build information for bug reports.
+
+Use --check to compare against the latest published release.`,
+	RunE: runVersion,
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+
+	versionCmd.Flags().BoolVar(&versionCheck, "check", false, "Compare against the latest published release")
+}
+
+// buildInfo describes the running binary
+type buildInfo struct {
+	Version   string `json:"version"`
+	Module    string `json:"module"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+
+	// Populated only with --check
+	LatestVersion   string `json:"latest_version,omitempty"`
+	ReleaseURL      string `json:"release_url,omitempty"`
+	UpdateAvailable *bool  `json:"update_available,omitempty"`
+}
+
+// currentBuildInfo merges ldflags values with the module metadata embedded by
+// the Go toolchain, so `go install` builds still report a commit and date.
+func currentBuildInfo() buildInfo {
+	info := buildInfo{
+		Version:   Version,
+		Module:    modulePath,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		if bi.Main.Path != "" {
+			info.Module = bi.Main.Path
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+					if len(info.Commit) > 12 {
+						info.Commit = info.Commit[:12]
+					}
+				}
+			case "vcs.time":
+				if info.BuildDate == "" {
+					info.BuildDate = s.Value
+				}
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = "unknown"
+	}
+	return info
+}
+
+// addLatestRelease fills in the latest release fields. Lookup failures are
+// reported but never fail the command: version must work without a network.
+func addLatestRelease(ctx context.Context, info *buildInfo) error {
+	if IsOffline() {
+		return i18n.Errorf(i18n.SkippedInOfflineMode)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	release, err := checkLatestRelease(ctx, &http.Client{Timeout: 5 * time.Second})
+	if err != nil || release == nil {
+		return i18n.Errorf(i18n.CouldNotFetchLatestRelease)
+	}
+
+	info.LatestVersion = strings.TrimPrefix(release.TagName, "v")
+	info.ReleaseURL = release.HTMLURL
+	if newer, err := update.IsNewer(info.Version, release.TagName); err == nil {
+		info.UpdateAvailable = &newer
+	}
+	return nil
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	info := currentBuildInfo()
+
+	var checkErr error
+	if versionCheck {
+		checkErr = addLatestRelease(cmd.Context(), &info)
+	}
+
+	if IsJSONOutput() {
+		return GetFormatter().Print(info)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "notte version %s\n", info.Version)
+	fmt.Fprintf(&b, "  module:     %s\n", info.Module)
+	fmt.Fprintf(&b, "  commit:     %s\n", info.Commit)
+	fmt.Fprintf(&b, "  built:      %s\n", info.BuildDate)
+	fmt.Fprintf(&b, "  go version: %s\n", info.GoVersion)
+	fmt.Fprintf(&b, "  platform:   %s", info.Platform)
+
+	if versionCheck {
+		switch {
+		case checkErr != nil:
+			fmt.Fprintf(&b, "\n  latest:     unknown (%v)", checkErr)
+		case info.UpdateAvailable != nil && *info.UpdateAvailable:
+			fmt.Fprintf(&b, "\n  latest:     %s (update available: %s)", info.LatestVersion, info.ReleaseURL)
+		case info.UpdateAvailable != nil:
+			fmt.Fprintf(&b, "\n  latest:     %s (up to date)", info.LatestVersion)
+		default:
+			fmt.Fprintf(&b, "\n  latest:     %s", info.LatestVersion)
+		}
+	}
+	return PrintResult(b.String(), nil)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+// Statuses that `status --wait-for` accepts. Anything but active is final.
+var (
+	sessionStatuses = []string{
+		string(api.SessionResponseStatusActive),
+		string(api.SessionResponseStatusClosed),
+		string(api.SessionResponseStatusError),
+		string(api.SessionResponseStatusTimedOut),
+	}
+	agentStatuses = []string{
+		string(api.AgentStatusActive),
+		string(api.AgentStatusClosed),
+	}
+)
+
+const defaultWaitTimeout = 2 * time.Minute
+
+// waitPollInterval is how often --wait-for checks the status
+var waitPollInterval = time.Second
+
+func registerWaitForFlags(cmd *cobra.Command, statuses []string) {
+	cmd.Flags().String("wait-for", "", fmt.Sprintf("Poll until the status is %s; exits 1 on timeout or if another final status is reached", strings.Join(statuses, ", ")))
+	cmd.Flags().Duration("wait-timeout", defaultWaitTimeout, "How long --wait-for waits")
+	_ = cmd.Flags().SetAnnotation("wait-for", flagEnumAnnotation, statuses)
+}
+
+// getWaitForFlags returns the lowercased --wait-for status (empty when not
+// waiting) and --wait-timeout
+func getWaitForFlags(cmd *cobra.Command, statuses []string) (string, time.Duration, error) {
+	want, _ := cmd.Flags().GetString("wait-for")
+	if want == "" {
+		return "", 0, nil
+	}
+	want = strings.ToLower(want)
+	if !slices.Contains(statuses, want) {
+		return "", 0, i18n.Errorf(i18n.InvalidWaitFor, want, strings.Join(statuses, ", "))
+	}
+	timeout, _ := cmd.Flags().GetDuration("wait-timeout")
+	if timeout <= 0 {
+		return "", 0, i18n.Errorf(i18n.WaitTimeoutMustBePositive)
+	}
+	return want, timeout, nil
+}
+
+// waitForStatus calls poll until it returns want. Statuses other than active
+// are final, so reaching a different one fails without waiting further.
+func waitForStatus(ctx context.Context, resource, id, want string, timeout time.Duration, poll func(ctx context.Context) (string, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	announced := false
+	for {
+		status, err := poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return i18n.Errorf(i18n.TimedOutWaitingForState, timeout, resource, id, want)
+			}
+			return err
+		}
+		status = strings.ToLower(status)
+		if status == want {
+			return nil
+		}
+		if status != string(api.SessionResponseStatusActive) {
+			return i18n.Errorf(i18n.ResourceInWrongState, resource, id, status, want)
+		}
+
+		if !announced {
+			PrintInfo(i18n.T(i18n.WaitingForState, resource, id, want))
+			announced = true
+		}
+		select {
+		case <-ctx.Done():
+			return i18n.Errorf(i18n.TimedOutWaitingForStateLast, timeout, resource, id, want, status)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+	"github.com/nottelabs/notte-cli/pkg/mockserver"
+)
+
+func sessionStatusJSON(status string) string {
+	return `{"session_id":"` + sessionIDTest + `","status":"` + status + `","created_at":"2020-01-01T00:00:00Z","last_accessed_at":"2020-01-01T00:00:00Z","timeout_minutes":0}`
+}
+
+func newWaitCmd(t *testing.T, waitFor string, timeout time.Duration) *cobra.Command {
+	t.Helper()
+	orig := waitPollInterval
+	waitPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { waitPollInterval = orig })
+
+	cmd := &cobra.Command{}
+	registerWaitForFlags(cmd, sessionStatuses)
+	_ = cmd.Flags().Set("wait-for", waitFor)
+	_ = cmd.Flags().Set("wait-timeout", timeout.String())
+	cmd.SetContext(context.Background())
+	return cmd
+}
+
+func TestRunSessionStatus_WaitFor(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddSequence("/sessions/"+sessionIDTest,
+		mockserver.JSONResponse(200, sessionStatusJSON("active")),
+		mockserver.JSONResponse(200, sessionStatusJSON("active")),
+		mockserver.JSONResponse(200, sessionStatusJSON("closed")),
+	)
+
+	origFormat := outputFormat
+	outputFormat = "json"
+	t.Cleanup(func() { outputFormat = origFormat })
+
+	cmd := newWaitCmd(t, "CLOSED", 5*time.Second)
+
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runSessionStatus(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if !strings.Contains(stdout, `"status":"closed"`) {
+		t.Errorf("expected the final status to be printed, got %q", stdout)
+	}
+	if n := len(server.Requests("/sessions/" + sessionIDTest)); n != 3 {
+		t.Errorf("expected 3 polls, got %d", n)
+	}
+}
+
+func TestRunSessionStatus_WaitForOtherFinalStatus(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/"+sessionIDTest, 200, sessionStatusJSON("error"))
+
+	cmd := newWaitCmd(t, "closed", 5*time.Second)
+
+	err := runSessionStatus(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "is error, not closed") {
+		t.Fatalf("expected a final-status error, got %v", err)
+	}
+	if n := len(server.Requests("/sessions/" + sessionIDTest)); n != 1 {
+		t.Errorf("expected a single poll, got %d", n)
+	}
+}
+
+func TestRunSessionStatus_WaitForTimeout(t *testing.T) {
+	server := setupSessionTest(t)
+	server.AddResponse("/sessions/"+sessionIDTest, 200, sessionStatusJSON("active"))
+
+	cmd := newWaitCmd(t, "closed", 50*time.Millisecond)
+
+	_, _ = testutil.CaptureOutput(func() {
+		err := runSessionStatus(cmd, nil)
+		if err == nil || !strings.Contains(err.Error(), "timed out after 50ms") {
+			t.Errorf("expected a timeout, got %v", err)
+		}
+	})
+}
+
+func TestGetWaitForFlags_Invalid(t *testing.T) {
+	cmd := newWaitCmd(t, "running", time.Second)
+
+	if _, _, err := getWaitForFlags(cmd, sessionStatuses); err == nil || !strings.Contains(err.Error(), "invalid --wait-for") {
+		t.Errorf("expected invalid status error, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/api"
+	"github.com/nottelabs/notte-cli/internal/auth"
+	apierrors "github.com/nottelabs/notte-cli/internal/errors"
+	"github.com/nottelabs/notte-cli/internal/i18n"
+)
+
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show which key, environment and plan the CLI is using",
+	Long: `Print the API key in use (masked) and where it comes from, the active
+environment and API URL, and the account's plan, on one line.
+
+The API doesn't expose the account's email or organization, so the plan
+from 'notte usage' is the account detail shown. Fetching it also checks that
+the key is accepted; when the API can't be reached, the plan is left out.`,
+	Example: `  notte whoami
+  notte whoami -o json | jq -r .environment`,
+	Args: cobra.NoArgs,
+	RunE: runWhoami,
+}
+
+func init() {
+	rootCmd.AddCommand(whoamiCmd)
+}
+
+// whoami is what notte whoami reports
+type whoami struct {
+	APIKey      string `json:"api_key"`
+	Source      string `json:"source"`
+	Environment string `json:"environment"`
+	APIURL      string `json:"api_url"`
+	Plan        string `json:"plan,omitempty"`
+}
+
+func (w whoami) String() string {
+	parts := []string{
+		fmt.Sprintf("%s (%s)", w.APIKey, w.Source),
+		fmt.Sprintf("%s %s", w.Environment, w.APIURL),
+	}
+	if w.Plan != "" {
+		parts = append(parts, "plan "+w.Plan)
+	}
+	return strings.Join(parts, " · ")
+}
+
+func runWhoami(cmd *cobra.Command, args []string) error {
+	key, source, err := auth.GetAPIKey("")
+	if err != nil {
+		return i18n.Errorf(i18n.NotAuthenticated, err)
+	}
+	apiURL := auth.GetCurrentAPIURL()
+	info := whoami{
+		APIKey:      maskAPIKey(key),
+		Source:      string(source),
+		Environment: auth.ResolveEnvLabel(apiURL),
+		APIURL:      apiURL,
+	}
+
+	plan, err := accountPlan(cmd)
+	var authErr *apierrors.AuthError
+	switch {
+	case errors.As(err, &authErr):
+		return i18n.Errorf(i18n.APIKeyRejected, info.Environment, info.Source, err)
+	case err != nil:
+		PrintInfo(i18n.T(i18n.WarningCouldNotFetchPlan, err))
+	default:
+		info.Plan = plan
+	}
+
+	if IsJSONOutput() {
+		return GetFormatter().Print(info)
+	}
+	fmt.Println(info.String())
+	return nil
+}
+
+// accountPlan returns the plan of the account the API key belongs to
+func accountPlan(cmd *cobra.Command) (string, error) {
+	client, err := GetClient()
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := GetContextWithTimeout(cmd.Context())
+	defer cancel()
+
+	resp, err := client.Client().GetUsageWithResponse(ctx, &api.GetUsageParams{})
+	if err != nil {
+		return "", i18n.Errorf(i18n.APIRequestFailed, err)
+	}
+	if err := HandleAPIResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return "", err
+	}
+	if resp.JSON200 == nil {
+		return "", errors.New(i18n.T(i18n.EmptyUsageResponse))
+	}
+	data, err := json.Marshal(resp.JSON200.PlanType)
+	if err != nil {
+		return "", err
+	}
+	var plan any
+	if err := json.Unmarshal(data, &plan); err != nil || plan == nil {
+		return "", err
+	}
+	return fmt.Sprint(plan), nil
+}
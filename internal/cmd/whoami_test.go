@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nottelabs/notte-cli/internal/testutil"
+)
+
+func setupWhoamiTest(t *testing.T, format string) *testutil.MockServer {
+	t.Helper()
+	env := testutil.SetupTestEnv(t)
+	env.SetEnv("NOTTE_API_KEY", "sk-test-1234567890abcd")
+	server := testutil.NewMockServer()
+	t.Cleanup(server.Close)
+	env.SetEnv("NOTTE_API_URL", server.URL())
+
+	origFormat := outputFormat
+	t.Cleanup(func() { outputFormat = origFormat })
+	outputFormat = format
+	return server
+}
+
+func TestRunWhoami(t *testing.T) {
+	server := setupWhoamiTest(t, "text")
+	server.AddResponse("/usage", 200, `{"period":"May 2025","plan_type":"pro","total_cost":1.5}`)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runWhoami(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	want := "sk-test-...abcd (environment) · 127.0.0.1 " + server.URL() + " · plan pro\n"
+	if stdout != want {
+		t.Errorf("got %q, want %q", stdout, want)
+	}
+}
+
+func TestRunWhoami_JSON(t *testing.T) {
+	server := setupWhoamiTest(t, "json")
+	server.AddResponse("/usage", 200, `{"period":"May 2025","plan_type":"free","total_cost":0}`)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runWhoami(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var got whoami
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout, err)
+	}
+	want := whoami{APIKey: "sk-test-...abcd", Source: "environment", Environment: "127.0.0.1", APIURL: server.URL(), Plan: "free"}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if strings.Contains(stdout, "1234567890") {
+		t.Error("the API key must be masked")
+	}
+}
+
+func TestRunWhoami_RejectedKey(t *testing.T) {
+	server := setupWhoamiTest(t, "text")
+	server.AddResponse("/usage", 401, `{"detail": "invalid api key"}`)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	err := runWhoami(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "API key from environment was rejected") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRunWhoami_PlanUnavailable(t *testing.T) {
+	server := setupWhoamiTest(t, "text")
+	server.AddResponse("/usage", 404, `{"detail": "not found"}`)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	stdout, _ := testutil.CaptureOutput(func() {
+		if err := runWhoami(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	warning, line, _ := strings.Cut(strings.TrimSpace(stdout), "\n")
+	if !strings.Contains(warning, "could not fetch the account plan") {
+		t.Errorf("expected a warning, got %q", stdout)
+	}
+	if strings.Contains(line, "plan") || !strings.HasPrefix(line, "sk-test-...abcd") {
+		t.Errorf("unexpected output: %q", line)
+	}
+}
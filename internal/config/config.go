@@ -10,6 +10,7 @@ import (
 const (
 	DefaultAPIURL            = "https://api.notte.cc"
 	DefaultConsoleURL        = "https://console.notte.cc"
+	DocsURL                  = "https://docs.notte.cc"
 	ConfigDirName            = ".notte/cli"
 	ConfigFileName           = "config.json"
 	CurrentSessionFile       = "current_session"
@@ -17,6 +18,14 @@ const (
 	CurrentViewerURLFile     = "current_viewer_url"
 	CurrentAgentFile         = "current_agent"
 	CurrentSessionExpiryFile = "current_session_expiry"
+	LastSessionStartFile     = "last_session_start.json"
+	ObserveCacheDir          = "observe"
+	ScreenshotStateDir       = "screenshots"
+	TaskTemplatesDir         = "templates"
+	UploadManifestFile       = "uploads.json"
+	IDHistoryFile            = "id_history.json"
+	AgentRunsFile            = "agent_runs.json"
+	ConfirmationsLogFile     = "confirmations.log"
 	DefaultRequestOrigin     = "cli"
 	EnvConfigDir             = "NOTTE_CONFIG_DIR"
 	EnvAPIURL                = "NOTTE_API_URL"
@@ -26,6 +35,12 @@ const (
 	EnvFunctionID            = "NOTTE_FUNCTION_ID"
 	EnvAgentID               = "NOTTE_AGENT_ID"
 	EnvNoUpdateCheck         = "NOTTE_NO_UPDATE_CHECK"
+	EnvOffline               = "NOTTE_OFFLINE"
+	EnvMock                  = "NOTTE_MOCK"
+	EnvMockMode              = "NOTTE_MOCK_MODE"
+	EnvNoDaemon              = "NOTTE_NO_DAEMON"
+	EnvLatencyBudget         = "NOTTE_LATENCY_BUDGET"
+	EnvNonInteractive        = "NOTTE_NONINTERACTIVE"
 )
 
 // testConfigDir allows overriding the config directory for testing.
@@ -42,6 +57,68 @@ func SetTestConfigDir(dir string) {
 type Config struct {
 	APIKey string `json:"api_key,omitempty"`
 	APIURL string `json:"api_url,omitempty"`
+
+	// TLS settings for APIs behind private gateways: a PEM CA bundle and a
+	// PEM client certificate/key pair for mutual TLS
+	CACert     string `json:"ca_cert,omitempty"`
+	ClientCert string `json:"client_cert,omitempty"`
+	ClientKey  string `json:"client_key,omitempty"`
+
+	// Aliases maps a user-defined command name to the command line it expands
+	// to, e.g. {"co": "page goto"}
+	Aliases map[string]string `json:"aliases,omitempty"`
+
+	// Recipes are named `sessions start` presets: flag name -> value
+	Recipes map[string]map[string]string `json:"recipes,omitempty"`
+
+	// Env holds default flags per environment (prod, staging, dev or an
+	// API host), applied when that environment is active: flag name or
+	// "<command>.<flag>" -> value, e.g. {"staging": {"sessions_start.headless": "true"}}
+	Env map[string]map[string]string `json:"env,omitempty"`
+
+	// Transport tunes API connections; unset fields keep the defaults
+	Transport *TransportConfig `json:"transport,omitempty"`
+
+	// KeyringCacheTTL enables a short-lived, owner-only file cache of the
+	// keyring API key, encrypted per boot session, e.g. "5m". Empty
+	// disables it.
+	KeyringCacheTTL string `json:"keyring_cache_ttl,omitempty"`
+
+	// Locale is the language of CLI messages, e.g. "fr". Empty follows
+	// LC_ALL, LC_MESSAGES or LANG.
+	Locale string `json:"locale,omitempty"`
+
+	// NonInteractive decides prompts when the CLI runs non-interactively:
+	// "deny" (the default) fails the command, "yes" answers as --yes would
+	NonInteractive string `json:"non_interactive,omitempty"`
+
+	// Policy guards destructive commands, e.g. on shared service accounts
+	Policy *PolicyConfig `json:"policy,omitempty"`
+}
+
+// PolicyConfig restricts what the CLI may do with this config
+type PolicyConfig struct {
+	// Protect lists resource IDs (personas, vaults, sessions, ...) that
+	// delete and stop commands refuse to touch
+	Protect []string `json:"protect,omitempty"`
+	// Forbid lists commands that may not run at all, e.g. "vaults delete".
+	// A group such as "vaults" forbids all of its subcommands.
+	Forbid []string `json:"forbid,omitempty"`
+	// ConfirmAgentSteps makes agents start ask for confirmation when a run
+	// is estimated to take more steps than this. Zero disables the check.
+	ConfirmAgentSteps int `json:"confirm_agent_steps,omitempty"`
+}
+
+// TransportConfig holds connection pooling, HTTP/2 and timeout settings.
+// Durations use Go syntax, e.g. "10s".
+type TransportConfig struct {
+	MaxIdleConns          int    `json:"max_idle_conns,omitempty"`
+	MaxIdleConnsPerHost   int    `json:"max_idle_conns_per_host,omitempty"`
+	MaxConnsPerHost       int    `json:"max_conns_per_host,omitempty"`
+	HTTP2                 *bool  `json:"http2,omitempty"`
+	DialTimeout           string `json:"dial_timeout,omitempty"`
+	ResponseHeaderTimeout string `json:"response_header_timeout,omitempty"`
+	RequestTimeout        string `json:"request_timeout,omitempty"`
 }
 
 // Dir returns the notte config directory path (~/.notte/cli)
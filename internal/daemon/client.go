@@ -0,0 +1,76 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// daemonHost is a placeholder host for requests sent over the socket
+const daemonHost = "notte-daemon"
+
+// Transport returns a RoundTripper that sends every request to the daemon
+// listening on socketPath, which forwards it to the API
+func Transport(socketPath string) http.RoundTripper {
+	return &socketTransport{
+		base: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socketPath)
+			},
+			MaxIdleConns:    10,
+			IdleConnTimeout: 30 * time.Second,
+		},
+	}
+}
+
+type socketTransport struct {
+	base http.RoundTripper
+}
+
+func (t *socketTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	out.URL.Scheme = "http"
+	out.URL.Host = daemonHost
+	out.Host = daemonHost
+	return t.base.RoundTrip(out)
+}
+
+// GetStatus queries the daemon on socketPath
+func GetStatus(ctx context.Context, socketPath string) (*Status, error) {
+	var status Status
+	if err := control(ctx, socketPath, http.MethodGet, "status", &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Stop asks the daemon on socketPath to shut down
+func Stop(ctx context.Context, socketPath string) error {
+	return control(ctx, socketPath, http.MethodPost, "shutdown", nil)
+}
+
+func control(ctx context.Context, socketPath, method, name string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, "http://"+daemonHost+controlPrefix+name, nil)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Transport: Transport(socketPath)}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("daemon not reachable: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("daemon returned %s", resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
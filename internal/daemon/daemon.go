@@ -0,0 +1,242 @@
+// Package daemon implements a long-lived local process that proxies API
+// requests for the CLI over a unix socket.
+//
+// Every CLI invocation otherwise pays for a fresh TLS handshake and a keyring
+// lookup. The daemon keeps a warm connection pool to the API, authenticates
+// delegated requests with the key it was started with, and caches the latest
+// observe result per session for editors and other local tools.
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nottelabs/notte-cli/internal/config"
+)
+
+const (
+	// SocketFileName is the daemon socket inside the config directory
+	SocketFileName = "daemon.sock"
+
+	// DelegatedAPIKey is sent by the CLI instead of a real key when it relies
+	// on the daemon's credentials
+	DelegatedAPIKey = "notte-daemon-delegated"
+
+	// controlPrefix namespaces the daemon's own endpoints
+	controlPrefix = "/_daemon/"
+)
+
+// SocketPath returns the default daemon socket path
+func SocketPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, SocketFileName), nil
+}
+
+// Status describes a running daemon
+type Status struct {
+	PID       int       `json:"pid"`
+	Upstream  string    `json:"upstream"`
+	StartedAt time.Time `json:"started_at"`
+	Requests  int64     `json:"requests"`
+	HasAPIKey bool      `json:"has_api_key"`
+	Sessions  int       `json:"cached_sessions"`
+}
+
+// Server proxies API requests received on a local socket to the upstream API
+type Server struct {
+	upstream  *url.URL
+	apiKey    string
+	startedAt time.Time
+	requests  atomic.Int64
+	proxy     *httputil.ReverseProxy
+	http      *http.Server
+
+	mu      sync.RWMutex
+	observe map[string][]byte // session ID -> latest observe response
+}
+
+var (
+	observePath    = regexp.MustCompile(`^/sessions/([^/]+)/page/observe$`)
+	sessionSubPath = regexp.MustCompile(`^/sessions/([^/]+)/`)
+)
+
+// NewServer creates a daemon proxying to upstream. apiKey authenticates
+// requests that carry DelegatedAPIKey; it may be empty.
+func NewServer(upstream, apiKey string, transport http.RoundTripper) (*Server, error) {
+	u, err := url.Parse(upstream)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid upstream URL %q", upstream)
+	}
+
+	s := &Server{
+		upstream:  u,
+		apiKey:    apiKey,
+		startedAt: time.Now().UTC(),
+		observe:   make(map[string][]byte),
+	}
+	s.proxy = &httputil.ReverseProxy{
+		Rewrite:        s.rewrite,
+		Transport:      transport,
+		ModifyResponse: s.cacheObserve,
+	}
+	s.http = &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s, nil
+}
+
+func (s *Server) rewrite(r *httputil.ProxyRequest) {
+	r.SetURL(s.upstream)
+	r.Out.Host = s.upstream.Host
+
+	if s.apiKey == "" {
+		return
+	}
+	if r.In.Header.Get("Authorization") == "Bearer "+DelegatedAPIKey {
+		r.Out.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+	if r.In.Header.Get("x-notte-api-key") == DelegatedAPIKey {
+		r.Out.Header.Set("x-notte-api-key", s.apiKey)
+	}
+}
+
+// cacheObserve stores successful observe responses and drops a session's
+// cached state when anything else touches that session
+func (s *Server) cacheObserve(resp *http.Response) error {
+	path := strings.TrimPrefix(resp.Request.URL.Path, strings.TrimSuffix(s.upstream.Path, "/"))
+
+	if m := observePath.FindStringSubmatch(path); m != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		resp.Body = io.NopCloser(strings.NewReader(string(body)))
+
+		s.mu.Lock()
+		s.observe[m[1]] = body
+		s.mu.Unlock()
+		return nil
+	}
+
+	if resp.Request.Method != http.MethodGet {
+		if m := sessionSubPath.FindStringSubmatch(path); m != nil {
+			s.mu.Lock()
+			delete(s.observe, m[1])
+			s.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// ServeHTTP handles control endpoints and proxies everything else
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, controlPrefix) {
+		s.requests.Add(1)
+		s.proxy.ServeHTTP(w, r)
+		return
+	}
+
+	switch name := strings.TrimPrefix(r.URL.Path, controlPrefix); {
+	case name == "status" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.Status())
+	case name == "shutdown" && r.Method == http.MethodPost:
+		writeJSON(w, http.StatusOK, map[string]bool{"stopping": true})
+		go func() { _ = s.Shutdown(context.Background()) }()
+	case strings.HasPrefix(name, "observe/") && r.Method == http.MethodGet:
+		s.mu.RLock()
+		body, ok := s.observe[strings.TrimPrefix(name, "observe/")]
+		s.mu.RUnlock()
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"detail": "no cached observe state for this session"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "unknown daemon endpoint"})
+	}
+}
+
+// Status reports the daemon's state
+func (s *Server) Status() Status {
+	s.mu.RLock()
+	sessions := len(s.observe)
+	s.mu.RUnlock()
+
+	return Status{
+		PID:       os.Getpid(),
+		Upstream:  s.upstream.String(),
+		StartedAt: s.startedAt,
+		Requests:  s.requests.Load(),
+		HasAPIKey: s.apiKey != "",
+		Sessions:  sessions,
+	}
+}
+
+// Serve accepts connections on ln until Shutdown is called
+func (s *Server) Serve(ln net.Listener) error {
+	err := s.http.Serve(ln)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops the daemon gracefully
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.http.Shutdown(ctx)
+}
+
+// Listen opens the daemon socket, replacing a stale socket file left behind
+// by a daemon that is no longer running
+func Listen(socketPath string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0o700); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(socketPath); err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if _, err := GetStatus(ctx, socketPath); err == nil {
+			return nil, fmt.Errorf("a daemon is already running on %s", socketPath)
+		}
+		_ = os.Remove(socketPath)
+	}
+
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(socketPath, 0o600); err != nil {
+		_ = ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,176 @@
+package daemon
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// startDaemon runs a daemon proxying to upstream on a socket in a short temp
+// dir (unix socket paths are length-limited)
+func startDaemon(t *testing.T, upstream, apiKey string) (string, *Server) {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "nd")
+	if err != nil {
+		t.Fatalf("MkdirTemp() error = %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socketPath := filepath.Join(dir, SocketFileName)
+
+	srv, err := NewServer(upstream, apiKey, nil)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	ln, err := Listen(socketPath)
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+	return socketPath, srv
+}
+
+func get(t *testing.T, socketPath, path, key string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.example.com"+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	resp, err := (&http.Client{Transport: Transport(socketPath)}).Do(req)
+	if err != nil {
+		t.Fatalf("request %s error = %v", path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestNewServer_InvalidUpstream(t *testing.T) {
+	if _, err := NewServer("not a url", "", nil); err == nil {
+		t.Error("expected error for invalid upstream")
+	}
+}
+
+func TestServer_InjectsDelegatedKey(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Get("Authorization"))
+		mu.Unlock()
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer upstream.Close()
+
+	socketPath, _ := startDaemon(t, upstream.URL, "real-key")
+
+	get(t, socketPath, "/sessions", DelegatedAPIKey)
+	get(t, socketPath, "/sessions", "own-key")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) != 2 || seen[0] != "Bearer real-key" || seen[1] != "Bearer own-key" {
+		t.Errorf("upstream saw Authorization = %v", seen)
+	}
+}
+
+func TestServer_ObserveCache(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/sessions/sess_1/page/observe" {
+			_, _ = io.WriteString(w, `{"url":"https://example.com"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer upstream.Close()
+
+	socketPath, srv := startDaemon(t, upstream.URL, "")
+
+	if code, _ := get(t, socketPath, controlPrefix+"observe/sess_1", ""); code != http.StatusNotFound {
+		t.Errorf("observe before caching: status = %d, want 404", code)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "https://api.example.com/sessions/sess_1/page/observe", nil)
+	resp, err := (&http.Client{Transport: Transport(socketPath)}).Do(req)
+	if err != nil {
+		t.Fatalf("observe error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(body) != `{"url":"https://example.com"}` {
+		t.Errorf("proxied observe body = %q", body)
+	}
+
+	code, cached := get(t, socketPath, controlPrefix+"observe/sess_1", "")
+	if code != http.StatusOK || cached != string(body) {
+		t.Errorf("cached observe = %d %q", code, cached)
+	}
+	if got := srv.Status().Sessions; got != 1 {
+		t.Errorf("Status().Sessions = %d, want 1", got)
+	}
+
+	// An action on the session invalidates the cached state
+	req, _ = http.NewRequest(http.MethodPost, "https://api.example.com/sessions/sess_1/page/execute", nil)
+	resp, err = (&http.Client{Transport: Transport(socketPath)}).Do(req)
+	if err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if code, _ := get(t, socketPath, controlPrefix+"observe/sess_1", ""); code != http.StatusNotFound {
+		t.Errorf("observe after execute: status = %d, want 404", code)
+	}
+}
+
+func TestStatusAndStop(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer upstream.Close()
+
+	socketPath, _ := startDaemon(t, upstream.URL, "key")
+	get(t, socketPath, "/health", "")
+
+	status, err := GetStatus(context.Background(), socketPath)
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	if status.Upstream != upstream.URL || status.PID != os.Getpid() || !status.HasAPIKey || status.Requests != 1 {
+		t.Errorf("GetStatus() = %+v", status)
+	}
+
+	if _, err := Listen(socketPath); err == nil {
+		t.Error("Listen() on a live daemon socket should fail")
+	}
+
+	if err := Stop(context.Background(), socketPath); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+}
+
+func TestListen_ReplacesStaleSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "nd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	socketPath := filepath.Join(dir, SocketFileName)
+	if err := os.WriteFile(socketPath, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ln, err := Listen(socketPath)
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	_ = ln.Close()
+}
@@ -2,7 +2,11 @@ package errors
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
+
+	"github.com/nottelabs/notte-cli/internal/i18n"
 )
 
 // APIError represents an error from the Notte API
@@ -12,6 +16,8 @@ type APIError struct {
 	StatusCode int    // HTTP status code
 	Source     string // Which field caused the error (optional)
 	Cause      error  // Underlying error (optional)
+	// Fields are the per-field problems of a validation error (optional)
+	Fields []FieldError
 }
 
 func (e *APIError) Error() string {
@@ -25,6 +31,27 @@ func (e *APIError) Unwrap() error {
 	return e.Cause
 }
 
+// FieldError is one problem the API found with a request field, from a
+// FastAPI-style validation error
+type FieldError struct {
+	Location string // Where the field is: "body", "query", "path" or "header"
+	Path     string // Dotted path of the field, e.g. "viewport.width" (empty for the whole body)
+	Message  string // What is wrong with it
+	Type     string // Error type, e.g. "missing" (optional)
+	Flag     string // CLI flag that sets the field, without dashes (optional)
+}
+
+func (e FieldError) String() string {
+	switch {
+	case e.Flag != "":
+		return fmt.Sprintf("--%s: %s", e.Flag, e.Message)
+	case e.Path != "":
+		return fmt.Sprintf("%s: %s", e.Path, e.Message)
+	default:
+		return e.Message
+	}
+}
+
 // ValidationError represents client-side input validation failure
 type ValidationError struct {
 	Field   string
@@ -84,6 +111,92 @@ func (e *CircuitBreakerError) Error() string {
 	return fmt.Sprintf("service unavailable: circuit breaker open, retry in %s", remaining.Round(time.Second))
 }
 
+// OfflineError indicates the API could not be reached because there is no network
+type OfflineError struct {
+	Host  string // Host that could not be reached (optional)
+	Cause error  // Underlying network error (optional)
+}
+
+func (e *OfflineError) Error() string {
+	if e.Host != "" {
+		return fmt.Sprintf("offline: cannot reach %s (check your network connection)", e.Host)
+	}
+	return "offline: network is unavailable (check your network connection)"
+}
+
+func (e *OfflineError) Unwrap() error {
+	return e.Cause
+}
+
+// Sources of a TimeoutError
+const (
+	TimeoutCommand = "command" // The command's --timeout ran out
+	TimeoutClient  = "client"  // One of the HTTP client's transport timeouts ran out
+	TimeoutServer  = "server"  // The API or a gateway in front of it gave up
+)
+
+// TimeoutError tells which limit cut a request short, so the user knows
+// whether to wait longer or ask for less
+type TimeoutError struct {
+	Source     string        // TimeoutCommand, TimeoutClient or TimeoutServer
+	Setting    string        // Config setting of a client timeout, e.g. "request_timeout"
+	Limit      time.Duration // The limit that ran out (optional)
+	StatusCode int           // HTTP status of a server timeout (optional)
+	Cause      error         // Underlying error (optional)
+}
+
+func (e *TimeoutError) Error() string {
+	switch e.Source {
+	case TimeoutCommand:
+		return fmt.Sprintf("timed out: the command's %s limit ran out (raise it with --timeout <seconds>)", formatLimit(e.Limit))
+	case TimeoutClient:
+		return fmt.Sprintf("timed out: the HTTP client's %s limit ran out after %s (raise transport.%s in the config file)",
+			e.Setting, formatLimit(e.Limit), e.Setting)
+	default:
+		status := ""
+		if e.StatusCode != 0 {
+			status = fmt.Sprintf(" (%d %s)", e.StatusCode, http.StatusText(e.StatusCode))
+		}
+		return fmt.Sprintf("timed out: the API gave up on the request%s; retry it, or ask for less at once (fewer items, a shorter task)", status)
+	}
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Cause
+}
+
+// formatLimit prints d without trailing zero units, e.g. "1m" rather than "1m0s"
+func formatLimit(d time.Duration) string {
+	if d <= 0 {
+		return "time"
+	}
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// ConfirmationRequiredError indicates a command needed the user to confirm
+// an action, but could not ask
+type ConfirmationRequiredError struct {
+	Action   string // What needed confirming, e.g. "delete"
+	Resource string // Resource type, e.g. "vault"
+	ID       string // Resource ID (optional)
+	Reason   string // Why the prompt could not be shown
+}
+
+func (e *ConfirmationRequiredError) Error() string {
+	target := e.Resource
+	if e.ID != "" {
+		target += " " + e.ID
+	}
+	return i18n.T(i18n.ConfirmationRequired, e.Action, target, e.Reason)
+}
+
 // IsRetryable returns true if the error is potentially recoverable via retry
 func IsRetryable(err error) bool {
 	switch e := err.(type) {
@@ -111,6 +111,66 @@ func TestCircuitBreakerError_Error(t *testing.T) {
 	}
 }
 
+func TestOfflineError_Error(t *testing.T) {
+	err := &OfflineError{Host: "api.notte.cc"}
+	if got, want := err.Error(), "offline: cannot reach api.notte.cc (check your network connection)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	err = &OfflineError{}
+	if got := err.Error(); got == "" {
+		t.Error("error message should not be empty")
+	}
+}
+
+func TestTimeoutError_Error(t *testing.T) {
+	tests := []struct {
+		err  *TimeoutError
+		want string
+	}{
+		{&TimeoutError{Source: TimeoutCommand, Limit: time.Minute},
+			"timed out: the command's 1m limit ran out (raise it with --timeout <seconds>)"},
+		{&TimeoutError{Source: TimeoutClient, Setting: "request_timeout", Limit: 5 * time.Minute},
+			"timed out: the HTTP client's request_timeout limit ran out after 5m (raise transport.request_timeout in the config file)"},
+		{&TimeoutError{Source: TimeoutServer, StatusCode: 504},
+			"timed out: the API gave up on the request (504 Gateway Timeout); retry it, or ask for less at once (fewer items, a shorter task)"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+
+	cause := errors.New("context deadline exceeded")
+	if err := (&TimeoutError{Source: TimeoutCommand, Cause: cause}); !errors.Is(err, cause) {
+		t.Error("expected TimeoutError to unwrap to its cause")
+	}
+}
+
+func TestFormatLimit(t *testing.T) {
+	tests := map[time.Duration]string{
+		45 * time.Second:           "45s",
+		90 * time.Second:           "1m30s",
+		10 * time.Minute:           "10m",
+		2 * time.Hour:              "2h",
+		time.Hour + 30*time.Minute: "1h30m",
+		1500 * time.Millisecond:    "1.5s",
+		0:                          "time",
+	}
+	for d, want := range tests {
+		if got := formatLimit(d); got != want {
+			t.Errorf("formatLimit(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestConfirmationRequiredError_Error(t *testing.T) {
+	err := &ConfirmationRequiredError{Action: "delete", Resource: "vault", ID: "vault_123", Reason: "prompts are disabled"}
+	if got, want := err.Error(), "confirmation required to delete vault vault_123: prompts are disabled"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
 func TestIsRetryable(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -127,6 +187,7 @@ func TestIsRetryable(t *testing.T) {
 		{"validation", &ValidationError{Field: "x"}, false},
 		{"auth", &AuthError{Reason: "expired"}, false},
 		{"circuit breaker", &CircuitBreakerError{}, false},
+		{"offline", &OfflineError{}, false},
 	}
 
 	for _, tt := range tests {
@@ -2,6 +2,7 @@ package errors
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -95,12 +96,18 @@ func ParseAPIError(resp *http.Response, body []byte) error {
 		code = http.StatusText(resp.StatusCode)
 	}
 
-	return &APIError{
+	apiErr := &APIError{
 		StatusCode: resp.StatusCode,
 		Code:       code,
 		Message:    SanitizeMessage(message),
 		Source:     source,
 	}
+	for _, fe := range parseFieldErrors(apiResp.Detail) {
+		fe.Path = SanitizeMessage(fe.Path)
+		fe.Message = SanitizeMessage(fe.Message)
+		apiErr.Fields = append(apiErr.Fields, fe)
+	}
+	return apiErr
 }
 
 // extractErrorMessage extracts the error message from various API response formats
@@ -149,14 +156,11 @@ func parseDetailMessage(detail json.RawMessage) string {
 	}
 
 	// Try parsing as an array of validation errors
-	var validationErrors []fastAPIValidationError
-	if err := json.Unmarshal(detail, &validationErrors); err == nil && len(validationErrors) > 0 {
+	if fields := parseFieldErrors(detail); len(fields) > 0 {
 		// Combine all error messages
-		var messages []string
-		for _, ve := range validationErrors {
-			if ve.Msg != "" {
-				messages = append(messages, ve.Msg)
-			}
+		messages := make([]string, len(fields))
+		for i, fe := range fields {
+			messages[i] = fe.String()
 		}
 		return strings.Join(messages, "; ")
 	}
@@ -165,6 +169,38 @@ func parseDetailMessage(detail json.RawMessage) string {
 	return string(detail)
 }
 
+// parseFieldErrors reads FastAPI's array of validation errors. Each loc
+// starts with where the field is ("body", "query", ...) followed by the path
+// to it.
+func parseFieldErrors(detail json.RawMessage) []FieldError {
+	var validationErrors []fastAPIValidationError
+	if err := json.Unmarshal(detail, &validationErrors); err != nil {
+		return nil
+	}
+	var fields []FieldError
+	for _, ve := range validationErrors {
+		if ve.Msg == "" {
+			continue
+		}
+		fe := FieldError{Message: ve.Msg, Type: ve.Type}
+		var path []string
+		for i, part := range ve.Loc {
+			s := fmt.Sprint(part)
+			if f, ok := part.(float64); ok {
+				s = strconv.FormatFloat(f, 'f', -1, 64)
+			}
+			if i == 0 {
+				fe.Location = s
+				continue
+			}
+			path = append(path, s)
+		}
+		fe.Path = strings.Join(path, ".")
+		fields = append(fields, fe)
+	}
+	return fields
+}
+
 func parseRateLimitError(resp *http.Response, body []byte) *RateLimitError {
 	retryAfter := 60 * time.Second // Default
 
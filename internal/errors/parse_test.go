@@ -2,6 +2,7 @@ package errors
 
 import (
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -215,6 +216,50 @@ func TestParseAPIError_FastAPISingleError(t *testing.T) {
 	}
 }
 
+func TestParseAPIError_FastAPIFieldErrors(t *testing.T) {
+	body := []byte(`{
+		"detail": [
+			{"loc": ["body", "viewport", "width"], "msg": "Input should be greater than 0", "type": "greater_than"},
+			{"loc": ["body", "actions", 1, "type"], "msg": "Field required", "type": "missing"},
+			{"loc": ["query", "page"], "msg": "Input should be a valid integer", "type": "int_parsing"}
+		]
+	}`)
+
+	err := ParseAPIError(&http.Response{StatusCode: 422}, body)
+
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	want := []FieldError{
+		{Location: "body", Path: "viewport.width", Message: "Input should be greater than 0", Type: "greater_than"},
+		{Location: "body", Path: "actions.1.type", Message: "Field required", Type: "missing"},
+		{Location: "query", Path: "page", Message: "Input should be a valid integer", Type: "int_parsing"},
+	}
+	if !reflect.DeepEqual(apiErr.Fields, want) {
+		t.Errorf("Fields = %+v, want %+v", apiErr.Fields, want)
+	}
+	if !strings.HasPrefix(apiErr.Message, "viewport.width: Input should be greater than 0; actions.1.type: Field required") {
+		t.Errorf("Message = %q, should name the fields", apiErr.Message)
+	}
+}
+
+func TestFieldError_String(t *testing.T) {
+	tests := []struct {
+		fe   FieldError
+		want string
+	}{
+		{FieldError{Path: "max_steps", Message: "too large", Flag: "max-steps"}, "--max-steps: too large"},
+		{FieldError{Path: "max_steps", Message: "too large"}, "max_steps: too large"},
+		{FieldError{Message: "invalid body"}, "invalid body"},
+	}
+	for _, tt := range tests {
+		if got := tt.fe.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
 // TestParseAPIError_ErrorAsString tests the case where "error" is a string, not an object
 func TestParseAPIError_ErrorAsString(t *testing.T) {
 	body := []byte(`{
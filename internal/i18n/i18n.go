@@ -0,0 +1,175 @@
+// Package i18n translates the CLI's user-facing messages with go-i18n.
+// Messages are looked up by ID in JSON catalogs embedded from locales/, one
+// per language, and fall back to English when a language or message is
+// missing. Message text uses fmt verbs rather than templates; translations
+// reorder arguments with explicit indexes such as %[2]s.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nicksnyder/go-i18n/v2/i18n/template"
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the language messages are written in
+const DefaultLocale = "en"
+
+// Message IDs
+const (
+	ConfirmDelete         = "confirm.delete"
+	ConfirmStop           = "confirm.stop"
+	ConfirmReplaceSession = "confirm.replace_session"
+	ConfirmReplaceAgent   = "confirm.replace_agent"
+	ConfirmAgentSteps     = "confirm.agent_steps"
+	ChoiceDefaultYes      = "confirm.choice_default_yes"
+	ChoiceDefaultNo       = "confirm.choice_default_no"
+	YesAnswers            = "confirm.yes_answers"
+	ConfirmationRequired  = "confirm.required"
+	Cancelled             = "confirm.cancelled"
+	NonInteractiveDenied  = "confirm.non_interactive_denied"
+)
+
+//go:embed locales/*.json
+var catalogFS embed.FS
+
+var (
+	loadOnce sync.Once
+	bundle   *goi18n.Bundle
+
+	mu        sync.RWMutex
+	locale    = DefaultLocale
+	localizer *goi18n.Localizer
+)
+
+func load() {
+	bundle = goi18n.NewBundle(language.English)
+	bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
+	entries, err := catalogFS.ReadDir("locales")
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		_, _ = bundle.LoadMessageFileFS(catalogFS, path.Join("locales", e.Name()))
+	}
+}
+
+// Locales returns the languages with a catalog
+func Locales() []string {
+	loadOnce.Do(load)
+	var tags []string
+	for _, tag := range bundle.LanguageTags() {
+		tags = append(tags, tag.String())
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// Normalize reduces a locale such as "fr_FR.UTF-8" or "pt-BR" to its
+// language, "fr" or "pt". "C" and "POSIX" are English.
+func Normalize(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	if i := strings.IndexAny(tag, "_-"); i >= 0 {
+		tag = tag[:i]
+	}
+	tag = strings.ToLower(tag)
+	if tag == "c" || tag == "posix" {
+		return DefaultLocale
+	}
+	return tag
+}
+
+// FromEnv picks the locale from LC_ALL, LC_MESSAGES or LANG, in the order
+// POSIX gives them precedence. It returns "" when none is set.
+func FromEnv(getenv func(string) string) string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := getenv(key); v != "" {
+			return Normalize(v)
+		}
+	}
+	return ""
+}
+
+// SetLocale selects the language of messages. A language without a catalog
+// selects English, and SetLocale reports whether tag was available.
+func SetLocale(tag string) bool {
+	tag = Normalize(tag)
+	ok := slices.Contains(Locales(), tag)
+	mu.Lock()
+	defer mu.Unlock()
+	if ok {
+		locale = tag
+	} else {
+		locale = DefaultLocale
+	}
+	localizer = goi18n.NewLocalizer(bundle, locale)
+	return ok
+}
+
+// Locale returns the selected language
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locale
+}
+
+// message returns the text of id in the selected language, the English
+// text when it isn't translated, or id itself when it isn't a message
+func message(id string) string {
+	loadOnce.Do(load)
+	mu.RLock()
+	l := localizer
+	mu.RUnlock()
+	if l == nil {
+		l = goi18n.NewLocalizer(bundle, DefaultLocale)
+	}
+	// The identity parser keeps text such as {{.name}} from being read as a
+	// template; arguments are filled in with fmt instead
+	msg, _ := l.Localize(&goi18n.LocalizeConfig{MessageID: id, TemplateParser: template.IdentityParser{}})
+	if msg == "" {
+		return id
+	}
+	return msg
+}
+
+// T returns the message id in the selected language, formatted with args
+func T(id string, args ...any) string {
+	msg := message(id)
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Errorf returns an error with the message id in the selected language,
+// formatted with args the way fmt.Errorf does, so %w wraps
+func Errorf(id string, args ...any) error {
+	msg := message(id)
+	if len(args) == 0 {
+		return errors.New(msg)
+	}
+	return fmt.Errorf(msg, args...)
+}
+
+// List returns a message holding a comma-separated list, such as the
+// answers accepted as yes, split into its items
+func List(id string) []string {
+	var items []string
+	for _, item := range strings.Split(T(id), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,162 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"fr_FR.UTF-8":   "fr",
+		"de_DE@euro":    "de",
+		"pt-BR":         "pt",
+		"ES":            "es",
+		"C":             "en",
+		"POSIX":         "en",
+		"C.UTF-8":       "en",
+		"":              "",
+		" en_US.UTF-8 ": "en",
+	}
+	for in, want := range tests {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	env := map[string]string{"LANG": "de_DE.UTF-8", "LC_MESSAGES": "es_ES.UTF-8"}
+	if got := FromEnv(func(k string) string { return env[k] }); got != "es" {
+		t.Errorf("LC_MESSAGES should win over LANG, got %q", got)
+	}
+	env["LC_ALL"] = "fr_FR.UTF-8"
+	if got := FromEnv(func(k string) string { return env[k] }); got != "fr" {
+		t.Errorf("LC_ALL should win, got %q", got)
+	}
+	if got := FromEnv(func(string) string { return "" }); got != "" {
+		t.Errorf("expected no locale, got %q", got)
+	}
+}
+
+func TestSetLocaleAndT(t *testing.T) {
+	t.Cleanup(func() { SetLocale(DefaultLocale) })
+
+	if !SetLocale("fr_FR.UTF-8") || Locale() != "fr" {
+		t.Fatalf("expected French, got %q", Locale())
+	}
+	if got := T(ConfirmDelete, "vault", "vault_123"); got != "Supprimer vault vault_123 ? Cette action est irréversible." {
+		t.Errorf("unexpected translation: %q", got)
+	}
+	if got := List(YesAnswers); !slices.Equal(got, []string{"o", "oui"}) {
+		t.Errorf("unexpected yes answers: %v", got)
+	}
+
+	if SetLocale("tlh") || Locale() != DefaultLocale {
+		t.Errorf("a language without a catalog should select English, got %q", Locale())
+	}
+	if got := T(Cancelled); got != "Cancelled." {
+		t.Errorf("unexpected message: %q", got)
+	}
+	if got := T("no.such.message"); got != "no.such.message" {
+		t.Errorf("an unknown message should fall back to its ID, got %q", got)
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	t.Cleanup(func() { SetLocale(DefaultLocale) })
+	SetLocale("de")
+
+	err := Errorf(FailedToReadFile, fs.ErrNotExist)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected the cause to be wrapped, got %v", err)
+	}
+	if got := err.Error(); got != "Datei konnte nicht gelesen werden: file does not exist" {
+		t.Errorf("unexpected message: %q", got)
+	}
+}
+
+func TestTemplateTextIsLiteral(t *testing.T) {
+	want := "agent %q: task uses {{.%s}}, but %q is not an agent it depends on"
+	if got := message(AgentTaskUsesNonDependency); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// messageIDs returns the IDs declared as constants in the package's Go files
+func messageIDs(t *testing.T) []string {
+	t.Helper()
+	files, err := parser.ParseDir(token.NewFileSet(), ".", nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ids []string
+	for _, file := range files["i18n"].Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				for _, value := range spec.(*ast.ValueSpec).Values {
+					if lit, ok := value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						id, _ := strconv.Unquote(lit.Value)
+						if id != DefaultLocale {
+							ids = append(ids, id)
+						}
+					}
+				}
+			}
+		}
+	}
+	return ids
+}
+
+var verbPattern = regexp.MustCompile(`%(\[\d+\])?[a-z]`)
+
+// Every catalog must translate every English message with the same number of
+// arguments, or formatting would print %!s(MISSING) or %!(EXTRA ...)
+func TestCatalogsMatchEnglish(t *testing.T) {
+	read := func(tag string) map[string]string {
+		data, err := catalogFS.ReadFile("locales/" + tag + ".json")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			t.Fatalf("invalid %s catalog: %v", tag, err)
+		}
+		return messages
+	}
+	english := read(DefaultLocale)
+	for _, id := range messageIDs(t) {
+		if _, ok := english[id]; !ok {
+			t.Errorf("no English message for %s", id)
+		}
+	}
+	for _, tag := range Locales() {
+		messages := read(tag)
+		for id, msg := range english {
+			translated, ok := messages[id]
+			if !ok {
+				t.Errorf("%s: missing %s", tag, id)
+				continue
+			}
+			if got, want := len(verbPattern.FindAllString(translated, -1)), len(verbPattern.FindAllString(msg, -1)); got != want {
+				t.Errorf("%s: %s has %d arguments, English has %d", tag, id, got, want)
+			}
+		}
+		for id := range messages {
+			if _, ok := english[id]; !ok {
+				t.Errorf("%s: %s is not an English message", tag, id)
+			}
+		}
+	}
+}
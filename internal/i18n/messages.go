@@ -0,0 +1,442 @@
+package i18n
+
+// Message IDs of command errors, progress and warnings, and results.
+// The English text of each is in locales/en.json.
+
+// Errors
+const (
+	ActionError                       = "error.action_error"
+	ActionFailed                      = "error.action_failed"
+	ActionFailedOnOfSessions          = "error.action_failed_on_of_sessions"
+	ActionFailedWithReason            = "error.action_failed_with_reason"
+	ActionsFailed                     = "error.actions_failed"
+	AgentDependsOnItself              = "error.agent_depends_on_itself"
+	AgentDependsOnUnknownAgent        = "error.agent_depends_on_unknown_agent"
+	AgentError                        = "error.agent_error"
+	AgentHasNoAssociatedSession       = "error.agent_has_no_associated_session"
+	AgentHasNoAssociatedSessionID     = "error.agent_has_no_associated_session_id"
+	AgentIDRequired                   = "error.agent_id_required"
+	AgentIsDefinedTwice               = "error.agent_is_defined_twice"
+	AgentStepsOverPolicy              = "error.agent_steps_over_policy"
+	AgentTaskUsesNonDependency        = "error.agent_task_uses_non_dependency"
+	AgentsDidNotSucceed               = "error.agents_did_not_succeed"
+	AllDownloadsFailed                = "error.all_downloads_failed"
+	AllNetworkLogDownloadsFailed      = "error.all_network_log_downloads_failed"
+	AmountCannotBeNegative            = "error.amount_cannot_be_negative"
+	APIKeyRejected                    = "error.api_key_rejected"
+	APIRequestFailed                  = "error.api_request_failed"
+	AuthenticationFailed              = "error.authentication_failed"
+	BrokenLinks                       = "error.broken_links"
+	CloneCookiesFailed                = "error.clone_cookies_failed"
+	CloneStorageFailed                = "error.clone_storage_failed"
+	CommandForbiddenByPolicy          = "error.command_forbidden_by_policy"
+	ConcurrencyTooSmall               = "error.concurrency_too_small"
+	CouldNotFetchLatestRelease        = "error.could_not_fetch_latest_release"
+	CSVNeedsScrapeInstructions        = "error.csv_needs_scrape_instructions"
+	DaemonStartTimeout                = "error.daemon_start_timeout"
+	DashNeedsTerminal                 = "error.dash_needs_terminal"
+	DependencyCycleBetweenAgents      = "error.dependency_cycle_between_agents"
+	DidYouMean                        = "error.did_you_mean"
+	DownloadFailedWithStatus          = "error.download_failed_with_status"
+	DownloadFiltersWithURLsOnly       = "error.download_filters_with_ur_ls_only"
+	EditorFailed                      = "error.editor_failed"
+	ElementIDCannotBeEmpty            = "error.element_id_cannot_be_empty"
+	ElementIDNeedsSingleValue         = "error.element_id_needs_single_value"
+	ElementsWithoutAccessibleName     = "error.elements_without_accessible_name"
+	EmptyAgentStartResponse           = "error.empty_agent_start_response"
+	EmptyAgentStatusResponse          = "error.empty_agent_status_response"
+	EmptySessionStartResponse         = "error.empty_session_start_response"
+	EmptySessionStatusResponse        = "error.empty_session_status_response"
+	EmptyUsageResponse                = "error.empty_usage_response"
+	EnvFlagNotAllowed                 = "error.env_flag_not_allowed"
+	EnvUnknownFlag                    = "error.env_unknown_flag"
+	EnvValueError                     = "error.env_value_error"
+	EnvelopeNeedsJSONOrYAML           = "error.envelope_needs_json_or_yaml"
+	EnvelopeWithRaw                   = "error.envelope_with_raw"
+	ExpectedJSONObject                = "error.expected_json_object"
+	FailedForIDs                      = "error.failed_for_i_ds"
+	FailedToAccessDirectory           = "error.failed_to_access_directory"
+	FailedToAccessFile                = "error.failed_to_access_file"
+	FailedToCheckPageLoadState        = "error.failed_to_check_page_load_state"
+	FailedToClearCurrentAgent         = "error.failed_to_clear_current_agent"
+	FailedToClearCurrentFunction      = "error.failed_to_clear_current_function"
+	FailedToClearCurrentSession       = "error.failed_to_clear_current_session"
+	FailedToClearCurrentSessionExpiry = "error.failed_to_clear_current_session_expiry"
+	FailedToClearCurrentViewerURL     = "error.failed_to_clear_current_viewer_url"
+	FailedToCollectLinks              = "error.failed_to_collect_links"
+	FailedToCopyFileData              = "error.failed_to_copy_file_data"
+	FailedToCreate                    = "error.failed_to_create"
+	FailedToCreateDirectory           = "error.failed_to_create_directory"
+	FailedToCreateDownloadRequest     = "error.failed_to_create_download_request"
+	FailedToCreateExternalProxy       = "error.failed_to_create_external_proxy"
+	FailedToCreateFile                = "error.failed_to_create_file"
+	FailedToCreateFormFile            = "error.failed_to_create_form_file"
+	FailedToCreateNotteProxy          = "error.failed_to_create_notte_proxy"
+	FailedToCreateOutputDirectory     = "error.failed_to_create_output_directory"
+	FailedToCreateRequest             = "error.failed_to_create_request"
+	FailedToCreateTailnetProxy        = "error.failed_to_create_tailnet_proxy"
+	FailedToCreateTempDirectory       = "error.failed_to_create_temp_directory"
+	FailedToDecodeScreenshot          = "error.failed_to_decode_screenshot"
+	FailedToDownload                  = "error.failed_to_download"
+	FailedToDownloadFile              = "error.failed_to_download_file"
+	FailedToDownloadFileHTTP          = "error.failed_to_download_file_http"
+	FailedToDownloadHTTP              = "error.failed_to_download_http"
+	FailedToDownloadReason            = "error.failed_to_download_reason"
+	FailedToDownloadReplayVideo       = "error.failed_to_download_replay_video"
+	FailedToDownloadReplayVideoHTTP   = "error.failed_to_download_replay_video_http"
+	FailedToEncodeSchema              = "error.failed_to_encode_schema"
+	FailedToGetPageURLForSlug         = "error.failed_to_get_page_url_for_slug"
+	FailedToInstallDialogHandler      = "error.failed_to_install_dialog_handler"
+	FailedToInstallSkill              = "error.failed_to_install_skill"
+	FailedToListOwnResources          = "error.failed_to_list_own_resources"
+	FailedToListSessionDownloads      = "error.failed_to_list_session_downloads"
+	FailedToListSessions              = "error.failed_to_list_sessions"
+	FailedToLoadConfig                = "error.failed_to_load_config"
+	FailedToLocateNotteExecutable     = "error.failed_to_locate_notte_executable"
+	FailedToMarshalAction             = "error.failed_to_marshal_action"
+	FailedToMarshalRequestBody        = "error.failed_to_marshal_request_body"
+	FailedToMarshalSelectArguments    = "error.failed_to_marshal_select_arguments"
+	FailedToOpen                      = "error.failed_to_open"
+	FailedToOpenBrowser               = "error.failed_to_open_browser"
+	FailedToOpenChecksums             = "error.failed_to_open_checksums"
+	FailedToOpenDaemonLog             = "error.failed_to_open_daemon_log"
+	FailedToOpenFile                  = "error.failed_to_open_file"
+	FailedToParse                     = "error.failed_to_parse"
+	FailedToParseChecksums            = "error.failed_to_parse_checksums"
+	FailedToParseCookiesJSON          = "error.failed_to_parse_cookies_json"
+	FailedToParseDownloadResponse     = "error.failed_to_parse_download_response"
+	FailedToParseJSONMetadata         = "error.failed_to_parse_json_metadata"
+	FailedToParseLastSessionStart     = "error.failed_to_parse_last_session_start"
+	FailedToParseObserveSnapshot      = "error.failed_to_parse_observe_snapshot"
+	FailedToParseResponse             = "error.failed_to_parse_response"
+	FailedToParseStatusPage           = "error.failed_to_parse_status_page"
+	FailedToParseUploadManifest       = "error.failed_to_parse_upload_manifest"
+	FailedToParseVarsJSON             = "error.failed_to_parse_vars_json"
+	FailedToRead                      = "error.failed_to_read"
+	FailedToReadAccessibilityTree     = "error.failed_to_read_accessibility_tree"
+	FailedToReadActionsFromStdin      = "error.failed_to_read_actions_from_stdin"
+	FailedToReadBaseline              = "error.failed_to_read_baseline"
+	FailedToReadDialogLog             = "error.failed_to_read_dialog_log"
+	FailedToReadDirectory             = "error.failed_to_read_directory"
+	FailedToReadFile                  = "error.failed_to_read_file"
+	FailedToReadFromStdin             = "error.failed_to_read_from_stdin"
+	FailedToReadIDsFromStdin          = "error.failed_to_read_i_ds_from_stdin"
+	FailedToReadNamedFile             = "error.failed_to_read_named_file"
+	FailedToReadPageMetadata          = "error.failed_to_read_page_metadata"
+	FailedToReadResponse              = "error.failed_to_read_response"
+	FailedToReadResponseBody          = "error.failed_to_read_response_body"
+	FailedToReadSourceStorage         = "error.failed_to_read_source_storage"
+	FailedToRemoveAPIKey              = "error.failed_to_remove_api_key"
+	FailedToRemoveDialogHandler       = "error.failed_to_remove_dialog_handler"
+	FailedToRemoveSkill               = "error.failed_to_remove_skill"
+	FailedToRender                    = "error.failed_to_render"
+	FailedToRun                       = "error.failed_to_run"
+	FailedToSave                      = "error.failed_to_save"
+	FailedToSaveConfig                = "error.failed_to_save_config"
+	FailedToSetProxies                = "error.failed_to_set_proxies"
+	FailedToSetUpTerminal             = "error.failed_to_set_up_terminal"
+	FailedToStartDaemon               = "error.failed_to_start_daemon"
+	FailedToStartSession              = "error.failed_to_start_session"
+	FailedToStopSessions              = "error.failed_to_stop_sessions"
+	FailedToUpload                    = "error.failed_to_upload"
+	FailedToUploadError               = "error.failed_to_upload_error"
+	FailedToUploadToStorage           = "error.failed_to_upload_to_storage"
+	FailedToWrite                     = "error.failed_to_write"
+	FailedToWriteDescriptionField     = "error.failed_to_write_description_field"
+	FailedToWriteDiffImage            = "error.failed_to_write_diff_image"
+	FailedToWriteFile                 = "error.failed_to_write_file"
+	FailedToWriteJUnitReport          = "error.failed_to_write_junit_report"
+	FailedToWriteNameField            = "error.failed_to_write_name_field"
+	FailedToWritePrompt               = "error.failed_to_write_prompt"
+	FailedToWriteReplayVideo          = "error.failed_to_write_replay_video"
+	FailedToWriteResult               = "error.failed_to_write_result"
+	FailedToWriteScrapeResult         = "error.failed_to_write_scrape_result"
+	FailedToWriteScreenshot           = "error.failed_to_write_screenshot"
+	FailedToWriteSharedField          = "error.failed_to_write_shared_field"
+	FailedToWriteSpec                 = "error.failed_to_write_spec"
+	FailedWithExitCode                = "error.failed_with_exit_code"
+	FileCannotBeEmpty                 = "error.file_cannot_be_empty"
+	FileIsEmpty                       = "error.file_is_empty"
+	FileNotFound                      = "error.file_not_found"
+	FileTooLargeToUpload              = "error.file_too_large_to_upload"
+	FilterNeedsAll                    = "error.filter_needs_all"
+	FilterNeedsFollow                 = "error.filter_needs_follow"
+	FullNeedsStep                     = "error.full_needs_step"
+	FunctionIDRequired                = "error.function_id_required"
+	GenerateScriptNotFound            = "error.generate_script_not_found"
+	HTTPCredentialsConflict           = "error.http_credentials_conflict"
+	HTTPCredentialsNeedAbsoluteURL    = "error.http_credentials_need_absolute_url"
+	HTTPPassRequiresHTTPUser          = "error.http_pass_requires_http_user"
+	InputIsEmpty                      = "error.input_is_empty"
+	InputTooLarge                     = "error.input_too_large"
+	Interrupted                       = "error.interrupted"
+	IntervalMustBePositive            = "error.interval_must_be_positive"
+	IntervalTooSmall                  = "error.interval_too_small"
+	InvalidActionJSON                 = "error.invalid_action_json"
+	InvalidActionJSONError            = "error.invalid_action_json_error"
+	InvalidAPIURL                     = "error.invalid_api_url"
+	InvalidBy                         = "error.invalid_by"
+	InvalidCallbackURL                = "error.invalid_callback_url"
+	InvalidChoice                     = "error.invalid_choice"
+	InvalidComposeFile                = "error.invalid_compose_file"
+	InvalidComposeFileNoAgents        = "error.invalid_compose_file_no_agents"
+	InvalidComposeName                = "error.invalid_compose_name"
+	InvalidComposeSessionName         = "error.invalid_compose_session_name"
+	InvalidDepth                      = "error.invalid_depth"
+	InvalidEmailFormat                = "error.invalid_email_format"
+	InvalidExtraHTTPHeaders           = "error.invalid_extra_http_headers"
+	InvalidFailOn                     = "error.invalid_fail_on"
+	InvalidFileJSON                   = "error.invalid_file_json"
+	InvalidFilter                     = "error.invalid_filter"
+	InvalidHTTPCredentials            = "error.invalid_http_credentials"
+	InvalidJSONData                   = "error.invalid_json_data"
+	InvalidJSONOnStdin                = "error.invalid_json_on_stdin"
+	InvalidJSONSchema                 = "error.invalid_json_schema"
+	InvalidMaxSize                    = "error.invalid_max_size"
+	InvalidNonInteractiveConfig       = "error.invalid_non_interactive_config"
+	InvalidOnly                       = "error.invalid_only"
+	InvalidOptionIndex                = "error.invalid_option_index"
+	InvalidOutputType                 = "error.invalid_output_type"
+	InvalidScope                      = "error.invalid_scope"
+	InvalidScript                     = "error.invalid_script"
+	InvalidScriptLine                 = "error.invalid_script_line"
+	InvalidScrollAmount               = "error.invalid_scroll_amount"
+	InvalidSize                       = "error.invalid_size"
+	InvalidSkillName                  = "error.invalid_skill_name"
+	InvalidStatusPageURL              = "error.invalid_status_page_url"
+	InvalidTabIndex                   = "error.invalid_tab_index"
+	InvalidTaskTemplate               = "error.invalid_task_template"
+	InvalidTemplate                   = "error.invalid_template"
+	InvalidTemplateFlag               = "error.invalid_template_flag"
+	InvalidTemplateName               = "error.invalid_template_name"
+	InvalidTimeValue                  = "error.invalid_time_value"
+	InvalidTransportConfig            = "error.invalid_transport_config"
+	InvalidURLFormat                  = "error.invalid_url_format"
+	InvalidValue                      = "error.invalid_value"
+	InvalidVariableFormat             = "error.invalid_variable_format"
+	InvalidWaitFor                    = "error.invalid_wait_for"
+	InvalidWaitLoad                   = "error.invalid_wait_load"
+	InvalidWaitLoadOrNone             = "error.invalid_wait_load_or_none"
+	JSONInputRequired                 = "error.json_input_required"
+	KeyCannotBeEmpty                  = "error.key_cannot_be_empty"
+	KeyValueWithFile                  = "error.key_value_with_file"
+	LatencyBudgetNegative             = "error.latency_budget_negative"
+	MaxIterationsTooSmall             = "error.max_iterations_too_small"
+	MaxParallelCannotBeNegative       = "error.max_parallel_cannot_be_negative"
+	MissingFilePathAfterAt            = "error.missing_file_path_after_at"
+	NoAgentStartRecorded              = "error.no_agent_start_recorded"
+	NoDaemonRunning                   = "error.no_daemon_running"
+	NoDownloadURL                     = "error.no_download_url"
+	NoDownloadURLInResponse           = "error.no_download_url_in_response"
+	NoFinishedAgentRuns               = "error.no_finished_agent_runs"
+	NoIDsOnStdin                      = "error.no_ids_on_stdin"
+	NoObservedPageState               = "error.no_observed_page_state"
+	NoPreviousSessionStart            = "error.no_previous_session_start"
+	NoReasoningModelsInSpec           = "error.no_reasoning_models_in_spec"
+	NoSessionFoundForAgent            = "error.no_session_found_for_agent"
+	NoSuchAgentStep                   = "error.no_such_agent_step"
+	NoSuchOperation                   = "error.no_such_operation"
+	NoUploadedFileNamed               = "error.no_uploaded_file_named"
+	NoViewerURL                       = "error.no_viewer_url"
+	NotADirectory                     = "error.not_a_directory"
+	NotARegularFile                   = "error.not_a_regular_file"
+	NotAuthenticated                  = "error.not_authenticated"
+	NotJSON                           = "error.not_json"
+	NotLocalAndUploadsUnlisted        = "error.not_local_and_uploads_unlisted"
+	NotPNGOrJPEG                      = "error.not_png_or_jpeg"
+	NothingToSet                      = "error.nothing_to_set"
+	ObserveNeedsStep                  = "error.observe_needs_step"
+	OperationHint                     = "error.operation_hint"
+	PageEvaluationFailed              = "error.page_evaluation_failed"
+	PageHasNoOrigin                   = "error.page_has_no_origin"
+	PageSizeTooSmall                  = "error.page_size_too_small"
+	PageTooSmall                      = "error.page_too_small"
+	PasswordEmpty                     = "error.password_empty"
+	PathIsDirectory                   = "error.path_is_directory"
+	PlatformDegraded                  = "error.platform_degraded"
+	ProtectedByPolicy                 = "error.protected_by_policy"
+	ProxyFlagsExclusive               = "error.proxy_flags_exclusive"
+	RecipeError                       = "error.recipe_error"
+	RecipeNotFound                    = "error.recipe_not_found"
+	RecipeNotFoundHint                = "error.recipe_not_found_hint"
+	RecipeUnknownFlag                 = "error.recipe_unknown_flag"
+	ReplayHasNoVideoURL               = "error.replay_has_no_video_url"
+	RequiredFlagsNotSet               = "error.required_flags_not_set"
+	ResourceInWrongState              = "error.resource_in_wrong_state"
+	SampleTooSmall                    = "error.sample_too_small"
+	SchemaReferenceNotFound           = "error.schema_reference_not_found"
+	SchemaReferenceNotLocal           = "error.schema_reference_not_local"
+	ScopeTeamUnsupported              = "error.scope_team_unsupported"
+	ScrapeAfterScroll                 = "error.scrape_after_scroll"
+	ScrapeBeforeScrolling             = "error.scrape_before_scrolling"
+	ScrapeFailed                      = "error.scrape_failed"
+	ScrapeNotStructured               = "error.scrape_not_structured"
+	ScreenshotDiffers                 = "error.screenshot_differs"
+	ScriptAborted                     = "error.script_aborted"
+	ScriptHasNoActions                = "error.script_has_no_actions"
+	ScriptStopped                     = "error.script_stopped"
+	ScrollError                       = "error.scroll_error"
+	SearchQueryEmpty                  = "error.search_query_empty"
+	SecretValueRequired               = "error.secret_value_required"
+	SelectorEmpty                     = "error.selector_empty"
+	SessionAndSessionID               = "error.session_and_session_id"
+	SessionError                      = "error.session_error"
+	SessionIDRequired                 = "error.session_id_required"
+	SessionIDWithAll                  = "error.session_id_with_all"
+	SessionsNeedsID                   = "error.sessions_needs_id"
+	SessionsWithSessionID             = "error.sessions_with_session_id"
+	SeveralOperations                 = "error.several_operations"
+	SkippedInOfflineMode              = "error.skipped_in_offline_mode"
+	SpecChecksumMismatch              = "error.spec_checksum_mismatch"
+	StatusPageReturned                = "error.status_page_returned"
+	StdinNeedsIDs                     = "error.stdin_needs_i_ds"
+	StdinWithFlag                     = "error.stdin_with_flag"
+	StepNonInteractive                = "error.step_non_interactive"
+	StepWithStdinScript               = "error.step_with_stdin_script"
+	StorageKeyNotFound                = "error.storage_key_not_found"
+	StructuredDataIsObject            = "error.structured_data_is_object"
+	StructuredDataNotRows             = "error.structured_data_not_rows"
+	TableNeedsInstructions            = "error.table_needs_instructions"
+	TaskIsRequired                    = "error.task_is_required"
+	TaskTemplateError                 = "error.task_template_error"
+	TaskWithTaskTemplate              = "error.task_with_task_template"
+	TemplateError                     = "error.template_error"
+	TemplateNeedsVarFor               = "error.template_needs_var_for"
+	TemplateNotFound                  = "error.template_not_found"
+	TemplateRendersEmptyTask          = "error.template_renders_empty_task"
+	TemplateWithCSV                   = "error.template_with_csv"
+	TemplateWithRaw                   = "error.template_with_raw"
+	TemplatesEditNonInteractive       = "error.templates_edit_non_interactive"
+	ThresholdOutOfRange               = "error.threshold_out_of_range"
+	TimedOutWaitingForDownload        = "error.timed_out_waiting_for_download"
+	TimedOutWaitingForPage            = "error.timed_out_waiting_for_page"
+	TimedOutWaitingForState           = "error.timed_out_waiting_for_state"
+	TimedOutWaitingForStateLast       = "error.timed_out_waiting_for_state_last"
+	UnexpectedAccessibilityTree       = "error.unexpected_accessibility_tree"
+	UnexpectedDialogHandlerResult     = "error.unexpected_dialog_handler_result"
+	UnexpectedDialogLog               = "error.unexpected_dialog_log"
+	UnexpectedEmptyAgentStatus        = "error.unexpected_empty_agent_status"
+	UnexpectedEmptyNetworkLogs        = "error.unexpected_empty_network_logs"
+	UnexpectedEmptyReplay             = "error.unexpected_empty_replay"
+	UnexpectedEmptyScrape             = "error.unexpected_empty_scrape"
+	UnexpectedEmptySessionStatus      = "error.unexpected_empty_session_status"
+	UnexpectedEmptyWorkflowCode       = "error.unexpected_empty_workflow_code"
+	UnexpectedLinkList                = "error.unexpected_link_list"
+	UnexpectedLoadState               = "error.unexpected_load_state"
+	UnexpectedPageMetadata            = "error.unexpected_page_metadata"
+	UnexpectedScrollState             = "error.unexpected_scroll_state"
+	UnexpectedStatusCode              = "error.unexpected_status_code"
+	UnknownAgent                      = "error.unknown_agent"
+	UnknownNamePlaceholder            = "error.unknown_name_placeholder"
+	UnsupportedPlatform               = "error.unsupported_platform"
+	UntilBottomNeedsFollow            = "error.until_bottom_needs_follow"
+	ValidateNeedsInstructions         = "error.validate_needs_instructions"
+	VarNeedsTaskTemplate              = "error.var_needs_task_template"
+	VersionRequiredForDev             = "error.version_required_for_dev"
+	WaitTimeoutMustBePositive         = "error.wait_timeout_must_be_positive"
+	WaitWithSessions                  = "error.wait_with_sessions"
+	XlsxWithPath                      = "error.xlsx_with_path"
+)
+
+// Progress and warnings, printed to stderr in JSON mode
+const (
+	AgentHasNoStepsYet                   = "info.agent_has_no_steps_yet"
+	CheckingLinks                        = "info.checking_links"
+	CloneUsesDefaultProxies              = "info.clone_uses_default_proxies"
+	ClonedSessionInto                    = "info.cloned_session_into"
+	ComposeAgentFinished                 = "info.compose_agent_finished"
+	ComposeAgentSkipped                  = "info.compose_agent_skipped"
+	ComposeAgentStartFailed              = "info.compose_agent_start_failed"
+	ComposeAgentStarted                  = "info.compose_agent_started"
+	ComposeStatusCheckFailed             = "info.compose_status_check_failed"
+	ConcurrencyLimitQueued               = "info.concurrency_limit_queued"
+	ConcurrencyLimitRetrying             = "info.concurrency_limit_retrying"
+	CopiedCookies                        = "info.copied_cookies"
+	CopiedStorageItems                   = "info.copied_storage_items"
+	DaemonListening                      = "info.daemon_listening"
+	FetchingSpec                         = "info.fetching_spec"
+	FollowingNetworkRequests             = "info.following_network_requests"
+	FollowingScrollPosition              = "info.following_scroll_position"
+	NoSessionSettingsRecorded            = "info.no_session_settings_recorded"
+	OpeningBrowserForAuthentication      = "info.opening_browser_for_authentication"
+	OpeningViewerInBrowser               = "info.opening_viewer_in_browser"
+	OtherModelsAccepted                  = "info.other_models_accepted"
+	RerunningAgentAs                     = "info.rerunning_agent_as"
+	ScreenshotSaved                      = "info.screenshot_saved"
+	SessionIsStillRunning                = "info.session_is_still_running"
+	SkippingProtectedSession             = "info.skipping_protected_session"
+	StorageEmpty                         = "info.storage_empty"
+	UploadedToStorage                    = "info.uploaded_to_storage"
+	WaitingForDownload                   = "info.waiting_for_download"
+	WaitingForState                      = "info.waiting_for_state"
+	WarningCallbackFailed                = "info.warning_callback_failed"
+	WarningCouldNotClearCurrentAgent     = "info.warning_could_not_clear_current_agent"
+	WarningCouldNotEstimateSteps         = "info.warning_could_not_estimate_steps"
+	WarningCouldNotFetchPlan             = "info.warning_could_not_fetch_plan"
+	WarningCouldNotGetViewerURL          = "info.warning_could_not_get_viewer_url"
+	WarningCouldNotReadNetworkBatch      = "info.warning_could_not_read_network_batch"
+	WarningCouldNotRecordAgentStart      = "info.warning_could_not_record_agent_start"
+	WarningCouldNotRecordSessionStart    = "info.warning_could_not_record_session_start"
+	WarningCouldNotSaveCurrentAgent      = "info.warning_could_not_save_current_agent"
+	WarningCouldNotSaveCurrentFunction   = "info.warning_could_not_save_current_function"
+	WarningCouldNotSaveCurrentSession    = "info.warning_could_not_save_current_session"
+	WarningCouldNotSaveScreenshot        = "info.warning_could_not_save_screenshot"
+	WarningCouldNotSaveSessionExpiry     = "info.warning_could_not_save_session_expiry"
+	WarningCouldNotSaveViewerURL         = "info.warning_could_not_save_viewer_url"
+	WarningCouldNotStopAgent             = "info.warning_could_not_stop_agent"
+	WarningCouldNotStopSession           = "info.warning_could_not_stop_session"
+	WarningCouldNotUpdateUploadManifest  = "info.warning_could_not_update_upload_manifest"
+	WarningDownloadsFailed               = "info.warning_downloads_failed"
+	WarningError                         = "info.warning_error"
+	WarningFailedToOpenBrowser           = "info.warning_failed_to_open_browser"
+	WarningIgnoringUploadManifest        = "info.warning_ignoring_upload_manifest"
+	WarningNoTranslation                 = "info.warning_no_translation"
+	WarningSessionCouldNotSaveScreenshot = "info.warning_session_could_not_save_screenshot"
+	WarningSessionPagesTruncated         = "info.warning_session_pages_truncated"
+	WarningSessionSettingsReconstructed  = "info.warning_session_settings_reconstructed"
+)
+
+// Results
+const (
+	ActionFinished                   = "result.action_finished"
+	AgentStopped                     = "result.agent_stopped"
+	APIKeyRemoved                    = "result.api_key_removed"
+	APIKeyStored                     = "result.api_key_stored"
+	ClearedAllState                  = "result.cleared_all_state"
+	CredentialsDeleted               = "result.credentials_deleted"
+	DaemonAlreadyRunningPID          = "result.daemon_already_running_pid"
+	DaemonIsNotRunning               = "result.daemon_is_not_running"
+	DaemonStartedPID                 = "result.daemon_started_pid"
+	DaemonStatus                     = "result.daemon_status"
+	DaemonStopped                    = "result.daemon_stopped"
+	DeletedRecipe                    = "result.deleted_recipe"
+	DownloadedNetworkLogs            = "result.downloaded_network_logs"
+	FileAlreadyUploaded              = "result.file_already_uploaded"
+	FileDownloaded                   = "result.file_downloaded"
+	FileUploaded                     = "result.file_uploaded"
+	FunctionDeleted                  = "result.function_deleted"
+	FunctionEnvironmentSecretDeleted = "result.function_environment_secret_deleted"
+	FunctionScheduleRemoved          = "result.function_schedule_removed"
+	FunctionScheduled                = "result.function_scheduled"
+	NoNetworkLogs                    = "result.no_network_logs"
+	NoRecipesSaved                   = "result.no_recipes_saved"
+	NoReplayVideo                    = "result.no_replay_video"
+	NoSessionsToStop                 = "result.no_sessions_to_stop"
+	NoTaskTemplates                  = "result.no_task_templates"
+	OpenedInBrowser                  = "result.opened_in_browser"
+	OpenedViewerInBrowser            = "result.opened_viewer_in_browser"
+	PersonaDeleted                   = "result.persona_deleted"
+	ProfileDeleted                   = "result.profile_deleted"
+	RemovedStorageItem               = "result.removed_storage_item"
+	ReplayVideoSaved                 = "result.replay_video_saved"
+	SavedMatchingRequests            = "result.saved_matching_requests"
+	SavedRecipe                      = "result.saved_recipe"
+	SavedTemplate                    = "result.saved_template"
+	ScrapeSaved                      = "result.scrape_saved"
+	ScrapeSavedRows                  = "result.scrape_saved_rows"
+	SessionStopped                   = "result.session_stopped"
+	SetStorageItems                  = "result.set_storage_items"
+	SkillAlreadyInstalled            = "result.skill_already_installed"
+	VaultDeleted                     = "result.vault_deleted"
+	WroteManifests                   = "result.wrote_manifests"
+)
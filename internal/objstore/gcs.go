@@ -0,0 +1,86 @@
+package objstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// gcsMetadataTokenURL serves access tokens on GCE, GKE and Cloud Run
+var gcsMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// gcloudToken runs gcloud; replaced in tests
+var gcloudToken = func(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, "gcloud", "auth", "print-access-token").Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+func putGCS(ctx context.Context, bucket, object string, body io.Reader, contentType string) error {
+	// A media upload sends the whole object in one request of known length
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	base := "https://storage.googleapis.com"
+	emulator := firstEnv("STORAGE_EMULATOR_HOST")
+	if emulator != "" {
+		base = strings.TrimSuffix(emulator, "/")
+		if !strings.Contains(base, "://") {
+			base = "http://" + base
+		}
+	}
+
+	u := base + "/upload/storage/v1/b/" + url.PathEscape(bucket) + "/o?uploadType=media&name=" + url.QueryEscape(object)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	// The emulator doesn't check credentials
+	if emulator == "" {
+		token, err := gcsAccessToken(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return do(req, data)
+}
+
+// gcsAccessToken finds an OAuth token: GOOGLE_OAUTH_ACCESS_TOKEN, then the
+// gcloud CLI, then the metadata server when running on Google Cloud
+func gcsAccessToken(ctx context.Context) (string, error) {
+	if token := firstEnv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+	if token, err := gcloudToken(ctx); err == nil && token != "" {
+		return token, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcsMetadataTokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := httpClient.Do(req)
+	if err == nil {
+		defer func() { _ = resp.Body.Close() }()
+		var body struct {
+			AccessToken string `json:"access_token"`
+		}
+		if resp.StatusCode == http.StatusOK && json.NewDecoder(resp.Body).Decode(&body) == nil && body.AccessToken != "" {
+			return body.AccessToken, nil
+		}
+	}
+	return "", errors.New("no Google Cloud credentials: set GOOGLE_OAUTH_ACCESS_TOKEN or run 'gcloud auth login'")
+}
@@ -0,0 +1,112 @@
+// Package objstore uploads files to S3 and Google Cloud Storage using the
+// credentials already present on the machine, without the cloud SDKs.
+package objstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// httpClient is replaced in tests
+var httpClient = &http.Client{Timeout: 5 * time.Minute}
+
+// IsURL reports whether dest is an s3:// or gs:// URL
+func IsURL(dest string) bool {
+	return strings.HasPrefix(dest, "s3://") || strings.HasPrefix(dest, "gs://")
+}
+
+// IsPrefix reports whether dest names a "directory" (ends with a slash or
+// has no object key) rather than an object
+func IsPrefix(dest string) bool {
+	_, _, key := split(dest)
+	return key == "" || strings.HasSuffix(key, "/")
+}
+
+// Join appends name to an object URL, like filepath.Join for local paths
+func Join(dest, name string) string {
+	return strings.TrimSuffix(dest, "/") + "/" + strings.TrimPrefix(name, "/")
+}
+
+// split returns the scheme, bucket and key of an object URL
+func split(dest string) (scheme, bucket, key string) {
+	scheme, rest, _ := strings.Cut(dest, "://")
+	bucket, key, _ = strings.Cut(rest, "/")
+	return scheme, bucket, key
+}
+
+// Put uploads body to an s3:// or gs:// URL. Large S3 objects are streamed
+// in parts, so body doesn't need to fit in memory.
+func Put(ctx context.Context, dest string, body io.Reader, contentType string) error {
+	scheme, bucket, key := split(dest)
+	if bucket == "" || key == "" || strings.HasSuffix(key, "/") {
+		return fmt.Errorf("invalid object URL %q: expected %s://bucket/key", dest, scheme)
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	var err error
+	switch scheme {
+	case "s3":
+		err = putS3(ctx, bucket, key, body, contentType)
+	case "gs":
+		err = putGCS(ctx, bucket, key, body, contentType)
+	default:
+		err = fmt.Errorf("unsupported scheme %q", scheme)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", dest, err)
+	}
+	return nil
+}
+
+// errorMessage matches the message of an S3 or GCS XML error body
+var errorMessage = regexp.MustCompile(`<Message>([^<]*)</Message>`)
+
+// do sends req and turns a non-2xx response into an error
+func do(req *http.Request, body []byte) error {
+	_, _, err := send(req, body)
+	return err
+}
+
+// send sends req with body and returns the response headers and body,
+// turning a non-2xx response into an error
+func send(req *http.Request, body []byte) (http.Header, []byte, error) {
+	req.Body = http.NoBody
+	if len(body) > 0 {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	req.ContentLength = int64(len(body))
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.Header, data, err
+	}
+	return nil, nil, responseError(resp.StatusCode, data)
+}
+
+// responseError describes a failed response from its S3 or GCS error body
+func responseError(status int, body []byte) error {
+	msg := body
+	if len(msg) > 4096 {
+		msg = msg[:4096]
+	}
+	if m := errorMessage.FindSubmatch(msg); m != nil {
+		msg = m[1]
+	}
+	if s := strings.TrimSpace(string(msg)); s != "" {
+		return fmt.Errorf("HTTP %d: %s", status, s)
+	}
+	return fmt.Errorf("HTTP %d", status)
+}
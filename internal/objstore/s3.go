@@ -0,0 +1,493 @@
+package objstore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// awsCredentials are the keys used to sign S3 requests
+type awsCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// now is replaced in tests
+var now = time.Now
+
+// s3PartSize is the size of the parts of a multipart upload, and the size
+// from which an object is uploaded in parts. Replaced in tests.
+var s3PartSize = 8 << 20
+
+// s3MaxParts is the most parts S3 accepts in one multipart upload
+const s3MaxParts = 10000
+
+// s3Object is an object to upload, with what's needed to sign requests for it
+type s3Object struct {
+	url    *url.URL
+	creds  awsCredentials
+	region string
+}
+
+func putS3(ctx context.Context, bucket, key string, body io.Reader, contentType string) error {
+	creds, err := loadAWSCredentials(ctx)
+	if err != nil {
+		return err
+	}
+	region := awsRegion()
+	obj := &s3Object{url: s3ObjectURL(bucket, key, region), creds: creds, region: region}
+
+	// Objects smaller than a part go in one PUT. Larger ones are uploaded
+	// part by part, so only one part is held in memory at a time.
+	buf := make([]byte, s3PartSize)
+	first, err := readPart(body, buf)
+	if err != nil {
+		return err
+	}
+	if len(first) < s3PartSize {
+		_, _, err := obj.send(ctx, http.MethodPut, nil, first, http.Header{"Content-Type": {contentType}})
+		return err
+	}
+	return obj.putMultipart(ctx, first, body, buf, contentType)
+}
+
+// readPart fills buf from r and returns the bytes read, fewer than
+// len(buf) only at the end of r
+func readPart(r io.Reader, buf []byte) ([]byte, error) {
+	n, err := io.ReadFull(r, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		err = nil
+	}
+	return buf[:n], err
+}
+
+// send signs and sends a request for the object with the query and headers
+// given, and returns the response headers and body
+func (o *s3Object) send(ctx context.Context, method string, query url.Values, body []byte, header http.Header) (http.Header, []byte, error) {
+	u := *o.url
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.URL = &u
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	sum := sha256.Sum256(body)
+	signV4(req, hex.EncodeToString(sum[:]), o.creds, o.region, now())
+	return send(req, body)
+}
+
+// completedPart is a part of a multipart upload in CompleteMultipartUpload
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// putMultipart uploads first and then the rest of body in parts of
+// s3PartSize, reading each into buf. A failed upload is aborted so S3
+// doesn't keep (and bill) the parts already sent.
+func (o *s3Object) putMultipart(ctx context.Context, first []byte, body io.Reader, buf []byte, contentType string) error {
+	_, resp, err := o.send(ctx, http.MethodPost, url.Values{"uploads": {""}}, nil, http.Header{"Content-Type": {contentType}})
+	if err != nil {
+		return err
+	}
+	var initiated struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.Unmarshal(resp, &initiated); err != nil || initiated.UploadID == "" {
+		return fmt.Errorf("no upload ID in the response to CreateMultipartUpload: %s", resp)
+	}
+	uploadID := initiated.UploadID
+
+	err = func() error {
+		var parts []completedPart
+		for part, n := first, 1; len(part) > 0; n++ {
+			if n > s3MaxParts {
+				return fmt.Errorf("object is larger than %d parts of %d MiB", s3MaxParts, s3PartSize>>20)
+			}
+			query := url.Values{"partNumber": {strconv.Itoa(n)}, "uploadId": {uploadID}}
+			header, _, err := o.send(ctx, http.MethodPut, query, part, nil)
+			if err != nil {
+				return fmt.Errorf("part %d: %w", n, err)
+			}
+			parts = append(parts, completedPart{PartNumber: n, ETag: header.Get("ETag")})
+			if len(part) < s3PartSize {
+				break
+			}
+			if part, err = readPart(body, buf); err != nil {
+				return err
+			}
+		}
+
+		complete, err := xml.Marshal(struct {
+			XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+			Parts   []completedPart `xml:"Part"`
+		}{Parts: parts})
+		if err != nil {
+			return err
+		}
+		_, resp, err := o.send(ctx, http.MethodPost, url.Values{"uploadId": {uploadID}}, complete, http.Header{"Content-Type": {"application/xml"}})
+		if err != nil {
+			return err
+		}
+		// CompleteMultipartUpload can fail after answering 200
+		if bytes.Contains(resp, []byte("<Error>")) {
+			return responseError(http.StatusOK, resp)
+		}
+		return nil
+	}()
+	if err != nil {
+		abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		_, _, _ = o.send(abortCtx, http.MethodDelete, url.Values{"uploadId": {uploadID}}, nil, nil)
+	}
+	return err
+}
+
+// s3ObjectURL addresses the object virtual-hosted style on AWS, and path
+// style on a custom endpoint (MinIO, LocalStack, ...) or when the bucket
+// name has dots, which breaks the TLS wildcard certificate
+func s3ObjectURL(bucket, key, region string) *url.URL {
+	endpoint := firstEnv("AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL")
+	var base *url.URL
+	objectPath := "/" + key
+	switch {
+	case endpoint != "":
+		base, _ = url.Parse(strings.TrimSuffix(endpoint, "/"))
+		if base == nil || base.Host == "" {
+			base = &url.URL{Scheme: "https", Host: strings.TrimSuffix(endpoint, "/")}
+		}
+		objectPath = "/" + bucket + objectPath
+	case strings.Contains(bucket, "."):
+		base = &url.URL{Scheme: "https", Host: "s3." + region + ".amazonaws.com"}
+		objectPath = "/" + bucket + objectPath
+	default:
+		base = &url.URL{Scheme: "https", Host: bucket + ".s3." + region + ".amazonaws.com"}
+	}
+	return &url.URL{
+		Scheme:  base.Scheme,
+		Host:    base.Host,
+		Path:    base.Path + objectPath,
+		RawPath: uriEncode(base.Path+objectPath, false),
+	}
+}
+
+// signV4 adds AWS Signature Version 4 headers to req, signing the host and
+// every header already set on it
+func signV4(req *http.Request, payloadHash string, creds awsCredentials, region string, t time.Time) {
+	t = t.UTC()
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		values := append([]string{}, q[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes everything but unreserved characters, and
+// slashes unless encodeSlash is set, as SigV4 requires
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// loadAWSCredentials reads the standard environment variables, then the
+// shared credentials file for AWS_PROFILE (or "default"), then asks the ECS
+// container credentials endpoint and the EC2 instance metadata service
+func loadAWSCredentials(ctx context.Context) (awsCredentials, error) {
+	creds := awsCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID != "" && creds.SecretAccessKey != "" {
+		return creds, nil
+	}
+
+	path := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if path == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, ".aws", "credentials")
+		}
+	}
+	section := readINISection(path, awsProfile())
+	creds = awsCredentials{
+		AccessKeyID:     section["aws_access_key_id"],
+		SecretAccessKey: section["aws_secret_access_key"],
+		SessionToken:    section["aws_session_token"],
+	}
+	if creds.AccessKeyID != "" && creds.SecretAccessKey != "" {
+		return creds, nil
+	}
+
+	if firstEnv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "AWS_CONTAINER_CREDENTIALS_FULL_URI") != "" {
+		return ecsCredentials(ctx)
+	}
+	if !strings.EqualFold(os.Getenv("AWS_EC2_METADATA_DISABLED"), "true") {
+		if creds, err := imdsCredentials(ctx); err == nil {
+			return creds, nil
+		}
+	}
+	return creds, errors.New("no AWS credentials: set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, configure ~/.aws/credentials, or run with an ECS task role or EC2 instance profile")
+}
+
+// ecsCredentialsHost serves container credentials on ECS; replaced in tests
+var ecsCredentialsHost = "http://169.254.170.2"
+
+// imdsEndpoint is the EC2 instance metadata service; replaced in tests
+var imdsEndpoint = "http://169.254.169.254"
+
+// roleCredentials are temporary credentials as served by the ECS container
+// endpoint and the EC2 instance metadata service
+type roleCredentials struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	Token           string `json:"Token"`
+}
+
+// ecsCredentials fetches the task role's credentials from the endpoint ECS
+// (or EKS Pod Identity, through the full URI) gives the container
+func ecsCredentials(ctx context.Context) (awsCredentials, error) {
+	endpoint := os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI")
+	if relative := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"); relative != "" {
+		endpoint = ecsCredentialsHost + relative
+	}
+	header := http.Header{}
+	token := os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN")
+	if file := os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE"); file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return awsCredentials{}, fmt.Errorf("failed to read the container authorization token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token != "" {
+		header.Set("Authorization", token)
+	}
+
+	data, err := metadataGet(ctx, endpoint, header)
+	if err != nil {
+		return awsCredentials{}, fmt.Errorf("failed to get ECS container credentials: %w", err)
+	}
+	return parseRoleCredentials(data)
+}
+
+// imdsCredentials fetches the instance profile's credentials from the EC2
+// instance metadata service, with an IMDSv2 session token
+func imdsCredentials(ctx context.Context) (awsCredentials, error) {
+	base := strings.TrimSuffix(firstEnv("AWS_EC2_METADATA_SERVICE_ENDPOINT"), "/")
+	if base == "" {
+		base = imdsEndpoint
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, base+"/latest/api/token", nil)
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	req.Header.Set("X-Aws-Ec2-Metadata-Token-Ttl-Seconds", "21600")
+	_, token, err := send(req, nil)
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	header := http.Header{"X-Aws-Ec2-Metadata-Token": {strings.TrimSpace(string(token))}}
+
+	rolesURL := base + "/latest/meta-data/iam/security-credentials/"
+	roles, err := metadataGet(ctx, rolesURL, header)
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	role, _, _ := strings.Cut(strings.TrimSpace(string(roles)), "\n")
+	if role == "" {
+		return awsCredentials{}, errors.New("the instance has no IAM role")
+	}
+	data, err := metadataGet(ctx, rolesURL+role, header)
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	return parseRoleCredentials(data)
+}
+
+// metadataGet reads a credentials or metadata endpoint
+func metadataGet(ctx context.Context, endpoint string, header http.Header) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	_, data, err := send(req, nil)
+	return data, err
+}
+
+func parseRoleCredentials(data []byte) (awsCredentials, error) {
+	var role roleCredentials
+	if err := json.Unmarshal(data, &role); err != nil {
+		return awsCredentials{}, fmt.Errorf("invalid credentials response: %w", err)
+	}
+	if role.AccessKeyID == "" || role.SecretAccessKey == "" {
+		return awsCredentials{}, errors.New("the credentials response has no access key")
+	}
+	return awsCredentials{AccessKeyID: role.AccessKeyID, SecretAccessKey: role.SecretAccessKey, SessionToken: role.Token}, nil
+}
+
+// awsRegion reads AWS_REGION, AWS_DEFAULT_REGION, then ~/.aws/config
+func awsRegion() string {
+	if region := firstEnv("AWS_REGION", "AWS_DEFAULT_REGION"); region != "" {
+		return region
+	}
+	path := os.Getenv("AWS_CONFIG_FILE")
+	if path == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, ".aws", "config")
+		}
+	}
+	profile := awsProfile()
+	section := readINISection(path, "profile "+profile)
+	if profile == "default" && section["region"] == "" {
+		section = readINISection(path, "default")
+	}
+	if region := section["region"]; region != "" {
+		return region
+	}
+	return "us-east-1"
+}
+
+func awsProfile() string {
+	if profile := os.Getenv("AWS_PROFILE"); profile != "" {
+		return profile
+	}
+	return "default"
+}
+
+// readINISection returns the key/value pairs of [name] in an INI file
+func readINISection(path, name string) map[string]string {
+	values := map[string]string{}
+	if path == "" {
+		return values
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return values
+	}
+	defer func() { _ = f.Close() }()
+
+	in := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			in = strings.TrimSpace(line[1:len(line)-1]) == name
+			continue
+		}
+		if k, v, ok := strings.Cut(line, "="); ok && in {
+			values[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return values
+}
+
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+package output
+
+import "reflect"
+
+// EnvelopeVersion is the version of the --envelope format. It changes only
+// when the envelope itself, or the shape of a kind, changes incompatibly.
+const EnvelopeVersion = "v1"
+
+// Envelope wraps a result with the kind and version of its shape: lists go
+// in Items, anything else in Item
+type Envelope struct {
+	APIVersion string `json:"api_version"`
+	Kind       string `json:"kind"`
+	Items      any    `json:"items,omitempty"`
+	Item       any    `json:"item,omitempty"`
+}
+
+// Wrap puts data in an envelope of the given kind
+func Wrap(kind string, data any) Envelope {
+	env := Envelope{APIVersion: EnvelopeVersion, Kind: kind}
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		// A nil list is still a list: print [] rather than null
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			data = []any{}
+		}
+		env.Items = data
+		return env
+	}
+	env.Item = data
+	return env
+}
+
+// EnvelopeFormatter wraps every result in an Envelope before printing it.
+// Errors are printed as they are.
+type EnvelopeFormatter struct {
+	Formatter
+	Kind string
+}
+
+func (f *EnvelopeFormatter) Print(data any) error {
+	return f.Formatter.Print(Wrap(f.Kind, data))
+}
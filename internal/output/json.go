@@ -2,6 +2,7 @@ package output
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -20,17 +21,38 @@ func (f *JSONFormatter) Print(data any) error {
 }
 
 func (f *JSONFormatter) PrintError(err error) {
+	enc := json.NewEncoder(os.Stderr)
+	if encErr := enc.Encode(errorObject(err)); encErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+	}
+}
+
+// errorObject describes err for machine-readable output
+func errorObject(err error) map[string]any {
 	// For API errors, include status code and message
 	if apiErr, ok := err.(*apierrors.APIError); ok && apiErr.Message != "" {
 		errObj := map[string]any{
 			"error":       apiErr.Message,
 			"status_code": apiErr.StatusCode,
 		}
-		enc := json.NewEncoder(os.Stderr)
-		if encErr := enc.Encode(errObj); encErr != nil {
-			fmt.Fprintf(os.Stderr, "Error %d: %s\n", apiErr.StatusCode, apiErr.Message)
+		if len(apiErr.Fields) > 0 {
+			fields := make([]map[string]any, len(apiErr.Fields))
+			for i, fe := range apiErr.Fields {
+				field := map[string]any{"path": fe.Path, "message": fe.Message}
+				if fe.Location != "" {
+					field["location"] = fe.Location
+				}
+				if fe.Type != "" {
+					field["type"] = fe.Type
+				}
+				if fe.Flag != "" {
+					field["flag"] = "--" + fe.Flag
+				}
+				fields[i] = field
+			}
+			errObj["fields"] = fields
 		}
-		return
+		return errObj
 	}
 
 	// For auth errors, include status code, reason, and message
@@ -42,16 +64,51 @@ func (f *JSONFormatter) PrintError(err error) {
 		if authErr.Message != "" {
 			errObj["message"] = authErr.Message
 		}
-		enc := json.NewEncoder(os.Stderr)
-		if encErr := enc.Encode(errObj); encErr != nil {
-			fmt.Fprintf(os.Stderr, "Error %d: %s\n", authErr.StatusCode, err.Error())
+		return errObj
+	}
+
+	// For offline errors, flag them so scripts can tell them apart
+	var offlineErr *apierrors.OfflineError
+	if errors.As(err, &offlineErr) {
+		return map[string]any{
+			"error":   offlineErr.Error(),
+			"offline": true,
 		}
-		return
 	}
 
-	errObj := map[string]string{"error": err.Error()}
-	enc := json.NewEncoder(os.Stderr)
-	if encErr := enc.Encode(errObj); encErr != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+	// For timeouts, say which limit ran out
+	var timeoutErr *apierrors.TimeoutError
+	if errors.As(err, &timeoutErr) {
+		errObj := map[string]any{
+			"error":   timeoutErr.Error(),
+			"timeout": timeoutErr.Source,
+		}
+		if timeoutErr.Setting != "" {
+			errObj["setting"] = timeoutErr.Setting
+		}
+		if timeoutErr.Limit > 0 {
+			errObj["limit_seconds"] = timeoutErr.Limit.Seconds()
+		}
+		if timeoutErr.StatusCode != 0 {
+			errObj["status_code"] = timeoutErr.StatusCode
+		}
+		return errObj
+	}
+
+	// For confirmations that could not be asked, say what needs confirming
+	var confirmErr *apierrors.ConfirmationRequiredError
+	if errors.As(err, &confirmErr) {
+		errObj := map[string]any{
+			"error":                 confirmErr.Error(),
+			"confirmation_required": true,
+			"action":                confirmErr.Action,
+			"resource":              confirmErr.Resource,
+		}
+		if confirmErr.ID != "" {
+			errObj["id"] = confirmErr.ID
+		}
+		return errObj
 	}
+
+	return map[string]any{"error": err.Error()}
 }
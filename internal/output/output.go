@@ -11,6 +11,7 @@ type Format string
 const (
 	FormatText Format = "text"
 	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
 )
 
 // Formatter interface for output formatting
@@ -28,6 +29,8 @@ func NewFormatter(format Format, w io.Writer) Formatter {
 	switch format {
 	case FormatJSON:
 		return &JSONFormatter{Writer: w}
+	case FormatYAML:
+		return &YAMLFormatter{Writer: w}
 	default:
 		return &TextFormatter{Writer: w}
 	}
@@ -8,6 +8,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
+
+	apierrors "github.com/nottelabs/notte-cli/internal/errors"
 )
 
 type testData struct {
@@ -232,6 +235,31 @@ func TestTextFormatter_Print_NestedStruct(t *testing.T) {
 	})
 }
 
+func TestTextFormatter_Print_EmbeddedStruct(t *testing.T) {
+	type Item struct {
+		ID string
+	}
+	type OwnedItem struct {
+		Owner string
+		Item
+	}
+
+	var buf bytes.Buffer
+	f := &TextFormatter{Writer: &buf, NoColor: true}
+
+	if err := f.Print(OwnedItem{Owner: "me", Item: Item{ID: "item_1"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "Item:") {
+		t.Errorf("expected embedded struct fields inline, got %q", output)
+	}
+	if !strings.Contains(output, "Owner:") || !strings.Contains(output, "ID:") || strings.Contains(output, "  ID:") {
+		t.Errorf("expected Owner and ID at the same level, got %q", output)
+	}
+}
+
 func TestTextFormatter_Print_PointerField(t *testing.T) {
 	type TestStruct struct {
 		Name  *string
@@ -410,6 +438,35 @@ func TestTextFormatter_PrintError(t *testing.T) {
 	}
 }
 
+func TestTextFormatter_PrintError_FieldErrors(t *testing.T) {
+	f := &TextFormatter{NoColor: true}
+
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	f.PrintError(&apierrors.APIError{
+		StatusCode: 422,
+		Code:       "Unprocessable Entity",
+		Message:    "--max-steps: too large; url: Field required",
+		Fields: []apierrors.FieldError{
+			{Location: "body", Path: "max_steps", Message: "too large", Flag: "max-steps"},
+			{Location: "body", Path: "url", Message: "Field required"},
+		},
+	})
+
+	_ = w.Close()
+	os.Stderr = oldStderr
+
+	var errBuf bytes.Buffer
+	_, _ = io.Copy(&errBuf, r)
+
+	want := "Error 422: Unprocessable Entity\n  --max-steps: too large\n  url: Field required\n"
+	if errBuf.String() != want {
+		t.Errorf("got %q, want %q", errBuf.String(), want)
+	}
+}
+
 func TestTextFormatter_PrintTable(t *testing.T) {
 	var buf bytes.Buffer
 	f := &TextFormatter{Writer: &buf, NoColor: true}
@@ -477,6 +534,101 @@ func TestJSONFormatter_PrintError(t *testing.T) {
 	}
 }
 
+func TestJSONFormatter_PrintError_FieldErrors(t *testing.T) {
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	f := &JSONFormatter{Writer: os.Stdout}
+	f.PrintError(&apierrors.APIError{
+		StatusCode: 422,
+		Message:    "--max-steps: too large",
+		Fields:     []apierrors.FieldError{{Location: "body", Path: "max_steps", Message: "too large", Type: "less_than", Flag: "max-steps"}},
+	})
+
+	_ = w.Close()
+	os.Stderr = oldStderr
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+
+	want := `"fields":[{"flag":"--max-steps","location":"body","message":"too large","path":"max_steps","type":"less_than"}]`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("expected the fields in the JSON error, got %q", buf.String())
+	}
+}
+
+func TestJSONFormatter_PrintError_Timeout(t *testing.T) {
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	f := &JSONFormatter{Writer: os.Stdout}
+	f.PrintError(fmt.Errorf("API request failed: %w", &apierrors.TimeoutError{
+		Source:  apierrors.TimeoutClient,
+		Setting: "request_timeout",
+		Limit:   5 * time.Minute,
+	}))
+
+	_ = w.Close()
+	os.Stderr = oldStderr
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+
+	output := buf.String()
+	for _, want := range []string{`"timeout":"client"`, `"setting":"request_timeout"`, `"limit_seconds":300`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %s in the JSON error, got %q", want, output)
+		}
+	}
+	if strings.Contains(output, "API request failed") {
+		t.Errorf("expected the wrapping to be dropped, got %q", output)
+	}
+}
+
+func TestJSONFormatter_PrintError_Offline(t *testing.T) {
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	f := &JSONFormatter{Writer: os.Stdout}
+	f.PrintError(fmt.Errorf("API request failed: %w", &apierrors.OfflineError{Host: "api.notte.cc"}))
+
+	_ = w.Close()
+	os.Stderr = oldStderr
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+
+	output := buf.String()
+	if !strings.Contains(output, `"offline":true`) || !strings.Contains(output, "cannot reach api.notte.cc") {
+		t.Errorf("expected offline JSON error, got %q", output)
+	}
+}
+
+func TestJSONFormatter_PrintError_ConfirmationRequired(t *testing.T) {
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	f := &JSONFormatter{Writer: os.Stdout}
+	f.PrintError(&apierrors.ConfirmationRequiredError{Action: "stop", Resource: "session", ID: "sess_123", Reason: "pass --yes to confirm"})
+
+	_ = w.Close()
+	os.Stderr = oldStderr
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+
+	output := buf.String()
+	for _, want := range []string{`"confirmation_required":true`, `"action":"stop"`, `"resource":"session"`, `"id":"sess_123"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %s in %q", want, output)
+		}
+	}
+}
+
 func TestNewFormatter(t *testing.T) {
 	tests := []struct {
 		format   Format
@@ -497,3 +649,232 @@ func TestNewFormatter(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateFormatter(t *testing.T) {
+	name := "ptr"
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{"struct field", "{{.Name}}", testData{Name: "test", Count: 42}, "test\n"},
+		{"list per item", "{{.Name}}:{{.Count}}", []testData{{Name: "one", Count: 1}, {Name: "two", Count: 2}}, "one:1\ntwo:2\n"},
+		{"map key", "{{.session_id}}", map[string]any{"session_id": "s1"}, "s1\n"},
+		{"pointer field", "{{.P}}", struct{ P *string }{&name}, "ptr\n"},
+		{"json", "{{json .}}", testData{Name: "x"}, `{"name":"x","count":0}` + "\n"},
+		{"join", `{{join "," .Tags}}`, map[string]any{"Tags": []*string{&name, &name}}, "ptr,ptr\n"},
+		{"upper", "{{upper .Name}}", testData{Name: "x"}, "X\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(tt.tmpl)
+			if err != nil {
+				t.Fatalf("ParseTemplate failed: %v", err)
+			}
+			var buf bytes.Buffer
+			f := &TemplateFormatter{Writer: &buf, Template: tmpl}
+			if err := f.Print(tt.data); err != nil {
+				t.Fatalf("Print failed: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateFormatter_Errors(t *testing.T) {
+	if _, err := ParseTemplate("{{.Name"); err == nil {
+		t.Error("expected a parse error")
+	}
+	tmpl, _ := ParseTemplate("{{.Missing}}")
+	f := &TemplateFormatter{Writer: io.Discard, Template: tmpl}
+	if err := f.Print(testData{}); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
+
+func TestEnvelopeFormatter(t *testing.T) {
+	var nilList []testData
+	tests := []struct {
+		name string
+		data any
+		want string
+	}{
+		{"list", []testData{{Name: "one", Count: 1}}, `{"api_version":"v1","kind":"Thing","items":[{"name":"one","count":1}]}`},
+		{"empty list", nilList, `{"api_version":"v1","kind":"Thing","items":[]}`},
+		{"object", testData{Name: "x"}, `{"api_version":"v1","kind":"Thing","item":{"name":"x","count":0}}`},
+		{"pointer to list", &[]string{"a"}, `{"api_version":"v1","kind":"Thing","items":["a"]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			f := &EnvelopeFormatter{Formatter: &JSONFormatter{Writer: &buf}, Kind: "Thing"}
+			if err := f.Print(tt.data); err != nil {
+				t.Fatalf("Print failed: %v", err)
+			}
+			if got := strings.TrimSpace(buf.String()); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{850 * time.Millisecond, "850ms"},
+		{45 * time.Second, "45s"},
+		{3 * time.Minute, "3m"},
+		{3*time.Minute + 20*time.Second + 400*time.Millisecond, "3m20s"},
+		{2*time.Hour + 15*time.Minute + 9*time.Second, "2h15m"},
+		{28 * time.Hour, "1d4h"},
+		{-90 * time.Second, "-1m30s"},
+	}
+	for _, tt := range tests {
+		if got := FormatDuration(tt.d); got != tt.want {
+			t.Errorf("FormatDuration(%s) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	now := time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{now.Add(-3*time.Minute - 10*time.Second), "2026-03-02 13:56:50 UTC (3m ago)"},
+		{now.Add(-26 * time.Hour), "2026-03-01 12:00:00 UTC (1d ago)"},
+		{now.Add(2 * time.Hour), "2026-03-02 16:00:00 UTC (in 2h)"},
+		{now, "2026-03-02 14:00:00 UTC (just now)"},
+		{time.Time{}, "-"},
+	}
+	for _, tt := range tests {
+		if got := FormatTime(tt.t, now, true); got != tt.want {
+			t.Errorf("FormatTime(%s) = %q, want %q", tt.t, got, tt.want)
+		}
+	}
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("no time zone database")
+	}
+	defer func(loc *time.Location) { time.Local = loc }(time.Local)
+	time.Local = paris
+	if got := FormatTime(now, now, false); got != "2026-03-02 15:00:00 CET (just now)" {
+		t.Errorf("local time = %q", got)
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		s    string
+		want time.Duration
+		ok   bool
+	}{
+		{"PT2H15M3.5S", 2*time.Hour + 15*time.Minute + 3500*time.Millisecond, true},
+		{"P1DT4H", 28 * time.Hour, true},
+		{"PT45S", 45 * time.Second, true},
+		{"P", 0, false},
+		{"PT", 0, false},
+		{"0:05:00", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseISODuration(tt.s)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseISODuration(%q) = %s, %v, want %s, %v", tt.s, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+type embeddedTime struct {
+	time.Time
+}
+
+func TestTextFormatter_Times(t *testing.T) {
+	now := time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+	closed := "2026-03-02T13:30:00"
+	duration := "PT2H15M"
+	data := struct {
+		CreatedAt embeddedTime
+		ClosedAt  *string
+		UpdatedAt string
+		Duration  *string
+		Timeout   time.Duration
+		Format    string
+	}{
+		CreatedAt: embeddedTime{now.Add(-3 * time.Minute)},
+		ClosedAt:  &closed,
+		UpdatedAt: "not a time",
+		Duration:  &duration,
+		Timeout:   90 * time.Second,
+		Format:    "2026-03-02T13:30:00Z",
+	}
+
+	var buf bytes.Buffer
+	f := &TextFormatter{Writer: &buf, NoColor: true, UTC: true, Now: func() time.Time { return now }}
+	if err := f.Print(data); err != nil {
+		t.Fatalf("Print failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"CreatedAt:  2026-03-02 13:57:00 UTC (3m ago)",
+		"ClosedAt:   2026-03-02 13:30:00 UTC (30m ago)",
+		"UpdatedAt:  not a time",
+		"Duration:   2h15m",
+		"Timeout:    1m30s",
+		"Format:     2026-03-02T13:30:00Z",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := f.Print(map[string]any{"started_at": now.Add(-time.Hour)}); err != nil {
+		t.Fatalf("Print failed: %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "2026-03-02 13:00:00 UTC (1h ago)") {
+		t.Errorf("map output = %q", got)
+	}
+}
+
+func TestYAMLFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	f := &YAMLFormatter{Writer: &buf}
+	data := struct {
+		Name   string         `json:"name"`
+		Count  int            `json:"count"`
+		Flag   string         `json:"flag"`
+		Tags   []string       `json:"tags"`
+		Nested map[string]any `json:"nested"`
+		Skip   *string        `json:"skip,omitempty"`
+	}{Name: "one", Count: 2, Flag: "true", Tags: []string{"a", "b: c"}, Nested: map[string]any{"k": nil}}
+	if err := f.Print(data); err != nil {
+		t.Fatalf("Print failed: %v", err)
+	}
+	if err := f.Print([]testData{}); err != nil {
+		t.Fatalf("Print failed: %v", err)
+	}
+
+	want := `---
+name: one
+count: 2
+flag: "true"
+tags:
+  - a
+  - 'b: c'
+nested:
+  k: null
+---
+[]
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,84 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// templateFuncs are the helpers available to --template, on top of the
+// text/template builtins
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join": func(sep string, v any) (string, error) {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return "", fmt.Errorf("join: expected a list, got %T", v)
+		}
+		parts := make([]string, rv.Len())
+		for i := range rv.Len() {
+			parts[i] = fmt.Sprint(indirect(rv.Index(i)))
+		}
+		return strings.Join(parts, sep), nil
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// ParseTemplate parses a --template string
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("output").Funcs(templateFuncs).Parse(text)
+}
+
+// TemplateFormatter renders data with a Go template, one line per result.
+// Lists are rendered one item at a time, like docker's --format.
+type TemplateFormatter struct {
+	Writer   io.Writer
+	Template *template.Template
+}
+
+func (f *TemplateFormatter) Print(data any) error {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		for i := range v.Len() {
+			if err := f.execute(v.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return f.execute(data)
+}
+
+func (f *TemplateFormatter) execute(data any) error {
+	if err := f.Template.Execute(f.Writer, data); err != nil {
+		return fmt.Errorf("template: %w", err)
+	}
+	_, err := fmt.Fprintln(f.Writer)
+	return err
+}
+
+// PrintError prints errors as text, since a template only describes results
+func (f *TemplateFormatter) PrintError(err error) {
+	(&TextFormatter{Writer: f.Writer, NoColor: true}).PrintError(err)
+}
+
+// indirect follows pointers so list items print as values, not addresses
+func indirect(v reflect.Value) any {
+	for (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || ((v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil()) {
+		return ""
+	}
+	return v.Interface()
+}
@@ -1,12 +1,14 @@
 package output
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"reflect"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/muesli/termenv"
 
@@ -17,6 +19,10 @@ import (
 type TextFormatter struct {
 	Writer  io.Writer
 	NoColor bool
+	// UTC prints timestamps in UTC rather than local time
+	UTC bool
+	// Now is the time timestamps are relative to, time.Now when nil
+	Now func() time.Time
 }
 
 var output = termenv.NewOutput(os.Stdout)
@@ -60,6 +66,13 @@ func (f *TextFormatter) printKeyValue(data any) error {
 	for _, key := range v.MapKeys() {
 		val := v.MapIndex(key)
 		label := f.colorize(fmt.Sprintf("%v:", key.Interface()), termenv.ANSICyan)
+		if val.Kind() == reflect.Interface && !val.IsNil() {
+			val = val.Elem()
+		}
+		if s, ok := f.humanValue(fmt.Sprint(key.Interface()), val); ok {
+			_, _ = fmt.Fprintf(w, "%s\t%s\n", label, s)
+			continue
+		}
 		_, _ = fmt.Fprintf(w, "%s\t%v\n", label, val.Interface())
 	}
 
@@ -91,6 +104,16 @@ func (f *TextFormatter) printStructWithIndent(data any, indent string) error {
 			}
 		}
 
+		// Print embedded structs' fields as if they were our own
+		if field.Anonymous && fieldValue.Kind() == reflect.Struct {
+			_ = w.Flush()
+			if err := f.printStructWithIndent(fieldValue.Interface(), indent); err != nil {
+				return err
+			}
+			w = tabwriter.NewWriter(f.Writer, 0, 0, 2, ' ', 0)
+			continue
+		}
+
 		label := f.colorize(indent+field.Name+":", termenv.ANSICyan)
 
 		// Handle pointer fields by dereferencing
@@ -98,6 +121,11 @@ func (f *TextFormatter) printStructWithIndent(data any, indent string) error {
 			fieldValue = fieldValue.Elem()
 		}
 
+		if s, ok := f.humanValue(field.Name, fieldValue); ok {
+			_, _ = fmt.Fprintf(w, "%s\t%s\n", label, s)
+			continue
+		}
+
 		// Handle nested structs recursively
 		if fieldValue.Kind() == reflect.Struct {
 			_, _ = fmt.Fprintln(w, label)
@@ -209,6 +237,14 @@ func (f *TextFormatter) PrintError(err error) {
 	// For API errors, display "Error <status>: <message>"
 	if apiErr, ok := err.(*apierrors.APIError); ok && apiErr.Message != "" {
 		errText := f.colorize(fmt.Sprintf("Error %d:", apiErr.StatusCode), termenv.ANSIRed)
+		// Validation errors get one line per field
+		if len(apiErr.Fields) > 1 {
+			fmt.Fprintf(os.Stderr, "%s %s\n", errText, apiErr.Code)
+			for _, fe := range apiErr.Fields {
+				fmt.Fprintf(os.Stderr, "  %s\n", fe)
+			}
+			return
+		}
 		fmt.Fprintf(os.Stderr, "%s %s\n", errText, apiErr.Message)
 		return
 	}
@@ -224,6 +260,16 @@ func (f *TextFormatter) PrintError(err error) {
 		return
 	}
 
+	// For offline errors and timeouts, drop the request URL noise wrapped
+	// around the cause
+	var offlineErr *apierrors.OfflineError
+	var timeoutErr *apierrors.TimeoutError
+	if errors.As(err, &offlineErr) {
+		err = offlineErr
+	} else if errors.As(err, &timeoutErr) {
+		err = timeoutErr
+	}
+
 	errText := f.colorize("Error:", termenv.ANSIRed)
 	fmt.Fprintf(os.Stderr, "%s %s\n", errText, err.Error())
 }
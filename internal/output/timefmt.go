@@ -0,0 +1,185 @@
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeLayout is how text output prints timestamps
+const TimeLayout = "2006-01-02 15:04:05 MST"
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+)
+
+// Layouts of timestamps the API sends as strings; those without a zone are UTC
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// FormatTime prints t in local time, or in UTC with utc, followed by how
+// long ago it was: "2026-03-02 14:03:05 CET (3m ago)"
+func FormatTime(t, now time.Time, utc bool) string {
+	if t.IsZero() {
+		return "-"
+	}
+	if utc {
+		t = t.UTC()
+	} else {
+		t = t.Local()
+	}
+	return fmt.Sprintf("%s (%s)", t.Format(TimeLayout), Ago(t, now))
+}
+
+// Ago says how long before now t was, to its largest unit: "3m ago", or
+// "in 2h" for times still to come
+func Ago(t, now time.Time) string {
+	d := now.Sub(t)
+	if d > -time.Second && d < time.Second {
+		return "just now"
+	}
+	if d < 0 {
+		return "in " + coarseDuration(-d)
+	}
+	return coarseDuration(d) + " ago"
+}
+
+var durationUnits = []struct {
+	size   time.Duration
+	suffix string
+}{
+	{24 * time.Hour, "d"},
+	{time.Hour, "h"},
+	{time.Minute, "m"},
+	{time.Second, "s"},
+}
+
+func coarseDuration(d time.Duration) string {
+	for _, u := range durationUnits {
+		if d >= u.size {
+			return fmt.Sprintf("%d%s", d/u.size, u.suffix)
+		}
+	}
+	return "0s"
+}
+
+// FormatDuration prints d to its two largest units: "2h15m", "3m20s",
+// "1d4h". Durations under a second keep their milliseconds.
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + FormatDuration(-d)
+	}
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	d = d.Truncate(time.Second)
+	for i, u := range durationUnits {
+		if d < u.size {
+			continue
+		}
+		s := fmt.Sprintf("%d%s", d/u.size, u.suffix)
+		if i+1 < len(durationUnits) {
+			next := durationUnits[i+1]
+			if rest := d % u.size / next.size; rest > 0 {
+				s += fmt.Sprintf("%d%s", rest, next.suffix)
+			}
+		}
+		return s
+	}
+	return "0s"
+}
+
+// ParseTime reads a timestamp the API sent as a string
+func ParseTime(s string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+var isoDuration = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseISODuration reads an ISO 8601 duration such as "PT2H15M3.5S", as
+// the API sends them
+func ParseISODuration(s string) (time.Duration, bool) {
+	m := isoDuration.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, false
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute} {
+		if m[i+1] != "" {
+			n, err := strconv.Atoi(m[i+1])
+			if err != nil {
+				return 0, false
+			}
+			d += time.Duration(n) * unit
+		}
+	}
+	if m[4] != "" {
+		secs, err := strconv.ParseFloat(m[4], 64)
+		if err != nil {
+			return 0, false
+		}
+		d += time.Duration(secs * float64(time.Second))
+	}
+	return d, true
+}
+
+// humanValue prints the timestamps and durations among text output's
+// fields: time.Time values and the structs embedding one, time.Duration
+// values, strings in fields named like "CreatedAt" or "created_at" and ISO
+// 8601 durations in fields named "Duration". ok is false for anything else.
+func (f *TextFormatter) humanValue(name string, v reflect.Value) (s string, ok bool) {
+	if t, ok := timeOf(v); ok {
+		return FormatTime(t, f.now(), f.UTC), true
+	}
+	if v.Type() == durationType {
+		return FormatDuration(time.Duration(v.Int())), true
+	}
+	if v.Kind() != reflect.String {
+		return "", false
+	}
+	switch {
+	case strings.HasSuffix(name, "At") || strings.HasSuffix(name, "_at"):
+		if t, ok := ParseTime(v.String()); ok {
+			return FormatTime(t, f.now(), f.UTC), true
+		}
+	case name == "Duration" || name == "duration":
+		if d, ok := ParseISODuration(v.String()); ok {
+			return FormatDuration(d), true
+		}
+	}
+	return "", false
+}
+
+// timeOf returns the time of a time.Time, or of a struct embedding one
+func timeOf(v reflect.Value) (time.Time, bool) {
+	if v.Type() == timeType {
+		return v.Interface().(time.Time), true
+	}
+	if v.Kind() == reflect.Struct && v.NumField() > 0 {
+		field := v.Type().Field(0)
+		if field.Anonymous && field.Type == timeType {
+			return v.Field(0).Interface().(time.Time), true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (f *TextFormatter) now() time.Time {
+	if f.Now != nil {
+		return f.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,62 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLFormatter outputs data as YAML. Fields are named and ordered as in
+// JSON output, and every document starts with "---" so that results
+// printed one after another, as streaming commands do, stay valid YAML.
+type YAMLFormatter struct {
+	Writer io.Writer
+}
+
+func (f *YAMLFormatter) Print(data any) error {
+	return writeYAML(f.Writer, data)
+}
+
+func (f *YAMLFormatter) PrintError(err error) {
+	if encErr := writeYAML(os.Stderr, errorObject(err)); encErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+	}
+}
+
+// writeYAML converts data through JSON, so its json tags and MarshalJSON
+// methods apply, and writes it as a YAML document
+func writeYAML(w io.Writer, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	// JSON is YAML, and decoding it to a node keeps the order of its keys
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+	plainStyle(&doc)
+
+	if _, err := io.WriteString(w, "---\n"); err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// plainStyle drops the JSON look of a decoded document (flow mappings,
+// quoted strings) so it prints in block style; strings that would read as
+// another type are still quoted
+func plainStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		plainStyle(c)
+	}
+}
@@ -0,0 +1,251 @@
+// Package release renders package-manager manifests (Homebrew, Scoop, nfpm)
+// for a published release so every distribution channel is generated from the
+// same version and checksums.
+package release
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+const (
+	ProjectName = "notte-cli"
+	BinaryName  = "notte"
+	Homepage    = "https://notte.cc"
+	Description = "Browser automation CLI for notte.cc"
+	License     = "MIT"
+	Maintainer  = "Notte Labs"
+	Repository  = "nottelabs/notte-cli"
+)
+
+// Info describes a release to generate manifests for
+type Info struct {
+	Version   string            // Without the leading "v"
+	Checksums map[string]string // Archive file name -> sha256, as in goreleaser's checksums.txt
+	DistDir   string            // goreleaser dist directory holding built binaries (for nfpm)
+}
+
+// Artifact is a downloadable archive for one platform
+type Artifact struct {
+	OS     string
+	Arch   string
+	URL    string
+	SHA256 string
+}
+
+// Archive returns the archive file name goreleaser produces for a platform
+func (i Info) Archive(goos, goarch string) string {
+	ext := "tar.gz"
+	if goos == "windows" {
+		ext = "zip"
+	}
+	return fmt.Sprintf("%s_%s_%s_%s.%s", ProjectName, i.Version, goos, goarch, ext)
+}
+
+// Artifact returns the download URL and checksum for a platform.
+// A missing checksum is an error: publishing a manifest without one is unsafe.
+func (i Info) Artifact(goos, goarch string) (Artifact, error) {
+	name := i.Archive(goos, goarch)
+	sum, ok := i.Checksums[name]
+	if !ok {
+		return Artifact{}, fmt.Errorf("no checksum for %s", name)
+	}
+	return Artifact{
+		OS:     goos,
+		Arch:   goarch,
+		URL:    fmt.Sprintf("https://github.com/%s/releases/download/v%s/%s", Repository, i.Version, name),
+		SHA256: sum,
+	}, nil
+}
+
+// ParseChecksums parses a goreleaser checksums.txt ("<sha256>  <file>" per line)
+func ParseChecksums(r io.Reader) (map[string]string, error) {
+	sums := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 || len(fields[0]) != 64 {
+			return nil, fmt.Errorf("checksums line %d: expected \"<sha256>  <file>\"", line)
+		}
+		sums[strings.TrimPrefix(fields[1], "*")] = fields[0]
+	}
+	return sums, scanner.Err()
+}
+
+type templateData struct {
+	Info
+	Project     string
+	Binary      string
+	Homepage    string
+	Description string
+	License     string
+	Maintainer  string
+	Arch        string
+	Artifacts   map[string]Artifact
+}
+
+func (i Info) data(platforms ...[2]string) (templateData, error) {
+	d := templateData{
+		Info:        i,
+		Project:     ProjectName,
+		Binary:      BinaryName,
+		Homepage:    Homepage,
+		Description: Description,
+		License:     License,
+		Maintainer:  Maintainer,
+		Artifacts:   map[string]Artifact{},
+	}
+	for _, p := range platforms {
+		a, err := i.Artifact(p[0], p[1])
+		if err != nil {
+			return d, err
+		}
+		d.Artifacts[p[0]+"_"+p[1]] = a
+	}
+	return d, nil
+}
+
+func render(tmpl *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var homebrewTemplate = template.Must(template.New("homebrew").Parse(`# typed: false
+# frozen_string_literal: true
+
+# This file was generated by notte dev package-manifests. DO NOT EDIT.
+class Notte < Formula
+  desc "{{.Description}}"
+  homepage "{{.Homepage}}"
+  version "{{.Version}}"
+  license "{{.License}}"
+
+  on_macos do
+    if Hardware::CPU.intel?
+      url "{{(index .Artifacts "darwin_amd64").URL}}"
+      sha256 "{{(index .Artifacts "darwin_amd64").SHA256}}"
+    end
+    if Hardware::CPU.arm?
+      url "{{(index .Artifacts "darwin_arm64").URL}}"
+      sha256 "{{(index .Artifacts "darwin_arm64").SHA256}}"
+    end
+  end
+
+  on_linux do
+    if Hardware::CPU.intel? && Hardware::CPU.is_64_bit?
+      url "{{(index .Artifacts "linux_amd64").URL}}"
+      sha256 "{{(index .Artifacts "linux_amd64").SHA256}}"
+    end
+    if Hardware::CPU.arm? && Hardware::CPU.is_64_bit?
+      url "{{(index .Artifacts "linux_arm64").URL}}"
+      sha256 "{{(index .Artifacts "linux_arm64").SHA256}}"
+    end
+  end
+
+  def install
+    bin.install "{{.Binary}}"
+    generate_completions_from_executable(bin/"{{.Binary}}", "completion")
+  end
+
+  test do
+    system "#{bin}/{{.Binary}}", "version"
+  end
+end
+`))
+
+// Homebrew renders a Homebrew formula
+func Homebrew(i Info) ([]byte, error) {
+	d, err := i.data([2]string{"darwin", "amd64"}, [2]string{"darwin", "arm64"}, [2]string{"linux", "amd64"}, [2]string{"linux", "arm64"})
+	if err != nil {
+		return nil, err
+	}
+	return render(homebrewTemplate, d)
+}
+
+var scoopTemplate = template.Must(template.New("scoop").Parse(`{
+  "version": "{{.Version}}",
+  "description": "{{.Description}}",
+  "homepage": "{{.Homepage}}",
+  "license": "{{.License}}",
+  "architecture": {
+    "64bit": {
+      "url": "{{(index .Artifacts "windows_amd64").URL}}",
+      "hash": "{{(index .Artifacts "windows_amd64").SHA256}}"
+    },
+    "arm64": {
+      "url": "{{(index .Artifacts "windows_arm64").URL}}",
+      "hash": "{{(index .Artifacts "windows_arm64").SHA256}}"
+    }
+  },
+  "bin": "{{.Binary}}.exe",
+  "checkver": {
+    "github": "https://github.com/` + Repository + `"
+  },
+  "autoupdate": {
+    "architecture": {
+      "64bit": {
+        "url": "https://github.com/` + Repository + `/releases/download/v$version/{{.Project}}_$version_windows_amd64.zip"
+      },
+      "arm64": {
+        "url": "https://github.com/` + Repository + `/releases/download/v$version/{{.Project}}_$version_windows_arm64.zip"
+      }
+    }
+  }
+}
+`))
+
+// Scoop renders a Scoop manifest
+func Scoop(i Info) ([]byte, error) {
+	d, err := i.data([2]string{"windows", "amd64"}, [2]string{"windows", "arm64"})
+	if err != nil {
+		return nil, err
+	}
+	return render(scoopTemplate, d)
+}
+
+var nfpmTemplate = template.Must(template.New("nfpm").Parse(`# Generated by notte dev package-manifests. DO NOT EDIT.
+# Build with: nfpm package --config nfpm-{{.Arch}}.yaml --packager deb (or rpm)
+name: {{.Binary}}
+arch: {{.Arch}}
+platform: linux
+version: {{.Version}}
+section: utils
+priority: optional
+maintainer: {{.Maintainer}}
+description: {{.Description}}
+vendor: Notte Labs
+homepage: {{.Homepage}}
+license: {{.License}}
+contents:
+  - src: {{.DistDir}}/notte-other_linux_{{.Arch}}_{{if eq .Arch "amd64"}}v1{{else}}v8.0{{end}}/{{.Binary}}
+    dst: /usr/bin/{{.Binary}}
+    file_info:
+      mode: 0755
+`))
+
+// NFPMArchitectures lists the Linux architectures packaged as deb/rpm
+var NFPMArchitectures = []string{"amd64", "arm64"}
+
+// NFPM renders an nfpm config producing deb and rpm packages for one architecture
+func NFPM(i Info, arch string) ([]byte, error) {
+	d, err := i.data()
+	if err != nil {
+		return nil, err
+	}
+	if d.DistDir == "" {
+		d.DistDir = "dist"
+	}
+	d.Arch = arch
+	return render(nfpmTemplate, d)
+}
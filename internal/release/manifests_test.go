@@ -0,0 +1,91 @@
+package release
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testInfo(t *testing.T) Info {
+	t.Helper()
+
+	var b strings.Builder
+	for _, p := range [][2]string{{"darwin", "amd64"}, {"darwin", "arm64"}, {"linux", "amd64"}, {"linux", "arm64"}, {"windows", "amd64"}, {"windows", "arm64"}} {
+		b.WriteString(strings.Repeat(string(p[1][0]), 64) + "  " + Info{Version: "1.2.3"}.Archive(p[0], p[1]) + "\n")
+	}
+	b.WriteString(strings.Repeat("f", 64) + "  notte-cli_1.2.3_checksums.sig\n")
+
+	sums, err := ParseChecksums(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("ParseChecksums() error = %v", err)
+	}
+	return Info{Version: "1.2.3", Checksums: sums}
+}
+
+func TestParseChecksums_Invalid(t *testing.T) {
+	if _, err := ParseChecksums(strings.NewReader("not-a-checksum file.tar.gz\n")); err == nil {
+		t.Error("expected error for malformed line")
+	}
+}
+
+func TestHomebrew(t *testing.T) {
+	out, err := Homebrew(testInfo(t))
+	if err != nil {
+		t.Fatalf("Homebrew() error = %v", err)
+	}
+	formula := string(out)
+	for _, want := range []string{
+		`version "1.2.3"`,
+		`url "https://github.com/nottelabs/notte-cli/releases/download/v1.2.3/notte-cli_1.2.3_darwin_arm64.tar.gz"`,
+		`sha256 "` + strings.Repeat("a", 64) + `"`,
+		`generate_completions_from_executable`,
+	} {
+		if !strings.Contains(formula, want) {
+			t.Errorf("formula missing %q", want)
+		}
+	}
+}
+
+func TestScoop(t *testing.T) {
+	out, err := Scoop(testInfo(t))
+	if err != nil {
+		t.Fatalf("Scoop() error = %v", err)
+	}
+
+	var manifest struct {
+		Version      string `json:"version"`
+		Bin          string `json:"bin"`
+		Architecture map[string]struct {
+			URL  string `json:"url"`
+			Hash string `json:"hash"`
+		} `json:"architecture"`
+	}
+	if err := json.Unmarshal(out, &manifest); err != nil {
+		t.Fatalf("Scoop manifest is not valid JSON: %v\n%s", err, out)
+	}
+	if manifest.Version != "1.2.3" || manifest.Bin != "notte.exe" {
+		t.Errorf("manifest = %+v", manifest)
+	}
+	if got := manifest.Architecture["64bit"].URL; !strings.HasSuffix(got, "notte-cli_1.2.3_windows_amd64.zip") {
+		t.Errorf("64bit url = %q", got)
+	}
+}
+
+func TestNFPM(t *testing.T) {
+	out, err := NFPM(testInfo(t), "arm64")
+	if err != nil {
+		t.Fatalf("NFPM() error = %v", err)
+	}
+	for _, want := range []string{"arch: arm64", "version: 1.2.3", "src: dist/notte-other_linux_arm64_v8.0/notte", "dst: /usr/bin/notte"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("nfpm config missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMissingChecksum(t *testing.T) {
+	info := Info{Version: "1.2.3", Checksums: map[string]string{}}
+	if _, err := Homebrew(info); err == nil {
+		t.Error("expected error when checksums are missing")
+	}
+}
@@ -0,0 +1,171 @@
+// Package skills installs agent skills (a directory with a SKILL.md) for AI
+// coding assistants from GitHub, any git URL or a local directory, without
+// Node.js tooling.
+package skills
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// UniversalDir is where skills are installed for any assistant that reads
+// the shared .agents directory; assistant-specific copies go next to it
+const UniversalDir = ".agents/skills"
+
+// maxArchiveSize bounds the downloaded archive
+const maxArchiveSize = 64 << 20
+
+// Assistant is an AI coding assistant that loads skills from a directory
+type Assistant struct {
+	// Name is the identifier used by --agent
+	Name string
+	// ConfigDir is the assistant's config directory, relative to the home
+	// or project directory; its presence means the assistant is in use
+	ConfigDir string
+}
+
+// SkillsDir returns the directory the assistant loads skills from, under root
+func (a Assistant) SkillsDir(root string) string {
+	return filepath.Join(root, a.ConfigDir, "skills")
+}
+
+// Assistants are the supported AI coding assistants
+var Assistants = []Assistant{
+	{Name: "claude-code", ConfigDir: ".claude"},
+	{Name: "cursor", ConfigDir: ".cursor"},
+}
+
+// AssistantNames lists the names of the supported assistants
+func AssistantNames() []string {
+	names := make([]string, len(Assistants))
+	for i, a := range Assistants {
+		names[i] = a.Name
+	}
+	return names
+}
+
+// Lookup returns the assistant called name
+func Lookup(name string) (Assistant, bool) {
+	for _, a := range Assistants {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Assistant{}, false
+}
+
+// Detect returns the assistants whose config directory exists in any of dirs
+func Detect(dirs ...string) []Assistant {
+	var found []Assistant
+	for _, a := range Assistants {
+		for _, dir := range dirs {
+			if info, err := os.Stat(filepath.Join(dir, a.ConfigDir)); err == nil && info.IsDir() {
+				found = append(found, a)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// ManifestFile records where an installed skill came from, next to its SKILL.md
+const ManifestFile = ".notte-skill.json"
+
+// Manifest describes an installed skill
+type Manifest struct {
+	Name        string    `json:"name"`
+	Source      string    `json:"source"`
+	Kind        string    `json:"kind"`
+	Ref         string    `json:"ref,omitempty"`
+	Subdir      string    `json:"subdir,omitempty"`
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// Install writes the skill and its manifest into dir/<name>, replacing any
+// previous install
+func Install(s *Skill, dir string) (string, error) {
+	target := filepath.Join(dir, s.Name)
+	if err := os.RemoveAll(target); err != nil {
+		return "", err
+	}
+	for rel, data := range s.Files {
+		p := filepath.Join(target, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(p, data, 0o644); err != nil {
+			return "", err
+		}
+	}
+
+	m := Manifest{
+		Name:        s.Name,
+		Source:      s.Source.String(),
+		Kind:        string(s.Source.Kind),
+		Ref:         s.Source.Ref,
+		Subdir:      s.Source.Subdir,
+		InstalledAt: time.Now().UTC(),
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(target, ManifestFile), append(data, '\n'), 0o644); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// ReadManifest returns the manifest of the skill installed in dir/name. It
+// is nil for skills installed by other tools, and when the recorded ref would
+// be read by git as an option.
+func ReadManifest(dir, name string) *Manifest {
+	data, err := os.ReadFile(filepath.Join(dir, name, ManifestFile))
+	if err != nil {
+		return nil
+	}
+	var m Manifest
+	if json.Unmarshal(data, &m) != nil || CheckRef(m.Ref) != nil {
+		return nil
+	}
+	return &m
+}
+
+// List returns the names of the skills installed in dir, sorted
+func List(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if Installed(dir, e.Name()) {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+// Installed reports whether dir/name holds an installed skill
+func Installed(dir, name string) bool {
+	_, err := os.Stat(filepath.Join(dir, name, "SKILL.md"))
+	return err == nil
+}
+
+// Remove deletes dir/name and reports whether there was anything to delete
+func Remove(dir, name string) (bool, error) {
+	if !ValidName(name) {
+		return false, fmt.Errorf("invalid skill name %q", name)
+	}
+	target := filepath.Join(dir, name)
+	if _, err := os.Lstat(target); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, os.RemoveAll(target)
+}
@@ -0,0 +1,367 @@
+package skills
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// tarball builds a gzipped tar archive of files, as GitHub serves them
+func tarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func serve(t *testing.T, status int, body []byte) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestLoad_GitHub(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = w.Write(tarball(t, map[string]string{
+			"notte-skills-v1/README.md":                                    "repo readme",
+			"notte-skills-v1/plugins/cli/skills/browser/SKILL.md":          "---\nname: notte-browser\ndescription: x\n---\n# skill",
+			"notte-skills-v1/plugins/cli/skills/browser/reference/page.md": "page ref",
+			"notte-skills-v1/plugins/cli/skills/other/SKILL.md":            "# other",
+		}))
+	}))
+	defer srv.Close()
+	orig := GitHubBaseURL
+	GitHubBaseURL = srv.URL
+	t.Cleanup(func() { GitHubBaseURL = orig })
+
+	src := Source{Kind: SourceGitHub, Location: "nottelabs/notte-skills", Ref: "v1", Subdir: "plugins/cli/skills/browser"}
+	s, err := Load(context.Background(), http.DefaultClient, src, "")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if gotPath != "/nottelabs/notte-skills/archive/v1.tar.gz" {
+		t.Errorf("downloaded %s", gotPath)
+	}
+	if s.Name != "notte-browser" || len(s.Files) != 2 || string(s.Files["reference/page.md"]) != "page ref" {
+		t.Errorf("unexpected skill: %s %v", s.Name, s.Files)
+	}
+
+	// Without a subdir, several skills need --skill to pick one
+	src.Subdir = ""
+	if _, err := Load(context.Background(), http.DefaultClient, src, ""); err == nil || !strings.Contains(err.Error(), "several skills (notte-browser, other)") {
+		t.Errorf("expected an ambiguity error, got %v", err)
+	}
+	s, err = Load(context.Background(), http.DefaultClient, src, "other")
+	if err != nil || s.Name != "other" || string(s.Files["SKILL.md"]) != "# other" {
+		t.Errorf("Load(want=other) = %v, %v", s, err)
+	}
+}
+
+func TestLoad_Local(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my-skill")
+	for name, body := range map[string]string{
+		"SKILL.md":       "# no front matter",
+		"scripts/run.sh": "echo hi",
+		".git/HEAD":      "ref: refs/heads/main",
+		"node_modules/x": "dep",
+	} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	src, err := ParseSource(dir)
+	if err != nil || src.Kind != SourceLocal {
+		t.Fatalf("ParseSource() = %v, %v", src, err)
+	}
+	s, err := Load(context.Background(), nil, src, "")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if s.Name != "my-skill" || len(s.Files) != 2 {
+		t.Errorf("unexpected skill: %s %v", s.Name, s.Files)
+	}
+
+	src.Ref = "v1"
+	if _, err := Load(context.Background(), nil, src, ""); err == nil {
+		t.Error("a ref should be rejected for a local source")
+	}
+}
+
+func TestLoad_Git(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		c := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		if out, err := c.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "--quiet")
+	if err := os.WriteFile(filepath.Join(repo, "SKILL.md"), []byte("---\nname: team-skill\n---\nv1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".")
+	git("commit", "--quiet", "-m", "v1")
+	git("tag", "v1")
+	if err := os.WriteFile(filepath.Join(repo, "SKILL.md"), []byte("---\nname: team-skill\n---\nv2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git("commit", "--quiet", "-am", "v2")
+
+	src, err := ParseSource("file://" + filepath.ToSlash(repo))
+	if err != nil || src.Kind != SourceGit {
+		t.Fatalf("ParseSource() = %v, %v", src, err)
+	}
+	s, err := Load(context.Background(), nil, src, "")
+	if err != nil || s.Name != "team-skill" || !strings.HasSuffix(string(s.Files["SKILL.md"]), "v2") {
+		t.Fatalf("Load() = %v, %v", s, err)
+	}
+
+	src.Ref = "v1"
+	s, err = Load(context.Background(), nil, src, "")
+	if err != nil || !strings.HasSuffix(string(s.Files["SKILL.md"]), "v1") {
+		t.Errorf("Load(ref=v1) = %v, %v", s, err)
+	}
+}
+
+func TestLoad_GitOptionInjection(t *testing.T) {
+	if _, err := exec.LookPath("true"); err != nil {
+		t.Skip("true and false not available")
+	}
+	var calls [][]string
+	orig := gitCommand
+	gitCommand = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls = append(calls, args)
+		return exec.CommandContext(ctx, "false")
+	}
+	t.Cleanup(func() { gitCommand = orig })
+
+	// A URL like this is an option to git clone unless "--" comes first
+	url := "--upload-pack=touch /tmp/pwned://x"
+	if _, err := ParseSource(url); err == nil {
+		t.Error("ParseSource() should reject a source starting with -")
+	}
+	_, _ = Load(context.Background(), nil, Source{Kind: SourceGit, Location: url}, "")
+	if len(calls) != 1 {
+		t.Fatalf("git calls = %v, want one clone", calls)
+	}
+	clone := calls[0]
+	if n := len(clone); n < 3 || clone[n-3] != "--" || clone[n-2] != url {
+		t.Errorf("git %v: the URL must follow --", clone)
+	}
+
+	// The ref is followed by "--" so git can't take it for a path either
+	calls = nil
+	gitCommand = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls = append(calls, args)
+		return exec.CommandContext(ctx, "true")
+	}
+	_, _ = Load(context.Background(), nil, Source{Kind: SourceGit, Location: "https://git.example.com/s.git", Ref: "v1"}, "")
+	if len(calls) != 2 || strings.Join(calls[1][len(calls[1])-2:], " ") != "v1 --" {
+		t.Errorf("git calls = %v, want checkout v1 --", calls)
+	}
+
+	calls = nil
+	for _, ref := range []string{"--orphan=x", "-b"} {
+		_, err := Load(context.Background(), nil, Source{Kind: SourceGit, Location: "https://git.example.com/s.git", Ref: ref}, "")
+		if err == nil || !strings.Contains(err.Error(), "invalid ref") {
+			t.Errorf("Load(ref=%q) error = %v, want invalid ref", ref, err)
+		}
+	}
+	if len(calls) != 0 {
+		t.Errorf("git ran for an invalid ref: %v", calls)
+	}
+	if _, err := ParseSource("https://github.com/acme/skills/tree/--orphan=x"); err == nil {
+		t.Error("ParseSource() should reject a ref starting with -")
+	}
+}
+
+func TestReadManifest_RejectsOptionRef(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "demo"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	manifest := `{"name":"demo","source":"https://git.example.com/demo.git","ref":"--upload-pack=touch /tmp/x"}`
+	if err := os.WriteFile(filepath.Join(dir, "demo", ManifestFile), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if m := ReadManifest(dir, "demo"); m != nil {
+		t.Errorf("ReadManifest() = %+v, want nil for a ref starting with -", m)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    []byte
+		wantErr string
+	}{
+		{"http error", http.StatusNotFound, nil, "HTTP 404"},
+		{"not gzip", http.StatusOK, []byte("<html>"), "failed to read"},
+		{"no skill", http.StatusOK, tarball(t, map[string]string{"repo-main/README.md": "x"}), "no SKILL.md found"},
+		{"unsafe path", http.StatusOK, tarball(t, map[string]string{"repo-main/../../etc/SKILL.md": "x"}), "unsafe path"},
+		{"bad name", http.StatusOK, tarball(t, map[string]string{"repo-main/SKILL.md": "---\nname: ../up\n---"}), "invalid skill name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := GitHubBaseURL
+			GitHubBaseURL = serve(t, tt.status, tt.body)
+			t.Cleanup(func() { GitHubBaseURL = orig })
+
+			_, err := Load(context.Background(), http.DefaultClient, Source{Kind: SourceGitHub, Location: "o/r"}, "")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseSource(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Source
+	}{
+		{"nottelabs/notte-skills", Source{Kind: SourceGitHub, Location: "nottelabs/notte-skills"}},
+		{"https://github.com/acme/skills.git", Source{Kind: SourceGitHub, Location: "acme/skills"}},
+		{"https://github.com/acme/skills/tree/v2/skills/deploy", Source{Kind: SourceGitHub, Location: "acme/skills", Ref: "v2", Subdir: "skills/deploy"}},
+		{"https://gitlab.com/acme/skills.git", Source{Kind: SourceGit, Location: "https://gitlab.com/acme/skills.git"}},
+		{"git@github.com:acme/private.git", Source{Kind: SourceGit, Location: "git@github.com:acme/private.git"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseSource(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseSource(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"./does-not-exist", "not a source"} {
+		if _, err := ParseSource(bad); err == nil {
+			t.Errorf("ParseSource(%q) should fail", bad)
+		}
+	}
+}
+
+func TestDefaultName(t *testing.T) {
+	if got := defaultName(Source{Kind: SourceGit, Location: "git@host:acme/team-skill.git"}, ""); got != "team-skill" {
+		t.Errorf("defaultName() = %q, want team-skill", got)
+	}
+}
+
+func TestInstallAndRemove(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".claude", "skills")
+	src := Source{Kind: SourceGitHub, Location: "acme/skills", Ref: "v2"}
+
+	// A file from a previous version must not survive an upgrade
+	if _, err := Install(&Skill{Name: "browser", Files: map[string][]byte{"SKILL.md": []byte("v1"), "old.md": []byte("old")}, Source: src}, dir); err != nil {
+		t.Fatal(err)
+	}
+	path, err := Install(&Skill{Name: "browser", Files: map[string][]byte{"SKILL.md": []byte("v2"), "ref/a.md": []byte("a")}, Source: src}, dir)
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if path != filepath.Join(dir, "browser") {
+		t.Errorf("Install() path = %s", path)
+	}
+	if data, _ := os.ReadFile(filepath.Join(path, "SKILL.md")); string(data) != "v2" {
+		t.Errorf("SKILL.md = %q, want v2", data)
+	}
+	if _, err := os.Stat(filepath.Join(path, "old.md")); !os.IsNotExist(err) {
+		t.Error("old.md should have been removed by the reinstall")
+	}
+	if m := ReadManifest(dir, "browser"); m == nil || m.Source != "github.com/acme/skills" || m.Ref != "v2" || m.Kind != "github" {
+		t.Errorf("manifest = %+v", m)
+	}
+	if names := List(dir); len(names) != 1 || names[0] != "browser" {
+		t.Errorf("List() = %v", names)
+	}
+
+	removed, err := Remove(dir, "browser")
+	if err != nil || !removed {
+		t.Fatalf("Remove() = %v, %v", removed, err)
+	}
+	if Installed(dir, "browser") {
+		t.Error("Installed() = true after Remove")
+	}
+	if removed, err := Remove(dir, "browser"); err != nil || removed {
+		t.Errorf("second Remove() = %v, %v; want false, nil", removed, err)
+	}
+
+	// "." would be the skills directory itself
+	if _, err := Remove(dir, "."); err == nil {
+		t.Error(`Remove(".") should fail`)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("skills directory was removed: %v", err)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	home, project := t.TempDir(), t.TempDir()
+	if err := os.Mkdir(filepath.Join(home, ".claude"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// A file named like a config dir doesn't count
+	if err := os.WriteFile(filepath.Join(home, ".cursor"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	found := Detect(home, project)
+	if len(found) != 1 || found[0].Name != "claude-code" {
+		t.Errorf("Detect() = %v, want [claude-code]", found)
+	}
+
+	if err := os.Mkdir(filepath.Join(project, ".cursor"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if found := Detect(home, project); len(found) != 2 {
+		t.Errorf("Detect() = %v, want both assistants", found)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	a, ok := Lookup("cursor")
+	if !ok || a.SkillsDir("/p") != filepath.Join("/p", ".cursor", "skills") {
+		t.Errorf("Lookup(cursor) = %v, %v", a, ok)
+	}
+	if _, ok := Lookup("emacs"); ok {
+		t.Error("Lookup(emacs) should fail")
+	}
+}
@@ -0,0 +1,401 @@
+package skills
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// GitHubBaseURL is where GitHub sources are downloaded from (replaced in tests)
+var GitHubBaseURL = "https://github.com"
+
+// gitCommand builds git processes for non-GitHub git sources (replaced in tests)
+var gitCommand = exec.CommandContext
+
+// SourceKind says where a skill comes from
+type SourceKind string
+
+const (
+	SourceLocal  SourceKind = "local"
+	SourceGitHub SourceKind = "github"
+	SourceGit    SourceKind = "git"
+)
+
+// Source is where a skill is installed from
+type Source struct {
+	Kind SourceKind
+	// Location is a directory for local sources, owner/repo for GitHub and
+	// the clone URL for other git sources
+	Location string
+	// Ref is the branch, tag or commit to install (default: the default branch)
+	Ref string
+	// Subdir is the skill directory inside the source; when empty, the skill
+	// is found by looking for SKILL.md
+	Subdir string
+}
+
+// String identifies the source in manifests and messages
+func (s Source) String() string {
+	if s.Kind == SourceGitHub {
+		return "github.com/" + s.Location
+	}
+	return s.Location
+}
+
+var (
+	githubRepo = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+	githubURL  = regexp.MustCompile(`^https://github\.com/([A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+?)(?:\.git)?(?:/tree/([^/]+)(?:/(.+))?)?/?$`)
+	skillName  = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+)
+
+// ValidName reports whether name can name a skill directory. It can't
+// start with a dot, so "." and ".." are rejected.
+func ValidName(name string) bool {
+	return skillName.MatchString(name)
+}
+
+// CheckRef rejects refs git would read as an option
+func CheckRef(ref string) error {
+	if strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid ref %q: refs can't start with -", ref)
+	}
+	return nil
+}
+
+// ParseSource recognizes a local directory, a GitHub URL or owner/repo
+// shorthand (github.com/owner/repo/tree/ref/path selects a ref and
+// directory), or any other URL git can clone
+func ParseSource(s string) (Source, error) {
+	if strings.HasPrefix(s, "-") {
+		return Source{}, fmt.Errorf("invalid skill source %q: sources can't start with -", s)
+	}
+	switch {
+	case strings.HasPrefix(s, ".") || filepath.IsAbs(s) || strings.HasPrefix(s, "~"):
+		return localSource(s)
+	case githubURL.MatchString(s):
+		m := githubURL.FindStringSubmatch(s)
+		if err := CheckRef(m[2]); err != nil {
+			return Source{}, err
+		}
+		return Source{Kind: SourceGitHub, Location: m[1], Ref: m[2], Subdir: m[3]}, nil
+	case strings.Contains(s, "://") || strings.HasPrefix(s, "git@"):
+		return Source{Kind: SourceGit, Location: s}, nil
+	}
+	if info, err := os.Stat(s); err == nil && info.IsDir() {
+		return localSource(s)
+	}
+	if githubRepo.MatchString(s) {
+		return Source{Kind: SourceGitHub, Location: s}, nil
+	}
+	return Source{}, fmt.Errorf("unrecognized skill source %q: expected a directory, a git URL or owner/repo", s)
+}
+
+func localSource(s string) (Source, error) {
+	if rest, ok := strings.CutPrefix(s, "~"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return Source{}, err
+		}
+		s = home + rest
+	}
+	abs, err := filepath.Abs(s)
+	if err != nil {
+		return Source{}, err
+	}
+	if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+		return Source{}, fmt.Errorf("skill directory %s not found", s)
+	}
+	return Source{Kind: SourceLocal, Location: abs}, nil
+}
+
+// Skill is a skill ready to be installed
+type Skill struct {
+	Name string
+	// Files maps paths relative to the skill directory to their content
+	Files  map[string][]byte
+	Source Source
+}
+
+// Load reads the skill from src. When src holds several skills and has no
+// Subdir, want names the one to load.
+func Load(ctx context.Context, client *http.Client, src Source, want string) (*Skill, error) {
+	if err := CheckRef(src.Ref); err != nil {
+		return nil, err
+	}
+	var tree map[string][]byte
+	var err error
+	switch src.Kind {
+	case SourceLocal:
+		if src.Ref != "" {
+			return nil, errors.New("a ref can't be used with a local skill directory")
+		}
+		tree, err = readDir(src.Location)
+	case SourceGitHub:
+		ref := src.Ref
+		if ref == "" {
+			ref = "HEAD"
+		}
+		tree, err = fetchArchive(ctx, client, fmt.Sprintf("%s/%s/archive/%s.tar.gz", GitHubBaseURL, src.Location, ref))
+	case SourceGit:
+		tree, err = cloneGit(ctx, src.Location, src.Ref)
+	default:
+		err = fmt.Errorf("unsupported source kind %q", src.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := findSkill(tree, src, want)
+	if err != nil {
+		return nil, err
+	}
+	files := subtree(tree, dir)
+
+	name := frontmatterName(files["SKILL.md"])
+	if name == "" {
+		name = defaultName(src, dir)
+	}
+	if !ValidName(name) {
+		return nil, fmt.Errorf("invalid skill name %q in %s", name, src)
+	}
+	return &Skill{Name: name, Files: files, Source: src}, nil
+}
+
+// findSkill returns the directory of the skill to load within tree
+func findSkill(tree map[string][]byte, src Source, want string) (string, error) {
+	if src.Subdir != "" {
+		dir := strings.Trim(path.Clean(src.Subdir), "/")
+		if _, ok := tree[dir+"/SKILL.md"]; !ok {
+			return "", fmt.Errorf("no SKILL.md found under %s in %s", src.Subdir, src)
+		}
+		return dir, nil
+	}
+	if _, ok := tree["SKILL.md"]; ok {
+		return "", nil
+	}
+
+	var dirs, names []string
+	for p, data := range tree {
+		if path.Base(p) != "SKILL.md" {
+			continue
+		}
+		dir := path.Dir(p)
+		name := frontmatterName(data)
+		if name == "" {
+			name = path.Base(dir)
+		}
+		if want != "" && name == want {
+			return dir, nil
+		}
+		dirs, names = append(dirs, dir), append(names, name)
+	}
+	switch {
+	case len(dirs) == 0:
+		return "", fmt.Errorf("no SKILL.md found in %s", src)
+	case want != "":
+		return "", fmt.Errorf("skill %q not found in %s (found: %s)", want, src, strings.Join(sorted(names), ", "))
+	case len(dirs) > 1:
+		return "", fmt.Errorf("%s holds several skills (%s): pick one with --skill", src, strings.Join(sorted(names), ", "))
+	}
+	return dirs[0], nil
+}
+
+func sorted(s []string) []string {
+	slices.Sort(s)
+	return s
+}
+
+// subtree returns the files under dir ("" for all), relative to it
+func subtree(tree map[string][]byte, dir string) map[string][]byte {
+	if dir == "" || dir == "." {
+		return tree
+	}
+	files := map[string][]byte{}
+	for p, data := range tree {
+		if rel, ok := strings.CutPrefix(p, dir+"/"); ok {
+			files[rel] = data
+		}
+	}
+	return files
+}
+
+// defaultName names a skill without a name in its SKILL.md after its
+// directory, or after the source itself when the skill is at the root
+func defaultName(src Source, dir string) string {
+	if dir != "" && dir != "." {
+		return path.Base(dir)
+	}
+	base := strings.TrimSuffix(path.Base(filepath.ToSlash(src.Location)), ".git")
+	if i := strings.LastIndexAny(base, ":/"); i >= 0 {
+		base = base[i+1:]
+	}
+	return base
+}
+
+// frontmatterName returns the name: field of a SKILL.md YAML front matter
+func frontmatterName(skillMD []byte) string {
+	sc := bufio.NewScanner(bytes.NewReader(skillMD))
+	if !sc.Scan() || strings.TrimSpace(sc.Text()) != "---" {
+		return ""
+	}
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "---" {
+			break
+		}
+		if v, ok := strings.CutPrefix(line, "name:"); ok {
+			return strings.Trim(strings.TrimSpace(v), `"'`)
+		}
+	}
+	return ""
+}
+
+// readDir reads the files under dir, skipping .git
+func readDir(dir string) (map[string][]byte, error) {
+	tree := map[string][]byte{}
+	var size int64
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" || d.Name() == "node_modules" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if size += int64(len(data)); size > maxArchiveSize {
+			return fmt.Errorf("%s is larger than %d MB", dir, maxArchiveSize>>20)
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		tree[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	return tree, err
+}
+
+// cloneGit clones url at ref into a temporary directory and reads it
+func cloneGit(ctx context.Context, url, ref string) (map[string][]byte, error) {
+	tmp, err := os.MkdirTemp("", "notte-skill-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	// "--" keeps git from reading a URL starting with - as an option
+	clone := []string{"clone", "--quiet", "--", url, tmp}
+	if ref == "" {
+		clone = []string{"clone", "--quiet", "--depth", "1", "--", url, tmp}
+	}
+	if err := runGit(ctx, clone...); err != nil {
+		return nil, err
+	}
+	if ref != "" {
+		if err := runGit(ctx, "-C", tmp, "checkout", "--quiet", ref, "--"); err != nil {
+			return nil, err
+		}
+	}
+	return readDir(tmp)
+}
+
+func runGit(ctx context.Context, args ...string) error {
+	var stderr bytes.Buffer
+	git := gitCommand(ctx, "git", args...)
+	git.Stderr = &stderr
+	if err := git.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return errors.New("git is required to install skills from this URL; use a GitHub URL or a local directory instead")
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("git %s failed: %s", args[0], msg)
+		}
+		return fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return nil
+}
+
+// fetchArchive downloads a gzipped tarball (as served by GitHub) and returns
+// its files without the top-level "repo-ref/" directory
+func fetchArchive(ctx context.Context, client *http.Client, archiveURL string) (map[string][]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "notte-cli")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", archiveURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: HTTP %d", archiveURL, resp.StatusCode)
+	}
+
+	tree, err := extract(io.LimitReader(resp.Body, maxArchiveSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", archiveURL, err)
+	}
+	return tree, nil
+}
+
+func extract(r io.Reader) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = gz.Close() }()
+
+	tree := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return tree, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Drop the top-level "repo-ref/" directory
+		top, name, ok := strings.Cut(path.Clean(hdr.Name), "/")
+		if !ok {
+			continue
+		}
+		if top == ".." || !filepath.IsLocal(filepath.FromSlash(name)) {
+			return nil, fmt.Errorf("unsafe path %q in archive", hdr.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		tree[name] = data
+	}
+}
@@ -26,12 +26,17 @@ func SetupTestEnv(t *testing.T) *TestEnv {
 		MockStore: NewMockKeyring(),
 	}
 
-	// Clear auth-related env vars
-	for _, key := range []string{"NOTTE_API_KEY", "NOTTE_API_URL"} {
+	// Clear auth-related env vars, the ones that turn off prompts in CI and
+	// the ones that pick the language of messages
+	for _, key := range []string{"NOTTE_API_KEY", "NOTTE_API_URL", "NOTTE_NONINTERACTIVE", "CI", "LC_ALL", "LC_MESSAGES", "LANG"} {
 		env.origEnv[key] = os.Getenv(key)
 		_ = os.Unsetenv(key)
 	}
 
+	// Keep config files written by commands (current session, history,
+	// logs) out of the real config directory
+	env.SetEnv("NOTTE_CONFIG_DIR", env.TempDir)
+
 	t.Cleanup(func() {
 		for key, val := range env.origEnv {
 			if val != "" {
@@ -0,0 +1,118 @@
+// Package timing records where a single CLI invocation spends its time:
+// credential lookup, API requests, retry backoff and output formatting.
+//
+// All Recorder methods are safe to call on a nil *Recorder, so callers can
+// instrument code unconditionally and only pay for it when timings are on.
+package timing
+
+import (
+	"sync"
+	"time"
+)
+
+// Phase names a part of a command's execution
+type Phase string
+
+const (
+	PhaseAuth    Phase = "auth"    // API key resolution (env, keyring, config)
+	PhaseRequest Phase = "request" // time on the wire, per attempt
+	PhaseRetry   Phase = "retry"   // backoff waits between attempts
+	PhaseFormat  Phase = "format"  // rendering output
+)
+
+// Phases lists the phases in reporting order
+var Phases = []Phase{PhaseAuth, PhaseRequest, PhaseRetry, PhaseFormat}
+
+// Total is the accumulated time and number of occurrences of a phase
+type Total struct {
+	Duration time.Duration
+	Count    int
+}
+
+// Call is one logical API call, including all of its retries
+type Call struct {
+	Method   string
+	Path     string
+	Duration time.Duration
+}
+
+// Recorder accumulates timings for one invocation
+type Recorder struct {
+	mu     sync.Mutex
+	totals map[Phase]Total
+	calls  []Call
+}
+
+// New returns an empty Recorder
+func New() *Recorder {
+	return &Recorder{totals: make(map[Phase]Total)}
+}
+
+// Add records d against phase p
+func (r *Recorder) Add(p Phase, d time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t := r.totals[p]
+	t.Duration += d
+	t.Count++
+	r.totals[p] = t
+}
+
+// Start begins timing phase p; call the returned func to stop.
+//
+//	defer rec.Start(timing.PhaseAuth)()
+func (r *Recorder) Start(p Phase) func() {
+	if r == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() { r.Add(p, time.Since(start)) }
+}
+
+// AddCall records a completed API call
+func (r *Recorder) AddCall(c Call) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, c)
+}
+
+// Total returns the accumulated total for phase p
+func (r *Recorder) Total(p Phase) Total {
+	if r == nil {
+		return Total{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.totals[p]
+}
+
+// Calls returns the recorded API calls in order
+func (r *Recorder) Calls() []Call {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// SlowCalls returns the calls that took longer than budget. A zero budget
+// disables the check.
+func (r *Recorder) SlowCalls(budget time.Duration) []Call {
+	if budget <= 0 {
+		return nil
+	}
+	var slow []Call
+	for _, c := range r.Calls() {
+		if c.Duration > budget {
+			slow = append(slow, c)
+		}
+	}
+	return slow
+}
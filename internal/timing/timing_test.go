@@ -0,0 +1,47 @@
+package timing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecorder_NilSafe(t *testing.T) {
+	var r *Recorder
+	r.Add(PhaseAuth, time.Second)
+	r.Start(PhaseFormat)()
+	r.AddCall(Call{Method: "GET", Path: "/"})
+	if got := r.Total(PhaseAuth); got != (Total{}) {
+		t.Errorf("Total() on nil = %+v", got)
+	}
+	if r.Calls() != nil || r.SlowCalls(time.Millisecond) != nil {
+		t.Error("expected no calls on nil recorder")
+	}
+}
+
+func TestRecorder_Totals(t *testing.T) {
+	r := New()
+	r.Add(PhaseRequest, 100*time.Millisecond)
+	r.Add(PhaseRequest, 50*time.Millisecond)
+
+	got := r.Total(PhaseRequest)
+	if got.Duration != 150*time.Millisecond || got.Count != 2 {
+		t.Errorf("Total(request) = %+v", got)
+	}
+	if got := r.Total(PhaseRetry); got.Count != 0 {
+		t.Errorf("Total(retry) = %+v, want zero", got)
+	}
+}
+
+func TestRecorder_SlowCalls(t *testing.T) {
+	r := New()
+	r.AddCall(Call{Method: "GET", Path: "/fast", Duration: 100 * time.Millisecond})
+	r.AddCall(Call{Method: "POST", Path: "/slow", Duration: 3 * time.Second})
+
+	slow := r.SlowCalls(time.Second)
+	if len(slow) != 1 || slow[0].Path != "/slow" {
+		t.Errorf("SlowCalls(1s) = %+v", slow)
+	}
+	if got := r.SlowCalls(0); got != nil {
+		t.Errorf("SlowCalls(0) = %+v, want nil", got)
+	}
+}
@@ -33,12 +33,12 @@ type Checker struct {
 }
 
 // NewChecker creates a Checker. Returns nil if version is "dev" or
-// NOTTE_NO_UPDATE_CHECK is set.
+// NOTTE_NO_UPDATE_CHECK or NOTTE_OFFLINE is set.
 func NewChecker(currentVersion string) *Checker {
 	if currentVersion == "dev" {
 		return nil
 	}
-	if os.Getenv(config.EnvNoUpdateCheck) != "" {
+	if os.Getenv(config.EnvNoUpdateCheck) != "" || os.Getenv(config.EnvOffline) != "" {
 		return nil
 	}
 
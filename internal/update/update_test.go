@@ -21,9 +21,18 @@ func TestNewChecker_EnvDisabled(t *testing.T) {
 	}
 }
 
+func TestNewChecker_Offline(t *testing.T) {
+	t.Setenv("NOTTE_OFFLINE", "1")
+	checker := NewChecker("0.0.10")
+	if checker != nil {
+		t.Fatal("expected nil checker when NOTTE_OFFLINE is set")
+	}
+}
+
 func TestNewChecker_ValidVersion(t *testing.T) {
 	// Set to empty string so the check is not disabled
 	t.Setenv("NOTTE_NO_UPDATE_CHECK", "")
+	t.Setenv("NOTTE_OFFLINE", "")
 
 	checker := NewChecker("0.0.10")
 	if checker == nil {
@@ -87,10 +87,12 @@ func OutputFormat(s string) error {
 	valid := map[string]bool{
 		"text": true,
 		"json": true,
+		"yaml": true,
+		"csv":  true,
 	}
 
 	if !valid[s] {
-		return fmt.Errorf("invalid output format: expected text|json, got %q", s)
+		return fmt.Errorf("invalid output format: expected text|json|yaml|csv, got %q", s)
 	}
 
 	return nil
@@ -105,6 +107,7 @@ func NonEmpty(s, name string) error {
 }
 
 var (
+	uuidPattern       = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
 	sessionIDPattern  = regexp.MustCompile(`^sess_[a-zA-Z0-9]{1,64}$`)
 	agentIDPattern    = regexp.MustCompile(`^agent_[a-zA-Z0-9]{1,64}$`)
 	workflowIDPattern = regexp.MustCompile(`^wf_[a-zA-Z0-9]{1,64}$`)
@@ -112,57 +115,51 @@ var (
 	personaIDPattern  = regexp.MustCompile(`^persona_[a-zA-Z0-9]{1,64}$`)
 )
 
-// SessionID validates that a string is a valid Notte session ID
-func SessionID(s string) error {
-	if s == "" {
-		return fmt.Errorf("session ID cannot be empty")
-	}
-	if !sessionIDPattern.MatchString(s) {
-		return fmt.Errorf("invalid session ID: expected sess_<alphanumeric 1-64 chars>, got %q", s)
+// exampleUUID is shown in error messages as the canonical ID format
+const exampleUUID = "27ac8eea-f7ab-4a68-a8c5-2e0a7d3c9d51"
+
+// UUID validates that a string is a canonical UUID
+func UUID(s string) error {
+	if !uuidPattern.MatchString(s) {
+		return fmt.Errorf("invalid UUID: expected %s format, got %q", exampleUUID, s)
 	}
 	return nil
 }
 
-// AgentID validates that a string is a valid Notte agent ID
-func AgentID(s string) error {
+// resourceID validates an ID that is either a bare UUID (what the API returns)
+// or a legacy prefixed ID, and points the user at the list command on failure
+func resourceID(s, kind, prefix, listCmd string, pattern *regexp.Regexp) error {
 	if s == "" {
-		return fmt.Errorf("agent ID cannot be empty")
+		return fmt.Errorf("%s ID cannot be empty", kind)
 	}
-	if !agentIDPattern.MatchString(s) {
-		return fmt.Errorf("invalid agent ID: expected agent_<alphanumeric 1-64 chars>, got %q", s)
+	if uuidPattern.MatchString(s) || pattern.MatchString(s) {
+		return nil
 	}
-	return nil
+	return fmt.Errorf("invalid %s ID %q: expected a UUID (e.g. %s) or %s<alphanumeric 1-64 chars>; run '%s' to see valid IDs",
+		kind, s, exampleUUID, prefix, listCmd)
+}
+
+// SessionID validates that a string is a valid Notte session ID
+func SessionID(s string) error {
+	return resourceID(s, "session", "sess_", "notte sessions list", sessionIDPattern)
+}
+
+// AgentID validates that a string is a valid Notte agent ID
+func AgentID(s string) error {
+	return resourceID(s, "agent", "agent_", "notte agents list", agentIDPattern)
 }
 
 // WorkflowID validates that a string is a valid Notte workflow ID
 func WorkflowID(s string) error {
-	if s == "" {
-		return fmt.Errorf("workflow ID cannot be empty")
-	}
-	if !workflowIDPattern.MatchString(s) {
-		return fmt.Errorf("invalid workflow ID: expected wf_<alphanumeric 1-64 chars>, got %q", s)
-	}
-	return nil
+	return resourceID(s, "workflow", "wf_", "notte functions list", workflowIDPattern)
 }
 
 // VaultID validates that a string is a valid Notte vault ID
 func VaultID(s string) error {
-	if s == "" {
-		return fmt.Errorf("vault ID cannot be empty")
-	}
-	if !vaultIDPattern.MatchString(s) {
-		return fmt.Errorf("invalid vault ID: expected vault_<alphanumeric 1-64 chars>, got %q", s)
-	}
-	return nil
+	return resourceID(s, "vault", "vault_", "notte vaults list", vaultIDPattern)
 }
 
 // PersonaID validates that a string is a valid Notte persona ID
 func PersonaID(s string) error {
-	if s == "" {
-		return fmt.Errorf("persona ID cannot be empty")
-	}
-	if !personaIDPattern.MatchString(s) {
-		return fmt.Errorf("invalid persona ID: expected persona_<alphanumeric 1-64 chars>, got %q", s)
-	}
-	return nil
+	return resourceID(s, "persona", "persona_", "notte personas list", personaIDPattern)
 }
@@ -0,0 +1,91 @@
+package validate
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func FuzzURL(f *testing.F) {
+	for _, seed := range []string{"https://example.com", "http://localhost:8080/path?q=1", "ftp://x", "", "://", "https://", "http://[::1]:80"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		if err := URL(s); err != nil {
+			return
+		}
+		u, err := url.Parse(s)
+		if err != nil {
+			t.Fatalf("URL(%q) accepted an unparseable URL: %v", s, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			t.Fatalf("URL(%q) accepted scheme %q host %q", s, u.Scheme, u.Host)
+		}
+	})
+}
+
+func FuzzJSON(f *testing.F) {
+	for _, seed := range []string{`{}`, `[]`, `{"a":[1,2,{"b":null}]}`, `{`, ``, `"str"`, `1e999`} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		err := JSON(s)
+		if s == "" {
+			if err == nil {
+				t.Fatal("JSON(\"\") should fail")
+			}
+			return
+		}
+		var v any
+		wantOK := json.Unmarshal([]byte(s), &v) == nil
+		if (err == nil) != wantOK {
+			t.Fatalf("JSON(%q) = %v, want ok=%v", s, err, wantOK)
+		}
+	})
+}
+
+func FuzzDuration(f *testing.F) {
+	for _, seed := range []string{"5m", "1h30m", "0", "", "-1s", "9999999999h", "1.5s"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		err := Duration(s)
+		if s == "" {
+			if err == nil {
+				t.Fatal("Duration(\"\") should fail")
+			}
+			return
+		}
+		_, parseErr := time.ParseDuration(s)
+		if (err == nil) != (parseErr == nil) {
+			t.Fatalf("Duration(%q) = %v, disagrees with time.ParseDuration: %v", s, err, parseErr)
+		}
+	})
+}
+
+func FuzzIDs(f *testing.F) {
+	for _, seed := range []string{"sess_abc123", "agent_x", "wf_1", "vault_v", "persona_p", "", "sess_", "sess_a-b"} {
+		f.Add(seed)
+	}
+
+	validators := map[string]func(string) error{
+		"sess_":    SessionID,
+		"agent_":   AgentID,
+		"wf_":      WorkflowID,
+		"vault_":   VaultID,
+		"persona_": PersonaID,
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		for prefix, validator := range validators {
+			if err := validator(s); err == nil && !strings.HasPrefix(s, prefix) && UUID(s) != nil {
+				t.Fatalf("validator for %q accepted %q", prefix, s)
+			}
+		}
+	})
+}
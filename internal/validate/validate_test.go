@@ -127,7 +127,9 @@ func TestOutputFormat(t *testing.T) {
 	}{
 		{"text", false},
 		{"json", false},
-		{"yaml", true},
+		{"csv", false},
+		{"yaml", false},
+		{"xml", true},
 		{"", true},
 	}
 
@@ -148,6 +150,10 @@ func TestSessionID(t *testing.T) {
 	}{
 		{"sess_abc123def456", false},
 		{"sess_" + strings.Repeat("a", 32), false},
+		{"27ac8eea-f7ab-4a68-a8c5-2e0a7d3c9d51", false},
+		{"27AC8EEA-F7AB-4A68-A8C5-2E0A7D3C9D51", false},
+		{"27ac8eea-f7ab-4a68-a8c5-2e0a7d3c9d5", true}, // Truncated UUID
+		{"27ac8eeaf7ab4a68a8c52e0a7d3c9d51", true},    // UUID without dashes
 		{"", true},
 		{"abc123", true},         // Missing prefix
 		{"session_abc123", true}, // Wrong prefix
@@ -164,12 +170,34 @@ func TestSessionID(t *testing.T) {
 	}
 }
 
+func TestSessionIDErrorIsActionable(t *testing.T) {
+	err := SessionID("nonexistent")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{"UUID", "sess_", "notte sessions list"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %q", err.Error(), want)
+		}
+	}
+}
+
+func TestUUID(t *testing.T) {
+	if err := UUID("27ac8eea-f7ab-4a68-a8c5-2e0a7d3c9d51"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := UUID("sess_abc"); err == nil {
+		t.Error("expected error for non-UUID")
+	}
+}
+
 func TestAgentID(t *testing.T) {
 	tests := []struct {
 		input   string
 		wantErr bool
 	}{
 		{"agent_abc123def456", false},
+		{"0b0f4f4e-5e2b-4c83-9a55-7d3f0e1c2a9b", false},
 		{"", true},
 		{"abc123", true},
 	}
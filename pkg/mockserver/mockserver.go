@@ -0,0 +1,284 @@
+// Package mockserver provides an HTTP server that mimics the Notte API for tests.
+//
+// Responses are matched on path and, optionally, HTTP method, query parameters
+// and request body. A route can hold a sequence of responses that are served
+// in order (the last one repeats), which makes it easy to exercise retry and
+// pagination logic. Responses can also be delayed to simulate latency.
+//
+//	server := mockserver.New()
+//	defer server.Close()
+//
+//	server.AddSequence("/sessions", mockserver.RateLimitResponse(time.Second), mockserver.JSONResponse(200, `{"items":[]}`))
+//	server.AddMatchedResponse(mockserver.Match{Method: "GET", Path: "/sessions", Query: map[string]string{"page": "2"}},
+//		mockserver.JSONResponse(200, `{"items":[]}`))
+package mockserver
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Response represents a canned response
+type Response struct {
+	StatusCode int
+	Body       string
+	Headers    map[string]string
+	Delay      time.Duration // Wait this long before responding
+}
+
+// RecordedRequest stores request details
+type RecordedRequest struct {
+	Method  string
+	Path    string
+	Query   string
+	Headers http.Header
+	Body    string
+}
+
+// Match describes which requests a route applies to.
+// Empty fields match anything; Path is required.
+type Match struct {
+	Method       string            // HTTP method, e.g. "POST"
+	Path         string            // Exact URL path
+	Query        map[string]string // Query parameters that must be present with these values
+	BodyContains string            // Substring the request body must contain
+}
+
+// specificity ranks matches so the most specific route wins
+func (m Match) specificity() int {
+	score := 0
+	if m.Method != "" {
+		score++
+	}
+	score += len(m.Query)
+	if m.BodyContains != "" {
+		score++
+	}
+	return score
+}
+
+func (m Match) matches(r *http.Request, body string) bool {
+	if m.Path != r.URL.Path {
+		return false
+	}
+	if m.Method != "" && !strings.EqualFold(m.Method, r.Method) {
+		return false
+	}
+	query := r.URL.Query()
+	for k, v := range m.Query {
+		if query.Get(k) != v {
+			return false
+		}
+	}
+	if m.BodyContains != "" && !strings.Contains(body, m.BodyContains) {
+		return false
+	}
+	return true
+}
+
+type route struct {
+	match     Match
+	responses []Response
+	next      int
+}
+
+// Server provides a test HTTP server with canned responses
+type Server struct {
+	server   *httptest.Server
+	mu       sync.RWMutex
+	routes   []*route
+	requests map[string][]RecordedRequest
+}
+
+// New creates a new mock HTTP server
+func New() *Server {
+	ms := &Server{
+		requests: make(map[string][]RecordedRequest),
+	}
+
+	ms.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := ms.recordRequest(r)
+
+		resp, ok := ms.nextResponse(r, body)
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "not found"}`))
+			return
+		}
+
+		if resp.Delay > 0 {
+			select {
+			case <-time.After(resp.Delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		for key, val := range resp.Headers {
+			w.Header().Set(key, val)
+		}
+		w.WriteHeader(resp.StatusCode)
+		_, _ = w.Write([]byte(resp.Body))
+	}))
+
+	return ms
+}
+
+func (ms *Server) recordRequest(r *http.Request) string {
+	var body []byte
+	if r.Body != nil {
+		body, _ = io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	rec := RecordedRequest{
+		Method:  r.Method,
+		Path:    r.URL.Path,
+		Query:   r.URL.RawQuery,
+		Headers: r.Header.Clone(),
+		Body:    string(body),
+	}
+
+	ms.requests[r.URL.Path] = append(ms.requests[r.URL.Path], rec)
+	return string(body)
+}
+
+// nextResponse picks the most specific matching route (latest added wins ties)
+// and advances its sequence
+func (ms *Server) nextResponse(r *http.Request, body string) (Response, bool) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	var best *route
+	for _, rt := range ms.routes {
+		if !rt.match.matches(r, body) {
+			continue
+		}
+		if best == nil || rt.match.specificity() >= best.match.specificity() {
+			best = rt
+		}
+	}
+	if best == nil || len(best.responses) == 0 {
+		return Response{}, false
+	}
+
+	resp := best.responses[best.next]
+	if best.next < len(best.responses)-1 {
+		best.next++
+	}
+	return resp, true
+}
+
+// AddMatchedResponse registers responses for requests matching m. When several
+// responses are given they are served in order and the last one repeats.
+// A route with the same Match replaces any earlier one.
+func (ms *Server) AddMatchedResponse(m Match, responses ...Response) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	for i, rt := range ms.routes {
+		if sameMatch(rt.match, m) {
+			ms.routes = append(ms.routes[:i], ms.routes[i+1:]...)
+			break
+		}
+	}
+	ms.routes = append(ms.routes, &route{match: m, responses: responses})
+}
+
+// AddSequence registers responses served in order for a path (any method)
+func (ms *Server) AddSequence(path string, responses ...Response) {
+	ms.AddMatchedResponse(Match{Path: path}, responses...)
+}
+
+// AddResponse adds a canned response for a path
+func (ms *Server) AddResponse(path string, statusCode int, body string) {
+	ms.AddSequence(path, JSONResponse(statusCode, body))
+}
+
+// AddResponseWithHeaders adds a response with custom headers
+func (ms *Server) AddResponseWithHeaders(path string, statusCode int, body string, headers map[string]string) {
+	ms.AddSequence(path, Response{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    headers,
+	})
+}
+
+// JSONResponse builds a response with a JSON content type
+func JSONResponse(statusCode int, body string) Response {
+	return Response{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+	}
+}
+
+// RateLimitResponse builds a 429 response with a Retry-After header. The
+// header holds whole seconds, so a fractional duration is rounded up.
+func RateLimitResponse(retryAfter time.Duration) Response {
+	resp := JSONResponse(http.StatusTooManyRequests, `{"detail": "rate limit exceeded"}`)
+	resp.Headers["Retry-After"] = strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+	return resp
+}
+
+// URL returns the server's base URL
+func (ms *Server) URL() string {
+	return ms.server.URL
+}
+
+// Requests returns recorded requests for a path
+func (ms *Server) Requests(path string) []RecordedRequest {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	return ms.requests[path]
+}
+
+// AllRequests returns all recorded requests
+func (ms *Server) AllRequests() map[string][]RecordedRequest {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	result := make(map[string][]RecordedRequest)
+	for k, v := range ms.requests {
+		result[k] = v
+	}
+	return result
+}
+
+// Reset clears all responses and recorded requests
+func (ms *Server) Reset() {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.routes = nil
+	ms.requests = make(map[string][]RecordedRequest)
+}
+
+// Close shuts down the server
+func (ms *Server) Close() {
+	ms.server.Close()
+}
+
+func sameMatch(a, b Match) bool {
+	if a.Method != b.Method || a.Path != b.Path || a.BodyContains != b.BodyContains || len(a.Query) != len(b.Query) {
+		return false
+	}
+	for k, v := range a.Query {
+		if bv, ok := b.Query[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package mockserver
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestServer_SequenceRepeatsLast(t *testing.T) {
+	server := New()
+	defer server.Close()
+
+	server.AddSequence("/status",
+		JSONResponse(http.StatusOK, `{"status":"pending"}`),
+		JSONResponse(http.StatusOK, `{"status":"done"}`),
+	)
+
+	for _, want := range []string{"pending", "done", "done"} {
+		_, body := get(t, server.URL()+"/status")
+		if !strings.Contains(body, want) {
+			t.Fatalf("got %q, want %q", body, want)
+		}
+	}
+}
+
+func TestServer_MatchesMethodAndQuery(t *testing.T) {
+	server := New()
+	defer server.Close()
+
+	server.AddResponse("/sessions", http.StatusOK, `{"page":"any"}`)
+	server.AddMatchedResponse(Match{Method: http.MethodGet, Path: "/sessions", Query: map[string]string{"page": "2"}},
+		JSONResponse(http.StatusOK, `{"page":"2"}`))
+	server.AddMatchedResponse(Match{Method: http.MethodPost, Path: "/sessions"},
+		JSONResponse(http.StatusCreated, `{"created":true}`))
+
+	if _, body := get(t, server.URL()+"/sessions?page=2"); !strings.Contains(body, `"2"`) {
+		t.Errorf("expected page 2 route, got %q", body)
+	}
+	if _, body := get(t, server.URL()+"/sessions?page=1"); !strings.Contains(body, "any") {
+		t.Errorf("expected fallback route, got %q", body)
+	}
+
+	resp, err := http.Post(server.URL()+"/sessions", "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("expected POST route, got status %d", resp.StatusCode)
+	}
+}
+
+func TestServer_MatchesBody(t *testing.T) {
+	server := New()
+	defer server.Close()
+
+	server.AddMatchedResponse(Match{Path: "/execute", BodyContains: `"type":"click"`},
+		JSONResponse(http.StatusOK, `{"action":"click"}`))
+	server.AddResponse("/execute", http.StatusBadRequest, `{"detail":"unknown"}`)
+
+	resp, err := http.Post(server.URL()+"/execute", "application/json", strings.NewReader(`{"type":"click"}`))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected body match, got status %d", resp.StatusCode)
+	}
+}
+
+func TestServer_RateLimitAndDelay(t *testing.T) {
+	server := New()
+	defer server.Close()
+
+	limited := RateLimitResponse(30 * time.Second)
+	slow := JSONResponse(http.StatusOK, `{}`)
+	slow.Delay = 50 * time.Millisecond
+	server.AddSequence("/limited", limited, slow)
+
+	resp, err := http.Get(server.URL() + "/limited")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "30" {
+		t.Errorf("expected 429 with Retry-After, got %d %q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+
+	start := time.Now()
+	if status, _ := get(t, server.URL()+"/limited"); status != http.StatusOK {
+		t.Errorf("expected 200, got %d", status)
+	}
+	if time.Since(start) < 50*time.Millisecond {
+		t.Error("expected response to be delayed")
+	}
+}
+
+func TestRateLimitResponse_RoundsUp(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		500 * time.Millisecond:  "1",
+		1500 * time.Millisecond: "2",
+		2 * time.Second:         "2",
+	} {
+		if got := RateLimitResponse(d).Headers["Retry-After"]; got != want {
+			t.Errorf("Retry-After for %v = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestServer_UnmatchedReturns404(t *testing.T) {
+	server := New()
+	defer server.Close()
+
+	if status, _ := get(t, server.URL()+"/missing"); status != http.StatusNotFound {
+		t.Errorf("got status %d, want 404", status)
+	}
+	if len(server.Requests("/missing")) != 1 {
+		t.Error("expected unmatched request to be recorded")
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 )
 
@@ -48,10 +50,6 @@ func GenerateFlagsFile(config *CommandConfig, schemas map[string]*Field) (string
 	if needsFmt {
 		buf.WriteString("\t\"fmt\"\n")
 	}
-	if needsJSON {
-		buf.WriteString("\t\"os\"\n")
-		buf.WriteString("\t\"strings\"\n")
-	}
 	if needsFmt || needsJSON {
 		buf.WriteString("\n")
 	}
@@ -60,27 +58,6 @@ func GenerateFlagsFile(config *CommandConfig, schemas map[string]*Field) (string
 	buf.WriteString("\t\"github.com/nottelabs/notte-cli/internal/api\"\n")
 	buf.WriteString(")\n\n")
 
-	// Add helper function for JSON file reading if needed
-	if needsJSON {
-		buf.WriteString("// readJSONFile reads JSON from a file path (supports @file.json syntax)\n")
-		buf.WriteString("func readJSONFile(path string) (interface{}, error) {\n")
-		buf.WriteString("\t// Remove @ prefix if present\n")
-		buf.WriteString("\tfilePath := strings.TrimPrefix(path, \"@\")\n")
-		buf.WriteString("\t\n")
-		buf.WriteString("\tdata, err := os.ReadFile(filePath)\n")
-		buf.WriteString("\tif err != nil {\n")
-		buf.WriteString("\t\treturn nil, fmt.Errorf(\"failed to read file %s: %w\", filePath, err)\n")
-		buf.WriteString("\t}\n")
-		buf.WriteString("\t\n")
-		buf.WriteString("\tvar result interface{}\n")
-		buf.WriteString("\tif err := json.Unmarshal(data, &result); err != nil {\n")
-		buf.WriteString("\t\treturn nil, fmt.Errorf(\"failed to parse JSON from %s: %w\", filePath, err)\n")
-		buf.WriteString("\t}\n")
-		buf.WriteString("\t\n")
-		buf.WriteString("\treturn result, nil\n")
-		buf.WriteString("}\n\n")
-	}
-
 	// Generate flag variables
 	fmt.Fprintf(&buf, "// %s command flags\n", config.Name)
 	buf.WriteString("var (\n")
@@ -149,14 +126,34 @@ func generateRegisterFunction(buf *bytes.Buffer, config *CommandConfig) error {
 			// Register sub-fields
 			fmt.Fprintf(buf, "\t// %s (flattened object)\n", fc.Field.Name)
 			for _, subFC := range fc.SubFields {
-				generateFlagRegistration(buf, subFC)
+				generateFlagRegistration(buf, subFC, fc.Field.JSONName+"."+subFC.Field.JSONName)
 			}
 		case CategoryJSONFileInput:
 			// Register as string flag for JSON file path
-			fmt.Fprintf(buf, "\tcmd.Flags().StringVar(&%s, \"%s-json\", \"\", \"%s configuration (JSON file path, e.g., @config.json)\")\n",
+			fmt.Fprintf(buf, "\tcmd.Flags().StringVar(&%s, \"%s-json\", \"\", \"%s configuration (JSON, @file, or '-' for stdin)\")\n",
 				fc.VarName, fc.FlagName, fc.FlagName)
+			writeFieldAnnotation(buf, fc.FlagName+"-json", fc.Field.JSONName)
+			jsonFC := *fc
+			jsonFC.FlagName += "-json"
+			generateDeprecations(buf, &jsonFC, func(alias string) {
+				fmt.Fprintf(buf, "\tcmd.Flags().StringVar(&%s, \"%s\", \"\", \"%s configuration (JSON, @file, or '-' for stdin)\")\n",
+					fc.VarName, alias, fc.FlagName)
+			})
 		default:
-			generateFlagRegistration(buf, fc)
+			generateFlagRegistration(buf, fc, fc.Field.JSONName)
+		}
+	}
+
+	// Fields the schema requires and has no default for must be passed explicitly
+	if required := requiredFlagNames(config); len(required) > 0 {
+		buf.WriteString("\n")
+		for _, group := range required {
+			if len(group) == 1 {
+				fmt.Fprintf(buf, "\t_ = cmd.MarkFlagRequired(\"%s\")\n", group[0])
+				continue
+			}
+			// A renamed field can be set through its old flag too
+			fmt.Fprintf(buf, "\tcmd.MarkFlagsOneRequired(\"%s\")\n", strings.Join(group, "\", \""))
 		}
 	}
 
@@ -164,8 +161,42 @@ func generateRegisterFunction(buf *bytes.Buffer, config *CommandConfig) error {
 	return nil
 }
 
-func generateFlagRegistration(buf *bytes.Buffer, fc *FieldConfig) {
-	defaultValue := getDefaultValue(fc)
+// requiredFlagNames returns the flags to mark required, in field order, each
+// grouped with its aliases. Sub-fields of a flattened object are only
+// required if the object is. Required fields that are nullable are sent as
+// null when their flag is omitted, so their flags stay optional.
+func requiredFlagNames(config *CommandConfig) [][]string {
+	var groups [][]string
+	for _, fc := range config.Fields {
+		if !fc.Field.Required || fc.Field.Nullable || fc.Field.Deprecated || IsRuntimeRequired(config.Name, fc.Field.Name) {
+			continue
+		}
+
+		switch fc.Category {
+		case CategoryUnsupported, CategorySkipped:
+			continue
+		case CategoryFlattenedFlags:
+			for _, subFC := range fc.SubFields {
+				if subFC.Field.Required && !subFC.Field.Deprecated && subFC.Field.Default == nil {
+					groups = append(groups, append([]string{subFC.FlagName}, subFC.Aliases...))
+				}
+			}
+		case CategoryJSONFileInput:
+			if fc.Field.Default == nil {
+				groups = append(groups, append([]string{fc.FlagName + "-json"}, fc.Aliases...))
+			}
+		default:
+			if fc.Field.Default == nil {
+				groups = append(groups, append([]string{fc.FlagName}, fc.Aliases...))
+			}
+		}
+	}
+	return groups
+}
+
+// generateFlagRegistration registers the field's flag; fieldPath is the
+// dotted path of the field in the request body
+func generateFlagRegistration(buf *bytes.Buffer, fc *FieldConfig, fieldPath string) {
 	description := fc.Field.Description
 	if description == "" {
 		description = fc.FlagName
@@ -176,41 +207,108 @@ func generateFlagRegistration(buf *bytes.Buffer, fc *FieldConfig) {
 		description += fmt.Sprintf(" (%s)", strings.Join(fc.Field.Enum, ", "))
 	}
 
+	writeFlagDefinition(buf, fc, fc.FlagName, description)
+	writeFieldAnnotation(buf, fc.FlagName, fieldPath)
+	if fc.Category == CategoryEnumFlag && len(fc.Field.Enum) > 0 {
+		// Expose the values to `notte __schema`; union types also accept other strings
+		annotation := "flagEnumAnnotation"
+		if fc.Field.IsUnionType {
+			annotation = "flagSuggestionsAnnotation"
+		}
+		quoted := make([]string, len(fc.Field.Enum))
+		for i, v := range fc.Field.Enum {
+			quoted[i] = strconv.Quote(v)
+		}
+		fmt.Fprintf(buf, "\t_ = cmd.Flags().SetAnnotation(\"%s\", %s, []string{%s})\n",
+			fc.FlagName, annotation, strings.Join(quoted, ", "))
+	}
+	generateDeprecations(buf, fc, func(alias string) {
+		writeFlagDefinition(buf, fc, alias, description)
+	})
+}
+
+// writeFieldAnnotation records which request field a flag sets, so the API's
+// validation errors can point at the flag
+func writeFieldAnnotation(buf *bytes.Buffer, flagName, fieldPath string) {
+	fmt.Fprintf(buf, "\t_ = cmd.Flags().SetAnnotation(\"%s\", flagFieldAnnotation, []string{%s})\n",
+		flagName, strconv.Quote(fieldPath))
+}
+
+// writeFlagDefinition registers flagName bound to the field's variable
+func writeFlagDefinition(buf *bytes.Buffer, fc *FieldConfig, flagName, description string) {
+	defaultValue := getDefaultValue(fc)
+
 	switch fc.FlagType {
 	case "StringVar":
-		fmt.Fprintf(buf, "\tcmd.Flags().StringVar(&%s, \"%s\", \"%s\", \"%s\")\n",
-			fc.VarName, fc.FlagName, defaultValue, description)
+		fmt.Fprintf(buf, "\tcmd.Flags().StringVar(&%s, \"%s\", %s, \"%s\")\n",
+			fc.VarName, flagName, defaultValue, description)
 	case "IntVar":
 		fmt.Fprintf(buf, "\tcmd.Flags().IntVar(&%s, \"%s\", %s, \"%s\")\n",
-			fc.VarName, fc.FlagName, defaultValue, description)
+			fc.VarName, flagName, defaultValue, description)
 	case "BoolVar":
 		fmt.Fprintf(buf, "\tcmd.Flags().BoolVar(&%s, \"%s\", %s, \"%s\")\n",
-			fc.VarName, fc.FlagName, defaultValue, description)
+			fc.VarName, flagName, defaultValue, description)
 	case "Float64Var":
 		fmt.Fprintf(buf, "\tcmd.Flags().Float64Var(&%s, \"%s\", %s, \"%s\")\n",
-			fc.VarName, fc.FlagName, defaultValue, description)
+			fc.VarName, flagName, defaultValue, description)
 	case "StringSliceVar":
 		fmt.Fprintf(buf, "\tcmd.Flags().StringSliceVar(&%s, \"%s\", []string{}, \"%s (repeatable)\")\n",
-			fc.VarName, fc.FlagName, description)
+			fc.VarName, flagName, description)
 	}
 }
 
-func getDefaultValue(fc *FieldConfig) string {
-	if fc.Field.Default != nil {
-		return fmt.Sprintf("%v", fc.Field.Default)
+// generateDeprecations registers hidden aliases for the field's previous
+// names, which share its variable and warn when used, and deprecates the
+// flag itself if the field is deprecated
+func generateDeprecations(buf *bytes.Buffer, fc *FieldConfig, register func(alias string)) {
+	for _, alias := range fc.Aliases {
+		register(alias)
+		fmt.Fprintf(buf, "\t_ = cmd.Flags().MarkDeprecated(\"%s\", \"use --%s instead\")\n", alias, fc.FlagName)
 	}
+	if fc.Field.Deprecated {
+		fmt.Fprintf(buf, "\t_ = cmd.Flags().MarkDeprecated(\"%s\", \"it will be removed in a future release\")\n", fc.FlagName)
+	}
+}
+
+// changedCondition is true when the flag or any of its aliases was set
+func changedCondition(fc *FieldConfig) string {
+	conditions := []string{fmt.Sprintf("cmd.Flags().Changed(\"%s\")", fc.FlagName)}
+	for _, alias := range fc.Aliases {
+		conditions = append(conditions, fmt.Sprintf("cmd.Flags().Changed(\"%s\")", alias))
+	}
+	return strings.Join(conditions, " || ")
+}
 
-	switch fc.Field.Type {
-	case "string":
-		return ""
-	case "integer":
+// getDefaultValue returns the Go literal for the flag default, using the
+// schema default when it fits the flag type
+func getDefaultValue(fc *FieldConfig) string {
+	switch fc.FlagType {
+	case "StringVar":
+		if v, ok := fc.Field.Default.(string); ok {
+			return strconv.Quote(v)
+		}
+		return `""`
+	case "IntVar":
+		if v, ok := fc.Field.Default.(float64); ok && v == math.Trunc(v) {
+			return strconv.FormatInt(int64(v), 10)
+		}
 		return "0"
-	case "boolean":
+	case "BoolVar":
+		if v, ok := fc.Field.Default.(bool); ok {
+			return strconv.FormatBool(v)
+		}
 		return "false"
-	case "number":
+	case "Float64Var":
+		if v, ok := fc.Field.Default.(float64); ok {
+			s := strconv.FormatFloat(v, 'f', -1, 64)
+			if !strings.Contains(s, ".") {
+				s += ".0"
+			}
+			return s
+		}
 		return "0.0"
 	default:
-		return ""
+		return `""`
 	}
 }
 
@@ -252,11 +350,15 @@ func generateJSONFileInputMapping(buf *bytes.Buffer, fc *FieldConfig) {
 
 	fmt.Fprintf(buf, "\t// %s (JSON file input)\n", fc.Field.Name)
 	fmt.Fprintf(buf, "\tif %s != \"\" {\n", fc.VarName)
-	fmt.Fprintf(buf, "\t\tdata, err := readJSONFile(%s)\n", fc.VarName)
+	fmt.Fprintf(buf, "\t\tdata, err := readJSONFileInput(cmd, %s, \"%s-json\")\n", fc.VarName, fc.FlagName)
 	buf.WriteString("\t\tif err != nil {\n")
-	fmt.Fprintf(buf, "\t\t\treturn nil, fmt.Errorf(\"failed to read %s: %%w\", err)\n", fc.FlagName)
+	buf.WriteString("\t\t\treturn nil, err\n")
 	buf.WriteString("\t\t}\n")
-	fmt.Fprintf(buf, "\t\tbody.%s = data\n", apiFieldName)
+	buf.WriteString("\t\tvar val interface{}\n")
+	buf.WriteString("\t\tif err := json.Unmarshal(data, &val); err != nil {\n")
+	fmt.Fprintf(buf, "\t\t\treturn nil, fmt.Errorf(\"invalid JSON for --%s-json: %%w\", err)\n", fc.FlagName)
+	buf.WriteString("\t\t}\n")
+	fmt.Fprintf(buf, "\t\tbody.%s = val\n", apiFieldName)
 	buf.WriteString("\t}\n\n")
 }
 
@@ -275,12 +377,20 @@ func generateSimpleFieldMapping(buf *bytes.Buffer, fc *FieldConfig) {
 
 	if fc.Field.Type == "boolean" {
 		// For booleans, check if flag was changed
-		fmt.Fprintf(buf, "\tif cmd.Flags().Changed(\"%s\") {\n", fc.FlagName)
+		fmt.Fprintf(buf, "\tif %s {\n", changedCondition(fc))
 		fmt.Fprintf(buf, "\t\tbody.%s = %s\n", apiFieldName, assignOp)
 		buf.WriteString("\t}\n\n")
+	} else if fc.Field.Required && fc.Field.Default != nil {
+		// Required fields with a default always hold a value to send
+		if fc.Field.Type == "string" {
+			fmt.Fprintf(buf, "\tif %s == \"\" {\n", fc.VarName)
+			fmt.Fprintf(buf, "\t\treturn nil, fmt.Errorf(\"--%s cannot be empty\")\n", fc.FlagName)
+			buf.WriteString("\t}\n")
+		}
+		fmt.Fprintf(buf, "\tbody.%s = %s\n\n", apiFieldName, assignOp)
 	} else if fc.Field.Required {
 		// For required fields, validate non-empty for strings
-		fmt.Fprintf(buf, "\tif cmd.Flags().Changed(\"%s\") {\n", fc.FlagName)
+		fmt.Fprintf(buf, "\tif %s {\n", changedCondition(fc))
 		if fc.Field.Type == "string" {
 			fmt.Fprintf(buf, "\t\tif %s == \"\" {\n", fc.VarName)
 			fmt.Fprintf(buf, "\t\t\treturn nil, fmt.Errorf(\"--%s cannot be empty\")\n", fc.FlagName)
@@ -288,6 +398,12 @@ func generateSimpleFieldMapping(buf *bytes.Buffer, fc *FieldConfig) {
 		}
 		fmt.Fprintf(buf, "\t\tbody.%s = %s\n", apiFieldName, assignOp)
 		buf.WriteString("\t}\n\n")
+	} else if fc.Field.Default != nil {
+		// For optional fields with a default, only send values the user set
+		// so the API stays the source of truth for the default
+		fmt.Fprintf(buf, "\tif %s {\n", changedCondition(fc))
+		fmt.Fprintf(buf, "\t\tbody.%s = &%s\n", apiFieldName, fc.VarName)
+		buf.WriteString("\t}\n\n")
 	} else {
 		// For optional fields, check if non-zero
 		switch fc.Field.Type {
@@ -309,7 +425,11 @@ func generateEnumFieldMapping(buf *bytes.Buffer, fc *FieldConfig, config *Comman
 		apiFieldName = toCamelCase(fc.Field.Name)
 	}
 
-	fmt.Fprintf(buf, "\tif %s != \"\" {\n", fc.VarName)
+	if fc.Field.Default != nil {
+		fmt.Fprintf(buf, "\tif %s {\n", changedCondition(fc))
+	} else {
+		fmt.Fprintf(buf, "\tif %s != \"\" {\n", fc.VarName)
+	}
 
 	if fc.Field.IsUnionType {
 		// Union type (anyOf with enum + string) - use From*1 method to set string value
@@ -353,7 +473,7 @@ func generateFlattenedFieldMapping(buf *bytes.Buffer, fc *FieldConfig, config *C
 		} else if subFC.Field.Type == "string" {
 			optionalConditions = append(optionalConditions, fmt.Sprintf("%s != \"\"", subFC.VarName))
 		} else {
-			optionalConditions = append(optionalConditions, fmt.Sprintf("cmd.Flags().Changed(\"%s\")", subFC.FlagName))
+			optionalConditions = append(optionalConditions, changedCondition(subFC))
 		}
 	}
 
@@ -434,7 +554,7 @@ func generateFlattenedFieldMapping(buf *bytes.Buffer, fc *FieldConfig, config *C
 				fmt.Fprintf(buf, "\t\t\t%s.%s = &%s\n", varName, subAPIFieldName, subFC.VarName)
 				buf.WriteString("\t\t}\n")
 			} else {
-				fmt.Fprintf(buf, "\t\tif cmd.Flags().Changed(\"%s\") {\n", subFC.FlagName)
+				fmt.Fprintf(buf, "\t\tif %s {\n", changedCondition(subFC))
 				fmt.Fprintf(buf, "\t\t\t%s.%s = &%s\n", varName, subAPIFieldName, subFC.VarName)
 				buf.WriteString("\t\t}\n")
 			}
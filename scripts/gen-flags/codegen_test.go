@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const agentStartSpec = `{
+  "paths": {
+    "/agents/start": {"post": {"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/AgentStartRequest"}}}}}},
+    "/vaults/{vault_id}/credentials": {"post": {"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/AddCredentialsRequest"}}}}}}
+  },
+  "components": {"schemas": {
+    "AgentStartRequest": {
+      "type": "object",
+      "required": ["task", "session_id", "response_format"],
+      "properties": {
+        "task": {"type": "string"},
+        "response_format": {"nullable": true},
+        "session_id": {"type": "string"},
+        "max_steps": {"type": "integer", "default": 20},
+        "use_vision": {"type": "boolean", "default": true},
+        "temperature": {"type": "number", "default": 1},
+        "url": {"type": "string", "default": "https://example.com/\"start\""}
+      }
+    },
+    "AddCredentialsRequest": {
+      "type": "object",
+      "required": ["url", "credentials"],
+      "properties": {
+        "url": {"type": "string"},
+        "credentials": {"$ref": "#/components/schemas/CredentialsDict-Input"}
+      }
+    },
+    "CredentialsDict-Input": {
+      "type": "object",
+      "required": ["password"],
+      "properties": {
+        "password": {"type": "string"},
+        "email": {"type": "string"}
+      }
+    }
+  }}
+}`
+
+const renamedFieldsSpec = `{
+  "paths": {
+    "/agents/start": {"post": {"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/AgentStartRequest"}}}}}}
+  },
+  "components": {"schemas": {
+    "AgentStartRequest": {
+      "type": "object",
+      "required": ["task"],
+      "properties": {
+        "task": {"type": "string", "x-renamed-from": ["instructions"]},
+        "max_steps": {"type": "integer", "x-renamed-from": ["step_limit"]},
+        "use_vision": {"type": "boolean", "deprecated": true}
+      }
+    }
+  }}
+}`
+
+func generateForTest(t *testing.T, specJSON, name string) string {
+	t.Helper()
+
+	spec, err := ParseOpenAPISpecBytes([]byte(specJSON))
+	if err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+	configs, err := ExtractCommandConfigs(spec)
+	if err != nil {
+		t.Fatalf("failed to extract commands: %v", err)
+	}
+	schemas := buildSchemaMap(spec)
+	for _, config := range configs {
+		if config.Name != name {
+			continue
+		}
+		code, genErrors, err := GenerateFlagsFile(config, schemas)
+		if err != nil {
+			t.Fatalf("failed to generate: %v", err)
+		}
+		if len(genErrors) > 0 {
+			t.Fatalf("unexpected generation errors: %v", genErrors)
+		}
+		return code
+	}
+	t.Fatalf("command %s not generated", name)
+	return ""
+}
+
+func TestGenerateFlagsFile_Defaults(t *testing.T) {
+	code := generateForTest(t, agentStartSpec, "AgentStart")
+
+	for _, want := range []string{
+		`cmd.Flags().IntVar(&AgentStartMaxSteps, "max-steps", 20,`,
+		`cmd.Flags().BoolVar(&AgentStartUseVision, "use-vision", true,`,
+		`cmd.Flags().Float64Var(&AgentStartTemperature, "temperature", 1.0,`,
+		`cmd.Flags().StringVar(&AgentStartUrl, "url", "https://example.com/\"start\"",`,
+		"if cmd.Flags().Changed(\"max-steps\") {\n\t\tbody.MaxSteps = &AgentStartMaxSteps",
+		"if cmd.Flags().Changed(\"url\") {\n\t\tbody.Url = &AgentStartUrl",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q\n%s", want, code)
+		}
+	}
+}
+
+func TestGenerateFlagsFile_MarksRequired(t *testing.T) {
+	code := generateForTest(t, agentStartSpec, "AgentStart")
+
+	if !strings.Contains(code, `_ = cmd.MarkFlagRequired("task")`) {
+		t.Errorf("expected --task to be marked required\n%s", code)
+	}
+	if strings.Contains(code, `MarkFlagRequired("session-id")`) {
+		t.Errorf("--session-id falls back to the current session and must not be marked required\n%s", code)
+	}
+	if strings.Contains(code, `MarkFlagRequired("max-steps")`) {
+		t.Errorf("optional --max-steps must not be marked required\n%s", code)
+	}
+	if strings.Contains(code, `MarkFlagRequired("response-format-json")`) {
+		t.Errorf("nullable --response-format-json can be sent as null and must not be marked required\n%s", code)
+	}
+}
+
+func TestGenerateFlagsFile_MarksRequiredFlattened(t *testing.T) {
+	code := generateForTest(t, agentStartSpec, "VaultCredentialsAdd")
+
+	for _, want := range []string{
+		`_ = cmd.MarkFlagRequired("password")`,
+		`_ = cmd.MarkFlagRequired("url")`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q\n%s", want, code)
+		}
+	}
+	if strings.Contains(code, `MarkFlagRequired("email")`) {
+		t.Errorf("optional --email must not be marked required\n%s", code)
+	}
+}
+
+func TestGenerateFlagsFile_RenamedAndDeprecated(t *testing.T) {
+	code := generateForTest(t, renamedFieldsSpec, "AgentStart")
+
+	for _, want := range []string{
+		`cmd.Flags().StringVar(&AgentStartTask, "instructions", "",`,
+		`_ = cmd.Flags().MarkDeprecated("instructions", "use --task instead")`,
+		`cmd.Flags().IntVar(&AgentStartMaxSteps, "step-limit", 0,`,
+		`_ = cmd.Flags().MarkDeprecated("step-limit", "use --max-steps instead")`,
+		`_ = cmd.Flags().MarkDeprecated("use-vision", "it will be removed in a future release")`,
+		`cmd.MarkFlagsOneRequired("task", "instructions")`,
+		`if cmd.Flags().Changed("task") || cmd.Flags().Changed("instructions") {`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q\n%s", want, code)
+		}
+	}
+	if strings.Contains(code, `MarkFlagRequired("task")`) {
+		t.Errorf("--task must not be required on its own when --instructions can set it\n%s", code)
+	}
+}
+
+func TestGenerateFlagsFile_FieldAnnotations(t *testing.T) {
+	code := generateForTest(t, agentStartSpec, "VaultCredentialsAdd")
+
+	for _, want := range []string{
+		`_ = cmd.Flags().SetAnnotation("password", flagFieldAnnotation, []string{"credentials.password"})`,
+		`_ = cmd.Flags().SetAnnotation("url", flagFieldAnnotation, []string{"url"})`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q\n%s", want, code)
+		}
+	}
+}
+
+// The checked-in flag files must be what the bundled spec generates, so the
+// two can't drift apart between regenerations
+func TestBundledSpecMatchesGeneratedFlags(t *testing.T) {
+	dir := t.TempDir()
+	if err := run("../../internal/api/openapi.json", dir); err != nil {
+		t.Fatalf("failed to generate from the bundled spec: %v", err)
+	}
+
+	generated, err := filepath.Glob(filepath.Join(dir, "*_flags.gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkedIn, err := filepath.Glob("../../internal/cmd/*_flags.gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(generated) != len(checkedIn) {
+		t.Errorf("bundled spec generates %d flag files, %d are checked in", len(generated), len(checkedIn))
+	}
+
+	for _, path := range generated {
+		name := filepath.Base(path)
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := format.Source(raw)
+		if err != nil {
+			t.Fatalf("generated %s does not format: %v", name, err)
+		}
+		got, err := os.ReadFile(filepath.Join("../../internal/cmd", name))
+		if err != nil {
+			t.Errorf("generated %s is not checked in: %v", name, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("internal/cmd/%s is out of date with internal/api/openapi.json; run make generate", name)
+		}
+	}
+}
@@ -47,6 +47,10 @@ type SchemaRef struct {
 	Nullable    bool                 `json:"nullable,omitempty"`
 	AnyOf       []SchemaRef          `json:"anyOf,omitempty"`
 	Description string               `json:"description,omitempty"`
+	Default     interface{}          `json:"default,omitempty"`
+	Deprecated  bool                 `json:"deprecated,omitempty"`
+	// RenamedFrom lists previous names of a property (vendor extension)
+	RenamedFrom []string `json:"x-renamed-from,omitempty"`
 }
 
 type Components struct {
@@ -60,6 +64,11 @@ func ParseOpenAPISpec(filename string) (*OpenAPISpec, error) {
 		return nil, fmt.Errorf("failed to read spec file: %w", err)
 	}
 
+	return ParseOpenAPISpecBytes(data)
+}
+
+// ParseOpenAPISpecBytes parses an OpenAPI specification from raw JSON
+func ParseOpenAPISpecBytes(data []byte) (*OpenAPISpec, error) {
 	var spec OpenAPISpec
 	if err := json.Unmarshal(data, &spec); err != nil {
 		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
@@ -187,6 +196,13 @@ func processField(commandName, fieldName string, field *Field, schemas map[strin
 		FlagType: field.FlagType(),
 		GoType:   field.GoType(),
 	}
+	for _, oldName := range field.RenamedFrom {
+		alias := toKebabCase(oldName)
+		if category == CategoryJSONFileInput {
+			alias += "-json"
+		}
+		fc.Aliases = append(fc.Aliases, alias)
+	}
 
 	// For flattened objects, process sub-fields (sorted for deterministic output)
 	if category == CategoryFlattenedFlags {
@@ -222,6 +238,13 @@ func processField(commandName, fieldName string, field *Field, schemas map[strin
 				FlagType: subField.FlagType(),
 				GoType:   subField.GoType(),
 			}
+			for _, oldName := range subField.RenamedFrom {
+				if skipPrefix {
+					subFC.Aliases = append(subFC.Aliases, toKebabCase(oldName))
+				} else {
+					subFC.Aliases = append(subFC.Aliases, flagName+"-"+toKebabCase(oldName))
+				}
+			}
 			fc.SubFields = append(fc.SubFields, subFC)
 		}
 	}
@@ -243,6 +266,9 @@ func convertSchemaRefToField(name string, schemaRef SchemaRef, allSchemas map[st
 		Type:        schemaRef.Type,
 		Nullable:    schemaRef.Nullable,
 		Description: schemaRef.Description,
+		Default:     schemaRef.Default,
+		Deprecated:  schemaRef.Deprecated,
+		RenamedFrom: schemaRef.RenamedFrom,
 		Properties:  make(map[string]*Field),
 	}
 
@@ -254,6 +280,9 @@ func convertSchemaRefToField(name string, schemaRef SchemaRef, allSchemas map[st
 	if len(schemaRef.AnyOf) > 0 {
 		field = handleAnyOf(name, schemaRef, allSchemas)
 		field.Description = schemaRef.Description
+		field.Default = schemaRef.Default
+		field.Deprecated = schemaRef.Deprecated
+		field.RenamedFrom = schemaRef.RenamedFrom
 		return field
 	}
 
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func FuzzParseOpenAPISpec(f *testing.F) {
+	f.Add([]byte(`{}`))
+	f.Add([]byte(`{"paths":{"/sessions/start":{"post":{"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Missing"}}}}}}}}`))
+	f.Add([]byte(`{"paths":{"/sessions/start":{"post":{"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Req"}}}}}}},` +
+		`"components":{"schemas":{"Req":{"type":"object","required":["url"],"properties":{"url":{"type":"string"},"headless":{"type":"boolean"},` +
+		`"mode":{"anyOf":[{"$ref":"#/components/schemas/Mode"},{"type":"string"}]},"tags":{"type":"array","items":{"type":"string"}},` +
+		`"nested":{"$ref":"#/components/schemas/Req"}}},"Mode":{"enum":["a","b"]}}}}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		spec, err := ParseOpenAPISpecBytes(data)
+		if err != nil {
+			return
+		}
+		configs, err := ExtractCommandConfigs(spec)
+		if err != nil {
+			return
+		}
+		schemas := buildSchemaMap(spec)
+		for _, config := range configs {
+			_, _, _ = GenerateFlagsFile(config, schemas)
+		}
+	})
+}
+
+func TestParseOpenAPISpec_MissingFile(t *testing.T) {
+	_, err := ParseOpenAPISpec(filepath.Join(t.TempDir(), "missing.json"))
+	if err == nil {
+		t.Fatal("expected error for missing spec file")
+	}
+}
+
+func TestParseOpenAPISpec_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.json")
+	if err := os.WriteFile(path, []byte(`{"openapi":"3.0.0","paths":{}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := ParseOpenAPISpec(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.OpenAPI != "3.0.0" {
+		t.Errorf("got openapi %q, want 3.0.0", spec.OpenAPI)
+	}
+}
@@ -58,6 +58,16 @@ var FlattenWithoutPrefix = map[string]map[string]bool{
 	},
 }
 
+// RuntimeRequiredFields - command-scoped required fields that the command
+// fills in itself when the flag is omitted, so they must not be marked
+// required on the cobra command
+// e.g., "session_id" in AgentStart falls back to the current session
+var RuntimeRequiredFields = map[string]map[string]bool{
+	"AgentStart": {
+		"session_id": true,
+	},
+}
+
 // Field represents a field in an OpenAPI schema
 type Field struct {
 	Name        string
@@ -70,6 +80,8 @@ type Field struct {
 	Items       *Field
 	Ref         string
 	Default     interface{}
+	Deprecated  bool
+	RenamedFrom []string // Previous names, kept as hidden deprecated flags
 	Nullable    bool
 	IsUnionType bool // True if field is an anyOf with enum + string (not a simple enum)
 }
@@ -160,6 +172,7 @@ type FieldConfig struct {
 	VarName   string
 	FlagType  string
 	GoType    string
+	Aliases   []string       // Deprecated flag names for the same field
 	SubFields []*FieldConfig // For flattened objects
 }
 
@@ -276,6 +289,14 @@ func ShouldFlattenWithoutPrefix(commandName, fieldName string) bool {
 	return false
 }
 
+// IsRuntimeRequired checks if a required field is filled in by the command when its flag is omitted
+func IsRuntimeRequired(commandName, fieldName string) bool {
+	if cmdFields, ok := RuntimeRequiredFields[commandName]; ok {
+		return cmdFields[fieldName]
+	}
+	return false
+}
+
 // IsForceFlattenable checks if a field should be force-flattened (regardless of field count)
 func IsForceFlattenable(commandName, fieldName string, field *Field, schemas map[string]*Field) bool {
 	if !ShouldFlattenWithoutPrefix(commandName, fieldName) {
@@ -9,10 +9,17 @@ OUTPUT_DIR="$PROJECT_ROOT/internal/api"
 NOTTE_API_URL="${NOTTE_API_URL:-https://us-staging.notte.cc}"
 OPENAPI_URL="${NOTTE_API_URL}/openapi.json"
 
-echo "Fetching OpenAPI spec from ${NOTTE_API_URL}..."
-if ! curl -f -s "${OPENAPI_URL}" -o /tmp/notte-openapi.json; then
-  echo "Error: Failed to fetch OpenAPI spec from ${NOTTE_API_URL}" >&2
-  exit 1
+# NOTTE_OPENAPI_FILE skips the download and uses a spec already on disk
+# (set by `notte dev regen` once it has checked the spec's hash)
+if [[ -n "${NOTTE_OPENAPI_FILE:-}" ]]; then
+  echo "Using OpenAPI spec from ${NOTTE_OPENAPI_FILE}..."
+  cp "${NOTTE_OPENAPI_FILE}" /tmp/notte-openapi.json
+else
+  echo "Fetching OpenAPI spec from ${NOTTE_API_URL}..."
+  if ! curl -f -s "${OPENAPI_URL}" -o /tmp/notte-openapi.json; then
+    echo "Error: Failed to fetch OpenAPI spec from ${NOTTE_API_URL}" >&2
+    exit 1
+  fi
 fi
 
 # Read excluded endpoints and build regex pattern
@@ -88,6 +95,17 @@ jq --arg excluded "$EXCLUDED_PATHS" '
   ) | .openapi = "3.0.3"
 ' /tmp/notte-openapi.json > /tmp/notte-openapi-3.0.json
 
+# Bundle the converted spec for `notte api describe`
+jq -c . /tmp/notte-openapi-3.0.json > "$OUTPUT_DIR/openapi.json"
+
+# Pin the bundled spec's hash; a unit test fails if openapi.json is edited
+# without regenerating
+if command -v sha256sum >/dev/null; then
+  (cd "$OUTPUT_DIR" && sha256sum openapi.json > openapi.json.sha256)
+else
+  (cd "$OUTPUT_DIR" && shasum -a 256 openapi.json > openapi.json.sha256)
+fi
+
 echo "Generating Go client..."
 mkdir -p "$OUTPUT_DIR"
 
@@ -149,7 +167,7 @@ echo ""
 echo "Generating CLI flags..."
 CMD_OUTPUT_DIR="$PROJECT_ROOT/internal/cmd"
 
-if ! go run "$SCRIPT_DIR/gen-flags"/*.go \
+if ! go -C "$PROJECT_ROOT" run ./scripts/gen-flags \
   -spec /tmp/notte-openapi-3.0.json \
   -output "$CMD_OUTPUT_DIR"; then
   echo "ERROR: Flag generation failed. See errors above." >&2
@@ -165,6 +183,8 @@ echo "✓ Code generation complete!"
 echo "════════════════════════════════════════════════════════════════"
 echo ""
 echo "Generated files:"
+echo "  - $OUTPUT_DIR/openapi.json"
+echo "  - $OUTPUT_DIR/openapi.json.sha256"
 echo "  - $OUTPUT_DIR/client.gen.go"
 echo "  - $OUTPUT_DIR/property_names.gen.go"
 echo "  - $CMD_OUTPUT_DIR/*_flags.gen.go"
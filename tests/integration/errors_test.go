@@ -30,7 +30,7 @@ func TestErrorParsing_InvalidBrowserType(t *testing.T) {
 // TestErrorParsing_NonexistentSession tests that 404 errors show proper messages
 func TestErrorParsing_NonexistentSession(t *testing.T) {
 	// Try to get status of a non-existent session
-	result := runCLI(t, "sessions", "status", "--session-id", "nonexistent-session-id-xyz789")
+	result := runCLI(t, "sessions", "status", "--session-id", "00000000-0000-4000-8000-000000000000")
 	requireFailure(t, result)
 
 	// Verify we get a proper error (not "failed to read response body")
@@ -91,7 +91,7 @@ func TestErrorParsing_NonexistentProfile(t *testing.T) {
 // TestErrorParsing_NonexistentAgent tests that agent not found errors show proper messages
 func TestErrorParsing_NonexistentAgent(t *testing.T) {
 	// Try to get status of a non-existent agent
-	result := runCLI(t, "agents", "status", "--agent-id", "nonexistent-agent-id-jkl012")
+	result := runCLI(t, "agents", "status", "--agent-id", "00000000-0000-4000-8000-000000000000")
 	requireFailure(t, result)
 
 	// Verify we get a proper error (not "failed to read response body")
@@ -109,6 +109,13 @@ func containsString(output, substr string) bool {
 	return strings.Contains(output, substr)
 }
 
+// waitForSessionActive blocks until the session reports active
+func waitForSessionActive(t *testing.T, sessionID string) {
+	t.Helper()
+	result := runCLI(t, "sessions", "status", "--session-id", sessionID, "--wait-for", "active", "--wait-timeout", "30s")
+	requireSuccess(t, result)
+}
+
 // cleanupSession stops a session, ignoring errors (for deferred cleanup)
 func cleanupSession(t *testing.T, sessionID string) {
 	t.Helper()
@@ -25,8 +25,7 @@ func TestJSONOutputValidity(t *testing.T) {
 	sessionID := startResp.SessionID
 	defer cleanupSession(t, sessionID)
 
-	// Wait for session to be ready
-	time.Sleep(2 * time.Second)
+	waitForSessionActive(t, sessionID)
 
 	// Navigate to a page for commands that need page content
 	result = runCLIWithTimeout(t, 120*time.Second, "page", "goto", "https://example.com", "--session-id", sessionID)
@@ -74,15 +73,18 @@ func TestJSONOutputValidity(t *testing.T) {
 		{"clear", []string{"clear"}, ""},
 		{"sessions code", []string{"sessions", "code", "--session-id", sessionID}, ""},
 		{"sessions viewer", []string{"sessions", "viewer", "--session-id", sessionID}, ""},
+		{"open session", []string{"open", "session", "--print", "--session-id", sessionID}, ""},
 		{"version", []string{"version"}, ""},
+		{"api describe", []string{"api", "describe", "page_execute"}, ""},
 		{"page screenshot", []string{"page", "screenshot", "--session-id", sessionID}, ""},
 
 		// files download - will fail but error should still be valid JSON
 		{"files download", []string{"files", "download", "nonexistent.txt", "--session-id", sessionID}, ""},
 
-		// Known broken commands - skip these (pass through to external tools)
-		{"skill add", []string{"skill", "add"}, "passes through to npx, no JSON support"},
-		{"skill remove", []string{"skill", "remove"}, "passes through to npx, no JSON support"},
+		// Skills - the native installer reports its result as JSON
+		{"skill add", []string{"skill", "add"}, ""},
+		{"skill list", []string{"skill", "list"}, ""},
+		{"skill remove", []string{"skill", "remove"}, ""},
 	}
 
 	var brokenCommands []string
@@ -21,8 +21,7 @@ func startTestSession(t *testing.T) string {
 		t.Fatalf("Failed to parse session start response: %v", err)
 	}
 
-	// Wait for session to be ready
-	time.Sleep(2 * time.Second)
+	waitForSessionActive(t, startResp.SessionID)
 
 	return startResp.SessionID
 }
@@ -330,8 +329,7 @@ func TestPageUsesCurrentSession(t *testing.T) {
 	sessionID := startResp.SessionID
 	defer cleanupSession(t, sessionID)
 
-	// Wait for session to be ready
-	time.Sleep(2 * time.Second)
+	waitForSessionActive(t, sessionID)
 
 	// Run page command WITHOUT --session-id flag (should use current session)
 	result = runCLIWithTimeout(t, 120*time.Second, "page", "goto", "https://example.com")
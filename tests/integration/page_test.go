@@ -22,8 +22,7 @@ func TestPageObserve(t *testing.T) {
 	sessionID := startResp.SessionID
 	defer cleanupSession(t, sessionID)
 
-	// Wait for session to be ready
-	time.Sleep(2 * time.Second)
+	waitForSessionActive(t, sessionID)
 
 	// Navigate to the page first
 	result = runCLIWithTimeout(t, 120*time.Second, "page", "goto", "https://example.com", "--session-id", sessionID)
@@ -54,8 +53,7 @@ func TestPageExecuteAction(t *testing.T) {
 	sessionID := startResp.SessionID
 	defer cleanupSession(t, sessionID)
 
-	// Wait for session to be ready
-	time.Sleep(2 * time.Second)
+	waitForSessionActive(t, sessionID)
 
 	// First navigate to a page
 	result = runCLIWithTimeout(t, 120*time.Second, "page", "goto", "https://example.com", "--session-id", sessionID)
@@ -85,8 +83,7 @@ func TestPageScrapeBasic(t *testing.T) {
 	sessionID := startResp.SessionID
 	defer cleanupSession(t, sessionID)
 
-	// Wait for session to be ready
-	time.Sleep(2 * time.Second)
+	waitForSessionActive(t, sessionID)
 
 	// Navigate to a page
 	result = runCLIWithTimeout(t, 120*time.Second, "page", "goto", "https://example.com", "--session-id", sessionID)
@@ -120,8 +117,7 @@ func TestPageScrapeWithInstructions(t *testing.T) {
 	sessionID := startResp.SessionID
 	defer cleanupSession(t, sessionID)
 
-	// Wait for session to be ready
-	time.Sleep(2 * time.Second)
+	waitForSessionActive(t, sessionID)
 
 	// Navigate to a page
 	result = runCLIWithTimeout(t, 120*time.Second, "page", "goto", "https://example.com", "--session-id", sessionID)
@@ -150,8 +146,7 @@ func TestPageScrapeOnlyMainContent(t *testing.T) {
 	sessionID := startResp.SessionID
 	defer cleanupSession(t, sessionID)
 
-	// Wait for session to be ready
-	time.Sleep(2 * time.Second)
+	waitForSessionActive(t, sessionID)
 
 	// Navigate to a page
 	result = runCLIWithTimeout(t, 120*time.Second, "page", "goto", "https://example.com", "--session-id", sessionID)
@@ -180,8 +175,7 @@ func TestPageObserveExecuteScrapeFlow(t *testing.T) {
 	sessionID := startResp.SessionID
 	defer cleanupSession(t, sessionID)
 
-	// Wait for session to be ready
-	time.Sleep(2 * time.Second)
+	waitForSessionActive(t, sessionID)
 
 	// Step 1: Navigate to initial page
 	result = runCLIWithTimeout(t, 120*time.Second, "page", "goto", "https://example.com", "--session-id", sessionID)
@@ -111,8 +111,7 @@ func TestSessionsObserve(t *testing.T) {
 	sessionID := startResp.SessionID
 	defer cleanupSession(t, sessionID)
 
-	// Wait a moment for the session to be fully ready
-	time.Sleep(2 * time.Second)
+	waitForSessionActive(t, sessionID)
 
 	// Navigate to a URL
 	result = runCLIWithTimeout(t, 120*time.Second, "page", "goto", "https://example.com", "--session-id", sessionID)
@@ -138,8 +137,7 @@ func TestSessionsScrape(t *testing.T) {
 	sessionID := startResp.SessionID
 	defer cleanupSession(t, sessionID)
 
-	// Wait a moment for the session to be fully ready
-	time.Sleep(2 * time.Second)
+	waitForSessionActive(t, sessionID)
 
 	// First navigate to a page
 	result = runCLIWithTimeout(t, 120*time.Second, "page", "goto", "https://example.com", "--session-id", sessionID)
@@ -168,8 +166,7 @@ func TestSessionsNetwork(t *testing.T) {
 	sessionID := startResp.SessionID
 	defer cleanupSession(t, sessionID)
 
-	// Wait a moment for the session to be fully ready
-	time.Sleep(2 * time.Second)
+	waitForSessionActive(t, sessionID)
 
 	// Navigate to generate some network activity
 	result = runCLIWithTimeout(t, 120*time.Second, "page", "goto", "https://example.com", "--session-id", sessionID)
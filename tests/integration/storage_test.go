@@ -54,8 +54,7 @@ func TestStorageDownloadFromSession(t *testing.T) {
 	sessionID := startResp.SessionID
 	defer cleanupSession(t, sessionID)
 
-	// Wait for session to be ready
-	time.Sleep(2 * time.Second)
+	waitForSessionActive(t, sessionID)
 
 	// List downloads from session (likely empty)
 	result = runCLI(t, "files", "list", "--downloads", "--session-id", sessionID)